@@ -73,6 +73,7 @@ func main() {
 	// Init Redis services
 	sessionService := services.NewSessionService(redisClient)
 	cacheService := services.NewCacheService(redisClient)
+	calendarFeedService := services.NewCalendarFeedService(redisClient)
 
 	// Init clients
 	fileClient := http_clients.NewFileClient(common.GetEnvOrDefault("FILE_SERVICE_URL", "http://localhost:8080"))
@@ -128,6 +129,7 @@ func main() {
 	chatController := controllers.NewChatController(chatClient, fileClient, cacheService)
 	taskController := controllers.NewTaskController(taskClient, fileClient, cacheService)
 	rolePermissionController := controllers.NewRolePermissionController(rolePermissionClient, cacheService)
+	calendarController := controllers.NewCalendarController(taskClient, userClient, calendarFeedService, common.GetEnvOrDefault("CALENDAR_FEED_BASE_URL", "http://localhost:8084"))
 
 	//Init handlers with session service
 	authHandler := handlers.NewAuthHandler(authController)
@@ -135,6 +137,7 @@ func main() {
 	chatHandler := handlers.NewChatHandler(chatController)
 	taskHandler := handlers.NewTaskHandler(taskController)
 	rolePermissionHandler := handlers.NewRolePermissionHandler(rolePermissionController)
+	calendarHandler := handlers.NewCalendarHandler(calendarController)
 
 	r := gin.Default()
 
@@ -161,6 +164,7 @@ func main() {
 	routes.RegisterChatRoutes(r, chatHandler, publicKeyManager, sessionService, redisClient, rateLimitConfig)
 	routes.RegisterTaskRoutes(r, taskHandler, publicKeyManager, sessionService, redisClient, rateLimitConfig)
 	routes.RegisterRolePermissionRoutes(r, rolePermissionHandler, publicKeyManager, sessionService, redisClient, rateLimitConfig)
+	routes.RegisterCalendarRoutes(r, calendarHandler, publicKeyManager, sessionService, redisClient, rateLimitConfig)
 
 	// Graceful shutdown
 	go func() {
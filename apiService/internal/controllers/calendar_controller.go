@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"apiService/internal/custom_errors"
+	"apiService/internal/dto"
+	"apiService/internal/http_clients"
+	"apiService/internal/services"
+	at "common/contracts/api-task"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// calendarFeedPageSize - размер страницы при выборке задач для ленты
+	calendarFeedPageSize = 100
+	// calendarFeedMaxTasks ограничивает ленту, чтобы один запрос календаря не выкачивал всю историю
+	calendarFeedMaxTasks = 500
+	// calendarFeedPastWindow - насколько далеко в прошлое попадают сроки задач
+	calendarFeedPastWindow = 90 * 24 * time.Hour
+	// calendarFeedPermission - право, без которого владелец не может пользоваться лентой
+	calendarFeedPermission = "process_tasks"
+)
+
+type CalendarController struct {
+	taskClient          http_clients.TaskClient
+	userClient          http_clients.UserClient
+	calendarFeedService *services.CalendarFeedService
+	feedBaseURL         string
+}
+
+func NewCalendarController(taskClient http_clients.TaskClient, userClient http_clients.UserClient, calendarFeedService *services.CalendarFeedService, feedBaseURL string) *CalendarController {
+	return &CalendarController{
+		taskClient:          taskClient,
+		userClient:          userClient,
+		calendarFeedService: calendarFeedService,
+		feedBaseURL:         strings.TrimSuffix(feedBaseURL, "/"),
+	}
+}
+
+// IssueFeedToken выпускает новую ссылку на ленту; старая ссылка перестаёт работать
+func (ctrl *CalendarController) IssueFeedToken(userID uuid.UUID) (*dto.CalendarFeedResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	token, err := ctrl.calendarFeedService.IssueToken(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CalendarFeedResponse{
+		Token: token,
+		URL:   ctrl.feedBaseURL + "/api/v1/calendar/" + token + ".ics",
+	}, nil
+}
+
+func (ctrl *CalendarController) RevokeFeedToken(userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return ctrl.calendarFeedService.RevokeToken(ctx, userID)
+}
+
+// GetFeed собирает ленту задач владельца токена со сроком не старше calendarFeedPastWindow.
+// Лента не кешируется: календарь сам опрашивает её редко, а изменения задач должны попадать в неё сразу.
+// Задачи читаются от имени владельца без прав администратора, поэтому в ленту попадают только видимые ему.
+// Если владелец потерял право process_tasks, токен отзывается и лента считается не найденной
+func (ctrl *CalendarController) GetFeed(token string, kind services.CalendarEntryKind) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	userID, err := ctrl.calendarFeedService.GetUserID(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctrl.checkFeedOwner(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	dueFrom := time.Now().Add(-calendarFeedPastWindow).UTC().Format(time.RFC3339)
+	filter := &dto.TaskListFilterGateway{
+		DueFrom: &dueFrom,
+		SortBy:  "due_at",
+		Order:   "asc",
+	}
+
+	tasks := make([]at.TaskToList, 0, calendarFeedPageSize)
+	for offset := 0; offset < calendarFeedMaxTasks; offset += calendarFeedPageSize {
+		page, err := ctrl.taskClient.GetUserTasks(userID.String(), userID, nil, filter, calendarFeedPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+		tasks = append(tasks, *page...)
+		if len(*page) < calendarFeedPageSize {
+			break
+		}
+	}
+
+	return services.RenderTaskCalendar(tasks, kind), nil
+}
+
+// checkFeedOwner проверяет право владельца на момент запроса: в токене ленты права не хранятся,
+// а JWT с правами у календаря нет
+func (ctrl *CalendarController) checkFeedOwner(ctx context.Context, userID uuid.UUID) error {
+	user, err := ctrl.userClient.GetUserByID(userID.String())
+	if err != nil {
+		return err
+	}
+	if user.User != nil {
+		for _, permission := range user.User.Role.Permissions {
+			if permission.Name == calendarFeedPermission {
+				return nil
+			}
+		}
+	}
+
+	if err := ctrl.calendarFeedService.RevokeToken(ctx, userID); err != nil {
+		return err
+	}
+	return custom_errors.ErrCalendarFeedTokenNotFound
+}
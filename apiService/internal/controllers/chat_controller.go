@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"apiService/internal/custom_errors"
 	"apiService/internal/dto"
 	"apiService/internal/http_clients"
 	"apiService/internal/services"
@@ -100,23 +101,61 @@ func (ctrl *ChatController) SendMessage(chatID uuid.UUID, senderID uuid.UUID, re
 
 	var fileIDs []int
 
-	if len(req.Files) > 0 {
+	// Загрузку вложений для clientID резервирует первая отправка; повторы используют уже загруженные вложения
+	uploaded := false
+	reserved := false
+	if req.ClientID != nil {
+		var err error
+		reserved, err = ctrl.cacheService.ReserveMessageUpload(ctx, chatID.String(), senderID.String(), *req.ClientID)
+		if err != nil {
+			log.Printf("Failed to reserve attachments upload for message %s: %v", *req.ClientID, err)
+		} else if !reserved {
+			err := ctrl.cacheService.GetMessageUploadCache(ctx, chatID.String(), senderID.String(), *req.ClientID, &fileIDs)
+			if errors.Is(err, custom_errors.ErrMessageUploadInProgress) {
+				return nil, err
+			}
+			if err == nil {
+				log.Printf("Attachments for message %s already uploaded, skipping upload", *req.ClientID)
+				uploaded = true
+			}
+		}
+	}
+
+	if !uploaded && len(req.Files) > 0 {
 		fileIDs = make([]int, 0, len(req.Files))
 		for _, file := range req.Files {
 			uploadedFile, err := ctrl.fileClient.UploadFile(file)
+			if err == nil && uploadedFile.ID == nil {
+				err = fmt.Errorf("file service returned no file ID")
+			}
 			if err != nil {
+				// С clientID клиент повторит отправку: сообщение без части вложений не отправляется и не кешируется,
+				// а резерв снимается, чтобы повтор загрузил вложения заново
+				if req.ClientID != nil {
+					if reserved {
+						if errRelease := ctrl.cacheService.ReleaseMessageUpload(ctx, chatID.String(), senderID.String(), *req.ClientID); errRelease != nil {
+							log.Printf("Failed to release attachments upload for message %s: %v", *req.ClientID, errRelease)
+						}
+					}
+					return nil, fmt.Errorf("failed to upload file %s: %w", file.Filename, err)
+				}
 				log.Printf("failed to upload file %s: %v\n", file.Filename, err)
 				continue
 			}
-			if uploadedFile.ID != nil {
-				fileIDs = append(fileIDs, *uploadedFile.ID)
-			}
+			fileIDs = append(fileIDs, *uploadedFile.ID)
+		}
+	}
+
+	if !uploaded && req.ClientID != nil {
+		if err := ctrl.cacheService.SetMessageUploadCache(ctx, chatID.String(), senderID.String(), *req.ClientID, fileIDs); err != nil {
+			log.Printf("Failed to cache uploaded attachments for message %s: %v", *req.ClientID, err)
 		}
 	}
 
 	createReq := &ac.CreateMessageRequest{
-		Content: req.Content,
-		FileIDs: fileIDs,
+		Content:  req.Content,
+		FileIDs:  fileIDs,
+		ClientID: req.ClientID,
 	}
 
 	message, err := ctrl.chatClient.SendMessage(chatID, senderID, createReq)
@@ -334,7 +373,33 @@ func (ctrl *ChatController) GetMyRoleInChat(chatID, userID uuid.UUID) (*ac.MyRol
 	return role, nil
 }
 
-// GetChatMembers - получение списка участников чата
-func (ctrl *ChatController) GetChatMembers(chatID uuid.UUID) ([]*ac.ChatMember, error) {
-	return ctrl.chatClient.GetChatMembers(chatID)
+// GetChatMembers - получение страницы участников чата с профилями
+func (ctrl *ChatController) GetChatMembers(chatID uuid.UUID, query *ac.ChatMembersQuery) (*ac.ChatMembersResponse, error) {
+	return ctrl.chatClient.GetChatMembers(chatID, query)
+}
+
+// SaveMessage - добавление сообщения в закладки пользователя
+func (ctrl *ChatController) SaveMessage(userID, messageID uuid.UUID, req *dto.SaveMessageRequestGateway) (*ac.SavedMessage, error) {
+	return ctrl.chatClient.SaveMessage(userID, messageID, &ac.SaveMessageRequest{Note: req.Note})
+}
+
+// GetSavedMessages - получение закладок пользователя.
+// Не кешируется: доступ к чату может быть потерян в любой момент (удаление, бан), и содержимое должно скрываться сразу
+func (ctrl *ChatController) GetSavedMessages(userID uuid.UUID, offset, limit int) (*ac.GetSavedMessagesResponse, error) {
+	return ctrl.chatClient.GetSavedMessages(userID, offset, limit)
+}
+
+// DeleteSavedMessage - удаление сообщения из закладок пользователя
+func (ctrl *ChatController) DeleteSavedMessage(userID, messageID uuid.UUID) error {
+	return ctrl.chatClient.DeleteSavedMessage(userID, messageID)
+}
+
+// GetTaskEventSettings - получение настроек событий задач чата (не кешируется: читается редко)
+func (ctrl *ChatController) GetTaskEventSettings(chatID, userID uuid.UUID) (*ac.TaskEventSettings, error) {
+	return ctrl.chatClient.GetTaskEventSettings(chatID, userID)
+}
+
+// UpdateTaskEventSettings - изменение настроек событий задач чата
+func (ctrl *ChatController) UpdateTaskEventSettings(chatID, userID uuid.UUID, req *ac.UpdateTaskEventSettingsRequest) (*ac.TaskEventSettings, error) {
+	return ctrl.chatClient.UpdateTaskEventSettings(chatID, userID, req)
 }
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"apiService/internal/dto"
+	"apiService/internal/services"
 	ac "common/contracts/api-chat"
 	at "common/contracts/api-task"
 	au "common/contracts/api-user"
@@ -23,7 +24,12 @@ type ChatControllerInterface interface {
 	BanUser(chatID, userID, ownerID uuid.UUID) error
 	ChangeUserRole(chatID, ownerID uuid.UUID, changeRoleReq *ac.ChangeRoleRequest) error
 	GetMyRoleInChat(chatID, userID uuid.UUID) (*ac.MyRoleResponse, error)
-	GetChatMembers(chatID uuid.UUID) ([]*ac.ChatMember, error)
+	GetChatMembers(chatID uuid.UUID, query *ac.ChatMembersQuery) (*ac.ChatMembersResponse, error)
+	SaveMessage(userID, messageID uuid.UUID, req *dto.SaveMessageRequestGateway) (*ac.SavedMessage, error)
+	GetSavedMessages(userID uuid.UUID, offset, limit int) (*ac.GetSavedMessagesResponse, error)
+	DeleteSavedMessage(userID, messageID uuid.UUID) error
+	GetTaskEventSettings(chatID, userID uuid.UUID) (*ac.TaskEventSettings, error)
+	UpdateTaskEventSettings(chatID, userID uuid.UUID, req *ac.UpdateTaskEventSettingsRequest) (*ac.TaskEventSettings, error)
 }
 
 // UserControllerInterface - интерфейс для UserController
@@ -50,14 +56,73 @@ type AuthControllerInterface interface {
 
 // TaskControllerInterface - интерфейс для TaskController
 type TaskControllerInterface interface {
-	CreateTask(req *dto.CreateTaskRequestGateway, creatorID uuid.UUID) (*at.TaskResponse, error)
-	UpdateTaskStatus(taskID, statusID int) error
-	GetTaskByID(taskID int) (*at.TaskServiceResponse, error)
-	GetUserTasks(userID string, limit, offset int) (*[]at.TaskToList, error)
+	CreateTask(req *dto.CreateTaskRequestGateway, creatorID uuid.UUID, permissions []string) (*at.TaskResponse, error)
+	UpdateTaskStatus(taskID, statusID int, actorID uuid.UUID, permissions []string) error
+	GetTaskByID(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskServiceResponse, error)
+	GetUserTasks(userID string, filter *dto.TaskListFilterGateway, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskToList, error)
+	SearchTasks(query *dto.TaskQueryGateway, actorID uuid.UUID, permissions []string) (*at.TaskQueryResult, error)
+	GetTaskBoard(query *dto.TaskBoardQueryGateway, actorID uuid.UUID, permissions []string) (*at.TaskBoard, error)
+	MoveTask(taskID int, req *at.MoveTaskRequest, actorID uuid.UUID, permissions []string) error
+	UpdateTask(taskID int, req *dto.UpdateTaskRequestGateway, actorID uuid.UUID, permissions []string) (*at.TaskResponse, error)
+	DeleteTask(taskID int, actorID uuid.UUID, permissions []string) error
+	BulkUpdateTasks(actorID uuid.UUID, permissions []string, req *at.TaskBulkRequest) (*at.TaskBulkResult, error)
+	GetCreatedTasks(userID string, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskToList, error)
+	GetChatTasks(chatID string, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskToList, error)
+	GetTaskHistory(taskID, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskActivity, error)
+	GetUserActivity(userID string, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskActivity, error)
+	CreateTaskComment(taskID int, req *dto.CreateTaskCommentRequestGateway, actorID uuid.UUID) (*at.TaskComment, error)
+	UpdateTaskComment(taskID, commentID int, req *dto.UpdateTaskCommentRequestGateway, actorID uuid.UUID) (*at.TaskComment, error)
+	DeleteTaskComment(taskID, commentID int, actorID uuid.UUID, permissions []string) error
+	GetTaskComments(taskID, limit, offset int, actorID uuid.UUID, permissions []string) ([]at.TaskComment, error)
+	GetTaskTree(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskTreeNode, error)
+	GetTaskDependencies(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskDependencyGraph, error)
+	AddTaskDependency(taskID, blockerTaskID int, actorID uuid.UUID, permissions []string) error
+	RemoveTaskDependency(taskID, blockerTaskID int, actorID uuid.UUID, permissions []string) error
+	GetTaskLabels(taskID int, actorID uuid.UUID, permissions []string) ([]at.TaskLabel, error)
+	AddTaskLabel(taskID, labelID int, actorID uuid.UUID, permissions []string) error
+	RemoveTaskLabel(taskID, labelID int, actorID uuid.UUID, permissions []string) error
+	AddTaskAssignee(taskID int, userID, actorID uuid.UUID, permissions []string) error
+	RemoveTaskAssignee(taskID int, userID, actorID uuid.UUID, permissions []string) error
+	AddTaskWatcher(taskID int, userID, actorID uuid.UUID, permissions []string) error
+	RemoveTaskWatcher(taskID int, userID, actorID uuid.UUID, permissions []string) error
+	StartTaskTimer(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error)
+	StopTaskTimer(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error)
+	CreateTaskTimeLog(taskID int, req *at.CreateTaskTimeLogRequest, actorID uuid.UUID) (*at.TaskTimeLog, error)
+	UpdateTaskTimeLog(taskID, logID int, req *at.UpdateTaskTimeLogRequest, actorID uuid.UUID, permissions []string) (*at.TaskTimeLog, error)
+	DeleteTaskTimeLog(taskID, logID int, actorID uuid.UUID, permissions []string) error
+	GetTaskTimeLogs(taskID, limit, offset int, actorID uuid.UUID, permissions []string) ([]at.TaskTimeLog, error)
+	GetTimeSpent(query *dto.TimeSpentQueryGateway, actorID uuid.UUID, permissions []string) ([]at.TimeSpent, error)
+	GetAllLabels() ([]at.Label, error)
+	GetLabelByID(labelID int) (*at.Label, error)
+	CreateLabel(req *at.SaveLabelRequest) (*at.Label, error)
+	UpdateLabel(labelID int, req *at.SaveLabelRequest) (*at.Label, error)
+	DeleteLabel(labelID int) error
+	CreateTaskFromMessage(req *at.CreateTaskFromMessageRequest, actorID uuid.UUID) (*at.TaskResponse, error)
+	CreateTaskRecurrence(req *at.SaveTaskRecurrenceRequest, actorID uuid.UUID) (*at.TaskRecurrence, error)
+	GetTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string) (*at.TaskRecurrence, error)
+	UpdateTaskRecurrence(recurrenceID int, req *at.SaveTaskRecurrenceRequest, actorID uuid.UUID, permissions []string) (*at.TaskRecurrence, error)
+	DeleteTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string) error
+	GetTaskReport(report string, query *dto.TaskReportQueryGateway, actorID uuid.UUID, permissions []string) (*dto.TaskReport, error)
+	GetAllTaskTemplates(actorID uuid.UUID, permissions []string) ([]at.TaskTemplate, error)
+	GetTaskTemplate(templateID int, actorID uuid.UUID, permissions []string) (*at.TaskTemplate, error)
+	CreateTaskTemplate(req *at.SaveTaskTemplateRequest, actorID uuid.UUID) (*at.TaskTemplate, error)
+	UpdateTaskTemplate(templateID int, req *at.SaveTaskTemplateRequest, actorID uuid.UUID, permissions []string) (*at.TaskTemplate, error)
+	DeleteTaskTemplate(templateID int, actorID uuid.UUID, permissions []string) error
+	CreateTaskFromTemplate(templateID int, req *at.CreateTaskFromTemplateRequest, actorID uuid.UUID, permissions []string) (*at.TaskResponse, error)
+	GetTaskChecklist(taskID int, actorID uuid.UUID, permissions []string) ([]at.TaskChecklistItem, error)
+	AddChecklistItem(taskID int, req *at.AddChecklistItemRequest, actorID uuid.UUID, permissions []string) (*at.TaskChecklistItem, error)
+	UpdateChecklistItem(taskID, itemID int, req *at.UpdateChecklistItemRequest, actorID uuid.UUID, permissions []string) (*at.TaskChecklistItem, error)
+	RemoveChecklistItem(taskID, itemID int, actorID uuid.UUID, permissions []string) error
 	GetAllStatuses() ([]at.TaskStatus, error)
 	CreateStatus(statusName string) (*at.TaskStatus, error)
 	GetStatusByID(statusID int) (*at.TaskStatus, error)
-	DeleteStatus(statusID int) error
+	GetStatusUsage(statusID int) (*at.TaskStatusUsage, error)
+	DeleteStatus(statusID int, replacementID *int, actorID uuid.UUID, permissions []string) error
+	GetAllWorkflows() ([]at.TaskWorkflow, error)
+	GetWorkflowByID(workflowID int) (*at.TaskWorkflow, error)
+	CreateWorkflow(req *at.SaveWorkflowRequest) (*at.TaskWorkflow, error)
+	UpdateWorkflow(workflowID int, req *at.SaveWorkflowRequest) (*at.TaskWorkflow, error)
+	DeleteWorkflow(workflowID int) error
 }
 
 // ChatRolePermissionControllerInterface - интерфейс для ChatRolePermissionController
@@ -71,3 +136,10 @@ type ChatRolePermissionControllerInterface interface {
 	CreatePermission(req *dto.CreateChatPermissionRequestGateway) (*dto.ChatPermissionResponseGateway, error)
 	DeletePermission(permissionID int) error
 }
+
+// CalendarControllerInterface - интерфейс для CalendarController
+type CalendarControllerInterface interface {
+	IssueFeedToken(userID uuid.UUID) (*dto.CalendarFeedResponse, error)
+	RevokeFeedToken(userID uuid.UUID) error
+	GetFeed(token string, kind services.CalendarEntryKind) ([]byte, error)
+}
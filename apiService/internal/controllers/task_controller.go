@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"apiService/internal/custom_errors"
 	"apiService/internal/dto"
 	"apiService/internal/http_clients"
 	"apiService/internal/services"
@@ -9,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"mime/multipart"
 	"time"
 
 	"github.com/google/uuid"
@@ -28,7 +30,7 @@ func NewTaskController(taskClient http_clients.TaskClient, fileClient http_clien
 	}
 }
 
-func (ctrl *TaskController) CreateTask(req *dto.CreateTaskRequestGateway, creatorID uuid.UUID) (*at.TaskResponse, error) {
+func (ctrl *TaskController) CreateTask(req *dto.CreateTaskRequestGateway, creatorID uuid.UUID, permissions []string) (*at.TaskResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
@@ -37,6 +39,10 @@ func (ctrl *TaskController) CreateTask(req *dto.CreateTaskRequestGateway, creato
 	if err != nil {
 		return nil, err
 	}
+	startAt, dueAt, err := req.ParseSchedule()
+	if err != nil {
+		return nil, err
+	}
 
 	// Загружаем файлы, если есть
 	var fileIDs []int
@@ -56,11 +62,16 @@ func (ctrl *TaskController) CreateTask(req *dto.CreateTaskRequestGateway, creato
 
 	// Создаем запрос к Task Service
 	createReq := &at.CreateTaskRequest{
-		Title:       req.Title,
-		Description: req.Description,
-		CreatorID:   creatorID,
-		ExecutorID:  *executorID,
-		FileIDs:     fileIDs,
+		Title:           req.Title,
+		Description:     req.Description,
+		ExecutorID:      *executorID,
+		FileIDs:         fileIDs,
+		WorkflowID:      req.WorkflowID,
+		ParentTaskID:    req.ParentTaskID,
+		Priority:        req.Priority,
+		StartAt:         startAt,
+		DueAt:           dueAt,
+		EstimateMinutes: req.EstimateMinutes,
 	}
 
 	// Устанавливаем ChatID (используем uuid.Nil если не указан)
@@ -70,8 +81,13 @@ func (ctrl *TaskController) CreateTask(req *dto.CreateTaskRequestGateway, creato
 		createReq.ChatID = uuid.Nil
 	}
 
-	taskResp, err := ctrl.taskClient.CreateTask(createReq)
+	taskResp, err := ctrl.taskClient.CreateTask(creatorID, permissions, createReq)
 	if err != nil {
+		// Ошибки валидации taskService (например, дата начала позже срока) отдаются клиенту как есть
+		var taskErr *custom_errors.TaskServiceError
+		if errors.As(err, &taskErr) {
+			return nil, err
+		}
 		return nil, errors.New("error of task client")
 	}
 
@@ -83,88 +99,661 @@ func (ctrl *TaskController) CreateTask(req *dto.CreateTaskRequestGateway, creato
 		_ = ctrl.cacheService.DeleteUserTasksCache(ctx, executorID.String())
 	}
 
+	// Инвалидация кеша задач чата (если указан)
+	if chatID != nil && *chatID != uuid.Nil {
+		_ = ctrl.cacheService.DeleteChatTasksCache(ctx, chatID.String())
+	}
+	_ = ctrl.cacheService.DeleteTaskQueryCache(ctx)
+
 	return taskResp, nil
 }
 
-func (ctrl *TaskController) UpdateTaskStatus(taskID, statusID int) error {
-	err := ctrl.taskClient.UpdateTaskStatus(taskID, statusID)
+// CreateTaskFromMessage - создание задачи из сообщения чата. Кроме списков задач сбрасывается кеш
+// сообщений чата: taskService публикует в чат системное сообщение со ссылкой на задачу
+func (ctrl *TaskController) CreateTaskFromMessage(req *at.CreateTaskFromMessageRequest, actorID uuid.UUID) (*at.TaskResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	task, err := ctrl.taskClient.CreateTaskFromMessage(actorID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrl.invalidateTaskListsCache(ctx, task)
+	_ = ctrl.cacheService.DeleteTaskQueryCache(ctx)
+	if task.ChatID != nil && *task.ChatID != uuid.Nil {
+		_ = ctrl.cacheService.DeleteChatMessagesCache(ctx, task.ChatID.String())
+		_ = ctrl.cacheService.DeleteSearchCacheByChat(ctx, task.ChatID.String())
+	}
+
+	return task, nil
+}
+
+// UpdateTaskStatus - смена статуса задачи; допустимость перехода проверяет taskService
+func (ctrl *TaskController) UpdateTaskStatus(taskID, statusID int, actorID uuid.UUID, permissions []string) error {
+	err := ctrl.taskClient.UpdateTaskStatus(taskID, statusID, actorID, permissions)
 	if err != nil {
 		return err
 	}
 
-	// Инвалидация кеша задачи
-	ctx := context.Background()
-	_ = ctrl.cacheService.DeleteTaskCache(ctx, taskID)
+	// Инвалидация кеша задачи, списков с её статусом и результатов поиска, отфильтрованных по статусу
+	ctrl.invalidateChangedTaskCache(taskID, actorID, permissions)
 
 	return nil
 }
 
-func (ctrl *TaskController) GetTaskByID(taskID int) (*at.TaskServiceResponse, error) {
+// GetTaskByID - задача, если она видна пользователю; кешируется отдельно для каждого пользователя
+func (ctrl *TaskController) GetTaskByID(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskServiceResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	// Пытаемся получить из кеша
 	var cachedTask at.TaskServiceResponse
-	err := ctrl.cacheService.GetTaskCache(ctx, taskID, &cachedTask)
+	err := ctrl.cacheService.GetTaskCache(ctx, taskID, actorID.String(), &cachedTask)
 	if err == nil {
 		log.Printf("Task %d found in cache", taskID)
 		return &cachedTask, nil
 	}
 
 	// Получаем из сервиса
-	task, err := ctrl.taskClient.GetTaskByID(taskID)
+	task, err := ctrl.taskClient.GetTaskByID(taskID, actorID, permissions)
 	if err != nil {
 		return nil, err
 	}
 
 	// Сохраняем в кеш
-	if err := ctrl.cacheService.SetTaskCache(ctx, taskID, task); err != nil {
+	if err := ctrl.cacheService.SetTaskCache(ctx, taskID, actorID.String(), task); err != nil {
 		log.Printf("Failed to cache task %d: %v", taskID, err)
 	}
 
 	return task, nil
 }
 
-func (ctrl *TaskController) GetUserTasks(userID string, limit, offset int) (*[]at.TaskToList, error) {
+// GetUserTasks - задачи исполнителя, видимые пользователю; кешируется только первая страница без фильтров
+func (ctrl *TaskController) GetUserTasks(userID string, filter *dto.TaskListFilterGateway, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskToList, error) {
+	if !filter.IsEmpty() {
+		return ctrl.taskClient.GetUserTasks(userID, actorID, permissions, filter, limit, offset)
+	}
+	return ctrl.getCachedTaskList(ctrl.cacheService.UserTasksCacheKey(userID, actorID.String()), limit, offset, func(limit, offset int) (*[]at.TaskToList, error) {
+		return ctrl.taskClient.GetUserTasks(userID, actorID, permissions, nil, limit, offset)
+	})
+}
+
+// SearchTasks - поиск среди видимых пользователю задач. Результат кешируется по пользователю и полному
+// набору параметров, включая курсор, и сбрасывается при любом изменении задач
+func (ctrl *TaskController) SearchTasks(query *dto.TaskQueryGateway, actorID uuid.UUID, permissions []string) (*at.TaskQueryResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Кешируем только первую страницу (offset = 0)
-	if offset == 0 && limit <= 20 {
-		var cachedTasks []at.TaskToList
-		err := ctrl.cacheService.GetUserTasksCache(ctx, userID, &cachedTasks)
-		if err == nil {
-			log.Printf("User tasks for %s found in cache", userID)
-			if limit < len(cachedTasks) {
-				result := cachedTasks[:limit]
-				return &result, nil
-			}
-			return &cachedTasks, nil
+	values := query.Query()
+	var cached at.TaskQueryResult
+	if err := ctrl.cacheService.GetTaskQueryCache(ctx, values, actorID.String(), &cached); err == nil {
+		return &cached, nil
+	}
+
+	result, err := ctrl.taskClient.QueryTasks(actorID, permissions, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctrl.cacheService.SetTaskQueryCache(ctx, values, actorID.String(), result); err != nil {
+		log.Printf("Failed to cache task query: %v", err)
+	}
+	return result, nil
+}
+
+// GetTaskBoard - доска задач; не кешируется, так как порядок карточек меняется при каждом переносе
+func (ctrl *TaskController) GetTaskBoard(query *dto.TaskBoardQueryGateway, actorID uuid.UUID, permissions []string) (*at.TaskBoard, error) {
+	return ctrl.taskClient.GetTaskBoard(actorID, permissions, query)
+}
+
+// MoveTask - перенос карточки на доске; может сменить статус, поэтому сбрасывает кеш задачи, её списков и поиска
+func (ctrl *TaskController) MoveTask(taskID int, req *at.MoveTaskRequest, actorID uuid.UUID, permissions []string) error {
+	if err := ctrl.taskClient.MoveTask(taskID, actorID, permissions, req); err != nil {
+		return err
+	}
+
+	ctrl.invalidateChangedTaskCache(taskID, actorID, permissions)
+	return nil
+}
+
+// GetCreatedTasks - задачи, созданные пользователем, с кешированием первой страницы
+func (ctrl *TaskController) GetCreatedTasks(userID string, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskToList, error) {
+	return ctrl.getCachedTaskList(ctrl.cacheService.UserCreatedTasksCacheKey(userID, actorID.String()), limit, offset, func(limit, offset int) (*[]at.TaskToList, error) {
+		return ctrl.taskClient.GetCreatedTasks(userID, actorID, permissions, limit, offset)
+	})
+}
+
+// GetChatTasks - видимые пользователю задачи чата с кешированием первой страницы
+func (ctrl *TaskController) GetChatTasks(chatID string, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskToList, error) {
+	return ctrl.getCachedTaskList(ctrl.cacheService.ChatTasksCacheKey(chatID, actorID.String()), limit, offset, func(limit, offset int) (*[]at.TaskToList, error) {
+		return ctrl.taskClient.GetChatTasks(chatID, actorID, permissions, limit, offset)
+	})
+}
+
+// GetTaskHistory - история задачи; не кешируется, так как меняется при каждом действии с задачей
+func (ctrl *TaskController) GetTaskHistory(taskID, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskActivity, error) {
+	return ctrl.taskClient.GetTaskHistory(taskID, actorID, permissions, limit, offset)
+}
+
+// GetUserActivity - лента активности пользователя
+func (ctrl *TaskController) GetUserActivity(userID string, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskActivity, error) {
+	return ctrl.taskClient.GetUserActivity(userID, actorID, permissions, limit, offset)
+}
+
+// CreateTaskComment - комментарий к задаче с загрузкой вложений; комментарии не кешируются
+func (ctrl *TaskController) CreateTaskComment(taskID int, req *dto.CreateTaskCommentRequestGateway, actorID uuid.UUID) (*at.TaskComment, error) {
+	return ctrl.taskClient.CreateTaskComment(taskID, actorID, &at.CreateTaskCommentRequest{
+		Body:    req.Body,
+		FileIDs: ctrl.uploadFiles(req.Files),
+	})
+}
+
+// UpdateTaskComment - правка комментария с загрузкой новых вложений
+func (ctrl *TaskController) UpdateTaskComment(taskID, commentID int, req *dto.UpdateTaskCommentRequestGateway, actorID uuid.UUID) (*at.TaskComment, error) {
+	return ctrl.taskClient.UpdateTaskComment(taskID, commentID, actorID, &at.UpdateTaskCommentRequest{
+		Body:          req.Body,
+		AddFileIDs:    ctrl.uploadFiles(req.Files),
+		RemoveFileIDs: req.RemoveFileIDs,
+	})
+}
+
+func (ctrl *TaskController) DeleteTaskComment(taskID, commentID int, actorID uuid.UUID, permissions []string) error {
+	return ctrl.taskClient.DeleteTaskComment(taskID, commentID, actorID, permissions)
+}
+
+func (ctrl *TaskController) GetTaskComments(taskID, limit, offset int, actorID uuid.UUID, permissions []string) ([]at.TaskComment, error) {
+	return ctrl.taskClient.GetTaskComments(taskID, actorID, permissions, limit, offset)
+}
+
+// GetTaskTree - дерево подзадач; не кешируется, так как прогресс меняется вместе со статусами подзадач
+func (ctrl *TaskController) GetTaskTree(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskTreeNode, error) {
+	return ctrl.taskClient.GetTaskTree(taskID, actorID, permissions)
+}
+
+func (ctrl *TaskController) GetTaskDependencies(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskDependencyGraph, error) {
+	return ctrl.taskClient.GetTaskDependencies(taskID, actorID, permissions)
+}
+
+func (ctrl *TaskController) AddTaskDependency(taskID, blockerTaskID int, actorID uuid.UUID, permissions []string) error {
+	return ctrl.taskClient.AddTaskDependency(taskID, blockerTaskID, actorID, permissions)
+}
+
+func (ctrl *TaskController) RemoveTaskDependency(taskID, blockerTaskID int, actorID uuid.UUID, permissions []string) error {
+	return ctrl.taskClient.RemoveTaskDependency(taskID, blockerTaskID, actorID, permissions)
+}
+
+func (ctrl *TaskController) GetTaskLabels(taskID int, actorID uuid.UUID, permissions []string) ([]at.TaskLabel, error) {
+	return ctrl.taskClient.GetTaskLabels(taskID, actorID, permissions)
+}
+
+// AddTaskLabel - назначить метку задаче; сбрасывает кеш поиска, так как меняется выборка по меткам
+func (ctrl *TaskController) AddTaskLabel(taskID, labelID int, actorID uuid.UUID, permissions []string) error {
+	if err := ctrl.taskClient.AddTaskLabel(taskID, labelID, actorID, permissions); err != nil {
+		return err
+	}
+	_ = ctrl.cacheService.DeleteTaskQueryCache(context.Background())
+	return nil
+}
+
+// RemoveTaskLabel - снять метку с задачи с инвалидацией кеша поиска
+func (ctrl *TaskController) RemoveTaskLabel(taskID, labelID int, actorID uuid.UUID, permissions []string) error {
+	if err := ctrl.taskClient.RemoveTaskLabel(taskID, labelID, actorID, permissions); err != nil {
+		return err
+	}
+	_ = ctrl.cacheService.DeleteTaskQueryCache(context.Background())
+	return nil
+}
+
+// StartTaskTimer - запуск таймера затраченного времени; записи времени не кешируются
+func (ctrl *TaskController) StartTaskTimer(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error) {
+	return ctrl.taskClient.StartTaskTimer(taskID, actorID)
+}
+
+func (ctrl *TaskController) StopTaskTimer(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error) {
+	return ctrl.taskClient.StopTaskTimer(taskID, actorID)
+}
+
+func (ctrl *TaskController) CreateTaskTimeLog(taskID int, req *at.CreateTaskTimeLogRequest, actorID uuid.UUID) (*at.TaskTimeLog, error) {
+	return ctrl.taskClient.CreateTaskTimeLog(taskID, actorID, req)
+}
+
+func (ctrl *TaskController) UpdateTaskTimeLog(taskID, logID int, req *at.UpdateTaskTimeLogRequest, actorID uuid.UUID, permissions []string) (*at.TaskTimeLog, error) {
+	return ctrl.taskClient.UpdateTaskTimeLog(taskID, logID, actorID, permissions, req)
+}
+
+func (ctrl *TaskController) DeleteTaskTimeLog(taskID, logID int, actorID uuid.UUID, permissions []string) error {
+	return ctrl.taskClient.DeleteTaskTimeLog(taskID, logID, actorID, permissions)
+}
+
+func (ctrl *TaskController) GetTaskTimeLogs(taskID, limit, offset int, actorID uuid.UUID, permissions []string) ([]at.TaskTimeLog, error) {
+	return ctrl.taskClient.GetTaskTimeLogs(taskID, actorID, permissions, limit, offset)
+}
+
+func (ctrl *TaskController) GetTimeSpent(query *dto.TimeSpentQueryGateway, actorID uuid.UUID, permissions []string) ([]at.TimeSpent, error) {
+	return ctrl.taskClient.GetTimeSpent(actorID, permissions, query)
+}
+
+// AddTaskAssignee - назначить соисполнителя; сбрасывает кеш задачи, поиска и списков задач соисполнителя
+func (ctrl *TaskController) AddTaskAssignee(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	if err := ctrl.taskClient.AddTaskAssignee(taskID, userID, actorID, permissions); err != nil {
+		return err
+	}
+	ctrl.invalidateTaskMemberCache(taskID, userID)
+	return nil
+}
+
+// RemoveTaskAssignee - снять соисполнителя с инвалидацией кеша задачи, поиска и списков задач соисполнителя
+func (ctrl *TaskController) RemoveTaskAssignee(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	if err := ctrl.taskClient.RemoveTaskAssignee(taskID, userID, actorID, permissions); err != nil {
+		return err
+	}
+	ctrl.invalidateTaskMemberCache(taskID, userID)
+	return nil
+}
+
+// AddTaskWatcher - подписать наблюдателя; сбрасывает кеш задачи, поиска и списков задач наблюдателя
+func (ctrl *TaskController) AddTaskWatcher(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	if err := ctrl.taskClient.AddTaskWatcher(taskID, userID, actorID, permissions); err != nil {
+		return err
+	}
+	ctrl.invalidateTaskMemberCache(taskID, userID)
+	return nil
+}
+
+// RemoveTaskWatcher - отписать наблюдателя с инвалидацией кеша задачи, поиска и списков задач наблюдателя
+func (ctrl *TaskController) RemoveTaskWatcher(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	if err := ctrl.taskClient.RemoveTaskWatcher(taskID, userID, actorID, permissions); err != nil {
+		return err
+	}
+	ctrl.invalidateTaskMemberCache(taskID, userID)
+	return nil
+}
+
+// GetAllLabels - все метки; не кешируются, так как число использований меняется вместе с задачами
+func (ctrl *TaskController) GetAllLabels() ([]at.Label, error) {
+	return ctrl.taskClient.GetAllLabels()
+}
+
+func (ctrl *TaskController) GetLabelByID(labelID int) (*at.Label, error) {
+	return ctrl.taskClient.GetLabelByID(labelID)
+}
+
+func (ctrl *TaskController) CreateLabel(req *at.SaveLabelRequest) (*at.Label, error) {
+	return ctrl.taskClient.CreateLabel(req)
+}
+
+func (ctrl *TaskController) UpdateLabel(labelID int, req *at.SaveLabelRequest) (*at.Label, error) {
+	return ctrl.taskClient.UpdateLabel(labelID, req)
+}
+
+// DeleteLabel - удалить метку с инвалидацией кеша поиска
+func (ctrl *TaskController) DeleteLabel(labelID int) error {
+	if err := ctrl.taskClient.DeleteLabel(labelID); err != nil {
+		return err
+	}
+	_ = ctrl.cacheService.DeleteTaskQueryCache(context.Background())
+	return nil
+}
+
+// CreateTaskRecurrence - серия повторяющихся задач от имени пользователя; экземпляры создаёт taskService
+func (ctrl *TaskController) CreateTaskRecurrence(req *at.SaveTaskRecurrenceRequest, actorID uuid.UUID) (*at.TaskRecurrence, error) {
+	return ctrl.taskClient.CreateTaskRecurrence(actorID, req)
+}
+
+func (ctrl *TaskController) GetTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string) (*at.TaskRecurrence, error) {
+	return ctrl.taskClient.GetTaskRecurrence(recurrenceID, actorID, permissions)
+}
+
+// UpdateTaskRecurrence - замена серии. taskService меняет или пересоздаёт ещё не начавшиеся экземпляры,
+// поэтому сбрасываются списки задач прежних и новых участников серии и кеш поиска
+func (ctrl *TaskController) UpdateTaskRecurrence(recurrenceID int, req *at.SaveTaskRecurrenceRequest, actorID uuid.UUID, permissions []string) (*at.TaskRecurrence, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	previous, _ := ctrl.taskClient.GetTaskRecurrence(recurrenceID, actorID, permissions)
+
+	recurrence, err := ctrl.taskClient.UpdateTaskRecurrence(recurrenceID, actorID, permissions, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrl.invalidateRecurrenceCache(ctx, previous)
+	ctrl.invalidateRecurrenceCache(ctx, recurrence)
+	return recurrence, nil
+}
+
+// DeleteTaskRecurrence - удаление серии вместе с её ещё не начавшимися экземплярами с инвалидацией кеша
+func (ctrl *TaskController) DeleteTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	previous, _ := ctrl.taskClient.GetTaskRecurrence(recurrenceID, actorID, permissions)
+
+	if err := ctrl.taskClient.DeleteTaskRecurrence(recurrenceID, actorID, permissions); err != nil {
+		return err
+	}
+
+	ctrl.invalidateRecurrenceCache(ctx, previous)
+	return nil
+}
+
+// invalidateRecurrenceCache сбрасывает списки задач, в которые входят экземпляры серии, и кеш поиска
+func (ctrl *TaskController) invalidateRecurrenceCache(ctx context.Context, recurrence *at.TaskRecurrence) {
+	if recurrence == nil {
+		return
+	}
+	ctrl.invalidateTaskListsCache(ctx, &at.TaskResponse{
+		CreatorID:  recurrence.CreatorID,
+		ExecutorID: &recurrence.ExecutorID,
+		ChatID:     &recurrence.ChatID,
+	})
+	_ = ctrl.cacheService.DeleteTaskQueryCache(ctx)
+}
+
+// GetTaskReport - отчёт по задачам; не кешируется, так как taskService строит его по заранее
+// агрегированным данным. taskService считает только задачи, которые видит пользователь
+func (ctrl *TaskController) GetTaskReport(report string, query *dto.TaskReportQueryGateway, actorID uuid.UUID, permissions []string) (*dto.TaskReport, error) {
+	return ctrl.taskClient.GetTaskReport(report, actorID, permissions, query)
+}
+
+// GetAllTaskTemplates - шаблоны задач, которые видит пользователь; не кешируются, так как меняются
+// редко и запрашиваются нечасто
+func (ctrl *TaskController) GetAllTaskTemplates(actorID uuid.UUID, permissions []string) ([]at.TaskTemplate, error) {
+	return ctrl.taskClient.GetAllTaskTemplates(actorID, permissions)
+}
+
+func (ctrl *TaskController) GetTaskTemplate(templateID int, actorID uuid.UUID, permissions []string) (*at.TaskTemplate, error) {
+	return ctrl.taskClient.GetTaskTemplate(templateID, actorID, permissions)
+}
+
+// CreateTaskTemplate - шаблон задачи от имени пользователя
+func (ctrl *TaskController) CreateTaskTemplate(req *at.SaveTaskTemplateRequest, actorID uuid.UUID) (*at.TaskTemplate, error) {
+	return ctrl.taskClient.CreateTaskTemplate(actorID, req)
+}
+
+// UpdateTaskTemplate - замена шаблона; права создателя или manage_all_tasks проверяет taskService
+func (ctrl *TaskController) UpdateTaskTemplate(templateID int, req *at.SaveTaskTemplateRequest, actorID uuid.UUID, permissions []string) (*at.TaskTemplate, error) {
+	return ctrl.taskClient.UpdateTaskTemplate(templateID, actorID, permissions, req)
+}
+
+func (ctrl *TaskController) DeleteTaskTemplate(templateID int, actorID uuid.UUID, permissions []string) error {
+	return ctrl.taskClient.DeleteTaskTemplate(templateID, actorID, permissions)
+}
+
+// CreateTaskFromTemplate - создание задачи по шаблону с инвалидацией списков задач и кеша поиска
+func (ctrl *TaskController) CreateTaskFromTemplate(templateID int, req *at.CreateTaskFromTemplateRequest, actorID uuid.UUID, permissions []string) (*at.TaskResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	task, err := ctrl.taskClient.CreateTaskFromTemplate(templateID, actorID, permissions, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrl.invalidateTaskListsCache(ctx, task)
+	_ = ctrl.cacheService.DeleteTaskQueryCache(ctx)
+	return task, nil
+}
+
+func (ctrl *TaskController) GetTaskChecklist(taskID int, actorID uuid.UUID, permissions []string) ([]at.TaskChecklistItem, error) {
+	return ctrl.taskClient.GetTaskChecklist(taskID, actorID, permissions)
+}
+
+// AddChecklistItem - добавить пункт чек-листа с инвалидацией кеша задачи и списков с прогрессом чек-листа
+func (ctrl *TaskController) AddChecklistItem(taskID int, req *at.AddChecklistItemRequest, actorID uuid.UUID, permissions []string) (*at.TaskChecklistItem, error) {
+	item, err := ctrl.taskClient.AddChecklistItem(taskID, actorID, permissions, req)
+	if err != nil {
+		return nil, err
+	}
+	ctrl.invalidateChecklistCache(taskID, actorID, permissions)
+	return item, nil
+}
+
+// UpdateChecklistItem - изменить пункт чек-листа с инвалидацией кеша задачи и списков с прогрессом чек-листа
+func (ctrl *TaskController) UpdateChecklistItem(taskID, itemID int, req *at.UpdateChecklistItemRequest, actorID uuid.UUID, permissions []string) (*at.TaskChecklistItem, error) {
+	item, err := ctrl.taskClient.UpdateChecklistItem(taskID, itemID, actorID, permissions, req)
+	if err != nil {
+		return nil, err
+	}
+	ctrl.invalidateChecklistCache(taskID, actorID, permissions)
+	return item, nil
+}
+
+// RemoveChecklistItem - удалить пункт чек-листа с инвалидацией кеша задачи и списков с прогрессом чек-листа
+func (ctrl *TaskController) RemoveChecklistItem(taskID, itemID int, actorID uuid.UUID, permissions []string) error {
+	if err := ctrl.taskClient.RemoveChecklistItem(taskID, itemID, actorID, permissions); err != nil {
+		return err
+	}
+	ctrl.invalidateChecklistCache(taskID, actorID, permissions)
+	return nil
+}
+
+// invalidateChecklistCache сбрасывает кеш задачи, а также списки и поиск, где показан прогресс её чек-листа.
+// Участники задачи при изменении чек-листа не меняются, поэтому их можно взять из текущего состояния
+func (ctrl *TaskController) invalidateChecklistCache(taskID int, actorID uuid.UUID, permissions []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_ = ctrl.cacheService.DeleteTaskCache(ctx, taskID)
+	if current, err := ctrl.taskClient.GetTaskByID(taskID, actorID, permissions); err == nil && current != nil {
+		ctrl.invalidateTaskListsCache(ctx, current.Task)
+	}
+	_ = ctrl.cacheService.DeleteTaskQueryCache(ctx)
+}
+
+// uploadFiles загружает вложения в fileService; файлы, которые не удалось загрузить, пропускаются
+func (ctrl *TaskController) uploadFiles(files []*multipart.FileHeader) []int {
+	var fileIDs []int
+	for _, file := range files {
+		uploadedFile, err := ctrl.fileClient.UploadFile(file)
+		if err != nil {
+			log.Printf("failed to upload file %s: %v\n", file.Filename, err)
+			continue
+		}
+		if uploadedFile.ID != nil {
+			fileIDs = append(fileIDs, *uploadedFile.ID)
 		}
+	}
+	return fileIDs
+}
 
-		// Получаем из сервиса (всегда запрашиваем 20 для кеша)
-		tasks, err := ctrl.taskClient.GetUserTasks(userID, 20, 0)
+// UpdateTask - редактирование задачи с загрузкой новых вложений и инвалидацией кеша
+func (ctrl *TaskController) UpdateTask(taskID int, req *dto.UpdateTaskRequestGateway, actorID uuid.UUID, permissions []string) (*at.TaskResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	executorID, chatID, err := req.ParseUUIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	updateReq := &at.UpdateTaskRequest{
+		Title:         req.Title,
+		Description:   req.Description,
+		ExecutorID:    executorID,
+		ChatID:        chatID,
+		RemoveFileIDs: req.RemoveFileIDs,
+		Priority:      req.Priority,
+	}
+	if err := req.ApplySchedule(updateReq); err != nil {
+		return nil, err
+	}
+	if err := req.ApplyParent(updateReq); err != nil {
+		return nil, err
+	}
+	if err := req.ApplyEstimate(updateReq); err != nil {
+		return nil, err
+	}
+	for _, file := range req.Files {
+		uploadedFile, err := ctrl.fileClient.UploadFile(file)
 		if err != nil {
-			return nil, err
+			log.Printf("failed to upload file %s: %v\n", file.Filename, err)
+			continue
+		}
+		if uploadedFile.ID != nil {
+			updateReq.AddFileIDs = append(updateReq.AddFileIDs, *uploadedFile.ID)
 		}
+	}
 
-		// Сохраняем в кеш
-		if tasks != nil {
-			if err := ctrl.cacheService.SetUserTasksCache(ctx, userID, *tasks); err != nil {
-				log.Printf("Failed to cache user tasks for %s: %v", userID, err)
-			}
+	// Прежнее состояние нужно, чтобы сбросить списки прежних исполнителя и чата
+	previous, _ := ctrl.taskClient.GetTaskByID(taskID, actorID, permissions)
+
+	task, err := ctrl.taskClient.UpdateTask(taskID, actorID, permissions, updateReq)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = ctrl.cacheService.DeleteTaskCache(ctx, taskID)
+	if previous != nil {
+		ctrl.invalidateTaskListsCache(ctx, previous.Task)
+	}
+	ctrl.invalidateTaskListsCache(ctx, task)
+	_ = ctrl.cacheService.DeleteTaskQueryCache(ctx)
+
+	return task, nil
+}
+
+// DeleteTask - удаление задачи с инвалидацией кеша
+func (ctrl *TaskController) DeleteTask(taskID int, actorID uuid.UUID, permissions []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	previous, _ := ctrl.taskClient.GetTaskByID(taskID, actorID, permissions)
+
+	if err := ctrl.taskClient.DeleteTask(taskID, actorID, permissions); err != nil {
+		return err
+	}
+
+	_ = ctrl.cacheService.DeleteTaskCache(ctx, taskID)
+	if previous != nil {
+		ctrl.invalidateTaskListsCache(ctx, previous.Task)
+	}
+	_ = ctrl.cacheService.DeleteTaskQueryCache(ctx)
+
+	return nil
+}
+
+// BulkUpdateTasks - массовая операция над задачами. Сбрасывает кеш изменённых задач, поиска
+// и списков задач, в которые они входили: любое действие меняет содержимое этих списков
+func (ctrl *TaskController) BulkUpdateTasks(actorID uuid.UUID, permissions []string, req *at.TaskBulkRequest) (*at.TaskBulkResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Состояние до операции нужно, чтобы сбросить списки прежних исполнителей и чатов
+	previous := make(map[int]*at.TaskResponse, len(req.TaskIDs))
+	for _, taskID := range req.TaskIDs {
+		if resp, err := ctrl.taskClient.GetTaskByID(taskID, actorID, permissions); err == nil && resp != nil {
+			previous[taskID] = resp.Task
 		}
+	}
 
-		// Возвращаем запрошенное количество
-		if tasks != nil && limit < len(*tasks) {
-			result := (*tasks)[:limit]
-			return &result, nil
+	result, err := ctrl.taskClient.BulkUpdateTasks(actorID, permissions, req)
+	if err != nil {
+		return nil, err
+	}
+	if result.Succeeded == 0 {
+		return result, nil
+	}
+
+	for _, item := range result.Items {
+		if !item.Success {
+			continue
 		}
-		return tasks, nil
+		_ = ctrl.cacheService.DeleteTaskCache(ctx, item.TaskID)
+		ctrl.invalidateTaskListsCache(ctx, previous[item.TaskID])
+	}
+	if req.Action == at.TaskBulkReassign && req.ExecutorID != nil && *req.ExecutorID != uuid.Nil {
+		_ = ctrl.cacheService.DeleteUserTasksCache(ctx, req.ExecutorID.String())
+	}
+	_ = ctrl.cacheService.DeleteTaskQueryCache(ctx)
+
+	return result, nil
+}
+
+// invalidateTaskListsCache сбрасывает списки задач, в которые может входить задача:
+// создателя, исполнителя, соисполнителей, наблюдателей и чата
+func (ctrl *TaskController) invalidateTaskListsCache(ctx context.Context, task *at.TaskResponse) {
+	if task == nil {
+		return
+	}
+	_ = ctrl.cacheService.DeleteUserTasksCache(ctx, task.CreatorID.String())
+	if task.ExecutorID != nil && *task.ExecutorID != uuid.Nil {
+		_ = ctrl.cacheService.DeleteUserTasksCache(ctx, task.ExecutorID.String())
+	}
+	for _, assignee := range task.Assignees {
+		_ = ctrl.cacheService.DeleteUserTasksCache(ctx, assignee.UserID.String())
+	}
+	for _, watcher := range task.Watchers {
+		_ = ctrl.cacheService.DeleteUserTasksCache(ctx, watcher.UserID.String())
+	}
+	if task.ChatID != nil && *task.ChatID != uuid.Nil {
+		_ = ctrl.cacheService.DeleteChatTasksCache(ctx, task.ChatID.String())
+	}
+}
+
+// invalidateChangedTaskCache сбрасывает кеш задачи, поиска и всех списков, в которые она входит.
+// Задача перечитывается после изменения: состав её участников и чат при этом не меняются
+func (ctrl *TaskController) invalidateChangedTaskCache(taskID int, actorID uuid.UUID, permissions []string) {
+	ctx := context.Background()
+	_ = ctrl.cacheService.DeleteTaskCache(ctx, taskID)
+	if resp, err := ctrl.taskClient.GetTaskByID(taskID, actorID, permissions); err == nil && resp != nil {
+		ctrl.invalidateTaskListsCache(ctx, resp.Task)
 	}
+	_ = ctrl.cacheService.DeleteTaskQueryCache(ctx)
+}
+
+// invalidateTaskMemberCache сбрасывает кеш задачи, поиска и списков задач добавленного или снятого участника
+func (ctrl *TaskController) invalidateTaskMemberCache(taskID int, userID uuid.UUID) {
+	ctx := context.Background()
+	_ = ctrl.cacheService.DeleteTaskCache(ctx, taskID)
+	_ = ctrl.cacheService.DeleteUserTasksCache(ctx, userID.String())
+	_ = ctrl.cacheService.DeleteTaskQueryCache(ctx)
+}
+
+// getCachedTaskList возвращает список задач, кешируя только первую страницу (offset = 0, limit <= 20)
+func (ctrl *TaskController) getCachedTaskList(
+	cacheKey string,
+	limit, offset int,
+	fetch func(limit, offset int) (*[]at.TaskToList, error),
+) (*[]at.TaskToList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
 	// Для остальных запросов идём напрямую в сервис
-	return ctrl.taskClient.GetUserTasks(userID, limit, offset)
+	if offset != 0 || limit > 20 {
+		return fetch(limit, offset)
+	}
+
+	var cachedTasks []at.TaskToList
+	if err := ctrl.cacheService.Get(ctx, cacheKey, &cachedTasks); err == nil {
+		log.Printf("Task list %s found in cache", cacheKey)
+		if limit < len(cachedTasks) {
+			result := cachedTasks[:limit]
+			return &result, nil
+		}
+		return &cachedTasks, nil
+	}
+
+	// Получаем из сервиса (всегда запрашиваем 20 для кеша)
+	tasks, err := fetch(20, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Сохраняем в кеш
+	if tasks != nil {
+		if err := ctrl.cacheService.Set(ctx, cacheKey, *tasks, 10*time.Minute); err != nil {
+			log.Printf("Failed to cache task list %s: %v", cacheKey, err)
+		}
+	}
+
+	// Возвращаем запрошенное количество
+	if tasks != nil && limit < len(*tasks) {
+		result := (*tasks)[:limit]
+		return &result, nil
+	}
+	return tasks, nil
 }
 
 // GetAllStatuses - получить все статусы задач с кешированием
@@ -239,15 +828,26 @@ func (ctrl *TaskController) GetStatusByID(statusID int) (*at.TaskStatus, error)
 	return status, nil
 }
 
-// DeleteStatus - удалить статус задачи с инвалидацией кеша
-func (ctrl *TaskController) DeleteStatus(statusID int) error {
-	err := ctrl.taskClient.DeleteStatus(statusID)
+// GetStatusUsage - число задач в статусе и workflow, которые его используют
+func (ctrl *TaskController) GetStatusUsage(statusID int) (*at.TaskStatusUsage, error) {
+	return ctrl.taskClient.GetStatusUsage(statusID)
+}
+
+// DeleteStatus - удалить статус задачи с инвалидацией кеша. Какие задачи перенесены в replacementID,
+// неизвестно, поэтому при переносе сбрасывается кеш всех задач
+func (ctrl *TaskController) DeleteStatus(statusID int, replacementID *int, actorID uuid.UUID, permissions []string) error {
+	err := ctrl.taskClient.DeleteStatus(statusID, replacementID, actorID, permissions)
 	if err != nil {
 		return err
 	}
 
 	// Инвалидация кеша
 	ctx := context.Background()
+	if replacementID != nil {
+		_ = ctrl.cacheService.DeleteAllTasksCache(ctx)
+		return nil
+	}
+
 	statusKey := fmt.Sprintf("task:status:%d", statusID)
 	_ = ctrl.cacheService.Delete(ctx, statusKey)
 
@@ -256,3 +856,28 @@ func (ctrl *TaskController) DeleteStatus(statusID int) error {
 
 	return nil
 }
+
+// GetAllWorkflows - получить все workflow задач
+func (ctrl *TaskController) GetAllWorkflows() ([]at.TaskWorkflow, error) {
+	return ctrl.taskClient.GetAllWorkflows()
+}
+
+// GetWorkflowByID - получить workflow по ID
+func (ctrl *TaskController) GetWorkflowByID(workflowID int) (*at.TaskWorkflow, error) {
+	return ctrl.taskClient.GetWorkflowByID(workflowID)
+}
+
+// CreateWorkflow - создать workflow
+func (ctrl *TaskController) CreateWorkflow(req *at.SaveWorkflowRequest) (*at.TaskWorkflow, error) {
+	return ctrl.taskClient.CreateWorkflow(req)
+}
+
+// UpdateWorkflow - полностью заменить workflow
+func (ctrl *TaskController) UpdateWorkflow(workflowID int, req *at.SaveWorkflowRequest) (*at.TaskWorkflow, error) {
+	return ctrl.taskClient.UpdateWorkflow(workflowID, req)
+}
+
+// DeleteWorkflow - удалить workflow
+func (ctrl *TaskController) DeleteWorkflow(workflowID int) error {
+	return ctrl.taskClient.DeleteWorkflow(workflowID)
+}
@@ -1,9 +1,16 @@
 package custom_errors
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrNilUserInClient = "User nil error"
+	// ErrCalendarFeedTokenNotFound - токен ленты календаря не выпускался или уже отозван
+	ErrCalendarFeedTokenNotFound = errors.New("calendar feed token not found")
+	// ErrMessageUploadInProgress - вложения сообщения с тем же clientID ещё загружает другой запрос
+	ErrMessageUploadInProgress = errors.New("message attachments are still being uploaded, retry later")
 )
 
 type FileSource string
@@ -25,3 +32,17 @@ func (e *FileServiceConflictError) Error() string {
 func NewFileServiceConflictError(name string, source FileSource) *FileServiceConflictError {
 	return &FileServiceConflictError{name: name, source: source}
 }
+
+// TaskServiceError - ошибка, которую вернул taskService; StatusCode позволяет пробросить клиенту 4xx-ответы
+type TaskServiceError struct {
+	StatusCode int
+	body       string
+}
+
+func (e *TaskServiceError) Error() string {
+	return fmt.Sprintf("task service returned error: status %d, body: %s", e.StatusCode, e.body)
+}
+
+func NewTaskServiceError(statusCode int, body string) *TaskServiceError {
+	return &TaskServiceError{StatusCode: statusCode, body: body}
+}
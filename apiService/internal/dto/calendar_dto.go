@@ -0,0 +1,8 @@
+package dto
+
+// CalendarFeedResponse - ссылка на подписку календаря задач. Токен показывается только при выпуске
+type CalendarFeedResponse struct {
+	Token string `json:"token"`
+	// URL - адрес ленты .ics для подписки в календаре; не требует авторизации
+	URL string `json:"url"`
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	ac "common/contracts/api-chat"
+	fc "common/contracts/file-contracts"
 	"mime/multipart"
 
 	"github.com/google/uuid"
@@ -36,8 +37,9 @@ func (r *CreateChatRequestGateway) ParseUUIDs() (ownerID uuid.UUID, userIDs []uu
 }
 
 type SendMessageRequestGateway struct {
-	Content string                  `form:"content" binding:"required"`
-	Files   []*multipart.FileHeader `form:"files"`
+	Content  string                  `form:"content" binding:"required,max=4000"`
+	Files    []*multipart.FileHeader `form:"files"`
+	ClientID *string                 `form:"clientID" binding:"omitempty,max=64"`
 }
 
 type CreateChatResponse struct {
@@ -119,7 +121,20 @@ type MyRoleResponseGateway struct {
 
 // ChatMemberResponseGateway - участник чата для swagger
 type ChatMemberResponseGateway struct {
-	UserID   string `json:"userId"`
-	RoleID   int    `json:"roleId"`
-	RoleName string `json:"roleName"`
+	UserID     string   `json:"userId"`
+	RoleID     int      `json:"roleId"`
+	RoleName   string   `json:"roleName"`
+	Username   string   `json:"username,omitempty"`
+	AvatarFile *fc.File `json:"avatarFile,omitempty"`
+}
+
+// ChatMembersResponseGateway - страница участников чата для swagger
+type ChatMembersResponseGateway struct {
+	Members []ChatMemberResponseGateway `json:"members"`
+	Total   int64                       `json:"total"`
+}
+
+// SaveMessageRequestGateway - запрос на добавление сообщения в закладки
+type SaveMessageRequestGateway struct {
+	Note *string `json:"note" binding:"omitempty,max=1000"`
 }
@@ -2,18 +2,45 @@ package dto
 
 import (
 	"errors"
+	"fmt"
 	"mime/multipart"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	at "common/contracts/api-task"
 	"github.com/google/uuid"
 )
 
 // CreateTaskRequestGateway - запрос на создание задачи через API Gateway
 type CreateTaskRequestGateway struct {
-	Title       string                  `form:"title" binding:"required"`
-	Description *string                 `form:"description"`
-	ExecutorID  string                  `form:"executor_id" binding:"required"`
-	ChatID      *string                 `form:"chat_id"`
-	Files       []*multipart.FileHeader `form:"files"`
+	Title        string  `form:"title" binding:"required"`
+	Description  *string `form:"description"`
+	ExecutorID   string  `form:"executor_id" binding:"required"`
+	ChatID       *string `form:"chat_id"`
+	WorkflowID   *int    `form:"workflow_id"`
+	ParentTaskID *int    `form:"parent_task_id"`
+	Priority     string  `form:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	StartAt      *string `form:"start_at"`
+	DueAt        *string `form:"due_at"`
+	// EstimateMinutes - оценка трудозатрат в минутах
+	EstimateMinutes *int                    `form:"estimate_minutes" binding:"omitempty,min=0"`
+	Files           []*multipart.FileHeader `form:"files"`
+}
+
+// ParseSchedule парсит дату начала и срок задачи в формате RFC3339
+func (req *CreateTaskRequestGateway) ParseSchedule() (*time.Time, *time.Time, error) {
+	startAt, err := parseOptionalTime("start_at", req.StartAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	dueAt, err := parseOptionalTime("due_at", req.DueAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return startAt, dueAt, nil
 }
 
 // ParseUUIDs парсит строковые UUID в структуру CreateTaskRequestGateway
@@ -42,7 +69,340 @@ func (req *CreateTaskRequestGateway) ParseUUIDs() (*uuid.UUID, *uuid.UUID, error
 	return executorID, chatID, nil
 }
 
+// UpdateTaskRequestGateway - запрос на редактирование задачи через API Gateway.
+// Незаполненные поля не изменяются; пустые executor_id или chat_id снимают исполнителя или отвязывают чат,
+// пустые start_at или due_at снимают дату начала или срок, пустой estimate_minutes снимает оценку,
+// пустой parent_task_id делает задачу задачей верхнего уровня
+type UpdateTaskRequestGateway struct {
+	Title           *string                 `form:"title" binding:"omitempty,min=1,max=255"`
+	Description     *string                 `form:"description"`
+	ExecutorID      *string                 `form:"executor_id"`
+	ChatID          *string                 `form:"chat_id"`
+	Files           []*multipart.FileHeader `form:"files"`
+	RemoveFileIDs   []int                   `form:"remove_file_ids"`
+	Priority        *string                 `form:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	StartAt         *string                 `form:"start_at"`
+	DueAt           *string                 `form:"due_at"`
+	EstimateMinutes *string                 `form:"estimate_minutes"`
+	ParentTaskID    *string                 `form:"parent_task_id"`
+}
+
+// ParseUUIDs парсит строковые UUID в структуру UpdateTaskRequestGateway
+func (req *UpdateTaskRequestGateway) ParseUUIDs() (*uuid.UUID, *uuid.UUID, error) {
+	executorID, err := parseOptionalUUID(req.ExecutorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	chatID, err := parseOptionalUUID(req.ChatID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return executorID, chatID, nil
+}
+
+// ApplySchedule переносит дату начала и срок в запрос к taskService, пустое значение снимает дату
+func (req *UpdateTaskRequestGateway) ApplySchedule(updateReq *at.UpdateTaskRequest) error {
+	if req.StartAt != nil && *req.StartAt == "" {
+		updateReq.ClearStartAt = true
+	} else {
+		startAt, err := parseOptionalTime("start_at", req.StartAt)
+		if err != nil {
+			return err
+		}
+		updateReq.StartAt = startAt
+	}
+
+	if req.DueAt != nil && *req.DueAt == "" {
+		updateReq.ClearDueAt = true
+	} else {
+		dueAt, err := parseOptionalTime("due_at", req.DueAt)
+		if err != nil {
+			return err
+		}
+		updateReq.DueAt = dueAt
+	}
+	return nil
+}
+
+// ApplyParent переносит родительскую задачу в запрос к taskService, пустое значение снимает родителя
+func (req *UpdateTaskRequestGateway) ApplyParent(updateReq *at.UpdateTaskRequest) error {
+	if req.ParentTaskID == nil {
+		return nil
+	}
+	if *req.ParentTaskID == "" {
+		updateReq.ClearParentTaskID = true
+		return nil
+	}
+	parentTaskID, err := strconv.Atoi(*req.ParentTaskID)
+	if err != nil || parentTaskID <= 0 {
+		return errors.New("invalid parent_task_id: expected task ID")
+	}
+	updateReq.ParentTaskID = &parentTaskID
+	return nil
+}
+
+// ApplyEstimate переносит оценку трудозатрат в запрос к taskService, пустое значение снимает оценку
+func (req *UpdateTaskRequestGateway) ApplyEstimate(updateReq *at.UpdateTaskRequest) error {
+	if req.EstimateMinutes == nil {
+		return nil
+	}
+	if *req.EstimateMinutes == "" {
+		updateReq.ClearEstimate = true
+		return nil
+	}
+	estimate, err := strconv.Atoi(*req.EstimateMinutes)
+	if err != nil || estimate < 0 {
+		return errors.New("invalid estimate_minutes: expected non-negative number of minutes")
+	}
+	updateReq.EstimateMinutes = &estimate
+	return nil
+}
+
+func parseOptionalTime(field string, value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: expected RFC3339 date", field)
+	}
+	return &parsed, nil
+}
+
+// TaskListFilterGateway - фильтры и сортировка списка задач пользователя; проверяются и применяются в taskService
+type TaskListFilterGateway struct {
+	Priority string  `form:"priority"`
+	Label    string  `form:"label"`
+	DueFrom  *string `form:"due_from"`
+	DueTo    *string `form:"due_to"`
+	Overdue  *bool   `form:"overdue"`
+	SortBy   string  `form:"sort_by" binding:"omitempty,oneof=created_at due_at start_at priority"`
+	Order    string  `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// IsEmpty сообщает, что фильтры не заданы и можно использовать кеш первой страницы
+func (f *TaskListFilterGateway) IsEmpty() bool {
+	return f == nil || len(f.Query()) == 0
+}
+
+// Query возвращает заданные фильтры в виде query-параметров запроса к taskService
+func (f *TaskListFilterGateway) Query() url.Values {
+	values := url.Values{}
+	if f == nil {
+		return values
+	}
+	if f.Priority != "" {
+		values.Set("priority", f.Priority)
+	}
+	if f.Label != "" {
+		values.Set("label", f.Label)
+	}
+	if f.DueFrom != nil && *f.DueFrom != "" {
+		values.Set("due_from", *f.DueFrom)
+	}
+	if f.DueTo != nil && *f.DueTo != "" {
+		values.Set("due_to", *f.DueTo)
+	}
+	if f.Overdue != nil {
+		values.Set("overdue", strconv.FormatBool(*f.Overdue))
+	}
+	if f.SortBy != "" {
+		values.Set("sort_by", f.SortBy)
+	}
+	if f.Order != "" {
+		values.Set("order", f.Order)
+	}
+	return values
+}
+
+// TaskQueryGateway - параметры поиска задач; значения проверяются и применяются в taskService
+type TaskQueryGateway struct {
+	Q           string `form:"q"`
+	Status      string `form:"status"`
+	CreatorID   string `form:"creator_id"`
+	ExecutorID  string `form:"executor_id"`
+	WatcherID   string `form:"watcher_id"`
+	ChatID      string `form:"chat_id"`
+	Priority    string `form:"priority"`
+	Label       string `form:"label"`
+	DueFrom     string `form:"due_from"`
+	DueTo       string `form:"due_to"`
+	StartFrom   string `form:"start_from"`
+	StartTo     string `form:"start_to"`
+	CreatedFrom string `form:"created_from"`
+	CreatedTo   string `form:"created_to"`
+	Overdue     *bool  `form:"overdue"`
+	SortBy      string `form:"sort_by" binding:"omitempty,oneof=created_at updated_at due_at start_at priority status title"`
+	Order       string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Limit       int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor      string `form:"cursor"`
+}
+
+// Query возвращает параметры поиска в каноническом виде: списки отсортированы, пробелы в запросе
+// схлопнуты. Одинаковые по смыслу запросы дают одинаковую строку, поэтому она же служит ключом кеша
+func (q *TaskQueryGateway) Query() url.Values {
+	values := url.Values{}
+	set := func(key, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			values.Set(key, value)
+		}
+	}
+
+	set("q", strings.Join(strings.Fields(q.Q), " "))
+	set("status", canonicalList(q.Status))
+	set("creator_id", strings.ToLower(q.CreatorID))
+	set("executor_id", strings.ToLower(q.ExecutorID))
+	set("watcher_id", strings.ToLower(q.WatcherID))
+	set("chat_id", strings.ToLower(q.ChatID))
+	set("priority", canonicalList(q.Priority))
+	set("label", canonicalList(q.Label))
+	set("due_from", q.DueFrom)
+	set("due_to", q.DueTo)
+	set("start_from", q.StartFrom)
+	set("start_to", q.StartTo)
+	set("created_from", q.CreatedFrom)
+	set("created_to", q.CreatedTo)
+	if q.Overdue != nil {
+		values.Set("overdue", strconv.FormatBool(*q.Overdue))
+	}
+	set("sort_by", q.SortBy)
+	set("order", q.Order)
+	if q.Limit > 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+	set("cursor", q.Cursor)
+	return values
+}
+
+// TaskBoardQueryGateway - параметры доски задач: задачи чата или, без chat_id, задачи текущего пользователя
+type TaskBoardQueryGateway struct {
+	ChatID     string `form:"chat_id" binding:"omitempty,uuid"`
+	ExecutorID string `form:"-"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// Query возвращает параметры доски для taskService: chat_id или executor_id и limit
+func (q *TaskBoardQueryGateway) Query() url.Values {
+	values := url.Values{}
+	if q.ChatID != "" {
+		values.Set("chat_id", q.ChatID)
+	} else {
+		values.Set("executor_id", q.ExecutorID)
+	}
+	if q.Limit > 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return values
+}
+
+// TimeSpentQueryGateway - параметры отчёта о затраченном времени; даты в RFC3339 проверяет taskService
+type TimeSpentQueryGateway struct {
+	GroupBy string `form:"group_by" binding:"omitempty,oneof=task user chat"`
+	From    string `form:"from"`
+	To      string `form:"to"`
+	TaskID  int    `form:"task_id" binding:"omitempty,min=1"`
+	UserID  string `form:"user_id" binding:"omitempty,uuid"`
+	ChatID  string `form:"chat_id" binding:"omitempty,uuid"`
+}
+
+// Query возвращает заполненные параметры отчёта для taskService
+func (q *TimeSpentQueryGateway) Query() url.Values {
+	values := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	set("group_by", q.GroupBy)
+	set("from", q.From)
+	set("to", q.To)
+	if q.TaskID > 0 {
+		values.Set("task_id", strconv.Itoa(q.TaskID))
+	}
+	set("user_id", q.UserID)
+	set("chat_id", q.ChatID)
+	return values
+}
+
+// TaskReportQueryGateway - параметры отчётов по задачам; даты в RFC3339 и обязательность chat_id
+// для накопительной диаграммы проверяет taskService
+type TaskReportQueryGateway struct {
+	Interval string `form:"interval" binding:"omitempty,oneof=day week"`
+	From     string `form:"from"`
+	To       string `form:"to"`
+	ChatID   string `form:"chat_id" binding:"omitempty,uuid"`
+	Format   string `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// Query возвращает заполненные параметры отчёта для taskService
+func (q *TaskReportQueryGateway) Query() url.Values {
+	values := url.Values{}
+	for key, value := range map[string]string{
+		"interval": q.Interval,
+		"from":     q.From,
+		"to":       q.To,
+		"chat_id":  q.ChatID,
+		"format":   q.Format,
+	} {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	return values
+}
+
+// TaskReport - отчёт taskService в исходном виде (JSON или CSV), который передаётся клиенту без изменений
+type TaskReport struct {
+	ContentType        string
+	ContentDisposition string
+	Body               []byte
+}
+
+// canonicalList сортирует значения списка через запятую и убирает повторы
+func canonicalList(raw string) string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	slices.Sort(items)
+	return strings.Join(slices.Compact(items), ",")
+}
+
+func parseOptionalUUID(value *string) (*uuid.UUID, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if *value == "" {
+		return &uuid.Nil, nil
+	}
+	parsed, err := uuid.Parse(*value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
 // CreateStatusRequestGateway - запрос на создание статуса задачи через API Gateway
 type CreateStatusRequestGateway struct {
 	Name string `json:"name" binding:"required"`
 }
+
+// CreateTaskCommentRequestGateway - комментарий к задаче; вложения загружаются в fileService до отправки в taskService
+type CreateTaskCommentRequestGateway struct {
+	Body  string                  `form:"body" binding:"required,max=10000"`
+	Files []*multipart.FileHeader `form:"files"`
+}
+
+// UpdateTaskCommentRequestGateway - правка комментария; nil-поля не изменяются
+type UpdateTaskCommentRequestGateway struct {
+	Body          *string                 `form:"body" binding:"omitempty,min=1,max=10000"`
+	Files         []*multipart.FileHeader `form:"files"`
+	RemoveFileIDs []int                   `form:"remove_file_ids"`
+}
+
+// AddTaskDependencyRequestGateway - блокирующая задача для задачи из пути запроса
+type AddTaskDependencyRequestGateway struct {
+	BlockerTaskID int `json:"blocker_task_id" binding:"required,min=1"`
+}
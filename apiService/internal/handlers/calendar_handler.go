@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"apiService/internal/controllers"
+	"apiService/internal/custom_errors"
+	"apiService/internal/services"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+type CalendarHandler struct {
+	calendarController controllers.CalendarControllerInterface
+}
+
+func NewCalendarHandler(calendarController controllers.CalendarControllerInterface) *CalendarHandler {
+	return &CalendarHandler{calendarController: calendarController}
+}
+
+// IssueCalendarFeed Выпуск ссылки на календарь задач
+// @Summary Получить ссылку на календарь задач
+// @Description Выпускает токен и ссылку на ленту .ics со сроками задач пользователя для подписки в календаре. Ссылка не требует входа и не зависит от JWT-сессий; повторный вызов выпускает новую ссылку, а прежняя перестаёт работать
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} dto.CalendarFeedResponse "Ссылка на ленту"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/calendar-feed [post]
+func (h *CalendarHandler) IssueCalendarFeed(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	feed, err := h.calendarController.IssueFeedToken(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, feed)
+}
+
+// RevokeCalendarFeed Отзыв ссылки на календарь задач
+// @Summary Отозвать ссылку на календарь задач
+// @Description Отзывает токен ленты .ics; подписанные календари перестают получать задачи. Если ссылки нет, ничего не делает
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Success 204 "Ссылка отозвана"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/calendar-feed [delete]
+func (h *CalendarHandler) RevokeCalendarFeed(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.calendarController.RevokeFeedToken(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCalendarFeed Лента задач в формате iCalendar
+// @Summary Лента задач в формате iCalendar
+// @Description Возвращает задачи владельца токена со сроком (исполнитель, соисполнитель или наблюдатель, сроки не старше 90 дней, не более 500 задач) как события VEVENT или задачи VTODO. Авторизация - токен из ссылки подписки, JWT не нужен. Лента собирается при каждом запросе, поэтому отражает текущее состояние задач
+// @Tags tasks
+// @Produce text/calendar
+// @Param token path string true "Токен ленты с расширением .ics"
+// @Param kind query string false "Вид записей" Enums(event, todo) default(event)
+// @Success 200 {string} string "Календарь iCalendar"
+// @Failure 400 {object} map[string]interface{} "Некорректный вид записей"
+// @Failure 404 {object} map[string]interface{} "Ссылка не найдена или отозвана"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /calendar/{token} [get]
+func (h *CalendarHandler) GetCalendarFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	if token == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": custom_errors.ErrCalendarFeedTokenNotFound.Error()})
+		return
+	}
+
+	kind := services.CalendarEntryKind(c.DefaultQuery("kind", string(services.CalendarEntryEvent)))
+	if kind != services.CalendarEntryEvent && kind != services.CalendarEntryTodo {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid kind"})
+		return
+	}
+
+	feed, err := h.calendarController.GetFeed(token, kind)
+	if err != nil {
+		if errors.Is(err, custom_errors.ErrCalendarFeedTokenNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="tasks.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"apiService/internal/controllers"
+	"apiService/internal/custom_errors"
 	"apiService/internal/dto"
+	ac "common/contracts/api-chat"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -158,9 +161,12 @@ func (h *ChatHandler) GetChatMessages(c *gin.Context) {
 // @Param chat_id path string true "UUID чата"
 // @Param content formData string true "Текст сообщения"
 // @Param files formData []file false "Прикрепленные файлы"
+// @Param clientID formData string false "Сгенерированный клиентом ID сообщения для безопасных повторов"
+// @Param Idempotency-Key header string false "Ключ идемпотентности (альтернатива clientID)"
 // @Success 201 {object} map[string]interface{} "Сообщение успешно отправлено"
 // @Failure 400 {object} map[string]interface{} "Некорректный запрос или неверный UUID чата"
 // @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 409 {object} map[string]interface{} "Вложения сообщения с этим clientID ещё загружаются"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /chats/messages/{chat_id} [post]
 func (h *ChatHandler) SendMessage(c *gin.Context) {
@@ -182,8 +188,23 @@ func (h *ChatHandler) SendMessage(c *gin.Context) {
 		return
 	}
 
+	// Idempotency-Key используется, если клиент не передал clientID в форме
+	if req.ClientID == nil {
+		if key := c.GetHeader("Idempotency-Key"); key != "" {
+			if len(key) > 64 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key is too long"})
+				return
+			}
+			req.ClientID = &key
+		}
+	}
+
 	message, err := h.chatController.SendMessage(chatID, userID, &req)
 	if err != nil {
+		if errors.Is(err, custom_errors.ErrMessageUploadInProgress) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -505,16 +526,20 @@ func (h *ChatHandler) GetMyRoleInChat(c *gin.Context) {
 
 // GetChatMembers Получение списка участников чата
 // @Summary Получить список участников чата
-// @Description Возвращает список всех участников чата с их ролями
+// @Description Возвращает страницу участников чата с ролями, именами и аватарами. Поддерживает фильтр по роли и поиск по подстроке имени пользователя
 // @Tags chats
 // @Produce json
 // @Security BearerAuth
 // @Param chat_id path string true "UUID чата"
-// @Success 200 {array} dto.ChatMemberResponseGateway "Список участников"
-// @Failure 400 {object} map[string]interface{} "Некорректный UUID чата"
+// @Param offset query int false "Смещение" default(0)
+// @Param limit query int false "Количество участников на странице" default(50) maximum(100)
+// @Param role query string false "Название роли"
+// @Param search query string false "Поиск по подстроке имени пользователя"
+// @Success 200 {object} dto.ChatMembersResponseGateway "Страница участников"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID чата или параметры пагинации"
 // @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
-// @Router /chats/{chat_id}/members [get]
+// @Router /chats/members/{chat_id} [get]
 func (h *ChatHandler) GetChatMembers(c *gin.Context) {
 	chatID, err := uuid.Parse(c.Param("chat_id"))
 	if err != nil {
@@ -522,7 +547,26 @@ func (h *ChatHandler) GetChatMembers(c *gin.Context) {
 		return
 	}
 
-	members, err := h.chatController.GetChatMembers(chatID)
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+
+	query := &ac.ChatMembersQuery{
+		Offset: offset,
+		Limit:  limit,
+		Role:   c.Query("role"),
+		Search: c.Query("search"),
+	}
+
+	members, err := h.chatController.GetChatMembers(chatID, query)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -530,3 +574,197 @@ func (h *ChatHandler) GetChatMembers(c *gin.Context) {
 
 	c.JSON(http.StatusOK, members)
 }
+
+// SaveMessage Добавление сообщения в закладки
+// @Summary Сохранить сообщение в закладки
+// @Description Добавляет сообщение из доступного пользователю чата в закладки с необязательной заметкой. Повторный вызов обновляет заметку
+// @Tags chats
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param message_id path string true "UUID сообщения"
+// @Param request body dto.SaveMessageRequestGateway false "Заметка к закладке"
+// @Success 201 {object} map[string]interface{} "Сообщение сохранено"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или неверный UUID сообщения"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/saved-messages/{message_id} [post]
+func (h *ChatHandler) SaveMessage(c *gin.Context) {
+	messageID, err := uuid.Parse(c.Param("message_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message ID"})
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req dto.SaveMessageRequestGateway
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	savedMessage, err := h.chatController.SaveMessage(userID, messageID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, savedMessage)
+}
+
+// GetSavedMessages Получение закладок пользователя
+// @Summary Получить сохранённые сообщения
+// @Description Возвращает закладки текущего пользователя с пагинацией. Содержимое сообщений из чатов, к которым пользователь потерял доступ, скрыто
+// @Tags chats
+// @Produce json
+// @Security BearerAuth
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Param limit query int false "Количество закладок на странице" default(20) maximum(100)
+// @Success 200 {object} map[string]interface{} "Список закладок"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры пагинации"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/saved-messages [get]
+func (h *ChatHandler) GetSavedMessages(c *gin.Context) {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+
+	savedMessages, err := h.chatController.GetSavedMessages(userID, offset, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, savedMessages)
+}
+
+// DeleteSavedMessage Удаление сообщения из закладок
+// @Summary Удалить сообщение из закладок
+// @Description Удаляет сообщение из закладок текущего пользователя
+// @Tags chats
+// @Produce json
+// @Security BearerAuth
+// @Param message_id path string true "UUID сообщения"
+// @Success 200 {object} map[string]interface{} "Закладка удалена"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID сообщения"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/saved-messages/{message_id} [delete]
+func (h *ChatHandler) DeleteSavedMessage(c *gin.Context) {
+	messageID, err := uuid.Parse(c.Param("message_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message ID"})
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.chatController.DeleteSavedMessage(userID, messageID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "saved message deleted successfully"})
+}
+
+// GetTaskEventSettings Получение настроек событий задач в чате
+// @Summary Получить настройки событий задач в чате
+// @Description Возвращает, какие события привязанных к чату задач (создание, смена статуса, переназначение) публикуются в чат системными сообщениями. По умолчанию публикуются все. Требует права view_messages в чате
+// @Tags chats
+// @Produce json
+// @Security BearerAuth
+// @Param chat_id path string true "UUID чата"
+// @Success 200 {object} ac.TaskEventSettings "Настройки чата"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID чата"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/task-event-settings/{chat_id} [get]
+func (h *ChatHandler) GetTaskEventSettings(c *gin.Context) {
+	chatID, err := uuid.Parse(c.Param("chat_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chat ID"})
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.chatController.GetTaskEventSettings(chatID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateTaskEventSettings Изменение настроек событий задач в чате
+// @Summary Изменить настройки событий задач в чате
+// @Description Включает или отключает системные сообщения о создании, смене статуса и переназначении привязанных к чату задач. Требует права edit_chat в чате
+// @Tags chats
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param chat_id path string true "UUID чата"
+// @Param settings body ac.UpdateTaskEventSettingsRequest true "Настройки событий задач"
+// @Success 200 {object} ac.TaskEventSettings "Настройки сохранены"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или UUID чата"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/task-event-settings/{chat_id} [put]
+func (h *ChatHandler) UpdateTaskEventSettings(c *gin.Context) {
+	chatID, err := uuid.Parse(c.Param("chat_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chat ID"})
+		return
+	}
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req ac.UpdateTaskEventSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.chatController.UpdateTaskEventSettings(chatID, userID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"apiService/internal/controllers"
+	"apiService/internal/custom_errors"
 	"apiService/internal/dto"
+	at "common/contracts/api-task"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -26,6 +29,38 @@ func getUserIDFromTaskContext(c *gin.Context) (uuid.UUID, error) {
 	return userID.(uuid.UUID), nil
 }
 
+func getPermissionsFromTaskContext(c *gin.Context) []string {
+	perms, _ := c.Get("permissions")
+	permissions, _ := perms.([]string)
+	return permissions
+}
+
+// respondTaskServiceError пробрасывает клиенту 4xx-ответы taskService, остальные ошибки считает внутренними
+func respondTaskServiceError(c *gin.Context, err error) {
+	var taskErr *custom_errors.TaskServiceError
+	if errors.As(err, &taskErr) && taskErr.StatusCode >= 400 && taskErr.StatusCode < 500 {
+		c.JSON(taskErr.StatusCode, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// parseTaskListPagination разбирает limit и offset; при ошибке сам отвечает клиенту
+func parseTaskListPagination(c *gin.Context) (int, int, bool) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return 0, 0, false
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+		return 0, 0, false
+	}
+	return limit, offset, true
+}
+
 // CreateTask Создание новой задачи
 // @Summary Создать новую задачу
 // @Description Создает новую задачу с указанными параметрами, исполнителем и прикрепленными файлами
@@ -37,10 +72,17 @@ func getUserIDFromTaskContext(c *gin.Context) (uuid.UUID, error) {
 // @Param description formData string false "Описание задачи"
 // @Param executor_id formData string false "UUID исполнителя задачи"
 // @Param chat_id formData string false "UUID чата, связанного с задачей"
+// @Param workflow_id formData int false "ID workflow; стартовым станет его первый статус"
+// @Param parent_task_id formData int false "ID родительской задачи"
+// @Param priority formData string false "Приоритет задачи" Enums(low, normal, high, urgent) default(normal)
+// @Param start_at formData string false "Дата начала (RFC3339)"
+// @Param due_at formData string false "Срок выполнения (RFC3339)"
+// @Param estimate_minutes formData int false "Оценка трудозатрат в минутах"
 // @Param files formData []file false "Прикрепленные файлы"
 // @Success 201 {object} map[string]interface{} "Задача успешно создана"
-// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос, дата начала позже срока или родительская задача не найдена"
 // @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Пользователь не состоит в чате задачи"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /tasks [post]
 func (h *TaskHandler) CreateTask(c *gin.Context) {
@@ -55,10 +97,51 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if _, _, err := req.ParseSchedule(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	task, err := h.taskController.CreateTask(&req, userID)
+	task, err := h.taskController.CreateTask(&req, userID, getPermissionsFromTaskContext(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
+// CreateTaskFromMessage Создание задачи из сообщения чата
+// @Summary Создать задачу из сообщения чата
+// @Description Создает задачу по сообщению чата: название (если не передано) берется из первой строки сообщения, описание - из всего текста, вложения сообщения прикрепляются к задаче, задача привязывается к чату. Требуется право view_messages в чате сообщения. В чат публикуется системное сообщение со ссылкой на задачу
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body at.CreateTaskFromMessageRequest true "Сообщение, исполнитель и параметры задачи"
+// @Success 201 {object} at.TaskResponse "Задача успешно создана"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос, workflow не найден или дата начала позже срока"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет права view_messages в чате сообщения"
+// @Failure 404 {object} map[string]interface{} "Сообщение не найдено"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/from-message [post]
+func (h *TaskHandler) CreateTaskFromMessage(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req at.CreateTaskFromMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.taskController.CreateTaskFromMessage(&req, userID)
+	if err != nil {
+		respondTaskServiceError(c, err)
 		return
 	}
 
@@ -67,7 +150,7 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 
 // UpdateTaskStatus Обновление статуса задачи
 // @Summary Обновить статус задачи
-// @Description Изменяет статус задачи на указанный
+// @Description Изменяет статус задачи на указанный. Переход должен быть разрешён workflow задачи для роли пользователя
 // @Tags tasks
 // @Produce json
 // @Security BearerAuth
@@ -75,9 +158,18 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 // @Param status_id path int true "ID статуса"
 // @Success 200 "Статус задачи успешно обновлен"
 // @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или статуса"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Переход не разрешён для роли пользователя"
+// @Failure 409 {object} map[string]interface{} "Переход между статусами не предусмотрен workflow"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /tasks/{task_id}/status/{status_id} [patch]
 func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
 	taskID, err := strconv.Atoi(c.Param("task_id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
@@ -90,9 +182,9 @@ func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
 		return
 	}
 
-	err = h.taskController.UpdateTaskStatus(taskID, statusID)
+	err = h.taskController.UpdateTaskStatus(taskID, statusID, userID, getPermissionsFromTaskContext(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondTaskServiceError(c, err)
 		return
 	}
 
@@ -101,25 +193,33 @@ func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
 
 // GetTaskByID Получение задачи по ID
 // @Summary Получить задачу по ID
-// @Description Возвращает информацию о задаче и прикрепленных файлах по ID
+// @Description Возвращает информацию о задаче и прикрепленных файлах по ID. Задача видна её создателю, исполнителю и соисполнителям, участникам её чата и пользователям с правом manage_all_tasks; для остальных она не найдена
 // @Tags tasks
 // @Produce json
 // @Security BearerAuth
 // @Param task_id path int true "ID задачи"
 // @Success 200 {object} map[string]interface{} "Информация о задаче"
 // @Failure 400 {object} map[string]interface{} "Некорректный ID задачи"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /tasks/{task_id} [get]
 func (h *TaskHandler) GetTaskByID(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
 	taskID, err := strconv.Atoi(c.Param("task_id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
 		return
 	}
 
-	task, err := h.taskController.GetTaskByID(taskID)
+	task, err := h.taskController.GetTaskByID(taskID, userID, getPermissionsFromTaskContext(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondTaskServiceError(c, err)
 		return
 	}
 
@@ -128,18 +228,32 @@ func (h *TaskHandler) GetTaskByID(c *gin.Context) {
 
 // GetUserTasks Получение списка задач пользователя
 // @Summary Получить список задач пользователя
-// @Description Возвращает список задач указанного пользователя с пагинацией
+// @Description Возвращает список задач указанного пользователя с пагинацией, фильтрами по приоритету и сроку и сортировкой
 // @Tags tasks
 // @Produce json
 // @Security BearerAuth
 // @Param user_id path string true "UUID пользователя"
 // @Param limit query int false "Количество задач на странице" default(20) maximum(100)
 // @Param offset query int false "Смещение для пагинации" default(0)
+// @Param priority query string false "Приоритеты через запятую: low, normal, high, urgent"
+// @Param label query string false "ID меток через запятую; задача должна иметь хотя бы одну из них"
+// @Param due_from query string false "Срок не раньше (RFC3339)"
+// @Param due_to query string false "Срок не позже (RFC3339)"
+// @Param overdue query bool false "Только незакрытые задачи с истёкшим сроком"
+// @Param sort_by query string false "Поле сортировки" Enums(created_at, due_at, start_at, priority) default(created_at)
+// @Param order query string false "Направление сортировки" Enums(asc, desc) default(desc)
 // @Success 200 {array} map[string]interface{} "Список задач пользователя"
-// @Failure 400 {object} map[string]interface{} "Некорректный UUID пользователя или параметры пагинации"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID пользователя, параметры пагинации или фильтры"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /users/{user_id}/tasks [get]
 func (h *TaskHandler) GetUserTasks(c *gin.Context) {
+	actorID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
 	userID := c.Param("user_id")
 	if userID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "user ID is required"})
@@ -158,112 +272,2352 @@ func (h *TaskHandler) GetUserTasks(c *gin.Context) {
 		return
 	}
 
-	tasks, err := h.taskController.GetUserTasks(userID, limit, offset)
+	var filter dto.TaskListFilterGateway
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tasks, err := h.taskController.GetUserTasks(userID, &filter, limit, offset, actorID, getPermissionsFromTaskContext(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondTaskServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, tasks)
 }
 
-// GetAllStatuses Получение всех статусов задач
-// @Summary Получить все статусы задач
-// @Description Возвращает список всех доступных статусов задач
+// SearchTasks Поиск задач
+// @Summary Поиск задач
+// @Description Ищет задачи по набору статусов, создателю, исполнителю, наблюдателю, чату, приоритету, диапазонам дат и полнотекстовому запросу по названию и описанию среди задач, видимых пользователю. Возвращает общее число найденных задач и курсор следующей страницы; курсор действителен только для той же сортировки
 // @Tags tasks
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} map[string]interface{} "Список статусов задач"
+// @Param q query string false "Полнотекстовый запрос по названию и описанию"
+// @Param status query string false "ID статусов через запятую"
+// @Param creator_id query string false "UUID создателя"
+// @Param executor_id query string false "UUID исполнителя или соисполнителя"
+// @Param watcher_id query string false "UUID наблюдателя"
+// @Param chat_id query string false "UUID чата"
+// @Param priority query string false "Приоритеты через запятую: low, normal, high, urgent"
+// @Param label query string false "ID меток через запятую; задача должна иметь хотя бы одну из них"
+// @Param due_from query string false "Срок не раньше (RFC3339)"
+// @Param due_to query string false "Срок не позже (RFC3339)"
+// @Param start_from query string false "Дата начала не раньше (RFC3339)"
+// @Param start_to query string false "Дата начала не позже (RFC3339)"
+// @Param created_from query string false "Создана не раньше (RFC3339)"
+// @Param created_to query string false "Создана не позже (RFC3339)"
+// @Param overdue query bool false "Только незакрытые задачи с истёкшим сроком"
+// @Param sort_by query string false "Поле сортировки" Enums(created_at, updated_at, due_at, start_at, priority, status, title) default(created_at)
+// @Param order query string false "Направление сортировки" Enums(asc, desc) default(desc)
+// @Param limit query int false "Количество задач на странице" default(20) maximum(100)
+// @Param cursor query string false "Курсор следующей страницы из nextCursor"
+// @Success 200 {object} map[string]interface{} "Страница найденных задач: tasks, total, nextCursor"
+// @Failure 400 {object} map[string]interface{} "Некорректные фильтры, сортировка или курсор"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
-// @Router /tasks/statuses [get]
-func (h *TaskHandler) GetAllStatuses(c *gin.Context) {
-	statuses, err := h.taskController.GetAllStatuses()
+// @Router /tasks/search [get]
+func (h *TaskHandler) SearchTasks(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, statuses)
+	var query dto.TaskQueryGateway
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.taskController.SearchTasks(&query, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
 }
 
-// CreateStatus Создание нового статуса задачи
-// @Summary Создать новый статус задачи
-// @Description Создает новый статус задачи с указанным названием
+// GetTaskBoard Канбан-доска задач
+// @Summary Получить доску задач
+// @Description Возвращает задачи чата или, без chat_id, задачи текущего пользователя, сгруппированные по статусам. Колонки идут в порядке статусов workflow по умолчанию, остальные статусы - после них; карточки в колонке упорядочены по rank
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param chat_id query string false "UUID чата; по умолчанию - задачи текущего пользователя"
+// @Param limit query int false "Максимум карточек в колонке" default(50) maximum(200)
+// @Success 200 {object} map[string]interface{} "Доска задач: columns со statusId, status, total и tasks"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/board [get]
+func (h *TaskHandler) GetTaskBoard(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var query dto.TaskBoardQueryGateway
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if query.ChatID == "" {
+		query.ExecutorID = userID.String()
+	}
+
+	board, err := h.taskController.GetTaskBoard(&query, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+// MoveTask Перенос карточки на доске
+// @Summary Перенести карточку задачи
+// @Description Ставит задачу в колонку status_id между карточками after_task_id (выше) и before_task_id (ниже); без after_task_id - в начало колонки, без before_task_id - в конец, без обоих - в конец колонки. Смена статуса подчиняется правилам workflow; перестановка внутри колонки доступна создателю, исполнителю и пользователям с правом manage_all_tasks
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param request body dto.CreateStatusRequestGateway true "Данные для создания статуса"
-// @Success 201 {object} map[string]interface{} "Статус успешно создан"
-// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Param task_id path int true "ID задачи"
+// @Param request body at.MoveTaskRequest true "Новая колонка и соседние карточки"
+// @Success 204 "Карточка перенесена"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или статус не найден"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на перенос"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 409 {object} map[string]interface{} "Переход не предусмотрен workflow, задачу блокируют незакрытые задачи или доска устарела"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
-// @Router /tasks/statuses [post]
-func (h *TaskHandler) CreateStatus(c *gin.Context) {
-	var req dto.CreateStatusRequestGateway
+// @Router /tasks/{task_id}/move [post]
+func (h *TaskHandler) MoveTask(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	var req at.MoveTaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	status, err := h.taskController.CreateStatus(req.Name)
+	if err := h.taskController.MoveTask(taskID, &req, userID, getPermissionsFromTaskContext(c)); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateTask Редактирование задачи
+// @Summary Редактировать задачу
+// @Description Частично обновляет задачу: название, описание, исполнителя, чат, вложения, приоритет, сроки и оценку трудозатрат. Доступно создателю, исполнителю и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param title formData string false "Новое название задачи"
+// @Param description formData string false "Новое описание задачи"
+// @Param executor_id formData string false "UUID нового исполнителя (пустая строка снимает исполнителя)"
+// @Param chat_id formData string false "UUID чата (пустая строка отвязывает задачу от чата)"
+// @Param files formData []file false "Новые вложения"
+// @Param remove_file_ids formData []int false "ID вложений, которые нужно открепить"
+// @Param priority formData string false "Новый приоритет" Enums(low, normal, high, urgent)
+// @Param start_at formData string false "Дата начала в RFC3339 (пустая строка снимает дату)"
+// @Param due_at formData string false "Срок в RFC3339 (пустая строка снимает срок)"
+// @Param estimate_minutes formData string false "Оценка трудозатрат в минутах (пустая строка снимает оценку)"
+// @Param parent_task_id formData string false "ID родительской задачи (пустая строка делает задачу задачей верхнего уровня)"
+// @Success 200 {object} map[string]interface{} "Задача успешно обновлена"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос, дата начала позже срока или родительская задача не найдена"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 409 {object} map[string]interface{} "Родительская задача - сама задача или её подзадача"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id} [patch]
+func (h *TaskHandler) UpdateTask(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, status)
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	var req dto.UpdateTaskRequestGateway
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if _, _, err := req.ParseUUIDs(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := req.ApplySchedule(&at.UpdateTaskRequest{}); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := req.ApplyParent(&at.UpdateTaskRequest{}); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := req.ApplyEstimate(&at.UpdateTaskRequest{}); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.taskController.UpdateTask(taskID, &req, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
 }
 
-// GetStatusByID Получение статуса задачи по ID
-// @Summary Получить статус задачи по ID
-// @Description Возвращает информацию о статусе задачи по ID
+// DeleteTask Удаление задачи
+// @Summary Удалить задачу
+// @Description Мягко удаляет задачу. Доступно создателю, исполнителю и пользователям с правом manage_all_tasks
 // @Tags tasks
 // @Produce json
 // @Security BearerAuth
-// @Param status_id path int true "ID статуса"
-// @Success 200 {object} map[string]interface{} "Информация о статусе"
-// @Failure 400 {object} map[string]interface{} "Некорректный ID статуса"
+// @Param task_id path int true "ID задачи"
+// @Success 204 "Задача успешно удалена"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на удаление задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
-// @Router /tasks/statuses/{status_id} [get]
-func (h *TaskHandler) GetStatusByID(c *gin.Context) {
-	statusID, err := strconv.Atoi(c.Param("status_id"))
+// @Router /tasks/{task_id} [delete]
+func (h *TaskHandler) DeleteTask(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status ID"})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
-	status, err := h.taskController.GetStatusByID(statusID)
+	taskID, err := strconv.Atoi(c.Param("task_id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
 		return
 	}
 
-	c.JSON(http.StatusOK, status)
+	if err := h.taskController.DeleteTask(taskID, userID, getPermissionsFromTaskContext(c)); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
 }
 
-// DeleteStatus Удаление статуса задачи
-// @Summary Удалить статус задачи
-// @Description Удаляет статус задачи по ID
+// BulkUpdateTasks Массовая операция над задачами
+// @Summary Изменить несколько задач
+// @Description Меняет статус (status_id), исполнителя (executor_id), добавляет метки (label_ids) или удаляет до 100 задач одним запросом. Каждая задача проверяется как при одиночном изменении, результат возвращается по каждой задаче. С atomic=true изменения применяются одной транзакцией и только если проверку прошли все задачи
 // @Tags tasks
+// @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param status_id path int true "ID статуса"
-// @Success 200 {object} map[string]interface{} "Статус успешно удален"
-// @Failure 400 {object} map[string]interface{} "Некорректный ID статуса"
+// @Param request body at.TaskBulkRequest true "Задачи, действие и его параметр"
+// @Success 200 {object} at.TaskBulkResult "Результат по каждой задаче"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или не задан параметр действия"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Статус или метка не найдены"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
-// @Router /tasks/statuses/{status_id} [delete]
-func (h *TaskHandler) DeleteStatus(c *gin.Context) {
-	statusID, err := strconv.Atoi(c.Param("status_id"))
+// @Router /tasks/bulk [post]
+func (h *TaskHandler) BulkUpdateTasks(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status ID"})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req at.TaskBulkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	err = h.taskController.DeleteStatus(statusID)
+	result, err := h.taskController.BulkUpdateTasks(userID, getPermissionsFromTaskContext(c), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondTaskServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "status deleted successfully"})
+	c.JSON(http.StatusOK, result)
+}
+
+// GetCreatedTasks Получение задач, созданных текущим пользователем
+// @Summary Получить задачи, созданные мной
+// @Description Возвращает список задач, созданных текущим пользователем, с пагинацией
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Количество задач на странице" default(20) maximum(100)
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Success 200 {array} map[string]interface{} "Список задач"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры пагинации"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/created [get]
+func (h *TaskHandler) GetCreatedTasks(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit, offset, ok := parseTaskListPagination(c)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.taskController.GetCreatedTasks(userID.String(), limit, offset, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetChatTasks Получение задач чата
+// @Summary Получить задачи чата
+// @Description Возвращает список задач, привязанных к чату, с пагинацией
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param chat_id path string true "UUID чата"
+// @Param limit query int false "Количество задач на странице" default(20) maximum(100)
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Success 200 {array} map[string]interface{} "Список задач"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID чата или параметры пагинации"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/chat/{chat_id} [get]
+func (h *TaskHandler) GetChatTasks(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	chatID, err := uuid.Parse(c.Param("chat_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chat ID"})
+		return
+	}
+
+	limit, offset, ok := parseTaskListPagination(c)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.taskController.GetChatTasks(chatID.String(), limit, offset, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetTaskHistory Получение истории задачи
+// @Summary Получить историю задачи
+// @Description Возвращает события задачи: создание, смена статуса, переназначение, правки, вложения и комментарии, с автором и значениями до и после
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param limit query int false "Количество событий на странице" default(20) maximum(100)
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Success 200 {array} map[string]interface{} "История задачи"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или параметры пагинации"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/history [get]
+func (h *TaskHandler) GetTaskHistory(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	limit, offset, ok := parseTaskListPagination(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.taskController.GetTaskHistory(taskID, limit, offset, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// CreateTaskComment Добавление комментария к задаче
+// @Summary Добавить комментарий к задаче
+// @Description Добавляет комментарий с вложениями. Упомянутые через @username пользователи получают уведомление об упоминании, участники задачи - о новом комментарии
+// @Tags tasks
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param body formData string true "Текст комментария"
+// @Param files formData []file false "Вложения"
+// @Success 201 {object} map[string]interface{} "Комментарий добавлен"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/comments [post]
+func (h *TaskHandler) CreateTaskComment(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	var req dto.CreateTaskCommentRequestGateway
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.taskController.CreateTaskComment(taskID, &req, userID)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// UpdateTaskComment Редактирование комментария
+// @Summary Редактировать комментарий
+// @Description Изменяет текст и вложения комментария. Доступно только автору
+// @Tags tasks
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param comment_id path int true "ID комментария"
+// @Param body formData string false "Новый текст комментария"
+// @Param files formData []file false "Новые вложения"
+// @Param remove_file_ids formData []int false "ID вложений, которые нужно открепить"
+// @Success 200 {object} map[string]interface{} "Комментарий обновлён"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Комментарий может редактировать только автор"
+// @Failure 404 {object} map[string]interface{} "Задача или комментарий не найдены"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/comments/{comment_id} [patch]
+func (h *TaskHandler) UpdateTaskComment(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, commentID, ok := parseTaskCommentPath(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTaskCommentRequestGateway
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.taskController.UpdateTaskComment(taskID, commentID, &req, userID)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteTaskComment Удаление комментария
+// @Summary Удалить комментарий
+// @Description Удаляет комментарий. Доступно автору и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param comment_id path int true "ID комментария"
+// @Success 204 "Комментарий удалён"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или комментария"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на удаление комментария"
+// @Failure 404 {object} map[string]interface{} "Комментарий не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/comments/{comment_id} [delete]
+func (h *TaskHandler) DeleteTaskComment(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, commentID, ok := parseTaskCommentPath(c)
+	if !ok {
+		return
+	}
+
+	if err := h.taskController.DeleteTaskComment(taskID, commentID, userID, getPermissionsFromTaskContext(c)); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetTaskComments Получение комментариев задачи
+// @Summary Получить комментарии задачи
+// @Description Возвращает комментарии задачи с вложениями и упоминаниями, старые первыми
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param limit query int false "Количество комментариев на странице" default(20) maximum(100)
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Success 200 {array} map[string]interface{} "Комментарии задачи"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или параметры пагинации"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/comments [get]
+func (h *TaskHandler) GetTaskComments(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	limit, offset, ok := parseTaskListPagination(c)
+	if !ok {
+		return
+	}
+
+	comments, err := h.taskController.GetTaskComments(taskID, limit, offset, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// GetTaskTree Получение дерева подзадач
+// @Summary Получить дерево подзадач
+// @Description Возвращает задачу со всеми подзадачами. Прогресс закрытой задачи - 100%, открытой - средний прогресс её подзадач
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Success 200 {object} map[string]interface{} "Дерево подзадач"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/tree [get]
+func (h *TaskHandler) GetTaskTree(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	tree, err := h.taskController.GetTaskTree(taskID, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// GetTaskDependencies Получение графа зависимостей
+// @Summary Получить граф зависимостей задачи
+// @Description Возвращает задачи, которые прямо или транзитивно блокируют задачу или блокируются ею, и связи между ними
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Success 200 {object} map[string]interface{} "Граф зависимостей"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/dependencies [get]
+func (h *TaskHandler) GetTaskDependencies(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	graph, err := h.taskController.GetTaskDependencies(taskID, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, graph)
+}
+
+// AddTaskDependency Добавление блокирующей задачи
+// @Summary Добавить блокирующую задачу
+// @Description Задача blocker_task_id начинает блокировать задачу из пути: пока она открыта, заблокированную задачу нельзя закрыть. Доступно создателю, исполнителю и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID заблокированной задачи"
+// @Param dependency body dto.AddTaskDependencyRequestGateway true "Блокирующая задача"
+// @Success 204 "Зависимость добавлена"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 409 {object} map[string]interface{} "Зависимость образует цикл"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/dependencies [post]
+func (h *TaskHandler) AddTaskDependency(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	var req dto.AddTaskDependencyRequestGateway
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.taskController.AddTaskDependency(taskID, req.BlockerTaskID, userID, getPermissionsFromTaskContext(c)); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveTaskDependency Удаление блокирующей задачи
+// @Summary Удалить блокирующую задачу
+// @Description Задача blocker_task_id перестаёт блокировать задачу из пути. Права те же, что и на добавление
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID заблокированной задачи"
+// @Param blocker_task_id path int true "ID блокирующей задачи"
+// @Success 204 "Зависимость удалена"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача или зависимость не найдены"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/dependencies/{blocker_task_id} [delete]
+func (h *TaskHandler) RemoveTaskDependency(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	blockerTaskID, err := strconv.Atoi(c.Param("blocker_task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid blocker task ID"})
+		return
+	}
+
+	if err := h.taskController.RemoveTaskDependency(taskID, blockerTaskID, userID, getPermissionsFromTaskContext(c)); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseTaskCommentPath разбирает ID задачи и комментария из пути; при ошибке сам отвечает клиенту
+func parseTaskCommentPath(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return 0, 0, false
+	}
+
+	commentID, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		return 0, 0, false
+	}
+	return taskID, commentID, true
+}
+
+// GetMyActivity Получение ленты активности текущего пользователя
+// @Summary Получить мою ленту активности
+// @Description Объединяет события задач, где пользователь создатель или исполнитель, и его собственные действия, новые первыми
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Количество событий на странице" default(20) maximum(100)
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Success 200 {array} map[string]interface{} "Лента активности"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры пагинации"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/activity [get]
+func (h *TaskHandler) GetMyActivity(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit, offset, ok := parseTaskListPagination(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.taskController.GetUserActivity(userID.String(), limit, offset, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// GetAllStatuses Получение всех статусов задач
+// @Summary Получить все статусы задач
+// @Description Возвращает список всех доступных статусов задач
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} map[string]interface{} "Список статусов задач"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/statuses [get]
+func (h *TaskHandler) GetAllStatuses(c *gin.Context) {
+	statuses, err := h.taskController.GetAllStatuses()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, statuses)
+}
+
+// CreateStatus Создание нового статуса задачи
+// @Summary Создать новый статус задачи
+// @Description Создает новый статус задачи с указанным названием
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateStatusRequestGateway true "Данные для создания статуса"
+// @Success 201 {object} map[string]interface{} "Статус успешно создан"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/statuses [post]
+func (h *TaskHandler) CreateStatus(c *gin.Context) {
+	var req dto.CreateStatusRequestGateway
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status, err := h.taskController.CreateStatus(req.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, status)
+}
+
+// GetStatusByID Получение статуса задачи по ID
+// @Summary Получить статус задачи по ID
+// @Description Возвращает информацию о статусе задачи по ID
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param status_id path int true "ID статуса"
+// @Success 200 {object} map[string]interface{} "Информация о статусе"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID статуса"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/statuses/{status_id} [get]
+func (h *TaskHandler) GetStatusByID(c *gin.Context) {
+	statusID, err := strconv.Atoi(c.Param("status_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status ID"})
+		return
+	}
+
+	status, err := h.taskController.GetStatusByID(statusID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// GetStatusUsage Использование статуса задачи
+// @Summary Получить использование статуса задачи
+// @Description Возвращает число задач и удалённых задач в статусе и workflow, в которых он используется. protected - статус нельзя удалить
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param status_id path int true "ID статуса"
+// @Success 200 {object} at.TaskStatusUsage "Использование статуса"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID статуса"
+// @Failure 404 {object} map[string]interface{} "Статус не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/statuses/{status_id}/usage [get]
+func (h *TaskHandler) GetStatusUsage(c *gin.Context) {
+	statusID, err := strconv.Atoi(c.Param("status_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status ID"})
+		return
+	}
+
+	usage, err := h.taskController.GetStatusUsage(statusID)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// DeleteStatus Удаление статуса задачи
+// @Summary Удалить статус задачи
+// @Description Удаляет статус задачи по ID. Статус "created" и статусы, используемые в workflow, удалить нельзя. Задачи статуса, включая удалённые, переносятся в статус replacement_id с записью в историю; без replacement_id статус с задачами не удаляется
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param status_id path int true "ID статуса"
+// @Param replacement_id query int false "ID статуса, в который переносятся задачи удаляемого статуса"
+// @Success 200 {object} map[string]interface{} "Статус успешно удален"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID статуса или статус для переноса"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Статус не найден"
+// @Failure 409 {object} map[string]interface{} "Статус используется и не может быть удалён"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/statuses/{status_id} [delete]
+func (h *TaskHandler) DeleteStatus(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	statusID, err := strconv.Atoi(c.Param("status_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status ID"})
+		return
+	}
+
+	var replacementID *int
+	if raw := c.Query("replacement_id"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid replacement_id"})
+			return
+		}
+		replacementID = &parsed
+	}
+
+	err = h.taskController.DeleteStatus(statusID, replacementID, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "status deleted successfully"})
+}
+
+// GetTaskLabels Получение меток задачи
+// @Summary Получить метки задачи
+// @Description Возвращает метки, назначенные задаче
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Success 200 {array} map[string]interface{} "Метки задачи"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/labels [get]
+func (h *TaskHandler) GetTaskLabels(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	labels, err := h.taskController.GetTaskLabels(taskID, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, labels)
+}
+
+// AddTaskLabel Назначение метки задаче
+// @Summary Назначить метку задаче
+// @Description Назначает метку задаче; повторное назначение ничего не меняет. Доступно создателю, исполнителю и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param label_id path int true "ID метки"
+// @Success 204 "Метка назначена"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или метки"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача или метка не найдены"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/labels/{label_id} [post]
+func (h *TaskHandler) AddTaskLabel(c *gin.Context) {
+	h.changeTaskLabel(c, h.taskController.AddTaskLabel)
+}
+
+// RemoveTaskLabel Снятие метки с задачи
+// @Summary Снять метку с задачи
+// @Description Снимает метку с задачи. Права те же, что и на назначение
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param label_id path int true "ID метки"
+// @Success 204 "Метка снята"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или метки"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача или метка не найдены, либо метка не назначена задаче"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/labels/{label_id} [delete]
+func (h *TaskHandler) RemoveTaskLabel(c *gin.Context) {
+	h.changeTaskLabel(c, h.taskController.RemoveTaskLabel)
+}
+
+// AddTaskAssignee Назначение соисполнителя задачи
+// @Summary Назначить соисполнителя
+// @Description Добавляет пользователя в соисполнители задачи и отправляет ему уведомление; повторное назначение и назначение основного исполнителя ничего не меняют. Доступно создателю, исполнителям задачи и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param user_id path string true "UUID соисполнителя"
+// @Success 204 "Соисполнитель назначен"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/assignees/{user_id} [post]
+func (h *TaskHandler) AddTaskAssignee(c *gin.Context) {
+	h.changeTaskMember(c, h.taskController.AddTaskAssignee)
+}
+
+// RemoveTaskAssignee Снятие соисполнителя задачи
+// @Summary Снять соисполнителя
+// @Description Убирает пользователя из соисполнителей задачи. Права те же, что и на назначение
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param user_id path string true "UUID соисполнителя"
+// @Success 204 "Соисполнитель снят"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена или пользователь не является соисполнителем"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/assignees/{user_id} [delete]
+func (h *TaskHandler) RemoveTaskAssignee(c *gin.Context) {
+	h.changeTaskMember(c, h.taskController.RemoveTaskAssignee)
+}
+
+// AddTaskWatcher Подписка на задачу
+// @Summary Добавить наблюдателя
+// @Description Подписывает пользователя на уведомления о смене статуса и комментариях задачи. Подписаться сам может любой пользователь, подписать другого - создатель, исполнители задачи и пользователи с правом manage_all_tasks
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param user_id path string true "UUID наблюдателя"
+// @Success 204 "Наблюдатель добавлен"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав подписывать других пользователей"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/watchers/{user_id} [post]
+func (h *TaskHandler) AddTaskWatcher(c *gin.Context) {
+	h.changeTaskMember(c, h.taskController.AddTaskWatcher)
+}
+
+// RemoveTaskWatcher Отписка от задачи
+// @Summary Убрать наблюдателя
+// @Description Отписывает пользователя от уведомлений задачи. Права те же, что и на подписку
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param user_id path string true "UUID наблюдателя"
+// @Success 204 "Наблюдатель удалён"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав отписывать других пользователей"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена или пользователь не наблюдает за ней"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/watchers/{user_id} [delete]
+func (h *TaskHandler) RemoveTaskWatcher(c *gin.Context) {
+	h.changeTaskMember(c, h.taskController.RemoveTaskWatcher)
+}
+
+// StartTaskTimer Запуск таймера
+// @Summary Запустить таймер
+// @Description Запускает таймер затраченного на задачу времени. Доступно исполнителю и соисполнителям задачи; у пользователя может быть запущен только один таймер
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Success 201 {object} at.TaskTimeLog "Таймер запущен"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Пользователь не является исполнителем задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 409 {object} map[string]interface{} "У пользователя уже запущен таймер"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/time-logs/start [post]
+func (h *TaskHandler) StartTaskTimer(c *gin.Context) {
+	h.changeTaskTimer(c, http.StatusCreated, h.taskController.StartTaskTimer)
+}
+
+// StopTaskTimer Остановка таймера
+// @Summary Остановить таймер
+// @Description Останавливает запущенный пользователем таймер задачи; длительность округляется до минут
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Success 200 {object} at.TaskTimeLog "Таймер остановлен"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "У пользователя нет запущенного таймера на этой задаче"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/time-logs/stop [post]
+func (h *TaskHandler) StopTaskTimer(c *gin.Context) {
+	h.changeTaskTimer(c, http.StatusOK, h.taskController.StopTaskTimer)
+}
+
+// changeTaskTimer разбирает пользователя и ID задачи из запроса и запускает или останавливает таймер
+func (h *TaskHandler) changeTaskTimer(c *gin.Context, status int, change func(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error)) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	log, err := change(taskID, userID)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(status, log)
+}
+
+// CreateTaskTimeLog Ручная запись затраченного времени
+// @Summary Списать время вручную
+// @Description Добавляет запись о затраченном времени длительностью minutes начиная с started_at; запись не может заканчиваться в будущем. Доступно исполнителю и соисполнителям задачи
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param request body at.CreateTaskTimeLogRequest true "Интервал и описание работы"
+// @Success 201 {object} at.TaskTimeLog "Время списано"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Пользователь не является исполнителем задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/time-logs [post]
+func (h *TaskHandler) CreateTaskTimeLog(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	var req at.CreateTaskTimeLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	log, err := h.taskController.CreateTaskTimeLog(taskID, &req, userID)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, log)
+}
+
+// UpdateTaskTimeLog Изменение записи времени
+// @Summary Изменить запись времени
+// @Description Изменяет начало, длительность и описание записи. Доступно автору записи и пользователям с правом manage_all_tasks; длительность запущенного таймера изменить нельзя
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param log_id path int true "ID записи"
+// @Param request body at.UpdateTaskTimeLogRequest true "Изменяемые поля записи"
+// @Success 200 {object} at.TaskTimeLog "Запись обновлена"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение записи"
+// @Failure 404 {object} map[string]interface{} "Запись не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/time-logs/{log_id} [patch]
+func (h *TaskHandler) UpdateTaskTimeLog(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, logID, ok := parseTaskTimeLogPath(c)
+	if !ok {
+		return
+	}
+
+	var req at.UpdateTaskTimeLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	log, err := h.taskController.UpdateTaskTimeLog(taskID, logID, &req, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, log)
+}
+
+// DeleteTaskTimeLog Удаление записи времени
+// @Summary Удалить запись времени
+// @Description Удаляет запись или запущенный таймер. Доступно автору записи и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param log_id path int true "ID записи"
+// @Success 204 "Запись удалена"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или записи"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на удаление записи"
+// @Failure 404 {object} map[string]interface{} "Запись не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/time-logs/{log_id} [delete]
+func (h *TaskHandler) DeleteTaskTimeLog(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, logID, ok := parseTaskTimeLogPath(c)
+	if !ok {
+		return
+	}
+
+	if err := h.taskController.DeleteTaskTimeLog(taskID, logID, userID, getPermissionsFromTaskContext(c)); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetTaskTimeLogs Получение записей времени задачи
+// @Summary Получить записи времени задачи
+// @Description Возвращает записи затраченного на задачу времени, включая запущенные таймеры, последние первыми
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param limit query int false "Количество записей (1-100)" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {array} at.TaskTimeLog "Записи времени"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или параметры пагинации"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/time-logs [get]
+func (h *TaskHandler) GetTaskTimeLogs(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	limit, offset, ok := parseTaskListPagination(c)
+	if !ok {
+		return
+	}
+
+	logs, err := h.taskController.GetTaskTimeLogs(taskID, limit, offset, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, logs)
+}
+
+// GetTimeSpent Отчёт о затраченном времени
+// @Summary Получить затраченное время
+// @Description Суммирует остановленные записи времени по задачам (вместе с оценкой), пользователям или чатам. Учитываются записи, начатые в [from, to); запущенные таймеры не учитываются
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param group_by query string false "Группировка" Enums(task, user, chat) default(task)
+// @Param from query string false "Начало периода (RFC3339)"
+// @Param to query string false "Конец периода, не включительно (RFC3339)"
+// @Param task_id query int false "Только записи задачи"
+// @Param user_id query string false "Только записи пользователя"
+// @Param chat_id query string false "Только записи задач чата"
+// @Success 200 {array} at.TimeSpent "Затраченное время, больше всего первыми"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры отчёта"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/time-spent [get]
+func (h *TaskHandler) GetTimeSpent(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var query dto.TimeSpentQueryGateway
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rows, err := h.taskController.GetTimeSpent(&query, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rows)
+}
+
+// GetTaskThroughput Созданные и завершённые задачи
+// @Summary Получить пропускную способность
+// @Description Считает задачи, созданные и завершённые в каждом дне или неделе периода [from, to), включая периоды без задач. Завершённой считается задача в статусе без исходящих переходов своего workflow. Завершения считаются по истории статусов и обновляются периодически. По умолчанию период - 30 дней до текущего момента, не длиннее 366 дней. Учитываются только задачи, которые видит пользователь; отчёт по чату доступен его участникам и пользователям с правом manage_all_tasks. Требуется право view_task_reports
+// @Tags tasks
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param interval query string false "Шаг периодов (недели начинаются с понедельника)" Enums(day, week) default(day)
+// @Param from query string false "Начало периода (RFC3339)"
+// @Param to query string false "Конец периода, не включительно (RFC3339)"
+// @Param chat_id query string false "Только задачи чата"
+// @Param format query string false "Формат ответа" Enums(json, csv) default(json)
+// @Success 200 {array} at.TaskThroughput "Задачи по периодам"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры отчёта"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет права view_task_reports или пользователь не состоит в чате"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/reports/throughput [get]
+func (h *TaskHandler) GetTaskThroughput(c *gin.Context) {
+	h.respondTaskReport(c, "throughput")
+}
+
+// GetTaskStatusDurations Время в статусах
+// @Summary Получить среднее время в статусах
+// @Description Усредняет по истории смены статусов длительность пребываний задач в каждом статусе, закончившихся в [from, to). Удалённые статусы возвращаются без statusID. Данные обновляются периодически. Учитываются только задачи, которые видит пользователь; отчёт по чату доступен его участникам и пользователям с правом manage_all_tasks. Требуется право view_task_reports
+// @Tags tasks
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param from query string false "Начало периода (RFC3339), по умолчанию 30 дней до to"
+// @Param to query string false "Конец периода, не включительно (RFC3339), по умолчанию текущее время"
+// @Param chat_id query string false "Только задачи чата"
+// @Param format query string false "Формат ответа" Enums(json, csv) default(json)
+// @Success 200 {array} at.TaskStatusDuration "Среднее время по статусам"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры отчёта"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет права view_task_reports или пользователь не состоит в чате"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/reports/status-durations [get]
+func (h *TaskHandler) GetTaskStatusDurations(c *gin.Context) {
+	h.respondTaskReport(c, "status-durations")
+}
+
+// GetOverdueTasksReport Просроченные задачи по исполнителям
+// @Summary Получить просроченные задачи по исполнителям
+// @Description Считает незакрытые задачи с истёкшим сроком у каждого исполнителя на текущий момент, больше всего просроченных первыми. Учитываются только задачи, которые видит пользователь; отчёт по чату доступен его участникам и пользователям с правом manage_all_tasks. Требуется право view_task_reports
+// @Tags tasks
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param chat_id query string false "Только задачи чата"
+// @Param format query string false "Формат ответа" Enums(json, csv) default(json)
+// @Success 200 {array} at.TaskOverdueCount "Просроченные задачи по исполнителям"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры отчёта"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет права view_task_reports или пользователь не состоит в чате"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/reports/overdue [get]
+func (h *TaskHandler) GetOverdueTasksReport(c *gin.Context) {
+	h.respondTaskReport(c, "overdue")
+}
+
+// GetTaskCumulativeFlow Накопительная диаграмма потока
+// @Summary Получить накопительную диаграмму потока чата
+// @Description Для каждого дня периода [from, to) считает задачи чата в каждом статусе на конец дня (UTC); статусы без задач не возвращаются. Данные обновляются периодически. Учитываются только задачи, которые видит пользователь; отчёт по чату доступен его участникам и пользователям с правом manage_all_tasks. Требуется право view_task_reports
+// @Tags tasks
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param chat_id query string true "ID чата"
+// @Param from query string false "Начало периода (RFC3339), по умолчанию 30 дней до to"
+// @Param to query string false "Конец периода, не включительно (RFC3339), по умолчанию текущее время"
+// @Param format query string false "Формат ответа" Enums(json, csv) default(json)
+// @Success 200 {array} at.TaskCumulativeFlow "Задачи по дням и статусам"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры отчёта"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет права view_task_reports или пользователь не состоит в чате"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/reports/cumulative-flow [get]
+func (h *TaskHandler) GetTaskCumulativeFlow(c *gin.Context) {
+	h.respondTaskReport(c, "cumulative-flow")
+}
+
+// respondTaskReport запрашивает отчёт report у taskService и передаёт его клиенту в полученном формате
+func (h *TaskHandler) respondTaskReport(c *gin.Context, report string) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var query dto.TaskReportQueryGateway
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.taskController.GetTaskReport(report, &query, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	if result.ContentDisposition != "" {
+		c.Header("Content-Disposition", result.ContentDisposition)
+	}
+	c.Data(http.StatusOK, result.ContentType, result.Body)
+}
+
+// parseTaskTimeLogPath разбирает ID задачи и записи времени из пути; при ошибке сам отвечает клиенту
+func parseTaskTimeLogPath(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return 0, 0, false
+	}
+
+	logID, err := strconv.Atoi(c.Param("log_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid time log ID"})
+		return 0, 0, false
+	}
+	return taskID, logID, true
+}
+
+// changeTaskMember разбирает пользователя и ID из запроса и вызывает изменение соисполнителей или наблюдателей
+func (h *TaskHandler) changeTaskMember(c *gin.Context, change func(taskID int, userID, actorID uuid.UUID, permissions []string) error) {
+	actorID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	if err := change(taskID, userID, actorID, getPermissionsFromTaskContext(c)); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// changeTaskLabel разбирает пользователя и ID из запроса и вызывает назначение или снятие метки
+func (h *TaskHandler) changeTaskLabel(c *gin.Context, change func(taskID, labelID int, actorID uuid.UUID, permissions []string) error) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	labelID, err := strconv.Atoi(c.Param("label_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label ID"})
+		return
+	}
+
+	if err := change(taskID, labelID, userID, getPermissionsFromTaskContext(c)); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetAllLabels Получение всех меток задач
+// @Summary Получить все метки
+// @Description Возвращает все метки по названию вместе с числом задач, которым назначена каждая (usageCount)
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} map[string]interface{} "Список меток"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/labels [get]
+func (h *TaskHandler) GetAllLabels(c *gin.Context) {
+	labels, err := h.taskController.GetAllLabels()
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, labels)
+}
+
+// GetLabelByID Получение метки по ID
+// @Summary Получить метку по ID
+// @Description Возвращает метку и число задач, которым она назначена
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param label_id path int true "ID метки"
+// @Success 200 {object} map[string]interface{} "Информация о метке"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID метки"
+// @Failure 404 {object} map[string]interface{} "Метка не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/labels/{label_id} [get]
+func (h *TaskHandler) GetLabelByID(c *gin.Context) {
+	labelID, err := strconv.Atoi(c.Param("label_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label ID"})
+		return
+	}
+
+	label, err := h.taskController.GetLabelByID(labelID)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, label)
+}
+
+// CreateLabel Создание метки задач
+// @Summary Создать метку
+// @Description Создает метку с названием и цветом. Требует права manage_task_labels
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body at.SaveLabelRequest true "Название и цвет (#RGB или #RRGGBB)"
+// @Success 201 {object} map[string]interface{} "Метка успешно создана"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 403 {object} map[string]interface{} "Нет права manage_task_labels"
+// @Failure 409 {object} map[string]interface{} "Метка с таким названием уже существует"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/labels [post]
+func (h *TaskHandler) CreateLabel(c *gin.Context) {
+	var req at.SaveLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	label, err := h.taskController.CreateLabel(&req)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, label)
+}
+
+// UpdateLabel Изменение метки задач
+// @Summary Обновить метку
+// @Description Заменяет название и цвет метки. Требует права manage_task_labels
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param label_id path int true "ID метки"
+// @Param request body at.SaveLabelRequest true "Название и цвет (#RGB или #RRGGBB)"
+// @Success 200 {object} map[string]interface{} "Метка успешно обновлена"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 403 {object} map[string]interface{} "Нет права manage_task_labels"
+// @Failure 404 {object} map[string]interface{} "Метка не найдена"
+// @Failure 409 {object} map[string]interface{} "Метка с таким названием уже существует"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/labels/{label_id} [put]
+func (h *TaskHandler) UpdateLabel(c *gin.Context) {
+	labelID, err := strconv.Atoi(c.Param("label_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label ID"})
+		return
+	}
+
+	var req at.SaveLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	label, err := h.taskController.UpdateLabel(labelID, &req)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, label)
+}
+
+// DeleteLabel Удаление метки задач
+// @Summary Удалить метку
+// @Description Удаляет метку и снимает её со всех задач. Требует права manage_task_labels
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param label_id path int true "ID метки"
+// @Success 204 "Метка успешно удалена"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID метки"
+// @Failure 403 {object} map[string]interface{} "Нет права manage_task_labels"
+// @Failure 404 {object} map[string]interface{} "Метка не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/labels/{label_id} [delete]
+func (h *TaskHandler) DeleteLabel(c *gin.Context) {
+	labelID, err := strconv.Atoi(c.Param("label_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label ID"})
+		return
+	}
+
+	if err := h.taskController.DeleteLabel(labelID); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateTaskRecurrence Создание серии повторяющихся задач
+// @Summary Создать серию повторяющихся задач
+// @Description Создает шаблон задачи с правилом повторения RRULE: FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY (для WEEKLY), BYMONTHDAY (для MONTHLY), COUNT или UNTIL. Экземпляры создаются заранее, исполнитель получает уведомление о каждом. Создателем серии становится текущий пользователь
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body at.SaveTaskRecurrenceRequest true "Шаблон задачи и правило повторения"
+// @Success 201 {object} at.TaskRecurrence "Серия успешно создана"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или правило, workflow не найден"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/recurrences [post]
+func (h *TaskHandler) CreateTaskRecurrence(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req at.SaveTaskRecurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recurrence, err := h.taskController.CreateTaskRecurrence(&req, userID)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, recurrence)
+}
+
+// GetTaskRecurrence Получение серии повторяющихся задач
+// @Summary Получить серию повторяющихся задач
+// @Description Возвращает шаблон задачи, правило повторения и начало следующего ещё не созданного экземпляра. Серию видят её создатель, исполнитель, участники её чата и пользователи с правом manage_all_tasks
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param recurrence_id path int true "ID серии"
+// @Success 200 {object} at.TaskRecurrence "Серия"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID серии"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Серия не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/recurrences/{recurrence_id} [get]
+func (h *TaskHandler) GetTaskRecurrence(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	recurrenceID, err := strconv.Atoi(c.Param("recurrence_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recurrence ID"})
+		return
+	}
+
+	recurrence, err := h.taskController.GetTaskRecurrence(recurrenceID, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurrence)
+}
+
+// UpdateTaskRecurrence Изменение серии повторяющихся задач
+// @Summary Изменить серию повторяющихся задач
+// @Description Заменяет шаблон и правило серии. Изменения получают ещё не начавшиеся экземпляры, которые не меняли отдельно; при смене правила, начала серии или workflow такие экземпляры пересоздаются. Один экземпляр меняется через PATCH /tasks/{task_id}. Доступно создателю серии и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param recurrence_id path int true "ID серии"
+// @Param request body at.SaveTaskRecurrenceRequest true "Шаблон задачи и правило повторения"
+// @Success 200 {object} at.TaskRecurrence "Серия успешно изменена"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или правило, workflow не найден"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение серии"
+// @Failure 404 {object} map[string]interface{} "Серия не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/recurrences/{recurrence_id} [put]
+func (h *TaskHandler) UpdateTaskRecurrence(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	recurrenceID, err := strconv.Atoi(c.Param("recurrence_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recurrence ID"})
+		return
+	}
+
+	var req at.SaveTaskRecurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recurrence, err := h.taskController.UpdateTaskRecurrence(recurrenceID, &req, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurrence)
+}
+
+// DeleteTaskRecurrence Удаление серии повторяющихся задач
+// @Summary Удалить серию повторяющихся задач
+// @Description Удаляет серию и её ещё не начавшиеся экземпляры, которые не меняли отдельно; остальные экземпляры остаются обычными задачами. Доступно создателю серии и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param recurrence_id path int true "ID серии"
+// @Success 204 "Серия удалена"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID серии"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на удаление серии"
+// @Failure 404 {object} map[string]interface{} "Серия не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/recurrences/{recurrence_id} [delete]
+func (h *TaskHandler) DeleteTaskRecurrence(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	recurrenceID, err := strconv.Atoi(c.Param("recurrence_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recurrence ID"})
+		return
+	}
+
+	if err := h.taskController.DeleteTaskRecurrence(recurrenceID, userID, getPermissionsFromTaskContext(c)); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetAllTaskTemplates Получение шаблонов задач
+// @Summary Получить шаблоны задач
+// @Description Возвращает по названию шаблоны задач, которые видит пользователь, вместе с метками и пунктами чек-листа. Шаблон видят его создатель, исполнитель по умолчанию и пользователи с правом manage_all_tasks
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} at.TaskTemplate "Шаблоны задач"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/templates [get]
+func (h *TaskHandler) GetAllTaskTemplates(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	templates, err := h.taskController.GetAllTaskTemplates(userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// GetTaskTemplate Получение шаблона задачи
+// @Summary Получить шаблон задачи
+// @Description Возвращает шаблон вместе с метками и пунктами чек-листа. Шаблон видят его создатель, исполнитель по умолчанию и пользователи с правом manage_all_tasks
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param template_id path int true "ID шаблона"
+// @Success 200 {object} at.TaskTemplate "Шаблон"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID шаблона"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Шаблон не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/templates/{template_id} [get]
+func (h *TaskHandler) GetTaskTemplate(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	templateID, err := strconv.Atoi(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template ID"})
+		return
+	}
+
+	template, err := h.taskController.GetTaskTemplate(templateID, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// CreateTaskTemplate Создание шаблона задачи
+// @Summary Создать шаблон задачи
+// @Description Создает шаблон: название задачи с подстановками {name} ({date} без значения заменяется текущей датой), описание, приоритет, исполнитель по умолчанию, метки и пункты чек-листа. Создателем шаблона становится текущий пользователь
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body at.SaveTaskTemplateRequest true "Данные шаблона"
+// @Success 201 {object} at.TaskTemplate "Шаблон успешно создан"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Метка не найдена"
+// @Failure 409 {object} map[string]interface{} "Шаблон с таким названием уже существует"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/templates [post]
+func (h *TaskHandler) CreateTaskTemplate(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req at.SaveTaskTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.taskController.CreateTaskTemplate(&req, userID)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// UpdateTaskTemplate Изменение шаблона задачи
+// @Summary Изменить шаблон задачи
+// @Description Заменяет шаблон целиком, включая метки и пункты чек-листа. Задачи, уже созданные по шаблону, не меняются. Доступно создателю шаблона и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param template_id path int true "ID шаблона"
+// @Param request body at.SaveTaskTemplateRequest true "Данные шаблона"
+// @Success 200 {object} at.TaskTemplate "Шаблон успешно изменен"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение шаблона"
+// @Failure 404 {object} map[string]interface{} "Шаблон или метка не найдены"
+// @Failure 409 {object} map[string]interface{} "Шаблон с таким названием уже существует"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/templates/{template_id} [put]
+func (h *TaskHandler) UpdateTaskTemplate(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	templateID, err := strconv.Atoi(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template ID"})
+		return
+	}
+
+	var req at.SaveTaskTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.taskController.UpdateTaskTemplate(templateID, &req, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTaskTemplate Удаление шаблона задачи
+// @Summary Удалить шаблон задачи
+// @Description Удаляет шаблон; задачи, созданные по нему, остаются. Доступно создателю шаблона и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param template_id path int true "ID шаблона"
+// @Success 204 "Шаблон удален"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID шаблона"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на удаление шаблона"
+// @Failure 404 {object} map[string]interface{} "Шаблон не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/templates/{template_id} [delete]
+func (h *TaskHandler) DeleteTaskTemplate(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	templateID, err := strconv.Atoi(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template ID"})
+		return
+	}
+
+	if err := h.taskController.DeleteTaskTemplate(templateID, userID, getPermissionsFromTaskContext(c)); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateTaskFromTemplate Создание задачи по шаблону
+// @Summary Создать задачу по шаблону
+// @Description Создает задачу с названием из шаблона (значения подстановок передаются в variables), описанием, приоритетом и исполнителем шаблона; исполнителя и приоритет можно переопределить. Задача проходит те же проверки пользователей, чата и файлов, что и при обычном создании, получает метки шаблона и копию его чек-листа. Создателем задачи становится текущий пользователь
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param template_id path int true "ID шаблона"
+// @Param request body at.CreateTaskFromTemplateRequest true "Значения подстановок и параметры задачи"
+// @Success 201 {object} at.TaskResponse "Задача успешно создана"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос, нет значения подстановки, workflow не найден или дата начала позже срока"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Пользователь не состоит в чате задачи"
+// @Failure 404 {object} map[string]interface{} "Шаблон не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/templates/{template_id}/tasks [post]
+func (h *TaskHandler) CreateTaskFromTemplate(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	templateID, err := strconv.Atoi(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template ID"})
+		return
+	}
+
+	var req at.CreateTaskFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.taskController.CreateTaskFromTemplate(templateID, &req, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
+// GetTaskChecklist Получение чек-листа задачи
+// @Summary Получить чек-лист задачи
+// @Description Возвращает пункты чек-листа задачи по порядку
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Success 200 {array} at.TaskChecklistItem "Пункты чек-листа"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/checklist [get]
+func (h *TaskHandler) GetTaskChecklist(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	items, err := h.taskController.GetTaskChecklist(taskID, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// AddChecklistItem Добавление пункта чек-листа
+// @Summary Добавить пункт чек-листа
+// @Description Добавляет пункт в конец чек-листа задачи. Доступно создателю, исполнителю задачи и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param request body at.AddChecklistItemRequest true "Пункт чек-листа"
+// @Success 201 {object} at.TaskChecklistItem "Пункт добавлен"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/checklist [post]
+func (h *TaskHandler) AddChecklistItem(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	var req at.AddChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.taskController.AddChecklistItem(taskID, &req, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// UpdateChecklistItem Изменение пункта чек-листа
+// @Summary Изменить пункт чек-листа
+// @Description Переименовывает пункт, отмечает его выполненным (done=true) или снимает отметку и переставляет на позицию position. Права те же, что и на добавление пункта
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param item_id path int true "ID пункта"
+// @Param request body at.UpdateChecklistItemRequest true "Изменяемые поля пункта"
+// @Success 200 {object} at.TaskChecklistItem "Пункт изменен"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача или пункт не найдены"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/checklist/{item_id} [patch]
+func (h *TaskHandler) UpdateChecklistItem(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, itemID, ok := parseChecklistItemPath(c)
+	if !ok {
+		return
+	}
+
+	var req at.UpdateChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.taskController.UpdateChecklistItem(taskID, itemID, &req, userID, getPermissionsFromTaskContext(c))
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// RemoveChecklistItem Удаление пункта чек-листа
+// @Summary Удалить пункт чек-листа
+// @Description Удаляет пункт из чек-листа задачи. Права те же, что и на добавление пункта
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param task_id path int true "ID задачи"
+// @Param item_id path int true "ID пункта"
+// @Success 204 "Пункт удален"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пункта"
+// @Failure 401 {object} map[string]interface{} "Пользователь не аутентифицирован"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача или пункт не найдены"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/checklist/{item_id} [delete]
+func (h *TaskHandler) RemoveChecklistItem(c *gin.Context) {
+	userID, err := getUserIDFromTaskContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, itemID, ok := parseChecklistItemPath(c)
+	if !ok {
+		return
+	}
+
+	if err := h.taskController.RemoveChecklistItem(taskID, itemID, userID, getPermissionsFromTaskContext(c)); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parseChecklistItemPath(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return 0, 0, false
+	}
+
+	itemID, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid checklist item ID"})
+		return 0, 0, false
+	}
+	return taskID, itemID, true
+}
+
+// GetAllWorkflows Получение всех workflow задач
+// @Summary Получить все workflow
+// @Description Возвращает список workflow задач со статусами и разрешёнными переходами
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} map[string]interface{} "Список workflow"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/workflows [get]
+func (h *TaskHandler) GetAllWorkflows(c *gin.Context) {
+	workflows, err := h.taskController.GetAllWorkflows()
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workflows)
+}
+
+// GetWorkflowByID Получение workflow по ID
+// @Summary Получить workflow по ID
+// @Description Возвращает workflow со статусами в заданном порядке и разрешёнными переходами
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param workflow_id path int true "ID workflow"
+// @Success 200 {object} map[string]interface{} "Информация о workflow"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID workflow"
+// @Failure 404 {object} map[string]interface{} "Workflow не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/workflows/{workflow_id} [get]
+func (h *TaskHandler) GetWorkflowByID(c *gin.Context) {
+	workflowID, err := strconv.Atoi(c.Param("workflow_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid workflow ID"})
+		return
+	}
+
+	workflow, err := h.taskController.GetWorkflowByID(workflowID)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workflow)
+}
+
+// CreateWorkflow Создание workflow задач
+// @Summary Создать workflow
+// @Description Создает workflow: упорядоченные статусы и переходы между ними с ограничением по роли (creator, executor, any)
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body at.SaveWorkflowRequest true "Описание workflow"
+// @Success 201 {object} map[string]interface{} "Workflow успешно создан"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или workflow"
+// @Failure 409 {object} map[string]interface{} "Workflow с таким названием уже существует"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/workflows [post]
+func (h *TaskHandler) CreateWorkflow(c *gin.Context) {
+	var req at.SaveWorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workflow, err := h.taskController.CreateWorkflow(&req)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, workflow)
+}
+
+// UpdateWorkflow Замена workflow задач
+// @Summary Обновить workflow
+// @Description Полностью заменяет название, статусы и переходы workflow
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workflow_id path int true "ID workflow"
+// @Param request body at.SaveWorkflowRequest true "Описание workflow"
+// @Success 200 {object} map[string]interface{} "Workflow успешно обновлен"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или workflow"
+// @Failure 404 {object} map[string]interface{} "Workflow не найден"
+// @Failure 409 {object} map[string]interface{} "Workflow с таким названием уже существует"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/workflows/{workflow_id} [put]
+func (h *TaskHandler) UpdateWorkflow(c *gin.Context) {
+	workflowID, err := strconv.Atoi(c.Param("workflow_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid workflow ID"})
+		return
+	}
+
+	var req at.SaveWorkflowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workflow, err := h.taskController.UpdateWorkflow(workflowID, &req)
+	if err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workflow)
+}
+
+// DeleteWorkflow Удаление workflow задач
+// @Summary Удалить workflow
+// @Description Удаляет workflow; привязанные к нему задачи переходят на workflow по умолчанию
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param workflow_id path int true "ID workflow"
+// @Success 204 "Workflow успешно удален"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID workflow"
+// @Failure 404 {object} map[string]interface{} "Workflow не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/workflows/{workflow_id} [delete]
+func (h *TaskHandler) DeleteWorkflow(c *gin.Context) {
+	workflowID, err := strconv.Atoi(c.Param("workflow_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid workflow ID"})
+		return
+	}
+
+	if err := h.taskController.DeleteWorkflow(workflowID); err != nil {
+		respondTaskServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
 }
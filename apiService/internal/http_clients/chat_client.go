@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/google/uuid"
 )
@@ -22,7 +24,12 @@ type ChatClient interface {
 	BanUser(chatID, userID, ownerID uuid.UUID) error
 	ChangeUserRole(chatID, ownerID uuid.UUID, changeRoleReq *ac.ChangeRoleRequest) error
 	GetMyRoleInChat(chatID, userID uuid.UUID) (*ac.MyRoleResponse, error)
-	GetChatMembers(chatID uuid.UUID) ([]*ac.ChatMember, error)
+	GetChatMembers(chatID uuid.UUID, query *ac.ChatMembersQuery) (*ac.ChatMembersResponse, error)
+	SaveMessage(userID, messageID uuid.UUID, req *ac.SaveMessageRequest) (*ac.SavedMessage, error)
+	GetSavedMessages(userID uuid.UUID, offset, limit int) (*ac.GetSavedMessagesResponse, error)
+	DeleteSavedMessage(userID, messageID uuid.UUID) error
+	GetTaskEventSettings(chatID, userID uuid.UUID) (*ac.TaskEventSettings, error)
+	UpdateTaskEventSettings(chatID, userID uuid.UUID, req *ac.UpdateTaskEventSettingsRequest) (*ac.TaskEventSettings, error)
 }
 
 type chatClient struct {
@@ -332,11 +339,20 @@ func (c *chatClient) GetMyRoleInChat(chatID, userID uuid.UUID) (*ac.MyRoleRespon
 	return &result, nil
 }
 
-// GetChatMembers - получение списка участников чата
-func (c *chatClient) GetChatMembers(chatID uuid.UUID) ([]*ac.ChatMember, error) {
-	url := fmt.Sprintf("%s/api/v1/chats/%s/members", c.host, chatID.String())
+// GetChatMembers - получение страницы участников чата с фильтрами
+func (c *chatClient) GetChatMembers(chatID uuid.UUID, query *ac.ChatMembersQuery) (*ac.ChatMembersResponse, error) {
+	params := url.Values{}
+	params.Set("offset", strconv.Itoa(query.Offset))
+	params.Set("limit", strconv.Itoa(query.Limit))
+	if query.Role != "" {
+		params.Set("role", query.Role)
+	}
+	if query.Search != "" {
+		params.Set("search", query.Search)
+	}
+	reqURL := fmt.Sprintf("%s/api/v1/chats/%s/members?%s", c.host, chatID.String(), params.Encode())
 
-	resp, err := http.Get(url)
+	resp, err := http.Get(reqURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chat members: %w", err)
 	}
@@ -347,10 +363,159 @@ func (c *chatClient) GetChatMembers(chatID uuid.UUID) ([]*ac.ChatMember, error)
 		return nil, fmt.Errorf("chat service returned error: %s", string(bodyBytes))
 	}
 
-	var members []*ac.ChatMember
+	var members ac.ChatMembersResponse
 	if err := json.NewDecoder(resp.Body).Decode(&members); err != nil {
 		return nil, fmt.Errorf("failed to decode members response: %w", err)
 	}
 
-	return members, nil
+	return &members, nil
+}
+
+// SaveMessage - добавление сообщения в закладки пользователя
+func (c *chatClient) SaveMessage(userID, messageID uuid.UUID, req *ac.SaveMessageRequest) (*ac.SavedMessage, error) {
+	url := fmt.Sprintf("%s/api/v1/chats/saved-messages/%s", c.host, messageID.String())
+
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-User-ID", userID.String())
+
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request to chat service failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("chat service returned error: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result ac.SavedMessage
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetSavedMessages - получение закладок пользователя
+func (c *chatClient) GetSavedMessages(userID uuid.UUID, offset, limit int) (*ac.GetSavedMessagesResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/chats/saved-messages?offset=%d&limit=%d", c.host, offset, limit)
+
+	httpReq, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("X-User-ID", userID.String())
+
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved messages: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("chat service returned error: %s", string(bodyBytes))
+	}
+
+	var result ac.GetSavedMessagesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode saved messages response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// DeleteSavedMessage - удаление сообщения из закладок пользователя
+func (c *chatClient) DeleteSavedMessage(userID, messageID uuid.UUID) error {
+	url := fmt.Sprintf("%s/api/v1/chats/saved-messages/%s", c.host, messageID.String())
+
+	httpReq, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("X-User-ID", userID.String())
+
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request to chat service failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("chat service returned error: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// GetTaskEventSettings - получение настроек событий задач чата
+func (c *chatClient) GetTaskEventSettings(chatID, userID uuid.UUID) (*ac.TaskEventSettings, error) {
+	url := fmt.Sprintf("%s/api/v1/chats/%s/task-event-settings", c.host, chatID.String())
+
+	httpReq, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("X-User-ID", userID.String())
+
+	return c.doTaskEventSettingsRequest(httpReq)
+}
+
+// UpdateTaskEventSettings - изменение настроек событий задач чата
+func (c *chatClient) UpdateTaskEventSettings(chatID, userID uuid.UUID, req *ac.UpdateTaskEventSettingsRequest) (*ac.TaskEventSettings, error) {
+	url := fmt.Sprintf("%s/api/v1/chats/%s/task-event-settings", c.host, chatID.String())
+
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-User-ID", userID.String())
+
+	return c.doTaskEventSettingsRequest(httpReq)
+}
+
+func (c *chatClient) doTaskEventSettingsRequest(httpReq *http.Request) (*ac.TaskEventSettings, error) {
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request to chat service failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("chat service returned error: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result ac.TaskEventSettings
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
 }
@@ -1,23 +1,88 @@
 package http_clients
 
 import (
+	"apiService/internal/custom_errors"
+	"apiService/internal/dto"
 	"bytes"
 	at "common/contracts/api-task"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
 )
 
 type TaskClient interface {
-	CreateTask(req *at.CreateTaskRequest) (*at.TaskResponse, error)
-	UpdateTaskStatus(taskID, statusID int) error
-	GetTaskByID(taskID int) (*at.TaskServiceResponse, error)
-	GetUserTasks(userID string, limit, offset int) (*[]at.TaskToList, error)
+	CreateTask(actorID uuid.UUID, permissions []string, req *at.CreateTaskRequest) (*at.TaskResponse, error)
+	UpdateTaskStatus(taskID, statusID int, actorID uuid.UUID, permissions []string) error
+	GetTaskByID(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskServiceResponse, error)
+	GetUserTasks(userID string, actorID uuid.UUID, permissions []string, filter *dto.TaskListFilterGateway, limit, offset int) (*[]at.TaskToList, error)
+	QueryTasks(actorID uuid.UUID, permissions []string, query *dto.TaskQueryGateway) (*at.TaskQueryResult, error)
+	GetTaskBoard(actorID uuid.UUID, permissions []string, query *dto.TaskBoardQueryGateway) (*at.TaskBoard, error)
+	MoveTask(taskID int, actorID uuid.UUID, permissions []string, req *at.MoveTaskRequest) error
+	UpdateTask(taskID int, actorID uuid.UUID, permissions []string, req *at.UpdateTaskRequest) (*at.TaskResponse, error)
+	DeleteTask(taskID int, actorID uuid.UUID, permissions []string) error
+	BulkUpdateTasks(actorID uuid.UUID, permissions []string, req *at.TaskBulkRequest) (*at.TaskBulkResult, error)
+	GetCreatedTasks(userID string, actorID uuid.UUID, permissions []string, limit, offset int) (*[]at.TaskToList, error)
+	GetChatTasks(chatID string, actorID uuid.UUID, permissions []string, limit, offset int) (*[]at.TaskToList, error)
+	GetTaskHistory(taskID int, actorID uuid.UUID, permissions []string, limit, offset int) (*[]at.TaskActivity, error)
+	GetUserActivity(userID string, actorID uuid.UUID, permissions []string, limit, offset int) (*[]at.TaskActivity, error)
+	CreateTaskComment(taskID int, actorID uuid.UUID, req *at.CreateTaskCommentRequest) (*at.TaskComment, error)
+	UpdateTaskComment(taskID, commentID int, actorID uuid.UUID, req *at.UpdateTaskCommentRequest) (*at.TaskComment, error)
+	DeleteTaskComment(taskID, commentID int, actorID uuid.UUID, permissions []string) error
+	GetTaskComments(taskID int, actorID uuid.UUID, permissions []string, limit, offset int) ([]at.TaskComment, error)
+	GetTaskTree(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskTreeNode, error)
+	GetTaskDependencies(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskDependencyGraph, error)
+	AddTaskDependency(taskID, blockerTaskID int, actorID uuid.UUID, permissions []string) error
+	RemoveTaskDependency(taskID, blockerTaskID int, actorID uuid.UUID, permissions []string) error
+	GetTaskLabels(taskID int, actorID uuid.UUID, permissions []string) ([]at.TaskLabel, error)
+	AddTaskLabel(taskID, labelID int, actorID uuid.UUID, permissions []string) error
+	RemoveTaskLabel(taskID, labelID int, actorID uuid.UUID, permissions []string) error
+	AddTaskAssignee(taskID int, userID, actorID uuid.UUID, permissions []string) error
+	RemoveTaskAssignee(taskID int, userID, actorID uuid.UUID, permissions []string) error
+	AddTaskWatcher(taskID int, userID, actorID uuid.UUID, permissions []string) error
+	RemoveTaskWatcher(taskID int, userID, actorID uuid.UUID, permissions []string) error
+	StartTaskTimer(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error)
+	StopTaskTimer(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error)
+	CreateTaskTimeLog(taskID int, actorID uuid.UUID, req *at.CreateTaskTimeLogRequest) (*at.TaskTimeLog, error)
+	UpdateTaskTimeLog(taskID, logID int, actorID uuid.UUID, permissions []string, req *at.UpdateTaskTimeLogRequest) (*at.TaskTimeLog, error)
+	DeleteTaskTimeLog(taskID, logID int, actorID uuid.UUID, permissions []string) error
+	GetTaskTimeLogs(taskID int, actorID uuid.UUID, permissions []string, limit, offset int) ([]at.TaskTimeLog, error)
+	GetTimeSpent(actorID uuid.UUID, permissions []string, query *dto.TimeSpentQueryGateway) ([]at.TimeSpent, error)
+	GetAllLabels() ([]at.Label, error)
+	GetLabelByID(labelID int) (*at.Label, error)
+	CreateLabel(req *at.SaveLabelRequest) (*at.Label, error)
+	UpdateLabel(labelID int, req *at.SaveLabelRequest) (*at.Label, error)
+	DeleteLabel(labelID int) error
+	CreateTaskFromMessage(actorID uuid.UUID, req *at.CreateTaskFromMessageRequest) (*at.TaskResponse, error)
+	CreateTaskRecurrence(actorID uuid.UUID, req *at.SaveTaskRecurrenceRequest) (*at.TaskRecurrence, error)
+	GetTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string) (*at.TaskRecurrence, error)
+	UpdateTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string, req *at.SaveTaskRecurrenceRequest) (*at.TaskRecurrence, error)
+	DeleteTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string) error
+	GetTaskReport(report string, actorID uuid.UUID, permissions []string, query *dto.TaskReportQueryGateway) (*dto.TaskReport, error)
+	GetAllTaskTemplates(actorID uuid.UUID, permissions []string) ([]at.TaskTemplate, error)
+	GetTaskTemplate(templateID int, actorID uuid.UUID, permissions []string) (*at.TaskTemplate, error)
+	CreateTaskTemplate(actorID uuid.UUID, req *at.SaveTaskTemplateRequest) (*at.TaskTemplate, error)
+	UpdateTaskTemplate(templateID int, actorID uuid.UUID, permissions []string, req *at.SaveTaskTemplateRequest) (*at.TaskTemplate, error)
+	DeleteTaskTemplate(templateID int, actorID uuid.UUID, permissions []string) error
+	CreateTaskFromTemplate(templateID int, actorID uuid.UUID, permissions []string, req *at.CreateTaskFromTemplateRequest) (*at.TaskResponse, error)
+	GetTaskChecklist(taskID int, actorID uuid.UUID, permissions []string) ([]at.TaskChecklistItem, error)
+	AddChecklistItem(taskID int, actorID uuid.UUID, permissions []string, req *at.AddChecklistItemRequest) (*at.TaskChecklistItem, error)
+	UpdateChecklistItem(taskID, itemID int, actorID uuid.UUID, permissions []string, req *at.UpdateChecklistItemRequest) (*at.TaskChecklistItem, error)
+	RemoveChecklistItem(taskID, itemID int, actorID uuid.UUID, permissions []string) error
 	GetAllStatuses() ([]at.TaskStatus, error)
 	CreateStatus(req *at.CreateStatusRequest) (*at.TaskStatus, error)
 	GetStatusByID(statusID int) (*at.TaskStatus, error)
-	DeleteStatus(statusID int) error
+	GetStatusUsage(statusID int) (*at.TaskStatusUsage, error)
+	DeleteStatus(statusID int, replacementID *int, actorID uuid.UUID, permissions []string) error
+	GetAllWorkflows() ([]at.TaskWorkflow, error)
+	GetWorkflowByID(workflowID int) (*at.TaskWorkflow, error)
+	CreateWorkflow(req *at.SaveWorkflowRequest) (*at.TaskWorkflow, error)
+	UpdateWorkflow(workflowID int, req *at.SaveWorkflowRequest) (*at.TaskWorkflow, error)
+	DeleteWorkflow(workflowID int) error
 }
 
 type taskClient struct {
@@ -28,13 +93,112 @@ func NewTaskClient(host string) TaskClient {
 	return &taskClient{host: host}
 }
 
-func (c *taskClient) CreateTask(req *at.CreateTaskRequest) (*at.TaskResponse, error) {
+// CreateTask - задача от имени пользователя; taskService проверяет его членство в чате и видимость родителя
+func (c *taskClient) CreateTask(actorID uuid.UUID, permissions []string, req *at.CreateTaskRequest) (*at.TaskResponse, error) {
+	var task at.TaskResponse
+	if err := c.doActorRequest(http.MethodPost, c.host+"/api/v1/tasks", actorID, permissions, req, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// CreateTaskFromMessage - задача из сообщения чата; taskService проверяет право view_messages пользователя в чате
+func (c *taskClient) CreateTaskFromMessage(actorID uuid.UUID, req *at.CreateTaskFromMessageRequest) (*at.TaskResponse, error) {
+	var task at.TaskResponse
+	url := fmt.Sprintf("%s/api/v1/tasks/from-message", c.host)
+	if err := c.doActorRequest(http.MethodPost, url, actorID, nil, req, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// UpdateTaskStatus - смена статуса задачи от имени пользователя; taskService проверяет переход по workflow
+func (c *taskClient) UpdateTaskStatus(taskID, statusID int, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/status/%d", c.host, taskID, statusID)
+
+	httpReq, err := http.NewRequest(http.MethodPatch, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	setTaskActorHeaders(httpReq, actorID, permissions)
+
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request to task service failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return custom_errors.NewTaskServiceError(resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// GetTaskByID - задача глазами пользователя; недоступную ему задачу taskService не отдаёт (404)
+func (c *taskClient) GetTaskByID(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskServiceResponse, error) {
+	var serviceResp at.TaskServiceResponse
+	url := fmt.Sprintf("%s/api/v1/tasks/%d", c.host, taskID)
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &serviceResp); err != nil {
+		return nil, err
+	}
+	return &serviceResp, nil
+}
+
+// GetUserTasks - задачи исполнителя, видимые actorID; фильтры передаются в taskService как query-параметры
+func (c *taskClient) GetUserTasks(userID string, actorID uuid.UUID, permissions []string, filter *dto.TaskListFilterGateway, limit, offset int) (*[]at.TaskToList, error) {
+	query := filter.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+	return c.getTaskList(fmt.Sprintf("%s/api/v1/users/%s/tasks?%s", c.host, userID, query.Encode()), actorID, permissions)
+}
+
+// QueryTasks - поиск среди задач, видимых actorID; параметры проверяет taskService
+func (c *taskClient) QueryTasks(actorID uuid.UUID, permissions []string, query *dto.TaskQueryGateway) (*at.TaskQueryResult, error) {
+	var result at.TaskQueryResult
+	url := fmt.Sprintf("%s/api/v1/tasks/search?%s", c.host, query.Query().Encode())
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetTaskBoard - задачи чата или исполнителя, сгруппированные по статусам
+func (c *taskClient) GetTaskBoard(actorID uuid.UUID, permissions []string, query *dto.TaskBoardQueryGateway) (*at.TaskBoard, error) {
+	var board at.TaskBoard
+	url := fmt.Sprintf("%s/api/v1/tasks/board?%s", c.host, query.Query().Encode())
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &board); err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// MoveTask - перенос карточки на доске; правила workflow и устаревшую доску проверяет taskService
+func (c *taskClient) MoveTask(taskID int, actorID uuid.UUID, permissions []string, req *at.MoveTaskRequest) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/move", c.host, taskID)
+	return c.doActorRequest(http.MethodPost, url, actorID, permissions, req, nil)
+}
+
+// UpdateTask - частичное обновление задачи от имени пользователя
+func (c *taskClient) UpdateTask(taskID int, actorID uuid.UUID, permissions []string, req *at.UpdateTaskRequest) (*at.TaskResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d", c.host, taskID)
+
 	payload, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode request: %w", err)
 	}
 
-	resp, err := http.Post(c.host+"/api/v1/tasks", "application/json", bytes.NewBuffer(payload))
+	httpReq, err := http.NewRequest(http.MethodPatch, url, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	setTaskActorHeaders(httpReq, actorID, permissions)
+
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("request to task service failed: %w", err)
 	}
@@ -42,24 +206,26 @@ func (c *taskClient) CreateTask(req *at.CreateTaskRequest) (*at.TaskResponse, er
 
 	if resp.StatusCode >= 400 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("task service returned error: %s", string(bodyBytes))
+		return nil, custom_errors.NewTaskServiceError(resp.StatusCode, string(bodyBytes))
 	}
 
-	var serviceTask at.TaskResponse
-	if err := json.NewDecoder(resp.Body).Decode(&serviceTask); err != nil {
-		return nil, fmt.Errorf("failed to decode task service response: %w", err)
+	var task at.TaskResponse
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, fmt.Errorf("failed to decode task response: %w", err)
 	}
 
-	return &serviceTask, nil
+	return &task, nil
 }
 
-func (c *taskClient) UpdateTaskStatus(taskID, statusID int) error {
-	url := fmt.Sprintf("%s/api/v1/tasks/%d/status/%d", c.host, taskID, statusID)
+// DeleteTask - мягкое удаление задачи от имени пользователя
+func (c *taskClient) DeleteTask(taskID int, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d", c.host, taskID)
 
-	httpReq, err := http.NewRequest(http.MethodPatch, url, nil)
+	httpReq, err := http.NewRequest(http.MethodDelete, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
+	setTaskActorHeaders(httpReq, actorID, permissions)
 
 	client := &http.Client{}
 	resp, err := client.Do(httpReq)
@@ -70,54 +236,473 @@ func (c *taskClient) UpdateTaskStatus(taskID, statusID int) error {
 
 	if resp.StatusCode >= 400 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("task service returned error: %s", string(bodyBytes))
+		return custom_errors.NewTaskServiceError(resp.StatusCode, string(bodyBytes))
 	}
 
 	return nil
 }
 
-func (c *taskClient) GetTaskByID(taskID int) (*at.TaskServiceResponse, error) {
-	url := fmt.Sprintf("%s/api/v1/tasks/%d", c.host, taskID)
+// BulkUpdateTasks - одно действие над списком задач от имени пользователя; taskService проверяет каждую задачу
+// и возвращает результат по каждой из них
+func (c *taskClient) BulkUpdateTasks(actorID uuid.UUID, permissions []string, req *at.TaskBulkRequest) (*at.TaskBulkResult, error) {
+	var result at.TaskBulkResult
+	url := fmt.Sprintf("%s/api/v1/tasks/bulk", c.host)
+	if err := c.doActorRequest(http.MethodPost, url, actorID, permissions, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
 
-	resp, err := http.Get(url)
+// GetCreatedTasks - задачи, созданные пользователем
+func (c *taskClient) GetCreatedTasks(userID string, actorID uuid.UUID, permissions []string, limit, offset int) (*[]at.TaskToList, error) {
+	return c.getTaskList(fmt.Sprintf("%s/api/v1/users/%s/tasks/created?limit=%d&offset=%d", c.host, userID, limit, offset), actorID, permissions)
+}
+
+// GetChatTasks - задачи, привязанные к чату
+func (c *taskClient) GetChatTasks(chatID string, actorID uuid.UUID, permissions []string, limit, offset int) (*[]at.TaskToList, error) {
+	return c.getTaskList(fmt.Sprintf("%s/api/v1/chats/%s/tasks?limit=%d&offset=%d", c.host, chatID, limit, offset), actorID, permissions)
+}
+
+func (c *taskClient) getTaskList(url string, actorID uuid.UUID, permissions []string) (*[]at.TaskToList, error) {
+	var tasks []at.TaskToList
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &tasks); err != nil {
+		return nil, err
+	}
+	return &tasks, nil
+}
+
+// GetTaskHistory - история задачи, новые события первыми
+func (c *taskClient) GetTaskHistory(taskID int, actorID uuid.UUID, permissions []string, limit, offset int) (*[]at.TaskActivity, error) {
+	return c.getActivity(fmt.Sprintf("%s/api/v1/tasks/%d/history?limit=%d&offset=%d", c.host, taskID, limit, offset), actorID, permissions)
+}
+
+// GetUserActivity - лента активности пользователя по его задачам
+func (c *taskClient) GetUserActivity(userID string, actorID uuid.UUID, permissions []string, limit, offset int) (*[]at.TaskActivity, error) {
+	return c.getActivity(fmt.Sprintf("%s/api/v1/users/%s/activity?limit=%d&offset=%d", c.host, userID, limit, offset), actorID, permissions)
+}
+
+func (c *taskClient) getActivity(url string, actorID uuid.UUID, permissions []string) (*[]at.TaskActivity, error) {
+	var events []at.TaskActivity
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &events); err != nil {
+		return nil, err
+	}
+	return &events, nil
+}
+
+// CreateTaskComment - комментарий от имени пользователя
+func (c *taskClient) CreateTaskComment(taskID int, actorID uuid.UUID, req *at.CreateTaskCommentRequest) (*at.TaskComment, error) {
+	var comment at.TaskComment
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/comments", c.host, taskID)
+	if err := c.doActorRequest(http.MethodPost, url, actorID, nil, req, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// UpdateTaskComment - правка комментария; taskService разрешает её только автору
+func (c *taskClient) UpdateTaskComment(taskID, commentID int, actorID uuid.UUID, req *at.UpdateTaskCommentRequest) (*at.TaskComment, error) {
+	var comment at.TaskComment
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/comments/%d", c.host, taskID, commentID)
+	if err := c.doActorRequest(http.MethodPatch, url, actorID, nil, req, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// DeleteTaskComment - удаление комментария автором или пользователем с правом manage_all_tasks
+func (c *taskClient) DeleteTaskComment(taskID, commentID int, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/comments/%d", c.host, taskID, commentID)
+	return c.doActorRequest(http.MethodDelete, url, actorID, permissions, nil, nil)
+}
+
+// GetTaskComments - комментарии задачи, старые первыми
+func (c *taskClient) GetTaskComments(taskID int, actorID uuid.UUID, permissions []string, limit, offset int) ([]at.TaskComment, error) {
+	var comments []at.TaskComment
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/comments?limit=%d&offset=%d", c.host, taskID, limit, offset)
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// GetTaskTree - задача с деревом подзадач и прогрессом
+func (c *taskClient) GetTaskTree(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskTreeNode, error) {
+	var tree at.TaskTreeNode
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/tree", c.host, taskID)
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &tree); err != nil {
+		return nil, err
+	}
+	return &tree, nil
+}
+
+// GetTaskDependencies - граф блокирующих и блокируемых задач
+func (c *taskClient) GetTaskDependencies(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskDependencyGraph, error) {
+	var graph at.TaskDependencyGraph
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/dependencies", c.host, taskID)
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &graph); err != nil {
+		return nil, err
+	}
+	return &graph, nil
+}
+
+// AddTaskDependency - задача blockerTaskID начинает блокировать taskID; циклы отклоняет taskService
+func (c *taskClient) AddTaskDependency(taskID, blockerTaskID int, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/dependencies", c.host, taskID)
+	req := &at.AddTaskDependencyRequest{BlockerTaskID: blockerTaskID}
+	return c.doActorRequest(http.MethodPost, url, actorID, permissions, req, nil)
+}
+
+func (c *taskClient) RemoveTaskDependency(taskID, blockerTaskID int, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/dependencies/%d", c.host, taskID, blockerTaskID)
+	return c.doActorRequest(http.MethodDelete, url, actorID, permissions, nil, nil)
+}
+
+// GetTaskLabels - метки, назначенные задаче
+func (c *taskClient) GetTaskLabels(taskID int, actorID uuid.UUID, permissions []string) ([]at.TaskLabel, error) {
+	var labels []at.TaskLabel
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/labels", c.host, taskID)
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+func (c *taskClient) AddTaskLabel(taskID, labelID int, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/labels/%d", c.host, taskID, labelID)
+	return c.doActorRequest(http.MethodPost, url, actorID, permissions, nil, nil)
+}
+
+func (c *taskClient) RemoveTaskLabel(taskID, labelID int, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/labels/%d", c.host, taskID, labelID)
+	return c.doActorRequest(http.MethodDelete, url, actorID, permissions, nil, nil)
+}
+
+func (c *taskClient) AddTaskAssignee(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/assignees/%s", c.host, taskID, userID)
+	return c.doActorRequest(http.MethodPost, url, actorID, permissions, nil, nil)
+}
+
+func (c *taskClient) RemoveTaskAssignee(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/assignees/%s", c.host, taskID, userID)
+	return c.doActorRequest(http.MethodDelete, url, actorID, permissions, nil, nil)
+}
+
+func (c *taskClient) AddTaskWatcher(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/watchers/%s", c.host, taskID, userID)
+	return c.doActorRequest(http.MethodPost, url, actorID, permissions, nil, nil)
+}
+
+func (c *taskClient) RemoveTaskWatcher(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/watchers/%s", c.host, taskID, userID)
+	return c.doActorRequest(http.MethodDelete, url, actorID, permissions, nil, nil)
+}
+
+// StartTaskTimer - запуск таймера; списывать время могут только исполнители задачи
+func (c *taskClient) StartTaskTimer(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error) {
+	var log at.TaskTimeLog
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/time-logs/start", c.host, taskID)
+	if err := c.doActorRequest(http.MethodPost, url, actorID, nil, nil, &log); err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+func (c *taskClient) StopTaskTimer(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error) {
+	var log at.TaskTimeLog
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/time-logs/stop", c.host, taskID)
+	if err := c.doActorRequest(http.MethodPost, url, actorID, nil, nil, &log); err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+func (c *taskClient) CreateTaskTimeLog(taskID int, actorID uuid.UUID, req *at.CreateTaskTimeLogRequest) (*at.TaskTimeLog, error) {
+	var log at.TaskTimeLog
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/time-logs", c.host, taskID)
+	if err := c.doActorRequest(http.MethodPost, url, actorID, nil, req, &log); err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+// UpdateTaskTimeLog - правка записи времени; taskService разрешает её автору и manage_all_tasks
+func (c *taskClient) UpdateTaskTimeLog(taskID, logID int, actorID uuid.UUID, permissions []string, req *at.UpdateTaskTimeLogRequest) (*at.TaskTimeLog, error) {
+	var log at.TaskTimeLog
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/time-logs/%d", c.host, taskID, logID)
+	if err := c.doActorRequest(http.MethodPatch, url, actorID, permissions, req, &log); err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+func (c *taskClient) DeleteTaskTimeLog(taskID, logID int, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/time-logs/%d", c.host, taskID, logID)
+	return c.doActorRequest(http.MethodDelete, url, actorID, permissions, nil, nil)
+}
+
+func (c *taskClient) GetTaskTimeLogs(taskID int, actorID uuid.UUID, permissions []string, limit, offset int) ([]at.TaskTimeLog, error) {
+	var logs []at.TaskTimeLog
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/time-logs?limit=%d&offset=%d", c.host, taskID, limit, offset)
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// GetTimeSpent - затраченное время по задачам, пользователям или чатам за период
+func (c *taskClient) GetTimeSpent(actorID uuid.UUID, permissions []string, query *dto.TimeSpentQueryGateway) ([]at.TimeSpent, error) {
+	var rows []at.TimeSpent
+	url := fmt.Sprintf("%s/api/v1/time-spent?%s", c.host, query.Query().Encode())
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// GetAllLabels - все метки с числом задач, которым назначена каждая
+func (c *taskClient) GetAllLabels() ([]at.Label, error) {
+	var labels []at.Label
+	if err := c.doActorRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/tasks/labels", c.host), uuid.Nil, nil, nil, &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+func (c *taskClient) GetLabelByID(labelID int) (*at.Label, error) {
+	var label at.Label
+	url := fmt.Sprintf("%s/api/v1/tasks/labels/%d", c.host, labelID)
+	if err := c.doActorRequest(http.MethodGet, url, uuid.Nil, nil, nil, &label); err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+func (c *taskClient) CreateLabel(req *at.SaveLabelRequest) (*at.Label, error) {
+	var label at.Label
+	if err := c.doActorRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/tasks/labels", c.host), uuid.Nil, nil, req, &label); err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// UpdateLabel - заменить название и цвет метки
+func (c *taskClient) UpdateLabel(labelID int, req *at.SaveLabelRequest) (*at.Label, error) {
+	var label at.Label
+	url := fmt.Sprintf("%s/api/v1/tasks/labels/%d", c.host, labelID)
+	if err := c.doActorRequest(http.MethodPut, url, uuid.Nil, nil, req, &label); err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// DeleteLabel - удалить метку; taskService снимает её со всех задач
+func (c *taskClient) DeleteLabel(labelID int) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/labels/%d", c.host, labelID)
+	return c.doActorRequest(http.MethodDelete, url, uuid.Nil, nil, nil, nil)
+}
+
+// CreateTaskRecurrence - серия повторяющихся задач; создателем становится actorID
+func (c *taskClient) CreateTaskRecurrence(actorID uuid.UUID, req *at.SaveTaskRecurrenceRequest) (*at.TaskRecurrence, error) {
+	var recurrence at.TaskRecurrence
+	url := fmt.Sprintf("%s/api/v1/tasks/recurrences", c.host)
+	if err := c.doActorRequest(http.MethodPost, url, actorID, nil, req, &recurrence); err != nil {
+		return nil, err
+	}
+	return &recurrence, nil
+}
+
+// GetTaskRecurrence - серия, если её видит actorID; чужая серия для taskService не существует
+func (c *taskClient) GetTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string) (*at.TaskRecurrence, error) {
+	var recurrence at.TaskRecurrence
+	url := fmt.Sprintf("%s/api/v1/tasks/recurrences/%d", c.host, recurrenceID)
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &recurrence); err != nil {
+		return nil, err
+	}
+	return &recurrence, nil
+}
+
+// UpdateTaskRecurrence - замена серии; taskService переносит изменения в ещё не начавшиеся экземпляры
+func (c *taskClient) UpdateTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string, req *at.SaveTaskRecurrenceRequest) (*at.TaskRecurrence, error) {
+	var recurrence at.TaskRecurrence
+	url := fmt.Sprintf("%s/api/v1/tasks/recurrences/%d", c.host, recurrenceID)
+	if err := c.doActorRequest(http.MethodPut, url, actorID, permissions, req, &recurrence); err != nil {
+		return nil, err
+	}
+	return &recurrence, nil
+}
+
+// DeleteTaskRecurrence - удаление серии вместе с её ещё не начавшимися экземплярами
+func (c *taskClient) DeleteTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/recurrences/%d", c.host, recurrenceID)
+	return c.doActorRequest(http.MethodDelete, url, actorID, permissions, nil, nil)
+}
+
+// GetTaskReport - отчёт taskService /reports/{report} по задачам, которые видит actorID,
+// в запрошенном формате без разбора ответа
+func (c *taskClient) GetTaskReport(report string, actorID uuid.UUID, permissions []string, query *dto.TaskReportQueryGateway) (*dto.TaskReport, error) {
+	url := fmt.Sprintf("%s/api/v1/reports/%s?%s", c.host, report, query.Query().Encode())
+	httpReq, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get task: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	setTaskActorHeaders(httpReq, actorID, permissions)
+
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request to task service failed: %w", err)
 	}
 	defer resp.Body.Close()
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task service response: %w", err)
+	}
 	if resp.StatusCode >= 400 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("task service returned error: %s", string(bodyBytes))
+		return nil, custom_errors.NewTaskServiceError(resp.StatusCode, string(body))
 	}
+	return &dto.TaskReport{
+		ContentType:        resp.Header.Get("Content-Type"),
+		ContentDisposition: resp.Header.Get("Content-Disposition"),
+		Body:               body,
+	}, nil
+}
 
-	var serviceResp at.TaskServiceResponse
-	if err := json.NewDecoder(resp.Body).Decode(&serviceResp); err != nil {
-		return nil, fmt.Errorf("failed to decode task response: %w", err)
+// GetAllTaskTemplates - шаблоны задач, которые видит actorID, с метками и пунктами чек-листа
+func (c *taskClient) GetAllTaskTemplates(actorID uuid.UUID, permissions []string) ([]at.TaskTemplate, error) {
+	var templates []at.TaskTemplate
+	url := fmt.Sprintf("%s/api/v1/tasks/templates", c.host)
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &templates); err != nil {
+		return nil, err
 	}
+	return templates, nil
+}
 
-	return &serviceResp, nil
+func (c *taskClient) GetTaskTemplate(templateID int, actorID uuid.UUID, permissions []string) (*at.TaskTemplate, error) {
+	var template at.TaskTemplate
+	url := fmt.Sprintf("%s/api/v1/tasks/templates/%d", c.host, templateID)
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
 }
 
-func (c *taskClient) GetUserTasks(userID string, limit, offset int) (*[]at.TaskToList, error) {
-	url := fmt.Sprintf("%s/api/v1/users/%s/tasks?limit=%d&offset=%d", c.host, userID, limit, offset)
+// CreateTaskTemplate - шаблон задачи; создателем становится actorID
+func (c *taskClient) CreateTaskTemplate(actorID uuid.UUID, req *at.SaveTaskTemplateRequest) (*at.TaskTemplate, error) {
+	var template at.TaskTemplate
+	url := fmt.Sprintf("%s/api/v1/tasks/templates", c.host)
+	if err := c.doActorRequest(http.MethodPost, url, actorID, nil, req, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
 
-	resp, err := http.Get(url)
+// UpdateTaskTemplate - замена шаблона; задачи, уже созданные по нему, не меняются
+func (c *taskClient) UpdateTaskTemplate(templateID int, actorID uuid.UUID, permissions []string, req *at.SaveTaskTemplateRequest) (*at.TaskTemplate, error) {
+	var template at.TaskTemplate
+	url := fmt.Sprintf("%s/api/v1/tasks/templates/%d", c.host, templateID)
+	if err := c.doActorRequest(http.MethodPut, url, actorID, permissions, req, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (c *taskClient) DeleteTaskTemplate(templateID int, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/templates/%d", c.host, templateID)
+	return c.doActorRequest(http.MethodDelete, url, actorID, permissions, nil, nil)
+}
+
+// CreateTaskFromTemplate - задача по шаблону; taskService проверяет её так же, как при обычном создании
+func (c *taskClient) CreateTaskFromTemplate(templateID int, actorID uuid.UUID, permissions []string, req *at.CreateTaskFromTemplateRequest) (*at.TaskResponse, error) {
+	var task at.TaskResponse
+	url := fmt.Sprintf("%s/api/v1/tasks/templates/%d/tasks", c.host, templateID)
+	if err := c.doActorRequest(http.MethodPost, url, actorID, permissions, req, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (c *taskClient) GetTaskChecklist(taskID int, actorID uuid.UUID, permissions []string) ([]at.TaskChecklistItem, error) {
+	var items []at.TaskChecklistItem
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/checklist", c.host, taskID)
+	if err := c.doActorRequest(http.MethodGet, url, actorID, permissions, nil, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// AddChecklistItem - пункт в конец чек-листа; менять чек-лист могут те же пользователи, что и задачу
+func (c *taskClient) AddChecklistItem(taskID int, actorID uuid.UUID, permissions []string, req *at.AddChecklistItemRequest) (*at.TaskChecklistItem, error) {
+	var item at.TaskChecklistItem
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/checklist", c.host, taskID)
+	if err := c.doActorRequest(http.MethodPost, url, actorID, permissions, req, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateChecklistItem - переименование, отметка или перестановка пункта
+func (c *taskClient) UpdateChecklistItem(taskID, itemID int, actorID uuid.UUID, permissions []string, req *at.UpdateChecklistItemRequest) (*at.TaskChecklistItem, error) {
+	var item at.TaskChecklistItem
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/checklist/%d", c.host, taskID, itemID)
+	if err := c.doActorRequest(http.MethodPatch, url, actorID, permissions, req, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (c *taskClient) RemoveChecklistItem(taskID, itemID int, actorID uuid.UUID, permissions []string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/%d/checklist/%d", c.host, taskID, itemID)
+	return c.doActorRequest(http.MethodDelete, url, actorID, permissions, nil, nil)
+}
+
+// doActorRequest выполняет запрос от имени пользователя; uuid.Nil в actorID - запрос без пользователя
+func (c *taskClient) doActorRequest(method, url string, actorID uuid.UUID, permissions []string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewBuffer(payload)
+	}
+
+	httpReq, err := http.NewRequest(method, url, reader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user tasks: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if actorID != uuid.Nil {
+		setTaskActorHeaders(httpReq, actorID, permissions)
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request to task service failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("task service returned error: %s", string(bodyBytes))
+		return custom_errors.NewTaskServiceError(resp.StatusCode, string(bodyBytes))
 	}
 
-	var tasks []at.TaskToList
-	if err := json.NewDecoder(resp.Body).Decode(&tasks); err != nil {
-		return nil, fmt.Errorf("failed to decode tasks response: %w", err)
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode task service response: %w", err)
 	}
+	return nil
+}
 
-	return &tasks, nil
+// setTaskActorHeaders передаёт taskService пользователя и его глобальные права для проверки доступа
+func setTaskActorHeaders(req *http.Request, actorID uuid.UUID, permissions []string) {
+	req.Header.Set("X-User-ID", actorID.String())
+	req.Header.Set("X-User-Permissions", strings.Join(permissions, ","))
 }
 
 // GetAllStatuses - получить все статусы задач
@@ -194,14 +779,83 @@ func (c *taskClient) GetStatusByID(statusID int) (*at.TaskStatus, error) {
 	return &status, nil
 }
 
-// DeleteStatus - удалить статус задачи
-func (c *taskClient) DeleteStatus(statusID int) error {
+func (c *taskClient) GetStatusUsage(statusID int) (*at.TaskStatusUsage, error) {
+	var usage at.TaskStatusUsage
+	url := fmt.Sprintf("%s/api/v1/tasks/statuses/%d/usage", c.host, statusID)
+	if err := c.doActorRequest(http.MethodGet, url, uuid.Nil, nil, nil, &usage); err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// DeleteStatus - удалить статус задачи; задачи статуса taskService переносит в replacementID
+func (c *taskClient) DeleteStatus(statusID int, replacementID *int, actorID uuid.UUID, permissions []string) error {
 	url := fmt.Sprintf("%s/api/v1/tasks/statuses/%d", c.host, statusID)
+	if replacementID != nil {
+		url += fmt.Sprintf("?replacement_id=%d", *replacementID)
+	}
+	return c.doActorRequest(http.MethodDelete, url, actorID, permissions, nil, nil)
+}
 
-	httpReq, err := http.NewRequest(http.MethodDelete, url, nil)
+// GetAllWorkflows - получить все workflow задач
+func (c *taskClient) GetAllWorkflows() ([]at.TaskWorkflow, error) {
+	var workflows []at.TaskWorkflow
+	if err := c.doWorkflowRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/tasks/workflows", c.host), nil, &workflows); err != nil {
+		return nil, err
+	}
+	return workflows, nil
+}
+
+// GetWorkflowByID - получить workflow по ID
+func (c *taskClient) GetWorkflowByID(workflowID int) (*at.TaskWorkflow, error) {
+	var workflow at.TaskWorkflow
+	if err := c.doWorkflowRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/tasks/workflows/%d", c.host, workflowID), nil, &workflow); err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+// CreateWorkflow - создать workflow
+func (c *taskClient) CreateWorkflow(req *at.SaveWorkflowRequest) (*at.TaskWorkflow, error) {
+	var workflow at.TaskWorkflow
+	if err := c.doWorkflowRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/tasks/workflows", c.host), req, &workflow); err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+// UpdateWorkflow - полностью заменить workflow
+func (c *taskClient) UpdateWorkflow(workflowID int, req *at.SaveWorkflowRequest) (*at.TaskWorkflow, error) {
+	var workflow at.TaskWorkflow
+	if err := c.doWorkflowRequest(http.MethodPut, fmt.Sprintf("%s/api/v1/tasks/workflows/%d", c.host, workflowID), req, &workflow); err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+// DeleteWorkflow - удалить workflow
+func (c *taskClient) DeleteWorkflow(workflowID int) error {
+	return c.doWorkflowRequest(http.MethodDelete, fmt.Sprintf("%s/api/v1/tasks/workflows/%d", c.host, workflowID), nil, nil)
+}
+
+// doWorkflowRequest выполняет запрос к API workflow; при out == nil тело ответа не читается
+func (c *taskClient) doWorkflowRequest(method, url string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewBuffer(payload)
+	}
+
+	httpReq, err := http.NewRequest(method, url, reader)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
 
 	client := &http.Client{}
 	resp, err := client.Do(httpReq)
@@ -210,10 +864,16 @@ func (c *taskClient) DeleteStatus(statusID int) error {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+	if resp.StatusCode >= 400 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("task service returned error: status %d, body: %s", resp.StatusCode, string(bodyBytes))
+		return custom_errors.NewTaskServiceError(resp.StatusCode, string(bodyBytes))
 	}
 
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode workflow response: %w", err)
+	}
 	return nil
 }
@@ -2,6 +2,8 @@ package middlewares
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -40,9 +42,58 @@ func StrictRateLimitConfig() RateLimitConfig {
 	}
 }
 
+// CalendarFeedRateLimitConfig returns config for the public calendar feed.
+// Calendar clients poll the feed rarely, so the limit is much lower than for the API
+func CalendarFeedRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Limit:     30,          // 30 requests
+		Window:    time.Minute, // per minute
+		KeyPrefix: "ratelimit:calendar:",
+	}
+}
+
 // RateLimitMiddleware creates a per-user rate limiting middleware using Redis
 // Uses sliding window algorithm for accurate rate limiting
 func RateLimitMiddleware(redisClient *redis.Client, config RateLimitConfig) gin.HandlerFunc {
+	return RateLimitByKey(redisClient, config, func(c *gin.Context) (string, bool) {
+		// Get userID from context (set by JWT middleware)
+		userIDValue, exists := c.Get("userID")
+		if !exists {
+			// No user ID - skip per-user limiting (Nginx handles per-IP)
+			return "", false
+		}
+
+		userID, ok := userIDValue.(uuid.UUID)
+		if !ok {
+			return "", false
+		}
+		return userID.String(), true
+	})
+}
+
+// RateLimitByClientIP creates a per-IP rate limiting middleware for endpoints without JWT
+func RateLimitByClientIP(redisClient *redis.Client, config RateLimitConfig) gin.HandlerFunc {
+	return RateLimitByKey(redisClient, config, func(c *gin.Context) (string, bool) {
+		return "ip:" + c.ClientIP(), true
+	})
+}
+
+// RateLimitByPathParam creates a rate limiting middleware keyed by a path parameter.
+// The value is hashed so that secrets from the path (e.g. feed tokens) are not stored in Redis keys
+func RateLimitByPathParam(redisClient *redis.Client, config RateLimitConfig, param string) gin.HandlerFunc {
+	return RateLimitByKey(redisClient, config, func(c *gin.Context) (string, bool) {
+		value := c.Param(param)
+		if value == "" {
+			return "", false
+		}
+		sum := sha256.Sum256([]byte(value))
+		return param + ":" + hex.EncodeToString(sum[:]), true
+	})
+}
+
+// RateLimitByKey creates a rate limiting middleware for keys returned by keyFunc.
+// If keyFunc returns false, the request is not limited
+func RateLimitByKey(redisClient *redis.Client, config RateLimitConfig, keyFunc func(c *gin.Context) (string, bool)) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Пропускаем OPTIONS запросы (preflight)
 		if c.Request.Method == "OPTIONS" {
@@ -56,15 +107,7 @@ func RateLimitMiddleware(redisClient *redis.Client, config RateLimitConfig) gin.
 			return
 		}
 
-		// Get userID from context (set by JWT middleware)
-		userIDValue, exists := c.Get("userID")
-		if !exists {
-			// No user ID - skip per-user limiting (Nginx handles per-IP)
-			c.Next()
-			return
-		}
-
-		userID, ok := userIDValue.(uuid.UUID)
+		subject, ok := keyFunc(c)
 		if !ok {
 			c.Next()
 			return
@@ -74,7 +117,7 @@ func RateLimitMiddleware(redisClient *redis.Client, config RateLimitConfig) gin.
 		defer cancel()
 
 		// Create rate limit key
-		key := fmt.Sprintf("%s%s", config.KeyPrefix, userID.String())
+		key := fmt.Sprintf("%s%s", config.KeyPrefix, subject)
 
 		// Check and increment counter using Redis
 		allowed, remaining, resetAt, err := checkRateLimit(ctx, redisClient, key, config)
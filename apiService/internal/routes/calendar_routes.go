@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"apiService/internal/handlers"
+	"apiService/internal/middlewares"
+	"apiService/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+func RegisterCalendarRoutes(
+	router *gin.Engine,
+	calendarHandler *handlers.CalendarHandler,
+	publicKeyManager *services.PublicKeyManager,
+	sessionService *services.SessionService,
+	redisClient *redis.Client,
+	rateLimitConfig middlewares.RateLimitConfig,
+) {
+	// --- УПРАВЛЕНИЕ ССЫЛКОЙ ---
+	feed := router.Group("api/v1/tasks/calendar-feed")
+	feed.Use(
+		middlewares.JWTMiddlewareWithKeyManager(publicKeyManager, sessionService),
+		middlewares.RateLimitMiddleware(redisClient, rateLimitConfig),
+		middlewares.RequirePermission("process_tasks"),
+	)
+
+	{
+		feed.POST("", calendarHandler.IssueCalendarFeed)
+		feed.DELETE("", calendarHandler.RevokeCalendarFeed)
+	}
+
+	// --- ЛЕНТА .ICS ---
+	// Календари не умеют передавать JWT, поэтому лента доступна по токену из ссылки.
+	// Лимит по IP мешает перебору токенов, лимит по токену - опросу утёкшей ссылки с разных адресов
+	feedRateLimitConfig := middlewares.CalendarFeedRateLimitConfig()
+	router.GET("api/v1/calendar/:token",
+		middlewares.RateLimitByClientIP(redisClient, feedRateLimitConfig),
+		middlewares.RateLimitByPathParam(redisClient, feedRateLimitConfig, "token"),
+		calendarHandler.GetCalendarFeed,
+	)
+}
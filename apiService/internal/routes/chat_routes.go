@@ -27,6 +27,11 @@ func RegisterChatRoutes(router *gin.Engine, chatHandler *handlers.ChatHandler, p
 		chats.POST("/messages/:chat_id", chatHandler.SendMessage)
 		chats.GET("/messages/:chat_id", chatHandler.GetChatMessages)
 		chats.GET("/search/:chat_id", chatHandler.SearchMessages)
+		chats.GET("/saved-messages", chatHandler.GetSavedMessages)
+		chats.POST("/saved-messages/:message_id", chatHandler.SaveMessage)
+		chats.DELETE("/saved-messages/:message_id", chatHandler.DeleteSavedMessage)
+		chats.GET("/task-event-settings/:chat_id", chatHandler.GetTaskEventSettings)
+		chats.PUT("/task-event-settings/:chat_id", chatHandler.UpdateTaskEventSettings)
 	}
 }
 
@@ -29,8 +29,83 @@ func RegisterTaskRoutes(
 	{
 		// Основные маршруты задач
 		tasks.POST("", taskHandler.CreateTask)
+		// Право view_messages в чате сообщения проверяет chatService по запросу taskService
+		tasks.POST("/from-message", taskHandler.CreateTaskFromMessage)
 		tasks.PATCH("/:task_id/status/:status_id", taskHandler.UpdateTaskStatus)
 		tasks.GET("/:task_id", taskHandler.GetTaskByID)
+		tasks.PATCH("/:task_id", taskHandler.UpdateTask)
+		tasks.DELETE("/:task_id", taskHandler.DeleteTask)
+		tasks.POST("/bulk", taskHandler.BulkUpdateTasks)
+		tasks.GET("/created", taskHandler.GetCreatedTasks)
+		tasks.GET("/search", taskHandler.SearchTasks)
+		tasks.GET("/board", taskHandler.GetTaskBoard)
+		tasks.POST("/:task_id/move", taskHandler.MoveTask)
+		tasks.GET("/chat/:chat_id", taskHandler.GetChatTasks)
+		tasks.GET("/:task_id/history", taskHandler.GetTaskHistory)
+		tasks.GET("/activity", taskHandler.GetMyActivity)
+		tasks.POST("/:task_id/comments", taskHandler.CreateTaskComment)
+		tasks.GET("/:task_id/comments", taskHandler.GetTaskComments)
+		tasks.PATCH("/:task_id/comments/:comment_id", taskHandler.UpdateTaskComment)
+		tasks.DELETE("/:task_id/comments/:comment_id", taskHandler.DeleteTaskComment)
+		tasks.GET("/:task_id/tree", taskHandler.GetTaskTree)
+		tasks.GET("/:task_id/dependencies", taskHandler.GetTaskDependencies)
+		tasks.POST("/:task_id/dependencies", taskHandler.AddTaskDependency)
+		tasks.DELETE("/:task_id/dependencies/:blocker_task_id", taskHandler.RemoveTaskDependency)
+		tasks.GET("/:task_id/labels", taskHandler.GetTaskLabels)
+		tasks.POST("/:task_id/labels/:label_id", taskHandler.AddTaskLabel)
+		tasks.DELETE("/:task_id/labels/:label_id", taskHandler.RemoveTaskLabel)
+		tasks.POST("/:task_id/assignees/:user_id", taskHandler.AddTaskAssignee)
+		tasks.DELETE("/:task_id/assignees/:user_id", taskHandler.RemoveTaskAssignee)
+		tasks.POST("/:task_id/watchers/:user_id", taskHandler.AddTaskWatcher)
+		tasks.DELETE("/:task_id/watchers/:user_id", taskHandler.RemoveTaskWatcher)
+		// Списывать время могут исполнители задачи, править чужие записи - manage_all_tasks; проверяет taskService
+		tasks.POST("/:task_id/time-logs", taskHandler.CreateTaskTimeLog)
+		tasks.GET("/:task_id/time-logs", taskHandler.GetTaskTimeLogs)
+		tasks.POST("/:task_id/time-logs/start", taskHandler.StartTaskTimer)
+		tasks.POST("/:task_id/time-logs/stop", taskHandler.StopTaskTimer)
+		tasks.PATCH("/:task_id/time-logs/:log_id", taskHandler.UpdateTaskTimeLog)
+		tasks.DELETE("/:task_id/time-logs/:log_id", taskHandler.DeleteTaskTimeLog)
+		tasks.GET("/time-spent", taskHandler.GetTimeSpent)
+		tasks.GET("/:task_id/checklist", taskHandler.GetTaskChecklist)
+		tasks.POST("/:task_id/checklist", taskHandler.AddChecklistItem)
+		tasks.PATCH("/:task_id/checklist/:item_id", taskHandler.UpdateChecklistItem)
+		tasks.DELETE("/:task_id/checklist/:item_id", taskHandler.RemoveChecklistItem)
+
+		// == /api/v1/tasks/recurrences ==
+		// Права на изменение серии проверяет taskService: создатель или manage_all_tasks
+		recurrences := tasks.Group("/recurrences")
+
+		{
+			recurrences.POST("", taskHandler.CreateTaskRecurrence)
+			recurrences.GET("/:recurrence_id", taskHandler.GetTaskRecurrence)
+			recurrences.PUT("/:recurrence_id", taskHandler.UpdateTaskRecurrence)
+			recurrences.DELETE("/:recurrence_id", taskHandler.DeleteTaskRecurrence)
+		}
+
+		// == /api/v1/tasks/templates ==
+		// Права на изменение шаблона проверяет taskService: создатель или manage_all_tasks
+		templates := tasks.Group("/templates")
+
+		{
+			templates.GET("", taskHandler.GetAllTaskTemplates)
+			templates.POST("", taskHandler.CreateTaskTemplate)
+			templates.GET("/:template_id", taskHandler.GetTaskTemplate)
+			templates.PUT("/:template_id", taskHandler.UpdateTaskTemplate)
+			templates.DELETE("/:template_id", taskHandler.DeleteTaskTemplate)
+			templates.POST("/:template_id/tasks", taskHandler.CreateTaskFromTemplate)
+		}
+
+		// == /api/v1/tasks/reports ==
+		// Отчёты по задачам - руководителям команд
+		reports := tasks.Group("/reports")
+		reports.Use(middlewares.RequirePermission("view_task_reports"))
+
+		{
+			reports.GET("/throughput", taskHandler.GetTaskThroughput)
+			reports.GET("/status-durations", taskHandler.GetTaskStatusDurations)
+			reports.GET("/overdue", taskHandler.GetOverdueTasksReport)
+			reports.GET("/cumulative-flow", taskHandler.GetTaskCumulativeFlow)
+		}
 
 		// == /api/v1/tasks/statuses ==
 		statuses := tasks.Group("/statuses")
@@ -50,8 +125,49 @@ func RegisterTaskRoutes(
 
 		{
 			statusesManage.POST("", taskHandler.CreateStatus)
+			statusesManage.GET("/:status_id/usage", taskHandler.GetStatusUsage)
 			statusesManage.DELETE("/:status_id", taskHandler.DeleteStatus)
 		}
+
+		// == /api/v1/tasks/workflows ==
+		workflows := tasks.Group("/workflows")
+
+		workflowsView := workflows.Group("")
+		workflowsView.Use(middlewares.RequirePermission("view_task_statuses"))
+
+		{
+			workflowsView.GET("", taskHandler.GetAllWorkflows)
+			workflowsView.GET("/:workflow_id", taskHandler.GetWorkflowByID)
+		}
+
+		// Управление workflow - те же права, что и для статусов
+		workflowsManage := workflows.Group("")
+		workflowsManage.Use(middlewares.RequirePermission("manage_task_statuses"))
+
+		{
+			workflowsManage.POST("", taskHandler.CreateWorkflow)
+			workflowsManage.PUT("/:workflow_id", taskHandler.UpdateWorkflow)
+			workflowsManage.DELETE("/:workflow_id", taskHandler.DeleteWorkflow)
+		}
+
+		// == /api/v1/tasks/labels ==
+		// Просмотр меток - всем, кто работает с задачами
+		labels := tasks.Group("/labels")
+
+		{
+			labels.GET("", taskHandler.GetAllLabels)
+			labels.GET("/:label_id", taskHandler.GetLabelByID)
+		}
+
+		// Управление метками - только админы
+		labelsManage := labels.Group("")
+		labelsManage.Use(middlewares.RequirePermission("manage_task_labels"))
+
+		{
+			labelsManage.POST("", taskHandler.CreateLabel)
+			labelsManage.PUT("/:label_id", taskHandler.UpdateLabel)
+			labelsManage.DELETE("/:label_id", taskHandler.DeleteLabel)
+		}
 	}
 
 	// --- USER TASKS ---
@@ -1,11 +1,15 @@
 package services
 
 import (
+	"apiService/internal/custom_errors"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/redis/go-redis/v9"
+	"net/url"
 	"time"
 )
 
@@ -215,42 +219,95 @@ func (c *CacheService) SetChatUserRoleCache(ctx context.Context, chatID, userID
 
 // Специализированные методы для задач
 
-func (c *CacheService) TaskCacheKey(taskID int) string {
-	return fmt.Sprintf("%s%d", TaskCachePrefix, taskID)
+// Задачи и их списки кешируются отдельно для каждого пользователя (viewerID): taskService отдаёт
+// только видимые ему задачи. Потеря доступа, например выход из чата, отражается в кеше не позже TTL
+
+func (c *CacheService) TaskCacheKey(taskID int, viewerID string) string {
+	return fmt.Sprintf("%s%d:%s", TaskCachePrefix, taskID, viewerID)
+}
+
+func (c *CacheService) UserTasksCacheKey(userID, viewerID string) string {
+	return fmt.Sprintf("%s%s:%s", UserTasksCachePrefix, userID, viewerID)
 }
 
-func (c *CacheService) UserTasksCacheKey(userID string) string {
-	return fmt.Sprintf("%s%s", UserTasksCachePrefix, userID)
+func (c *CacheService) UserCreatedTasksCacheKey(userID, viewerID string) string {
+	return fmt.Sprintf("%screated:%s:%s", UserTasksCachePrefix, userID, viewerID)
 }
 
-func (c *CacheService) SetTaskCache(ctx context.Context, taskID int, task interface{}) error {
-	key := c.TaskCacheKey(taskID)
+func (c *CacheService) ChatTasksCacheKey(chatID, viewerID string) string {
+	return fmt.Sprintf("%schat:%s:%s", TaskCachePrefix, chatID, viewerID)
+}
+
+func (c *CacheService) SetTaskCache(ctx context.Context, taskID int, viewerID string, task interface{}) error {
+	key := c.TaskCacheKey(taskID, viewerID)
 	return c.Set(ctx, key, task, 15*time.Minute)
 }
 
-func (c *CacheService) GetTaskCache(ctx context.Context, taskID int, dest interface{}) error {
-	key := c.TaskCacheKey(taskID)
+func (c *CacheService) GetTaskCache(ctx context.Context, taskID int, viewerID string, dest interface{}) error {
+	key := c.TaskCacheKey(taskID, viewerID)
 	return c.Get(ctx, key, dest)
 }
 
+// DeleteTaskCache удаляет задачу из кеша всех пользователей
 func (c *CacheService) DeleteTaskCache(ctx context.Context, taskID int) error {
-	key := c.TaskCacheKey(taskID)
-	return c.Delete(ctx, key)
+	return c.DeleteByPattern(ctx, c.TaskCacheKey(taskID, "*"))
 }
 
-func (c *CacheService) SetUserTasksCache(ctx context.Context, userID string, tasks interface{}) error {
-	key := c.UserTasksCacheKey(userID)
+func (c *CacheService) SetUserTasksCache(ctx context.Context, userID, viewerID string, tasks interface{}) error {
+	key := c.UserTasksCacheKey(userID, viewerID)
 	return c.Set(ctx, key, tasks, 10*time.Minute)
 }
 
-func (c *CacheService) GetUserTasksCache(ctx context.Context, userID string, dest interface{}) error {
-	key := c.UserTasksCacheKey(userID)
+func (c *CacheService) GetUserTasksCache(ctx context.Context, userID, viewerID string, dest interface{}) error {
+	key := c.UserTasksCacheKey(userID, viewerID)
 	return c.Get(ctx, key, dest)
 }
 
+// DeleteUserTasksCache удаляет закешированные у всех пользователей списки задач userID: назначенные ему и созданные им
 func (c *CacheService) DeleteUserTasksCache(ctx context.Context, userID string) error {
-	key := c.UserTasksCacheKey(userID)
-	return c.Delete(ctx, key)
+	if err := c.DeleteByPattern(ctx, c.UserTasksCacheKey(userID, "*")); err != nil {
+		return err
+	}
+	return c.DeleteByPattern(ctx, c.UserCreatedTasksCacheKey(userID, "*"))
+}
+
+func (c *CacheService) DeleteChatTasksCache(ctx context.Context, chatID string) error {
+	return c.DeleteByPattern(ctx, c.ChatTasksCacheKey(chatID, "*"))
+}
+
+// DeleteAllTasksCache сбрасывает весь кеш задач, статусов, списков и поиска задач - для массовых
+// изменений, затронувших неизвестный набор задач
+func (c *CacheService) DeleteAllTasksCache(ctx context.Context) error {
+	for _, prefix := range []string{TaskCachePrefix, UserTasksCachePrefix, TaskQueryCachePrefix} {
+		if err := c.DeleteByPattern(ctx, prefix+"*"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Специализированные методы для поиска задач
+
+const TaskQueryCachePrefix = "task_query:"
+
+// TaskQueryCacheKey строит ключ по пользователю и всем параметрам поиска, включая курсор и лимит
+func (c *CacheService) TaskQueryCacheKey(query url.Values, viewerID string) string {
+	hash := sha256.Sum256([]byte(viewerID + "?" + query.Encode()))
+	return TaskQueryCachePrefix + hex.EncodeToString(hash[:])
+}
+
+func (c *CacheService) SetTaskQueryCache(ctx context.Context, query url.Values, viewerID string, result interface{}) error {
+	return c.Set(ctx, c.TaskQueryCacheKey(query, viewerID), result, 2*time.Minute) // Короткий TTL для поиска
+}
+
+func (c *CacheService) GetTaskQueryCache(ctx context.Context, query url.Values, viewerID string, dest interface{}) error {
+	return c.Get(ctx, c.TaskQueryCacheKey(query, viewerID), dest)
+}
+
+// DeleteTaskQueryCache сбрасывает все результаты поиска задач: изменённая задача может попасть
+// в выдачу или выпасть из неё при любом наборе фильтров
+func (c *CacheService) DeleteTaskQueryCache(ctx context.Context) error {
+	return c.DeleteByPattern(ctx, TaskQueryCachePrefix+"*")
 }
 
 // Специализированные методы для ролей и permissions чатов
@@ -301,3 +358,62 @@ func (c *CacheService) DeleteSearchCacheByChat(ctx context.Context, chatID strin
 	pattern := fmt.Sprintf("%s%s:*", SearchCachePrefix, chatID)
 	return c.DeleteByPattern(ctx, pattern)
 }
+
+// Специализированные методы для идемпотентной отправки сообщений
+
+const MessageUploadCachePrefix = "message_upload:"
+
+// messageUploadPending - значение ключа, пока первая отправка загружает вложения
+const messageUploadPending = "pending"
+
+// messageUploadReservationTTL ограничивает резерв, если отправка оборвалась до сохранения ID вложений
+const messageUploadReservationTTL = time.Minute
+
+func (c *CacheService) MessageUploadCacheKey(chatID, senderID, clientID string) string {
+	return fmt.Sprintf("%s%s:%s:%s", MessageUploadCachePrefix, chatID, senderID, clientID)
+}
+
+// ReserveMessageUpload атомарно (SETNX) занимает ключ загрузки вложений.
+// Возвращает false, если ключ уже занят: вложения загружены или загружаются другим запросом
+func (c *CacheService) ReserveMessageUpload(ctx context.Context, chatID, senderID, clientID string) (bool, error) {
+	key := c.MessageUploadCacheKey(chatID, senderID, clientID)
+	return c.redis.SetNX(ctx, key, messageUploadPending, messageUploadReservationTTL).Result()
+}
+
+// releaseMessageUploadScript удаляет ключ, только пока в нём резерв: готовый список вложений не трогается
+var releaseMessageUploadScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// ReleaseMessageUpload снимает резерв загрузки вложений, чтобы повтор отправки загрузил их заново
+func (c *CacheService) ReleaseMessageUpload(ctx context.Context, chatID, senderID, clientID string) error {
+	key := c.MessageUploadCacheKey(chatID, senderID, clientID)
+	return releaseMessageUploadScript.Run(ctx, c.redis, []string{key}, messageUploadPending).Err()
+}
+
+// SetMessageUploadCache сохраняет ID уже загруженных вложений, чтобы повтор отправки не загружал их заново
+func (c *CacheService) SetMessageUploadCache(ctx context.Context, chatID, senderID, clientID string, fileIDs []int) error {
+	key := c.MessageUploadCacheKey(chatID, senderID, clientID)
+	return c.Set(ctx, key, fileIDs, 24*time.Hour) // Окно повторов отправки
+}
+
+// GetMessageUploadCache возвращает ID загруженных вложений; пока загрузка зарезервирована и не закончена,
+// возвращает ErrMessageUploadInProgress
+func (c *CacheService) GetMessageUploadCache(ctx context.Context, chatID, senderID, clientID string, dest *[]int) error {
+	key := c.MessageUploadCacheKey(chatID, senderID, clientID)
+	data, err := c.redis.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return fmt.Errorf("cache miss")
+		}
+		return fmt.Errorf("failed to get cache data: %w", err)
+	}
+	if data == messageUploadPending {
+		return custom_errors.ErrMessageUploadInProgress
+	}
+
+	return json.Unmarshal([]byte(data), dest)
+}
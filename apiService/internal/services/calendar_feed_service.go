@@ -0,0 +1,127 @@
+package services
+
+import (
+	"apiService/internal/custom_errors"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// calendarFeedTxRetries - число попыток изменить токен пользователя при параллельных изменениях
+const calendarFeedTxRetries = 10
+
+// CalendarFeedService хранит токены подписки на календарь задач. Токен не связан с JWT-сессией:
+// живёт, пока пользователь его не отзовёт или не выпустит новый, и даёт доступ только к ленте .ics
+type CalendarFeedService struct {
+	redis *redis.Client
+}
+
+func NewCalendarFeedService(redisClient *redis.Client) *CalendarFeedService {
+	return &CalendarFeedService{redis: redisClient}
+}
+
+// IssueToken выпускает новый токен ленты; предыдущий токен пользователя перестаёт действовать
+func (s *CalendarFeedService) IssueToken(ctx context.Context, userID uuid.UUID) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate calendar feed token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	tokenHash := s.hashToken(token)
+
+	// В Redis хранится только хеш токена: утечка базы не раскрывает ссылки подписки.
+	// Ключ пользователя отслеживается через WATCH: при параллельном выпуске один из запросов повторится
+	// и удалит токен, записанный другим, поэтому у пользователя не остаётся неотзываемых токенов
+	userKey := s.userKey(userID)
+	err := s.updateUserToken(ctx, userKey, func(tx *redis.Tx, oldHash string) error {
+		_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if oldHash != "" {
+				pipe.Del(ctx, s.tokenKey(oldHash))
+			}
+			pipe.Set(ctx, s.tokenKey(tokenHash), userID.String(), 0)
+			pipe.Set(ctx, userKey, tokenHash, 0)
+			return nil
+		})
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to save calendar feed token: %w", err)
+	}
+	return token, nil
+}
+
+// RevokeToken отзывает токен ленты пользователя; если токена нет, ничего не делает
+func (s *CalendarFeedService) RevokeToken(ctx context.Context, userID uuid.UUID) error {
+	userKey := s.userKey(userID)
+	err := s.updateUserToken(ctx, userKey, func(tx *redis.Tx, tokenHash string) error {
+		if tokenHash == "" {
+			return nil
+		}
+		_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, s.tokenKey(tokenHash), userKey)
+			return nil
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke calendar feed token: %w", err)
+	}
+	return nil
+}
+
+// updateUserToken читает хеш текущего токена пользователя под WATCH и выполняет fn.
+// Если ключ пользователя изменился до EXEC, чтение и fn повторяются
+func (s *CalendarFeedService) updateUserToken(ctx context.Context, userKey string, fn func(tx *redis.Tx, tokenHash string) error) error {
+	txf := func(tx *redis.Tx) error {
+		tokenHash, err := tx.Get(ctx, userKey).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		return fn(tx, tokenHash)
+	}
+
+	for i := 0; i < calendarFeedTxRetries; i++ {
+		err := s.redis.Watch(ctx, txf, userKey)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return redis.TxFailedErr
+}
+
+// GetUserID возвращает владельца токена ленты
+func (s *CalendarFeedService) GetUserID(ctx context.Context, token string) (uuid.UUID, error) {
+	data, err := s.redis.Get(ctx, s.tokenKey(s.hashToken(token))).Result()
+	if errors.Is(err, redis.Nil) {
+		return uuid.Nil, custom_errors.ErrCalendarFeedTokenNotFound
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get calendar feed token: %w", err)
+	}
+
+	userID, err := uuid.Parse(data)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid calendar feed token owner: %w", err)
+	}
+	return userID, nil
+}
+
+func (s *CalendarFeedService) tokenKey(tokenHash string) string {
+	return fmt.Sprintf("calendar_feed:token:%s", tokenHash)
+}
+
+func (s *CalendarFeedService) userKey(userID uuid.UUID) string {
+	return fmt.Sprintf("calendar_feed:user:%s", userID.String())
+}
+
+// hashToken создает SHA256 хеш токена для использования в ключе Redis
+func (s *CalendarFeedService) hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
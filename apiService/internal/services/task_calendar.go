@@ -0,0 +1,118 @@
+package services
+
+import (
+	at "common/contracts/api-task"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CalendarEntryKind - вид записей ленты: события видны во всех календарях, задачи (VTODO) - в
+// приложениях, которые поддерживают списки дел
+type CalendarEntryKind string
+
+const (
+	CalendarEntryEvent CalendarEntryKind = "event"
+	CalendarEntryTodo  CalendarEntryKind = "todo"
+)
+
+const icalTimeLayout = "20060102T150405Z"
+
+// icalPriority переводит приоритет задачи в шкалу PRIORITY из RFC 5545 (1 - наивысший)
+var icalPriority = map[string]int{
+	"urgent": 1,
+	"high":   3,
+	"normal": 5,
+	"low":    9,
+}
+
+// RenderTaskCalendar собирает iCalendar (RFC 5545) из задач со сроком; задачи без срока пропускаются.
+// DTSTAMP берётся из времени изменения задачи, чтобы неизменённые записи не считались обновлёнными
+func RenderTaskCalendar(tasks []at.TaskToList, kind CalendarEntryKind) []byte {
+	var b strings.Builder
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:-//apiService//Task Calendar//RU")
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	writeICalLine(&b, "METHOD:PUBLISH")
+	writeICalLine(&b, "X-WR-CALNAME:Задачи")
+	writeICalLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT15M")
+	writeICalLine(&b, "X-PUBLISHED-TTL:PT15M")
+
+	for _, task := range tasks {
+		if task.DueAt == nil {
+			continue
+		}
+		// Начало учитывается, только если оно раньше срока: иначе запись некорректна по RFC 5545
+		hasStart := task.StartAt != nil && task.StartAt.Before(*task.DueAt)
+
+		component := "VEVENT"
+		if kind == CalendarEntryTodo {
+			component = "VTODO"
+		}
+
+		writeICalLine(&b, "BEGIN:"+component)
+		writeICalLine(&b, fmt.Sprintf("UID:task-%d@taskservice", task.ID))
+		writeICalLine(&b, "DTSTAMP:"+formatICalTime(task.UpdatedAt))
+		writeICalLine(&b, "CREATED:"+formatICalTime(task.CreatedAt))
+		writeICalLine(&b, "LAST-MODIFIED:"+formatICalTime(task.UpdatedAt))
+		writeICalLine(&b, "SUMMARY:"+escapeICalText(task.Title))
+		writeICalLine(&b, "DESCRIPTION:"+escapeICalText(fmt.Sprintf("Статус: %s\nПриоритет: %s", task.Status, task.Priority)))
+		if priority, ok := icalPriority[task.Priority]; ok {
+			writeICalLine(&b, fmt.Sprintf("PRIORITY:%d", priority))
+		}
+
+		if kind == CalendarEntryTodo {
+			if hasStart {
+				writeICalLine(&b, "DTSTART:"+formatICalTime(*task.StartAt))
+			}
+			writeICalLine(&b, "DUE:"+formatICalTime(*task.DueAt))
+		} else {
+			// Задача без даты начала показывается моментом срока
+			if hasStart {
+				writeICalLine(&b, "DTSTART:"+formatICalTime(*task.StartAt))
+				writeICalLine(&b, "DTEND:"+formatICalTime(*task.DueAt))
+			} else {
+				writeICalLine(&b, "DTSTART:"+formatICalTime(*task.DueAt))
+			}
+		}
+		writeICalLine(&b, "END:"+component)
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+func formatICalTime(t time.Time) string {
+	return t.UTC().Format(icalTimeLayout)
+}
+
+// escapeICalText экранирует значение свойства типа TEXT
+func escapeICalText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// writeICalLine пишет строку с окончанием CRLF, перенося её по 75 байт без разрыва UTF-8 символов
+func writeICalLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Строка продолжения начинается с пробела, который тоже входит в 75 байт
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
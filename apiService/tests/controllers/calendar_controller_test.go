@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"apiService/internal/controllers"
+	"apiService/internal/custom_errors"
+	"apiService/internal/dto"
+	"apiService/internal/services"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	at "common/contracts/api-task"
+	au "common/contracts/api-user"
+	uc "common/contracts/user-contracts"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newCalendarController(t *testing.T, taskClient *MockTaskClient) (*controllers.CalendarController, *services.CalendarFeedService) {
+	userClient := new(MockUserClient)
+	userClient.On("GetUserByID", mock.Anything).Return(calendarFeedOwner([]string{"process_tasks"}), nil)
+	return newCalendarControllerWithUsers(t, taskClient, userClient)
+}
+
+func newCalendarControllerWithUsers(t *testing.T, taskClient *MockTaskClient, userClient *MockUserClient) (*controllers.CalendarController, *services.CalendarFeedService) {
+	redisClient := setupTestRedis(t)
+	t.Cleanup(func() { _ = redisClient.Close() })
+	feedService := services.NewCalendarFeedService(redisClient)
+	return controllers.NewCalendarController(taskClient, userClient, feedService, "https://api.example.com/"), feedService
+}
+
+// calendarFeedOwner - владелец ленты с ролью, в которой есть только перечисленные права
+func calendarFeedOwner(permissions []string) *au.GetUserResponse {
+	role := uc.Role{ID: 1, Name: "member"}
+	for i, name := range permissions {
+		role.Permissions = append(role.Permissions, uc.Permission{ID: i + 1, Name: name})
+	}
+	return &au.GetUserResponse{User: &uc.User{ID: uuid.New(), Role: role}}
+}
+
+// calendarFilter проверяет, что лента запрашивает задачи со сроком по возрастанию срока
+var calendarFilter = mock.MatchedBy(func(filter *dto.TaskListFilterGateway) bool {
+	return filter != nil && filter.DueFrom != nil && filter.SortBy == "due_at" && filter.Order == "asc"
+})
+
+func TestCalendarController_IssueFeedToken_ReturnsFeedURL(t *testing.T) {
+	controller, feedService := newCalendarController(t, new(MockTaskClient))
+	userID := uuid.New()
+
+	result, err := controller.IssueFeedToken(userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.example.com/api/v1/calendar/"+result.Token+".ics", result.URL)
+	owner, err := feedService.GetUserID(context.Background(), result.Token)
+	require.NoError(t, err)
+	assert.Equal(t, userID, owner)
+}
+
+func TestCalendarController_GetFeed_ReadsTasksAsOwner(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	controller, feedService := newCalendarController(t, mockTaskClient)
+	userID := uuid.New()
+	token, err := feedService.IssueToken(context.Background(), userID)
+	require.NoError(t, err)
+
+	due := time.Now().Add(24 * time.Hour)
+	fullPage := make([]at.TaskToList, 100)
+	for i := range fullPage {
+		fullPage[i] = at.TaskToList{ID: i + 1, Title: "Задача", DueAt: &due}
+	}
+	lastPage := []at.TaskToList{{ID: 101, Title: "Последняя", DueAt: &due}}
+
+	// Без прав администратора: в ленту попадают только задачи, которые видит владелец токена
+	mockTaskClient.On("GetUserTasks", userID.String(), userID, []string(nil), calendarFilter, 100, 0).Return(&fullPage, nil).Once()
+	mockTaskClient.On("GetUserTasks", userID.String(), userID, []string(nil), calendarFilter, 100, 100).Return(&lastPage, nil).Once()
+
+	feed, err := controller.GetFeed(token, services.CalendarEntryEvent)
+
+	require.NoError(t, err)
+	assert.Equal(t, 101, strings.Count(string(feed), "BEGIN:VEVENT"))
+	assert.Contains(t, string(feed), "UID:task-101@taskservice")
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestCalendarController_GetFeed_RevokedToken(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	controller, _ := newCalendarController(t, mockTaskClient)
+	userID := uuid.New()
+	result, err := controller.IssueFeedToken(userID)
+	require.NoError(t, err)
+	require.NoError(t, controller.RevokeFeedToken(userID))
+
+	_, err = controller.GetFeed(result.Token, services.CalendarEntryEvent)
+
+	assert.ErrorIs(t, err, custom_errors.ErrCalendarFeedTokenNotFound)
+	mockTaskClient.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCalendarController_GetFeed_TaskServiceError(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	controller, feedService := newCalendarController(t, mockTaskClient)
+	userID := uuid.New()
+	token, err := feedService.IssueToken(context.Background(), userID)
+	require.NoError(t, err)
+
+	mockTaskClient.On("GetUserTasks", userID.String(), userID, []string(nil), calendarFilter, 100, 0).
+		Return(nil, errors.New("task service unavailable"))
+
+	feed, err := controller.GetFeed(token, services.CalendarEntryTodo)
+
+	assert.Error(t, err)
+	assert.Nil(t, feed)
+}
+
+func TestCalendarController_GetFeed_OwnerLostPermission(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	mockUserClient := new(MockUserClient)
+	controller, feedService := newCalendarControllerWithUsers(t, mockTaskClient, mockUserClient)
+	userID := uuid.New()
+	token, err := feedService.IssueToken(context.Background(), userID)
+	require.NoError(t, err)
+
+	mockUserClient.On("GetUserByID", userID.String()).Return(calendarFeedOwner([]string{"view_profile"}), nil)
+
+	_, err = controller.GetFeed(token, services.CalendarEntryEvent)
+
+	assert.ErrorIs(t, err, custom_errors.ErrCalendarFeedTokenNotFound)
+	mockTaskClient.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	// Токен отзывается, и следующий запрос уже не доходит до сервиса пользователей
+	_, err = feedService.GetUserID(context.Background(), token)
+	assert.ErrorIs(t, err, custom_errors.ErrCalendarFeedTokenNotFound)
+}
+
+func TestCalendarController_GetFeed_UserServiceError(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	mockUserClient := new(MockUserClient)
+	controller, feedService := newCalendarControllerWithUsers(t, mockTaskClient, mockUserClient)
+	userID := uuid.New()
+	token, err := feedService.IssueToken(context.Background(), userID)
+	require.NoError(t, err)
+
+	mockUserClient.On("GetUserByID", userID.String()).Return(nil, errors.New("user service unavailable"))
+
+	_, err = controller.GetFeed(token, services.CalendarEntryEvent)
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, custom_errors.ErrCalendarFeedTokenNotFound)
+	// Сбой сервиса пользователей не отзывает токен
+	owner, err := feedService.GetUserID(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, userID, owner)
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"apiService/internal/controllers"
+	"apiService/internal/custom_errors"
 	"apiService/internal/dto"
 	"apiService/internal/services"
 	"context"
@@ -291,6 +292,129 @@ func TestChatController_SendMessage_Success(t *testing.T) {
 	mockChatClient.AssertExpectations(t)
 }
 
+func TestChatController_SendMessage_RetrySkipsUpload(t *testing.T) {
+	// Arrange
+	mockChatClient := new(MockChatClient)
+	mockFileClient := new(MockFileClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+
+	controller := controllers.NewChatController(mockChatClient, mockFileClient, cacheService)
+
+	chatID := uuid.New()
+	senderID := uuid.New()
+	clientID := "client-msg-1"
+	fileHeader := &multipart.FileHeader{Filename: "file1.txt"}
+	req := &dto.SendMessageRequestGateway{
+		Content:  "Test message with file",
+		Files:    []*multipart.FileHeader{fileHeader},
+		ClientID: &clientID,
+	}
+
+	expectedMessage := &ac.MessageResponse{
+		ID:       uuid.New(),
+		ChatID:   chatID,
+		SenderID: senderID,
+		Content:  "Test message with file",
+	}
+
+	mockFileClient.On("UploadFile", fileHeader).Return(&af.FileUploadResponse{ID: intPtr(7)}, nil).Once()
+	mockChatClient.On("SendMessage", chatID, senderID, mock.MatchedBy(func(r *ac.CreateMessageRequest) bool {
+		return r.ClientID != nil && *r.ClientID == clientID && len(r.FileIDs) == 1 && r.FileIDs[0] == 7
+	})).Return(expectedMessage, nil).Twice()
+
+	// Act - первая отправка и повтор с тем же clientID
+	first, err := controller.SendMessage(chatID, senderID, req)
+	require.NoError(t, err)
+	second, err := controller.SendMessage(chatID, senderID, req)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, first.ID, second.ID)
+	mockFileClient.AssertNumberOfCalls(t, "UploadFile", 1)
+	mockChatClient.AssertExpectations(t)
+}
+
+func TestChatController_SendMessage_ConcurrentRetryDoesNotUploadAgain(t *testing.T) {
+	// Arrange
+	mockChatClient := new(MockChatClient)
+	mockFileClient := new(MockFileClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+
+	controller := controllers.NewChatController(mockChatClient, mockFileClient, cacheService)
+
+	chatID := uuid.New()
+	senderID := uuid.New()
+	clientID := "client-msg-1"
+	req := &dto.SendMessageRequestGateway{
+		Content:  "Test message with file",
+		Files:    []*multipart.FileHeader{{Filename: "file1.txt"}},
+		ClientID: &clientID,
+	}
+
+	// Первая отправка зарезервировала загрузку и ещё не закончила её
+	reserved, err := cacheService.ReserveMessageUpload(context.Background(), chatID.String(), senderID.String(), clientID)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	// Act
+	result, err := controller.SendMessage(chatID, senderID, req)
+
+	// Assert
+	assert.ErrorIs(t, err, custom_errors.ErrMessageUploadInProgress)
+	assert.Nil(t, result)
+	mockFileClient.AssertNotCalled(t, "UploadFile", mock.Anything)
+	mockChatClient.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatController_SendMessage_RetryAfterFailedUploadUploadsAgain(t *testing.T) {
+	// Arrange
+	mockChatClient := new(MockChatClient)
+	mockFileClient := new(MockFileClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+
+	controller := controllers.NewChatController(mockChatClient, mockFileClient, cacheService)
+
+	chatID := uuid.New()
+	senderID := uuid.New()
+	clientID := "client-msg-1"
+	fileHeader1 := &multipart.FileHeader{Filename: "file1.txt"}
+	fileHeader2 := &multipart.FileHeader{Filename: "file2.txt"}
+	req := &dto.SendMessageRequestGateway{
+		Content:  "Test message with files",
+		Files:    []*multipart.FileHeader{fileHeader1, fileHeader2},
+		ClientID: &clientID,
+	}
+
+	mockFileClient.On("UploadFile", fileHeader1).Return(&af.FileUploadResponse{ID: intPtr(1)}, nil).Once()
+	mockFileClient.On("UploadFile", fileHeader2).Return(nil, errors.New("upload error")).Once()
+	mockFileClient.On("UploadFile", fileHeader1).Return(&af.FileUploadResponse{ID: intPtr(3)}, nil).Once()
+	mockFileClient.On("UploadFile", fileHeader2).Return(&af.FileUploadResponse{ID: intPtr(4)}, nil).Once()
+	mockChatClient.On("SendMessage", chatID, senderID, mock.MatchedBy(func(r *ac.CreateMessageRequest) bool {
+		return assert.ObjectsAreEqual([]int{3, 4}, r.FileIDs)
+	})).Return(&ac.MessageResponse{ID: uuid.New(), ChatID: chatID, SenderID: senderID}, nil).Once()
+
+	// Act - первая отправка не загрузила один файл, повтор с тем же clientID
+	_, errFirst := controller.SendMessage(chatID, senderID, req)
+	second, errSecond := controller.SendMessage(chatID, senderID, req)
+
+	// Assert - сообщение не отправлено без вложения, повтор загрузил все файлы заново
+	require.Error(t, errFirst)
+	require.NoError(t, errSecond)
+	assert.NotNil(t, second)
+	mockFileClient.AssertExpectations(t)
+	mockChatClient.AssertExpectations(t)
+
+	var cached []int
+	require.NoError(t, cacheService.GetMessageUploadCache(context.Background(), chatID.String(), senderID.String(), clientID, &cached))
+	assert.Equal(t, []int{3, 4}, cached)
+}
+
 func TestChatController_SendMessage_Success_WithFiles(t *testing.T) {
 	// Arrange
 	mockChatClient := new(MockChatClient)
@@ -700,19 +824,22 @@ func TestChatController_GetChatMembers_Success(t *testing.T) {
 	controller := controllers.NewChatController(mockChatClient, mockFileClient, cacheService)
 
 	chatID := uuid.New()
-	expectedMembers := []*ac.ChatMember{
-		{UserID: uuid.New().String(), RoleID: 1, RoleName: "user"},
+	query := &ac.ChatMembersQuery{Offset: 0, Limit: 50, Role: "main"}
+	expectedMembers := &ac.ChatMembersResponse{
+		Members: []*ac.ChatMember{{UserID: uuid.New().String(), RoleID: 1, RoleName: "main", Username: "user"}},
+		Total:   1,
 	}
 
-	mockChatClient.On("GetChatMembers", chatID).Return(expectedMembers, nil)
+	mockChatClient.On("GetChatMembers", chatID, query).Return(expectedMembers, nil)
 
 	// Act
-	result, err := controller.GetChatMembers(chatID)
+	result, err := controller.GetChatMembers(chatID, query)
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Len(t, result, 1)
+	assert.Len(t, result.Members, 1)
+	assert.Equal(t, int64(1), result.Total)
 
 	mockChatClient.AssertExpectations(t)
 }
@@ -1139,3 +1266,54 @@ func TestChatController_ChangeUserRole_ServiceError(t *testing.T) {
 
 	mockChatClient.AssertExpectations(t)
 }
+
+func TestChatController_GetSavedMessages_Success(t *testing.T) {
+	// Arrange
+	mockChatClient := new(MockChatClient)
+	mockFileClient := new(MockFileClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+
+	controller := controllers.NewChatController(mockChatClient, mockFileClient, cacheService)
+
+	userID := uuid.New()
+	expected := &ac.GetSavedMessagesResponse{Total: 0}
+
+	mockChatClient.On("GetSavedMessages", userID, 0, 20).Return(expected, nil)
+
+	// Act
+	result, err := controller.GetSavedMessages(userID, 0, 20)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+
+	mockChatClient.AssertExpectations(t)
+}
+
+func TestChatController_SaveMessage_ServiceError(t *testing.T) {
+	// Arrange
+	mockChatClient := new(MockChatClient)
+	mockFileClient := new(MockFileClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+
+	controller := controllers.NewChatController(mockChatClient, mockFileClient, cacheService)
+
+	userID := uuid.New()
+	messageID := uuid.New()
+	serviceError := errors.New("service error")
+
+	mockChatClient.On("SaveMessage", userID, messageID, mock.Anything).Return(nil, serviceError)
+
+	// Act
+	result, err := controller.SaveMessage(userID, messageID, &dto.SaveMessageRequestGateway{})
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+
+	mockChatClient.AssertExpectations(t)
+}
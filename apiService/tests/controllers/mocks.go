@@ -513,12 +513,49 @@ func (m *MockChatClient) GetMyRoleInChat(chatID, userID uuid.UUID) (*ac.MyRoleRe
 	return args.Get(0).(*ac.MyRoleResponse), args.Error(1)
 }
 
-func (m *MockChatClient) GetChatMembers(chatID uuid.UUID) ([]*ac.ChatMember, error) {
-	args := m.Called(chatID)
+func (m *MockChatClient) GetChatMembers(chatID uuid.UUID, query *ac.ChatMembersQuery) (*ac.ChatMembersResponse, error) {
+	args := m.Called(chatID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ac.ChatMembersResponse), args.Error(1)
+}
+
+func (m *MockChatClient) SaveMessage(userID, messageID uuid.UUID, req *ac.SaveMessageRequest) (*ac.SavedMessage, error) {
+	args := m.Called(userID, messageID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ac.SavedMessage), args.Error(1)
+}
+
+func (m *MockChatClient) GetSavedMessages(userID uuid.UUID, offset, limit int) (*ac.GetSavedMessagesResponse, error) {
+	args := m.Called(userID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ac.GetSavedMessagesResponse), args.Error(1)
+}
+
+func (m *MockChatClient) DeleteSavedMessage(userID, messageID uuid.UUID) error {
+	args := m.Called(userID, messageID)
+	return args.Error(0)
+}
+
+func (m *MockChatClient) GetTaskEventSettings(chatID, userID uuid.UUID) (*ac.TaskEventSettings, error) {
+	args := m.Called(chatID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ac.TaskEventSettings), args.Error(1)
+}
+
+func (m *MockChatClient) UpdateTaskEventSettings(chatID, userID uuid.UUID, req *ac.UpdateTaskEventSettingsRequest) (*ac.TaskEventSettings, error) {
+	args := m.Called(chatID, userID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*ac.ChatMember), args.Error(1)
+	return args.Get(0).(*ac.TaskEventSettings), args.Error(1)
 }
 
 // MockTaskClient - мок для TaskClient
@@ -526,35 +563,411 @@ type MockTaskClient struct {
 	mock.Mock
 }
 
-func (m *MockTaskClient) CreateTask(req *at.CreateTaskRequest) (*at.TaskResponse, error) {
-	args := m.Called(req)
+func (m *MockTaskClient) CreateTask(actorID uuid.UUID, permissions []string, req *at.CreateTaskRequest) (*at.TaskResponse, error) {
+	args := m.Called(actorID, permissions, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*at.TaskResponse), args.Error(1)
 }
 
-func (m *MockTaskClient) UpdateTaskStatus(taskID, statusID int) error {
-	args := m.Called(taskID, statusID)
+func (m *MockTaskClient) UpdateTaskStatus(taskID, statusID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, statusID, actorID, permissions)
 	return args.Error(0)
 }
 
-func (m *MockTaskClient) GetTaskByID(taskID int) (*at.TaskServiceResponse, error) {
-	args := m.Called(taskID)
+func (m *MockTaskClient) GetTaskByID(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskServiceResponse, error) {
+	args := m.Called(taskID, actorID, permissions)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*at.TaskServiceResponse), args.Error(1)
 }
 
-func (m *MockTaskClient) GetUserTasks(userID string, limit, offset int) (*[]at.TaskToList, error) {
-	args := m.Called(userID, limit, offset)
+func (m *MockTaskClient) GetUserTasks(userID string, actorID uuid.UUID, permissions []string, filter *dto.TaskListFilterGateway, limit, offset int) (*[]at.TaskToList, error) {
+	args := m.Called(userID, actorID, permissions, filter, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*[]at.TaskToList), args.Error(1)
 }
 
+func (m *MockTaskClient) UpdateTask(taskID int, actorID uuid.UUID, permissions []string, req *at.UpdateTaskRequest) (*at.TaskResponse, error) {
+	args := m.Called(taskID, actorID, permissions, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskResponse), args.Error(1)
+}
+
+func (m *MockTaskClient) DeleteTask(taskID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) BulkUpdateTasks(actorID uuid.UUID, permissions []string, req *at.TaskBulkRequest) (*at.TaskBulkResult, error) {
+	args := m.Called(actorID, permissions, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskBulkResult), args.Error(1)
+}
+
+func (m *MockTaskClient) GetCreatedTasks(userID string, actorID uuid.UUID, permissions []string, limit, offset int) (*[]at.TaskToList, error) {
+	args := m.Called(userID, actorID, permissions, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]at.TaskToList), args.Error(1)
+}
+
+func (m *MockTaskClient) GetChatTasks(chatID string, actorID uuid.UUID, permissions []string, limit, offset int) (*[]at.TaskToList, error) {
+	args := m.Called(chatID, actorID, permissions, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]at.TaskToList), args.Error(1)
+}
+
+func (m *MockTaskClient) GetTaskHistory(taskID int, actorID uuid.UUID, permissions []string, limit, offset int) (*[]at.TaskActivity, error) {
+	args := m.Called(taskID, actorID, permissions, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]at.TaskActivity), args.Error(1)
+}
+
+func (m *MockTaskClient) GetUserActivity(userID string, actorID uuid.UUID, permissions []string, limit, offset int) (*[]at.TaskActivity, error) {
+	args := m.Called(userID, actorID, permissions, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]at.TaskActivity), args.Error(1)
+}
+
+func (m *MockTaskClient) CreateTaskComment(taskID int, actorID uuid.UUID, req *at.CreateTaskCommentRequest) (*at.TaskComment, error) {
+	args := m.Called(taskID, actorID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskComment), args.Error(1)
+}
+
+func (m *MockTaskClient) UpdateTaskComment(taskID, commentID int, actorID uuid.UUID, req *at.UpdateTaskCommentRequest) (*at.TaskComment, error) {
+	args := m.Called(taskID, commentID, actorID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskComment), args.Error(1)
+}
+
+func (m *MockTaskClient) DeleteTaskComment(taskID, commentID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, commentID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) GetTaskComments(taskID int, actorID uuid.UUID, permissions []string, limit, offset int) ([]at.TaskComment, error) {
+	args := m.Called(taskID, actorID, permissions, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TaskComment), args.Error(1)
+}
+
+func (m *MockTaskClient) GetTaskTree(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskTreeNode, error) {
+	args := m.Called(taskID, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTreeNode), args.Error(1)
+}
+
+func (m *MockTaskClient) GetTaskDependencies(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskDependencyGraph, error) {
+	args := m.Called(taskID, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskDependencyGraph), args.Error(1)
+}
+
+func (m *MockTaskClient) AddTaskDependency(taskID, blockerTaskID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, blockerTaskID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) RemoveTaskDependency(taskID, blockerTaskID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, blockerTaskID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) GetTaskLabels(taskID int, actorID uuid.UUID, permissions []string) ([]at.TaskLabel, error) {
+	args := m.Called(taskID, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TaskLabel), args.Error(1)
+}
+
+func (m *MockTaskClient) AddTaskLabel(taskID, labelID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, labelID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) RemoveTaskLabel(taskID, labelID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, labelID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) AddTaskAssignee(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, userID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) RemoveTaskAssignee(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, userID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) AddTaskWatcher(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, userID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) RemoveTaskWatcher(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, userID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) StartTaskTimer(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error) {
+	args := m.Called(taskID, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskClient) StopTaskTimer(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error) {
+	args := m.Called(taskID, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskClient) CreateTaskTimeLog(taskID int, actorID uuid.UUID, req *at.CreateTaskTimeLogRequest) (*at.TaskTimeLog, error) {
+	args := m.Called(taskID, actorID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskClient) UpdateTaskTimeLog(taskID, logID int, actorID uuid.UUID, permissions []string, req *at.UpdateTaskTimeLogRequest) (*at.TaskTimeLog, error) {
+	args := m.Called(taskID, logID, actorID, permissions, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskClient) DeleteTaskTimeLog(taskID, logID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, logID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) GetTaskTimeLogs(taskID int, actorID uuid.UUID, permissions []string, limit, offset int) ([]at.TaskTimeLog, error) {
+	args := m.Called(taskID, actorID, permissions, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskClient) GetTimeSpent(actorID uuid.UUID, permissions []string, query *dto.TimeSpentQueryGateway) ([]at.TimeSpent, error) {
+	args := m.Called(actorID, permissions, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TimeSpent), args.Error(1)
+}
+
+func (m *MockTaskClient) GetAllLabels() ([]at.Label, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.Label), args.Error(1)
+}
+
+func (m *MockTaskClient) GetLabelByID(labelID int) (*at.Label, error) {
+	args := m.Called(labelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.Label), args.Error(1)
+}
+
+func (m *MockTaskClient) CreateLabel(req *at.SaveLabelRequest) (*at.Label, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.Label), args.Error(1)
+}
+
+func (m *MockTaskClient) UpdateLabel(labelID int, req *at.SaveLabelRequest) (*at.Label, error) {
+	args := m.Called(labelID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.Label), args.Error(1)
+}
+
+func (m *MockTaskClient) DeleteLabel(labelID int) error {
+	args := m.Called(labelID)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) CreateTaskFromMessage(actorID uuid.UUID, req *at.CreateTaskFromMessageRequest) (*at.TaskResponse, error) {
+	args := m.Called(actorID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskResponse), args.Error(1)
+}
+
+func (m *MockTaskClient) CreateTaskRecurrence(actorID uuid.UUID, req *at.SaveTaskRecurrenceRequest) (*at.TaskRecurrence, error) {
+	args := m.Called(actorID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskRecurrence), args.Error(1)
+}
+
+func (m *MockTaskClient) GetTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string) (*at.TaskRecurrence, error) {
+	args := m.Called(recurrenceID, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskRecurrence), args.Error(1)
+}
+
+func (m *MockTaskClient) UpdateTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string, req *at.SaveTaskRecurrenceRequest) (*at.TaskRecurrence, error) {
+	args := m.Called(recurrenceID, actorID, permissions, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskRecurrence), args.Error(1)
+}
+
+func (m *MockTaskClient) DeleteTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(recurrenceID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) GetTaskReport(report string, actorID uuid.UUID, permissions []string, query *dto.TaskReportQueryGateway) (*dto.TaskReport, error) {
+	args := m.Called(report, actorID, permissions, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TaskReport), args.Error(1)
+}
+
+func (m *MockTaskClient) GetAllTaskTemplates(actorID uuid.UUID, permissions []string) ([]at.TaskTemplate, error) {
+	args := m.Called(actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskClient) GetTaskTemplate(templateID int, actorID uuid.UUID, permissions []string) (*at.TaskTemplate, error) {
+	args := m.Called(templateID, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskClient) CreateTaskTemplate(actorID uuid.UUID, req *at.SaveTaskTemplateRequest) (*at.TaskTemplate, error) {
+	args := m.Called(actorID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskClient) UpdateTaskTemplate(templateID int, actorID uuid.UUID, permissions []string, req *at.SaveTaskTemplateRequest) (*at.TaskTemplate, error) {
+	args := m.Called(templateID, actorID, permissions, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskClient) DeleteTaskTemplate(templateID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(templateID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) CreateTaskFromTemplate(templateID int, actorID uuid.UUID, permissions []string, req *at.CreateTaskFromTemplateRequest) (*at.TaskResponse, error) {
+	args := m.Called(templateID, actorID, permissions, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskResponse), args.Error(1)
+}
+
+func (m *MockTaskClient) GetTaskChecklist(taskID int, actorID uuid.UUID, permissions []string) ([]at.TaskChecklistItem, error) {
+	args := m.Called(taskID, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskClient) AddChecklistItem(taskID int, actorID uuid.UUID, permissions []string, req *at.AddChecklistItemRequest) (*at.TaskChecklistItem, error) {
+	args := m.Called(taskID, actorID, permissions, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskClient) UpdateChecklistItem(taskID, itemID int, actorID uuid.UUID, permissions []string, req *at.UpdateChecklistItemRequest) (*at.TaskChecklistItem, error) {
+	args := m.Called(taskID, itemID, actorID, permissions, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskClient) RemoveChecklistItem(taskID, itemID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, itemID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) QueryTasks(actorID uuid.UUID, permissions []string, query *dto.TaskQueryGateway) (*at.TaskQueryResult, error) {
+	args := m.Called(actorID, permissions, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskQueryResult), args.Error(1)
+}
+
+func (m *MockTaskClient) GetTaskBoard(actorID uuid.UUID, permissions []string, query *dto.TaskBoardQueryGateway) (*at.TaskBoard, error) {
+	args := m.Called(actorID, permissions, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskBoard), args.Error(1)
+}
+
+func (m *MockTaskClient) MoveTask(taskID int, actorID uuid.UUID, permissions []string, req *at.MoveTaskRequest) error {
+	args := m.Called(taskID, actorID, permissions, req)
+	return args.Error(0)
+}
+
 func (m *MockTaskClient) GetAllStatuses() ([]at.TaskStatus, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
@@ -579,8 +992,53 @@ func (m *MockTaskClient) GetStatusByID(statusID int) (*at.TaskStatus, error) {
 	return args.Get(0).(*at.TaskStatus), args.Error(1)
 }
 
-func (m *MockTaskClient) DeleteStatus(statusID int) error {
+func (m *MockTaskClient) GetStatusUsage(statusID int) (*at.TaskStatusUsage, error) {
 	args := m.Called(statusID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskStatusUsage), args.Error(1)
+}
+
+func (m *MockTaskClient) DeleteStatus(statusID int, replacementID *int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(statusID, replacementID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskClient) GetAllWorkflows() ([]at.TaskWorkflow, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskClient) GetWorkflowByID(workflowID int) (*at.TaskWorkflow, error) {
+	args := m.Called(workflowID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskClient) CreateWorkflow(req *at.SaveWorkflowRequest) (*at.TaskWorkflow, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskClient) UpdateWorkflow(workflowID int, req *at.SaveWorkflowRequest) (*at.TaskWorkflow, error) {
+	args := m.Called(workflowID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskClient) DeleteWorkflow(workflowID int) error {
+	args := m.Called(workflowID)
 	return args.Error(0)
 }
 
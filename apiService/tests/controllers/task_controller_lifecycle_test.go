@@ -0,0 +1,739 @@
+package controllers
+
+import (
+	"apiService/internal/controllers"
+	"apiService/internal/custom_errors"
+	"apiService/internal/dto"
+	"apiService/internal/services"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	af "common/contracts/api-file"
+	at "common/contracts/api-task"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Тесты для TaskController.UpdateTask
+
+func TestTaskController_UpdateTask_InvalidatesCaches(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	mockFileClient := new(MockFileClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, mockFileClient, cacheService)
+	ctx := context.Background()
+
+	taskID := 7
+	actorID := uuid.New()
+	creatorID := uuid.New()
+	oldExecutorID := uuid.New()
+	newExecutorID := uuid.New()
+	chatID := uuid.New()
+	permissions := []string{"process_tasks"}
+	newExecutor := newExecutorID.String()
+	title := "Updated"
+
+	assigneeID := uuid.New()
+	watcherID := uuid.New()
+	previous := &at.TaskServiceResponse{Task: &at.TaskResponse{
+		ID: taskID, CreatorID: creatorID, ExecutorID: &oldExecutorID, ChatID: &chatID,
+		Assignees: []at.TaskMember{{UserID: assigneeID}}, Watchers: []at.TaskMember{{UserID: watcherID}},
+	}}
+	updated := &at.TaskResponse{ID: taskID, Title: title, CreatorID: creatorID, ExecutorID: &newExecutorID}
+
+	for _, key := range []string{
+		cacheService.TaskCacheKey(taskID, testViewer.String()),
+		cacheService.UserTasksCacheKey(oldExecutorID.String(), testViewer.String()),
+		cacheService.UserTasksCacheKey(newExecutorID.String(), testViewer.String()),
+		cacheService.UserCreatedTasksCacheKey(creatorID.String(), testViewer.String()),
+		cacheService.ChatTasksCacheKey(chatID.String(), testViewer.String()),
+		cacheService.UserTasksCacheKey(assigneeID.String(), testViewer.String()),
+		cacheService.UserTasksCacheKey(watcherID.String(), testViewer.String()),
+	} {
+		require.NoError(t, cacheService.Set(ctx, key, []int{1}, 0))
+	}
+
+	mockTaskClient.On("GetTaskByID", taskID, mock.Anything, mock.Anything).Return(previous, nil)
+	mockTaskClient.On("UpdateTask", taskID, actorID, permissions, mock.MatchedBy(func(req *at.UpdateTaskRequest) bool {
+		return *req.Title == title && *req.ExecutorID == newExecutorID && req.ChatID == nil
+	})).Return(updated, nil)
+
+	result, err := controller.UpdateTask(taskID, &dto.UpdateTaskRequestGateway{
+		Title:      &title,
+		ExecutorID: &newExecutor,
+	}, actorID, permissions)
+
+	require.NoError(t, err)
+	assert.Equal(t, title, result.Title)
+	for _, key := range []string{
+		cacheService.TaskCacheKey(taskID, testViewer.String()),
+		cacheService.UserTasksCacheKey(oldExecutorID.String(), testViewer.String()),
+		cacheService.UserTasksCacheKey(newExecutorID.String(), testViewer.String()),
+		cacheService.UserCreatedTasksCacheKey(creatorID.String(), testViewer.String()),
+		cacheService.ChatTasksCacheKey(chatID.String(), testViewer.String()),
+		cacheService.UserTasksCacheKey(assigneeID.String(), testViewer.String()),
+		cacheService.UserTasksCacheKey(watcherID.String(), testViewer.String()),
+	} {
+		exists, _ := cacheService.Exists(ctx, key)
+		assert.False(t, exists, key)
+	}
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestTaskController_UpdateTask_InvalidExecutorID(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+
+	executor := "not-a-uuid"
+	_, err := controller.UpdateTask(1, &dto.UpdateTaskRequestGateway{ExecutorID: &executor}, uuid.New(), nil)
+
+	require.Error(t, err)
+	mockTaskClient.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskController_UpdateTask_EmptyChatIDDetachesChat(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+
+	actorID := uuid.New()
+	emptyChat := ""
+	mockTaskClient.On("GetTaskByID", 1, mock.Anything, mock.Anything).Return(nil, errors.New("not cached"))
+	mockTaskClient.On("UpdateTask", 1, actorID, []string(nil), mock.MatchedBy(func(req *at.UpdateTaskRequest) bool {
+		return req.ChatID != nil && *req.ChatID == uuid.Nil
+	})).Return(&at.TaskResponse{ID: 1, CreatorID: actorID}, nil)
+
+	_, err := controller.UpdateTask(1, &dto.UpdateTaskRequestGateway{ChatID: &emptyChat}, actorID, nil)
+
+	require.NoError(t, err)
+	mockTaskClient.AssertExpectations(t)
+}
+
+// Тесты для TaskController.DeleteTask
+
+func TestTaskController_DeleteTask_Success(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	permissions := []string{"manage_all_tasks"}
+	previous := &at.TaskServiceResponse{Task: &at.TaskResponse{ID: 3, CreatorID: actorID}}
+	require.NoError(t, cacheService.SetTaskCache(ctx, 3, testViewer.String(), previous))
+	require.NoError(t, cacheService.SetUserTasksCache(ctx, actorID.String(), testViewer.String(), []at.TaskToList{}))
+
+	mockTaskClient.On("GetTaskByID", 3, mock.Anything, mock.Anything).Return(previous, nil)
+	mockTaskClient.On("DeleteTask", 3, actorID, permissions).Return(nil)
+
+	err := controller.DeleteTask(3, actorID, permissions)
+
+	require.NoError(t, err)
+	exists, _ := cacheService.Exists(ctx, cacheService.TaskCacheKey(3, testViewer.String()))
+	assert.False(t, exists)
+	exists, _ = cacheService.Exists(ctx, cacheService.UserTasksCacheKey(actorID.String(), testViewer.String()))
+	assert.False(t, exists)
+}
+
+func TestTaskController_DeleteTask_Forbidden(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	require.NoError(t, cacheService.SetTaskCache(ctx, 3, testViewer.String(), map[string]int{"id": 3}))
+	mockTaskClient.On("GetTaskByID", 3, mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+	mockTaskClient.On("DeleteTask", 3, actorID, []string(nil)).
+		Return(custom_errors.NewTaskServiceError(http.StatusForbidden, "denied"))
+
+	err := controller.DeleteTask(3, actorID, nil)
+
+	var taskErr *custom_errors.TaskServiceError
+	require.True(t, errors.As(err, &taskErr))
+	assert.Equal(t, http.StatusForbidden, taskErr.StatusCode)
+	exists, _ := cacheService.Exists(ctx, cacheService.TaskCacheKey(3, testViewer.String()))
+	assert.True(t, exists, "cache must stay intact when deletion fails")
+}
+
+// Тесты для TaskController.BulkUpdateTasks
+
+func TestTaskController_BulkUpdateTasks_InvalidatesChangedTasks(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	oldExecutorID := uuid.New()
+	newExecutorID := uuid.New()
+	req := &at.TaskBulkRequest{TaskIDs: []int{3, 4}, Action: at.TaskBulkReassign, ExecutorID: &newExecutorID}
+	require.NoError(t, cacheService.SetTaskCache(ctx, 3, testViewer.String(), map[string]int{"id": 3}))
+	require.NoError(t, cacheService.SetTaskCache(ctx, 4, testViewer.String(), map[string]int{"id": 4}))
+	require.NoError(t, cacheService.SetUserTasksCache(ctx, oldExecutorID.String(), testViewer.String(), []at.TaskToList{}))
+	require.NoError(t, cacheService.SetUserTasksCache(ctx, newExecutorID.String(), testViewer.String(), []at.TaskToList{}))
+
+	mockTaskClient.On("GetTaskByID", 3, mock.Anything, mock.Anything).Return(&at.TaskServiceResponse{Task: &at.TaskResponse{ID: 3, CreatorID: actorID, ExecutorID: &oldExecutorID}}, nil)
+	mockTaskClient.On("GetTaskByID", 4, mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+	mockTaskClient.On("BulkUpdateTasks", actorID, []string(nil), req).Return(&at.TaskBulkResult{Succeeded: 1, Failed: 1, Items: []at.TaskBulkItemResult{
+		{TaskID: 3, Success: true},
+		{TaskID: 4, Error: "task with id 4 not found"},
+	}}, nil)
+
+	result, err := controller.BulkUpdateTasks(actorID, nil, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	exists, _ := cacheService.Exists(ctx, cacheService.TaskCacheKey(3, testViewer.String()))
+	assert.False(t, exists)
+	exists, _ = cacheService.Exists(ctx, cacheService.TaskCacheKey(4, testViewer.String()))
+	assert.True(t, exists, "failed task must keep its cache")
+	exists, _ = cacheService.Exists(ctx, cacheService.UserTasksCacheKey(oldExecutorID.String(), testViewer.String()))
+	assert.False(t, exists)
+	exists, _ = cacheService.Exists(ctx, cacheService.UserTasksCacheKey(newExecutorID.String(), testViewer.String()))
+	assert.False(t, exists)
+}
+
+func TestTaskController_BulkUpdateTasks_Error(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+
+	actorID := uuid.New()
+	statusID := 2
+	req := &at.TaskBulkRequest{TaskIDs: []int{3}, Action: at.TaskBulkChangeStatus, StatusID: &statusID}
+	serviceErr := custom_errors.NewTaskServiceError(http.StatusNotFound, `{"error":"task status with id 2 not found"}`)
+	mockTaskClient.On("GetTaskByID", 3, actorID, []string(nil)).Return(&at.TaskServiceResponse{Task: &at.TaskResponse{ID: 3, CreatorID: actorID}}, nil)
+	mockTaskClient.On("BulkUpdateTasks", actorID, []string(nil), req).Return(nil, serviceErr)
+
+	_, err := controller.BulkUpdateTasks(actorID, nil, req)
+
+	assert.Equal(t, serviceErr, err)
+}
+
+func TestTaskController_BulkUpdateTasks_ChangeStatusInvalidatesLists(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	executorID := uuid.New()
+	chatID := uuid.New()
+	statusID := 2
+	req := &at.TaskBulkRequest{TaskIDs: []int{3}, Action: at.TaskBulkChangeStatus, StatusID: &statusID}
+	keys := []string{
+		cacheService.UserTasksCacheKey(executorID.String(), testViewer.String()),
+		cacheService.UserCreatedTasksCacheKey(actorID.String(), testViewer.String()),
+		cacheService.ChatTasksCacheKey(chatID.String(), testViewer.String()),
+	}
+	for _, key := range keys {
+		require.NoError(t, cacheService.Set(ctx, key, []int{1}, 0))
+	}
+
+	mockTaskClient.On("GetTaskByID", 3, actorID, []string(nil)).Return(&at.TaskServiceResponse{Task: &at.TaskResponse{
+		ID: 3, CreatorID: actorID, ExecutorID: &executorID, ChatID: &chatID,
+	}}, nil)
+	mockTaskClient.On("BulkUpdateTasks", actorID, []string(nil), req).Return(&at.TaskBulkResult{Succeeded: 1, Items: []at.TaskBulkItemResult{
+		{TaskID: 3, Success: true},
+	}}, nil)
+
+	_, err := controller.BulkUpdateTasks(actorID, nil, req)
+
+	require.NoError(t, err)
+	for _, key := range keys {
+		exists, _ := cacheService.Exists(ctx, key)
+		assert.False(t, exists, key)
+	}
+}
+
+// Тесты для TaskController.MoveTask
+
+func TestTaskController_MoveTask_InvalidatesListCaches(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	executorID := uuid.New()
+	chatID := uuid.New()
+	moveReq := &at.MoveTaskRequest{StatusID: 2}
+	keys := []string{
+		cacheService.TaskCacheKey(5, testViewer.String()),
+		cacheService.UserTasksCacheKey(executorID.String(), testViewer.String()),
+		cacheService.ChatTasksCacheKey(chatID.String(), testViewer.String()),
+	}
+	for _, key := range keys {
+		require.NoError(t, cacheService.Set(ctx, key, []int{1}, 0))
+	}
+
+	mockTaskClient.On("MoveTask", 5, actorID, []string(nil), moveReq).Return(nil)
+	mockTaskClient.On("GetTaskByID", 5, actorID, []string(nil)).Return(&at.TaskServiceResponse{Task: &at.TaskResponse{
+		ID: 5, CreatorID: actorID, ExecutorID: &executorID, ChatID: &chatID,
+	}}, nil)
+
+	require.NoError(t, controller.MoveTask(5, moveReq, actorID, nil))
+
+	for _, key := range keys {
+		exists, _ := cacheService.Exists(ctx, key)
+		assert.False(t, exists, key)
+	}
+	mockTaskClient.AssertExpectations(t)
+}
+
+// Тесты для участников задачи
+
+func TestTaskController_AddTaskAssignee_InvalidatesCaches(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	assigneeID := uuid.New()
+	otherUserID := uuid.New()
+	require.NoError(t, cacheService.SetTaskCache(ctx, 3, testViewer.String(), map[string]int{"id": 3}))
+	require.NoError(t, cacheService.SetUserTasksCache(ctx, assigneeID.String(), testViewer.String(), []at.TaskToList{}))
+	require.NoError(t, cacheService.SetUserTasksCache(ctx, otherUserID.String(), testViewer.String(), []at.TaskToList{}))
+
+	mockTaskClient.On("AddTaskAssignee", 3, assigneeID, actorID, []string(nil)).Return(nil)
+
+	require.NoError(t, controller.AddTaskAssignee(3, assigneeID, actorID, nil))
+
+	exists, _ := cacheService.Exists(ctx, cacheService.TaskCacheKey(3, testViewer.String()))
+	assert.False(t, exists)
+	exists, _ = cacheService.Exists(ctx, cacheService.UserTasksCacheKey(assigneeID.String(), testViewer.String()))
+	assert.False(t, exists)
+	exists, _ = cacheService.Exists(ctx, cacheService.UserTasksCacheKey(otherUserID.String(), testViewer.String()))
+	assert.True(t, exists, "lists of other users must stay cached")
+}
+
+func TestTaskController_RemoveTaskWatcher_ErrorKeepsCache(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	watcherID := uuid.New()
+	require.NoError(t, cacheService.SetUserTasksCache(ctx, watcherID.String(), testViewer.String(), []at.TaskToList{}))
+	serviceErr := custom_errors.NewTaskServiceError(http.StatusForbidden, `{"error":"forbidden"}`)
+	mockTaskClient.On("RemoveTaskWatcher", 3, watcherID, actorID, []string(nil)).Return(serviceErr)
+
+	err := controller.RemoveTaskWatcher(3, watcherID, actorID, nil)
+
+	assert.Equal(t, serviceErr, err)
+	exists, _ := cacheService.Exists(ctx, cacheService.UserTasksCacheKey(watcherID.String(), testViewer.String()))
+	assert.True(t, exists)
+}
+
+// Тесты для списков задач
+
+func TestTaskController_GetCreatedTasks_CachesFirstPage(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+
+	userID := uuid.New().String()
+	tasks := []at.TaskToList{{ID: 1, Title: "A"}, {ID: 2, Title: "B"}}
+	mockTaskClient.On("GetCreatedTasks", userID, mock.Anything, mock.Anything, 20, 0).Return(&tasks, nil).Once()
+
+	first, err := controller.GetCreatedTasks(userID, 20, 0, testViewer, nil)
+	require.NoError(t, err)
+	second, err := controller.GetCreatedTasks(userID, 1, 0, testViewer, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, *first, 2)
+	assert.Len(t, *second, 1)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestTaskController_GetChatTasks_SkipsCacheForOtherPages(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+
+	chatID := uuid.New().String()
+	tasks := []at.TaskToList{{ID: 3}}
+	mockTaskClient.On("GetChatTasks", chatID, mock.Anything, mock.Anything, 20, 20).Return(&tasks, nil).Twice()
+
+	_, err := controller.GetChatTasks(chatID, 20, 20, testViewer, nil)
+	require.NoError(t, err)
+	_, err = controller.GetChatTasks(chatID, 20, 20, testViewer, nil)
+	require.NoError(t, err)
+
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestTaskController_UpdateTask_Schedule(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+
+	actorID := uuid.New()
+	priority := "high"
+	dueAt := "2026-06-01T12:00:00Z"
+	clearStart := ""
+	mockTaskClient.On("GetTaskByID", 1, mock.Anything, mock.Anything).Return(nil, errors.New("not cached"))
+	mockTaskClient.On("UpdateTask", 1, actorID, []string(nil), mock.MatchedBy(func(req *at.UpdateTaskRequest) bool {
+		return *req.Priority == "high" && req.DueAt != nil && req.DueAt.Format(time.RFC3339) == dueAt &&
+			req.StartAt == nil && req.ClearStartAt && !req.ClearDueAt
+	})).Return(&at.TaskResponse{ID: 1, CreatorID: actorID}, nil)
+
+	_, err := controller.UpdateTask(1, &dto.UpdateTaskRequestGateway{
+		Priority: &priority,
+		DueAt:    &dueAt,
+		StartAt:  &clearStart,
+	}, actorID, nil)
+
+	require.NoError(t, err)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestTaskController_UpdateTask_Parent(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+
+	actorID := uuid.New()
+	clearParent := ""
+	mockTaskClient.On("GetTaskByID", 1, mock.Anything, mock.Anything).Return(nil, errors.New("not cached"))
+	mockTaskClient.On("UpdateTask", 1, actorID, []string(nil), mock.MatchedBy(func(req *at.UpdateTaskRequest) bool {
+		return req.ParentTaskID == nil && req.ClearParentTaskID
+	})).Return(&at.TaskResponse{ID: 1, CreatorID: actorID}, nil)
+
+	_, err := controller.UpdateTask(1, &dto.UpdateTaskRequestGateway{ParentTaskID: &clearParent}, actorID, nil)
+	require.NoError(t, err)
+
+	invalidParent := "root"
+	_, err = controller.UpdateTask(1, &dto.UpdateTaskRequestGateway{ParentTaskID: &invalidParent}, actorID, nil)
+	assert.Error(t, err)
+	mockTaskClient.AssertNumberOfCalls(t, "UpdateTask", 1)
+}
+
+func TestTaskController_UpdateTask_Estimate(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+
+	actorID := uuid.New()
+	estimate := "90"
+	mockTaskClient.On("GetTaskByID", 1, mock.Anything, mock.Anything).Return(nil, errors.New("not cached"))
+	mockTaskClient.On("UpdateTask", 1, actorID, []string(nil), mock.MatchedBy(func(req *at.UpdateTaskRequest) bool {
+		return req.EstimateMinutes != nil && *req.EstimateMinutes == 90 && !req.ClearEstimate
+	})).Return(&at.TaskResponse{ID: 1, CreatorID: actorID}, nil)
+
+	_, err := controller.UpdateTask(1, &dto.UpdateTaskRequestGateway{EstimateMinutes: &estimate}, actorID, nil)
+	require.NoError(t, err)
+
+	negative := "-5"
+	_, err = controller.UpdateTask(1, &dto.UpdateTaskRequestGateway{EstimateMinutes: &negative}, actorID, nil)
+	assert.Error(t, err)
+	mockTaskClient.AssertNumberOfCalls(t, "UpdateTask", 1)
+}
+
+func TestTaskController_GetUserTasks_FilteredBypassesCache(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+
+	userID := uuid.New().String()
+	require.NoError(t, cacheService.SetUserTasksCache(context.Background(), userID, testViewer.String(), []at.TaskToList{{ID: 1}}))
+	filter := &dto.TaskListFilterGateway{Priority: "urgent"}
+	mockTaskClient.On("GetUserTasks", userID, mock.Anything, mock.Anything, filter, 20, 0).Return(&[]at.TaskToList{{ID: 2}}, nil)
+
+	result, err := controller.GetUserTasks(userID, filter, 20, 0, testViewer, nil)
+
+	require.NoError(t, err)
+	require.Len(t, *result, 1)
+	assert.Equal(t, 2, (*result)[0].ID)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestTaskController_CreateTaskComment_SkipsFailedUploads(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	mockFileClient := new(MockFileClient)
+	controller := controllers.NewTaskController(mockTaskClient, mockFileClient, nil)
+
+	actorID := uuid.New()
+	uploaded := &multipart.FileHeader{Filename: "log.txt"}
+	broken := &multipart.FileHeader{Filename: "broken.bin"}
+	req := &dto.CreateTaskCommentRequestGateway{Body: "see @bob", Files: []*multipart.FileHeader{uploaded, broken}}
+
+	mockFileClient.On("UploadFile", uploaded).Return(&af.FileUploadResponse{ID: intPtr(9)}, nil)
+	mockFileClient.On("UploadFile", broken).Return(nil, errors.New("upload failed"))
+	mockTaskClient.On("CreateTaskComment", 4, actorID, &at.CreateTaskCommentRequest{Body: "see @bob", FileIDs: []int{9}}).
+		Return(&at.TaskComment{ID: 1, TaskID: 4, AuthorID: actorID, Body: "see @bob"}, nil)
+
+	comment, err := controller.CreateTaskComment(4, req, actorID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, comment.ID)
+	mockTaskClient.AssertExpectations(t)
+	mockFileClient.AssertExpectations(t)
+}
+
+func TestTaskController_UpdateTaskComment_ForwardsRemovedFiles(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), nil)
+
+	actorID := uuid.New()
+	body := "edited"
+	req := &dto.UpdateTaskCommentRequestGateway{Body: &body, RemoveFileIDs: []int{3}}
+	forbidden := custom_errors.NewTaskServiceError(http.StatusForbidden, `{"error":"access denied"}`)
+
+	mockTaskClient.On("UpdateTaskComment", 4, 2, actorID, &at.UpdateTaskCommentRequest{Body: &body, RemoveFileIDs: []int{3}}).
+		Return(nil, forbidden)
+
+	comment, err := controller.UpdateTaskComment(4, 2, req, actorID)
+
+	assert.Nil(t, comment)
+	var taskErr *custom_errors.TaskServiceError
+	require.True(t, errors.As(err, &taskErr))
+	assert.Equal(t, http.StatusForbidden, taskErr.StatusCode)
+}
+
+// Тесты для TaskController.UpdateTaskRecurrence
+
+func TestTaskController_UpdateTaskRecurrence_InvalidatesCaches(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	creatorID := uuid.New()
+	oldExecutorID := uuid.New()
+	newExecutorID := uuid.New()
+	chatID := uuid.New()
+	permissions := []string{"process_tasks"}
+	req := &at.SaveTaskRecurrenceRequest{Title: "Report", ExecutorID: newExecutorID, ChatID: chatID, Rule: "FREQ=DAILY"}
+
+	previous := &at.TaskRecurrence{ID: 3, CreatorID: creatorID, ExecutorID: oldExecutorID, ChatID: chatID}
+	updated := &at.TaskRecurrence{ID: 3, CreatorID: creatorID, ExecutorID: newExecutorID, ChatID: chatID}
+
+	keys := []string{
+		cacheService.UserTasksCacheKey(oldExecutorID.String(), testViewer.String()),
+		cacheService.UserTasksCacheKey(newExecutorID.String(), testViewer.String()),
+		cacheService.UserCreatedTasksCacheKey(creatorID.String(), testViewer.String()),
+		cacheService.ChatTasksCacheKey(chatID.String(), testViewer.String()),
+	}
+	for _, key := range keys {
+		require.NoError(t, cacheService.Set(ctx, key, []int{1}, 0))
+	}
+
+	mockTaskClient.On("GetTaskRecurrence", 3, actorID, permissions).Return(previous, nil)
+	mockTaskClient.On("UpdateTaskRecurrence", 3, actorID, permissions, req).Return(updated, nil)
+
+	result, err := controller.UpdateTaskRecurrence(3, req, actorID, permissions)
+
+	require.NoError(t, err)
+	assert.Equal(t, newExecutorID, result.ExecutorID)
+	for _, key := range keys {
+		exists, _ := cacheService.Exists(ctx, key)
+		assert.False(t, exists, key)
+	}
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestTaskController_DeleteTaskRecurrence_Error(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+
+	actorID := uuid.New()
+	serviceErr := custom_errors.NewTaskServiceError(http.StatusForbidden, `{"error":"access denied"}`)
+	mockTaskClient.On("GetTaskRecurrence", 3, actorID, []string(nil)).Return(&at.TaskRecurrence{ID: 3}, nil)
+	mockTaskClient.On("DeleteTaskRecurrence", 3, actorID, []string(nil)).Return(serviceErr)
+
+	err := controller.DeleteTaskRecurrence(3, actorID, nil)
+
+	assert.Equal(t, serviceErr, err)
+}
+
+// Тесты для TaskController.CreateTaskFromMessage
+
+func TestTaskController_CreateTaskFromMessage_InvalidatesChatCaches(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	executorID := uuid.New()
+	chatID := uuid.New()
+	req := &at.CreateTaskFromMessageRequest{MessageID: uuid.New(), ExecutorID: executorID}
+	created := &at.TaskResponse{ID: 12, CreatorID: actorID, ExecutorID: &executorID, ChatID: &chatID}
+
+	keys := []string{
+		cacheService.UserTasksCacheKey(actorID.String(), testViewer.String()),
+		cacheService.UserTasksCacheKey(executorID.String(), testViewer.String()),
+		cacheService.ChatTasksCacheKey(chatID.String(), testViewer.String()),
+		cacheService.ChatMessagesCacheKey(chatID.String()),
+	}
+	for _, key := range keys {
+		require.NoError(t, cacheService.Set(ctx, key, []int{1}, 0))
+	}
+
+	mockTaskClient.On("CreateTaskFromMessage", actorID, req).Return(created, nil)
+
+	result, err := controller.CreateTaskFromMessage(req, actorID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 12, result.ID)
+	for _, key := range keys {
+		exists, _ := cacheService.Exists(ctx, key)
+		assert.False(t, exists, key)
+	}
+}
+
+// Тесты для шаблонов задач и чек-листов
+
+func TestTaskController_CreateTaskFromTemplate_InvalidatesListCaches(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	executorID := uuid.New()
+	req := &at.CreateTaskFromTemplateRequest{Variables: map[string]string{"name": "Ivan"}}
+	created := &at.TaskResponse{ID: 14, CreatorID: actorID, ExecutorID: &executorID}
+
+	keys := []string{
+		cacheService.UserTasksCacheKey(executorID.String(), testViewer.String()),
+		cacheService.UserCreatedTasksCacheKey(actorID.String(), testViewer.String()),
+	}
+	for _, key := range keys {
+		require.NoError(t, cacheService.Set(ctx, key, []int{1}, 0))
+	}
+
+	mockTaskClient.On("CreateTaskFromTemplate", 2, actorID, []string(nil), req).Return(created, nil)
+
+	result, err := controller.CreateTaskFromTemplate(2, req, actorID, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 14, result.ID)
+	for _, key := range keys {
+		exists, _ := cacheService.Exists(ctx, key)
+		assert.False(t, exists, key)
+	}
+}
+
+func TestTaskController_UpdateChecklistItem_InvalidatesTaskCache(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	permissions := []string{"process_tasks"}
+	done := true
+	req := &at.UpdateChecklistItemRequest{Done: &done}
+	current := &at.TaskServiceResponse{Task: &at.TaskResponse{ID: 3, CreatorID: actorID}}
+	require.NoError(t, cacheService.SetTaskCache(ctx, 3, testViewer.String(), current))
+	require.NoError(t, cacheService.Set(ctx, cacheService.UserCreatedTasksCacheKey(actorID.String(), testViewer.String()), []int{1}, 0))
+
+	mockTaskClient.On("UpdateChecklistItem", 3, 7, actorID, permissions, req).
+		Return(&at.TaskChecklistItem{ID: 7, TaskID: 3, Done: true}, nil)
+	mockTaskClient.On("GetTaskByID", 3, mock.Anything, mock.Anything).Return(current, nil)
+
+	item, err := controller.UpdateChecklistItem(3, 7, req, actorID, permissions)
+
+	require.NoError(t, err)
+	assert.True(t, item.Done)
+	exists, _ := cacheService.Exists(ctx, cacheService.TaskCacheKey(3, testViewer.String()))
+	assert.False(t, exists)
+	exists, _ = cacheService.Exists(ctx, cacheService.UserCreatedTasksCacheKey(actorID.String(), testViewer.String()))
+	assert.False(t, exists)
+}
+
+func TestTaskController_RemoveChecklistItem_ErrorKeepsCache(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	require.NoError(t, cacheService.SetTaskCache(ctx, 3, testViewer.String(), &at.TaskServiceResponse{Task: &at.TaskResponse{ID: 3}}))
+	serviceErr := custom_errors.NewTaskServiceError(http.StatusForbidden, `{"error":"access denied"}`)
+	mockTaskClient.On("RemoveChecklistItem", 3, 7, actorID, []string(nil)).Return(serviceErr)
+
+	err := controller.RemoveChecklistItem(3, 7, actorID, nil)
+
+	assert.Equal(t, serviceErr, err)
+	exists, _ := cacheService.Exists(ctx, cacheService.TaskCacheKey(3, testViewer.String()))
+	assert.True(t, exists)
+	mockTaskClient.AssertNotCalled(t, "GetTaskByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskController_DeleteStatus_WithReplacementDropsTaskCaches(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	cacheService := services.NewCacheService(redisClient)
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), cacheService)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	executorID := uuid.New().String()
+	require.NoError(t, cacheService.SetTaskCache(ctx, 3, testViewer.String(), &at.TaskServiceResponse{Task: &at.TaskResponse{ID: 3}}))
+	require.NoError(t, cacheService.SetUserTasksCache(ctx, executorID, testViewer.String(), []int{3}))
+	require.NoError(t, cacheService.Set(ctx, "task:statuses", []at.TaskStatus{{ID: 4, Name: "archived"}}, 0))
+
+	replacementID := 2
+	mockTaskClient.On("DeleteStatus", 4, &replacementID, actorID, []string(nil)).Return(nil)
+
+	err := controller.DeleteStatus(4, &replacementID, actorID, nil)
+
+	require.NoError(t, err)
+	for _, key := range []string{cacheService.TaskCacheKey(3, testViewer.String()), cacheService.UserTasksCacheKey(executorID, testViewer.String()), "task:statuses"} {
+		exists, _ := cacheService.Exists(ctx, key)
+		assert.False(t, exists, key)
+	}
+}
@@ -16,6 +16,9 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// testViewer - пользователь, от имени которого задачи читаются и кешируются в тестах
+var testViewer = uuid.New()
+
 // Тесты для TaskController.CreateTask
 
 func TestTaskController_CreateTask_Success(t *testing.T) {
@@ -43,10 +46,10 @@ func TestTaskController_CreateTask_Success(t *testing.T) {
 		Title: "Test Task",
 	}
 
-	mockTaskClient.On("CreateTask", mock.Anything).Return(expectedTask, nil)
+	mockTaskClient.On("CreateTask", creatorID, []string(nil), mock.Anything).Return(expectedTask, nil)
 
 	// Act
-	result, err := controller.CreateTask(req, creatorID)
+	result, err := controller.CreateTask(req, creatorID, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -78,10 +81,10 @@ func TestTaskController_CreateTask_ServiceError(t *testing.T) {
 
 	serviceError := errors.New("service error")
 
-	mockTaskClient.On("CreateTask", mock.Anything).Return(nil, serviceError)
+	mockTaskClient.On("CreateTask", creatorID, []string(nil), mock.Anything).Return(nil, serviceError)
 
 	// Act
-	result, err := controller.CreateTask(req, creatorID)
+	result, err := controller.CreateTask(req, creatorID, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -111,13 +114,13 @@ func TestTaskController_CreateTask_InvalidUUID(t *testing.T) {
 	}
 
 	// Act
-	result, err := controller.CreateTask(req, creatorID)
+	result, err := controller.CreateTask(req, creatorID, nil)
 
 	// Assert
 	require.Error(t, err)
 	assert.Nil(t, result)
 
-	mockTaskClient.AssertNotCalled(t, "CreateTask", mock.Anything)
+	mockTaskClient.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Тесты для TaskController.GetTaskByID
@@ -141,17 +144,17 @@ func TestTaskController_GetTaskByID_Success_FromCache(t *testing.T) {
 	}
 
 	// Сохраняем задачу в кеш
-	cacheService.SetTaskCache(context.Background(), taskID, cachedTask)
+	cacheService.SetTaskCache(context.Background(), taskID, testViewer.String(), cachedTask)
 
 	// Act
-	result, err := controller.GetTaskByID(taskID)
+	result, err := controller.GetTaskByID(taskID, testViewer, nil)
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, cachedTask.Task.ID, result.Task.ID)
 
-	mockTaskClient.AssertNotCalled(t, "GetTaskByID", mock.Anything)
+	mockTaskClient.AssertNotCalled(t, "GetTaskByID", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestTaskController_GetTaskByID_Success_FromService(t *testing.T) {
@@ -172,10 +175,10 @@ func TestTaskController_GetTaskByID_Success_FromService(t *testing.T) {
 		},
 	}
 
-	mockTaskClient.On("GetTaskByID", taskID).Return(expectedTask, nil)
+	mockTaskClient.On("GetTaskByID", taskID, mock.Anything, mock.Anything).Return(expectedTask, nil)
 
 	// Act
-	result, err := controller.GetTaskByID(taskID)
+	result, err := controller.GetTaskByID(taskID, testViewer, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -198,10 +201,10 @@ func TestTaskController_GetTaskByID_ServiceError(t *testing.T) {
 	taskID := 1
 	serviceError := errors.New("service error")
 
-	mockTaskClient.On("GetTaskByID", taskID).Return(nil, serviceError)
+	mockTaskClient.On("GetTaskByID", taskID, mock.Anything, mock.Anything).Return(nil, serviceError)
 
 	// Act
-	result, err := controller.GetTaskByID(taskID)
+	result, err := controller.GetTaskByID(taskID, testViewer, nil)
 
 	// Assert
 	require.Error(t, err)
@@ -225,14 +228,37 @@ func TestTaskController_UpdateTaskStatus_Success(t *testing.T) {
 
 	taskID := 1
 	statusID := 2
+	actorID := uuid.New()
+	permissions := []string{"process_tasks"}
+
+	executorID := uuid.New()
+	chatID := uuid.New()
+	ctx := context.Background()
+	listKeys := []string{
+		cacheService.TaskCacheKey(taskID, testViewer.String()),
+		cacheService.UserTasksCacheKey(executorID.String(), testViewer.String()),
+		cacheService.UserCreatedTasksCacheKey(actorID.String(), testViewer.String()),
+		cacheService.ChatTasksCacheKey(chatID.String(), testViewer.String()),
+	}
+	for _, key := range listKeys {
+		require.NoError(t, cacheService.Set(ctx, key, []int{1}, 0))
+	}
 
-	mockTaskClient.On("UpdateTaskStatus", taskID, statusID).Return(nil)
+	mockTaskClient.On("UpdateTaskStatus", taskID, statusID, actorID, permissions).Return(nil)
+	mockTaskClient.On("GetTaskByID", taskID, actorID, permissions).Return(&at.TaskServiceResponse{Task: &at.TaskResponse{
+		ID: taskID, CreatorID: actorID, ExecutorID: &executorID, ChatID: &chatID,
+	}}, nil)
 
 	// Act
-	err := controller.UpdateTaskStatus(taskID, statusID)
+	err := controller.UpdateTaskStatus(taskID, statusID, actorID, permissions)
 
 	// Assert
 	require.NoError(t, err)
+	// Списки содержат статус задачи, поэтому сбрасываются вместе с её кешем
+	for _, key := range listKeys {
+		exists, _ := cacheService.Exists(ctx, key)
+		assert.False(t, exists, key)
+	}
 
 	mockTaskClient.AssertExpectations(t)
 }
@@ -249,12 +275,14 @@ func TestTaskController_UpdateTaskStatus_ServiceError(t *testing.T) {
 
 	taskID := 1
 	statusID := 2
+	actorID := uuid.New()
+	permissions := []string{"process_tasks"}
 	serviceError := errors.New("service error")
 
-	mockTaskClient.On("UpdateTaskStatus", taskID, statusID).Return(serviceError)
+	mockTaskClient.On("UpdateTaskStatus", taskID, statusID, actorID, permissions).Return(serviceError)
 
 	// Act
-	err := controller.UpdateTaskStatus(taskID, statusID)
+	err := controller.UpdateTaskStatus(taskID, statusID, actorID, permissions)
 
 	// Assert
 	require.Error(t, err)
@@ -282,17 +310,17 @@ func TestTaskController_GetUserTasks_Success_FromCache(t *testing.T) {
 	}
 
 	// Сохраняем задачи в кеш
-	cacheService.SetUserTasksCache(context.Background(), userID, cachedTasks)
+	cacheService.SetUserTasksCache(context.Background(), userID, testViewer.String(), cachedTasks)
 
 	// Act
-	result, err := controller.GetUserTasks(userID, 10, 0)
+	result, err := controller.GetUserTasks(userID, nil, 10, 0, testViewer, nil)
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Len(t, *result, 2)
 
-	mockTaskClient.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything)
+	mockTaskClient.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestTaskController_GetUserTasks_Success_FromService(t *testing.T) {
@@ -311,10 +339,10 @@ func TestTaskController_GetUserTasks_Success_FromService(t *testing.T) {
 		{ID: 2, Title: "Task 2"},
 	}
 
-	mockTaskClient.On("GetUserTasks", userID, 20, 0).Return(expectedTasks, nil)
+	mockTaskClient.On("GetUserTasks", userID, mock.Anything, mock.Anything, (*dto.TaskListFilterGateway)(nil), 20, 0).Return(expectedTasks, nil)
 
 	// Act
-	result, err := controller.GetUserTasks(userID, 10, 0)
+	result, err := controller.GetUserTasks(userID, nil, 10, 0, testViewer, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -489,10 +517,10 @@ func TestTaskController_DeleteStatus_Success(t *testing.T) {
 
 	statusID := 1
 
-	mockTaskClient.On("DeleteStatus", statusID).Return(nil)
+	mockTaskClient.On("DeleteStatus", statusID, (*int)(nil), mock.Anything, mock.Anything).Return(nil)
 
 	// Act
-	err := controller.DeleteStatus(statusID)
+	err := controller.DeleteStatus(statusID, nil, uuid.New(), nil)
 
 	// Assert
 	require.NoError(t, err)
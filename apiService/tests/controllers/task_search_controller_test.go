@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"apiService/internal/controllers"
+	"apiService/internal/dto"
+	"apiService/internal/services"
+	"testing"
+
+	at "common/contracts/api-task"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Тесты для TaskController.SearchTasks
+
+func TestTaskController_SearchTasks_CacheKeyedByFullQuery(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+
+	firstPage := &at.TaskQueryResult{Tasks: []at.TaskToList{{ID: 1}}, Total: 2, NextCursor: stringPtr("c1")}
+	secondPage := &at.TaskQueryResult{Tasks: []at.TaskToList{{ID: 2}}, Total: 2}
+	mockTaskClient.On("QueryTasks", mock.Anything, mock.Anything, mock.MatchedBy(func(q *dto.TaskQueryGateway) bool { return q.Cursor == "" })).
+		Return(firstPage, nil).Once()
+	mockTaskClient.On("QueryTasks", mock.Anything, mock.Anything, mock.MatchedBy(func(q *dto.TaskQueryGateway) bool { return q.Cursor == "c1" })).
+		Return(secondPage, nil).Once()
+
+	result, err := controller.SearchTasks(&dto.TaskQueryGateway{Q: "отчёт", Status: "3,1", Label: "9,2"}, testViewer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Tasks[0].ID)
+
+	// Тот же набор фильтров в другом порядке и с лишними пробелами берётся из кеша
+	result, err = controller.SearchTasks(&dto.TaskQueryGateway{Q: "  отчёт ", Status: "1, 3", Label: "2,9,2"}, testViewer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Tasks[0].ID)
+
+	// Курсор - часть ключа: следующая страница не должна совпасть с первой
+	result, err = controller.SearchTasks(&dto.TaskQueryGateway{Q: "отчёт", Status: "1,3", Cursor: "c1"}, testViewer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Tasks[0].ID)
+
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestTaskController_SearchTasks_InvalidatedByStatusChange(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+
+	query := &dto.TaskQueryGateway{Status: "1"}
+	mockTaskClient.On("QueryTasks", mock.Anything, mock.Anything, query).Return(&at.TaskQueryResult{Tasks: []at.TaskToList{{ID: 1}}, Total: 1}, nil).Once()
+	mockTaskClient.On("UpdateTaskStatus", 1, 2, mock.Anything, []string(nil)).Return(nil)
+	mockTaskClient.On("GetTaskByID", 1, mock.Anything, []string(nil)).Return(&at.TaskServiceResponse{Task: &at.TaskResponse{ID: 1}}, nil)
+	mockTaskClient.On("QueryTasks", mock.Anything, mock.Anything, query).Return(&at.TaskQueryResult{Tasks: []at.TaskToList{}, Total: 0}, nil).Once()
+
+	_, err := controller.SearchTasks(query, testViewer, nil)
+	require.NoError(t, err)
+	require.NoError(t, controller.UpdateTaskStatus(1, 2, uuid.New(), nil))
+
+	result, err := controller.SearchTasks(query, testViewer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), result.Total)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestTaskController_SearchTasks_InvalidatedByLabelChange(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+	actorID := uuid.New()
+
+	query := &dto.TaskQueryGateway{Label: "5"}
+	mockTaskClient.On("QueryTasks", mock.Anything, mock.Anything, query).Return(&at.TaskQueryResult{Tasks: []at.TaskToList{}, Total: 0}, nil).Once()
+	mockTaskClient.On("AddTaskLabel", 1, 5, actorID, []string(nil)).Return(nil)
+	mockTaskClient.On("QueryTasks", mock.Anything, mock.Anything, query).Return(&at.TaskQueryResult{Tasks: []at.TaskToList{{ID: 1}}, Total: 1}, nil).Once()
+
+	_, err := controller.SearchTasks(query, testViewer, nil)
+	require.NoError(t, err)
+	require.NoError(t, controller.AddTaskLabel(1, 5, actorID, nil))
+
+	result, err := controller.SearchTasks(query, testViewer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total)
+	mockTaskClient.AssertExpectations(t)
+}
+
+func TestTaskController_SearchTasks_InvalidatedByMoveTask(t *testing.T) {
+	mockTaskClient := new(MockTaskClient)
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	controller := controllers.NewTaskController(mockTaskClient, new(MockFileClient), services.NewCacheService(redisClient))
+	actorID := uuid.New()
+	moveReq := &at.MoveTaskRequest{StatusID: 2}
+
+	query := &dto.TaskQueryGateway{Status: "2"}
+	mockTaskClient.On("QueryTasks", mock.Anything, mock.Anything, query).Return(&at.TaskQueryResult{Tasks: []at.TaskToList{}, Total: 0}, nil).Once()
+	mockTaskClient.On("MoveTask", 1, actorID, []string(nil), moveReq).Return(nil)
+	mockTaskClient.On("GetTaskByID", 1, actorID, []string(nil)).Return(&at.TaskServiceResponse{Task: &at.TaskResponse{ID: 1}}, nil)
+	mockTaskClient.On("QueryTasks", mock.Anything, mock.Anything, query).Return(&at.TaskQueryResult{Tasks: []at.TaskToList{{ID: 1}}, Total: 1}, nil).Once()
+
+	_, err := controller.SearchTasks(query, testViewer, nil)
+	require.NoError(t, err)
+	require.NoError(t, controller.MoveTask(1, moveReq, actorID, nil))
+
+	result, err := controller.SearchTasks(query, testViewer, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total)
+	mockTaskClient.AssertExpectations(t)
+}
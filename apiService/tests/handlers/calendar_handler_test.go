@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"apiService/internal/custom_errors"
+	"apiService/internal/dto"
+	"apiService/internal/handlers"
+	"apiService/internal/services"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCalendarRouter собирает роутер с маршрутами ленты; userID кладётся в контекст, если он задан
+func newCalendarRouter(controller *MockCalendarController, userID *uuid.UUID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewCalendarHandler(controller)
+
+	router := gin.New()
+	router.GET("/calendar/:token", handler.GetCalendarFeed)
+
+	feed := router.Group("/tasks/calendar-feed")
+	feed.Use(func(c *gin.Context) {
+		if userID != nil {
+			c.Set("userID", *userID)
+		}
+		c.Next()
+	})
+	feed.POST("", handler.IssueCalendarFeed)
+	feed.DELETE("", handler.RevokeCalendarFeed)
+	return router
+}
+
+func TestCalendarHandler_IssueCalendarFeed_Success(t *testing.T) {
+	mockController := new(MockCalendarController)
+	userID := uuid.New()
+	expected := &dto.CalendarFeedResponse{Token: "token", URL: "http://localhost:8084/api/v1/calendar/token.ics"}
+	mockController.On("IssueFeedToken", userID).Return(expected, nil)
+
+	w := httptest.NewRecorder()
+	newCalendarRouter(mockController, &userID).ServeHTTP(w, httptest.NewRequest("POST", "/tasks/calendar-feed", nil))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response dto.CalendarFeedResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, *expected, response)
+	mockController.AssertExpectations(t)
+}
+
+func TestCalendarHandler_IssueCalendarFeed_Unauthorized(t *testing.T) {
+	mockController := new(MockCalendarController)
+
+	w := httptest.NewRecorder()
+	newCalendarRouter(mockController, nil).ServeHTTP(w, httptest.NewRequest("POST", "/tasks/calendar-feed", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockController.AssertNotCalled(t, "IssueFeedToken")
+}
+
+func TestCalendarHandler_RevokeCalendarFeed_Success(t *testing.T) {
+	mockController := new(MockCalendarController)
+	userID := uuid.New()
+	mockController.On("RevokeFeedToken", userID).Return(nil)
+
+	w := httptest.NewRecorder()
+	newCalendarRouter(mockController, &userID).ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/calendar-feed", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestCalendarHandler_GetCalendarFeed_Success(t *testing.T) {
+	mockController := new(MockCalendarController)
+	feed := []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+	mockController.On("GetFeed", "secret", services.CalendarEntryTodo).Return(feed, nil)
+
+	w := httptest.NewRecorder()
+	newCalendarRouter(mockController, nil).ServeHTTP(w, httptest.NewRequest("GET", "/calendar/secret.ics?kind=todo", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, feed, w.Body.Bytes())
+	mockController.AssertExpectations(t)
+}
+
+func TestCalendarHandler_GetCalendarFeed_DefaultsToEvents(t *testing.T) {
+	mockController := new(MockCalendarController)
+	mockController.On("GetFeed", "secret", services.CalendarEntryEvent).Return([]byte{}, nil)
+
+	w := httptest.NewRecorder()
+	newCalendarRouter(mockController, nil).ServeHTTP(w, httptest.NewRequest("GET", "/calendar/secret.ics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestCalendarHandler_GetCalendarFeed_InvalidKind(t *testing.T) {
+	mockController := new(MockCalendarController)
+
+	w := httptest.NewRecorder()
+	newCalendarRouter(mockController, nil).ServeHTTP(w, httptest.NewRequest("GET", "/calendar/secret.ics?kind=journal", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetFeed")
+}
+
+func TestCalendarHandler_GetCalendarFeed_TokenNotFound(t *testing.T) {
+	mockController := new(MockCalendarController)
+	mockController.On("GetFeed", "revoked", services.CalendarEntryEvent).Return(nil, custom_errors.ErrCalendarFeedTokenNotFound)
+
+	w := httptest.NewRecorder()
+	newCalendarRouter(mockController, nil).ServeHTTP(w, httptest.NewRequest("GET", "/calendar/revoked.ics", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestCalendarHandler_GetCalendarFeed_TaskServiceError(t *testing.T) {
+	mockController := new(MockCalendarController)
+	mockController.On("GetFeed", "secret", services.CalendarEntryEvent).Return(nil, errors.New("task service unavailable"))
+
+	w := httptest.NewRecorder()
+	newCalendarRouter(mockController, nil).ServeHTTP(w, httptest.NewRequest("GET", "/calendar/secret.ics", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
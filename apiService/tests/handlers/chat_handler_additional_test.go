@@ -272,3 +272,182 @@ func TestChatHandler_GetMyRoleInChat_NotFound(t *testing.T) {
 
 	mockController.AssertExpectations(t)
 }
+
+// Тесты для ChatHandler.GetSavedMessages / DeleteSavedMessage
+
+func TestChatHandler_GetSavedMessages_Success(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockChatController)
+	handler := handlers.NewChatHandler(mockController)
+
+	userID := uuid.New()
+	expected := &ac.GetSavedMessagesResponse{
+		SavedMessages: []ac.SavedMessage{{MessageID: uuid.New(), ChatID: uuid.New(), Available: true}},
+		Total:         1,
+	}
+
+	mockController.On("GetSavedMessages", userID, 0, 20).Return(expected, nil)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Next()
+	})
+	router.GET("/chats/saved-messages", handler.GetSavedMessages)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/chats/saved-messages", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response ac.GetSavedMessagesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), response.Total)
+	assert.Len(t, response.SavedMessages, 1)
+
+	mockController.AssertExpectations(t)
+}
+
+func TestChatHandler_GetSavedMessages_InvalidLimit(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockChatController)
+	handler := handlers.NewChatHandler(mockController)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", uuid.New())
+		c.Next()
+	})
+	router.GET("/chats/saved-messages", handler.GetSavedMessages)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/chats/saved-messages?limit=500", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetSavedMessages", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatHandler_DeleteSavedMessage_ServiceError(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockChatController)
+	handler := handlers.NewChatHandler(mockController)
+
+	userID := uuid.New()
+	messageID := uuid.New()
+
+	mockController.On("DeleteSavedMessage", userID, messageID).Return(errors.New("service error"))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Next()
+	})
+	router.DELETE("/chats/saved-messages/:message_id", handler.DeleteSavedMessage)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/chats/saved-messages/"+messageID.String(), nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+// Тесты для настроек событий задач в чате
+
+func TestChatHandler_UpdateTaskEventSettings_Success(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockChatController)
+	handler := handlers.NewChatHandler(mockController)
+
+	userID := uuid.New()
+	chatID := uuid.New()
+	mockController.On("UpdateTaskEventSettings", chatID, userID, mock.MatchedBy(func(req *ac.UpdateTaskEventSettingsRequest) bool {
+		return *req.TaskCreated && !*req.TaskStatusChanged && *req.TaskReassigned
+	})).Return(&ac.TaskEventSettings{TaskCreated: true, TaskReassigned: true}, nil)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Next()
+	})
+	router.PUT("/chats/task-event-settings/:chat_id", handler.UpdateTaskEventSettings)
+
+	// Act
+	body := `{"taskCreated":true,"taskStatusChanged":false,"taskReassigned":true}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/chats/task-event-settings/"+chatID.String(), bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response ac.TaskEventSettings
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.False(t, response.TaskStatusChanged)
+
+	mockController.AssertExpectations(t)
+}
+
+func TestChatHandler_UpdateTaskEventSettings_MissingField(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockChatController)
+	handler := handlers.NewChatHandler(mockController)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", uuid.New())
+		c.Next()
+	})
+	router.PUT("/chats/task-event-settings/:chat_id", handler.UpdateTaskEventSettings)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/chats/task-event-settings/"+uuid.New().String(), bytes.NewBufferString(`{"taskCreated":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "UpdateTaskEventSettings", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatHandler_GetTaskEventSettings_ServiceError(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockChatController)
+	handler := handlers.NewChatHandler(mockController)
+
+	userID := uuid.New()
+	chatID := uuid.New()
+	mockController.On("GetTaskEventSettings", chatID, userID).Return(nil, errors.New("service error"))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Next()
+	})
+	router.GET("/chats/task-event-settings/:chat_id", handler.GetTaskEventSettings)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/chats/task-event-settings/"+chatID.String(), nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"apiService/internal/custom_errors"
 	"apiService/internal/handlers"
 	ac "common/contracts/api-chat"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -243,6 +245,33 @@ func TestChatHandler_SendMessage_MissingUserID(t *testing.T) {
 	mockController.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
 }
 
+func TestChatHandler_SendMessage_UploadInProgress(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockChatController)
+	handler := handlers.NewChatHandler(mockController)
+
+	chatID := uuid.New()
+	userID := uuid.New()
+	mockController.On("SendMessage", chatID, userID, mock.Anything).Return(nil, custom_errors.ErrMessageUploadInProgress)
+
+	router := gin.New()
+	router.POST("/chats/messages/:chat_id", func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Next()
+	}, handler.SendMessage)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/chats/messages/"+chatID.String(), strings.NewReader("content=hello&clientID=client-msg-1"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockController.AssertExpectations(t)
+}
+
 func TestChatHandler_SendMessage_InvalidChatID(t *testing.T) {
 	// Arrange
 	gin.SetMode(gin.TestMode)
@@ -380,27 +409,49 @@ func TestChatHandler_GetChatMembers_Success(t *testing.T) {
 	handler := handlers.NewChatHandler(mockController)
 
 	chatID := uuid.New()
-	expectedMembers := []*ac.ChatMember{
-		{UserID: uuid.New().String(), RoleID: 1, RoleName: "user"},
+	expectedMembers := &ac.ChatMembersResponse{
+		Members: []*ac.ChatMember{{UserID: uuid.New().String(), RoleID: 1, RoleName: "main", Username: "user"}},
+		Total:   1,
 	}
+	expectedQuery := &ac.ChatMembersQuery{Offset: 0, Limit: 20, Role: "main", Search: "us"}
 
-	mockController.On("GetChatMembers", chatID).Return(expectedMembers, nil)
+	mockController.On("GetChatMembers", chatID, expectedQuery).Return(expectedMembers, nil)
 
 	router := gin.New()
 	router.GET("/chats/:chat_id/members", handler.GetChatMembers)
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/chats/"+chatID.String()+"/members", nil)
+	req := httptest.NewRequest("GET", "/chats/"+chatID.String()+"/members?limit=20&role=main&search=us", nil)
 	router.ServeHTTP(w, req)
 
 	// Assert
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response []*ac.ChatMember
+	var response ac.ChatMembersResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
-	assert.Len(t, response, 1)
+	assert.Len(t, response.Members, 1)
+	assert.Equal(t, "user", response.Members[0].Username)
 
 	mockController.AssertExpectations(t)
 }
+
+func TestChatHandler_GetChatMembers_InvalidLimit(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockChatController)
+	handler := handlers.NewChatHandler(mockController)
+
+	router := gin.New()
+	router.GET("/chats/:chat_id/members", handler.GetChatMembers)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/chats/"+uuid.New().String()+"/members?limit=1000", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetChatMembers", mock.Anything, mock.Anything)
+}
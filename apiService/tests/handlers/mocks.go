@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"apiService/internal/dto"
+	"apiService/internal/services"
 	ac "common/contracts/api-chat"
 	at "common/contracts/api-task"
 	au "common/contracts/api-user"
@@ -195,12 +196,49 @@ func (m *MockChatController) GetMyRoleInChat(chatID, userID uuid.UUID) (*ac.MyRo
 	return args.Get(0).(*ac.MyRoleResponse), args.Error(1)
 }
 
-func (m *MockChatController) GetChatMembers(chatID uuid.UUID) ([]*ac.ChatMember, error) {
-	args := m.Called(chatID)
+func (m *MockChatController) GetChatMembers(chatID uuid.UUID, query *ac.ChatMembersQuery) (*ac.ChatMembersResponse, error) {
+	args := m.Called(chatID, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*ac.ChatMember), args.Error(1)
+	return args.Get(0).(*ac.ChatMembersResponse), args.Error(1)
+}
+
+func (m *MockChatController) SaveMessage(userID, messageID uuid.UUID, req *dto.SaveMessageRequestGateway) (*ac.SavedMessage, error) {
+	args := m.Called(userID, messageID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ac.SavedMessage), args.Error(1)
+}
+
+func (m *MockChatController) GetSavedMessages(userID uuid.UUID, offset, limit int) (*ac.GetSavedMessagesResponse, error) {
+	args := m.Called(userID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ac.GetSavedMessagesResponse), args.Error(1)
+}
+
+func (m *MockChatController) DeleteSavedMessage(userID, messageID uuid.UUID) error {
+	args := m.Called(userID, messageID)
+	return args.Error(0)
+}
+
+func (m *MockChatController) GetTaskEventSettings(chatID, userID uuid.UUID) (*ac.TaskEventSettings, error) {
+	args := m.Called(chatID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ac.TaskEventSettings), args.Error(1)
+}
+
+func (m *MockChatController) UpdateTaskEventSettings(chatID, userID uuid.UUID, req *ac.UpdateTaskEventSettingsRequest) (*ac.TaskEventSettings, error) {
+	args := m.Called(chatID, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ac.TaskEventSettings), args.Error(1)
 }
 
 // MockTaskController - мок для TaskController
@@ -208,35 +246,411 @@ type MockTaskController struct {
 	mock.Mock
 }
 
-func (m *MockTaskController) CreateTask(req *dto.CreateTaskRequestGateway, creatorID uuid.UUID) (*at.TaskResponse, error) {
-	args := m.Called(req, creatorID)
+func (m *MockTaskController) CreateTask(req *dto.CreateTaskRequestGateway, creatorID uuid.UUID, permissions []string) (*at.TaskResponse, error) {
+	args := m.Called(req, creatorID, permissions)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*at.TaskResponse), args.Error(1)
 }
 
-func (m *MockTaskController) UpdateTaskStatus(taskID, statusID int) error {
-	args := m.Called(taskID, statusID)
+func (m *MockTaskController) UpdateTaskStatus(taskID, statusID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, statusID, actorID, permissions)
 	return args.Error(0)
 }
 
-func (m *MockTaskController) GetTaskByID(taskID int) (*at.TaskServiceResponse, error) {
-	args := m.Called(taskID)
+func (m *MockTaskController) GetTaskByID(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskServiceResponse, error) {
+	args := m.Called(taskID, actorID, permissions)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*at.TaskServiceResponse), args.Error(1)
 }
 
-func (m *MockTaskController) GetUserTasks(userID string, limit, offset int) (*[]at.TaskToList, error) {
-	args := m.Called(userID, limit, offset)
+func (m *MockTaskController) GetUserTasks(userID string, filter *dto.TaskListFilterGateway, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskToList, error) {
+	args := m.Called(userID, filter, limit, offset, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]at.TaskToList), args.Error(1)
+}
+
+func (m *MockTaskController) UpdateTask(taskID int, req *dto.UpdateTaskRequestGateway, actorID uuid.UUID, permissions []string) (*at.TaskResponse, error) {
+	args := m.Called(taskID, req, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskResponse), args.Error(1)
+}
+
+func (m *MockTaskController) DeleteTask(taskID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) BulkUpdateTasks(actorID uuid.UUID, permissions []string, req *at.TaskBulkRequest) (*at.TaskBulkResult, error) {
+	args := m.Called(actorID, permissions, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskBulkResult), args.Error(1)
+}
+
+func (m *MockTaskController) GetCreatedTasks(userID string, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskToList, error) {
+	args := m.Called(userID, limit, offset, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]at.TaskToList), args.Error(1)
+}
+
+func (m *MockTaskController) GetChatTasks(chatID string, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskToList, error) {
+	args := m.Called(chatID, limit, offset, actorID, permissions)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*[]at.TaskToList), args.Error(1)
 }
 
+func (m *MockTaskController) GetTaskHistory(taskID, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskActivity, error) {
+	args := m.Called(taskID, limit, offset, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]at.TaskActivity), args.Error(1)
+}
+
+func (m *MockTaskController) GetUserActivity(userID string, limit, offset int, actorID uuid.UUID, permissions []string) (*[]at.TaskActivity, error) {
+	args := m.Called(userID, limit, offset, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]at.TaskActivity), args.Error(1)
+}
+
+func (m *MockTaskController) CreateTaskComment(taskID int, req *dto.CreateTaskCommentRequestGateway, actorID uuid.UUID) (*at.TaskComment, error) {
+	args := m.Called(taskID, req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskComment), args.Error(1)
+}
+
+func (m *MockTaskController) UpdateTaskComment(taskID, commentID int, req *dto.UpdateTaskCommentRequestGateway, actorID uuid.UUID) (*at.TaskComment, error) {
+	args := m.Called(taskID, commentID, req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskComment), args.Error(1)
+}
+
+func (m *MockTaskController) DeleteTaskComment(taskID, commentID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, commentID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) GetTaskComments(taskID, limit, offset int, actorID uuid.UUID, permissions []string) ([]at.TaskComment, error) {
+	args := m.Called(taskID, limit, offset, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TaskComment), args.Error(1)
+}
+
+func (m *MockTaskController) GetTaskTree(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskTreeNode, error) {
+	args := m.Called(taskID, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTreeNode), args.Error(1)
+}
+
+func (m *MockTaskController) GetTaskDependencies(taskID int, actorID uuid.UUID, permissions []string) (*at.TaskDependencyGraph, error) {
+	args := m.Called(taskID, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskDependencyGraph), args.Error(1)
+}
+
+func (m *MockTaskController) AddTaskDependency(taskID, blockerTaskID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, blockerTaskID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) RemoveTaskDependency(taskID, blockerTaskID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, blockerTaskID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) GetTaskLabels(taskID int, actorID uuid.UUID, permissions []string) ([]at.TaskLabel, error) {
+	args := m.Called(taskID, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TaskLabel), args.Error(1)
+}
+
+func (m *MockTaskController) AddTaskLabel(taskID, labelID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, labelID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) RemoveTaskLabel(taskID, labelID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, labelID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) AddTaskAssignee(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, userID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) RemoveTaskAssignee(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, userID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) AddTaskWatcher(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, userID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) RemoveTaskWatcher(taskID int, userID, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, userID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) StartTaskTimer(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error) {
+	args := m.Called(taskID, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskController) StopTaskTimer(taskID int, actorID uuid.UUID) (*at.TaskTimeLog, error) {
+	args := m.Called(taskID, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskController) CreateTaskTimeLog(taskID int, req *at.CreateTaskTimeLogRequest, actorID uuid.UUID) (*at.TaskTimeLog, error) {
+	args := m.Called(taskID, req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskController) UpdateTaskTimeLog(taskID, logID int, req *at.UpdateTaskTimeLogRequest, actorID uuid.UUID, permissions []string) (*at.TaskTimeLog, error) {
+	args := m.Called(taskID, logID, req, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskController) DeleteTaskTimeLog(taskID, logID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, logID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) GetTaskTimeLogs(taskID, limit, offset int, actorID uuid.UUID, permissions []string) ([]at.TaskTimeLog, error) {
+	args := m.Called(taskID, limit, offset, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskController) GetTimeSpent(query *dto.TimeSpentQueryGateway, actorID uuid.UUID, permissions []string) ([]at.TimeSpent, error) {
+	args := m.Called(query, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TimeSpent), args.Error(1)
+}
+
+func (m *MockTaskController) GetAllLabels() ([]at.Label, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.Label), args.Error(1)
+}
+
+func (m *MockTaskController) GetLabelByID(labelID int) (*at.Label, error) {
+	args := m.Called(labelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.Label), args.Error(1)
+}
+
+func (m *MockTaskController) CreateLabel(req *at.SaveLabelRequest) (*at.Label, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.Label), args.Error(1)
+}
+
+func (m *MockTaskController) UpdateLabel(labelID int, req *at.SaveLabelRequest) (*at.Label, error) {
+	args := m.Called(labelID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.Label), args.Error(1)
+}
+
+func (m *MockTaskController) DeleteLabel(labelID int) error {
+	args := m.Called(labelID)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) CreateTaskFromMessage(req *at.CreateTaskFromMessageRequest, actorID uuid.UUID) (*at.TaskResponse, error) {
+	args := m.Called(req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskResponse), args.Error(1)
+}
+
+func (m *MockTaskController) CreateTaskRecurrence(req *at.SaveTaskRecurrenceRequest, actorID uuid.UUID) (*at.TaskRecurrence, error) {
+	args := m.Called(req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskRecurrence), args.Error(1)
+}
+
+func (m *MockTaskController) GetTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string) (*at.TaskRecurrence, error) {
+	args := m.Called(recurrenceID, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskRecurrence), args.Error(1)
+}
+
+func (m *MockTaskController) UpdateTaskRecurrence(recurrenceID int, req *at.SaveTaskRecurrenceRequest, actorID uuid.UUID, permissions []string) (*at.TaskRecurrence, error) {
+	args := m.Called(recurrenceID, req, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskRecurrence), args.Error(1)
+}
+
+func (m *MockTaskController) DeleteTaskRecurrence(recurrenceID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(recurrenceID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) GetTaskReport(report string, query *dto.TaskReportQueryGateway, actorID uuid.UUID, permissions []string) (*dto.TaskReport, error) {
+	args := m.Called(report, query, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TaskReport), args.Error(1)
+}
+
+func (m *MockTaskController) GetAllTaskTemplates(actorID uuid.UUID, permissions []string) ([]at.TaskTemplate, error) {
+	args := m.Called(actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskController) GetTaskTemplate(templateID int, actorID uuid.UUID, permissions []string) (*at.TaskTemplate, error) {
+	args := m.Called(templateID, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskController) CreateTaskTemplate(req *at.SaveTaskTemplateRequest, actorID uuid.UUID) (*at.TaskTemplate, error) {
+	args := m.Called(req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskController) UpdateTaskTemplate(templateID int, req *at.SaveTaskTemplateRequest, actorID uuid.UUID, permissions []string) (*at.TaskTemplate, error) {
+	args := m.Called(templateID, req, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskController) DeleteTaskTemplate(templateID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(templateID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) CreateTaskFromTemplate(templateID int, req *at.CreateTaskFromTemplateRequest, actorID uuid.UUID, permissions []string) (*at.TaskResponse, error) {
+	args := m.Called(templateID, req, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskResponse), args.Error(1)
+}
+
+func (m *MockTaskController) GetTaskChecklist(taskID int, actorID uuid.UUID, permissions []string) ([]at.TaskChecklistItem, error) {
+	args := m.Called(taskID, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskController) AddChecklistItem(taskID int, req *at.AddChecklistItemRequest, actorID uuid.UUID, permissions []string) (*at.TaskChecklistItem, error) {
+	args := m.Called(taskID, req, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskController) UpdateChecklistItem(taskID, itemID int, req *at.UpdateChecklistItemRequest, actorID uuid.UUID, permissions []string) (*at.TaskChecklistItem, error) {
+	args := m.Called(taskID, itemID, req, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskController) RemoveChecklistItem(taskID, itemID int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, itemID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) SearchTasks(query *dto.TaskQueryGateway, actorID uuid.UUID, permissions []string) (*at.TaskQueryResult, error) {
+	args := m.Called(query, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskQueryResult), args.Error(1)
+}
+
+func (m *MockTaskController) GetTaskBoard(query *dto.TaskBoardQueryGateway, actorID uuid.UUID, permissions []string) (*at.TaskBoard, error) {
+	args := m.Called(query, actorID, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskBoard), args.Error(1)
+}
+
+func (m *MockTaskController) MoveTask(taskID int, req *at.MoveTaskRequest, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(taskID, req, actorID, permissions)
+	return args.Error(0)
+}
+
 func (m *MockTaskController) GetAllStatuses() ([]at.TaskStatus, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
@@ -261,8 +675,53 @@ func (m *MockTaskController) GetStatusByID(statusID int) (*at.TaskStatus, error)
 	return args.Get(0).(*at.TaskStatus), args.Error(1)
 }
 
-func (m *MockTaskController) DeleteStatus(statusID int) error {
+func (m *MockTaskController) GetStatusUsage(statusID int) (*at.TaskStatusUsage, error) {
 	args := m.Called(statusID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskStatusUsage), args.Error(1)
+}
+
+func (m *MockTaskController) DeleteStatus(statusID int, replacementID *int, actorID uuid.UUID, permissions []string) error {
+	args := m.Called(statusID, replacementID, actorID, permissions)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) GetAllWorkflows() ([]at.TaskWorkflow, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]at.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskController) GetWorkflowByID(workflowID int) (*at.TaskWorkflow, error) {
+	args := m.Called(workflowID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskController) CreateWorkflow(req *at.SaveWorkflowRequest) (*at.TaskWorkflow, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskController) UpdateWorkflow(workflowID int, req *at.SaveWorkflowRequest) (*at.TaskWorkflow, error) {
+	args := m.Called(workflowID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*at.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskController) DeleteWorkflow(workflowID int) error {
+	args := m.Called(workflowID)
 	return args.Error(0)
 }
 
@@ -328,3 +787,29 @@ func (m *MockChatRolePermissionController) DeletePermission(permissionID int) er
 	args := m.Called(permissionID)
 	return args.Error(0)
 }
+
+// MockCalendarController - мок для CalendarController
+type MockCalendarController struct {
+	mock.Mock
+}
+
+func (m *MockCalendarController) IssueFeedToken(userID uuid.UUID) (*dto.CalendarFeedResponse, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.CalendarFeedResponse), args.Error(1)
+}
+
+func (m *MockCalendarController) RevokeFeedToken(userID uuid.UUID) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockCalendarController) GetFeed(token string, kind services.CalendarEntryKind) ([]byte, error) {
+	args := m.Called(token, kind)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
@@ -0,0 +1,1136 @@
+package handlers
+
+import (
+	"apiService/internal/custom_errors"
+	"apiService/internal/dto"
+	"apiService/internal/handlers"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	at "common/contracts/api-task"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTaskLifecycleRouter(controller *MockTaskController, userID uuid.UUID, permissions []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskHandler(controller)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Set("permissions", permissions)
+		c.Next()
+	})
+	router.PATCH("/tasks/:task_id", handler.UpdateTask)
+	router.PATCH("/tasks/:task_id/status/:status_id", handler.UpdateTaskStatus)
+	router.DELETE("/tasks/:task_id", handler.DeleteTask)
+	router.POST("/tasks/bulk", handler.BulkUpdateTasks)
+	router.GET("/tasks/created", handler.GetCreatedTasks)
+	router.GET("/tasks/search", handler.SearchTasks)
+	router.GET("/tasks/board", handler.GetTaskBoard)
+	router.POST("/tasks/:task_id/move", handler.MoveTask)
+	router.GET("/tasks/chat/:chat_id", handler.GetChatTasks)
+	router.GET("/tasks/:task_id/history", handler.GetTaskHistory)
+	router.GET("/tasks/activity", handler.GetMyActivity)
+	router.GET("/users/:user_id/tasks", handler.GetUserTasks)
+	router.POST("/tasks", handler.CreateTask)
+	router.POST("/tasks/from-message", handler.CreateTaskFromMessage)
+	router.POST("/tasks/:task_id/comments", handler.CreateTaskComment)
+	router.GET("/tasks/:task_id/comments", handler.GetTaskComments)
+	router.PATCH("/tasks/:task_id/comments/:comment_id", handler.UpdateTaskComment)
+	router.DELETE("/tasks/:task_id/comments/:comment_id", handler.DeleteTaskComment)
+	router.GET("/tasks/:task_id/tree", handler.GetTaskTree)
+	router.GET("/tasks/:task_id/dependencies", handler.GetTaskDependencies)
+	router.POST("/tasks/:task_id/dependencies", handler.AddTaskDependency)
+	router.DELETE("/tasks/:task_id/dependencies/:blocker_task_id", handler.RemoveTaskDependency)
+	router.GET("/tasks/:task_id/labels", handler.GetTaskLabels)
+	router.POST("/tasks/:task_id/labels/:label_id", handler.AddTaskLabel)
+	router.DELETE("/tasks/:task_id/labels/:label_id", handler.RemoveTaskLabel)
+	router.GET("/tasks/labels", handler.GetAllLabels)
+	router.POST("/tasks/labels", handler.CreateLabel)
+	router.PUT("/tasks/labels/:label_id", handler.UpdateLabel)
+	router.POST("/tasks/:task_id/assignees/:user_id", handler.AddTaskAssignee)
+	router.DELETE("/tasks/:task_id/assignees/:user_id", handler.RemoveTaskAssignee)
+	router.POST("/tasks/:task_id/watchers/:user_id", handler.AddTaskWatcher)
+	router.DELETE("/tasks/:task_id/watchers/:user_id", handler.RemoveTaskWatcher)
+	router.POST("/tasks/:task_id/time-logs", handler.CreateTaskTimeLog)
+	router.POST("/tasks/:task_id/time-logs/start", handler.StartTaskTimer)
+	router.DELETE("/tasks/:task_id/time-logs/:log_id", handler.DeleteTaskTimeLog)
+	router.GET("/tasks/time-spent", handler.GetTimeSpent)
+	router.POST("/tasks/recurrences", handler.CreateTaskRecurrence)
+	router.PUT("/tasks/recurrences/:recurrence_id", handler.UpdateTaskRecurrence)
+	router.DELETE("/tasks/recurrences/:recurrence_id", handler.DeleteTaskRecurrence)
+	router.POST("/tasks/templates", handler.CreateTaskTemplate)
+	router.DELETE("/tasks/templates/:template_id", handler.DeleteTaskTemplate)
+	router.POST("/tasks/templates/:template_id/tasks", handler.CreateTaskFromTemplate)
+	router.POST("/tasks/:task_id/checklist", handler.AddChecklistItem)
+	router.PATCH("/tasks/:task_id/checklist/:item_id", handler.UpdateChecklistItem)
+	router.GET("/tasks/reports/throughput", handler.GetTaskThroughput)
+	router.GET("/tasks/reports/cumulative-flow", handler.GetTaskCumulativeFlow)
+	router.GET("/tasks/statuses/:status_id/usage", handler.GetStatusUsage)
+	router.DELETE("/tasks/statuses/:status_id", handler.DeleteStatus)
+	return router
+}
+
+func TestTaskHandler_UpdateTask_Success(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	permissions := []string{"process_tasks", "manage_all_tasks"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("title", "Updated")
+	_ = writer.WriteField("remove_file_ids", "4")
+	_ = writer.Close()
+
+	mockController.On("UpdateTask", 5, mock.MatchedBy(func(req *dto.UpdateTaskRequestGateway) bool {
+		return req.Title != nil && *req.Title == "Updated" && len(req.RemoveFileIDs) == 1 && req.RemoveFileIDs[0] == 4
+	}), userID, permissions).Return(&at.TaskResponse{ID: 5, Title: "Updated"}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PATCH", "/tasks/5", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_UpdateTask_InvalidExecutorID(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("executor_id", "bad")
+	_ = writer.Close()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PATCH", "/tasks/5", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_DeleteTask_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"forbidden", custom_errors.NewTaskServiceError(http.StatusForbidden, "denied"), http.StatusForbidden},
+		{"not found", custom_errors.NewTaskServiceError(http.StatusNotFound, "missing"), http.StatusNotFound},
+		{"service failure", custom_errors.NewTaskServiceError(http.StatusInternalServerError, "boom"), http.StatusInternalServerError},
+		{"transport", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			userID := uuid.New()
+			router := newTaskLifecycleRouter(mockController, userID, []string{"process_tasks"})
+			mockController.On("DeleteTask", 9, userID, []string{"process_tasks"}).Return(tt.err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/tasks/9", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockController.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_BulkUpdateTasks_Success(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	permissions := []string{"process_tasks", "manage_all_tasks"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
+	statusID := 3
+
+	mockController.On("BulkUpdateTasks", userID, permissions, &at.TaskBulkRequest{
+		TaskIDs:  []int{1, 2},
+		Action:   at.TaskBulkChangeStatus,
+		StatusID: &statusID,
+		Atomic:   true,
+	}).Return(&at.TaskBulkResult{Atomic: true, Succeeded: 2, Items: []at.TaskBulkItemResult{
+		{TaskID: 1, Success: true},
+		{TaskID: 2, Success: true},
+	}}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/bulk", strings.NewReader(`{"task_ids":[1,2],"action":"change_status","status_id":3,"atomic":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var result at.TaskBulkResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, 2, result.Succeeded)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_BulkUpdateTasks_InvalidAction(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/bulk", strings.NewReader(`{"task_ids":[1],"action":"archive"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "BulkUpdateTasks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_GetCreatedTasks_UsesCurrentUser(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	router := newTaskLifecycleRouter(mockController, userID, nil)
+	tasks := []at.TaskToList{{ID: 1}}
+
+	mockController.On("GetCreatedTasks", userID.String(), 10, 0, mock.Anything, mock.Anything).Return(&tasks, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/tasks/created?limit=10", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetChatTasks(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+	chatID := uuid.New().String()
+	tasks := []at.TaskToList{{ID: 1}}
+
+	mockController.On("GetChatTasks", chatID, 20, 0, mock.Anything, mock.Anything).Return(&tasks, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/tasks/chat/"+chatID, nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/tasks/chat/bad-id", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetTaskHistory(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	mockController.On("GetTaskHistory", 4, 20, 0, mock.Anything, mock.Anything).Return(&[]at.TaskActivity{{ID: 1, TaskID: 4, EventType: "created"}}, nil)
+	mockController.On("GetTaskHistory", 5, 20, 0, mock.Anything, mock.Anything).
+		Return(nil, custom_errors.NewTaskServiceError(http.StatusNotFound, `{"error":"task not found"}`))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/4/history", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/5/history", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/5/history?limit=500", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTaskHandler_GetMyActivity(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	router := newTaskLifecycleRouter(mockController, userID, nil)
+
+	mockController.On("GetUserActivity", userID.String(), 10, 0, mock.Anything, mock.Anything).Return(&[]at.TaskActivity{}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/activity?limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetUserTasks_ForwardsFilter(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+	userID := uuid.New().String()
+	overdue := true
+
+	mockController.On("GetUserTasks", userID, &dto.TaskListFilterGateway{
+		Priority: "high,urgent",
+		Overdue:  &overdue,
+		SortBy:   "due_at",
+		Order:    "asc",
+	}, 20, 0, mock.Anything, mock.Anything).Return(&[]at.TaskToList{}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/users/"+userID+"/tasks?priority=high,urgent&overdue=true&sort_by=due_at&order=asc", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetUserTasks_InvalidSort(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/users/"+uuid.New().String()+"/tasks?sort_by=title", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_CreateTask_InvalidDueAt(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("title", "Task")
+	_ = writer.WriteField("executor_id", uuid.New().String())
+	_ = writer.WriteField("due_at", "tomorrow")
+	_ = writer.Close()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_CreateTask_ForwardsScheduleError(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	router := newTaskLifecycleRouter(mockController, userID, nil)
+
+	mockController.On("CreateTask", mock.MatchedBy(func(req *dto.CreateTaskRequestGateway) bool {
+		return req.Priority == "urgent" && req.DueAt != nil && req.StartAt != nil
+	}), userID, mock.Anything).Return(nil, custom_errors.NewTaskServiceError(http.StatusBadRequest, `{"error":"task start is after due date"}`))
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("title", "Task")
+	_ = writer.WriteField("executor_id", uuid.New().String())
+	_ = writer.WriteField("priority", "urgent")
+	_ = writer.WriteField("start_at", "2026-06-02T00:00:00Z")
+	_ = writer.WriteField("due_at", "2026-06-01T00:00:00Z")
+	_ = writer.Close()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_CreateTaskComment(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	router := newTaskLifecycleRouter(mockController, userID, nil)
+
+	mockController.On("CreateTaskComment", 3, mock.MatchedBy(func(req *dto.CreateTaskCommentRequestGateway) bool {
+		return req.Body == "hello @bob"
+	}), userID).Return(&at.TaskComment{ID: 1, TaskID: 3, Body: "hello @bob"}, nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("body", "hello @bob")
+	_ = writer.Close()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/3/comments", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_CreateTaskComment_EmptyBody(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("body", "")
+	_ = writer.Close()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/3/comments", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "CreateTaskComment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_UpdateTaskComment_ForwardsForbidden(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	router := newTaskLifecycleRouter(mockController, userID, nil)
+
+	mockController.On("UpdateTaskComment", 3, 8, mock.MatchedBy(func(req *dto.UpdateTaskCommentRequestGateway) bool {
+		return req.Body != nil && *req.Body == "edited"
+	}), userID).Return(nil, custom_errors.NewTaskServiceError(http.StatusForbidden, `{"error":"only the author can edit the comment"}`))
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("body", "edited")
+	_ = writer.Close()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PATCH", "/tasks/3/comments/8", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_DeleteTaskComment(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	permissions := []string{"process_tasks", "manage_all_tasks"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
+
+	mockController.On("DeleteTaskComment", 3, 8, userID, permissions).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/3/comments/8", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetTaskComments(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	mockController.On("GetTaskComments", 3, 20, 0, mock.Anything, mock.Anything).Return([]at.TaskComment{{ID: 1, TaskID: 3}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/3/comments", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetTaskTree(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	mockController.On("GetTaskTree", 3, mock.Anything, mock.Anything).Return(&at.TaskTreeNode{
+		ID:       3,
+		Progress: 50,
+		Subtasks: []*at.TaskTreeNode{{ID: 4, Closed: true, Progress: 100}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/3/tree", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response at.TaskTreeNode
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 50, response.Progress)
+	assert.Len(t, response.Subtasks, 1)
+}
+
+func TestTaskHandler_AddTaskDependency(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	permissions := []string{"process_tasks"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
+
+	mockController.On("AddTaskDependency", 3, 7, userID, permissions).
+		Return(custom_errors.NewTaskServiceError(http.StatusConflict, `{"error":"dependency cycle"}`))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/3/dependencies", strings.NewReader(`{"blocker_task_id":7}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_AddTaskDependency_MissingBlocker(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/3/dependencies", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "AddTaskDependency", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_RemoveTaskDependency(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	router := newTaskLifecycleRouter(mockController, userID, nil)
+
+	mockController.On("RemoveTaskDependency", 3, 7, userID, []string(nil)).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/3/dependencies/7", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_SearchTasks(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	mockController.On("SearchTasks", mock.MatchedBy(func(q *dto.TaskQueryGateway) bool {
+		return q.Q == "отчёт" && q.Status == "1,3" && q.SortBy == "title" && q.Limit == 5 && q.Cursor == "abc"
+	}), mock.Anything, mock.Anything).Return(&at.TaskQueryResult{Tasks: []at.TaskToList{{ID: 4}}, Total: 9, NextCursor: stringPtr("def")}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/search?q=%D0%BE%D1%82%D1%87%D1%91%D1%82&status=1,3&sort_by=title&limit=5&cursor=abc", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response at.TaskQueryResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(9), response.Total)
+	assert.Equal(t, "def", *response.NextCursor)
+}
+
+func TestTaskHandler_SearchTasks_InvalidParams(t *testing.T) {
+	for _, query := range []string{"sort_by=executor_id", "order=up", "limit=500"} {
+		t.Run(query, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/search?"+query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockController.AssertNotCalled(t, "SearchTasks", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTaskHandler_AddTaskLabel(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	permissions := []string{"process_tasks"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
+
+	mockController.On("AddTaskLabel", 3, 5, userID, permissions).
+		Return(custom_errors.NewTaskServiceError(http.StatusForbidden, `{"error":"access denied"}`))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/tasks/3/labels/5", nil))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_RemoveTaskLabel(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	router := newTaskLifecycleRouter(mockController, userID, nil)
+
+	mockController.On("RemoveTaskLabel", 3, 5, userID, []string(nil)).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/3/labels/5", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_AddTaskAssignee(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	assigneeID := uuid.New()
+	permissions := []string{"process_tasks"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
+
+	mockController.On("AddTaskAssignee", 3, assigneeID, userID, permissions).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/tasks/3/assignees/"+assigneeID.String(), nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_AddTaskAssignee_InvalidUserID(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/tasks/3/assignees/not-a-uuid", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "AddTaskAssignee", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_RemoveTaskWatcher_NotFound(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	router := newTaskLifecycleRouter(mockController, userID, nil)
+
+	mockController.On("RemoveTaskWatcher", 3, userID, userID, []string(nil)).
+		Return(custom_errors.NewTaskServiceError(http.StatusNotFound, `{"error":"watcher not found"}`))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/3/watchers/"+userID.String(), nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_StartTaskTimer_AlreadyRunning(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	router := newTaskLifecycleRouter(mockController, userID, nil)
+
+	mockController.On("StartTaskTimer", 3, userID).
+		Return(nil, custom_errors.NewTaskServiceError(http.StatusConflict, `{"error":"timer already running"}`))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/tasks/3/time-logs/start", nil))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_CreateTaskTimeLog_MissingMinutes(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/3/time-logs", strings.NewReader(`{"started_at":"2026-05-01T10:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "CreateTaskTimeLog", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_DeleteTaskTimeLog_PassesPermissions(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	permissions := []string{"process_tasks", "manage_all_tasks"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
+
+	mockController.On("DeleteTaskTimeLog", 3, 7, userID, permissions).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/3/time-logs/7", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetTimeSpent(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+	chatID := uuid.New().String()
+
+	mockController.On("GetTimeSpent", &dto.TimeSpentQueryGateway{GroupBy: "user", From: "2026-05-01T00:00:00Z", ChatID: chatID}, mock.Anything, mock.Anything).
+		Return([]at.TimeSpent{{Minutes: 90, Entries: 2}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/time-spent?group_by=user&from=2026-05-01T00:00:00Z&chat_id="+chatID, nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetTimeSpent_InvalidGroup(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/time-spent?group_by=label", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetTimeSpent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_GetAllLabels(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	mockController.On("GetAllLabels").Return([]at.Label{{ID: 1, Name: "bug", Color: "#ff0000", UsageCount: 2}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/labels", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []at.Label
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(2), response[0].UsageCount)
+}
+
+func TestTaskHandler_CreateLabel_InvalidColor(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/labels", strings.NewReader(`{"name":"bug","color":"red"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "CreateLabel", mock.Anything)
+}
+
+func TestTaskHandler_UpdateLabel_Conflict(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	mockController.On("UpdateLabel", 1, &at.SaveLabelRequest{Name: "bug", Color: "#f00"}).
+		Return(nil, custom_errors.NewTaskServiceError(http.StatusConflict, `{"error":"label with this name already exists"}`))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/tasks/labels/1", strings.NewReader(`{"name":"bug","color":"#f00"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestTaskHandler_GetTaskBoard_DefaultsToCurrentUser(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	router := newTaskLifecycleRouter(mockController, userID, nil)
+
+	mockController.On("GetTaskBoard", &dto.TaskBoardQueryGateway{ExecutorID: userID.String(), Limit: 10}, mock.Anything, mock.Anything).
+		Return(&at.TaskBoard{Columns: []at.TaskBoardColumn{{StatusID: 1, Status: "created", Tasks: []at.TaskBoardCard{}}}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/board?limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetTaskBoard_ByChat(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+	chatID := uuid.New().String()
+
+	mockController.On("GetTaskBoard", &dto.TaskBoardQueryGateway{ChatID: chatID}, mock.Anything, mock.Anything).Return(&at.TaskBoard{}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/board?chat_id="+chatID, nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetTaskBoard_InvalidChatID(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/board?chat_id=abc", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetTaskBoard", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_MoveTask(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		err          error
+		expectedCode int
+	}{
+		{name: "success", body: `{"status_id":2,"before_task_id":4}`, expectedCode: http.StatusNoContent},
+		{name: "missing status", body: `{"before_task_id":4}`, expectedCode: http.StatusBadRequest},
+		{name: "stale board", body: `{"status_id":2,"before_task_id":4}`,
+			err: custom_errors.NewTaskServiceError(http.StatusConflict, `{"error":"reload the board"}`), expectedCode: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			userID := uuid.New()
+			permissions := []string{"process_tasks"}
+			router := newTaskLifecycleRouter(mockController, userID, permissions)
+			beforeTaskID := 4
+			mockController.On("MoveTask", 3, &at.MoveTaskRequest{StatusID: 2, BeforeTaskID: &beforeTaskID}, userID, permissions).
+				Return(tt.err).Maybe()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/3/move", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskHandler_CreateTaskRecurrence(t *testing.T) {
+	executorID := uuid.New().String()
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode int
+	}{
+		{name: "success", body: `{"title":"Report","executor_id":"` + executorID + `","rule":"FREQ=WEEKLY;BYDAY=MO","starts_at":"2026-06-01T09:00:00Z"}`, expectedCode: http.StatusCreated},
+		{name: "missing rule", body: `{"title":"Report","executor_id":"` + executorID + `","starts_at":"2026-06-01T09:00:00Z"}`, expectedCode: http.StatusBadRequest},
+		{name: "invalid due", body: `{"title":"Report","executor_id":"` + executorID + `","rule":"FREQ=DAILY","starts_at":"2026-06-01T09:00:00Z","due_after_minutes":0}`, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			userID := uuid.New()
+			router := newTaskLifecycleRouter(mockController, userID, nil)
+			mockController.On("CreateTaskRecurrence", mock.AnythingOfType("*api_task.SaveTaskRecurrenceRequest"), userID).
+				Return(&at.TaskRecurrence{ID: 4, Title: "Report"}, nil).Maybe()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/recurrences", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode != http.StatusCreated {
+				mockController.AssertNotCalled(t, "CreateTaskRecurrence", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_UpdateTaskRecurrence_Forbidden(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	permissions := []string{"process_tasks"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
+
+	mockController.On("UpdateTaskRecurrence", 2, mock.AnythingOfType("*api_task.SaveTaskRecurrenceRequest"), userID, permissions).
+		Return(nil, custom_errors.NewTaskServiceError(http.StatusForbidden, `{"error":"access denied"}`))
+
+	body := `{"title":"Report","executor_id":"` + uuid.New().String() + `","rule":"FREQ=DAILY","starts_at":"2026-06-01T09:00:00Z"}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/tasks/recurrences/2", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_DeleteTaskRecurrence(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	permissions := []string{"manage_all_tasks"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
+
+	mockController.On("DeleteTaskRecurrence", 2, userID, permissions).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/recurrences/2", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_CreateTaskFromMessage(t *testing.T) {
+	messageID := uuid.New()
+	executorID := uuid.New()
+	validBody := `{"message_id":"` + messageID.String() + `","executor_id":"` + executorID.String() + `"}`
+	tests := []struct {
+		name         string
+		body         string
+		err          error
+		expectedCode int
+	}{
+		{name: "success", body: validBody, expectedCode: http.StatusCreated},
+		{name: "missing message", body: `{"executor_id":"` + executorID.String() + `"}`, expectedCode: http.StatusBadRequest},
+		{name: "no view_messages", body: validBody,
+			err: custom_errors.NewTaskServiceError(http.StatusForbidden, `{"error":"no permission"}`), expectedCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			userID := uuid.New()
+			router := newTaskLifecycleRouter(mockController, userID, nil)
+			var task *at.TaskResponse
+			if tt.err == nil {
+				task = &at.TaskResponse{ID: 12}
+			}
+			mockController.On("CreateTaskFromMessage", &at.CreateTaskFromMessageRequest{MessageID: messageID, ExecutorID: executorID}, userID).
+				Return(task, tt.err).Maybe()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/from-message", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskHandler_CreateTaskTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		err          error
+		expectedCode int
+	}{
+		{name: "success", body: `{"name":"Onboarding","title_pattern":"Onboarding {name}","checklist_items":["Laptop","Access"]}`, expectedCode: http.StatusCreated},
+		{name: "missing pattern", body: `{"name":"Onboarding"}`, expectedCode: http.StatusBadRequest},
+		{name: "empty checklist item", body: `{"name":"Onboarding","title_pattern":"Onboarding","checklist_items":[""]}`, expectedCode: http.StatusBadRequest},
+		{name: "duplicate name", body: `{"name":"Onboarding","title_pattern":"Onboarding"}`,
+			err: custom_errors.NewTaskServiceError(http.StatusConflict, `{"error":"task template already exists"}`), expectedCode: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			userID := uuid.New()
+			router := newTaskLifecycleRouter(mockController, userID, nil)
+			var template *at.TaskTemplate
+			if tt.err == nil {
+				template = &at.TaskTemplate{ID: 2, Name: "Onboarding"}
+			}
+			mockController.On("CreateTaskTemplate", mock.AnythingOfType("*api_task.SaveTaskTemplateRequest"), userID).
+				Return(template, tt.err).Maybe()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/templates", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusBadRequest {
+				mockController.AssertNotCalled(t, "CreateTaskTemplate", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_DeleteTaskTemplate_PassesPermissions(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	permissions := []string{"manage_all_tasks"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
+
+	mockController.On("DeleteTaskTemplate", 2, userID, permissions).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/templates/2", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_CreateTaskFromTemplate_MissingVariable(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	router := newTaskLifecycleRouter(mockController, userID, nil)
+
+	mockController.On("CreateTaskFromTemplate", 2, mock.AnythingOfType("*api_task.CreateTaskFromTemplateRequest"), userID, mock.Anything).
+		Return(nil, custom_errors.NewTaskServiceError(http.StatusBadRequest, `{"error":"no value for {name}"}`))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/templates/2/tasks", strings.NewReader(`{"chat_id":"`+uuid.New().String()+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "no value for {name}")
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_AddChecklistItem_EmptyTitle(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/3/checklist", strings.NewReader(`{"title":""}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "AddChecklistItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_UpdateChecklistItem(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		body         string
+		expectedCode int
+	}{
+		{name: "mark done", url: "/tasks/3/checklist/7", body: `{"done":true}`, expectedCode: http.StatusOK},
+		{name: "invalid item", url: "/tasks/3/checklist/abc", body: `{"done":true}`, expectedCode: http.StatusBadRequest},
+		{name: "negative position", url: "/tasks/3/checklist/7", body: `{"position":-1}`, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			userID := uuid.New()
+			permissions := []string{"process_tasks"}
+			router := newTaskLifecycleRouter(mockController, userID, permissions)
+			mockController.On("UpdateChecklistItem", 3, 7, mock.AnythingOfType("*api_task.UpdateChecklistItemRequest"), userID, permissions).
+				Return(&at.TaskChecklistItem{ID: 7, TaskID: 3, Done: true}, nil).Maybe()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode != http.StatusOK {
+				mockController.AssertNotCalled(t, "UpdateChecklistItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_GetTaskThroughput_PassesCSVThrough(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	permissions := []string{"view_task_reports"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
+
+	query := &dto.TaskReportQueryGateway{Interval: "week", Format: "csv"}
+	mockController.On("GetTaskReport", "throughput", query, userID, permissions).Return(&dto.TaskReport{
+		ContentType:        "text/csv; charset=utf-8",
+		ContentDisposition: `attachment; filename="throughput.csv"`,
+		Body:               []byte("period,created,completed\n2026-05-25,3,1\n"),
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/reports/throughput?interval=week&format=csv", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="throughput.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "period,created,completed\n2026-05-25,3,1\n", w.Body.String())
+}
+
+func TestTaskHandler_TaskReports_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "invalid interval", url: "/tasks/reports/throughput?interval=month"},
+		{name: "invalid format", url: "/tasks/reports/throughput?format=xlsx"},
+		{name: "invalid chat", url: "/tasks/reports/cumulative-flow?chat_id=abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", tt.url, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockController.AssertNotCalled(t, "GetTaskReport", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTaskHandler_GetTaskCumulativeFlow_ForwardsServiceError(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	mockController.On("GetTaskReport", "cumulative-flow", &dto.TaskReportQueryGateway{}, mock.Anything, mock.Anything).
+		Return(nil, custom_errors.NewTaskServiceError(http.StatusBadRequest, `{"error":"invalid report: chat_id is required"}`))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/reports/cumulative-flow", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "chat_id is required")
+}
+
+func TestTaskHandler_GetStatusUsage(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	usage := &at.TaskStatusUsage{StatusID: 4, Name: "archived", Tasks: 2, DeletedTasks: 1, Workflows: []at.TaskStatusWorkflowRef{}}
+	mockController.On("GetStatusUsage", 4).Return(usage, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/statuses/4/usage", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response at.TaskStatusUsage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, *usage, response)
+}
+
+func TestTaskHandler_DeleteStatus_WithReplacement(t *testing.T) {
+	mockController := new(MockTaskController)
+	userID := uuid.New()
+	permissions := []string{"manage_task_statuses"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
+
+	replacementID := 3
+	mockController.On("DeleteStatus", 4, &replacementID, userID, permissions).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/statuses/4?replacement_id=3", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_DeleteStatus_InvalidReplacementID(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/statuses/4?replacement_id=abc", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "DeleteStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_DeleteStatus_ForwardsConflict(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
+
+	mockController.On("DeleteStatus", 4, (*int)(nil), mock.Anything, mock.Anything).
+		Return(custom_errors.NewTaskServiceError(http.StatusConflict, `{"error":"task status 4 is used by 2 tasks, replacement status is required"}`))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/statuses/4", nil))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "replacement status is required")
+}
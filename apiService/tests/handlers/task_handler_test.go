@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"apiService/internal/custom_errors"
+	"apiService/internal/dto"
 	"apiService/internal/handlers"
 	"bytes"
 	at "common/contracts/api-task"
@@ -33,9 +35,13 @@ func TestTaskHandler_GetTaskByID_Success(t *testing.T) {
 		},
 	}
 
-	mockController.On("GetTaskByID", taskID).Return(expectedTask, nil)
+	mockController.On("GetTaskByID", taskID, mock.Anything, mock.Anything).Return(expectedTask, nil)
 
 	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", uuid.New())
+		c.Next()
+	})
 	router.GET("/tasks/:task_id", handler.GetTaskByID)
 
 	// Act
@@ -61,6 +67,10 @@ func TestTaskHandler_GetTaskByID_InvalidID(t *testing.T) {
 	handler := handlers.NewTaskHandler(mockController)
 
 	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", uuid.New())
+		c.Next()
+	})
 	router.GET("/tasks/:task_id", handler.GetTaskByID)
 
 	// Act
@@ -71,7 +81,7 @@ func TestTaskHandler_GetTaskByID_InvalidID(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
-	mockController.AssertNotCalled(t, "GetTaskByID", mock.Anything)
+	mockController.AssertNotCalled(t, "GetTaskByID", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Тесты для TaskHandler.GetUserTasks
@@ -88,9 +98,13 @@ func TestTaskHandler_GetUserTasks_Success(t *testing.T) {
 		{ID: 2, Title: "Task 2"},
 	}
 
-	mockController.On("GetUserTasks", userID, 20, 0).Return(&expectedTasks, nil)
+	mockController.On("GetUserTasks", userID, &dto.TaskListFilterGateway{}, 20, 0, mock.Anything, mock.Anything).Return(&expectedTasks, nil)
 
 	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", uuid.New())
+		c.Next()
+	})
 	router.GET("/users/:user_id/tasks", handler.GetUserTasks)
 
 	// Act
@@ -116,6 +130,10 @@ func TestTaskHandler_GetUserTasks_MissingUserID(t *testing.T) {
 	handler := handlers.NewTaskHandler(mockController)
 
 	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", uuid.New())
+		c.Next()
+	})
 	router.GET("/users/:user_id/tasks", handler.GetUserTasks)
 
 	// Act
@@ -125,7 +143,7 @@ func TestTaskHandler_GetUserTasks_MissingUserID(t *testing.T) {
 
 	// Assert
 	// Может быть 404 или 400 в зависимости от роутера
-	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything)
+	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestTaskHandler_GetUserTasks_InvalidLimit(t *testing.T) {
@@ -137,6 +155,10 @@ func TestTaskHandler_GetUserTasks_InvalidLimit(t *testing.T) {
 	userID := uuid.New().String()
 
 	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", uuid.New())
+		c.Next()
+	})
 	router.GET("/users/:user_id/tasks", handler.GetUserTasks)
 
 	// Act
@@ -147,7 +169,7 @@ func TestTaskHandler_GetUserTasks_InvalidLimit(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
-	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything)
+	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Тесты для TaskHandler.GetAllStatuses
@@ -188,17 +210,15 @@ func TestTaskHandler_GetAllStatuses_Success(t *testing.T) {
 
 func TestTaskHandler_UpdateTaskStatus_Success(t *testing.T) {
 	// Arrange
-	gin.SetMode(gin.TestMode)
 	mockController := new(MockTaskController)
-	handler := handlers.NewTaskHandler(mockController)
+	userID := uuid.New()
+	permissions := []string{"process_tasks"}
+	router := newTaskLifecycleRouter(mockController, userID, permissions)
 
 	taskID := 1
 	statusID := 2
 
-	mockController.On("UpdateTaskStatus", taskID, statusID).Return(nil)
-
-	router := gin.New()
-	router.PATCH("/tasks/:task_id/status/:status_id", handler.UpdateTaskStatus)
+	mockController.On("UpdateTaskStatus", taskID, statusID, userID, permissions).Return(nil)
 
 	// Act
 	w := httptest.NewRecorder()
@@ -213,12 +233,8 @@ func TestTaskHandler_UpdateTaskStatus_Success(t *testing.T) {
 
 func TestTaskHandler_UpdateTaskStatus_InvalidTaskID(t *testing.T) {
 	// Arrange
-	gin.SetMode(gin.TestMode)
 	mockController := new(MockTaskController)
-	handler := handlers.NewTaskHandler(mockController)
-
-	router := gin.New()
-	router.PATCH("/tasks/:task_id/status/:status_id", handler.UpdateTaskStatus)
+	router := newTaskLifecycleRouter(mockController, uuid.New(), nil)
 
 	// Act
 	w := httptest.NewRecorder()
@@ -228,7 +244,36 @@ func TestTaskHandler_UpdateTaskStatus_InvalidTaskID(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
-	mockController.AssertNotCalled(t, "UpdateTaskStatus", mock.Anything, mock.Anything)
+	mockController.AssertNotCalled(t, "UpdateTaskStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_UpdateTaskStatus_ForwardsWorkflowErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		expectedCode int
+	}{
+		{name: "illegal transition", statusCode: http.StatusConflict, expectedCode: http.StatusConflict},
+		{name: "role forbidden", statusCode: http.StatusForbidden, expectedCode: http.StatusForbidden},
+		{name: "task service failure", statusCode: http.StatusInternalServerError, expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			userID := uuid.New()
+			router := newTaskLifecycleRouter(mockController, userID, nil)
+
+			mockController.On("UpdateTaskStatus", 1, 3, userID, mock.Anything).
+				Return(custom_errors.NewTaskServiceError(tt.statusCode, `{"error":"transition"}`))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/tasks/1/status/3", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
 }
 
 // Тесты для TaskHandler.CreateStatus
@@ -353,9 +398,14 @@ func TestTaskHandler_DeleteStatus_Success(t *testing.T) {
 
 	statusID := 1
 
-	mockController.On("DeleteStatus", statusID).Return(nil)
+	userID := uuid.New()
+	mockController.On("DeleteStatus", statusID, (*int)(nil), userID, mock.Anything).Return(nil)
 
 	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Next()
+	})
 	router.DELETE("/tasks/statuses/:status_id", handler.DeleteStatus)
 
 	// Act
@@ -381,6 +431,10 @@ func TestTaskHandler_DeleteStatus_InvalidID(t *testing.T) {
 	handler := handlers.NewTaskHandler(mockController)
 
 	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", uuid.New())
+		c.Next()
+	})
 	router.DELETE("/tasks/statuses/:status_id", handler.DeleteStatus)
 
 	// Act
@@ -391,7 +445,7 @@ func TestTaskHandler_DeleteStatus_InvalidID(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
-	mockController.AssertNotCalled(t, "DeleteStatus", mock.Anything)
+	mockController.AssertNotCalled(t, "DeleteStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Тесты для TaskHandler.CreateTask
@@ -409,7 +463,7 @@ func TestTaskHandler_CreateTask_Success(t *testing.T) {
 		Title: "New Task",
 	}
 
-	mockController.On("CreateTask", mock.Anything, userID).Return(expectedTask, nil)
+	mockController.On("CreateTask", mock.Anything, userID, mock.Anything).Return(expectedTask, nil)
 
 	router := gin.New()
 	router.Use(func(c *gin.Context) {
@@ -465,7 +519,7 @@ func TestTaskHandler_CreateTask_MissingUserID(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 
-	mockController.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+	mockController.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestTaskHandler_CreateTask_InvalidForm(t *testing.T) {
@@ -491,5 +545,5 @@ func TestTaskHandler_CreateTask_InvalidForm(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 
-	mockController.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+	mockController.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything, mock.Anything)
 }
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"apiService/internal/custom_errors"
+	"apiService/internal/handlers"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	at "common/contracts/api-task"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTaskWorkflowRouter(controller *MockTaskController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskHandler(controller)
+
+	router := gin.New()
+	router.GET("/tasks/workflows", handler.GetAllWorkflows)
+	router.GET("/tasks/workflows/:workflow_id", handler.GetWorkflowByID)
+	router.POST("/tasks/workflows", handler.CreateWorkflow)
+	router.PUT("/tasks/workflows/:workflow_id", handler.UpdateWorkflow)
+	router.DELETE("/tasks/workflows/:workflow_id", handler.DeleteWorkflow)
+	return router
+}
+
+func TestTaskHandler_CreateWorkflow_Success(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskWorkflowRouter(mockController)
+
+	mockController.On("CreateWorkflow", mock.MatchedBy(func(req *at.SaveWorkflowRequest) bool {
+		return req.Name == "dev" && len(req.StatusIDs) == 2 && req.Transitions[0].AllowedRole == "any"
+	})).Return(&at.TaskWorkflow{ID: 2, Name: "dev"}, nil)
+
+	body := `{"name":"dev","status_ids":[1,2],"transitions":[{"from_status_id":1,"to_status_id":2,"allowed_role":"any"}]}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/workflows", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response at.TaskWorkflow
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.ID)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_CreateWorkflow_InvalidRole(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskWorkflowRouter(mockController)
+
+	body := `{"name":"dev","status_ids":[1,2],"transitions":[{"from_status_id":1,"to_status_id":2,"allowed_role":"admin"}]}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/workflows", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "CreateWorkflow", mock.Anything)
+}
+
+func TestTaskHandler_CreateWorkflow_Conflict(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskWorkflowRouter(mockController)
+
+	mockController.On("CreateWorkflow", mock.Anything).
+		Return(nil, custom_errors.NewTaskServiceError(http.StatusConflict, `{"error":"already exists"}`))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/workflows", bytes.NewBufferString(`{"name":"dev","status_ids":[1]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestTaskHandler_UpdateWorkflow_NotFound(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskWorkflowRouter(mockController)
+
+	mockController.On("UpdateWorkflow", 5, mock.Anything).
+		Return(nil, custom_errors.NewTaskServiceError(http.StatusNotFound, `{"error":"not found"}`))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/tasks/workflows/5", bytes.NewBufferString(`{"name":"dev","status_ids":[1]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTaskHandler_GetWorkflows(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskWorkflowRouter(mockController)
+
+	mockController.On("GetAllWorkflows").Return([]at.TaskWorkflow{{ID: 1, Name: "default", IsDefault: true}}, nil)
+	mockController.On("GetWorkflowByID", 1).Return(&at.TaskWorkflow{ID: 1, Name: "default"}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/workflows", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/workflows/1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/workflows/x", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTaskHandler_DeleteWorkflow(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newTaskWorkflowRouter(mockController)
+
+	mockController.On("DeleteWorkflow", 3).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/workflows/3", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
@@ -412,7 +412,7 @@ func TestChatController_GetChatMembers_Integration(t *testing.T) {
 	chatID := uuid.New()
 
 	// Act
-	members, err := chatController.GetChatMembers(chatID)
+	members, err := chatController.GetChatMembers(chatID, &ac.ChatMembersQuery{Limit: 50})
 
 	// Assert
 	require.NoError(t, err)
@@ -258,8 +258,11 @@ func setupChatServiceMock(t *testing.T) *httptest.Server {
 		if strings.Contains(r.URL.Path, "/members") {
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
-			json.NewEncoder(w).Encode([]map[string]interface{}{
-				{"userID": uuid.New().String(), "role": map[string]interface{}{"name": "main"}},
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"members": []map[string]interface{}{
+					{"userId": uuid.New().String(), "roleId": 3, "roleName": "main", "username": "test_user"},
+				},
+				"total": 1,
 			})
 			return
 		}
@@ -388,6 +391,19 @@ func setupTaskServiceMock(t *testing.T) *httptest.Server {
 				return
 			}
 		}
+		if strings.HasPrefix(r.URL.Path, "/api/v1/tasks/") && r.Method == http.MethodPatch {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":    1,
+				"title": "test_task_updated",
+			})
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/v1/tasks/") && r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
 		if strings.HasPrefix(r.URL.Path, "/api/v1/tasks/") && r.Method == http.MethodGet {
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
@@ -42,7 +42,7 @@ func TestTaskController_GetTaskByID_Integration_NotFound(t *testing.T) {
 	taskID := 99999
 
 	// Act
-	task, err := taskController.GetTaskByID(taskID)
+	task, err := taskController.GetTaskByID(taskID, uuid.New(), nil)
 
 	// Assert
 	assert.Error(t, err)
@@ -78,7 +78,7 @@ func TestTaskController_CreateTask_Integration_ServiceError(t *testing.T) {
 	createReq := NewTestCreateTaskRequest()
 
 	// Act
-	response, err := taskController.CreateTask(createReq, uuid.New())
+	response, err := taskController.CreateTask(createReq, uuid.New(), nil)
 
 	// Assert
 	assert.Error(t, err)
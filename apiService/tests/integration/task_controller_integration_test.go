@@ -35,7 +35,7 @@ func TestTaskController_CreateTask_Integration(t *testing.T) {
 
 	// Создаем кеш перед созданием задачи
 	ctx := context.Background()
-	cacheService.SetUserTasksCache(ctx, creatorID.String(), []interface{}{})
+	cacheService.SetUserTasksCache(ctx, creatorID.String(), creatorID.String(), []interface{}{})
 
 	createReq := &dto.CreateTaskRequestGateway{
 		Title:      "test_task",
@@ -43,14 +43,14 @@ func TestTaskController_CreateTask_Integration(t *testing.T) {
 	}
 
 	// Act
-	task, err := taskController.CreateTask(createReq, creatorID)
+	task, err := taskController.CreateTask(createReq, creatorID, nil)
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, task)
 
 	// Проверяем, что кеш задач инвалидирован для создателя
-	exists, _ := cacheService.Exists(ctx, cacheService.UserTasksCacheKey(creatorID.String()))
+	exists, _ := cacheService.Exists(ctx, cacheService.UserTasksCacheKey(creatorID.String(), creatorID.String()))
 	assert.False(t, exists, "User tasks cache should be invalidated after create")
 }
 
@@ -70,19 +70,20 @@ func TestTaskController_UpdateTaskStatus_Integration(t *testing.T) {
 
 	taskID := 1
 	statusID := 2
+	viewerID := uuid.New().String()
 
 	// Создаем кеш перед обновлением
 	ctx := context.Background()
-	cacheService.SetTaskCache(ctx, taskID, map[string]interface{}{"id": taskID})
+	cacheService.SetTaskCache(ctx, taskID, viewerID, map[string]interface{}{"id": taskID})
 
 	// Act
-	err := taskController.UpdateTaskStatus(taskID, statusID)
+	err := taskController.UpdateTaskStatus(taskID, statusID, uuid.New(), []string{"process_tasks"})
 
 	// Assert
 	require.NoError(t, err)
 
 	// Проверяем, что кеш задачи инвалидирован
-	exists, _ := cacheService.Exists(ctx, cacheService.TaskCacheKey(taskID))
+	exists, _ := cacheService.Exists(ctx, cacheService.TaskCacheKey(taskID, viewerID))
 	assert.False(t, exists, "Task cache should be invalidated after status update")
 }
 
@@ -101,16 +102,17 @@ func TestTaskController_GetTaskByID_Integration(t *testing.T) {
 	taskController := controllers.NewTaskController(taskClient, fileClient, cacheService)
 
 	taskID := 1
+	viewerID := uuid.New()
 
 	// Act - первый запрос (должен идти в сервис)
-	task1, err := taskController.GetTaskByID(taskID)
+	task1, err := taskController.GetTaskByID(taskID, viewerID, nil)
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, task1)
 
 	// Act - второй запрос (должен быть из кеша)
-	task2, err := taskController.GetTaskByID(taskID)
+	task2, err := taskController.GetTaskByID(taskID, viewerID, nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -119,7 +121,7 @@ func TestTaskController_GetTaskByID_Integration(t *testing.T) {
 	// Проверяем, что данные реально в кеше
 	ctx := context.Background()
 	var cachedTask interface{}
-	err = cacheService.GetTaskCache(ctx, taskID, &cachedTask)
+	err = cacheService.GetTaskCache(ctx, taskID, viewerID.String(), &cachedTask)
 	require.NoError(t, err)
 }
 
@@ -140,14 +142,14 @@ func TestTaskController_GetUserTasks_Integration(t *testing.T) {
 	userID := uuid.New().String()
 
 	// Act - первый запрос (offset=0, limit=20, должен кешироваться)
-	tasks1, err := taskController.GetUserTasks(userID, 20, 0)
+	tasks1, err := taskController.GetUserTasks(userID, nil, 20, 0, uuid.MustParse(userID), nil)
 
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, tasks1)
 
 	// Act - второй запрос (должен быть из кеша)
-	tasks2, err := taskController.GetUserTasks(userID, 20, 0)
+	tasks2, err := taskController.GetUserTasks(userID, nil, 20, 0, uuid.MustParse(userID), nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -156,7 +158,7 @@ func TestTaskController_GetUserTasks_Integration(t *testing.T) {
 	// Проверяем, что данные реально в кеше
 	ctx := context.Background()
 	var cachedTasks []interface{}
-	err = cacheService.GetUserTasksCache(ctx, userID, &cachedTasks)
+	err = cacheService.GetUserTasksCache(ctx, userID, userID, &cachedTasks)
 	require.NoError(t, err)
 }
 
@@ -288,7 +290,7 @@ func TestTaskController_DeleteStatus_Integration(t *testing.T) {
 	cacheService.Set(ctx, "task:status:1", map[string]interface{}{"id": 1}, time.Hour)
 
 	// Act
-	err := taskController.DeleteStatus(statusID)
+	err := taskController.DeleteStatus(statusID, nil, uuid.New(), nil)
 
 	// Assert
 	require.NoError(t, err)
@@ -299,3 +301,62 @@ func TestTaskController_DeleteStatus_Integration(t *testing.T) {
 	assert.False(t, exists1, "Statuses cache should be invalidated after delete")
 	assert.False(t, exists2, "Status cache should be invalidated after delete")
 }
+
+// TestTaskController_UpdateTask_Integration тестирует редактирование задачи с инвалидацией кеша
+func TestTaskController_UpdateTask_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Arrange
+	redisClient := setupTestRedis(t)
+	_, _, taskServer, _, _, _, taskClient, fileClient, _ := setupTestHTTPClients(t)
+	defer taskServer.Close()
+
+	cacheService := services.NewCacheService(redisClient)
+	taskController := controllers.NewTaskController(taskClient, fileClient, cacheService)
+
+	ctx := context.Background()
+	taskID := 1
+	viewerID := uuid.New().String()
+	cacheService.SetTaskCache(ctx, taskID, viewerID, map[string]interface{}{"id": taskID})
+	title := "test_task_updated"
+
+	// Act
+	task, err := taskController.UpdateTask(taskID, &dto.UpdateTaskRequestGateway{Title: &title}, uuid.New(), []string{"process_tasks"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, title, task.Title)
+
+	exists, _ := cacheService.Exists(ctx, cacheService.TaskCacheKey(taskID, viewerID))
+	assert.False(t, exists, "Task cache should be invalidated after update")
+}
+
+// TestTaskController_DeleteTask_Integration тестирует удаление задачи с инвалидацией кеша
+func TestTaskController_DeleteTask_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Arrange
+	redisClient := setupTestRedis(t)
+	_, _, taskServer, _, _, _, taskClient, fileClient, _ := setupTestHTTPClients(t)
+	defer taskServer.Close()
+
+	cacheService := services.NewCacheService(redisClient)
+	taskController := controllers.NewTaskController(taskClient, fileClient, cacheService)
+
+	ctx := context.Background()
+	taskID := 1
+	viewerID := uuid.New().String()
+	cacheService.SetTaskCache(ctx, taskID, viewerID, map[string]interface{}{"id": taskID})
+
+	// Act
+	err := taskController.DeleteTask(taskID, uuid.New(), nil)
+
+	// Assert
+	require.NoError(t, err)
+	exists, _ := cacheService.Exists(ctx, cacheService.TaskCacheKey(taskID, viewerID))
+	assert.False(t, exists, "Task cache should be invalidated after delete")
+}
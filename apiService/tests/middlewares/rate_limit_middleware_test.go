@@ -397,3 +397,93 @@ func TestRateLimitByEndpoint(t *testing.T) {
 	require.NoError(t, err)
 	assert.Greater(t, len(keys), 0)
 }
+
+// Тесты для лимитов публичной ленты календаря
+
+func TestRateLimitByClientIP_ExceedsLimit(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	redisClient := setupTestRedisForRateLimit(t)
+	defer redisClient.Close()
+
+	config := middlewares.RateLimitConfig{
+		Limit:     2,
+		Window:    time.Minute,
+		KeyPrefix: "ratelimit:calendar:",
+	}
+
+	router := gin.New()
+	router.GET("/calendar/:token", middlewares.RateLimitByClientIP(redisClient, config), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// Act: перебор разных токенов с одного адреса упирается в лимит
+	codes := make([]int, 0, 3)
+	for _, token := range []string{"a", "b", "c"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/calendar/"+token, nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		router.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+	}
+
+	// Запрос с другого адреса не ограничивается
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/calendar/a", nil)
+	req.RemoteAddr = "10.0.0.2:1234"
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRateLimitByPathParam_ExceedsLimit(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	redisClient := setupTestRedisForRateLimit(t)
+	defer redisClient.Close()
+
+	config := middlewares.RateLimitConfig{
+		Limit:     2,
+		Window:    time.Minute,
+		KeyPrefix: "ratelimit:calendar:",
+	}
+
+	router := gin.New()
+	router.GET("/calendar/:token", middlewares.RateLimitByPathParam(redisClient, config, "token"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// Act: один и тот же токен с разных адресов упирается в лимит
+	codes := make([]int, 0, 3)
+	for _, addr := range []string{"10.0.0.1:1234", "10.0.0.2:1234", "10.0.0.3:1234"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/calendar/secret", nil)
+		req.RemoteAddr = addr
+		router.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/calendar/other", nil))
+
+	// Assert
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	assert.Equal(t, http.StatusOK, w.Code)
+	// Токен не попадает в ключи Redis
+	keys, err := redisClient.Keys(context.Background(), "ratelimit:calendar:*").Result()
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+	for _, key := range keys {
+		assert.NotContains(t, key, "secret")
+	}
+}
+
+func TestCalendarFeedRateLimitConfig(t *testing.T) {
+	config := middlewares.CalendarFeedRateLimitConfig()
+
+	assert.Equal(t, 30, config.Limit)
+	assert.Equal(t, time.Minute, config.Window)
+	assert.Equal(t, "ratelimit:calendar:", config.KeyPrefix)
+}
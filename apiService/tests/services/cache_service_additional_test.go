@@ -3,6 +3,7 @@ package services
 import (
 	"apiService/internal/services"
 	"context"
+	"net/url"
 	"testing"
 	"time"
 
@@ -332,13 +333,15 @@ func TestCacheService_TaskCacheKey(t *testing.T) {
 	cacheService := services.NewCacheService(redisClient)
 
 	taskID := 123
+	viewerID := uuid.New().String()
 
 	// Act
-	key := cacheService.TaskCacheKey(taskID)
+	key := cacheService.TaskCacheKey(taskID, viewerID)
 
 	// Assert
 	assert.Contains(t, key, "task:")
 	assert.Contains(t, key, "123")
+	assert.Contains(t, key, viewerID)
 }
 
 func TestCacheService_SetTaskCache_Success(t *testing.T) {
@@ -350,16 +353,17 @@ func TestCacheService_SetTaskCache_Success(t *testing.T) {
 	ctx := context.Background()
 
 	taskID := 123
+	viewerID := uuid.New().String()
 	task := map[string]string{"id": "123", "title": "Test Task"}
 
 	// Act
-	err := cacheService.SetTaskCache(ctx, taskID, task)
+	err := cacheService.SetTaskCache(ctx, taskID, viewerID, task)
 
 	// Assert
 	require.NoError(t, err)
 
 	// Проверяем, что ключ создан
-	key := cacheService.TaskCacheKey(taskID)
+	key := cacheService.TaskCacheKey(taskID, viewerID)
 	exists, _ := redisClient.Exists(ctx, key).Result()
 	assert.Equal(t, int64(1), exists)
 }
@@ -373,14 +377,15 @@ func TestCacheService_GetTaskCache_Success(t *testing.T) {
 	ctx := context.Background()
 
 	taskID := 123
+	viewerID := uuid.New().String()
 	task := map[string]string{"id": "123", "title": "Test Task"}
 
 	// Сохраняем данные
-	cacheService.SetTaskCache(ctx, taskID, task)
+	cacheService.SetTaskCache(ctx, taskID, viewerID, task)
 
 	// Act
 	var result map[string]string
-	err := cacheService.GetTaskCache(ctx, taskID, &result)
+	err := cacheService.GetTaskCache(ctx, taskID, viewerID, &result)
 
 	// Assert
 	require.NoError(t, err)
@@ -397,10 +402,15 @@ func TestCacheService_DeleteTaskCache_Success(t *testing.T) {
 	ctx := context.Background()
 
 	taskID := 123
+	viewerID := uuid.New().String()
 	task := map[string]string{"id": "123", "title": "Test Task"}
 
-	// Сохраняем данные
-	cacheService.SetTaskCache(ctx, taskID, task)
+	otherViewerID := uuid.New().String()
+
+	// Сохраняем данные для двух пользователей и соседней задачи
+	cacheService.SetTaskCache(ctx, taskID, viewerID, task)
+	cacheService.SetTaskCache(ctx, taskID, otherViewerID, task)
+	cacheService.SetTaskCache(ctx, 1234, viewerID, task)
 
 	// Act
 	err := cacheService.DeleteTaskCache(ctx, taskID)
@@ -408,11 +418,14 @@ func TestCacheService_DeleteTaskCache_Success(t *testing.T) {
 	// Assert
 	require.NoError(t, err)
 
-	// Проверяем, что ключ удален
+	// Проверяем, что задача удалена из кеша всех пользователей
 	var result map[string]string
-	err = cacheService.GetTaskCache(ctx, taskID, &result)
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "cache miss")
+	for _, viewer := range []string{viewerID, otherViewerID} {
+		err = cacheService.GetTaskCache(ctx, taskID, viewer, &result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cache miss")
+	}
+	require.NoError(t, cacheService.GetTaskCache(ctx, 1234, viewerID, &result))
 }
 
 // Тесты для UserTasksCache методов
@@ -425,9 +438,10 @@ func TestCacheService_UserTasksCacheKey(t *testing.T) {
 	cacheService := services.NewCacheService(redisClient)
 
 	userID := uuid.New().String()
+	viewerID := uuid.New().String()
 
 	// Act
-	key := cacheService.UserTasksCacheKey(userID)
+	key := cacheService.UserTasksCacheKey(userID, viewerID)
 
 	// Assert
 	assert.Contains(t, key, "user_tasks:")
@@ -443,16 +457,17 @@ func TestCacheService_SetUserTasksCache_Success(t *testing.T) {
 	ctx := context.Background()
 
 	userID := uuid.New().String()
+	viewerID := uuid.New().String()
 	tasks := []map[string]string{{"id": "1", "title": "Task 1"}}
 
 	// Act
-	err := cacheService.SetUserTasksCache(ctx, userID, tasks)
+	err := cacheService.SetUserTasksCache(ctx, userID, viewerID, tasks)
 
 	// Assert
 	require.NoError(t, err)
 
 	// Проверяем, что ключ создан
-	key := cacheService.UserTasksCacheKey(userID)
+	key := cacheService.UserTasksCacheKey(userID, viewerID)
 	exists, _ := redisClient.Exists(ctx, key).Result()
 	assert.Equal(t, int64(1), exists)
 }
@@ -466,14 +481,15 @@ func TestCacheService_GetUserTasksCache_Success(t *testing.T) {
 	ctx := context.Background()
 
 	userID := uuid.New().String()
+	viewerID := uuid.New().String()
 	tasks := []map[string]string{{"id": "1", "title": "Task 1"}}
 
 	// Сохраняем данные
-	cacheService.SetUserTasksCache(ctx, userID, tasks)
+	cacheService.SetUserTasksCache(ctx, userID, viewerID, tasks)
 
 	// Act
 	var result []map[string]string
-	err := cacheService.GetUserTasksCache(ctx, userID, &result)
+	err := cacheService.GetUserTasksCache(ctx, userID, viewerID, &result)
 
 	// Assert
 	require.NoError(t, err)
@@ -490,10 +506,12 @@ func TestCacheService_DeleteUserTasksCache_Success(t *testing.T) {
 	ctx := context.Background()
 
 	userID := uuid.New().String()
+	viewerID := uuid.New().String()
 	tasks := []map[string]string{{"id": "1", "title": "Task 1"}}
 
 	// Сохраняем данные
-	cacheService.SetUserTasksCache(ctx, userID, tasks)
+	cacheService.SetUserTasksCache(ctx, userID, viewerID, tasks)
+	cacheService.Set(ctx, cacheService.UserCreatedTasksCacheKey(userID, viewerID), tasks, time.Minute)
 
 	// Act
 	err := cacheService.DeleteUserTasksCache(ctx, userID)
@@ -503,9 +521,27 @@ func TestCacheService_DeleteUserTasksCache_Success(t *testing.T) {
 
 	// Проверяем, что ключ удален
 	var result []map[string]string
-	err = cacheService.GetUserTasksCache(ctx, userID, &result)
+	err = cacheService.GetUserTasksCache(ctx, userID, viewerID, &result)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "cache miss")
+	exists, _ := cacheService.Exists(ctx, cacheService.UserCreatedTasksCacheKey(userID, viewerID))
+	assert.False(t, exists)
+}
+
+func TestCacheService_TaskQueryCacheKey_PerViewer(t *testing.T) {
+	// Arrange
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+
+	cacheService := services.NewCacheService(redisClient)
+	query := url.Values{"q": {"отчёт"}}
+
+	// Act
+	first := cacheService.TaskQueryCacheKey(query, uuid.New().String())
+	second := cacheService.TaskQueryCacheKey(query, uuid.New().String())
+
+	// Assert
+	assert.NotEqual(t, first, second)
 }
 
 // Тесты для ChatRolesCache методов
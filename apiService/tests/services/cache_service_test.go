@@ -527,3 +527,31 @@ func TestCacheService_UserChatListCacheKey(t *testing.T) {
 	assert.Contains(t, key, "chat_list:")
 	assert.Contains(t, key, userID)
 }
+
+func TestCacheService_ReleaseMessageUpload(t *testing.T) {
+	// Arrange
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+
+	cacheService := services.NewCacheService(redisClient)
+	ctx := context.Background()
+	chatID, senderID := uuid.New().String(), uuid.New().String()
+
+	reserved, err := cacheService.ReserveMessageUpload(ctx, chatID, senderID, "pending-msg")
+	require.NoError(t, err)
+	require.True(t, reserved)
+	require.NoError(t, cacheService.SetMessageUploadCache(ctx, chatID, senderID, "done-msg", []int{1}))
+
+	// Act
+	require.NoError(t, cacheService.ReleaseMessageUpload(ctx, chatID, senderID, "pending-msg"))
+	require.NoError(t, cacheService.ReleaseMessageUpload(ctx, chatID, senderID, "done-msg"))
+
+	// Assert - резерв снят, а готовый список вложений остался
+	reserved, err = cacheService.ReserveMessageUpload(ctx, chatID, senderID, "pending-msg")
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	var fileIDs []int
+	require.NoError(t, cacheService.GetMessageUploadCache(ctx, chatID, senderID, "done-msg", &fileIDs))
+	assert.Equal(t, []int{1}, fileIDs)
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"apiService/internal/custom_errors"
+	"apiService/internal/services"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarFeedService_IssueToken_ResolvesOwner(t *testing.T) {
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	service := services.NewCalendarFeedService(redisClient)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	token, err := service.IssueToken(ctx, userID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+
+	owner, err := service.GetUserID(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, userID, owner)
+
+	// В ключах хранится только хеш токена
+	keys, err := redisClient.Keys(ctx, "calendar_feed:*").Result()
+	require.NoError(t, err)
+	for _, key := range keys {
+		assert.False(t, strings.Contains(key, token))
+	}
+}
+
+func TestCalendarFeedService_IssueToken_RotatesPreviousToken(t *testing.T) {
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	service := services.NewCalendarFeedService(redisClient)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	oldToken, err := service.IssueToken(ctx, userID)
+	require.NoError(t, err)
+	newToken, err := service.IssueToken(ctx, userID)
+	require.NoError(t, err)
+	assert.NotEqual(t, oldToken, newToken)
+
+	_, err = service.GetUserID(ctx, oldToken)
+	assert.ErrorIs(t, err, custom_errors.ErrCalendarFeedTokenNotFound)
+
+	owner, err := service.GetUserID(ctx, newToken)
+	require.NoError(t, err)
+	assert.Equal(t, userID, owner)
+}
+
+func TestCalendarFeedService_IssueToken_ConcurrentLeavesSingleToken(t *testing.T) {
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	service := services.NewCalendarFeedService(redisClient)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	const issuers = 5
+	tokens := make([]string, issuers)
+	var wg sync.WaitGroup
+	for i := 0; i < issuers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			token, err := service.IssueToken(ctx, userID)
+			assert.NoError(t, err)
+			tokens[i] = token
+		}(i)
+	}
+	wg.Wait()
+
+	// Действует ровно один токен, и он привязан к пользователю, поэтому его можно отозвать
+	tokenKeys, err := redisClient.Keys(ctx, "calendar_feed:token:*").Result()
+	require.NoError(t, err)
+	assert.Len(t, tokenKeys, 1)
+
+	valid := 0
+	for _, token := range tokens {
+		if _, err := service.GetUserID(ctx, token); err == nil {
+			valid++
+		}
+	}
+	assert.Equal(t, 1, valid)
+
+	require.NoError(t, service.RevokeToken(ctx, userID))
+	tokenKeys, err = redisClient.Keys(ctx, "calendar_feed:token:*").Result()
+	require.NoError(t, err)
+	assert.Empty(t, tokenKeys)
+}
+
+func TestCalendarFeedService_RevokeToken(t *testing.T) {
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	service := services.NewCalendarFeedService(redisClient)
+	ctx := context.Background()
+	userID := uuid.New()
+	otherUserID := uuid.New()
+
+	token, err := service.IssueToken(ctx, userID)
+	require.NoError(t, err)
+	otherToken, err := service.IssueToken(ctx, otherUserID)
+	require.NoError(t, err)
+
+	require.NoError(t, service.RevokeToken(ctx, userID))
+
+	_, err = service.GetUserID(ctx, token)
+	assert.ErrorIs(t, err, custom_errors.ErrCalendarFeedTokenNotFound)
+
+	// Токены других пользователей не затрагиваются
+	owner, err := service.GetUserID(ctx, otherToken)
+	require.NoError(t, err)
+	assert.Equal(t, otherUserID, owner)
+}
+
+func TestCalendarFeedService_RevokeToken_NoToken(t *testing.T) {
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	service := services.NewCalendarFeedService(redisClient)
+
+	assert.NoError(t, service.RevokeToken(context.Background(), uuid.New()))
+}
+
+func TestCalendarFeedService_GetUserID_UnknownToken(t *testing.T) {
+	redisClient := setupTestRedis(t)
+	defer redisClient.Close()
+	service := services.NewCalendarFeedService(redisClient)
+
+	_, err := service.GetUserID(context.Background(), "unknown")
+	assert.ErrorIs(t, err, custom_errors.ErrCalendarFeedTokenNotFound)
+}
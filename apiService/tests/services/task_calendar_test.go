@@ -0,0 +1,72 @@
+package services
+
+import (
+	"apiService/internal/services"
+	at "common/contracts/api-task"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func calendarTestTasks() []at.TaskToList {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 3, 5, 18, 30, 0, 0, time.FixedZone("MSK", 3*60*60))
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	return []at.TaskToList{
+		{ID: 1, Title: "Отчёт; квартал, итоги", Status: "В работе", Priority: "high", StartAt: &start, DueAt: &due, CreatedAt: updated, UpdatedAt: updated},
+		{ID: 2, Title: "Без срока", Status: "Новая", Priority: "low", CreatedAt: updated, UpdatedAt: updated},
+	}
+}
+
+func TestRenderTaskCalendar_Events(t *testing.T) {
+	feed := string(services.RenderTaskCalendar(calendarTestTasks(), services.CalendarEntryEvent))
+
+	assert.True(t, strings.HasPrefix(feed, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(feed, "END:VCALENDAR\r\n"))
+	assert.Equal(t, 1, strings.Count(feed, "BEGIN:VEVENT"))
+	assert.NotContains(t, feed, "VTODO")
+	assert.Contains(t, feed, "UID:task-1@taskservice\r\n")
+	assert.Contains(t, feed, "SUMMARY:Отчёт\\; квартал\\, итоги\r\n")
+	assert.Contains(t, feed, "DTSTART:20260302T090000Z\r\n")
+	// Срок переводится в UTC
+	assert.Contains(t, feed, "DTEND:20260305T153000Z\r\n")
+	assert.Contains(t, feed, "PRIORITY:3\r\n")
+	assert.NotContains(t, feed, "task-2@")
+}
+
+func TestRenderTaskCalendar_Todos(t *testing.T) {
+	feed := string(services.RenderTaskCalendar(calendarTestTasks(), services.CalendarEntryTodo))
+
+	assert.Equal(t, 1, strings.Count(feed, "BEGIN:VTODO"))
+	assert.NotContains(t, feed, "VEVENT")
+	assert.Contains(t, feed, "DTSTART:20260302T090000Z\r\n")
+	assert.Contains(t, feed, "DUE:20260305T153000Z\r\n")
+	assert.NotContains(t, feed, "DTEND")
+}
+
+func TestRenderTaskCalendar_StartAfterDueIgnored(t *testing.T) {
+	due := time.Date(2026, 3, 5, 18, 0, 0, 0, time.UTC)
+	start := due.Add(time.Hour)
+	tasks := []at.TaskToList{{ID: 3, Title: "Задача", StartAt: &start, DueAt: &due}}
+
+	feed := string(services.RenderTaskCalendar(tasks, services.CalendarEntryEvent))
+
+	assert.Contains(t, feed, "DTSTART:20260305T180000Z\r\n")
+	assert.NotContains(t, feed, "DTEND")
+}
+
+func TestRenderTaskCalendar_FoldsLongLines(t *testing.T) {
+	due := time.Date(2026, 3, 5, 18, 0, 0, 0, time.UTC)
+	tasks := []at.TaskToList{{ID: 4, Title: strings.Repeat("Очень длинное название задачи ", 10), DueAt: &due}}
+
+	feed := services.RenderTaskCalendar(tasks, services.CalendarEntryEvent)
+
+	for _, line := range strings.Split(strings.TrimSuffix(string(feed), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+		assert.True(t, strings.ToValidUTF8(line, "") == line, "line must not split UTF-8 characters")
+	}
+	unfolded := strings.ReplaceAll(string(feed), "\r\n ", "")
+	assert.Contains(t, unfolded, "SUMMARY:"+strings.Repeat("Очень длинное название задачи ", 10))
+}
@@ -10,6 +10,7 @@ import (
 	"common/config"
 	"common/db"
 	"common/kafka"
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"log"
@@ -37,6 +38,9 @@ import (
 // @tag.name messages
 // @tag.description Операции с сообщениями
 
+// @tag.name saved-messages
+// @tag.description Закладки сообщений пользователя
+
 func main() {
 	// Загружаем переменные окружения из .env файла (если существует)
 	if err := godotenv.Load(); err != nil {
@@ -79,16 +83,23 @@ func main() {
 	chatUserRepository := repositories.NewChatUserRepository(db)
 	chatRepository := repositories.NewChatRepository(db)
 	chatPermissionRepository := repositories.NewChatPermissionRepository(db)
+	savedMessageRepository := repositories.NewSavedMessageRepository(db)
+	taskEventSettingsRepository := repositories.NewChatTaskEventSettingsRepository(db)
+	unitOfWork := repositories.NewUnitOfWork(db)
 
 	// Init controllers
 	messageController := controllers.NewMessageController(messageRepository, chatRepository, chatUserRepository)
-	chatController := controllers.NewChatController(chatRepository, chatUserRepository, chatRoleRepository, notificationService)
+	chatController := controllers.NewChatController(chatRepository, chatUserRepository, chatRoleRepository, notificationService, unitOfWork)
 	rolePermissionController := controllers.NewRolePermissionController(chatRoleRepository, chatPermissionRepository)
+	savedMessageController := controllers.NewSavedMessageController(savedMessageRepository, messageRepository, chatUserRepository)
+	taskEventController := controllers.NewTaskEventController(taskEventSettingsRepository, messageController)
 
 	// Init handlers
 	messageHandler := handlers.NewMessageHandler(messageController)
 	chatHandler := handlers.NewChatHandler(chatController)
 	rolePermissionHandler := handlers.NewRolePermissionHandler(rolePermissionController)
+	savedMessageHandler := handlers.NewSavedMessageHandler(savedMessageController)
+	taskEventSettingsHandler := handlers.NewTaskEventSettingsHandler(taskEventController)
 
 	//Init services
 	permissionsService := services.NewChatPermissionService(chatUserRepository)
@@ -96,6 +107,24 @@ func main() {
 	//Init middlewares
 	permissionsMiddleware := middlewares.NewChatPermissionMiddleware(permissionsService)
 
+	// События задач публикуются в чаты только при доступной Kafka
+	consumerCtx, stopConsumer := context.WithCancel(context.Background())
+	defer stopConsumer()
+	taskEventConsumer, err := services.NewTaskEventConsumer(&services.TaskEventConsumerConfig{
+		Brokers: kafka.GetKafkaBrokers(),
+		Topic:   kafka.GetTaskEventsTopic(),
+		GroupID: "chat-service-task-events",
+	}, taskEventController)
+	if err != nil {
+		log.Printf("Warning: Failed to initialize task event consumer: %v", err)
+	} else {
+		go func() {
+			if err := taskEventConsumer.Start(consumerCtx); err != nil {
+				log.Printf("Task event consumer stopped: %v", err)
+			}
+		}()
+	}
+
 	r := gin.Default()
 
 	// Health check endpoint
@@ -107,14 +136,21 @@ func main() {
 
 	routes.RegisterChatRoutes(r, chatHandler, messageHandler, permissionsMiddleware)
 	routes.RegisterRolePermissionRoutes(r, rolePermissionHandler)
+	routes.RegisterSavedMessageRoutes(r, savedMessageHandler)
+	routes.RegisterTaskEventSettingsRoutes(r, taskEventSettingsHandler, permissionsMiddleware)
 
-	// Graceful shutdown для Kafka producer
+	// Graceful shutdown для Kafka producer и consumer
 	defer func() {
 		if notificationService != nil {
 			if err := notificationService.Close(); err != nil {
 				log.Printf("Error closing notification service: %v", err)
 			}
 		}
+		if taskEventConsumer != nil {
+			if err := taskEventConsumer.Close(); err != nil {
+				log.Printf("Error closing task event consumer: %v", err)
+			}
+		}
 	}()
 
 	_ = r.Run(":" + strconv.Itoa(cfg.App.Port))
@@ -7,14 +7,18 @@ import (
 	"chatService/internal/models"
 	"chatService/internal/repositories"
 	"chatService/internal/services"
+	fc "common/contracts/file-contracts"
+	cuc "common/contracts/user-contracts"
 	"github.com/google/uuid"
 	"log"
+	"strings"
 )
 
 type ChatController struct {
 	ChatRepo            repositories.ChatRepository
 	ChatUserRepo        repositories.ChatUserRepository
 	ChatRoleRepo        repositories.ChatRoleRepository
+	UnitOfWork          repositories.UnitOfWork
 	NotificationService services.NotificationServiceInterface
 	FileClient          http_clients.FileClientInterface
 	UserClient          http_clients.UserClientInterface
@@ -25,11 +29,13 @@ func NewChatController(
 	chatUserRepo repositories.ChatUserRepository,
 	chatRoleRepo repositories.ChatRoleRepository,
 	notificationService services.NotificationServiceInterface,
+	unitOfWork repositories.UnitOfWork,
 ) *ChatController {
 	return &ChatController{
 		ChatRepo:            chatRepo,
 		ChatUserRepo:        chatUserRepo,
 		ChatRoleRepo:        chatRoleRepo,
+		UnitOfWork:          unitOfWork,
 		NotificationService: notificationService,
 		FileClient:          http_clients.NewFileClientAdapter(),
 		UserClient:          http_clients.NewUserClientAdapter(),
@@ -44,11 +50,13 @@ func NewChatControllerWithClients(
 	notificationService services.NotificationServiceInterface,
 	fileClient http_clients.FileClientInterface,
 	userClient http_clients.UserClientInterface,
+	unitOfWork repositories.UnitOfWork,
 ) *ChatController {
 	return &ChatController{
 		ChatRepo:            chatRepo,
 		ChatUserRepo:        chatUserRepo,
 		ChatRoleRepo:        chatRoleRepo,
+		UnitOfWork:          unitOfWork,
 		NotificationService: notificationService,
 		FileClient:          fileClient,
 		UserClient:          userClient,
@@ -151,77 +159,60 @@ func (c *ChatController) CreateChat(dto *dto.CreateChatDTO) (*uuid.UUID, error)
 		newChat.IsGroup = true
 	}
 
-	userOwnerClientResponse, err := c.UserClient.GetUserByID(&dto.OwnerID)
+	// Владельца и всех приглашенных получаем одним запросом до начала транзакции
+	users, err := c.resolveUsers(append([]uuid.UUID{dto.OwnerID}, dto.UserIDs...))
 	if err != nil {
-		return nil, custom_errors.NewUserClientError(err.Error())
-	}
-	if userOwnerClientResponse.User == nil {
-		return nil, custom_errors.NewUserClientError("nil user")
-	}
-
-	err = c.ChatRepo.CreateChat(newChat)
-	if err != nil {
-		return nil, custom_errors.NewDatabaseError(err.Error())
-	}
-
-	chatUser := &models.ChatUser{
-		ChatID: newChat.ID,
-		UserID: userOwnerClientResponse.User.ID,
-		RoleID: ownerRole.ID,
-	}
-	err = c.ChatUserRepo.AddUserToChat(chatUser)
-	if err != nil {
-		return nil, custom_errors.NewDatabaseError(err.Error())
-	}
-
-	// Получаем имя создателя для уведомлений
-	creatorName := "Unknown user"
-	if userOwnerClientResponse.User.Username != "" {
-		creatorName = userOwnerClientResponse.User.Username
+		return nil, err
 	}
+	owner := users[dto.OwnerID]
 
-	// Собираем список всех пользователей для уведомлений
-	var allUsers []uuid.UUID
-	allUsers = append(allUsers, dto.UserIDs...) // Добавляем приглашенных пользователей
-
-	// Добавляем пользователей в чат и отправляем уведомления
+	invitedUsers := make([]*cuc.User, 0, len(dto.UserIDs))
+	added := map[uuid.UUID]bool{owner.ID: true}
 	for _, userID := range dto.UserIDs {
-		userClientResponse, err := c.UserClient.GetUserByID(&userID)
-		if err != nil {
-			return nil, custom_errors.NewUserClientError(err.Error())
+		if added[userID] {
+			continue
 		}
-		if userClientResponse.User == nil {
-			return nil, custom_errors.NewUserClientError("nil user")
+		added[userID] = true
+		invitedUsers = append(invitedUsers, users[userID])
+	}
+
+	err = c.UnitOfWork.Do(func(repos repositories.TxRepositories) error {
+		if err := repos.ChatRepo.CreateChat(newChat); err != nil {
+			return err
 		}
-		newChatUser := &models.ChatUser{
+
+		ownerChatUser := &models.ChatUser{
 			ChatID: newChat.ID,
-			UserID: userClientResponse.User.ID,
-			RoleID: mainRole.ID,
+			UserID: owner.ID,
+			RoleID: ownerRole.ID,
 		}
-		err = c.ChatUserRepo.AddUserToChat(newChatUser)
-		if err != nil {
-			return nil, custom_errors.NewDatabaseError(err.Error())
+		if err := repos.ChatUserRepo.AddUserToChat(ownerChatUser); err != nil {
+			return err
 		}
 
-		// Отправляем уведомление о новом чате
-		if c.NotificationService != nil && userClientResponse.User.Email != "" {
-			description := ""
-			if newChat.Description != nil {
-				description = *newChat.Description
+		for _, user := range invitedUsers {
+			newChatUser := &models.ChatUser{
+				ChatID: newChat.ID,
+				UserID: user.ID,
+				RoleID: mainRole.ID,
 			}
-
-			if err := c.NotificationService.SendChatCreatedNotification(
-				newChat.ID,
-				newChat.Name,
-				creatorName,
-				newChat.IsGroup,
-				description,
-				userClientResponse.User.Email,
-			); err != nil {
-				// Логируем ошибку, но не прерываем процесс создания чата
-				log.Printf("Failed to send chat notification to user %s: %v", userClientResponse.User.Email, err)
+			if err := repos.ChatUserRepo.AddUserToChat(newChatUser); err != nil {
+				return err
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, custom_errors.NewDatabaseError(err.Error())
+	}
+
+	// Уведомления отправляем только после фиксации транзакции
+	creatorName := "Unknown user"
+	if owner.Username != "" {
+		creatorName = owner.Username
+	}
+	for _, user := range invitedUsers {
+		c.notifyAddedUser(newChat, creatorName, user)
 	}
 
 	return &newChat.ID, nil
@@ -250,61 +241,62 @@ func (c *ChatController) UpdateChat(chatID uuid.UUID, updateChatDTO *dto.UpdateC
 		chat.AvatarFileID = &file.ID
 	}
 
-	if err := c.ChatRepo.UpdateChat(chat); err != nil {
-		return nil, custom_errors.NewDatabaseError(err.Error())
-	}
-
 	mainRole, err := c.ChatRoleRepo.GetRoleByName("main")
 	if err != nil {
 		return nil, custom_errors.ErrInvalidCredentials
 	}
 
-	var updateUsers []dto.UpdateUser
-	for _, userID := range updateChatDTO.AddUserIDs {
-		userResp, err := c.UserClient.GetUserByID(&userID)
+	// Добавляемых пользователей получаем одним запросом до начала транзакции
+	addedUsers := make([]*cuc.User, 0, len(updateChatDTO.AddUserIDs))
+	if len(updateChatDTO.AddUserIDs) > 0 {
+		users, err := c.resolveUsers(updateChatDTO.AddUserIDs)
 		if err != nil {
-			return nil, custom_errors.NewUserClientError(err.Error())
-		}
-		if userResp.User == nil {
-			return nil, custom_errors.NewUserClientError("nil user")
+			return nil, err
 		}
-		newChatUser := &models.ChatUser{
-			ChatID: chatID,
-			UserID: userResp.User.ID,
-			RoleID: mainRole.ID,
+		added := make(map[uuid.UUID]bool, len(updateChatDTO.AddUserIDs))
+		for _, userID := range updateChatDTO.AddUserIDs {
+			if added[userID] {
+				continue
+			}
+			added[userID] = true
+			addedUsers = append(addedUsers, users[userID])
 		}
-		if err := c.ChatUserRepo.AddUserToChat(newChatUser); err != nil {
-			return nil, custom_errors.NewDatabaseError(err.Error())
+	}
+
+	err = c.UnitOfWork.Do(func(repos repositories.TxRepositories) error {
+		if err := repos.ChatRepo.UpdateChat(chat); err != nil {
+			return err
 		}
 
-		// Отправляем уведомление о добавлении в существующий чат
-		if c.NotificationService != nil && userResp.User.Email != "" {
-			// Получаем информацию о том, кто добавил пользователя (нужно передать в параметрах метода)
-			description := ""
-			if chat.Description != nil {
-				description = *chat.Description
+		for _, user := range addedUsers {
+			newChatUser := &models.ChatUser{
+				ChatID: chatID,
+				UserID: user.ID,
+				RoleID: mainRole.ID,
 			}
-
-			if err := c.NotificationService.SendChatCreatedNotification(
-				chat.ID,
-				chat.Name,
-				"Администратор",
-				chat.IsGroup,
-				description,
-				userResp.User.Email,
-			); err != nil {
-				// Логируем ошибку, но не прерываем процесс
-				log.Printf("Failed to send chat notification to user %s: %v", userResp.User.Email, err)
+			if err := repos.ChatUserRepo.AddUserToChat(newChatUser); err != nil {
+				return err
 			}
 		}
 
-		updateUsers = append(updateUsers, dto.UpdateUser{UserID: newChatUser.UserID, State: "created"})
+		for _, userID := range updateChatDTO.RemoveUserIDs {
+			if err := repos.ChatUserRepo.RemoveUserFromChat(chatID, userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, custom_errors.NewDatabaseError(err.Error())
 	}
 
+	var updateUsers []dto.UpdateUser
+	for _, user := range addedUsers {
+		// Уведомляем о добавлении в существующий чат только после фиксации транзакции
+		c.notifyAddedUser(chat, "Администратор", user)
+		updateUsers = append(updateUsers, dto.UpdateUser{UserID: user.ID, State: "created"})
+	}
 	for _, userID := range updateChatDTO.RemoveUserIDs {
-		if err := c.ChatUserRepo.RemoveUserFromChat(chatID, userID); err != nil {
-			return nil, custom_errors.NewDatabaseError(err.Error())
-		}
 		updateUsers = append(updateUsers, dto.UpdateUser{UserID: userID, State: "deleted"})
 	}
 
@@ -318,6 +310,64 @@ func (c *ChatController) UpdateChat(chatID uuid.UUID, updateChatDTO *dto.UpdateC
 	}, UpdateUsers: updateUsers}, nil
 }
 
+// resolveUsers получает пользователей одним пакетным запросом к userService.
+// Возвращает ошибку, если хотя бы один из пользователей не найден
+func (c *ChatController) resolveUsers(userIDs []uuid.UUID) (map[uuid.UUID]*cuc.User, error) {
+	uniqueIDs := make([]uuid.UUID, 0, len(userIDs))
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if !seen[userID] {
+			seen[userID] = true
+			uniqueIDs = append(uniqueIDs, userID)
+		}
+	}
+
+	// userService принимает не больше usersBatchSize идентификаторов за запрос
+	users := make(map[uuid.UUID]*cuc.User, len(uniqueIDs))
+	for start := 0; start < len(uniqueIDs); start += usersBatchSize {
+		end := min(start+usersBatchSize, len(uniqueIDs))
+		usersResp, err := c.UserClient.GetUsersByIDs(uniqueIDs[start:end])
+		if err != nil {
+			return nil, custom_errors.NewUserClientError(err.Error())
+		}
+		for _, user := range usersResp.Users {
+			if user != nil {
+				users[user.ID] = user
+			}
+		}
+	}
+	for _, userID := range uniqueIDs {
+		if users[userID] == nil {
+			return nil, custom_errors.NewUserClientError("user not found: " + userID.String())
+		}
+	}
+	return users, nil
+}
+
+// notifyAddedUser отправляет пользователю уведомление о добавлении в чат.
+// Ошибка отправки логируется и не прерывает операцию
+func (c *ChatController) notifyAddedUser(chat *models.Chat, creatorName string, user *cuc.User) {
+	if c.NotificationService == nil || user.Email == "" {
+		return
+	}
+
+	description := ""
+	if chat.Description != nil {
+		description = *chat.Description
+	}
+
+	if err := c.NotificationService.SendChatCreatedNotification(
+		chat.ID,
+		chat.Name,
+		creatorName,
+		chat.IsGroup,
+		description,
+		user.Email,
+	); err != nil {
+		log.Printf("Failed to send chat notification to user %s: %v", user.Email, err)
+	}
+}
+
 func (c *ChatController) DeleteChat(chatID uuid.UUID) error {
 	if err := c.ChatUserRepo.DeleteChatUsersByChatID(chatID); err != nil {
 		return custom_errors.NewDatabaseError(err.Error())
@@ -353,6 +403,16 @@ func (c *ChatController) BanUser(chatID, userID uuid.UUID) error {
 	return c.ChatUserRepo.ChangeUserRole(chatID, userID, bannedRole.ID)
 }
 
+// GetUserChatIDs возвращает ID чатов, в которых пользователь состоит и не заблокирован.
+// Используется другими сервисами для проверки доступа к привязанным к чатам данным
+func (c *ChatController) GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	chatIDs, err := c.ChatUserRepo.GetUserChatIDs(userID)
+	if err != nil {
+		return nil, custom_errors.NewDatabaseError(err.Error())
+	}
+	return chatIDs, nil
+}
+
 // GetUserRoleInChat возвращает название роли пользователя в чате
 // requesterID - ID пользователя, который делает запрос (для проверки прав доступа)
 func (c *ChatController) GetUserRoleInChat(chatID, userID, requesterID uuid.UUID) (string, error) {
@@ -382,11 +442,140 @@ func (c *ChatController) GetMyRoleWithPermissions(chatID, userID uuid.UUID) (*mo
 	return &chatUser.Role, nil
 }
 
-// GetChatMembers возвращает список участников чата с их ролями
-func (c *ChatController) GetChatMembers(chatID uuid.UUID) ([]models.ChatUser, error) {
-	chatUsers, err := c.ChatUserRepo.GetChatUsers(chatID)
+const (
+	// usersBatchSize - максимальное количество ID в одном пакетном запросе к userService
+	usersBatchSize = 100
+	// filesBatchSize - максимальное количество ID в одном пакетном запросе к fileService
+	filesBatchSize = 100
+)
+
+// GetChatMembers возвращает страницу участников чата с ролями, именами и аватарами
+func (c *ChatController) GetChatMembers(chatID uuid.UUID, filter *dto.ChatMembersFilter) (*dto.ChatMembersResponse, error) {
+	search := strings.TrimSpace(filter.Search)
+
+	// Поиск идёт только по имени: email участников не раскрывается через подбор запроса.
+	// Имена хранит userService, поэтому он отбирает совпадения среди участников, а страница и
+	// общее количество считаются запросом к chat_user по найденным ID
+	var userIDs []uuid.UUID
+	var users map[uuid.UUID]*cuc.User
+	if search != "" {
+		memberIDs, err := c.ChatUserRepo.GetChatUserIDs(chatID, filter.RoleName)
+		if err != nil {
+			return nil, custom_errors.NewDatabaseError(err.Error())
+		}
+		users, err = c.searchUsers(memberIDs, search)
+		if err != nil {
+			return nil, err
+		}
+		if len(users) == 0 {
+			return &dto.ChatMembersResponse{Members: []dto.ChatMemberResponse{}, Total: 0}, nil
+		}
+		userIDs = make([]uuid.UUID, 0, len(users))
+		for id := range users {
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	chatUsers, total, err := c.ChatUserRepo.GetChatUsersFiltered(chatID, filter.RoleName, userIDs, filter.Offset, filter.Limit)
 	if err != nil {
 		return nil, custom_errors.NewDatabaseError(err.Error())
 	}
-	return chatUsers, nil
+	if users == nil {
+		users, err = c.fetchUsers(chatUsers)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &dto.ChatMembersResponse{Members: c.toChatMembers(chatUsers, users), Total: total}, nil
+}
+
+// searchUsers отбирает среди userIDs пользователей с подстрокой query в имени, пакетами по usersBatchSize
+func (c *ChatController) searchUsers(userIDs []uuid.UUID, query string) (map[uuid.UUID]*cuc.User, error) {
+	users := make(map[uuid.UUID]*cuc.User)
+	for start := 0; start < len(userIDs); start += usersBatchSize {
+		end := min(start+usersBatchSize, len(userIDs))
+		usersResp, err := c.UserClient.SearchUsersByIDs(userIDs[start:end], query)
+		if err != nil {
+			return nil, custom_errors.NewUserClientError(err.Error())
+		}
+		for _, user := range usersResp.Users {
+			if user != nil {
+				users[user.ID] = user
+			}
+		}
+	}
+	return users, nil
+}
+
+// fetchUsers загружает профили участников пакетами по usersBatchSize.
+// Пользователи, отсутствующие в userService, в результат не попадают
+func (c *ChatController) fetchUsers(chatUsers []models.ChatUser) (map[uuid.UUID]*cuc.User, error) {
+	users := make(map[uuid.UUID]*cuc.User, len(chatUsers))
+	for start := 0; start < len(chatUsers); start += usersBatchSize {
+		end := min(start+usersBatchSize, len(chatUsers))
+		ids := make([]uuid.UUID, 0, end-start)
+		for _, chatUser := range chatUsers[start:end] {
+			ids = append(ids, chatUser.UserID)
+		}
+
+		usersResp, err := c.UserClient.GetUsersByIDs(ids)
+		if err != nil {
+			return nil, custom_errors.NewUserClientError(err.Error())
+		}
+		for _, user := range usersResp.Users {
+			if user != nil {
+				users[user.ID] = user
+			}
+		}
+	}
+	return users, nil
+}
+
+func (c *ChatController) toChatMembers(chatUsers []models.ChatUser, users map[uuid.UUID]*cuc.User) []dto.ChatMemberResponse {
+	avatarIDs := make([]int, 0, len(chatUsers))
+	for _, chatUser := range chatUsers {
+		if user := users[chatUser.UserID]; user != nil && user.AvatarFileID != nil {
+			avatarIDs = append(avatarIDs, *user.AvatarFileID)
+		}
+	}
+	avatars := c.fetchFiles(avatarIDs)
+
+	members := make([]dto.ChatMemberResponse, 0, len(chatUsers))
+	for _, chatUser := range chatUsers {
+		member := dto.ChatMemberResponse{
+			UserID:   chatUser.UserID.String(),
+			RoleID:   chatUser.RoleID,
+			RoleName: chatUser.Role.Name,
+		}
+
+		if user := users[chatUser.UserID]; user != nil {
+			member.Username = user.Username
+			if user.AvatarFileID != nil {
+				member.AvatarFile = avatars[*user.AvatarFileID]
+			}
+		}
+
+		members = append(members, member)
+	}
+	return members
+}
+
+// fetchFiles загружает файлы пакетами по filesBatchSize. Если пакет не загрузился, его файлы
+// просто не попадают в результат - аватары не должны ломать список участников
+func (c *ChatController) fetchFiles(fileIDs []int) map[int]*fc.File {
+	files := make(map[int]*fc.File, len(fileIDs))
+	for start := 0; start < len(fileIDs); start += filesBatchSize {
+		end := min(start+filesBatchSize, len(fileIDs))
+		filesResp, err := c.FileClient.GetFilesByIDs(fileIDs[start:end])
+		if err != nil {
+			log.Printf("failed to load files %v: %v", fileIDs[start:end], err)
+			continue
+		}
+		for _, file := range filesResp.Files {
+			if file != nil {
+				files[file.ID] = file
+			}
+		}
+	}
+	return files
 }
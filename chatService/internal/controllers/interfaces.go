@@ -4,6 +4,7 @@ import (
 	"chatService/internal/handlers/dto"
 	"chatService/internal/models"
 	ac "common/contracts/api-chat"
+	commonModels "common/models"
 	"github.com/google/uuid"
 )
 
@@ -11,6 +12,7 @@ import (
 type ChatControllerInterface interface {
 	ChangeUserRole(chatID, userID uuid.UUID, roleID int) error
 	GetUserChats(userID uuid.UUID) (*[]dto.ChatResponse, error)
+	GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error)
 	CreateChat(dto *dto.CreateChatDTO) (*uuid.UUID, error)
 	UpdateChat(chatID uuid.UUID, updateChatDTO *dto.UpdateChatDTO) (*dto.UpdateChatResponse, error)
 	DeleteChat(chatID uuid.UUID) error
@@ -18,7 +20,7 @@ type ChatControllerInterface interface {
 	GetUserRoleInChat(chatID, userID, requesterID uuid.UUID) (string, error)
 	GetMyRoleWithPermissions(chatID, userID uuid.UUID) (*models.ChatRole, error)
 	GetChatByID(chatID uuid.UUID) (*dto.ChatResponse, error)
-	GetChatMembers(chatID uuid.UUID) ([]models.ChatUser, error)
+	GetChatMembers(chatID uuid.UUID, filter *dto.ChatMembersFilter) (*dto.ChatMembersResponse, error)
 }
 
 // MessageControllerInterface - интерфейс для MessageController (для мокирования в тестах)
@@ -26,4 +28,20 @@ type MessageControllerInterface interface {
 	SendMessage(senderID, chatID uuid.UUID, dto *dto.CreateMessageDTO) (*models.Message, error)
 	GetChatMessages(chatID uuid.UUID, offset, limit int) (*[]dto.GetChatMessage, error)
 	SearchMessages(userID, chatID uuid.UUID, query string, limit, offset int) (*ac.GetSearchResponse, error)
+	GetMessageByID(userID, messageID uuid.UUID) (*models.Message, error)
+	CreateSystemMessage(chatID uuid.UUID, dto *dto.CreateSystemMessageDTO) (*models.Message, error)
+}
+
+// SavedMessageControllerInterface - интерфейс для SavedMessageController (для мокирования в тестах)
+type SavedMessageControllerInterface interface {
+	SaveMessage(userID, messageID uuid.UUID, saveDTO *dto.SaveMessageDTO) (*dto.SavedMessageResponse, error)
+	GetSavedMessages(userID uuid.UUID, offset, limit int) (*dto.GetSavedMessagesResponse, error)
+	DeleteSavedMessage(userID, messageID uuid.UUID) error
+}
+
+// TaskEventControllerInterface - интерфейс для TaskEventController (для мокирования в тестах)
+type TaskEventControllerInterface interface {
+	HandleTaskEvent(event *commonModels.TaskDomainEvent) error
+	GetSettings(chatID uuid.UUID) (*dto.TaskEventSettingsResponse, error)
+	UpdateSettings(chatID uuid.UUID, settingsDTO *dto.UpdateTaskEventSettingsDTO) (*dto.TaskEventSettingsResponse, error)
 }
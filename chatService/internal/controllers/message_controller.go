@@ -2,13 +2,17 @@ package controllers
 
 import (
 	"chatService/internal/custom_errors"
+	"chatService/internal/formatting"
 	"chatService/internal/handlers/dto"
 	"chatService/internal/http_clients"
 	"chatService/internal/models"
 	"chatService/internal/repositories"
 	ac "common/contracts/api-chat"
 	fc "common/contracts/file-contracts"
+	"errors"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"slices"
 	"time"
 )
 
@@ -47,12 +51,30 @@ func NewMessageControllerWithClients(
 	}
 }
 
+// SendMessage создает сообщение в чате.
+// Если передан ClientID и сообщение с ним уже было отправлено этим пользователем в этот чат,
+// возвращается исходное сообщение без создания дубликата
 func (c *MessageController) SendMessage(senderID, chatID uuid.UUID, dto *dto.CreateMessageDTO) (*models.Message, error) {
 	_, err := c.ChatRepo.GetChatByID(chatID)
 	if err != nil {
 		return nil, custom_errors.ErrInvalidCredentials
 	}
 
+	if dto.ClientID != nil {
+		existing, err := c.MessageRepo.GetMessageByClientID(chatID, senderID, *dto.ClientID)
+		if err == nil {
+			return existing, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_errors.NewDatabaseError(err.Error())
+		}
+	}
+
+	contentHTML, err := formatting.RenderMarkdown(dto.Content)
+	if err != nil {
+		return nil, custom_errors.NewMessageFormatError(err.Error())
+	}
+
 	userResp, err := c.UserClient.GetUserByID(&senderID)
 	if err != nil {
 		return nil, custom_errors.NewUserClientError(err.Error())
@@ -72,25 +94,23 @@ func (c *MessageController) SendMessage(senderID, chatID uuid.UUID, dto *dto.Cre
 	}
 
 	msg := &models.Message{
-		ID:        uuid.New(),
-		ChatID:    chatID,
-		SenderID:  &senderID,
-		Content:   dto.Content,
-		CreatedAt: time.Now(),
-	}
-
-	if err := c.MessageRepo.CreateMessage(msg); err != nil {
-		return nil, custom_errors.NewDatabaseError(err.Error())
+		ID:          uuid.New(),
+		ChatID:      chatID,
+		SenderID:    &senderID,
+		Content:     dto.Content,
+		ContentHTML: &contentHTML,
+		ClientID:    dto.ClientID,
+		CreatedAt:   time.Now(),
 	}
 
-	for _, fileID := range dto.FileIDs {
-		messageFile := &models.MessageFile{
-			MessageID: msg.ID,
-			FileID:    fileID,
-		}
-		if err := c.MessageRepo.CreateMessageFile(messageFile); err != nil {
-			return nil, custom_errors.NewDatabaseError(err.Error())
+	if err := c.MessageRepo.CreateMessageWithFiles(msg, dto.FileIDs); err != nil {
+		// Параллельный повтор мог успеть вставить сообщение с тем же ClientID
+		if dto.ClientID != nil {
+			if existing, errExisting := c.MessageRepo.GetMessageByClientID(chatID, senderID, *dto.ClientID); errExisting == nil {
+				return existing, nil
+			}
 		}
+		return nil, custom_errors.NewDatabaseError(err.Error())
 	}
 
 	newMsg, errMsg := c.MessageRepo.GetMessageWithFile(msg.ID)
@@ -121,13 +141,15 @@ func (c *MessageController) GetChatMessages(chatID uuid.UUID, offset, limit int)
 			files = append(files, fileHTTP)
 		}
 		messagesResponse = append(messagesResponse, dto.GetChatMessage{
-			ID:        message.ID,
-			ChatID:    message.ChatID,
-			SenderID:  message.SenderID,
-			Content:   message.Content,
-			UpdatedAt: message.UpdatedAt,
-			CreatedAt: message.CreatedAt,
-			Files:     &files,
+			ID:          message.ID,
+			ChatID:      message.ChatID,
+			SenderID:    message.SenderID,
+			Content:     message.Content,
+			ContentHTML: message.ContentHTML,
+			TaskID:      message.TaskID,
+			UpdatedAt:   message.UpdatedAt,
+			CreatedAt:   message.CreatedAt,
+			Files:       &files,
 		})
 	}
 	return &messagesResponse, nil
@@ -159,15 +181,87 @@ func (c *MessageController) SearchMessages(userID, chatID uuid.UUID, query strin
 	var messageResponse []ac.GetChatMessage
 	for _, message := range messages {
 		messageResponse = append(messageResponse, ac.GetChatMessage{
-			ID:        message.ID,
-			ChatID:    message.ChatID,
-			SenderID:  message.SenderID,
-			Content:   message.Content,
-			UpdatedAt: message.UpdatedAt,
-			CreatedAt: message.CreatedAt,
-			Files:     nil,
+			ID:          message.ID,
+			ChatID:      message.ChatID,
+			SenderID:    message.SenderID,
+			Content:     message.Content,
+			ContentHTML: message.ContentHTML,
+			TaskID:      message.TaskID,
+			UpdatedAt:   message.UpdatedAt,
+			CreatedAt:   message.CreatedAt,
+			Files:       nil,
 		})
 	}
 
 	return &ac.GetSearchResponse{Messages: &messageResponse, Total: &total}, nil
 }
+
+// GetMessageByID возвращает сообщение с вложениями, если у пользователя есть право view_messages в его чате
+func (c *MessageController) GetMessageByID(userID, messageID uuid.UUID) (*models.Message, error) {
+	message, err := c.MessageRepo.GetMessageWithFile(messageID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_errors.ErrMessageNotFound
+		}
+		return nil, custom_errors.NewDatabaseError(err.Error())
+	}
+
+	chatUser, err := c.ChatUserRepo.GetChatUserWithRoleAndPermissions(userID, message.ChatID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_errors.ErrViewMessagesDenied
+		}
+		return nil, custom_errors.NewDatabaseError(err.Error())
+	}
+	canView := slices.ContainsFunc(chatUser.Role.Permissions, func(permission models.ChatPermission) bool {
+		return permission.Name == "view_messages"
+	})
+	if !canView {
+		return nil, custom_errors.ErrViewMessagesDenied
+	}
+
+	return message, nil
+}
+
+// CreateSystemMessage публикует в чате сообщение без отправителя, например ссылку на задачу, созданную из сообщения.
+// Если сообщение с переданным EventID уже есть, оно возвращается без создания дубликата
+func (c *MessageController) CreateSystemMessage(chatID uuid.UUID, dto *dto.CreateSystemMessageDTO) (*models.Message, error) {
+	if _, err := c.ChatRepo.GetChatByID(chatID); err != nil {
+		return nil, custom_errors.ErrChatNotFound
+	}
+
+	if dto.EventID != nil {
+		existing, err := c.MessageRepo.GetMessageByEventID(*dto.EventID)
+		if err == nil {
+			return existing, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_errors.NewDatabaseError(err.Error())
+		}
+	}
+
+	contentHTML, err := formatting.RenderMarkdown(dto.Content)
+	if err != nil {
+		return nil, custom_errors.NewMessageFormatError(err.Error())
+	}
+
+	msg := &models.Message{
+		ID:          uuid.New(),
+		ChatID:      chatID,
+		Content:     dto.Content,
+		ContentHTML: &contentHTML,
+		TaskID:      dto.TaskID,
+		EventID:     dto.EventID,
+		CreatedAt:   time.Now(),
+	}
+	if err := c.MessageRepo.CreateMessage(msg); err != nil {
+		// Параллельная доставка того же события могла успеть вставить сообщение
+		if dto.EventID != nil {
+			if existing, errExisting := c.MessageRepo.GetMessageByEventID(*dto.EventID); errExisting == nil {
+				return existing, nil
+			}
+		}
+		return nil, custom_errors.NewDatabaseError(err.Error())
+	}
+	return msg, nil
+}
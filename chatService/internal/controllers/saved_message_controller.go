@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"chatService/internal/custom_errors"
+	"chatService/internal/handlers/dto"
+	"chatService/internal/http_clients"
+	"chatService/internal/models"
+	"chatService/internal/repositories"
+	fc "common/contracts/file-contracts"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type SavedMessageController struct {
+	SavedMessageRepo repositories.SavedMessageRepository
+	MessageRepo      repositories.MessageRepository
+	ChatUserRepo     repositories.ChatUserRepository
+	FileClient       http_clients.FileClientInterface
+}
+
+func NewSavedMessageController(
+	savedMessageRepo repositories.SavedMessageRepository,
+	messageRepo repositories.MessageRepository,
+	chatUserRepo repositories.ChatUserRepository,
+) *SavedMessageController {
+	return &SavedMessageController{
+		SavedMessageRepo: savedMessageRepo,
+		MessageRepo:      messageRepo,
+		ChatUserRepo:     chatUserRepo,
+		FileClient:       http_clients.NewFileClientAdapter(),
+	}
+}
+
+// NewSavedMessageControllerWithClients создает контроллер с указанным HTTP клиентом (для тестирования)
+func NewSavedMessageControllerWithClients(
+	savedMessageRepo repositories.SavedMessageRepository,
+	messageRepo repositories.MessageRepository,
+	chatUserRepo repositories.ChatUserRepository,
+	fileClient http_clients.FileClientInterface,
+) *SavedMessageController {
+	return &SavedMessageController{
+		SavedMessageRepo: savedMessageRepo,
+		MessageRepo:      messageRepo,
+		ChatUserRepo:     chatUserRepo,
+		FileClient:       fileClient,
+	}
+}
+
+// SaveMessage добавляет сообщение в закладки пользователя (или обновляет заметку у существующей закладки)
+func (c *SavedMessageController) SaveMessage(userID, messageID uuid.UUID, saveDTO *dto.SaveMessageDTO) (*dto.SavedMessageResponse, error) {
+	message, err := c.MessageRepo.GetMessageWithFile(messageID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_errors.ErrMessageNotFound
+		}
+		return nil, custom_errors.NewDatabaseError(err.Error())
+	}
+
+	if !c.hasChatAccess(message.ChatID, userID) {
+		return nil, custom_errors.ErrUnauthorizedChat
+	}
+
+	savedMessage := &models.SavedMessage{
+		UserID:    userID,
+		MessageID: message.ID,
+		Note:      saveDTO.Note,
+		CreatedAt: time.Now(),
+	}
+	if err := c.SavedMessageRepo.SaveMessage(savedMessage); err != nil {
+		return nil, custom_errors.NewDatabaseError(err.Error())
+	}
+
+	savedMessage.Message = *message
+	return c.toSavedMessageResponse(savedMessage, true)
+}
+
+// GetSavedMessages возвращает закладки пользователя с пагинацией.
+// Содержимое сообщений из чатов, к которым пользователь потерял доступ, скрывается
+func (c *SavedMessageController) GetSavedMessages(userID uuid.UUID, offset, limit int) (*dto.GetSavedMessagesResponse, error) {
+	savedMessages, total, err := c.SavedMessageRepo.GetUserSavedMessages(userID, offset, limit)
+	if err != nil {
+		return nil, custom_errors.NewDatabaseError(err.Error())
+	}
+
+	// Доступ проверяем один раз на чат
+	chatAccess := make(map[uuid.UUID]bool)
+
+	result := make([]dto.SavedMessageResponse, 0, len(savedMessages))
+	for i := range savedMessages {
+		chatID := savedMessages[i].Message.ChatID
+		available, checked := chatAccess[chatID]
+		if !checked {
+			available = c.hasChatAccess(chatID, userID)
+			chatAccess[chatID] = available
+		}
+
+		response, err := c.toSavedMessageResponse(&savedMessages[i], available)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *response)
+	}
+
+	return &dto.GetSavedMessagesResponse{SavedMessages: result, Total: total}, nil
+}
+
+// DeleteSavedMessage удаляет сообщение из закладок пользователя
+func (c *SavedMessageController) DeleteSavedMessage(userID, messageID uuid.UUID) error {
+	deleted, err := c.SavedMessageRepo.DeleteSavedMessage(userID, messageID)
+	if err != nil {
+		return custom_errors.NewDatabaseError(err.Error())
+	}
+	if !deleted {
+		return custom_errors.ErrSavedMessageNotFound
+	}
+	return nil
+}
+
+// hasChatAccess проверяет, что пользователь состоит в чате и не заблокирован в нём
+func (c *SavedMessageController) hasChatAccess(chatID, userID uuid.UUID) bool {
+	role, err := c.ChatUserRepo.GetUserRole(chatID, userID)
+	if err != nil || role == nil {
+		return false
+	}
+	return role.Name != "banned"
+}
+
+func (c *SavedMessageController) toSavedMessageResponse(savedMessage *models.SavedMessage, available bool) (*dto.SavedMessageResponse, error) {
+	response := &dto.SavedMessageResponse{
+		MessageID: savedMessage.MessageID,
+		ChatID:    savedMessage.Message.ChatID,
+		Note:      savedMessage.Note,
+		SavedAt:   savedMessage.CreatedAt,
+		Available: available,
+	}
+	if !available {
+		return response, nil
+	}
+
+	files := make([]*fc.File, 0, len(savedMessage.Message.Files))
+	for _, file := range savedMessage.Message.Files {
+		fileHTTP, err := c.FileClient.GetFileByID(file.FileID)
+		if err != nil {
+			return nil, custom_errors.NewGetFileHTTPError(file.FileID, err.Error())
+		}
+		files = append(files, fileHTTP)
+	}
+
+	response.Message = &dto.GetChatMessage{
+		ID:          savedMessage.Message.ID,
+		ChatID:      savedMessage.Message.ChatID,
+		SenderID:    savedMessage.Message.SenderID,
+		Content:     savedMessage.Message.Content,
+		ContentHTML: savedMessage.Message.ContentHTML,
+		UpdatedAt:   savedMessage.Message.UpdatedAt,
+		CreatedAt:   savedMessage.Message.CreatedAt,
+		Files:       &files,
+	}
+	return response, nil
+}
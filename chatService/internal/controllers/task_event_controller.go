@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"chatService/internal/custom_errors"
+	"chatService/internal/formatting"
+	"chatService/internal/handlers/dto"
+	"chatService/internal/http_clients"
+	"chatService/internal/models"
+	"chatService/internal/repositories"
+	commonModels "common/models"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+// unknownUserName - имя для пользователя, которого не удалось получить из userService
+const unknownUserName = "Пользователь"
+
+// TaskEventController публикует события задач taskService системными сообщениями в привязанные чаты
+// и хранит настройки чатов, отключающие такие сообщения
+type TaskEventController struct {
+	SettingsRepo      repositories.ChatTaskEventSettingsRepository
+	MessageController MessageControllerInterface
+	UserClient        http_clients.UserClientInterface
+}
+
+func NewTaskEventController(settingsRepo repositories.ChatTaskEventSettingsRepository, messageController MessageControllerInterface) *TaskEventController {
+	return &TaskEventController{
+		SettingsRepo:      settingsRepo,
+		MessageController: messageController,
+		UserClient:        http_clients.NewUserClientAdapter(),
+	}
+}
+
+// NewTaskEventControllerWithClients создает контроллер с указанными HTTP клиентами (для тестирования)
+func NewTaskEventControllerWithClients(
+	settingsRepo repositories.ChatTaskEventSettingsRepository,
+	messageController MessageControllerInterface,
+	userClient http_clients.UserClientInterface,
+) *TaskEventController {
+	return &TaskEventController{
+		SettingsRepo:      settingsRepo,
+		MessageController: messageController,
+		UserClient:        userClient,
+	}
+}
+
+// HandleTaskEvent публикует событие в чат задачи, если чат не отключил события этого типа.
+// Повторная доставка события возвращает уже созданное сообщение, поэтому обработка идемпотентна.
+// Событие для удалённого чата пропускается без ошибки
+func (c *TaskEventController) HandleTaskEvent(event *commonModels.TaskDomainEvent) error {
+	settings, err := c.settings(event.ChatID)
+	if err != nil {
+		return err
+	}
+	if !settings.Enabled(event.Type) {
+		return nil
+	}
+
+	eventID := event.EventID
+	_, err = c.MessageController.CreateSystemMessage(event.ChatID, &dto.CreateSystemMessageDTO{
+		Content: c.eventContent(event),
+		TaskID:  &event.TaskID,
+		EventID: &eventID,
+	})
+	if errors.Is(err, custom_errors.ErrChatNotFound) {
+		log.Printf("Chat %s of task %d not found, skipping task event %s", event.ChatID, event.TaskID, event.EventID)
+		return nil
+	}
+	return err
+}
+
+// GetSettings возвращает настройки событий задач чата; без сохранённых настроек все события включены
+func (c *TaskEventController) GetSettings(chatID uuid.UUID) (*dto.TaskEventSettingsResponse, error) {
+	settings, err := c.settings(chatID)
+	if err != nil {
+		return nil, err
+	}
+	return taskEventSettingsResponse(settings), nil
+}
+
+// UpdateSettings заменяет настройки событий задач чата
+func (c *TaskEventController) UpdateSettings(chatID uuid.UUID, settingsDTO *dto.UpdateTaskEventSettingsDTO) (*dto.TaskEventSettingsResponse, error) {
+	settings := &models.ChatTaskEventSettings{
+		ChatID:            chatID,
+		TaskCreated:       *settingsDTO.TaskCreated,
+		TaskStatusChanged: *settingsDTO.TaskStatusChanged,
+		TaskReassigned:    *settingsDTO.TaskReassigned,
+		UpdatedAt:         time.Now(),
+	}
+	if err := c.SettingsRepo.Save(settings); err != nil {
+		return nil, custom_errors.NewDatabaseError(err.Error())
+	}
+	return taskEventSettingsResponse(settings), nil
+}
+
+func (c *TaskEventController) settings(chatID uuid.UUID) (*models.ChatTaskEventSettings, error) {
+	settings, err := c.SettingsRepo.GetByChatID(chatID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DefaultChatTaskEventSettings(chatID), nil
+	}
+	if err != nil {
+		return nil, custom_errors.NewDatabaseError(err.Error())
+	}
+	return settings, nil
+}
+
+// eventContent формирует текст системного сообщения о событии задачи.
+// Название задачи, статусы и имена пользователей приходят от пользователей, поэтому их разметка экранируется
+func (c *TaskEventController) eventContent(event *commonModels.TaskDomainEvent) string {
+	task := fmt.Sprintf("#%d «%s»", event.TaskID, formatting.EscapeMarkdown(event.TaskTitle))
+
+	var executorID uuid.UUID
+	if event.Type == commonModels.TaskDomainEventReassigned {
+		executorID, _ = uuid.Parse(event.NewValue)
+	}
+	names := c.userNames(event.ActorID, executorID)
+	actor := names[event.ActorID]
+
+	switch event.Type {
+	case commonModels.TaskDomainEventCreated:
+		return fmt.Sprintf("%s создал(а) задачу %s", actor, task)
+	case commonModels.TaskDomainEventStatusChanged:
+		return fmt.Sprintf("%s перевел(а) задачу %s из статуса «%s» в «%s»", actor, task,
+			formatting.EscapeMarkdown(event.OldValue), formatting.EscapeMarkdown(event.NewValue))
+	case commonModels.TaskDomainEventReassigned:
+		if executorID == uuid.Nil {
+			return fmt.Sprintf("%s снял(а) исполнителя задачи %s", actor, task)
+		}
+		return fmt.Sprintf("%s назначил(а) исполнителем задачи %s пользователя %s", actor, task, names[executorID])
+	default:
+		return fmt.Sprintf("Задача %s изменена", task)
+	}
+}
+
+// userNames возвращает упоминания пользователей по ID; если userService недоступен,
+// сообщение всё равно публикуется с обезличенным именем
+func (c *TaskEventController) userNames(userIDs ...uuid.UUID) map[uuid.UUID]string {
+	names := make(map[uuid.UUID]string, len(userIDs))
+	var ids []uuid.UUID
+	for _, id := range userIDs {
+		if id != uuid.Nil {
+			names[id] = unknownUserName
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return names
+	}
+
+	resp, err := c.UserClient.GetUsersByIDs(ids)
+	if err != nil {
+		log.Printf("Failed to get users for task event: %v", err)
+		return names
+	}
+	for _, user := range resp.Users {
+		if user != nil && user.Username != "" {
+			names[user.ID] = formatting.Mention(user.Username)
+		}
+	}
+	return names
+}
+
+func taskEventSettingsResponse(settings *models.ChatTaskEventSettings) *dto.TaskEventSettingsResponse {
+	return &dto.TaskEventSettingsResponse{
+		TaskCreated:       settings.TaskCreated,
+		TaskStatusChanged: settings.TaskStatusChanged,
+		TaskReassigned:    settings.TaskReassigned,
+	}
+}
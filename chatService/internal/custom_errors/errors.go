@@ -6,12 +6,15 @@ import (
 )
 
 var (
-	ErrInvalidCredentials  = errors.New("invalid credentials")
-	ErrInternalServerError = errors.New("internal server error")
-	ErrEmptyQuery          = errors.New("query parameter cannot be empty")
-	ErrChatNotFound        = errors.New("chat with provided ID not found")
-	ErrUnauthorizedChat    = errors.New("user is not a member of this chat")
-	ErrUserNotInChat       = errors.New("requested user is not a member of this chat")
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrInternalServerError  = errors.New("internal server error")
+	ErrEmptyQuery           = errors.New("query parameter cannot be empty")
+	ErrChatNotFound         = errors.New("chat with provided ID not found")
+	ErrUnauthorizedChat     = errors.New("user is not a member of this chat")
+	ErrUserNotInChat        = errors.New("requested user is not a member of this chat")
+	ErrMessageNotFound      = errors.New("message with provided ID not found")
+	ErrSavedMessageNotFound = errors.New("message is not saved by this user")
+	ErrViewMessagesDenied   = errors.New("user has no permission to view messages in this chat")
 )
 
 type GetFileHTTPError struct {
@@ -60,3 +63,14 @@ func (e *DatabaseError) Error() string {
 func NewDatabaseError(error string) *DatabaseError {
 	return &DatabaseError{error}
 }
+
+type MessageFormatError struct {
+	description string
+}
+
+func (e *MessageFormatError) Error() string {
+	return fmt.Sprintf("incorrect message formatting: %s", e.description)
+}
+func NewMessageFormatError(error string) *MessageFormatError {
+	return &MessageFormatError{error}
+}
@@ -0,0 +1,267 @@
+package formatting
+
+import (
+	"errors"
+	"html"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// markdownSpecialChars - символы, которые начинают разметку и экранируются обратной косой чертой
+const markdownSpecialChars = "\\`*_[]()@"
+
+var (
+	ErrUnclosedCodeBlock = errors.New("code block is not closed")
+	ErrUnsafeLink        = errors.New("links must use http, https or mailto scheme")
+)
+
+// RenderMarkdown преобразует поддерживаемое подмножество Markdown в безопасный HTML.
+//
+// Поддерживаются: блоки кода (```lang ... ```), инлайн-код (`code`), **жирный**, *курсив* и _курсив_,
+// ссылки [текст](url) со схемами http, https и mailto, упоминания @username.
+// Обратная косая черта перед символом разметки выводит его как текст.
+// Весь остальной текст экранируется, поэтому HTML-разметка из исходного текста в результат не попадает
+func RenderMarkdown(source string) (string, error) {
+	var out strings.Builder
+	var paragraph []string
+
+	flush := func() error {
+		if len(paragraph) == 0 {
+			return nil
+		}
+		rendered, err := renderInline(strings.Join(paragraph, "\n"))
+		if err != nil {
+			return err
+		}
+		out.WriteString(rendered)
+		paragraph = nil
+		return nil
+	}
+
+	lines := strings.Split(source, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if !strings.HasPrefix(line, "```") {
+			paragraph = append(paragraph, line)
+			continue
+		}
+
+		end := -1
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == "```" {
+				end = j
+				break
+			}
+		}
+		if end == -1 {
+			return "", ErrUnclosedCodeBlock
+		}
+
+		if err := flush(); err != nil {
+			return "", err
+		}
+
+		out.WriteString("<pre><code")
+		if lang := strings.TrimSpace(line[3:]); isCodeLanguage(lang) {
+			out.WriteString(` class="language-` + lang + `"`)
+		}
+		out.WriteString(">")
+		out.WriteString(html.EscapeString(strings.Join(lines[i+1:end], "\n")))
+		out.WriteString("</code></pre>")
+		i = end
+	}
+
+	if err := flush(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// EscapeMarkdown экранирует разметку, чтобы текст из внешнего источника (например, название задачи)
+// отображался как есть и не мог вставить ссылку, упоминание или блок кода
+func EscapeMarkdown(s string) string {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(markdownSpecialChars, s[i]) >= 0 {
+			out.WriteByte('\\')
+		}
+		out.WriteByte(s[i])
+	}
+	return out.String()
+}
+
+// Mention возвращает упоминание пользователя; имя с символами, недопустимыми в упоминании, выводится текстом
+func Mention(username string) string {
+	for i := 0; i < len(username); i++ {
+		if !isMentionChar(username[i]) {
+			return EscapeMarkdown("@" + username)
+		}
+	}
+	return "@" + username
+}
+
+// renderInline обрабатывает инлайн-разметку внутри абзаца
+func renderInline(s string) (string, error) {
+	var out strings.Builder
+	var text strings.Builder
+
+	emit := func(markup string) {
+		out.WriteString(escapeText(text.String()))
+		text.Reset()
+		out.WriteString(markup)
+	}
+
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && strings.IndexByte(markdownSpecialChars, s[i+1]) >= 0:
+			text.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case s[i] == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end > 0 {
+				emit("<code>" + html.EscapeString(s[i+1:i+1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(s[i:], "**"):
+			if end := strings.Index(s[i+2:], "**"); end > 0 {
+				inner, err := renderInline(s[i+2 : i+2+end])
+				if err != nil {
+					return "", err
+				}
+				emit("<strong>" + inner + "</strong>")
+				i += end + 4
+				continue
+			}
+
+		case s[i] == '*' || s[i] == '_':
+			if end, ok := findEmphasisEnd(s, i); ok {
+				inner, err := renderInline(s[i+1 : end])
+				if err != nil {
+					return "", err
+				}
+				emit("<em>" + inner + "</em>")
+				i = end + 1
+				continue
+			}
+
+		case s[i] == '[':
+			if label, href, n, ok := parseLink(s[i:]); ok {
+				if !isSafeURL(href) {
+					return "", ErrUnsafeLink
+				}
+				inner, err := renderInline(label)
+				if err != nil {
+					return "", err
+				}
+				emit(`<a href="` + html.EscapeString(href) + `" rel="noopener noreferrer nofollow" target="_blank">` + inner + "</a>")
+				i += n
+				continue
+			}
+
+		case s[i] == '@' && (i == 0 || !isMentionChar(s[i-1])):
+			n := 0
+			for i+1+n < len(s) && isMentionChar(s[i+1+n]) {
+				n++
+			}
+			for n > 0 && (s[i+n] == '.' || s[i+n] == '-') {
+				n--
+			}
+			if n > 0 {
+				username := html.EscapeString(s[i+1 : i+1+n])
+				emit(`<span class="mention" data-username="` + username + `">@` + username + "</span>")
+				i += n + 1
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		text.WriteString(s[i : i+size])
+		i += size
+	}
+
+	emit("")
+	return out.String(), nil
+}
+
+// findEmphasisEnd ищет закрывающий разделитель курсива. Разделитель не должен примыкать к пробелу
+// изнутри, а '_' внутри слова (snake_case) курсивом не считается
+func findEmphasisEnd(s string, start int) (int, bool) {
+	delim := s[start]
+	if start+1 >= len(s) || s[start+1] == ' ' || s[start+1] == '\n' {
+		return 0, false
+	}
+	if delim == '_' && start > 0 && isMentionChar(s[start-1]) {
+		return 0, false
+	}
+
+	for j := start + 2; j < len(s); j++ {
+		if s[j] == '\n' {
+			return 0, false
+		}
+		if s[j] != delim || s[j-1] == ' ' {
+			continue
+		}
+		if delim == '_' && j+1 < len(s) && isMentionChar(s[j+1]) {
+			continue
+		}
+		return j, true
+	}
+	return 0, false
+}
+
+// parseLink разбирает ссылку вида [текст](url) в начале s и возвращает длину разобранного фрагмента
+func parseLink(s string) (label, href string, n int, ok bool) {
+	closeLabel := strings.Index(s, "](")
+	if closeLabel <= 1 || strings.ContainsAny(s[1:closeLabel], "\n[") {
+		return "", "", 0, false
+	}
+	closeHref := strings.IndexByte(s[closeLabel+2:], ')')
+	if closeHref <= 0 {
+		return "", "", 0, false
+	}
+	href = s[closeLabel+2 : closeLabel+2+closeHref]
+	if strings.ContainsAny(href, " \n\t") {
+		return "", "", 0, false
+	}
+	return s[1:closeLabel], href, closeLabel + 3 + closeHref, true
+}
+
+func isSafeURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return u.Host != ""
+	case "mailto":
+		return u.Opaque != ""
+	default:
+		return false
+	}
+}
+
+func isCodeLanguage(lang string) bool {
+	if lang == "" || len(lang) > 20 {
+		return false
+	}
+	for i := 0; i < len(lang); i++ {
+		c := lang[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '+' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func isMentionChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-'
+}
+
+func escapeText(s string) string {
+	return strings.ReplaceAll(html.EscapeString(s), "\n", "<br>")
+}
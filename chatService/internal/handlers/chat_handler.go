@@ -4,10 +4,12 @@ import (
 	"chatService/internal/controllers"
 	"chatService/internal/custom_errors"
 	"chatService/internal/handlers/dto"
+	cc "common/contracts/chat-contracts"
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"net/http"
+	"strconv"
 )
 
 type ChatHandler struct {
@@ -77,6 +79,30 @@ func (h *ChatHandler) GetUserChats(c *gin.Context) {
 	c.JSON(http.StatusOK, chats)
 }
 
+// GetUserChatIDs Получение ID чатов, в которых состоит пользователь
+// @Summary Получить ID чатов пользователя
+// @Description Возвращает ID чатов, в которых пользователь состоит и не заблокирован. Используется другими сервисами для проверки доступа
+// @Tags chats
+// @Produce json
+// @Param user_id path string true "UUID пользователя"
+// @Success 200 {object} cc.UserChatMemberships "ID чатов пользователя"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID пользователя"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/user/{user_id}/memberships [get]
+func (h *ChatHandler) GetUserChatIDs(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	chatIDs, err := h.ChatController.GetUserChatIDs(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, cc.UserChatMemberships{ChatIDs: chatIDs})
+}
+
 // CreateChat Создание нового чата
 // @Summary Создать новый чат
 // @Description Создает новый чат с указанными параметрами и участниками
@@ -363,12 +389,17 @@ func (h *ChatHandler) GetChatByID(c *gin.Context) {
 
 // GetChatMembers Получение списка участников чата
 // @Summary Получить список участников чата
-// @Description Возвращает список всех участников чата с их ролями
+// @Description Возвращает страницу участников чата с ролями, именами и аватарами. Поддерживает фильтр по роли и поиск по подстроке имени пользователя
 // @Tags chats
 // @Produce json
 // @Param chat_id path string true "UUID чата"
-// @Success 200 {array} dto.ChatMemberResponse "Список участников"
-// @Failure 400 {object} map[string]interface{} "Некорректный UUID"
+// @Param offset query int false "Смещение" default(0)
+// @Param limit query int false "Количество участников на странице" default(50) maximum(100)
+// @Param role query string false "Название роли (owner, main, banned, ...)"
+// @Param search query string false "Поиск по подстроке имени пользователя"
+// @Success 200 {object} dto.ChatMembersResponse "Страница участников"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID или параметры пагинации"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису пользователей"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /chats/{chat_id}/members [get]
 func (h *ChatHandler) GetChatMembers(c *gin.Context) {
@@ -378,20 +409,35 @@ func (h *ChatHandler) GetChatMembers(c *gin.Context) {
 		return
 	}
 
-	chatUsers, err := h.ChatController.GetChatMembers(chatID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
 		return
 	}
 
-	// Конвертируем в DTO
-	members := make([]dto.ChatMemberResponse, len(chatUsers))
-	for i, cu := range chatUsers {
-		members[i] = dto.ChatMemberResponse{
-			UserID:   cu.UserID.String(),
-			RoleID:   cu.RoleID,
-			RoleName: cu.Role.Name,
+	filter := &dto.ChatMembersFilter{
+		RoleName: c.Query("role"),
+		Search:   c.Query("search"),
+		Offset:   offset,
+		Limit:    limit,
+	}
+
+	members, err := h.ChatController.GetChatMembers(chatID, filter)
+	if err != nil {
+		var userErr *custom_errors.UserClientError
+		switch {
+		case errors.As(err, &userErr):
+			c.JSON(http.StatusBadGateway, gin.H{"error": userErr.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
+		return
 	}
 
 	c.JSON(http.StatusOK, members)
@@ -1,6 +1,39 @@
 package dto
 
+import (
+	"strings"
+	"unicode"
+)
+
 type CreateMessageDTO struct {
-	Content string `json:"content" binding:"required"`
+	// Content - исходный текст сообщения в поддерживаемом подмножестве Markdown, не длиннее 4000 символов
+	Content string `json:"content" binding:"required,max=4000"`
 	FileIDs []int  `json:"fileIDs"`
+	// ClientID - сгенерированный клиентом идентификатор сообщения для безопасных повторов отправки
+	ClientID *string `json:"clientID" binding:"omitempty,max=64"`
+}
+
+// CreateSystemMessageDTO - системное сообщение от имени сервиса (без отправителя)
+type CreateSystemMessageDTO struct {
+	Content string `json:"content" binding:"required,max=4000"`
+	// TaskID - задача, на которую ссылается сообщение
+	TaskID *int `json:"task_id"`
+	// EventID - ключ идемпотентности: повторный запрос с тем же ключом возвращает уже созданное сообщение
+	EventID *string `json:"event_id" binding:"omitempty,max=64"`
+}
+
+// Normalize приводит переводы строк к \n, удаляет управляющие символы (кроме \n и \t),
+// символы управления направлением текста и обрезает пробелы по краям
+func (d *CreateMessageDTO) Normalize() {
+	content := strings.ReplaceAll(d.Content, "\r\n", "\n")
+	d.Content = strings.TrimSpace(strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\t':
+			return r
+		case unicode.IsControl(r), r >= '\u202a' && r <= '\u202e', r >= '\u2066' && r <= '\u2069':
+			return -1
+		default:
+			return r
+		}
+	}, content))
 }
@@ -9,12 +9,14 @@ import (
 )
 
 type GetChatMessage struct {
-	ID        uuid.UUID  `json:"id"`
-	ChatID    uuid.UUID  `json:"chatID"`
-	SenderID  *uuid.UUID `json:"senderID"`
-	Content   string     `json:"content"`
-	UpdatedAt *time.Time `json:"updatedAt"`
-	CreatedAt time.Time  `json:"createdAt"`
+	ID          uuid.UUID  `json:"id"`
+	ChatID      uuid.UUID  `json:"chatID"`
+	SenderID    *uuid.UUID `json:"senderID"`
+	Content     string     `json:"content"`
+	ContentHTML *string    `json:"contentHTML,omitempty"`
+	TaskID      *int       `json:"taskID,omitempty"`
+	UpdatedAt   *time.Time `json:"updatedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
 
 	// Files - реальные данные для сериализации (скрыто от Swagger)
 	Files *[]*fc.File `json:"-" swaggerignore:"true"`
@@ -28,21 +30,25 @@ type GetChatMessage struct {
 func (m GetChatMessage) MarshalJSON() ([]byte, error) {
 	// Создаем временную структуру для сериализации, используя только Files
 	aux := struct {
-		ID        uuid.UUID   `json:"id"`
-		ChatID    uuid.UUID   `json:"chatID"`
-		SenderID  *uuid.UUID  `json:"senderID"`
-		Content   string      `json:"content"`
-		UpdatedAt *time.Time  `json:"updatedAt"`
-		CreatedAt time.Time   `json:"createdAt"`
-		Files     *[]*fc.File `json:"files,omitempty"`
+		ID          uuid.UUID   `json:"id"`
+		ChatID      uuid.UUID   `json:"chatID"`
+		SenderID    *uuid.UUID  `json:"senderID"`
+		Content     string      `json:"content"`
+		ContentHTML *string     `json:"contentHTML,omitempty"`
+		TaskID      *int        `json:"taskID,omitempty"`
+		UpdatedAt   *time.Time  `json:"updatedAt"`
+		CreatedAt   time.Time   `json:"createdAt"`
+		Files       *[]*fc.File `json:"files,omitempty"`
 	}{
-		ID:        m.ID,
-		ChatID:    m.ChatID,
-		SenderID:  m.SenderID,
-		Content:   m.Content,
-		UpdatedAt: m.UpdatedAt,
-		CreatedAt: m.CreatedAt,
-		Files:     m.Files,
+		ID:          m.ID,
+		ChatID:      m.ChatID,
+		SenderID:    m.SenderID,
+		Content:     m.Content,
+		ContentHTML: m.ContentHTML,
+		TaskID:      m.TaskID,
+		UpdatedAt:   m.UpdatedAt,
+		CreatedAt:   m.CreatedAt,
+		Files:       m.Files,
 	}
 	return json.Marshal(aux)
 }
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type SaveMessageDTO struct {
+	Note *string `json:"note" binding:"omitempty,max=1000"`
+}
+
+// SavedMessageResponse - закладка пользователя.
+// Если пользователь потерял доступ к чату (удалён или заблокирован), Available = false и Message не заполняется
+type SavedMessageResponse struct {
+	MessageID uuid.UUID       `json:"messageID"`
+	ChatID    uuid.UUID       `json:"chatID"`
+	Note      *string         `json:"note"`
+	SavedAt   time.Time       `json:"savedAt"`
+	Available bool            `json:"available"`
+	Message   *GetChatMessage `json:"message,omitempty"`
+}
+
+type GetSavedMessagesResponse struct {
+	SavedMessages []SavedMessageResponse `json:"savedMessages"`
+	Total         int64                  `json:"total"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+// UpdateTaskEventSettingsDTO - какие события задач публиковать в чат системными сообщениями
+type UpdateTaskEventSettingsDTO struct {
+	TaskCreated       *bool `json:"taskCreated" binding:"required"`
+	TaskStatusChanged *bool `json:"taskStatusChanged" binding:"required"`
+	TaskReassigned    *bool `json:"taskReassigned" binding:"required"`
+}
+
+type TaskEventSettingsResponse struct {
+	TaskCreated       bool `json:"taskCreated"`
+	TaskStatusChanged bool `json:"taskStatusChanged"`
+	TaskReassigned    bool `json:"taskReassigned"`
+}
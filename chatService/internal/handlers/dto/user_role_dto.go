@@ -1,5 +1,7 @@
 package dto
 
+import fc "common/contracts/file-contracts"
+
 // UserRoleResponse - ответ с ролью пользователя в чате (только имя)
 type UserRoleResponse struct {
 	RoleName string `json:"roleName"`
@@ -18,9 +20,25 @@ type UserRoleWithPermissionsResponse struct {
 	Permissions []ChatPermissionResponse `json:"permissions"`
 }
 
-// ChatMemberResponse - участник чата
+// ChatMemberResponse - участник чата с данными профиля
 type ChatMemberResponse struct {
-	UserID   string `json:"userId"`
-	RoleID   int    `json:"roleId"`
-	RoleName string `json:"roleName"`
+	UserID     string   `json:"userId"`
+	RoleID     int      `json:"roleId"`
+	RoleName   string   `json:"roleName"`
+	Username   string   `json:"username,omitempty"`
+	AvatarFile *fc.File `json:"avatarFile,omitempty"`
+}
+
+// ChatMembersFilter - параметры выборки участников чата
+type ChatMembersFilter struct {
+	RoleName string
+	Search   string
+	Offset   int
+	Limit    int
+}
+
+// ChatMembersResponse - страница участников чата
+type ChatMembersResponse struct {
+	Members []ChatMemberResponse `json:"members"`
+	Total   int64                `json:"total"`
 }
@@ -4,6 +4,7 @@ import (
 	"chatService/internal/controllers"
 	"chatService/internal/custom_errors"
 	"chatService/internal/handlers/dto"
+	cc "common/contracts/chat-contracts"
 	"errors"
 	"net/http"
 	"strconv"
@@ -28,9 +29,10 @@ func NewMessageHandler(messageController controllers.MessageControllerInterface)
 // @Produce json
 // @Param chat_id path string true "UUID чата"
 // @Param X-User-ID header string true "UUID отправителя"
+// @Param Idempotency-Key header string false "Ключ идемпотентности (альтернатива clientID в теле)"
 // @Param message body dto.CreateMessageDTO true "Данные сообщения"
 // @Success 201 {object} models.Message "Сообщение успешно отправлено"
-// @Failure 400 {object} map[string]interface{} "Некорректный запрос или неверный UUID"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос, неверный UUID или ошибка разметки сообщения"
 // @Failure 401 {object} map[string]interface{} "Неверные учетные данные"
 // @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
@@ -55,16 +57,36 @@ func (h *MessageHandler) SendMessage(c *gin.Context) {
 		return
 	}
 
+	messageDTO.Normalize()
+	if messageDTO.Content == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "message content is empty"})
+		return
+	}
+
+	// Idempotency-Key используется, если клиент не передал clientID в теле запроса
+	if messageDTO.ClientID == nil {
+		if key := c.GetHeader("Idempotency-Key"); key != "" {
+			if len(key) > 64 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key is too long"})
+				return
+			}
+			messageDTO.ClientID = &key
+		}
+	}
+
 	msg, err := h.MessageController.SendMessage(userID, chatID, &messageDTO)
 	if err != nil {
 		var userErr *custom_errors.UserClientError
 		var fileNotFoundErr *custom_errors.FileNotFoundError
 		var getFileHTTPError *custom_errors.GetFileHTTPError
 		var dbErr *custom_errors.DatabaseError
+		var formatErr *custom_errors.MessageFormatError
 
 		switch {
 		case errors.Is(err, custom_errors.ErrInvalidCredentials):
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.As(err, &formatErr):
+			c.JSON(http.StatusBadRequest, gin.H{"error": formatErr.Error()})
 		case errors.As(err, &userErr):
 			c.JSON(http.StatusBadRequest, gin.H{"error": userErr.Error()})
 		case errors.As(err, &fileNotFoundErr):
@@ -199,3 +221,104 @@ func (h *MessageHandler) SearchMessages(c *gin.Context) {
 
 	c.JSON(http.StatusOK, messages)
 }
+
+// GetMessageByID Получение сообщения по ID
+// @Summary Получить сообщение по ID
+// @Description Возвращает сообщение с ID вложений. Доступно участникам чата с правом view_messages; используется другими сервисами, например при создании задачи из сообщения
+// @Tags messages
+// @Produce json
+// @Param message_id path string true "UUID сообщения"
+// @Param X-User-ID header string true "UUID пользователя"
+// @Success 200 {object} cc.Message "Сообщение"
+// @Failure 400 {object} map[string]interface{} "Неверный UUID"
+// @Failure 403 {object} map[string]interface{} "Нет права view_messages в чате сообщения"
+// @Failure 404 {object} map[string]interface{} "Сообщение не найдено"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/messages/by-id/{message_id} [get]
+func (h *MessageHandler) GetMessageByID(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetHeader("X-User-ID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	messageID, err := uuid.Parse(c.Param("message_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message ID"})
+		return
+	}
+
+	message, err := h.MessageController.GetMessageByID(userID, messageID)
+	if err != nil {
+		switch {
+		case errors.Is(err, custom_errors.ErrMessageNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, custom_errors.ErrViewMessagesDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": custom_errors.ErrInternalServerError.Error()})
+		}
+		return
+	}
+
+	fileIDs := make([]int, 0, len(message.Files))
+	for _, file := range message.Files {
+		fileIDs = append(fileIDs, file.FileID)
+	}
+
+	c.JSON(http.StatusOK, cc.Message{
+		ID:        message.ID,
+		ChatID:    message.ChatID,
+		SenderID:  message.SenderID,
+		Content:   message.Content,
+		FileIDs:   fileIDs,
+		CreatedAt: message.CreatedAt,
+	})
+}
+
+// CreateSystemMessage Публикация системного сообщения
+// @Summary Опубликовать системное сообщение
+// @Description Создает в чате сообщение без отправителя, которое может ссылаться на задачу. Повторный запрос с тем же event_id возвращает уже созданное сообщение. Предназначено для вызова другими сервисами и не проксируется через API-шлюз
+// @Tags messages
+// @Accept json
+// @Produce json
+// @Param chat_id path string true "UUID чата"
+// @Param message body dto.CreateSystemMessageDTO true "Текст сообщения, ID задачи и ключ идемпотентности"
+// @Success 201 {object} models.Message "Сообщение опубликовано"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос, неверный UUID или ошибка разметки сообщения"
+// @Failure 404 {object} map[string]interface{} "Чат не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/{chat_id}/system-messages [post]
+func (h *MessageHandler) CreateSystemMessage(c *gin.Context) {
+	chatID, err := uuid.Parse(c.Param("chat_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chat ID"})
+		return
+	}
+
+	var messageDTO dto.CreateSystemMessageDTO
+	if err := c.ShouldBindJSON(&messageDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	msg, err := h.MessageController.CreateSystemMessage(chatID, &messageDTO)
+	if err != nil {
+		var formatErr *custom_errors.MessageFormatError
+		var dbErr *custom_errors.DatabaseError
+
+		switch {
+		case errors.Is(err, custom_errors.ErrChatNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.As(err, &formatErr):
+			c.JSON(http.StatusBadRequest, gin.H{"error": formatErr.Error()})
+		case errors.As(err, &dbErr):
+			c.JSON(http.StatusInternalServerError, gin.H{"error": dbErr.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": custom_errors.ErrInternalServerError.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, msg)
+}
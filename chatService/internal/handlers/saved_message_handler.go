@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"chatService/internal/controllers"
+	"chatService/internal/custom_errors"
+	"chatService/internal/handlers/dto"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SavedMessageHandler struct {
+	SavedMessageController controllers.SavedMessageControllerInterface
+}
+
+func NewSavedMessageHandler(savedMessageController controllers.SavedMessageControllerInterface) *SavedMessageHandler {
+	return &SavedMessageHandler{savedMessageController}
+}
+
+// SaveMessage Добавление сообщения в закладки
+// @Summary Сохранить сообщение в закладки
+// @Description Добавляет сообщение из чата, в котором состоит пользователь, в его закладки. Повторный вызов обновляет заметку
+// @Tags saved-messages
+// @Accept json
+// @Produce json
+// @Param message_id path string true "UUID сообщения"
+// @Param X-User-ID header string true "UUID пользователя"
+// @Param request body dto.SaveMessageDTO false "Заметка к закладке"
+// @Success 201 {object} dto.SavedMessageResponse "Сообщение сохранено"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или неверный UUID"
+// @Failure 403 {object} map[string]interface{} "Нет доступа к чату"
+// @Failure 404 {object} map[string]interface{} "Сообщение не найдено"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/saved-messages/{message_id} [post]
+func (h *SavedMessageHandler) SaveMessage(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetHeader("X-User-ID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	messageID, err := uuid.Parse(c.Param("message_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message ID"})
+		return
+	}
+
+	var saveDTO dto.SaveMessageDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&saveDTO); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input: " + err.Error()})
+			return
+		}
+	}
+
+	savedMessage, err := h.SavedMessageController.SaveMessage(userID, messageID, &saveDTO)
+	if err != nil {
+		var getFileHTTPError *custom_errors.GetFileHTTPError
+		var dbErr *custom_errors.DatabaseError
+
+		switch {
+		case errors.Is(err, custom_errors.ErrMessageNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, custom_errors.ErrUnauthorizedChat):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.As(err, &getFileHTTPError):
+			c.JSON(http.StatusBadGateway, gin.H{"error": getFileHTTPError.Error()})
+		case errors.As(err, &dbErr):
+			c.JSON(http.StatusInternalServerError, gin.H{"error": dbErr.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": custom_errors.ErrInternalServerError.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, savedMessage)
+}
+
+// GetSavedMessages Получение закладок пользователя
+// @Summary Получить сохранённые сообщения
+// @Description Возвращает закладки пользователя с пагинацией. Содержимое сообщений из чатов, к которым пользователь потерял доступ, скрыто
+// @Tags saved-messages
+// @Produce json
+// @Param X-User-ID header string true "UUID пользователя"
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Param limit query int false "Количество закладок на странице" default(20)
+// @Success 200 {object} dto.GetSavedMessagesResponse "Список закладок"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID или параметры пагинации"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/saved-messages [get]
+func (h *SavedMessageHandler) GetSavedMessages(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetHeader("X-User-ID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	savedMessages, err := h.SavedMessageController.GetSavedMessages(userID, offset, limit)
+	if err != nil {
+		var getFileHTTPError *custom_errors.GetFileHTTPError
+		var dbErr *custom_errors.DatabaseError
+
+		switch {
+		case errors.As(err, &getFileHTTPError):
+			c.JSON(http.StatusBadGateway, gin.H{"error": getFileHTTPError.Error()})
+		case errors.As(err, &dbErr):
+			c.JSON(http.StatusInternalServerError, gin.H{"error": dbErr.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": custom_errors.ErrInternalServerError.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, savedMessages)
+}
+
+// DeleteSavedMessage Удаление сообщения из закладок
+// @Summary Удалить сообщение из закладок
+// @Description Удаляет сообщение из закладок пользователя
+// @Tags saved-messages
+// @Produce json
+// @Param message_id path string true "UUID сообщения"
+// @Param X-User-ID header string true "UUID пользователя"
+// @Success 204 "Закладка удалена"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID"
+// @Failure 404 {object} map[string]interface{} "Закладка не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/saved-messages/{message_id} [delete]
+func (h *SavedMessageHandler) DeleteSavedMessage(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetHeader("X-User-ID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	messageID, err := uuid.Parse(c.Param("message_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message ID"})
+		return
+	}
+
+	if err := h.SavedMessageController.DeleteSavedMessage(userID, messageID); err != nil {
+		if errors.Is(err, custom_errors.ErrSavedMessageNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"chatService/internal/controllers"
+	"chatService/internal/handlers/dto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TaskEventSettingsHandler struct {
+	TaskEventController controllers.TaskEventControllerInterface
+}
+
+func NewTaskEventSettingsHandler(taskEventController controllers.TaskEventControllerInterface) *TaskEventSettingsHandler {
+	return &TaskEventSettingsHandler{taskEventController}
+}
+
+// GetSettings Получение настроек событий задач в чате
+// @Summary Получить настройки событий задач
+// @Description Возвращает, какие события задач, привязанных к чату (создание, смена статуса, переназначение), публикуются в чат системными сообщениями. По умолчанию публикуются все
+// @Tags chats
+// @Produce json
+// @Param chat_id path string true "UUID чата"
+// @Param X-User-ID header string true "UUID пользователя"
+// @Success 200 {object} dto.TaskEventSettingsResponse "Настройки чата"
+// @Failure 400 {object} map[string]interface{} "Неверный UUID"
+// @Failure 403 {object} map[string]interface{} "Нет права view_messages в чате"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/{chat_id}/task-event-settings [get]
+func (h *TaskEventSettingsHandler) GetSettings(c *gin.Context) {
+	chatID, err := uuid.Parse(c.Param("chat_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chat ID"})
+		return
+	}
+
+	settings, err := h.TaskEventController.GetSettings(chatID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings Изменение настроек событий задач в чате
+// @Summary Изменить настройки событий задач
+// @Description Включает или отключает публикацию в чат системных сообщений о создании, смене статуса и переназначении привязанных к нему задач
+// @Tags chats
+// @Accept json
+// @Produce json
+// @Param chat_id path string true "UUID чата"
+// @Param X-User-ID header string true "UUID пользователя"
+// @Param settings body dto.UpdateTaskEventSettingsDTO true "Настройки событий задач"
+// @Success 200 {object} dto.TaskEventSettingsResponse "Настройки сохранены"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или неверный UUID"
+// @Failure 403 {object} map[string]interface{} "Нет права edit_chat в чате"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/{chat_id}/task-event-settings [put]
+func (h *TaskEventSettingsHandler) UpdateSettings(c *gin.Context) {
+	chatID, err := uuid.Parse(c.Param("chat_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chat ID"})
+		return
+	}
+
+	var settingsDTO dto.UpdateTaskEventSettingsDTO
+	if err := c.ShouldBindJSON(&settingsDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input: " + err.Error()})
+		return
+	}
+
+	settings, err := h.TaskEventController.UpdateSettings(chatID, &settingsDTO)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
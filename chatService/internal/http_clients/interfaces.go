@@ -10,11 +10,14 @@ import (
 // FileClientInterface - интерфейс для HTTP клиента файлового сервиса для возможности мокирования
 type FileClientInterface interface {
 	GetFileByID(fileID int) (*fc.File, error)
+	GetFilesByIDs(fileIDs []int) (*fc.FilesResponse, error)
 }
 
 // UserClientInterface - интерфейс для HTTP клиента пользовательского сервиса для возможности мокирования
 type UserClientInterface interface {
 	GetUserByID(userID *uuid.UUID) (*cuc.Response, error)
+	GetUsersByIDs(userIDs []uuid.UUID) (*cuc.UsersResponse, error)
+	SearchUsersByIDs(userIDs []uuid.UUID, query string) (*cuc.UsersResponse, error)
 }
 
 // FileClientAdapter - адаптер для обертки функций из common/http_clients
@@ -28,6 +31,10 @@ func (f *FileClientAdapter) GetFileByID(fileID int) (*fc.File, error) {
 	return commonHttpClients.GetFileByID(fileID)
 }
 
+func (f *FileClientAdapter) GetFilesByIDs(fileIDs []int) (*fc.FilesResponse, error) {
+	return commonHttpClients.GetFilesByIDs(fileIDs)
+}
+
 // UserClientAdapter - адаптер для обертки функций из common/http_clients
 type UserClientAdapter struct{}
 
@@ -38,3 +45,11 @@ func NewUserClientAdapter() UserClientInterface {
 func (u *UserClientAdapter) GetUserByID(userID *uuid.UUID) (*cuc.Response, error) {
 	return commonHttpClients.GetUserByID(userID)
 }
+
+func (u *UserClientAdapter) GetUsersByIDs(userIDs []uuid.UUID) (*cuc.UsersResponse, error) {
+	return commonHttpClients.GetUsersByIDs(userIDs)
+}
+
+func (u *UserClientAdapter) SearchUsersByIDs(userIDs []uuid.UUID, query string) (*cuc.UsersResponse, error) {
+	return commonHttpClients.SearchUsersByIDs(userIDs, query)
+}
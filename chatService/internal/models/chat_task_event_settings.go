@@ -0,0 +1,45 @@
+package models
+
+import (
+	commonModels "common/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ChatTaskEventSettings - какие события задач публикуются в чат системными сообщениями.
+// Чат без настроек получает все события
+type ChatTaskEventSettings struct {
+	ChatID            uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TaskCreated       bool      `gorm:"not null;default:true"`
+	TaskStatusChanged bool      `gorm:"not null;default:true"`
+	TaskReassigned    bool      `gorm:"not null;default:true"`
+	UpdatedAt         time.Time
+}
+
+func (ChatTaskEventSettings) TableName() string {
+	return "chat_service.chat_task_event_settings"
+}
+
+// DefaultChatTaskEventSettings - настройки чата, который не отключал события задач
+func DefaultChatTaskEventSettings(chatID uuid.UUID) *ChatTaskEventSettings {
+	return &ChatTaskEventSettings{
+		ChatID:            chatID,
+		TaskCreated:       true,
+		TaskStatusChanged: true,
+		TaskReassigned:    true,
+	}
+}
+
+// Enabled сообщает, публикуется ли в чат событие указанного типа
+func (s *ChatTaskEventSettings) Enabled(eventType commonModels.TaskDomainEventType) bool {
+	switch eventType {
+	case commonModels.TaskDomainEventCreated:
+		return s.TaskCreated
+	case commonModels.TaskDomainEventStatusChanged:
+		return s.TaskStatusChanged
+	case commonModels.TaskDomainEventReassigned:
+		return s.TaskReassigned
+	default:
+		return false
+	}
+}
@@ -6,10 +6,17 @@ import (
 )
 
 type Message struct {
-	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
-	ChatID    uuid.UUID  `gorm:"type:uuid;not null"`
-	SenderID  *uuid.UUID `gorm:"type:uuid"`
-	Content   string     `gorm:"type:text;not null"`
+	ID       uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	ChatID   uuid.UUID  `gorm:"type:uuid;not null"`
+	SenderID *uuid.UUID `gorm:"type:uuid"`
+	Content  string     `gorm:"type:text;not null"`
+	// ContentHTML - очищенный HTML, полученный из Markdown в Content
+	ContentHTML *string `gorm:"type:text"`
+	ClientID    *string `gorm:"type:varchar(64)"`
+	// TaskID - задача taskService, на которую ссылается системное сообщение (SenderID = nil)
+	TaskID *int
+	// EventID - ключ идемпотентности системного сообщения о событии другого сервиса
+	EventID   *string `gorm:"type:varchar(64)"`
 	UpdatedAt *time.Time
 	CreatedAt time.Time
 
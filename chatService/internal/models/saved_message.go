@@ -0,0 +1,19 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type SavedMessage struct {
+	UserID    uuid.UUID `gorm:"primaryKey;type:uuid"`
+	MessageID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Note      *string
+	CreatedAt time.Time
+
+	Message Message `gorm:"foreignKey:MessageID"`
+}
+
+func (SavedMessage) TableName() string {
+	return "chat_service.saved_messages"
+}
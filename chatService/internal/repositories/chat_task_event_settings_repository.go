@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"chatService/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ChatTaskEventSettingsRepository interface {
+	GetByChatID(chatID uuid.UUID) (*models.ChatTaskEventSettings, error)
+	Save(settings *models.ChatTaskEventSettings) error
+}
+
+type chatTaskEventSettingsRepository struct {
+	db *gorm.DB
+}
+
+func NewChatTaskEventSettingsRepository(db *gorm.DB) ChatTaskEventSettingsRepository {
+	return &chatTaskEventSettingsRepository{db}
+}
+
+func (r *chatTaskEventSettingsRepository) GetByChatID(chatID uuid.UUID) (*models.ChatTaskEventSettings, error) {
+	var settings models.ChatTaskEventSettings
+	if err := r.db.Where("chat_id = ?", chatID).First(&settings).Error; err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// Save создаёт настройки чата или заменяет существующие
+func (r *chatTaskEventSettingsRepository) Save(settings *models.ChatTaskEventSettings) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chat_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"task_created", "task_status_changed", "task_reassigned", "updated_at"}),
+	}).Create(settings).Error
+}
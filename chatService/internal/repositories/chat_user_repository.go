@@ -13,6 +13,9 @@ type ChatUserRepository interface {
 	GetChatUserWithRoleAndPermissions(userID, chatID uuid.UUID) (*models.ChatUser, error)
 	GetChatUser(userID, chatID uuid.UUID) (*models.ChatUser, error)
 	GetChatUsers(chatID uuid.UUID) ([]models.ChatUser, error)
+	GetChatUsersFiltered(chatID uuid.UUID, roleName string, userIDs []uuid.UUID, offset, limit int) ([]models.ChatUser, int64, error)
+	GetChatUserIDs(chatID uuid.UUID, roleName string) ([]uuid.UUID, error)
+	GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error)
 	RemoveUserFromChat(chatID, userID uuid.UUID) error
 	DeleteChatUsersByChatID(chatID uuid.UUID) error
 }
@@ -83,6 +86,59 @@ func (r *chatUserRepository) GetChatUsers(chatID uuid.UUID) ([]models.ChatUser,
 	return chatUsers, nil
 }
 
+// GetChatUsersFiltered возвращает участников чата с пагинацией и общее количество.
+// Пустой roleName отключает фильтр по роли, nil userIDs - фильтр по пользователям, limit <= 0 - пагинацию
+func (r *chatUserRepository) GetChatUsersFiltered(chatID uuid.UUID, roleName string, userIDs []uuid.UUID, offset, limit int) ([]models.ChatUser, int64, error) {
+	var chatUsers []models.ChatUser
+	var total int64
+
+	query := r.chatUsersQuery(chatID, roleName)
+	if userIDs != nil {
+		query = query.Where("chat_user.user_id IN ?", userIDs)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Preload("Role").Order("chat_user.role_id, chat_user.user_id")
+	if limit > 0 {
+		query = query.Offset(offset).Limit(limit)
+	}
+	err := query.Find(&chatUsers).Error
+	return chatUsers, total, err
+}
+
+// GetChatUserIDs возвращает ID участников чата; пустой roleName отключает фильтр по роли
+func (r *chatUserRepository) GetChatUserIDs(chatID uuid.UUID, roleName string) ([]uuid.UUID, error) {
+	userIDs := make([]uuid.UUID, 0)
+	err := r.chatUsersQuery(chatID, roleName).
+		Order("chat_user.user_id").
+		Pluck("chat_user.user_id", &userIDs).Error
+	return userIDs, err
+}
+
+func (r *chatUserRepository) chatUsersQuery(chatID uuid.UUID, roleName string) *gorm.DB {
+	query := r.db.Model(&models.ChatUser{}).Where("chat_user.chat_id = ?", chatID)
+	if roleName != "" {
+		query = query.
+			Joins("JOIN chat_service.chat_roles cr ON cr.id = chat_user.role_id").
+			Where("cr.name = ?", roleName)
+	}
+	return query
+}
+
+// GetUserChatIDs возвращает ID чатов пользователя; чаты, где у него роль banned, не учитываются
+func (r *chatUserRepository) GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	chatIDs := make([]uuid.UUID, 0)
+	err := r.db.Model(&models.ChatUser{}).
+		Joins("JOIN chat_service.chat_roles cr ON cr.id = chat_user.role_id").
+		Where("chat_user.user_id = ? AND cr.name <> ?", userID, "banned").
+		Order("chat_user.chat_id").
+		Pluck("chat_user.chat_id", &chatIDs).Error
+	return chatIDs, err
+}
+
 func (r *chatUserRepository) DeleteChatUsersByChatID(chatID uuid.UUID) error {
 	return r.db.Where("chat_id = ?", chatID).Delete(&models.ChatUser{}).Error
 }
@@ -9,7 +9,10 @@ import (
 type MessageRepository interface {
 	CreateMessage(message *models.Message) error
 	CreateMessageFile(msgFile *models.MessageFile) error
+	CreateMessageWithFiles(message *models.Message, fileIDs []int) error
 	GetMessageWithFile(msgID uuid.UUID) (*models.Message, error)
+	GetMessageByClientID(chatID, senderID uuid.UUID, clientID string) (*models.Message, error)
+	GetMessageByEventID(eventID string) (*models.Message, error)
 	GetChatMessages(chatID uuid.UUID, offset, limit int) ([]models.Message, error)
 	SearchMessages(userID, chatID uuid.UUID, text string, limit, offset int) ([]models.Message, int64, error)
 }
@@ -30,6 +33,22 @@ func (r *messageRepository) CreateMessageFile(msgFile *models.MessageFile) error
 	return r.db.Create(msgFile).Error
 }
 
+// CreateMessageWithFiles создает сообщение вместе с вложениями в одной транзакции,
+// чтобы сбой на вложениях не оставлял сообщение без файлов
+func (r *messageRepository) CreateMessageWithFiles(message *models.Message, fileIDs []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		for _, fileID := range fileIDs {
+			if err := tx.Create(&models.MessageFile{MessageID: message.ID, FileID: fileID}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (r *messageRepository) GetMessageWithFile(msgID uuid.UUID) (*models.Message, error) {
 	var message models.Message
 	err := r.db.Preload("Files").
@@ -41,6 +60,26 @@ func (r *messageRepository) GetMessageWithFile(msgID uuid.UUID) (*models.Message
 	return &message, nil
 }
 
+func (r *messageRepository) GetMessageByClientID(chatID, senderID uuid.UUID, clientID string) (*models.Message, error) {
+	var message models.Message
+	err := r.db.Preload("Files").
+		Where("chat_id = ? AND sender_id = ? AND client_id = ?", chatID, senderID, clientID).
+		First(&message).Error
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r *messageRepository) GetMessageByEventID(eventID string) (*models.Message, error) {
+	var message models.Message
+	err := r.db.Where("event_id = ?", eventID).First(&message).Error
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
 func (r *messageRepository) GetChatMessages(chatID uuid.UUID, offset, limit int) ([]models.Message, error) {
 	var messages []models.Message
 	err := r.db.Where("chat_id = ?", chatID).
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"chatService/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SavedMessageRepository interface {
+	SaveMessage(savedMessage *models.SavedMessage) error
+	GetSavedMessage(userID, messageID uuid.UUID) (*models.SavedMessage, error)
+	GetUserSavedMessages(userID uuid.UUID, offset, limit int) ([]models.SavedMessage, int64, error)
+	DeleteSavedMessage(userID, messageID uuid.UUID) (bool, error)
+}
+
+type savedMessageRepository struct {
+	db *gorm.DB
+}
+
+func NewSavedMessageRepository(db *gorm.DB) SavedMessageRepository {
+	return &savedMessageRepository{db}
+}
+
+// SaveMessage создаёт закладку или обновляет заметку, если сообщение уже сохранено
+func (r *savedMessageRepository) SaveMessage(savedMessage *models.SavedMessage) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "message_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"note"}),
+	}).Create(savedMessage).Error
+}
+
+func (r *savedMessageRepository) GetSavedMessage(userID, messageID uuid.UUID) (*models.SavedMessage, error) {
+	var savedMessage models.SavedMessage
+	err := r.db.Preload("Message.Files").
+		Where("user_id = ? AND message_id = ?", userID, messageID).
+		First(&savedMessage).Error
+	if err != nil {
+		return nil, err
+	}
+	return &savedMessage, nil
+}
+
+func (r *savedMessageRepository) GetUserSavedMessages(userID uuid.UUID, offset, limit int) ([]models.SavedMessage, int64, error) {
+	var savedMessages []models.SavedMessage
+	var total int64
+
+	query := r.db.Model(&models.SavedMessage{}).Where("user_id = ?", userID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Message.Files").
+		Order("created_at desc").
+		Offset(offset).Limit(limit).
+		Find(&savedMessages).Error
+
+	return savedMessages, total, err
+}
+
+// DeleteSavedMessage удаляет закладку и сообщает, существовала ли она
+func (r *savedMessageRepository) DeleteSavedMessage(userID, messageID uuid.UUID) (bool, error) {
+	result := r.db.Where("user_id = ? AND message_id = ?", userID, messageID).
+		Delete(&models.SavedMessage{})
+	return result.RowsAffected > 0, result.Error
+}
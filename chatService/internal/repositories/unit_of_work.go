@@ -0,0 +1,32 @@
+package repositories
+
+import "gorm.io/gorm"
+
+// TxRepositories - репозитории, привязанные к одной транзакции
+type TxRepositories struct {
+	ChatRepo     ChatRepository
+	ChatUserRepo ChatUserRepository
+}
+
+// UnitOfWork выполняет набор операций над репозиториями атомарно:
+// если fn возвращает ошибку, все изменения откатываются
+type UnitOfWork interface {
+	Do(fn func(repos TxRepositories) error) error
+}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &unitOfWork{db}
+}
+
+func (u *unitOfWork) Do(fn func(repos TxRepositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(TxRepositories{
+			ChatRepo:     NewChatRepository(tx),
+			ChatUserRepo: NewChatUserRepository(tx),
+		})
+	})
+}
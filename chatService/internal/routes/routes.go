@@ -14,11 +14,15 @@ func RegisterChatRoutes(router *gin.Engine, chatHandler *handlers.ChatHandler, m
 
 		// Получение списка чатов пользователя - используем /user/:user_id для избежания конфликта
 		chats.GET("/user/:user_id", chatHandler.GetUserChats)
+		// ID чатов пользователя для проверки доступа в других сервисах
+		chats.GET("/user/:user_id/memberships", chatHandler.GetUserChatIDs)
 
 		// Роуты с префиксом /messages и /search
 		chats.POST("/messages/:chat_id", permissionMiddleware.RequireChatPermission("send_message"), messageHandler.SendMessage)
 		chats.GET("/messages/:chat_id", permissionMiddleware.RequireChatPermission("view_messages"), messageHandler.GetChatMessages)
 		chats.GET("/search/:chat_id", permissionMiddleware.RequireChatPermission("view_messages"), messageHandler.SearchMessages)
+		// Сообщение по ID для других сервисов; право view_messages проверяется в контроллере по чату сообщения
+		chats.GET("/messages/by-id/:message_id", messageHandler.GetMessageByID)
 
 		// Роуты с /:chat_id
 		chatID := chats.Group("/:chat_id")
@@ -35,10 +39,22 @@ func RegisterChatRoutes(router *gin.Engine, chatHandler *handlers.ChatHandler, m
 			chatID.PATCH("/ban/:user_id", permissionMiddleware.RequireChatPermission("ban_user"), chatHandler.BanUser)
 			chatID.PUT("", permissionMiddleware.RequireChatPermission("edit_chat"), chatHandler.UpdateChat)
 			chatID.DELETE("", permissionMiddleware.RequireChatPermission("delete_chat"), chatHandler.DeleteChat)
+			// Системные сообщения публикуют другие сервисы (без отправителя и проверки прав)
+			chatID.POST("/system-messages", messageHandler.CreateSystemMessage)
 		}
 	}
 }
 
+func RegisterSavedMessageRoutes(router *gin.Engine, savedMessageHandler *handlers.SavedMessageHandler) {
+	// Закладки пользователя (доступ к чату проверяется в контроллере по сообщению)
+	saved := router.Group("api/v1/chats/saved-messages")
+	{
+		saved.GET("", savedMessageHandler.GetSavedMessages)
+		saved.POST("/:message_id", savedMessageHandler.SaveMessage)
+		saved.DELETE("/:message_id", savedMessageHandler.DeleteSavedMessage)
+	}
+}
+
 func RegisterRolePermissionRoutes(router *gin.Engine, rolePermissionHandler *handlers.RolePermissionHandler) {
 	// Роли чатов (глобальные)
 	roles := router.Group("api/v1/chat-roles")
@@ -58,3 +74,12 @@ func RegisterRolePermissionRoutes(router *gin.Engine, rolePermissionHandler *han
 		permissions.DELETE("/:permission_id", rolePermissionHandler.DeletePermission)
 	}
 }
+
+func RegisterTaskEventSettingsRoutes(router *gin.Engine, taskEventSettingsHandler *handlers.TaskEventSettingsHandler, permissionMiddleware *middlewares.ChatPermissionMiddleware) {
+	// Какие события задач публикуются в чат системными сообщениями
+	settings := router.Group("api/v1/chats/:chat_id/task-event-settings")
+	{
+		settings.GET("", permissionMiddleware.RequireChatPermission("view_messages"), taskEventSettingsHandler.GetSettings)
+		settings.PUT("", permissionMiddleware.RequireChatPermission("edit_chat"), taskEventSettingsHandler.UpdateSettings)
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"chatService/internal/custom_errors"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"common/models"
+	"github.com/IBM/sarama"
+)
+
+// TaskEventHandler - обработчик доменных событий задач (реализуется TaskEventController)
+type TaskEventHandler interface {
+	HandleTaskEvent(event *models.TaskDomainEvent) error
+}
+
+type TaskEventConsumerConfig struct {
+	Brokers []string
+	Topic   string
+	GroupID string
+}
+
+// TaskEventConsumer читает доменные события задач taskService. Смещение фиксируется только после
+// успешной обработки, поэтому при сбое событие доставляется повторно; обработчик идемпотентен по EventID
+type TaskEventConsumer struct {
+	consumer sarama.ConsumerGroup
+	Handler  TaskEventHandler // публичный для тестирования
+	config   *TaskEventConsumerConfig
+}
+
+func NewTaskEventConsumer(cfg *TaskEventConsumerConfig, handler TaskEventHandler) (*TaskEventConsumer, error) {
+	config := sarama.NewConfig()
+	config.Consumer.Group.Rebalance.Strategy = sarama.BalanceStrategyRoundRobin
+	config.Consumer.Offsets.Initial = sarama.OffsetNewest
+	config.Consumer.Return.Errors = true
+
+	consumer, err := sarama.NewConsumerGroup(cfg.Brokers, cfg.GroupID, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer group: %w", err)
+	}
+
+	return &TaskEventConsumer{
+		consumer: consumer,
+		Handler:  handler,
+		config:   cfg,
+	}, nil
+}
+
+func (tc *TaskEventConsumer) Start(ctx context.Context) error {
+	topics := []string{tc.config.Topic}
+
+	// Горутина для обработки ошибок
+	go func() {
+		for err := range tc.consumer.Errors() {
+			log.Printf("Task event consumer error: %v", err)
+		}
+	}()
+
+	// Основной цикл обработки сообщений
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Task event consumer context cancelled")
+			return ctx.Err()
+		default:
+			if err := tc.consumer.Consume(ctx, topics, tc); err != nil {
+				log.Printf("Error from task event consumer: %v", err)
+				time.Sleep(5 * time.Second) // Пауза перед переподключением
+			}
+		}
+	}
+}
+
+func (tc *TaskEventConsumer) Close() error {
+	return tc.consumer.Close()
+}
+
+// Реализация интерфейса sarama.ConsumerGroupHandler
+
+func (tc *TaskEventConsumer) Setup(sarama.ConsumerGroupSession) error {
+	log.Println("Task event consumer setup")
+	return nil
+}
+
+func (tc *TaskEventConsumer) Cleanup(sarama.ConsumerGroupSession) error {
+	log.Println("Task event consumer cleanup")
+	return nil
+}
+
+func (tc *TaskEventConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case message := <-claim.Messages():
+			if message == nil {
+				return nil
+			}
+
+			event, err := tc.ParseTaskEvent(message)
+			if err != nil {
+				// Повторная доставка не исправит некорректное сообщение
+				log.Printf("Skipping invalid task event: topic=%s partition=%d offset=%d: %v",
+					message.Topic, message.Partition, message.Offset, err)
+				session.MarkMessage(message, "")
+				continue
+			}
+
+			if err := tc.Handler.HandleTaskEvent(event); err != nil {
+				if !isRetryableTaskEventError(err) {
+					// Повторная доставка даст ту же ошибку и заблокирует следующие события партиции
+					log.Printf("Skipping task event %s that cannot be published: %v", event.EventID, err)
+					session.MarkMessage(message, "")
+					continue
+				}
+				// Смещение не фиксируется: после переподключения событие будет доставлено снова
+				return fmt.Errorf("failed to handle task event %s: %w", event.EventID, err)
+			}
+
+			session.MarkMessage(message, "")
+
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// isRetryableTaskEventError сообщает, может ли повторная доставка события завершиться успешно.
+// Ошибки формата сообщения от повтора не исчезнут, повторяются только сбои БД и внешних сервисов
+func isRetryableTaskEventError(err error) bool {
+	var formatErr *custom_errors.MessageFormatError
+	return !errors.As(err, &formatErr)
+}
+
+// ProcessMessage обрабатывает сообщение из Kafka (публичный для тестирования)
+func (tc *TaskEventConsumer) ProcessMessage(message *sarama.ConsumerMessage) error {
+	event, err := tc.ParseTaskEvent(message)
+	if err != nil {
+		return err
+	}
+	return tc.Handler.HandleTaskEvent(event)
+}
+
+// ParseTaskEvent разбирает событие задачи из сообщения Kafka (публичный для тестирования)
+func (tc *TaskEventConsumer) ParseTaskEvent(message *sarama.ConsumerMessage) (*models.TaskDomainEvent, error) {
+	var event models.TaskDomainEvent
+	if err := json.Unmarshal(message.Value, &event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task event: %w", err)
+	}
+	if event.EventID == "" || event.TaskID == 0 {
+		return nil, fmt.Errorf("task event has no event_id or task_id")
+	}
+	return &event, nil
+}
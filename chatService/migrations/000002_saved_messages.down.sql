@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS chat_service.saved_messages_user_id_created_at_idx;
+DROP TABLE IF EXISTS chat_service.saved_messages;
@@ -0,0 +1,15 @@
+-- ========================
+-- Saved (bookmarked) messages
+-- ========================
+
+CREATE TABLE IF NOT EXISTS chat_service.saved_messages (
+    user_id    uuid not null,
+    message_id uuid not null
+        references chat_service.messages(id) on delete cascade,
+    note       text,
+    created_at timestamp default now(),
+    primary key (user_id, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS saved_messages_user_id_created_at_idx
+    ON chat_service.saved_messages (user_id, created_at desc);
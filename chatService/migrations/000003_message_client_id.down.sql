@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS chat_service.messages_chat_id_sender_id_client_id_uindex;
+
+ALTER TABLE chat_service.messages DROP COLUMN IF EXISTS client_id;
@@ -0,0 +1,6 @@
+ALTER TABLE chat_service.messages ADD COLUMN client_id varchar(64);
+
+-- Повторная отправка с тем же client_id не создаёт дубликат сообщения
+CREATE UNIQUE INDEX messages_chat_id_sender_id_client_id_uindex
+    ON chat_service.messages (chat_id, sender_id, client_id)
+    WHERE client_id IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Отрендеренный и очищенный HTML сообщения; исходный Markdown остаётся в content
+ALTER TABLE chat_service.messages ADD COLUMN content_html text;
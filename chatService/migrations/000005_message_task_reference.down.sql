@@ -0,0 +1 @@
+ALTER TABLE chat_service.messages DROP COLUMN IF EXISTS task_id;
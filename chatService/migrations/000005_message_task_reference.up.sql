@@ -0,0 +1,2 @@
+-- Системное сообщение может ссылаться на задачу taskService (например, созданную из сообщения чата)
+ALTER TABLE chat_service.messages ADD COLUMN task_id integer;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS chat_service.chat_task_event_settings;
+
+DROP INDEX IF EXISTS chat_service.messages_event_id_uindex;
+
+ALTER TABLE chat_service.messages DROP COLUMN IF EXISTS event_id;
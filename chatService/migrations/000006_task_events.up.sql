@@ -0,0 +1,16 @@
+ALTER TABLE chat_service.messages ADD COLUMN event_id varchar(64);
+
+-- Событие задачи, доставленное повторно, не создаёт второе системное сообщение
+CREATE UNIQUE INDEX messages_event_id_uindex
+    ON chat_service.messages (event_id)
+    WHERE event_id IS NOT NULL;
+
+-- Какие события задач публикуются в чат; без записи публикуются все
+CREATE TABLE chat_service.chat_task_event_settings
+(
+    chat_id             uuid PRIMARY KEY REFERENCES chat_service.chats (id) ON DELETE CASCADE,
+    task_created        boolean   NOT NULL DEFAULT true,
+    task_status_changed boolean   NOT NULL DEFAULT true,
+    task_reassigned     boolean   NOT NULL DEFAULT true,
+    updated_at          timestamp NOT NULL DEFAULT now()
+);
@@ -2,12 +2,15 @@ package controllers
 
 import (
 	"errors"
+	"slices"
 	"testing"
 
 	"chatService/internal/controllers"
 	"chatService/internal/custom_errors"
 	"chatService/internal/handlers/dto"
 	"chatService/internal/models"
+	fc "common/contracts/file-contracts"
+	cuc "common/contracts/user-contracts"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -38,7 +41,7 @@ func TestChatController_CreateChat_WithoutUsers_Success(t *testing.T) {
 
 	mockChatRoleRepo.On("GetRoleByName", "owner").Return(ownerRole, nil)
 	mockChatRoleRepo.On("GetRoleByName", "main").Return(createTestChatRoleWithID(2, "main"), nil)
-	mockUserClient.On("GetUserByID", &ownerID).Return(ownerUser, nil)
+	mockUserClient.On("GetUsersByIDs", []uuid.UUID{ownerID}).Return(createTestUsersResponse(ownerUser), nil)
 	mockChatRepo.On("CreateChat", mock.MatchedBy(func(chat *models.Chat) bool {
 		return chat.IsGroup == false
 	})).Return(nil).Run(func(args mock.Arguments) {
@@ -56,6 +59,7 @@ func TestChatController_CreateChat_WithoutUsers_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -97,6 +101,7 @@ func TestChatController_CreateChat_OwnerRoleNotFound(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -108,7 +113,7 @@ func TestChatController_CreateChat_OwnerRoleNotFound(t *testing.T) {
 	assert.True(t, errors.Is(err, custom_errors.ErrInvalidCredentials))
 
 	mockChatRoleRepo.AssertExpectations(t)
-	mockUserClient.AssertNotCalled(t, "GetUserByID", mock.Anything)
+	mockUserClient.AssertNotCalled(t, "GetUsersByIDs", mock.Anything)
 }
 
 func TestChatController_CreateChat_WithAvatar_Success(t *testing.T) {
@@ -138,7 +143,7 @@ func TestChatController_CreateChat_WithAvatar_Success(t *testing.T) {
 	mockChatRoleRepo.On("GetRoleByName", "owner").Return(ownerRole, nil)
 	mockChatRoleRepo.On("GetRoleByName", "main").Return(mainRole, nil)
 	mockFileClient.On("GetFileByID", avatarFileID).Return(expectedFile, nil)
-	mockUserClient.On("GetUserByID", &ownerID).Return(ownerUser, nil)
+	mockUserClient.On("GetUsersByIDs", []uuid.UUID{ownerID}).Return(createTestUsersResponse(ownerUser), nil)
 	mockChatRepo.On("CreateChat", mock.MatchedBy(func(chat *models.Chat) bool {
 		return chat.AvatarFileID != nil && *chat.AvatarFileID == expectedFile.ID
 	})).Return(nil).Run(func(args mock.Arguments) {
@@ -154,6 +159,7 @@ func TestChatController_CreateChat_WithAvatar_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -202,6 +208,7 @@ func TestChatController_CreateChat_InvalidAvatarFile(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -252,6 +259,7 @@ func TestChatController_UpdateChat_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -287,6 +295,7 @@ func TestChatController_UpdateChat_ChatNotFound(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -325,7 +334,7 @@ func TestChatController_UpdateChat_AddUser_Success(t *testing.T) {
 	mockChatRepo.On("GetChatByID", chatID).Return(chat, nil)
 	mockChatRepo.On("UpdateChat", chat).Return(nil)
 	mockChatRoleRepo.On("GetRoleByName", "main").Return(mainRole, nil)
-	mockUserClient.On("GetUserByID", &userID).Return(user, nil)
+	mockUserClient.On("GetUsersByIDs", []uuid.UUID{userID}).Return(createTestUsersResponse(user), nil)
 	mockChatUserRepo.On("AddUserToChat", mock.MatchedBy(func(cu *models.ChatUser) bool {
 		return cu.ChatID == chatID && cu.UserID == userID && cu.RoleID == mainRole.ID
 	})).Return(nil)
@@ -340,6 +349,7 @@ func TestChatController_UpdateChat_AddUser_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -390,6 +400,7 @@ func TestChatController_UpdateChat_RemoveUser_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -439,6 +450,7 @@ func TestChatController_UpdateChat_UpdateDescriptionOnly(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -477,7 +489,7 @@ func TestChatController_UpdateChat_NotificationServiceError(t *testing.T) {
 	mockChatRepo.On("GetChatByID", chatID).Return(chat, nil)
 	mockChatRepo.On("UpdateChat", chat).Return(nil)
 	mockChatRoleRepo.On("GetRoleByName", "main").Return(mainRole, nil)
-	mockUserClient.On("GetUserByID", &userID).Return(user, nil)
+	mockUserClient.On("GetUsersByIDs", []uuid.UUID{userID}).Return(createTestUsersResponse(user), nil)
 	mockChatUserRepo.On("AddUserToChat", mock.Anything).Return(nil)
 	mockNotificationService.On("SendChatCreatedNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("notification error"))
 
@@ -488,6 +500,7 @@ func TestChatController_UpdateChat_NotificationServiceError(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -533,8 +546,7 @@ func TestChatController_UpdateChat_MultipleUsers(t *testing.T) {
 	mockChatRepo.On("GetChatByID", chatID).Return(chat, nil)
 	mockChatRepo.On("UpdateChat", chat).Return(nil)
 	mockChatRoleRepo.On("GetRoleByName", "main").Return(mainRole, nil)
-	mockUserClient.On("GetUserByID", &userID1).Return(user1, nil)
-	mockUserClient.On("GetUserByID", &userID2).Return(user2, nil)
+	mockUserClient.On("GetUsersByIDs", []uuid.UUID{userID1, userID2}).Return(createTestUsersResponse(user1, user2), nil)
 	mockChatUserRepo.On("AddUserToChat", mock.MatchedBy(func(cu *models.ChatUser) bool {
 		return cu.UserID == userID1 || cu.UserID == userID2
 	})).Return(nil).Times(2)
@@ -549,6 +561,7 @@ func TestChatController_UpdateChat_MultipleUsers(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -589,6 +602,7 @@ func TestChatController_DeleteChat_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -622,6 +636,7 @@ func TestChatController_DeleteChat_DeleteUsersError(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -664,6 +679,7 @@ func TestChatController_ChangeUserRole_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -698,6 +714,7 @@ func TestChatController_ChangeUserRole_UserNotInChat(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -735,6 +752,7 @@ func TestChatController_ChangeUserRole_RoleNotFound(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -774,6 +792,7 @@ func TestChatController_ChangeUserRole_ChangeRoleError(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -809,6 +828,7 @@ func TestChatController_ChangeUserRole_ChatUserNil(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -849,6 +869,7 @@ func TestChatController_BanUser_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -882,6 +903,7 @@ func TestChatController_BanUser_UserNotInChat(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -918,6 +940,7 @@ func TestChatController_BanUser_BannedRoleNotFound(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -956,6 +979,7 @@ func TestChatController_BanUser_ChangeRoleError(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -990,6 +1014,7 @@ func TestChatController_BanUser_ChatUserNil(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -1030,6 +1055,7 @@ func TestChatController_GetUserRoleInChat_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -1064,6 +1090,7 @@ func TestChatController_GetUserRoleInChat_RequesterNotInChat(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -1101,6 +1128,7 @@ func TestChatController_GetUserRoleInChat_UserNotInChat(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -1138,6 +1166,7 @@ func TestChatController_GetUserRoleInChat_GetUserRoleError(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -1179,6 +1208,7 @@ func TestChatController_GetMyRoleWithPermissions_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -1215,6 +1245,7 @@ func TestChatController_GetMyRoleWithPermissions_UserNotInChat(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -1240,12 +1271,25 @@ func TestChatController_GetChatMembers_Success(t *testing.T) {
 	mockUserClient := new(MockUserClient)
 
 	chatID := uuid.New()
+	owner := createTestUserResponse()
+	owner.User.Username = "owner"
+	avatarID := 5
+	owner.User.AvatarFileID = &avatarID
+	member := createTestUserResponse()
+	member.User.Username = "member"
 	chatUsers := []models.ChatUser{
-		*createTestChatUserWithRole(chatID, uuid.New(), 1, "owner"),
-		*createTestChatUserWithRole(chatID, uuid.New(), 2, "main"),
+		*createTestChatUserWithRole(chatID, owner.User.ID, 1, "owner"),
+		*createTestChatUserWithRole(chatID, member.User.ID, 2, "main"),
 	}
+	filter := &dto.ChatMembersFilter{Offset: 0, Limit: 50}
 
-	mockChatUserRepo.On("GetChatUsers", chatID).Return(chatUsers, nil)
+	avatar := createTestFile()
+	avatar.ID = avatarID
+
+	mockChatUserRepo.On("GetChatUsersFiltered", chatID, "", []uuid.UUID(nil), 0, 50).Return(chatUsers, int64(2), nil)
+	mockUserClient.On("GetUsersByIDs", []uuid.UUID{owner.User.ID, member.User.ID}).Return(createTestUsersResponse(owner, member), nil)
+	// Аватары загружаются одним пакетным запросом
+	mockFileClient.On("GetFilesByIDs", []int{avatarID}).Return(&fc.FilesResponse{Files: []*fc.File{avatar}}, nil).Once()
 
 	controller := controllers.NewChatControllerWithClients(
 		mockChatRepo,
@@ -1254,17 +1298,122 @@ func TestChatController_GetChatMembers_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
-	result, err := controller.GetChatMembers(chatID)
+	result, err := controller.GetChatMembers(chatID, filter)
 
 	// Assert
 	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Len(t, result, 2)
+	require.NotNil(t, result)
+	assert.Equal(t, int64(2), result.Total)
+	require.Len(t, result.Members, 2)
+	assert.Equal(t, "owner", result.Members[0].Username)
+	assert.Equal(t, "owner", result.Members[0].RoleName)
+	assert.NotNil(t, result.Members[0].AvatarFile)
+	assert.Equal(t, "member", result.Members[1].Username)
+	assert.Nil(t, result.Members[1].AvatarFile)
 
 	mockChatUserRepo.AssertExpectations(t)
+	mockUserClient.AssertExpectations(t)
+	mockFileClient.AssertExpectations(t)
+}
+
+func TestChatController_GetChatMembers_SearchByName(t *testing.T) {
+	// Arrange
+	mockChatRepo := new(MockChatRepository)
+	mockChatUserRepo := new(MockChatUserRepository)
+	mockChatRoleRepo := new(MockChatRoleRepository)
+	mockNotificationService := new(MockNotificationService)
+	mockFileClient := new(MockFileClient)
+	mockUserClient := new(MockUserClient)
+
+	chatID := uuid.New()
+	alice := createTestUserResponseWithEmail("alice@example.com")
+	alice.User.Username = "Alice"
+	alex := createTestUserResponseWithEmail("alex@example.com")
+	alex.User.Username = "Alex"
+	bob := createTestUserResponseWithEmail("bob@example.com")
+	bob.User.Username = "Bob"
+	chatUsers := []models.ChatUser{
+		*createTestChatUserWithRole(chatID, alice.User.ID, 2, "main"),
+		*createTestChatUserWithRole(chatID, bob.User.ID, 2, "main"),
+		*createTestChatUserWithRole(chatID, alex.User.ID, 2, "main"),
+	}
+	memberIDs := []uuid.UUID{alice.User.ID, bob.User.ID, alex.User.ID}
+	filter := &dto.ChatMembersFilter{RoleName: "main", Search: " AL ", Offset: 1, Limit: 10}
+
+	// userService отбирает совпадения по имени, страница и total считаются в репозитории по найденным ID
+	mockChatUserRepo.On("GetChatUserIDs", chatID, "main").Return(memberIDs, nil)
+	mockUserClient.On("SearchUsersByIDs", memberIDs, "AL").Return(createTestUsersResponse(alice, alex), nil)
+	mockChatUserRepo.On("GetChatUsersFiltered", chatID, "main", mock.MatchedBy(func(ids []uuid.UUID) bool {
+		return len(ids) == 2 && slices.Contains(ids, alice.User.ID) && slices.Contains(ids, alex.User.ID)
+	}), 1, 10).Return([]models.ChatUser{chatUsers[2]}, int64(2), nil)
+
+	controller := controllers.NewChatControllerWithClients(
+		mockChatRepo,
+		mockChatUserRepo,
+		mockChatRoleRepo,
+		mockNotificationService,
+		mockFileClient,
+		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
+	)
+
+	// Act
+	result, err := controller.GetChatMembers(chatID, filter)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Total)
+	require.Len(t, result.Members, 1)
+	assert.Equal(t, "Alex", result.Members[0].Username)
+
+	mockChatUserRepo.AssertExpectations(t)
+	mockUserClient.AssertExpectations(t)
+	mockUserClient.AssertNotCalled(t, "GetUsersByIDs", mock.Anything)
+}
+
+func TestChatController_GetChatMembers_SearchUsersInBatches(t *testing.T) {
+	// Arrange
+	mockChatRepo := new(MockChatRepository)
+	mockChatUserRepo := new(MockChatUserRepository)
+	mockChatRoleRepo := new(MockChatRoleRepository)
+	mockNotificationService := new(MockNotificationService)
+	mockFileClient := new(MockFileClient)
+	mockUserClient := new(MockUserClient)
+
+	chatID := uuid.New()
+	memberIDs := make([]uuid.UUID, 150)
+	for i := range memberIDs {
+		memberIDs[i] = uuid.New()
+	}
+	filter := &dto.ChatMembersFilter{Search: "nobody", Limit: 50}
+
+	mockChatUserRepo.On("GetChatUserIDs", chatID, "").Return(memberIDs, nil)
+	mockUserClient.On("SearchUsersByIDs", memberIDs[:100], "nobody").Return(createTestUsersResponse(), nil).Once()
+	mockUserClient.On("SearchUsersByIDs", memberIDs[100:], "nobody").Return(createTestUsersResponse(), nil).Once()
+
+	controller := controllers.NewChatControllerWithClients(
+		mockChatRepo,
+		mockChatUserRepo,
+		mockChatRoleRepo,
+		mockNotificationService,
+		mockFileClient,
+		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
+	)
+
+	// Act
+	result, err := controller.GetChatMembers(chatID, filter)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), result.Total)
+	assert.Empty(t, result.Members)
+	mockUserClient.AssertExpectations(t)
+	mockChatUserRepo.AssertNotCalled(t, "GetChatUsersFiltered", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestChatController_GetChatMembers_RepositoryError(t *testing.T) {
@@ -1279,7 +1428,7 @@ func TestChatController_GetChatMembers_RepositoryError(t *testing.T) {
 	chatID := uuid.New()
 	repoError := errors.New("database error")
 
-	mockChatUserRepo.On("GetChatUsers", chatID).Return(nil, repoError)
+	mockChatUserRepo.On("GetChatUsersFiltered", chatID, "", []uuid.UUID(nil), 0, 50).Return(nil, int64(0), repoError)
 
 	controller := controllers.NewChatControllerWithClients(
 		mockChatRepo,
@@ -1288,18 +1437,18 @@ func TestChatController_GetChatMembers_RepositoryError(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
-	result, err := controller.GetChatMembers(chatID)
+	result, err := controller.GetChatMembers(chatID, &dto.ChatMembersFilter{Limit: 50})
 
 	// Assert
 	require.Error(t, err)
 	assert.Nil(t, result)
 	var dbErr *custom_errors.DatabaseError
 	assert.True(t, errors.As(err, &dbErr))
-
-	mockChatUserRepo.AssertExpectations(t)
+	mockUserClient.AssertNotCalled(t, "GetUsersByIDs", mock.Anything)
 }
 
 func TestChatController_CreateChat_WithMultipleUsers_WithNotifications(t *testing.T) {
@@ -1333,9 +1482,7 @@ func TestChatController_CreateChat_WithMultipleUsers_WithNotifications(t *testin
 
 	mockChatRoleRepo.On("GetRoleByName", "owner").Return(ownerRole, nil)
 	mockChatRoleRepo.On("GetRoleByName", "main").Return(mainRole, nil)
-	mockUserClient.On("GetUserByID", &ownerID).Return(ownerUser, nil)
-	mockUserClient.On("GetUserByID", &userID1).Return(user1, nil)
-	mockUserClient.On("GetUserByID", &userID2).Return(user2, nil)
+	mockUserClient.On("GetUsersByIDs", []uuid.UUID{ownerID, userID1, userID2}).Return(createTestUsersResponse(ownerUser, user1, user2), nil)
 	mockChatRepo.On("CreateChat", mock.MatchedBy(func(chat *models.Chat) bool {
 		return chat.IsGroup == true && chat.Name == "Group Chat"
 	})).Return(nil).Run(func(args mock.Arguments) {
@@ -1358,6 +1505,7 @@ func TestChatController_CreateChat_WithMultipleUsers_WithNotifications(t *testin
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -1400,8 +1548,7 @@ func TestChatController_CreateChat_NotificationError_DoesNotFail(t *testing.T) {
 
 	mockChatRoleRepo.On("GetRoleByName", "owner").Return(ownerRole, nil)
 	mockChatRoleRepo.On("GetRoleByName", "main").Return(mainRole, nil)
-	mockUserClient.On("GetUserByID", &ownerID).Return(ownerUser, nil)
-	mockUserClient.On("GetUserByID", &userID1).Return(user1, nil)
+	mockUserClient.On("GetUsersByIDs", []uuid.UUID{ownerID, userID1}).Return(createTestUsersResponse(ownerUser, user1), nil)
 	mockChatRepo.On("CreateChat", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		chat := args.Get(0).(*models.Chat)
 		chat.ID = uuid.New()
@@ -1416,6 +1563,7 @@ func TestChatController_CreateChat_NotificationError_DoesNotFail(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -1455,8 +1603,7 @@ func TestChatController_CreateChat_UserWithoutEmail_NoNotification(t *testing.T)
 
 	mockChatRoleRepo.On("GetRoleByName", "owner").Return(ownerRole, nil)
 	mockChatRoleRepo.On("GetRoleByName", "main").Return(mainRole, nil)
-	mockUserClient.On("GetUserByID", &ownerID).Return(ownerUser, nil)
-	mockUserClient.On("GetUserByID", &userID1).Return(user1, nil)
+	mockUserClient.On("GetUsersByIDs", []uuid.UUID{ownerID, userID1}).Return(createTestUsersResponse(ownerUser, user1), nil)
 	mockChatRepo.On("CreateChat", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		chat := args.Get(0).(*models.Chat)
 		chat.ID = uuid.New()
@@ -1470,6 +1617,7 @@ func TestChatController_CreateChat_UserWithoutEmail_NoNotification(t *testing.T)
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -1507,8 +1655,7 @@ func TestChatController_CreateChat_AddUserToChatError(t *testing.T) {
 
 	mockChatRoleRepo.On("GetRoleByName", "owner").Return(ownerRole, nil)
 	mockChatRoleRepo.On("GetRoleByName", "main").Return(mainRole, nil)
-	mockUserClient.On("GetUserByID", &ownerID).Return(ownerUser, nil)
-	mockUserClient.On("GetUserByID", &userID1).Return(user1, nil)
+	mockUserClient.On("GetUsersByIDs", []uuid.UUID{ownerID, userID1}).Return(createTestUsersResponse(ownerUser, user1), nil)
 	mockChatRepo.On("CreateChat", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 		chat := args.Get(0).(*models.Chat)
 		chat.ID = uuid.New()
@@ -1527,6 +1674,7 @@ func TestChatController_CreateChat_AddUserToChatError(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -1537,6 +1685,113 @@ func TestChatController_CreateChat_AddUserToChatError(t *testing.T) {
 	assert.Nil(t, result)
 	var dbErr *custom_errors.DatabaseError
 	assert.True(t, errors.As(err, &dbErr))
+	// Транзакция откатилась - уведомления не отправляются
+	mockNotificationService.AssertNotCalled(t, "SendChatCreatedNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatController_CreateChat_UserNotFound_NoWrites(t *testing.T) {
+	// Arrange
+	mockChatRepo := new(MockChatRepository)
+	mockChatUserRepo := new(MockChatUserRepository)
+	mockChatRoleRepo := new(MockChatRoleRepository)
+	mockNotificationService := new(MockNotificationService)
+	mockFileClient := new(MockFileClient)
+	mockUserClient := new(MockUserClient)
+
+	ownerID := uuid.New()
+	missingUserID := uuid.New()
+	ownerUser := createTestUserResponse()
+	ownerUser.User.ID = ownerID
+
+	createChatDTO := &dto.CreateChatDTO{
+		Name:    "Test Chat",
+		OwnerID: ownerID,
+		UserIDs: []uuid.UUID{missingUserID},
+	}
+
+	mockChatRoleRepo.On("GetRoleByName", "owner").Return(createTestChatRoleWithID(1, "owner"), nil)
+	mockChatRoleRepo.On("GetRoleByName", "main").Return(createTestChatRoleWithID(2, "main"), nil)
+	mockUserClient.On("GetUsersByIDs", []uuid.UUID{ownerID, missingUserID}).Return(createTestUsersResponse(ownerUser), nil)
+
+	controller := controllers.NewChatControllerWithClients(
+		mockChatRepo,
+		mockChatUserRepo,
+		mockChatRoleRepo,
+		mockNotificationService,
+		mockFileClient,
+		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
+	)
+
+	// Act
+	result, err := controller.CreateChat(createChatDTO)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	var userErr *custom_errors.UserClientError
+	assert.True(t, errors.As(err, &userErr))
+	mockChatRepo.AssertNotCalled(t, "CreateChat", mock.Anything)
+	mockChatUserRepo.AssertNotCalled(t, "AddUserToChat", mock.Anything)
+	mockUserClient.AssertExpectations(t)
+}
+
+func TestChatController_CreateChat_ResolvesUsersInBatches(t *testing.T) {
+	// Arrange
+	mockChatRepo := new(MockChatRepository)
+	mockChatUserRepo := new(MockChatUserRepository)
+	mockChatRoleRepo := new(MockChatRoleRepository)
+	mockNotificationService := new(MockNotificationService)
+	mockFileClient := new(MockFileClient)
+	mockUserClient := new(MockUserClient)
+
+	ownerID := uuid.New()
+	userIDs := make([]uuid.UUID, 149)
+	responses := make([]*cuc.Response, 0, 150)
+	owner := createTestUserResponse()
+	owner.User.ID = ownerID
+	responses = append(responses, owner)
+	for i := range userIDs {
+		userIDs[i] = uuid.New()
+		user := createTestUserResponse()
+		user.User.ID = userIDs[i]
+		responses = append(responses, user)
+	}
+	allIDs := append([]uuid.UUID{ownerID}, userIDs...)
+
+	createChatDTO := &dto.CreateChatDTO{
+		Name:    "Big Chat",
+		OwnerID: ownerID,
+		UserIDs: userIDs,
+	}
+
+	mockChatRoleRepo.On("GetRoleByName", "owner").Return(createTestChatRoleWithID(1, "owner"), nil)
+	mockChatRoleRepo.On("GetRoleByName", "main").Return(createTestChatRoleWithID(2, "main"), nil)
+	// Во втором пакете userService не нашёл последнего пользователя
+	mockUserClient.On("GetUsersByIDs", allIDs[:100]).Return(createTestUsersResponse(responses[:100]...), nil).Once()
+	mockUserClient.On("GetUsersByIDs", allIDs[100:]).Return(createTestUsersResponse(responses[100:149]...), nil).Once()
+
+	controller := controllers.NewChatControllerWithClients(
+		mockChatRepo,
+		mockChatUserRepo,
+		mockChatRoleRepo,
+		mockNotificationService,
+		mockFileClient,
+		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
+	)
+
+	// Act
+	result, err := controller.CreateChat(createChatDTO)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+	var userErr *custom_errors.UserClientError
+	assert.True(t, errors.As(err, &userErr))
+	assert.Contains(t, err.Error(), userIDs[148].String())
+	mockChatRepo.AssertNotCalled(t, "CreateChat", mock.Anything)
+	mockUserClient.AssertExpectations(t)
 }
 
 func TestChatController_GetUserChats_EmptyList(t *testing.T) {
@@ -1559,6 +1814,7 @@ func TestChatController_GetUserChats_EmptyList(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
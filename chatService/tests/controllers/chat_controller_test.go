@@ -38,6 +38,7 @@ func TestChatController_GetChatByID_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -78,6 +79,7 @@ func TestChatController_GetChatByID_WithAvatar_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -118,6 +120,7 @@ func TestChatController_GetChatByID_WithAvatar_FileClientError(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -152,6 +155,7 @@ func TestChatController_GetChatByID_NotFound(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -185,6 +189,7 @@ func TestChatController_GetChatByID_DatabaseError(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -225,6 +230,7 @@ func TestChatController_GetUserChats_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -265,6 +271,7 @@ func TestChatController_GetUserChats_WithAvatars(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -301,6 +308,7 @@ func TestChatController_GetUserChats_RepositoryError(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -346,9 +354,7 @@ func TestChatController_CreateChat_Success(t *testing.T) {
 
 	mockChatRoleRepo.On("GetRoleByName", "owner").Return(ownerRole, nil)
 	mockChatRoleRepo.On("GetRoleByName", "main").Return(mainRole, nil)
-	mockUserClient.On("GetUserByID", &ownerID).Return(ownerUser, nil)
-	mockUserClient.On("GetUserByID", &userID1).Return(user1, nil)
-	mockUserClient.On("GetUserByID", &userID2).Return(user2, nil)
+	mockUserClient.On("GetUsersByIDs", []uuid.UUID{ownerID, userID1, userID2}).Return(createTestUsersResponse(ownerUser, user1, user2), nil)
 	mockChatRepo.On("CreateChat", mock.AnythingOfType("*models.Chat")).Return(nil).Run(func(args mock.Arguments) {
 		chat := args.Get(0).(*models.Chat)
 		chat.ID = uuid.New()
@@ -386,6 +392,7 @@ func TestChatController_CreateChat_Success(t *testing.T) {
 		mockNotificationService,
 		mockFileClient,
 		mockUserClient,
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
 	)
 
 	// Act
@@ -401,3 +408,55 @@ func TestChatController_CreateChat_Success(t *testing.T) {
 	mockChatUserRepo.AssertExpectations(t)
 	mockNotificationService.AssertExpectations(t)
 }
+
+// Тесты для ChatController.GetUserChatIDs
+
+func TestChatController_GetUserChatIDs_Success(t *testing.T) {
+	mockChatRepo := new(MockChatRepository)
+	mockChatUserRepo := new(MockChatUserRepository)
+
+	userID := uuid.New()
+	chatIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	mockChatUserRepo.On("GetUserChatIDs", userID).Return(chatIDs, nil)
+
+	controller := controllers.NewChatControllerWithClients(
+		mockChatRepo,
+		mockChatUserRepo,
+		new(MockChatRoleRepository),
+		new(MockNotificationService),
+		new(MockFileClient),
+		new(MockUserClient),
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
+	)
+
+	result, err := controller.GetUserChatIDs(userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, chatIDs, result)
+	mockChatUserRepo.AssertExpectations(t)
+}
+
+func TestChatController_GetUserChatIDs_DatabaseError(t *testing.T) {
+	mockChatRepo := new(MockChatRepository)
+	mockChatUserRepo := new(MockChatUserRepository)
+
+	userID := uuid.New()
+	mockChatUserRepo.On("GetUserChatIDs", userID).Return(nil, errors.New("connection refused"))
+
+	controller := controllers.NewChatControllerWithClients(
+		mockChatRepo,
+		mockChatUserRepo,
+		new(MockChatRoleRepository),
+		new(MockNotificationService),
+		new(MockFileClient),
+		new(MockUserClient),
+		NewMockUnitOfWork(mockChatRepo, mockChatUserRepo),
+	)
+
+	result, err := controller.GetUserChatIDs(userID)
+
+	assert.Nil(t, result)
+	var dbErr *custom_errors.DatabaseError
+	assert.True(t, errors.As(err, &dbErr))
+	mockChatUserRepo.AssertExpectations(t)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 // Тесты для MessageController.SendMessage
@@ -39,8 +40,7 @@ func TestMessageController_SendMessage_Success(t *testing.T) {
 	mockFileClient.On("GetFileByID", 1).Return(createTestFile(), nil)
 	mockFileClient.On("GetFileByID", 2).Return(createTestFile(), nil)
 
-	mockMsgRepo.On("CreateMessage", mock.AnythingOfType("*models.Message")).Return(nil)
-	mockMsgRepo.On("CreateMessageFile", mock.AnythingOfType("*models.MessageFile")).Return(nil)
+	mockMsgRepo.On("CreateMessageWithFiles", mock.AnythingOfType("*models.Message"), fileIDs).Return(nil)
 
 	createdMsg := createTestMessage()
 	createdMsg.ChatID = chatID
@@ -66,6 +66,145 @@ func TestMessageController_SendMessage_Success(t *testing.T) {
 	mockMsgRepo.AssertExpectations(t)
 }
 
+func TestMessageController_SendMessage_RendersContentHTML(t *testing.T) {
+	t.Parallel()
+	mockMsgRepo := new(MockMessageRepository)
+	mockChatRepo := new(MockChatRepository)
+	mockChatUserRepo := new(MockChatUserRepository)
+	mockFileClient := new(MockFileClient)
+	mockUserClient := new(MockUserClient)
+
+	chatID := uuid.New()
+	senderID := uuid.New()
+	createDTO := &dto.CreateMessageDTO{Content: "**hi** <b>"}
+
+	mockChatRepo.On("GetChatByID", chatID).Return(createTestChat(), nil)
+	mockUserClient.On("GetUserByID", &senderID).Return(createTestUserResponse(), nil)
+	mockMsgRepo.On("CreateMessageWithFiles", mock.MatchedBy(func(m *models.Message) bool {
+		return m.Content == "**hi** <b>" && m.ContentHTML != nil && *m.ContentHTML == "<strong>hi</strong> &lt;b&gt;"
+	}), mock.Anything).Return(nil)
+	mockMsgRepo.On("GetMessageWithFile", mock.Anything).Return(createTestMessage(), nil)
+
+	controller := controllers.NewMessageControllerWithClients(
+		mockMsgRepo, mockChatRepo, mockChatUserRepo, mockFileClient, mockUserClient,
+	)
+
+	_, err := controller.SendMessage(senderID, chatID, createDTO)
+
+	require.NoError(t, err)
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestMessageController_SendMessage_FormatError(t *testing.T) {
+	t.Parallel()
+	mockMsgRepo := new(MockMessageRepository)
+	mockChatRepo := new(MockChatRepository)
+	mockChatUserRepo := new(MockChatUserRepository)
+	mockFileClient := new(MockFileClient)
+	mockUserClient := new(MockUserClient)
+
+	chatID := uuid.New()
+	senderID := uuid.New()
+	createDTO := &dto.CreateMessageDTO{Content: "[click](javascript:alert(1))"}
+
+	mockChatRepo.On("GetChatByID", chatID).Return(createTestChat(), nil)
+
+	controller := controllers.NewMessageControllerWithClients(
+		mockMsgRepo, mockChatRepo, mockChatUserRepo, mockFileClient, mockUserClient,
+	)
+
+	result, err := controller.SendMessage(senderID, chatID, createDTO)
+
+	assert.Nil(t, result)
+	var formatErr *custom_errors.MessageFormatError
+	assert.True(t, errors.As(err, &formatErr))
+	mockUserClient.AssertNotCalled(t, "GetUserByID", mock.Anything)
+	mockMsgRepo.AssertNotCalled(t, "CreateMessageWithFiles", mock.Anything, mock.Anything)
+}
+
+func TestMessageController_SendMessage_DuplicateClientID(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	mockMsgRepo := new(MockMessageRepository)
+	mockChatRepo := new(MockChatRepository)
+	mockChatUserRepo := new(MockChatUserRepository)
+	mockFileClient := new(MockFileClient)
+	mockUserClient := new(MockUserClient)
+
+	chatID := uuid.New()
+	senderID := uuid.New()
+	clientID := "client-msg-1"
+	createDTO := &dto.CreateMessageDTO{
+		Content:  "hello",
+		FileIDs:  []int{1},
+		ClientID: &clientID,
+	}
+
+	existingMsg := createTestMessage()
+	existingMsg.ChatID = chatID
+	existingMsg.SenderID = &senderID
+	existingMsg.ClientID = &clientID
+
+	mockChatRepo.On("GetChatByID", chatID).Return(createTestChat(), nil)
+	mockMsgRepo.On("GetMessageByClientID", chatID, senderID, clientID).Return(existingMsg, nil)
+
+	controller := controllers.NewMessageControllerWithClients(
+		mockMsgRepo, mockChatRepo, mockChatUserRepo, mockFileClient, mockUserClient,
+	)
+
+	// Act
+	result, err := controller.SendMessage(senderID, chatID, createDTO)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, existingMsg.ID, result.ID)
+
+	mockMsgRepo.AssertNotCalled(t, "CreateMessageWithFiles", mock.Anything, mock.Anything)
+	mockFileClient.AssertNotCalled(t, "GetFileByID", mock.Anything)
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestMessageController_SendMessage_ConcurrentDuplicateClientID(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	mockMsgRepo := new(MockMessageRepository)
+	mockChatRepo := new(MockChatRepository)
+	mockChatUserRepo := new(MockChatUserRepository)
+	mockFileClient := new(MockFileClient)
+	mockUserClient := new(MockUserClient)
+
+	chatID := uuid.New()
+	senderID := uuid.New()
+	clientID := "client-msg-2"
+	createDTO := &dto.CreateMessageDTO{
+		Content:  "hello",
+		ClientID: &clientID,
+	}
+
+	existingMsg := createTestMessage()
+	existingMsg.ChatID = chatID
+	existingMsg.SenderID = &senderID
+
+	mockChatRepo.On("GetChatByID", chatID).Return(createTestChat(), nil)
+	mockUserClient.On("GetUserByID", &senderID).Return(createTestUserResponse(), nil)
+	mockMsgRepo.On("GetMessageByClientID", chatID, senderID, clientID).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockMsgRepo.On("CreateMessageWithFiles", mock.AnythingOfType("*models.Message"), mock.Anything).Return(errors.New("duplicate key value violates unique constraint"))
+	mockMsgRepo.On("GetMessageByClientID", chatID, senderID, clientID).Return(existingMsg, nil).Once()
+
+	controller := controllers.NewMessageControllerWithClients(
+		mockMsgRepo, mockChatRepo, mockChatUserRepo, mockFileClient, mockUserClient,
+	)
+
+	// Act
+	result, err := controller.SendMessage(senderID, chatID, createDTO)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, existingMsg.ID, result.ID)
+
+	mockMsgRepo.AssertExpectations(t)
+}
+
 func TestMessageController_SendMessage_WithMultipleFiles(t *testing.T) {
 	t.Parallel()
 	mockMsgRepo := new(MockMessageRepository)
@@ -97,13 +236,10 @@ func TestMessageController_SendMessage_WithMultipleFiles(t *testing.T) {
 	mockFileClient.On("GetFileByID", 1).Return(file1, nil)
 	mockFileClient.On("GetFileByID", 2).Return(file2, nil)
 	mockFileClient.On("GetFileByID", 3).Return(file3, nil)
-	mockMsgRepo.On("CreateMessage", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+	mockMsgRepo.On("CreateMessageWithFiles", mock.Anything, []int{1, 2, 3}).Return(nil).Run(func(args mock.Arguments) {
 		m := args.Get(0).(*models.Message)
 		m.ID = msg.ID
 	})
-	mockMsgRepo.On("CreateMessageFile", mock.MatchedBy(func(mf *models.MessageFile) bool {
-		return mf.FileID == 1 || mf.FileID == 2 || mf.FileID == 3
-	})).Return(nil).Times(3)
 	mockMsgRepo.On("GetMessageWithFile", msg.ID).Return(msg, nil)
 
 	controller := controllers.NewMessageControllerWithClients(
@@ -142,7 +278,7 @@ func TestMessageController_SendMessage_WithoutFiles(t *testing.T) {
 
 	mockChatRepo.On("GetChatByID", chatID).Return(createTestChat(), nil)
 	mockUserClient.On("GetUserByID", &senderID).Return(createTestUserResponse(), nil)
-	mockMsgRepo.On("CreateMessage", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+	mockMsgRepo.On("CreateMessageWithFiles", mock.Anything, []int{}).Return(nil).Run(func(args mock.Arguments) {
 		m := args.Get(0).(*models.Message)
 		m.ID = msg.ID
 	})
@@ -160,7 +296,6 @@ func TestMessageController_SendMessage_WithoutFiles(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, msg.ID, result.ID)
-	mockMsgRepo.AssertNotCalled(t, "CreateMessageFile", mock.Anything)
 
 	mockChatRepo.AssertExpectations(t)
 	mockUserClient.AssertExpectations(t)
@@ -301,7 +436,7 @@ func TestMessageController_SendMessage_CreateMessageError(t *testing.T) {
 
 	mockChatRepo.On("GetChatByID", chatID).Return(createTestChat(), nil)
 	mockUserClient.On("GetUserByID", &senderID).Return(createTestUserResponse(), nil)
-	mockMsgRepo.On("CreateMessage", mock.Anything).Return(errors.New("db"))
+	mockMsgRepo.On("CreateMessageWithFiles", mock.Anything, mock.Anything).Return(errors.New("db"))
 
 	controller := controllers.NewMessageControllerWithClients(
 		mockMsgRepo, mockChatRepo, mockChatUserRepo, mockFileClient, mockUserClient,
@@ -315,7 +450,7 @@ func TestMessageController_SendMessage_CreateMessageError(t *testing.T) {
 	assert.True(t, errors.As(err, &dbErr))
 }
 
-func TestMessageController_SendMessage_CreateMessageFileError(t *testing.T) {
+func TestMessageController_SendMessage_CreateMessageFilesError(t *testing.T) {
 	t.Parallel()
 	mockMsgRepo := new(MockMessageRepository)
 	mockChatRepo := new(MockChatRepository)
@@ -330,8 +465,8 @@ func TestMessageController_SendMessage_CreateMessageFileError(t *testing.T) {
 	mockChatRepo.On("GetChatByID", chatID).Return(createTestChat(), nil)
 	mockUserClient.On("GetUserByID", &senderID).Return(createTestUserResponse(), nil)
 	mockFileClient.On("GetFileByID", fileID).Return(createTestFile(), nil)
-	mockMsgRepo.On("CreateMessage", mock.Anything).Return(nil)
-	mockMsgRepo.On("CreateMessageFile", mock.Anything).Return(errors.New("db error"))
+	// Сообщение и вложения сохраняются одной транзакцией, поэтому ошибка вложений откатывает и сообщение
+	mockMsgRepo.On("CreateMessageWithFiles", mock.Anything, []int{fileID}).Return(errors.New("db error"))
 
 	controller := controllers.NewMessageControllerWithClients(
 		mockMsgRepo, mockChatRepo, mockChatUserRepo, mockFileClient, mockUserClient,
@@ -345,6 +480,7 @@ func TestMessageController_SendMessage_CreateMessageFileError(t *testing.T) {
 	assert.Nil(t, result)
 	var dbErr *custom_errors.DatabaseError
 	assert.True(t, errors.As(err, &dbErr))
+	mockMsgRepo.AssertNotCalled(t, "GetMessageWithFile", mock.Anything)
 }
 
 func TestMessageController_SendMessage_GetMessageWithFileError(t *testing.T) {
@@ -360,8 +496,7 @@ func TestMessageController_SendMessage_GetMessageWithFileError(t *testing.T) {
 
 	mockChatRepo.On("GetChatByID", chatID).Return(createTestChat(), nil)
 	mockUserClient.On("GetUserByID", &senderID).Return(createTestUserResponse(), nil)
-	mockMsgRepo.On("CreateMessage", mock.Anything).Return(nil)
-	mockMsgRepo.On("CreateMessageFile", mock.Anything).Return(nil)
+	mockMsgRepo.On("CreateMessageWithFiles", mock.Anything, mock.Anything).Return(nil)
 	mockMsgRepo.On("GetMessageWithFile", mock.Anything).Return(nil, errors.New("db err"))
 
 	controller := controllers.NewMessageControllerWithClients(
@@ -765,3 +900,160 @@ func TestMessageController_SearchMessages_RepoError(t *testing.T) {
 	var dbErr *custom_errors.DatabaseError
 	assert.True(t, errors.As(err, &dbErr))
 }
+
+// Тесты для MessageController.GetMessageByID
+
+func TestMessageController_GetMessageByID(t *testing.T) {
+	viewMessages := *createTestChatPermissionWithID(2, "view_messages")
+	sendMessage := *createTestChatPermissionWithID(1, "send_message")
+
+	tests := []struct {
+		name        string
+		chatUser    *models.ChatUser
+		chatUserErr error
+		expectedErr error
+	}{
+		{
+			name:     "member with view_messages",
+			chatUser: &models.ChatUser{Role: *createTestChatRoleWithPermissions(1, "member", []models.ChatPermission{sendMessage, viewMessages})},
+		},
+		{
+			name:        "member without view_messages",
+			chatUser:    &models.ChatUser{Role: *createTestChatRoleWithPermissions(4, "banned", nil)},
+			expectedErr: custom_errors.ErrViewMessagesDenied,
+		},
+		{
+			name:        "not a member",
+			chatUserErr: gorm.ErrRecordNotFound,
+			expectedErr: custom_errors.ErrViewMessagesDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMsgRepo := new(MockMessageRepository)
+			mockChatUserRepo := new(MockChatUserRepository)
+			userID := uuid.New()
+			message := createTestMessage()
+
+			mockMsgRepo.On("GetMessageWithFile", message.ID).Return(message, nil)
+			mockChatUserRepo.On("GetChatUserWithRoleAndPermissions", userID, message.ChatID).Return(tt.chatUser, tt.chatUserErr)
+
+			controller := controllers.NewMessageControllerWithClients(
+				mockMsgRepo, new(MockChatRepository), mockChatUserRepo, new(MockFileClient), new(MockUserClient),
+			)
+
+			result, err := controller.GetMessageByID(userID, message.ID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, message.ID, result.ID)
+		})
+	}
+}
+
+func TestMessageController_GetMessageByID_NotFound(t *testing.T) {
+	mockMsgRepo := new(MockMessageRepository)
+	mockChatUserRepo := new(MockChatUserRepository)
+	messageID := uuid.New()
+
+	mockMsgRepo.On("GetMessageWithFile", messageID).Return(nil, gorm.ErrRecordNotFound)
+
+	controller := controllers.NewMessageControllerWithClients(
+		mockMsgRepo, new(MockChatRepository), mockChatUserRepo, new(MockFileClient), new(MockUserClient),
+	)
+
+	_, err := controller.GetMessageByID(uuid.New(), messageID)
+
+	assert.ErrorIs(t, err, custom_errors.ErrMessageNotFound)
+	mockChatUserRepo.AssertNotCalled(t, "GetChatUserWithRoleAndPermissions", mock.Anything, mock.Anything)
+}
+
+// Тесты для MessageController.CreateSystemMessage
+
+func TestMessageController_CreateSystemMessage_WithoutSender(t *testing.T) {
+	mockMsgRepo := new(MockMessageRepository)
+	mockChatRepo := new(MockChatRepository)
+	chatID := uuid.New()
+	taskID := 12
+
+	mockChatRepo.On("GetChatByID", chatID).Return(createTestChat(), nil)
+	mockMsgRepo.On("CreateMessage", mock.MatchedBy(func(msg *models.Message) bool {
+		return msg.ChatID == chatID && msg.SenderID == nil && msg.TaskID != nil && *msg.TaskID == taskID
+	})).Return(nil)
+
+	controller := controllers.NewMessageControllerWithClients(
+		mockMsgRepo, mockChatRepo, new(MockChatUserRepository), new(MockFileClient), new(MockUserClient),
+	)
+
+	result, err := controller.CreateSystemMessage(chatID, &dto.CreateSystemMessageDTO{Content: "Создана задача **#12**", TaskID: &taskID})
+
+	require.NoError(t, err)
+	require.NotNil(t, result.ContentHTML)
+	assert.Contains(t, *result.ContentHTML, "<strong>#12</strong>")
+	mockMsgRepo.AssertExpectations(t)
+}
+
+func TestMessageController_CreateSystemMessage_ChatNotFound(t *testing.T) {
+	mockMsgRepo := new(MockMessageRepository)
+	mockChatRepo := new(MockChatRepository)
+	chatID := uuid.New()
+
+	mockChatRepo.On("GetChatByID", chatID).Return(nil, gorm.ErrRecordNotFound)
+
+	controller := controllers.NewMessageControllerWithClients(
+		mockMsgRepo, mockChatRepo, new(MockChatUserRepository), new(MockFileClient), new(MockUserClient),
+	)
+
+	_, err := controller.CreateSystemMessage(chatID, &dto.CreateSystemMessageDTO{Content: "text"})
+
+	assert.ErrorIs(t, err, custom_errors.ErrChatNotFound)
+	mockMsgRepo.AssertNotCalled(t, "CreateMessage", mock.Anything)
+}
+
+func TestMessageController_CreateSystemMessage_RepeatedEventReturnsExisting(t *testing.T) {
+	mockMsgRepo := new(MockMessageRepository)
+	mockChatRepo := new(MockChatRepository)
+	chatID := uuid.New()
+	eventID := "task:12:created"
+	existing := &models.Message{ID: uuid.New(), ChatID: chatID, EventID: &eventID}
+
+	mockChatRepo.On("GetChatByID", chatID).Return(createTestChat(), nil)
+	mockMsgRepo.On("GetMessageByEventID", eventID).Return(existing, nil)
+
+	controller := controllers.NewMessageControllerWithClients(
+		mockMsgRepo, mockChatRepo, new(MockChatUserRepository), new(MockFileClient), new(MockUserClient),
+	)
+
+	result, err := controller.CreateSystemMessage(chatID, &dto.CreateSystemMessageDTO{Content: "text", EventID: &eventID})
+
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, result.ID)
+	mockMsgRepo.AssertNotCalled(t, "CreateMessage", mock.Anything)
+}
+
+func TestMessageController_CreateSystemMessage_ConcurrentEventReturnsExisting(t *testing.T) {
+	mockMsgRepo := new(MockMessageRepository)
+	mockChatRepo := new(MockChatRepository)
+	chatID := uuid.New()
+	eventID := "task_event:40"
+	existing := &models.Message{ID: uuid.New(), ChatID: chatID, EventID: &eventID}
+
+	mockChatRepo.On("GetChatByID", chatID).Return(createTestChat(), nil)
+	mockMsgRepo.On("GetMessageByEventID", eventID).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockMsgRepo.On("CreateMessage", mock.Anything).Return(errors.New("duplicate key value violates unique constraint"))
+	mockMsgRepo.On("GetMessageByEventID", eventID).Return(existing, nil).Once()
+
+	controller := controllers.NewMessageControllerWithClients(
+		mockMsgRepo, mockChatRepo, new(MockChatUserRepository), new(MockFileClient), new(MockUserClient),
+	)
+
+	result, err := controller.CreateSystemMessage(chatID, &dto.CreateSystemMessageDTO{Content: "text", EventID: &eventID})
+
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, result.ID)
+}
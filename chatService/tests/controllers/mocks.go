@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"chatService/internal/models"
+	"chatService/internal/repositories"
 	fc "common/contracts/file-contracts"
 	cuc "common/contracts/user-contracts"
 	"github.com/google/uuid"
@@ -92,6 +93,30 @@ func (m *MockChatUserRepository) GetChatUsers(chatID uuid.UUID) ([]models.ChatUs
 	return args.Get(0).([]models.ChatUser), args.Error(1)
 }
 
+func (m *MockChatUserRepository) GetChatUsersFiltered(chatID uuid.UUID, roleName string, userIDs []uuid.UUID, offset, limit int) ([]models.ChatUser, int64, error) {
+	args := m.Called(chatID, roleName, userIDs, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.ChatUser), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockChatUserRepository) GetChatUserIDs(chatID uuid.UUID, roleName string) ([]uuid.UUID, error) {
+	args := m.Called(chatID, roleName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockChatUserRepository) GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 func (m *MockChatUserRepository) RemoveUserFromChat(chatID, userID uuid.UUID) error {
 	args := m.Called(chatID, userID)
 	return args.Error(0)
@@ -161,6 +186,11 @@ func (m *MockMessageRepository) CreateMessageFile(msgFile *models.MessageFile) e
 	return args.Error(0)
 }
 
+func (m *MockMessageRepository) CreateMessageWithFiles(message *models.Message, fileIDs []int) error {
+	args := m.Called(message, fileIDs)
+	return args.Error(0)
+}
+
 func (m *MockMessageRepository) GetMessageWithFile(msgID uuid.UUID) (*models.Message, error) {
 	args := m.Called(msgID)
 	if args.Get(0) == nil {
@@ -169,6 +199,22 @@ func (m *MockMessageRepository) GetMessageWithFile(msgID uuid.UUID) (*models.Mes
 	return args.Get(0).(*models.Message), args.Error(1)
 }
 
+func (m *MockMessageRepository) GetMessageByClientID(chatID, senderID uuid.UUID, clientID string) (*models.Message, error) {
+	args := m.Called(chatID, senderID, clientID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) GetMessageByEventID(eventID string) (*models.Message, error) {
+	args := m.Called(eventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Message), args.Error(1)
+}
+
 func (m *MockMessageRepository) GetChatMessages(chatID uuid.UUID, offset, limit int) ([]models.Message, error) {
 	args := m.Called(chatID, offset, limit)
 	if args.Get(0) == nil {
@@ -309,6 +355,15 @@ func createTestUserResponseWithEmail(email string) *cuc.Response {
 	}
 }
 
+// createTestUsersResponse собирает ответ пакетного запроса пользователей из одиночных ответов
+func createTestUsersResponse(responses ...*cuc.Response) *cuc.UsersResponse {
+	users := make([]*cuc.User, 0, len(responses))
+	for _, resp := range responses {
+		users = append(users, resp.User)
+	}
+	return &cuc.UsersResponse{Users: users}
+}
+
 // MockFileClient - мок для FileClientInterface
 type MockFileClient struct {
 	mock.Mock
@@ -322,6 +377,14 @@ func (m *MockFileClient) GetFileByID(fileID int) (*fc.File, error) {
 	return args.Get(0).(*fc.File), args.Error(1)
 }
 
+func (m *MockFileClient) GetFilesByIDs(fileIDs []int) (*fc.FilesResponse, error) {
+	args := m.Called(fileIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fc.FilesResponse), args.Error(1)
+}
+
 // MockUserClient - мок для UserClientInterface
 type MockUserClient struct {
 	mock.Mock
@@ -335,6 +398,36 @@ func (m *MockUserClient) GetUserByID(userID *uuid.UUID) (*cuc.Response, error) {
 	return args.Get(0).(*cuc.Response), args.Error(1)
 }
 
+func (m *MockUserClient) GetUsersByIDs(userIDs []uuid.UUID) (*cuc.UsersResponse, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cuc.UsersResponse), args.Error(1)
+}
+
+func (m *MockUserClient) SearchUsersByIDs(userIDs []uuid.UUID, query string) (*cuc.UsersResponse, error) {
+	args := m.Called(userIDs, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cuc.UsersResponse), args.Error(1)
+}
+
+// MockUnitOfWork - мок для UnitOfWork: выполняет fn без транзакции на переданных мок-репозиториях
+type MockUnitOfWork struct {
+	ChatRepo     repositories.ChatRepository
+	ChatUserRepo repositories.ChatUserRepository
+}
+
+func NewMockUnitOfWork(chatRepo repositories.ChatRepository, chatUserRepo repositories.ChatUserRepository) *MockUnitOfWork {
+	return &MockUnitOfWork{ChatRepo: chatRepo, ChatUserRepo: chatUserRepo}
+}
+
+func (u *MockUnitOfWork) Do(fn func(repos repositories.TxRepositories) error) error {
+	return fn(repositories.TxRepositories{ChatRepo: u.ChatRepo, ChatUserRepo: u.ChatUserRepo})
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -404,3 +497,52 @@ func createTestChatRoleWithPermissions(roleID int, roleName string, permissions
 		Permissions: permissions,
 	}
 }
+
+// MockChatTaskEventSettingsRepository - мок для ChatTaskEventSettingsRepository
+type MockChatTaskEventSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *MockChatTaskEventSettingsRepository) GetByChatID(chatID uuid.UUID) (*models.ChatTaskEventSettings, error) {
+	args := m.Called(chatID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChatTaskEventSettings), args.Error(1)
+}
+
+func (m *MockChatTaskEventSettingsRepository) Save(settings *models.ChatTaskEventSettings) error {
+	args := m.Called(settings)
+	return args.Error(0)
+}
+
+// MockSavedMessageRepository - мок для SavedMessageRepository
+type MockSavedMessageRepository struct {
+	mock.Mock
+}
+
+func (m *MockSavedMessageRepository) SaveMessage(savedMessage *models.SavedMessage) error {
+	args := m.Called(savedMessage)
+	return args.Error(0)
+}
+
+func (m *MockSavedMessageRepository) GetSavedMessage(userID, messageID uuid.UUID) (*models.SavedMessage, error) {
+	args := m.Called(userID, messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SavedMessage), args.Error(1)
+}
+
+func (m *MockSavedMessageRepository) GetUserSavedMessages(userID uuid.UUID, offset, limit int) ([]models.SavedMessage, int64, error) {
+	args := m.Called(userID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.SavedMessage), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSavedMessageRepository) DeleteSavedMessage(userID, messageID uuid.UUID) (bool, error) {
+	args := m.Called(userID, messageID)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,211 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"chatService/internal/controllers"
+	"chatService/internal/custom_errors"
+	"chatService/internal/handlers/dto"
+	"chatService/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newSavedMessageControllerWithMocks() (*controllers.SavedMessageController, *MockSavedMessageRepository, *MockMessageRepository, *MockChatUserRepository, *MockFileClient) {
+	mockSavedRepo := new(MockSavedMessageRepository)
+	mockMsgRepo := new(MockMessageRepository)
+	mockChatUserRepo := new(MockChatUserRepository)
+	mockFileClient := new(MockFileClient)
+	controller := controllers.NewSavedMessageControllerWithClients(mockSavedRepo, mockMsgRepo, mockChatUserRepo, mockFileClient)
+	return controller, mockSavedRepo, mockMsgRepo, mockChatUserRepo, mockFileClient
+}
+
+// Тесты для SavedMessageController.SaveMessage
+
+func TestSavedMessageController_SaveMessage_Success(t *testing.T) {
+	controller, mockSavedRepo, mockMsgRepo, mockChatUserRepo, mockFileClient := newSavedMessageControllerWithMocks()
+
+	userID := uuid.New()
+	message := createTestMessage()
+	message.Files = []models.MessageFile{{MessageID: message.ID, FileID: 1}}
+	note := "важно"
+
+	mockMsgRepo.On("GetMessageWithFile", message.ID).Return(message, nil)
+	mockChatUserRepo.On("GetUserRole", message.ChatID, userID).Return(createTestChatRoleWithID(3, "main"), nil)
+	mockSavedRepo.On("SaveMessage", mock.MatchedBy(func(s *models.SavedMessage) bool {
+		return s.UserID == userID && s.MessageID == message.ID && s.Note == &note
+	})).Return(nil)
+	mockFileClient.On("GetFileByID", 1).Return(createTestFile(), nil)
+
+	result, err := controller.SaveMessage(userID, message.ID, &dto.SaveMessageDTO{Note: &note})
+
+	require.NoError(t, err)
+	assert.True(t, result.Available)
+	assert.Equal(t, message.ChatID, result.ChatID)
+	assert.Equal(t, &note, result.Note)
+	require.NotNil(t, result.Message)
+	assert.Equal(t, message.Content, result.Message.Content)
+	assert.Len(t, *result.Message.Files, 1)
+	mockSavedRepo.AssertExpectations(t)
+}
+
+func TestSavedMessageController_SaveMessage_MessageNotFound(t *testing.T) {
+	controller, mockSavedRepo, mockMsgRepo, _, _ := newSavedMessageControllerWithMocks()
+
+	messageID := uuid.New()
+	mockMsgRepo.On("GetMessageWithFile", messageID).Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := controller.SaveMessage(uuid.New(), messageID, &dto.SaveMessageDTO{})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, custom_errors.ErrMessageNotFound)
+	mockSavedRepo.AssertNotCalled(t, "SaveMessage", mock.Anything)
+}
+
+func TestSavedMessageController_SaveMessage_NotMember(t *testing.T) {
+	controller, mockSavedRepo, mockMsgRepo, mockChatUserRepo, _ := newSavedMessageControllerWithMocks()
+
+	userID := uuid.New()
+	message := createTestMessage()
+	mockMsgRepo.On("GetMessageWithFile", message.ID).Return(message, nil)
+	mockChatUserRepo.On("GetUserRole", message.ChatID, userID).Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := controller.SaveMessage(userID, message.ID, &dto.SaveMessageDTO{})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, custom_errors.ErrUnauthorizedChat)
+	mockSavedRepo.AssertNotCalled(t, "SaveMessage", mock.Anything)
+}
+
+func TestSavedMessageController_SaveMessage_Banned(t *testing.T) {
+	controller, mockSavedRepo, mockMsgRepo, mockChatUserRepo, _ := newSavedMessageControllerWithMocks()
+
+	userID := uuid.New()
+	message := createTestMessage()
+	mockMsgRepo.On("GetMessageWithFile", message.ID).Return(message, nil)
+	mockChatUserRepo.On("GetUserRole", message.ChatID, userID).Return(createTestChatRoleWithID(2, "banned"), nil)
+
+	result, err := controller.SaveMessage(userID, message.ID, &dto.SaveMessageDTO{})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, custom_errors.ErrUnauthorizedChat)
+	mockSavedRepo.AssertNotCalled(t, "SaveMessage", mock.Anything)
+}
+
+// Тесты для SavedMessageController.GetSavedMessages
+
+func TestSavedMessageController_GetSavedMessages_HidesInaccessibleContent(t *testing.T) {
+	controller, mockSavedRepo, _, mockChatUserRepo, _ := newSavedMessageControllerWithMocks()
+
+	userID := uuid.New()
+	accessible := createTestMessage()
+	banned := createTestMessage()
+	removed := createTestMessage()
+
+	saved := []models.SavedMessage{
+		{UserID: userID, MessageID: accessible.ID, Message: *accessible, CreatedAt: time.Now()},
+		{UserID: userID, MessageID: banned.ID, Message: *banned, CreatedAt: time.Now()},
+		{UserID: userID, MessageID: removed.ID, Message: *removed, CreatedAt: time.Now()},
+	}
+
+	mockSavedRepo.On("GetUserSavedMessages", userID, 0, 20).Return(saved, int64(3), nil)
+	mockChatUserRepo.On("GetUserRole", accessible.ChatID, userID).Return(createTestChatRoleWithID(3, "main"), nil)
+	mockChatUserRepo.On("GetUserRole", banned.ChatID, userID).Return(createTestChatRoleWithID(2, "banned"), nil)
+	mockChatUserRepo.On("GetUserRole", removed.ChatID, userID).Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := controller.GetSavedMessages(userID, 0, 20)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total)
+	require.Len(t, result.SavedMessages, 3)
+
+	assert.True(t, result.SavedMessages[0].Available)
+	require.NotNil(t, result.SavedMessages[0].Message)
+	assert.Equal(t, accessible.Content, result.SavedMessages[0].Message.Content)
+
+	for _, hidden := range result.SavedMessages[1:] {
+		assert.False(t, hidden.Available)
+		assert.Nil(t, hidden.Message)
+	}
+}
+
+func TestSavedMessageController_GetSavedMessages_ChecksAccessOncePerChat(t *testing.T) {
+	controller, mockSavedRepo, _, mockChatUserRepo, _ := newSavedMessageControllerWithMocks()
+
+	userID := uuid.New()
+	first := createTestMessage()
+	second := createTestMessage()
+	second.ChatID = first.ChatID
+
+	saved := []models.SavedMessage{
+		{UserID: userID, MessageID: first.ID, Message: *first},
+		{UserID: userID, MessageID: second.ID, Message: *second},
+	}
+
+	mockSavedRepo.On("GetUserSavedMessages", userID, 0, 10).Return(saved, int64(2), nil)
+	mockChatUserRepo.On("GetUserRole", first.ChatID, userID).Return(createTestChatRoleWithID(3, "main"), nil).Once()
+
+	result, err := controller.GetSavedMessages(userID, 0, 10)
+
+	require.NoError(t, err)
+	assert.Len(t, result.SavedMessages, 2)
+	mockChatUserRepo.AssertNumberOfCalls(t, "GetUserRole", 1)
+}
+
+func TestSavedMessageController_GetSavedMessages_FileError(t *testing.T) {
+	controller, mockSavedRepo, _, mockChatUserRepo, mockFileClient := newSavedMessageControllerWithMocks()
+
+	userID := uuid.New()
+	message := createTestMessage()
+	message.Files = []models.MessageFile{{MessageID: message.ID, FileID: 7}}
+
+	mockSavedRepo.On("GetUserSavedMessages", userID, 0, 20).Return([]models.SavedMessage{
+		{UserID: userID, MessageID: message.ID, Message: *message},
+	}, int64(1), nil)
+	mockChatUserRepo.On("GetUserRole", message.ChatID, userID).Return(createTestChatRoleWithID(3, "main"), nil)
+	mockFileClient.On("GetFileByID", 7).Return(nil, errors.New("file service down"))
+
+	result, err := controller.GetSavedMessages(userID, 0, 20)
+
+	assert.Nil(t, result)
+	var fileErr *custom_errors.GetFileHTTPError
+	assert.ErrorAs(t, err, &fileErr)
+}
+
+func TestSavedMessageController_GetSavedMessages_DatabaseError(t *testing.T) {
+	controller, mockSavedRepo, _, _, _ := newSavedMessageControllerWithMocks()
+
+	userID := uuid.New()
+	mockSavedRepo.On("GetUserSavedMessages", userID, 0, 20).Return(nil, int64(0), errors.New("db down"))
+
+	result, err := controller.GetSavedMessages(userID, 0, 20)
+
+	assert.Nil(t, result)
+	var dbErr *custom_errors.DatabaseError
+	assert.ErrorAs(t, err, &dbErr)
+}
+
+// Тесты для SavedMessageController.DeleteSavedMessage
+
+func TestSavedMessageController_DeleteSavedMessage_Success(t *testing.T) {
+	controller, mockSavedRepo, _, _, _ := newSavedMessageControllerWithMocks()
+
+	userID, messageID := uuid.New(), uuid.New()
+	mockSavedRepo.On("DeleteSavedMessage", userID, messageID).Return(true, nil)
+
+	assert.NoError(t, controller.DeleteSavedMessage(userID, messageID))
+}
+
+func TestSavedMessageController_DeleteSavedMessage_NotFound(t *testing.T) {
+	controller, mockSavedRepo, _, _, _ := newSavedMessageControllerWithMocks()
+
+	userID, messageID := uuid.New(), uuid.New()
+	mockSavedRepo.On("DeleteSavedMessage", userID, messageID).Return(false, nil)
+
+	assert.ErrorIs(t, controller.DeleteSavedMessage(userID, messageID), custom_errors.ErrSavedMessageNotFound)
+}
@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"chatService/internal/controllers"
+	"chatService/internal/custom_errors"
+	"chatService/internal/handlers/dto"
+	"chatService/internal/models"
+	cuc "common/contracts/user-contracts"
+	commonModels "common/models"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type taskEventMocks struct {
+	settingsRepo *MockChatTaskEventSettingsRepository
+	messageRepo  *MockMessageRepository
+	chatRepo     *MockChatRepository
+	userClient   *MockUserClient
+}
+
+// newTaskEventController собирает контроллер с настоящим MessageController, чтобы проверять
+// идемпотентность публикации на уровне репозитория сообщений
+func newTaskEventController() (*controllers.TaskEventController, *taskEventMocks) {
+	m := &taskEventMocks{
+		settingsRepo: new(MockChatTaskEventSettingsRepository),
+		messageRepo:  new(MockMessageRepository),
+		chatRepo:     new(MockChatRepository),
+		userClient:   new(MockUserClient),
+	}
+	messageController := controllers.NewMessageControllerWithClients(
+		m.messageRepo, m.chatRepo, new(MockChatUserRepository), new(MockFileClient), m.userClient,
+	)
+	controller := controllers.NewTaskEventControllerWithClients(m.settingsRepo, messageController, m.userClient)
+	return controller, m
+}
+
+func testTaskDomainEvent(eventType commonModels.TaskDomainEventType) *commonModels.TaskDomainEvent {
+	return &commonModels.TaskDomainEvent{
+		EventID:    "task_event:40",
+		Type:       eventType,
+		TaskID:     12,
+		TaskTitle:  "Release",
+		ChatID:     uuid.New(),
+		ActorID:    uuid.New(),
+		OccurredAt: time.Now(),
+	}
+}
+
+// Тесты для TaskEventController.HandleTaskEvent
+
+func TestTaskEventController_HandleTaskEvent_StatusChanged(t *testing.T) {
+	controller, m := newTaskEventController()
+	event := testTaskDomainEvent(commonModels.TaskDomainEventStatusChanged)
+	event.OldValue, event.NewValue = "created", "in_progress"
+
+	m.settingsRepo.On("GetByChatID", event.ChatID).Return(nil, gorm.ErrRecordNotFound)
+	m.chatRepo.On("GetChatByID", event.ChatID).Return(createTestChat(), nil)
+	m.messageRepo.On("GetMessageByEventID", "task_event:40").Return(nil, gorm.ErrRecordNotFound)
+	m.userClient.On("GetUsersByIDs", []uuid.UUID{event.ActorID}).
+		Return(&cuc.UsersResponse{Users: []*cuc.User{{ID: event.ActorID, Username: "lead"}}}, nil)
+	m.messageRepo.On("CreateMessage", mock.MatchedBy(func(msg *models.Message) bool {
+		return msg.ChatID == event.ChatID && msg.SenderID == nil &&
+			*msg.TaskID == 12 && *msg.EventID == "task_event:40" &&
+			msg.Content == `@lead перевел(а) задачу #12 «Release» из статуса «created» в «in\_progress»` &&
+			*msg.ContentHTML == `<span class="mention" data-username="lead">@lead</span> перевел(а) задачу #12 «Release» из статуса «created» в «in_progress»`
+	})).Return(nil)
+
+	err := controller.HandleTaskEvent(event)
+
+	require.NoError(t, err)
+	m.messageRepo.AssertExpectations(t)
+}
+
+func TestTaskEventController_HandleTaskEvent_Reassigned(t *testing.T) {
+	controller, m := newTaskEventController()
+	event := testTaskDomainEvent(commonModels.TaskDomainEventReassigned)
+	executorID := uuid.New()
+	event.NewValue = executorID.String()
+
+	m.settingsRepo.On("GetByChatID", event.ChatID).Return(models.DefaultChatTaskEventSettings(event.ChatID), nil)
+	m.chatRepo.On("GetChatByID", event.ChatID).Return(createTestChat(), nil)
+	m.messageRepo.On("GetMessageByEventID", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	// userService недоступен: сообщение публикуется с обезличенными именами
+	m.userClient.On("GetUsersByIDs", []uuid.UUID{event.ActorID, executorID}).Return(nil, errors.New("timeout"))
+	m.messageRepo.On("CreateMessage", mock.MatchedBy(func(msg *models.Message) bool {
+		return msg.Content == "Пользователь назначил(а) исполнителем задачи #12 «Release» пользователя Пользователь"
+	})).Return(nil)
+
+	err := controller.HandleTaskEvent(event)
+
+	require.NoError(t, err)
+	m.messageRepo.AssertExpectations(t)
+}
+
+func TestTaskEventController_HandleTaskEvent_EscapesMarkdownInTitle(t *testing.T) {
+	controller, m := newTaskEventController()
+	event := testTaskDomainEvent(commonModels.TaskDomainEventStatusChanged)
+	event.TaskTitle = "[x](javascript:1) **@all**"
+	event.OldValue, event.NewValue = "created", "`done`"
+
+	m.settingsRepo.On("GetByChatID", event.ChatID).Return(nil, gorm.ErrRecordNotFound)
+	m.chatRepo.On("GetChatByID", event.ChatID).Return(createTestChat(), nil)
+	m.messageRepo.On("GetMessageByEventID", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	m.userClient.On("GetUsersByIDs", []uuid.UUID{event.ActorID}).
+		Return(&cuc.UsersResponse{Users: []*cuc.User{{ID: event.ActorID, Username: "lead"}}}, nil)
+	m.messageRepo.On("CreateMessage", mock.MatchedBy(func(msg *models.Message) bool {
+		return msg.ContentHTML != nil && *msg.ContentHTML ==
+			`<span class="mention" data-username="lead">@lead</span> перевел(а) задачу #12 «[x](javascript:1) **@all**» из статуса «created» в «` + "`done`" + `»`
+	})).Return(nil)
+
+	err := controller.HandleTaskEvent(event)
+
+	require.NoError(t, err)
+	m.messageRepo.AssertExpectations(t)
+}
+
+func TestTaskEventController_HandleTaskEvent_OptedOut(t *testing.T) {
+	controller, m := newTaskEventController()
+	event := testTaskDomainEvent(commonModels.TaskDomainEventStatusChanged)
+	settings := models.DefaultChatTaskEventSettings(event.ChatID)
+	settings.TaskStatusChanged = false
+
+	m.settingsRepo.On("GetByChatID", event.ChatID).Return(settings, nil)
+
+	err := controller.HandleTaskEvent(event)
+
+	require.NoError(t, err)
+	m.messageRepo.AssertNotCalled(t, "CreateMessage", mock.Anything)
+	m.userClient.AssertNotCalled(t, "GetUsersByIDs", mock.Anything)
+}
+
+func TestTaskEventController_HandleTaskEvent_RedeliveryIsIgnored(t *testing.T) {
+	controller, m := newTaskEventController()
+	event := testTaskDomainEvent(commonModels.TaskDomainEventCreated)
+	event.EventID = commonModels.TaskCreatedEventID(12)
+
+	m.settingsRepo.On("GetByChatID", event.ChatID).Return(nil, gorm.ErrRecordNotFound)
+	m.chatRepo.On("GetChatByID", event.ChatID).Return(createTestChat(), nil)
+	m.userClient.On("GetUsersByIDs", mock.Anything).Return(&cuc.UsersResponse{}, nil)
+	m.messageRepo.On("GetMessageByEventID", "task:12:created").Return(&models.Message{ID: uuid.New()}, nil)
+
+	err := controller.HandleTaskEvent(event)
+
+	require.NoError(t, err)
+	m.messageRepo.AssertNotCalled(t, "CreateMessage", mock.Anything)
+}
+
+func TestTaskEventController_HandleTaskEvent_ChatDeleted(t *testing.T) {
+	controller, m := newTaskEventController()
+	event := testTaskDomainEvent(commonModels.TaskDomainEventCreated)
+
+	m.settingsRepo.On("GetByChatID", event.ChatID).Return(nil, gorm.ErrRecordNotFound)
+	m.userClient.On("GetUsersByIDs", mock.Anything).Return(&cuc.UsersResponse{}, nil)
+	m.chatRepo.On("GetChatByID", event.ChatID).Return(nil, gorm.ErrRecordNotFound)
+
+	err := controller.HandleTaskEvent(event)
+
+	require.NoError(t, err)
+	m.messageRepo.AssertNotCalled(t, "CreateMessage", mock.Anything)
+}
+
+func TestTaskEventController_HandleTaskEvent_SettingsDatabaseError(t *testing.T) {
+	controller, m := newTaskEventController()
+	event := testTaskDomainEvent(commonModels.TaskDomainEventCreated)
+
+	m.settingsRepo.On("GetByChatID", event.ChatID).Return(nil, errors.New("connection refused"))
+
+	err := controller.HandleTaskEvent(event)
+
+	var dbErr *custom_errors.DatabaseError
+	require.True(t, errors.As(err, &dbErr))
+	m.messageRepo.AssertNotCalled(t, "CreateMessage", mock.Anything)
+}
+
+// Тесты для настроек событий задач
+
+func TestTaskEventController_GetSettings_DefaultsToEnabled(t *testing.T) {
+	controller, m := newTaskEventController()
+	chatID := uuid.New()
+
+	m.settingsRepo.On("GetByChatID", chatID).Return(nil, gorm.ErrRecordNotFound)
+
+	settings, err := controller.GetSettings(chatID)
+
+	require.NoError(t, err)
+	assert.Equal(t, &dto.TaskEventSettingsResponse{TaskCreated: true, TaskStatusChanged: true, TaskReassigned: true}, settings)
+}
+
+func TestTaskEventController_UpdateSettings(t *testing.T) {
+	controller, m := newTaskEventController()
+	chatID := uuid.New()
+	enabled, disabled := true, false
+
+	m.settingsRepo.On("Save", mock.MatchedBy(func(s *models.ChatTaskEventSettings) bool {
+		return s.ChatID == chatID && s.TaskCreated && !s.TaskStatusChanged && s.TaskReassigned
+	})).Return(nil)
+
+	settings, err := controller.UpdateSettings(chatID, &dto.UpdateTaskEventSettingsDTO{
+		TaskCreated:       &enabled,
+		TaskStatusChanged: &disabled,
+		TaskReassigned:    &enabled,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, settings.TaskStatusChanged)
+	m.settingsRepo.AssertExpectations(t)
+}
@@ -0,0 +1,100 @@
+package formatting
+
+import (
+	"html"
+	"strings"
+	"testing"
+
+	"chatService/internal/formatting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"plain text", "hello", "hello"},
+		{"escapes html", `<script>alert("x")</script>`, "&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;"},
+		{"newlines", "a\nb", "a<br>b"},
+		{"bold", "**bold** text", "<strong>bold</strong> text"},
+		{"italic star", "*it*", "<em>it</em>"},
+		{"italic underscore", "_it_", "<em>it</em>"},
+		{"snake case is literal", "snake_case_name", "snake_case_name"},
+		{"lonely star is literal", "2 * 3 = 6", "2 * 3 = 6"},
+		{"nested italic in bold", "**a *b* c**", "<strong>a <em>b</em> c</strong>"},
+		{"inline code", "use `<b>` tag", "use <code>&lt;b&gt;</code> tag"},
+		{"markup inside code is literal", "`**x**`", "<code>**x**</code>"},
+		{
+			"link",
+			"[site](https://example.com/?a=1&b=2)",
+			`<a href="https://example.com/?a=1&amp;b=2" rel="noopener noreferrer nofollow" target="_blank">site</a>`,
+		},
+		{
+			"mailto link",
+			"[mail](mailto:user@example.com)",
+			`<a href="mailto:user@example.com" rel="noopener noreferrer nofollow" target="_blank">mail</a>`,
+		},
+		{"mention", "hi @john.doe!", `hi <span class="mention" data-username="john.doe">@john.doe</span>!`},
+		{"mention trailing dot", "@bob.", `<span class="mention" data-username="bob">@bob</span>.`},
+		{"email is not mention", "user@example.com", "user@example.com"},
+		{
+			"code block",
+			"before\n```go\nfmt.Println(\"<hi>\")\n```\nafter",
+			"before<pre><code class=\"language-go\">fmt.Println(&#34;&lt;hi&gt;&#34;)</code></pre>after",
+		},
+		{"code block with unsafe language", "```\"><x\ncode\n```", "<pre><code>code</code></pre>"},
+		{"escaped markup is literal", `\*a\* \[x\]\(y\) \@bob \\`, `*a* [x](y) @bob \`},
+		{"backslash before plain char is kept", `C:\dir`, `C:\dir`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatting.RenderMarkdown(tt.source)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenderMarkdown_UnclosedCodeBlock(t *testing.T) {
+	_, err := formatting.RenderMarkdown("```go\nfmt.Println()")
+	assert.ErrorIs(t, err, formatting.ErrUnclosedCodeBlock)
+}
+
+func TestRenderMarkdown_UnsafeLink(t *testing.T) {
+	for _, source := range []string{
+		"[x](javascript:alert(1))",
+		"[x](data:text/html;base64,PHNjcmlwdD4=)",
+		"**[x](JavaScript:alert(1))**",
+		"[x](https:///nohost)",
+	} {
+		_, err := formatting.RenderMarkdown(source)
+		assert.ErrorIs(t, err, formatting.ErrUnsafeLink, source)
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	for _, source := range []string{
+		"[x](javascript:1)",
+		"**bold** _it_ `code` @admin",
+		"```go\nfmt.Println()",
+		`back\slash\`,
+	} {
+		rendered, err := formatting.RenderMarkdown(formatting.EscapeMarkdown(source))
+
+		// Экранированный текст выводится без разметки
+		require.NoError(t, err, source)
+		assert.Equal(t, strings.ReplaceAll(html.EscapeString(source), "\n", "<br>"), rendered)
+	}
+}
+
+func TestMention(t *testing.T) {
+	assert.Equal(t, "@john.doe", formatting.Mention("john.doe"))
+
+	rendered, err := formatting.RenderMarkdown(formatting.Mention("x](javascript:1)"))
+	require.NoError(t, err)
+	assert.Equal(t, "@x](javascript:1)", rendered)
+}
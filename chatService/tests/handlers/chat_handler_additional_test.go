@@ -103,11 +103,13 @@ func TestChatHandler_GetChatMembers_AllErrorTypes(t *testing.T) {
 	}{
 		{"database error", custom_errors.NewDatabaseError("error"), http.StatusInternalServerError},
 		{"unknown error", errors.New("unknown"), http.StatusInternalServerError},
+		{"user service error", custom_errors.NewUserClientError("unavailable"), http.StatusBadGateway},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockController.On("GetChatMembers", chatID).Return(nil, tt.err)
+			mockController.ExpectedCalls = nil
+			mockController.On("GetChatMembers", chatID, mock.Anything).Return(nil, tt.err)
 
 			req, _ := http.NewRequest("GET", "/chats/"+chatID.String()+"/members", nil)
 			w := httptest.NewRecorder()
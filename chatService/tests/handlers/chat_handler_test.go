@@ -37,6 +37,14 @@ func (m *MockChatController) GetUserChats(userID uuid.UUID) (*[]dto.ChatResponse
 	return args.Get(0).(*[]dto.ChatResponse), args.Error(1)
 }
 
+func (m *MockChatController) GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 func (m *MockChatController) CreateChat(createDTO *dto.CreateChatDTO) (*uuid.UUID, error) {
 	args := m.Called(createDTO)
 	if args.Get(0) == nil {
@@ -84,12 +92,12 @@ func (m *MockChatController) GetChatByID(chatID uuid.UUID) (*dto.ChatResponse, e
 	return args.Get(0).(*dto.ChatResponse), args.Error(1)
 }
 
-func (m *MockChatController) GetChatMembers(chatID uuid.UUID) ([]models.ChatUser, error) {
-	args := m.Called(chatID)
+func (m *MockChatController) GetChatMembers(chatID uuid.UUID, filter *dto.ChatMembersFilter) (*dto.ChatMembersResponse, error) {
+	args := m.Called(chatID, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.ChatUser), args.Error(1)
+	return args.Get(0).(*dto.ChatMembersResponse), args.Error(1)
 }
 
 // Вспомогательные функции
@@ -308,8 +316,8 @@ func TestChatHandler_GetChatMembers_EmptyList(t *testing.T) {
 	handler := handlers.NewChatHandler(mockController)
 
 	chatID := uuid.New()
-	emptyMembers := []models.ChatUser{}
-	mockController.On("GetChatMembers", chatID).Return(emptyMembers, nil)
+	emptyMembers := &dto.ChatMembersResponse{Members: []dto.ChatMemberResponse{}}
+	mockController.On("GetChatMembers", chatID, mock.Anything).Return(emptyMembers, nil)
 
 	router := gin.New()
 	router.GET("/chats/:chat_id/members", handler.GetChatMembers)
@@ -319,9 +327,9 @@ func TestChatHandler_GetChatMembers_EmptyList(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	var resp []dto.ChatMemberResponse
+	var resp dto.ChatMembersResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Len(t, resp, 0)
+	assert.Len(t, resp.Members, 0)
 
 	mockController.AssertExpectations(t)
 }
@@ -1062,23 +1070,26 @@ func TestChatHandler_GetChatMembers_Success(t *testing.T) {
 	handler := handlers.NewChatHandler(mockController)
 
 	chatID := uuid.New()
-	chatUsers := []models.ChatUser{
-		{UserID: uuid.New(), RoleID: 1, Role: models.ChatRole{Name: "main"}},
+	members := &dto.ChatMembersResponse{
+		Members: []dto.ChatMemberResponse{{UserID: uuid.New().String(), RoleID: 1, RoleName: "main", Username: "user"}},
+		Total:   1,
 	}
+	expectedFilter := &dto.ChatMembersFilter{RoleName: "main", Search: "us", Offset: 10, Limit: 5}
 
-	mockController.On("GetChatMembers", chatID).Return(chatUsers, nil)
+	mockController.On("GetChatMembers", chatID, expectedFilter).Return(members, nil)
 
 	router := gin.New()
 	router.GET("/chats/:chat_id/members", handler.GetChatMembers)
 
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/chats/"+chatID.String()+"/members", nil)
+	req := httptest.NewRequest("GET", "/chats/"+chatID.String()+"/members?role=main&search=us&offset=10&limit=5", nil)
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	var resp []dto.ChatMemberResponse
+	var resp dto.ChatMembersResponse
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	assert.Len(t, resp, 1)
+	assert.Len(t, resp.Members, 1)
+	assert.Equal(t, int64(1), resp.Total)
 
 	mockController.AssertExpectations(t)
 }
@@ -1096,7 +1107,7 @@ func TestChatHandler_GetChatMembers_InvalidUUID(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	mockController.AssertNotCalled(t, "GetChatMembers", mock.Anything)
+	mockController.AssertNotCalled(t, "GetChatMembers", mock.Anything, mock.Anything)
 }
 
 func TestChatHandler_GetChatMembers_Error(t *testing.T) {
@@ -1105,7 +1116,7 @@ func TestChatHandler_GetChatMembers_Error(t *testing.T) {
 	handler := handlers.NewChatHandler(mockController)
 
 	chatID := uuid.New()
-	mockController.On("GetChatMembers", chatID).Return(nil, errors.New("db"))
+	mockController.On("GetChatMembers", chatID, mock.Anything).Return(nil, errors.New("db"))
 
 	router := gin.New()
 	router.GET("/chats/:chat_id/members", handler.GetChatMembers)
@@ -1117,3 +1128,44 @@ func TestChatHandler_GetChatMembers_Error(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	mockController.AssertExpectations(t)
 }
+
+func TestChatHandler_GetUserChatIDs_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockChatController)
+	handler := handlers.NewChatHandler(mockController)
+
+	userID := uuid.New()
+	chatIDs := []uuid.UUID{uuid.New()}
+	mockController.On("GetUserChatIDs", userID).Return(chatIDs, nil)
+
+	router := gin.New()
+	router.GET("/chats/user/:user_id/memberships", handler.GetUserChatIDs)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/chats/user/"+userID.String()+"/memberships", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		ChatIDs []uuid.UUID `json:"chatIDs"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, chatIDs, resp.ChatIDs)
+	mockController.AssertExpectations(t)
+}
+
+func TestChatHandler_GetUserChatIDs_InvalidUUID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockChatController)
+	handler := handlers.NewChatHandler(mockController)
+
+	router := gin.New()
+	router.GET("/chats/user/:user_id/memberships", handler.GetUserChatIDs)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/chats/user/invalid/memberships", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetUserChatIDs", mock.Anything)
+}
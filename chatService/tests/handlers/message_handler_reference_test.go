@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"chatService/internal/custom_errors"
+	"chatService/internal/handlers"
+	"chatService/internal/handlers/dto"
+	"chatService/internal/models"
+	cc "common/contracts/chat-contracts"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newMessageReferenceRouter(controller *MockMessageController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewMessageHandler(controller)
+
+	router := gin.New()
+	router.GET("/chats/messages/by-id/:message_id", handler.GetMessageByID)
+	router.POST("/chats/:chat_id/system-messages", handler.CreateSystemMessage)
+	return router
+}
+
+func TestMessageHandler_GetMessageByID_ReturnsFileIDs(t *testing.T) {
+	mockController := new(MockMessageController)
+	router := newMessageReferenceRouter(mockController)
+	userID := uuid.New()
+	message := createTestMessageModel()
+	message.Files = []models.MessageFile{{MessageID: message.ID, FileID: 3}, {MessageID: message.ID, FileID: 8}}
+
+	mockController.On("GetMessageByID", userID, message.ID).Return(message, nil)
+
+	req := httptest.NewRequest("GET", "/chats/messages/by-id/"+message.ID.String(), nil)
+	req.Header.Set("X-User-ID", userID.String())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response cc.Message
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, message.ChatID, response.ChatID)
+	assert.Equal(t, "hi", response.Content)
+	assert.Equal(t, []int{3, 8}, response.FileIDs)
+}
+
+func TestMessageHandler_GetMessageByID_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "not found", err: custom_errors.ErrMessageNotFound, expectedCode: http.StatusNotFound},
+		{name: "no view_messages", err: custom_errors.ErrViewMessagesDenied, expectedCode: http.StatusForbidden},
+		{name: "database", err: custom_errors.NewDatabaseError("timeout"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockMessageController)
+			router := newMessageReferenceRouter(mockController)
+			mockController.On("GetMessageByID", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			req := httptest.NewRequest("GET", "/chats/messages/by-id/"+uuid.New().String(), nil)
+			req.Header.Set("X-User-ID", uuid.New().String())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestMessageHandler_GetMessageByID_MissingUser(t *testing.T) {
+	mockController := new(MockMessageController)
+	router := newMessageReferenceRouter(mockController)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/chats/messages/by-id/"+uuid.New().String(), nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetMessageByID", mock.Anything, mock.Anything)
+}
+
+func TestMessageHandler_CreateSystemMessage(t *testing.T) {
+	mockController := new(MockMessageController)
+	router := newMessageReferenceRouter(mockController)
+	chatID := uuid.New()
+	taskID := 12
+
+	mockController.On("CreateSystemMessage", chatID, &dto.CreateSystemMessageDTO{Content: "Создана задача #12", TaskID: &taskID}).
+		Return(&models.Message{ID: uuid.New(), ChatID: chatID, Content: "Создана задача #12", TaskID: &taskID}, nil)
+
+	req := httptest.NewRequest("POST", "/chats/"+chatID.String()+"/system-messages",
+		bytes.NewBufferString(`{"content":"Создана задача #12","task_id":12}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestMessageHandler_CreateSystemMessage_ChatNotFound(t *testing.T) {
+	mockController := new(MockMessageController)
+	router := newMessageReferenceRouter(mockController)
+	mockController.On("CreateSystemMessage", mock.Anything, mock.Anything).Return(nil, custom_errors.ErrChatNotFound)
+
+	req := httptest.NewRequest("POST", "/chats/"+uuid.New().String()+"/system-messages", bytes.NewBufferString(`{"content":"text"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
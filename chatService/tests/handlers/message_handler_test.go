@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"chatService/internal/custom_errors"
@@ -49,6 +50,22 @@ func (m *MockMessageController) SearchMessages(userID, chatID uuid.UUID, query s
 	return args.Get(0).(*ac.GetSearchResponse), args.Error(1)
 }
 
+func (m *MockMessageController) GetMessageByID(userID, messageID uuid.UUID) (*models.Message, error) {
+	args := m.Called(userID, messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Message), args.Error(1)
+}
+
+func (m *MockMessageController) CreateSystemMessage(chatID uuid.UUID, dto *dto.CreateSystemMessageDTO) (*models.Message, error) {
+	args := m.Called(chatID, dto)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Message), args.Error(1)
+}
+
 // helpers
 func createTestMessageModel() *models.Message {
 	id := uuid.New()
@@ -102,6 +119,93 @@ func TestMessageHandler_SendMessage_Success(t *testing.T) {
 	mockController.AssertExpectations(t)
 }
 
+func TestMessageHandler_SendMessage_IdempotencyKeyHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockMessageController)
+	handler := handlers.NewMessageHandler(mockController)
+
+	chatID := uuid.New()
+	senderID := uuid.New()
+	msg := createTestMessageModel()
+	payload, _ := json.Marshal(dto.CreateMessageDTO{Content: "hi"})
+
+	mockController.On("SendMessage", senderID, chatID, mock.MatchedBy(func(d *dto.CreateMessageDTO) bool {
+		return d.ClientID != nil && *d.ClientID == "retry-key"
+	})).Return(msg, nil)
+
+	router := gin.New()
+	router.POST("/chats/messages/:chat_id", handler.SendMessage)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/chats/messages/"+chatID.String(), bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", senderID.String())
+	req.Header.Set("Idempotency-Key", "retry-key")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendMessage_NormalizesContent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockMessageController)
+	handler := handlers.NewMessageHandler(mockController)
+
+	chatID := uuid.New()
+	senderID := uuid.New()
+	payload, _ := json.Marshal(dto.CreateMessageDTO{Content: "  hi\x00\r\nthere\u202e\t!  "})
+
+	mockController.On("SendMessage", senderID, chatID, mock.MatchedBy(func(d *dto.CreateMessageDTO) bool {
+		return d.Content == "hi\nthere\t!"
+	})).Return(createTestMessageModel(), nil)
+
+	router := gin.New()
+	router.POST("/chats/messages/:chat_id", handler.SendMessage)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/chats/messages/"+chatID.String(), bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", senderID.String())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendMessage_InvalidContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"only whitespace and control characters", " \x01\r\n "},
+		{"too long", strings.Repeat("a", 4001)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockController := new(MockMessageController)
+			handler := handlers.NewMessageHandler(mockController)
+
+			chatID := uuid.New()
+			payload, _ := json.Marshal(dto.CreateMessageDTO{Content: tt.content})
+
+			router := gin.New()
+			router.POST("/chats/messages/:chat_id", handler.SendMessage)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/chats/messages/"+chatID.String(), bytes.NewBuffer(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-User-ID", uuid.New().String())
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockController.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestMessageHandler_SendMessage_InvalidUserID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockController := new(MockMessageController)
@@ -164,6 +268,7 @@ func TestMessageHandler_SendMessage_ControllerErrors(t *testing.T) {
 		{"get file http", custom_errors.NewGetFileHTTPError(1, "err"), http.StatusBadGateway},
 		{"db error", custom_errors.NewDatabaseError("db"), http.StatusInternalServerError},
 		{"user err", custom_errors.NewUserClientError("err"), http.StatusBadRequest},
+		{"format err", custom_errors.NewMessageFormatError("err"), http.StatusBadRequest},
 		{"unknown", errors.New("unknown"), http.StatusInternalServerError},
 	}
 
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"chatService/internal/custom_errors"
+	"chatService/internal/handlers"
+	"chatService/internal/handlers/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockSavedMessageController - мок для SavedMessageControllerInterface
+type MockSavedMessageController struct {
+	mock.Mock
+}
+
+func (m *MockSavedMessageController) SaveMessage(userID, messageID uuid.UUID, saveDTO *dto.SaveMessageDTO) (*dto.SavedMessageResponse, error) {
+	args := m.Called(userID, messageID, saveDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.SavedMessageResponse), args.Error(1)
+}
+
+func (m *MockSavedMessageController) GetSavedMessages(userID uuid.UUID, offset, limit int) (*dto.GetSavedMessagesResponse, error) {
+	args := m.Called(userID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.GetSavedMessagesResponse), args.Error(1)
+}
+
+func (m *MockSavedMessageController) DeleteSavedMessage(userID, messageID uuid.UUID) error {
+	args := m.Called(userID, messageID)
+	return args.Error(0)
+}
+
+func setupSavedMessageRouter(controller *MockSavedMessageController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewSavedMessageHandler(controller)
+	router := gin.New()
+	router.GET("/chats/saved-messages", handler.GetSavedMessages)
+	router.POST("/chats/saved-messages/:message_id", handler.SaveMessage)
+	router.DELETE("/chats/saved-messages/:message_id", handler.DeleteSavedMessage)
+	return router
+}
+
+func TestSavedMessageHandler_SaveMessage_Success(t *testing.T) {
+	mockController := new(MockSavedMessageController)
+	router := setupSavedMessageRouter(mockController)
+
+	userID, messageID := uuid.New(), uuid.New()
+	note := "прочитать позже"
+	mockController.On("SaveMessage", userID, messageID, mock.MatchedBy(func(d *dto.SaveMessageDTO) bool {
+		return d.Note != nil && *d.Note == note
+	})).Return(&dto.SavedMessageResponse{MessageID: messageID, Note: &note, Available: true}, nil)
+
+	payload, _ := json.Marshal(dto.SaveMessageDTO{Note: &note})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/chats/saved-messages/"+messageID.String(), bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", userID.String())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestSavedMessageHandler_SaveMessage_WithoutBody(t *testing.T) {
+	mockController := new(MockSavedMessageController)
+	router := setupSavedMessageRouter(mockController)
+
+	userID, messageID := uuid.New(), uuid.New()
+	mockController.On("SaveMessage", userID, messageID, &dto.SaveMessageDTO{}).
+		Return(&dto.SavedMessageResponse{MessageID: messageID, Available: true}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/chats/saved-messages/"+messageID.String(), nil)
+	req.Header.Set("X-User-ID", userID.String())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestSavedMessageHandler_SaveMessage_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{"message not found", custom_errors.ErrMessageNotFound, http.StatusNotFound},
+		{"no access to chat", custom_errors.ErrUnauthorizedChat, http.StatusForbidden},
+		{"file service error", custom_errors.NewGetFileHTTPError(1, "timeout"), http.StatusBadGateway},
+		{"database error", custom_errors.NewDatabaseError("boom"), http.StatusInternalServerError},
+		{"unknown error", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockSavedMessageController)
+			router := setupSavedMessageRouter(mockController)
+
+			mockController.On("SaveMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/chats/saved-messages/"+uuid.New().String(), nil)
+			req.Header.Set("X-User-ID", uuid.New().String())
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestSavedMessageHandler_SaveMessage_InvalidIDs(t *testing.T) {
+	mockController := new(MockSavedMessageController)
+	router := setupSavedMessageRouter(mockController)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/chats/saved-messages/"+uuid.New().String(), nil)
+	req.Header.Set("X-User-ID", "invalid")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/chats/saved-messages/invalid", nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockController.AssertNotCalled(t, "SaveMessage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSavedMessageHandler_GetSavedMessages_Success(t *testing.T) {
+	mockController := new(MockSavedMessageController)
+	router := setupSavedMessageRouter(mockController)
+
+	userID := uuid.New()
+	mockController.On("GetSavedMessages", userID, 10, 5).Return(&dto.GetSavedMessagesResponse{
+		SavedMessages: []dto.SavedMessageResponse{{MessageID: uuid.New(), Available: false}},
+		Total:         11,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/chats/saved-messages?offset=10&limit=5", nil)
+	req.Header.Set("X-User-ID", userID.String())
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response dto.GetSavedMessagesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(11), response.Total)
+	assert.Len(t, response.SavedMessages, 1)
+	assert.Nil(t, response.SavedMessages[0].Message)
+}
+
+func TestSavedMessageHandler_GetSavedMessages_InvalidPagination(t *testing.T) {
+	mockController := new(MockSavedMessageController)
+	router := setupSavedMessageRouter(mockController)
+
+	for _, query := range []string{"?offset=-1", "?limit=0", "?limit=abc"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/chats/saved-messages"+query, nil)
+		req.Header.Set("X-User-ID", uuid.New().String())
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	mockController.AssertNotCalled(t, "GetSavedMessages", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSavedMessageHandler_DeleteSavedMessage(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"not saved", custom_errors.ErrSavedMessageNotFound, http.StatusNotFound},
+		{"database error", custom_errors.NewDatabaseError("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockSavedMessageController)
+			router := setupSavedMessageRouter(mockController)
+
+			userID, messageID := uuid.New(), uuid.New()
+			mockController.On("DeleteSavedMessage", userID, messageID).Return(tt.err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/chats/saved-messages/"+messageID.String(), nil)
+			req.Header.Set("X-User-ID", userID.String())
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
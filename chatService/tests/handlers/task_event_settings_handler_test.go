@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"chatService/internal/handlers"
+	"chatService/internal/handlers/dto"
+	"common/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTaskEventController - мок для TaskEventControllerInterface
+type MockTaskEventController struct {
+	mock.Mock
+}
+
+func (m *MockTaskEventController) HandleTaskEvent(event *models.TaskDomainEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockTaskEventController) GetSettings(chatID uuid.UUID) (*dto.TaskEventSettingsResponse, error) {
+	args := m.Called(chatID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TaskEventSettingsResponse), args.Error(1)
+}
+
+func (m *MockTaskEventController) UpdateSettings(chatID uuid.UUID, settingsDTO *dto.UpdateTaskEventSettingsDTO) (*dto.TaskEventSettingsResponse, error) {
+	args := m.Called(chatID, settingsDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TaskEventSettingsResponse), args.Error(1)
+}
+
+func setupTaskEventSettingsRouter(controller *MockTaskEventController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskEventSettingsHandler(controller)
+	router := gin.New()
+	router.GET("/chats/:chat_id/task-event-settings", handler.GetSettings)
+	router.PUT("/chats/:chat_id/task-event-settings", handler.UpdateSettings)
+	return router
+}
+
+func TestTaskEventSettingsHandler_GetSettings_Success(t *testing.T) {
+	mockController := new(MockTaskEventController)
+	router := setupTaskEventSettingsRouter(mockController)
+	chatID := uuid.New()
+
+	mockController.On("GetSettings", chatID).
+		Return(&dto.TaskEventSettingsResponse{TaskCreated: true, TaskStatusChanged: false, TaskReassigned: true}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/chats/"+chatID.String()+"/task-event-settings", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]bool
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, map[string]bool{"taskCreated": true, "taskStatusChanged": false, "taskReassigned": true}, response)
+}
+
+func TestTaskEventSettingsHandler_GetSettings_InvalidChatID(t *testing.T) {
+	mockController := new(MockTaskEventController)
+	router := setupTaskEventSettingsRouter(mockController)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/chats/invalid/task-event-settings", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetSettings", mock.Anything)
+}
+
+func TestTaskEventSettingsHandler_UpdateSettings_Success(t *testing.T) {
+	mockController := new(MockTaskEventController)
+	router := setupTaskEventSettingsRouter(mockController)
+	chatID := uuid.New()
+
+	mockController.On("UpdateSettings", chatID, mock.MatchedBy(func(settingsDTO *dto.UpdateTaskEventSettingsDTO) bool {
+		return *settingsDTO.TaskCreated && !*settingsDTO.TaskStatusChanged && !*settingsDTO.TaskReassigned
+	})).Return(&dto.TaskEventSettingsResponse{TaskCreated: true}, nil)
+
+	body := []byte(`{"taskCreated":true,"taskStatusChanged":false,"taskReassigned":false}`)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/chats/"+chatID.String()+"/task-event-settings", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskEventSettingsHandler_UpdateSettings_MissingField(t *testing.T) {
+	mockController := new(MockTaskEventController)
+	router := setupTaskEventSettingsRouter(mockController)
+
+	body := []byte(`{"taskCreated":false}`)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/chats/"+uuid.New().String()+"/task-event-settings", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "UpdateSettings", mock.Anything, mock.Anything)
+}
+
+func TestTaskEventSettingsHandler_UpdateSettings_DatabaseError(t *testing.T) {
+	mockController := new(MockTaskEventController)
+	router := setupTaskEventSettingsRouter(mockController)
+
+	mockController.On("UpdateSettings", mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	body := []byte(`{"taskCreated":true,"taskStatusChanged":true,"taskReassigned":true}`)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/chats/"+uuid.New().String()+"/task-event-settings", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -31,6 +31,22 @@ func setupTestHTTPClients(t *testing.T) (userServer *httptest.Server, fileServer
 
 	// Тестовый сервер для User Service
 	userServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/users/batch" {
+			var req cuc.GetUsersByIDsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			response := cuc.UsersResponse{}
+			for _, id := range req.IDs {
+				response.Users = append(response.Users, &cuc.User{
+					ID:       id,
+					Username: "test_user_" + id.String()[:8],
+					Email:    "test_" + id.String()[:8] + "@example.com",
+				})
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+
 		userID := r.URL.Path[len("/api/v1/users/"):]
 		parsedUUID, err := uuid.Parse(userID)
 		if err != nil {
@@ -104,6 +120,17 @@ func setupTestHTTPClientsWithErrors(t *testing.T, userError, fileError bool) (us
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
+		if r.URL.Path == "/api/v1/users/batch" {
+			var req cuc.GetUsersByIDsRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			response := cuc.UsersResponse{}
+			for _, id := range req.IDs {
+				response.Users = append(response.Users, &cuc.User{ID: id, Username: "test_user", Email: "test@example.com"})
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(response)
+			return
+		}
 		userID := r.URL.Path[len("/api/v1/users/"):]
 		parsedUUID, _ := uuid.Parse(userID)
 		response := cuc.Response{
@@ -179,6 +206,7 @@ func TestChatController_GetChatByID_Integration(t *testing.T) {
 		nil, // NotificationService не нужен для GetChatByID
 		fileClient,
 		http_clients.NewUserClientAdapter(),
+		repositories.NewUnitOfWork(db),
 	)
 
 	// Act
@@ -209,6 +237,7 @@ func TestChatController_GetChatByID_Integration_NotFound(t *testing.T) {
 		chatUserRepo,
 		chatRoleRepo,
 		nil,
+		repositories.NewUnitOfWork(db),
 	)
 
 	nonExistentChatID := uuid.New()
@@ -268,6 +297,7 @@ func TestChatController_GetUserChats_Integration(t *testing.T) {
 		nil,
 		fileClient,
 		http_clients.NewUserClientAdapter(),
+		repositories.NewUnitOfWork(db),
 	)
 
 	// Act
@@ -309,6 +339,7 @@ func TestChatController_CreateChat_Integration(t *testing.T) {
 		notificationService,
 		fileClient,
 		userClient,
+		repositories.NewUnitOfWork(db),
 	)
 
 	ownerID := uuid.New()
@@ -366,6 +397,7 @@ func TestChatController_CreateChat_Integration_WithoutUsers(t *testing.T) {
 		notificationService,
 		fileClient,
 		userClient,
+		repositories.NewUnitOfWork(db),
 	)
 
 	ownerID := uuid.New()
@@ -422,6 +454,7 @@ func TestChatController_CreateChat_Integration_UserNotFound(t *testing.T) {
 		notificationService,
 		fileClient,
 		userClient,
+		repositories.NewUnitOfWork(db),
 	)
 
 	ownerID := uuid.New()
@@ -473,6 +506,7 @@ func TestChatController_CreateChat_Integration_FileNotFound(t *testing.T) {
 		notificationService,
 		fileClient,
 		userClient,
+		repositories.NewUnitOfWork(db),
 	)
 
 	ownerID := uuid.New()
@@ -532,6 +566,7 @@ func TestChatController_UpdateChat_Integration(t *testing.T) {
 		notificationService,
 		fileClient,
 		userClient,
+		repositories.NewUnitOfWork(db),
 	)
 
 	newName := "test_UpdateChat_Integration_New"
@@ -598,6 +633,7 @@ func TestChatController_UpdateChat_Integration_AddUsers(t *testing.T) {
 		notificationService,
 		fileClient,
 		userClient,
+		repositories.NewUnitOfWork(db),
 	)
 
 	newUserID := uuid.New()
@@ -675,6 +711,7 @@ func TestChatController_UpdateChat_Integration_RemoveUsers(t *testing.T) {
 		notificationService,
 		fileClient,
 		userClient,
+		repositories.NewUnitOfWork(db),
 	)
 
 	updateDTO := &dto.UpdateChatDTO{
@@ -715,6 +752,7 @@ func TestChatController_UpdateChat_Integration_ChatNotFound(t *testing.T) {
 		chatUserRepo,
 		chatRoleRepo,
 		nil,
+		repositories.NewUnitOfWork(db),
 	)
 
 	nonExistentChatID := uuid.New()
@@ -770,6 +808,7 @@ func TestChatController_DeleteChat_Integration(t *testing.T) {
 		chatUserRepo,
 		chatRoleRepo,
 		nil,
+		repositories.NewUnitOfWork(db),
 	)
 
 	// Act
@@ -826,6 +865,7 @@ func TestChatController_ChangeUserRole_Integration(t *testing.T) {
 		chatUserRepo,
 		chatRoleRepo,
 		nil,
+		repositories.NewUnitOfWork(db),
 	)
 
 	// Act
@@ -869,6 +909,7 @@ func TestChatController_ChangeUserRole_Integration_UserNotInChat(t *testing.T) {
 		chatUserRepo,
 		chatRoleRepo,
 		nil,
+		repositories.NewUnitOfWork(db),
 	)
 
 	nonExistentUserID := uuid.New()
@@ -920,6 +961,7 @@ func TestChatController_BanUser_Integration(t *testing.T) {
 		chatUserRepo,
 		chatRoleRepo,
 		nil,
+		repositories.NewUnitOfWork(db),
 	)
 
 	// Act
@@ -987,6 +1029,7 @@ func TestChatController_GetUserRoleInChat_Integration(t *testing.T) {
 		chatUserRepo,
 		chatRoleRepo,
 		nil,
+		repositories.NewUnitOfWork(db),
 	)
 
 	// Act
@@ -1023,6 +1066,7 @@ func TestChatController_GetUserRoleInChat_Integration_Unauthorized(t *testing.T)
 		chatUserRepo,
 		chatRoleRepo,
 		nil,
+		repositories.NewUnitOfWork(db),
 	)
 
 	requesterID := uuid.New()
@@ -1076,6 +1120,7 @@ func TestChatController_GetMyRoleWithPermissions_Integration(t *testing.T) {
 		chatUserRepo,
 		chatRoleRepo,
 		nil,
+		repositories.NewUnitOfWork(db),
 	)
 
 	// Act
@@ -1124,21 +1169,32 @@ func TestChatController_GetChatMembers_Integration(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	controller := controllers.NewChatController(
+	userServer, _, userClient, fileClient := setupTestHTTPClients(t)
+	defer userServer.Close()
+
+	controller := controllers.NewChatControllerWithClients(
 		chatRepo,
 		chatUserRepo,
 		chatRoleRepo,
 		nil,
+		fileClient,
+		userClient,
+		repositories.NewUnitOfWork(db),
 	)
 
 	// Act
-	members, err := controller.GetChatMembers(chat.ID)
+	members, err := controller.GetChatMembers(chat.ID, &dto.ChatMembersFilter{Limit: 2})
+	require.NoError(t, err)
+	owners, err := controller.GetChatMembers(chat.ID, &dto.ChatMembersFilter{RoleName: "owner", Limit: 50})
+	require.NoError(t, err)
 
 	// Assert
-	require.NoError(t, err)
-	assert.Len(t, members, 3)
-	for _, member := range members {
-		assert.Equal(t, chat.ID, member.ChatID)
-		assert.NotNil(t, member.Role)
+	assert.Equal(t, int64(3), members.Total)
+	assert.Len(t, members.Members, 2)
+	for _, member := range members.Members {
+		assert.Equal(t, "main", member.RoleName)
+		assert.NotEmpty(t, member.Username)
 	}
+	assert.Equal(t, int64(0), owners.Total)
+	assert.Empty(t, owners.Members)
 }
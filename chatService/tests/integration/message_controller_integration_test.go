@@ -289,6 +289,52 @@ func TestMessageController_SendMessage_Integration_FileNotFound(t *testing.T) {
 	assert.Nil(t, message)
 }
 
+// TestMessageRepository_CreateMessageWithFiles_Integration проверяет, что сбой на вложениях откатывает сообщение
+func TestMessageRepository_CreateMessageWithFiles_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Arrange
+	db := setupTestDB(t)
+	messageRepo := repositories.NewMessageRepository(db)
+	chatRepo := repositories.NewChatRepository(db)
+
+	chat := &models.Chat{
+		ID:      uuid.New(),
+		Name:    "test_CreateMessageWithFiles_Integration",
+		IsGroup: true,
+	}
+	require.NoError(t, chatRepo.CreateChat(chat))
+
+	// Отправитель должен состоять в чате (внешний ключ сообщений)
+	mainRole, err := repositories.NewChatRoleRepository(db).GetRoleByName("main")
+	require.NoError(t, err)
+	senderID := uuid.New()
+	require.NoError(t, repositories.NewChatUserRepository(db).AddUserToChat(&models.ChatUser{
+		ChatID: chat.ID,
+		UserID: senderID,
+		RoleID: mainRole.ID,
+	}))
+
+	// Act: повтор ID файла нарушает первичный ключ message_files на второй вставке
+	failed := &models.Message{ID: uuid.New(), ChatID: chat.ID, SenderID: &senderID, Content: "test_CreateMessageWithFiles_failed"}
+	errFailed := messageRepo.CreateMessageWithFiles(failed, []int{1, 1})
+
+	saved := &models.Message{ID: uuid.New(), ChatID: chat.ID, SenderID: &senderID, Content: "test_CreateMessageWithFiles_saved"}
+	errSaved := messageRepo.CreateMessageWithFiles(saved, []int{1, 2})
+
+	// Assert
+	require.Error(t, errFailed)
+	_, err = messageRepo.GetMessageWithFile(failed.ID)
+	assert.Error(t, err)
+
+	require.NoError(t, errSaved)
+	stored, err := messageRepo.GetMessageWithFile(saved.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Files, 2)
+}
+
 // TestMessageController_GetChatMessages_Integration тестирует получение сообщений чата с реальной интеграцией FileClient
 func TestMessageController_GetChatMessages_Integration(t *testing.T) {
 	if testing.Short() {
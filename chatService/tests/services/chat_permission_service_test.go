@@ -59,6 +59,30 @@ func (m *MockChatUserRepositoryForPermissionService) GetChatUsers(chatID uuid.UU
 	return args.Get(0).([]models.ChatUser), args.Error(1)
 }
 
+func (m *MockChatUserRepositoryForPermissionService) GetChatUsersFiltered(chatID uuid.UUID, roleName string, userIDs []uuid.UUID, offset, limit int) ([]models.ChatUser, int64, error) {
+	args := m.Called(chatID, roleName, userIDs, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]models.ChatUser), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockChatUserRepositoryForPermissionService) GetChatUserIDs(chatID uuid.UUID, roleName string) ([]uuid.UUID, error) {
+	args := m.Called(chatID, roleName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockChatUserRepositoryForPermissionService) GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 func (m *MockChatUserRepositoryForPermissionService) RemoveUserFromChat(chatID, userID uuid.UUID) error {
 	args := m.Called(chatID, userID)
 	return args.Error(0)
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"chatService/internal/custom_errors"
+	"chatService/internal/services"
+	"common/models"
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTaskEventHandler - мок для TaskEventHandler
+type MockTaskEventHandler struct {
+	mock.Mock
+}
+
+func (m *MockTaskEventHandler) HandleTaskEvent(event *models.TaskDomainEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+// fakeSession - сессия consumer group, запоминающая зафиксированные смещения
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []int64
+}
+
+func (s *fakeSession) Context() context.Context { return s.ctx }
+
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+// fakeClaim - партиция с заранее подготовленными сообщениями
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func newFakeClaim(messages ...*sarama.ConsumerMessage) *fakeClaim {
+	claim := &fakeClaim{messages: make(chan *sarama.ConsumerMessage, len(messages))}
+	for _, message := range messages {
+		claim.messages <- message
+	}
+	close(claim.messages)
+	return claim
+}
+
+func taskEventMessage(t *testing.T, offset int64, event *models.TaskDomainEvent) *sarama.ConsumerMessage {
+	value, err := json.Marshal(event)
+	require.NoError(t, err)
+	return &sarama.ConsumerMessage{Topic: "task_events", Offset: offset, Value: value}
+}
+
+func testTaskEvent(eventID string) *models.TaskDomainEvent {
+	return &models.TaskDomainEvent{
+		EventID: eventID,
+		Type:    models.TaskDomainEventStatusChanged,
+		TaskID:  12,
+		ChatID:  uuid.New(),
+	}
+}
+
+func TestTaskEventConsumer_ProcessMessage(t *testing.T) {
+	handler := new(MockTaskEventHandler)
+	consumer := &services.TaskEventConsumer{Handler: handler}
+	event := testTaskEvent("task_event:40")
+
+	handler.On("HandleTaskEvent", mock.MatchedBy(func(e *models.TaskDomainEvent) bool {
+		return e.EventID == "task_event:40" && e.ChatID == event.ChatID && e.TaskID == 12
+	})).Return(nil)
+
+	err := consumer.ProcessMessage(taskEventMessage(t, 1, event))
+
+	require.NoError(t, err)
+	handler.AssertExpectations(t)
+}
+
+func TestTaskEventConsumer_ParseTaskEvent_Invalid(t *testing.T) {
+	consumer := &services.TaskEventConsumer{}
+
+	_, err := consumer.ParseTaskEvent(&sarama.ConsumerMessage{Value: []byte("not json")})
+	assert.Error(t, err)
+
+	_, err = consumer.ParseTaskEvent(&sarama.ConsumerMessage{Value: []byte(`{"task_id":12}`)})
+	assert.Error(t, err)
+}
+
+func TestTaskEventConsumer_ConsumeClaim_SkipsInvalidMessages(t *testing.T) {
+	handler := new(MockTaskEventHandler)
+	consumer := &services.TaskEventConsumer{Handler: handler}
+	session := &fakeSession{ctx: context.Background()}
+
+	handler.On("HandleTaskEvent", mock.Anything).Return(nil).Once()
+	claim := newFakeClaim(
+		&sarama.ConsumerMessage{Offset: 1, Value: []byte("not json")},
+		taskEventMessage(t, 2, testTaskEvent("task_event:41")),
+	)
+
+	err := consumer.ConsumeClaim(session, claim)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, session.marked)
+}
+
+func TestTaskEventConsumer_ConsumeClaim_FailedEventIsRedelivered(t *testing.T) {
+	handler := new(MockTaskEventHandler)
+	consumer := &services.TaskEventConsumer{Handler: handler}
+	session := &fakeSession{ctx: context.Background()}
+
+	handler.On("HandleTaskEvent", mock.MatchedBy(func(e *models.TaskDomainEvent) bool {
+		return e.EventID == "task_event:40"
+	})).Return(nil).Once()
+	handler.On("HandleTaskEvent", mock.MatchedBy(func(e *models.TaskDomainEvent) bool {
+		return e.EventID == "task_event:41"
+	})).Return(errors.New("db down")).Once()
+	claim := newFakeClaim(
+		taskEventMessage(t, 1, testTaskEvent("task_event:40")),
+		taskEventMessage(t, 2, testTaskEvent("task_event:41")),
+		taskEventMessage(t, 3, testTaskEvent("task_event:42")),
+	)
+
+	err := consumer.ConsumeClaim(session, claim)
+
+	// Смещение остановилось на необработанном событии: оно и следующие будут доставлены снова
+	require.Error(t, err)
+	assert.Equal(t, []int64{1}, session.marked)
+	handler.AssertNumberOfCalls(t, "HandleTaskEvent", 2)
+}
+
+func TestTaskEventConsumer_ConsumeClaim_SkipsUnpublishableEvent(t *testing.T) {
+	handler := new(MockTaskEventHandler)
+	consumer := &services.TaskEventConsumer{Handler: handler}
+	session := &fakeSession{ctx: context.Background()}
+
+	handler.On("HandleTaskEvent", mock.MatchedBy(func(e *models.TaskDomainEvent) bool {
+		return e.EventID == "task_event:40"
+	})).Return(custom_errors.NewMessageFormatError("links must use http, https or mailto scheme")).Once()
+	handler.On("HandleTaskEvent", mock.MatchedBy(func(e *models.TaskDomainEvent) bool {
+		return e.EventID == "task_event:41"
+	})).Return(nil).Once()
+	claim := newFakeClaim(
+		taskEventMessage(t, 1, testTaskEvent("task_event:40")),
+		taskEventMessage(t, 2, testTaskEvent("task_event:41")),
+	)
+
+	err := consumer.ConsumeClaim(session, claim)
+
+	// Ошибка формата не исправится повтором, поэтому событие пропускается и не блокирует партицию
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, session.marked)
+	handler.AssertExpectations(t)
+}
@@ -25,23 +25,26 @@ type CreateChatRequest struct {
 }
 
 type MessageResponse struct {
-	ID        uuid.UUID      `json:"id"`
-	ChatID    uuid.UUID      `json:"chatID"`
-	SenderID  uuid.UUID      `json:"senderID"`
-	Content   string         `json:"content"`
-	Files     []*MessageFile `json:"files,omitempty"`
-	CreatedAt time.Time      `json:"createdAt"`
-	UpdatedAt *time.Time     `json:"updatedAt,omitempty"`
+	ID          uuid.UUID      `json:"id"`
+	ChatID      uuid.UUID      `json:"chatID"`
+	SenderID    uuid.UUID      `json:"senderID"`
+	Content     string         `json:"content"`
+	ContentHTML *string        `json:"contentHTML,omitempty"`
+	Files       []*MessageFile `json:"files,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   *time.Time     `json:"updatedAt,omitempty"`
 }
 
 type GetChatMessage struct {
-	ID        uuid.UUID   `json:"id"`
-	ChatID    uuid.UUID   `json:"chatID"`
-	SenderID  *uuid.UUID  `json:"senderID"`
-	Content   string      `json:"content"`
-	UpdatedAt *time.Time  `json:"updatedAt"`
-	CreatedAt time.Time   `json:"createdAt"`
-	Files     *[]*fc.File `json:"files,omitempty"`
+	ID          uuid.UUID   `json:"id"`
+	ChatID      uuid.UUID   `json:"chatID"`
+	SenderID    *uuid.UUID  `json:"senderID"`
+	Content     string      `json:"content"`
+	ContentHTML *string     `json:"contentHTML,omitempty"`
+	TaskID      *int        `json:"taskID,omitempty"`
+	UpdatedAt   *time.Time  `json:"updatedAt"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	Files       *[]*fc.File `json:"files,omitempty"`
 }
 
 type GetSearchResponse struct {
@@ -55,8 +58,9 @@ type MessageFile struct {
 }
 
 type CreateMessageRequest struct {
-	Content string `json:"content"`
-	FileIDs []int  `json:"fileIDs,omitempty"`
+	Content  string  `json:"content"`
+	FileIDs  []int   `json:"fileIDs,omitempty"`
+	ClientID *string `json:"clientID,omitempty"`
 }
 
 type CreateChatServiceResponse struct {
@@ -103,9 +107,61 @@ type MyRoleResponse struct {
 	Permissions []ChatPermissionItem `json:"permissions"`
 }
 
-// ChatMember - участник чата
+// ChatMember - участник чата с данными профиля
 type ChatMember struct {
-	UserID   string `json:"userId"`
-	RoleID   int    `json:"roleId"`
-	RoleName string `json:"roleName"`
+	UserID     string   `json:"userId"`
+	RoleID     int      `json:"roleId"`
+	RoleName   string   `json:"roleName"`
+	Username   string   `json:"username,omitempty"`
+	AvatarFile *fc.File `json:"avatarFile,omitempty"`
+}
+
+// ChatMembersQuery - параметры выборки участников чата
+type ChatMembersQuery struct {
+	Offset int
+	Limit  int
+	Role   string
+	Search string
+}
+
+// ChatMembersResponse - страница участников чата
+type ChatMembersResponse struct {
+	Members []*ChatMember `json:"members"`
+	Total   int64         `json:"total"`
+}
+
+// SaveMessageRequest - запрос на добавление сообщения в закладки
+type SaveMessageRequest struct {
+	Note *string `json:"note,omitempty"`
+}
+
+// SavedMessage - закладка пользователя.
+// Если пользователь потерял доступ к чату, Available = false и Message не заполняется
+type SavedMessage struct {
+	MessageID uuid.UUID       `json:"messageID"`
+	ChatID    uuid.UUID       `json:"chatID"`
+	Note      *string         `json:"note"`
+	SavedAt   time.Time       `json:"savedAt"`
+	Available bool            `json:"available"`
+	Message   *GetChatMessage `json:"message,omitempty"`
+}
+
+// GetSavedMessagesResponse - страница закладок пользователя
+type GetSavedMessagesResponse struct {
+	SavedMessages []SavedMessage `json:"savedMessages"`
+	Total         int64          `json:"total"`
+}
+
+// TaskEventSettings - какие события привязанных к чату задач публикуются в него системными сообщениями
+type TaskEventSettings struct {
+	TaskCreated       bool `json:"taskCreated"`
+	TaskStatusChanged bool `json:"taskStatusChanged"`
+	TaskReassigned    bool `json:"taskReassigned"`
+}
+
+// UpdateTaskEventSettingsRequest - новые настройки событий задач чата; все поля обязательны
+type UpdateTaskEventSettingsRequest struct {
+	TaskCreated       *bool `json:"taskCreated" binding:"required"`
+	TaskStatusChanged *bool `json:"taskStatusChanged" binding:"required"`
+	TaskReassigned    *bool `json:"taskReassigned" binding:"required"`
 }
@@ -7,27 +7,85 @@ import (
 	"github.com/google/uuid"
 )
 
-// CreateTaskRequest - запрос на создание задачи (должен соответствовать CreateTaskDTO в taskService)
+// CreateTaskRequest - запрос на создание задачи (должен соответствовать CreateTaskDTO в taskService).
+// Создателем становится пользователь, от имени которого выполняется запрос
 type CreateTaskRequest struct {
-	Title       string    `json:"title" binding:"required"`
-	Description *string   `json:"description"`
-	CreatorID   uuid.UUID `json:"creator_id" binding:"required"`
-	ExecutorID  uuid.UUID `json:"executor_id"`
-	ChatID      uuid.UUID `json:"chat_id"`
-	FileIDs     []int     `json:"file_ids"`
+	Title        string     `json:"title" binding:"required"`
+	Description  *string    `json:"description"`
+	ExecutorID   uuid.UUID  `json:"executor_id"`
+	ChatID       uuid.UUID  `json:"chat_id"`
+	FileIDs      []int      `json:"file_ids"`
+	WorkflowID   *int       `json:"workflow_id,omitempty"`
+	ParentTaskID *int       `json:"parent_task_id,omitempty"`
+	Priority     string     `json:"priority,omitempty"`
+	StartAt      *time.Time `json:"start_at,omitempty"`
+	DueAt        *time.Time `json:"due_at,omitempty"`
+	// EstimateMinutes - оценка трудозатрат в минутах
+	EstimateMinutes *int `json:"estimate_minutes,omitempty"`
+}
+
+// CreateTaskFromMessageRequest - задача из сообщения чата (должен соответствовать CreateTaskFromMessageDTO в taskService)
+type CreateTaskFromMessageRequest struct {
+	MessageID  uuid.UUID  `json:"message_id" binding:"required"`
+	ExecutorID uuid.UUID  `json:"executor_id" binding:"required"`
+	Title      *string    `json:"title,omitempty" binding:"omitempty,max=255"`
+	WorkflowID *int       `json:"workflow_id,omitempty"`
+	Priority   string     `json:"priority,omitempty" binding:"omitempty,oneof=low normal high urgent"`
+	StartAt    *time.Time `json:"start_at,omitempty"`
+	DueAt      *time.Time `json:"due_at,omitempty"`
+}
+
+// UpdateTaskRequest - частичное обновление задачи (должен соответствовать UpdateTaskDTO в taskService)
+type UpdateTaskRequest struct {
+	Title             *string    `json:"title,omitempty"`
+	Description       *string    `json:"description,omitempty"`
+	ExecutorID        *uuid.UUID `json:"executor_id,omitempty"`
+	ChatID            *uuid.UUID `json:"chat_id,omitempty"`
+	AddFileIDs        []int      `json:"add_file_ids,omitempty"`
+	RemoveFileIDs     []int      `json:"remove_file_ids,omitempty"`
+	Priority          *string    `json:"priority,omitempty"`
+	StartAt           *time.Time `json:"start_at,omitempty"`
+	DueAt             *time.Time `json:"due_at,omitempty"`
+	ClearStartAt      bool       `json:"clear_start_at,omitempty"`
+	ClearDueAt        bool       `json:"clear_due_at,omitempty"`
+	EstimateMinutes   *int       `json:"estimate_minutes,omitempty"`
+	ClearEstimate     bool       `json:"clear_estimate,omitempty"`
+	ParentTaskID      *int       `json:"parent_task_id,omitempty"`
+	ClearParentTaskID bool       `json:"clear_parent_task_id,omitempty"`
 }
 
 // TaskResponse - ответ с задачей
 type TaskResponse struct {
-	ID          int        `json:"id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	CreatorID   uuid.UUID  `json:"creatorID"`
-	ExecutorID  *uuid.UUID `json:"executorID,omitempty"`
-	ChatID      *uuid.UUID `json:"chatID,omitempty"`
-	Status      TaskStatus `json:"status"`
-	Files       []TaskFile `json:"files,omitempty"`
-	CreatedAt   time.Time  `json:"createdAt"`
+	ID           int        `json:"id"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	CreatorID    uuid.UUID  `json:"creatorID"`
+	ExecutorID   *uuid.UUID `json:"executorID,omitempty"`
+	ChatID       *uuid.UUID `json:"chatID,omitempty"`
+	Status       TaskStatus `json:"status"`
+	Files        []TaskFile `json:"files,omitempty"`
+	WorkflowID   *int       `json:"workflowID,omitempty"`
+	ParentTaskID *int       `json:"parentTaskID,omitempty"`
+	Priority     string     `json:"priority"`
+	StartAt      *time.Time `json:"startAt,omitempty"`
+	DueAt        *time.Time `json:"dueAt,omitempty"`
+	// EstimateMinutes - оценка трудозатрат в минутах, если задана
+	EstimateMinutes *int       `json:"estimateMinutes,omitempty"`
+	RecurrenceID    *int       `json:"recurrenceID,omitempty"`
+	OccurrenceAt    *time.Time `json:"occurrenceAt,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       *time.Time `json:"updatedAt,omitempty"`
+	// Assignees - соисполнители в дополнение к ExecutorID, Watchers - наблюдатели задачи
+	Assignees []TaskMember `json:"assignees,omitempty"`
+	Watchers  []TaskMember `json:"watchers,omitempty"`
+	// Checklist - пункты чек-листа задачи по порядку
+	Checklist []TaskChecklistItem `json:"checklist,omitempty"`
+}
+
+// TaskMember - соисполнитель или наблюдатель задачи
+type TaskMember struct {
+	UserID    uuid.UUID `json:"userID"`
+	CreatedAt time.Time `json:"createdAt"`
 }
 
 type TaskFile struct {
@@ -41,14 +99,139 @@ type TaskStatus struct {
 	Name string `json:"name"`
 }
 
+// TaskStatusUsage - где используется статус задачи; Protected - статус нельзя удалить,
+// так как он стартовый для задач без workflow или используется в workflow
+type TaskStatusUsage struct {
+	StatusID     int                     `json:"statusID"`
+	Name         string                  `json:"name"`
+	Tasks        int64                   `json:"tasks"`
+	DeletedTasks int64                   `json:"deletedTasks"`
+	Workflows    []TaskStatusWorkflowRef `json:"workflows"`
+	Protected    bool                    `json:"protected"`
+}
+
+// TaskStatusWorkflowRef - workflow, в статусах или переходах которого есть статус
+type TaskStatusWorkflowRef struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
 // TaskToList - задача для списка
 type TaskToList struct {
-	ID        int       `json:"id"`
-	Title     string    `json:"title"`
-	Status    string    `json:"status"`
+	ID              int        `json:"id"`
+	Title           string     `json:"title"`
+	Status          string     `json:"status"`
+	Priority        string     `json:"priority"`
+	StartAt         *time.Time `json:"startAt,omitempty"`
+	DueAt           *time.Time `json:"dueAt,omitempty"`
+	EstimateMinutes *int       `json:"estimateMinutes,omitempty"`
+	// ChecklistProgress - доля выполненных пунктов чек-листа в процентах; отсутствует, если чек-листа нет
+	ChecklistProgress *int      `json:"checklistProgress,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// TaskQueryResult - страница поиска задач (должен соответствовать TaskQueryResult в taskService)
+type TaskQueryResult struct {
+	Tasks []TaskToList `json:"tasks"`
+	// Total - число задач, подходящих под фильтры, без учёта курсора и лимита
+	Total int64 `json:"total"`
+	// NextCursor - курсор следующей страницы; отсутствует на последней странице
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// TaskActivity - событие истории задачи (должен соответствовать TaskActivity в taskService).
+// EventType: created, status_changed, reassigned, edited, attachment_added, commented, blocker_added, blocker_removed
+type TaskActivity struct {
+	ID        int64     `json:"id"`
+	TaskID    int       `json:"taskID"`
+	TaskTitle string    `json:"taskTitle"`
+	ActorID   uuid.UUID `json:"actorID"`
+	EventType string    `json:"eventType"`
+	Field     *string   `json:"field,omitempty"`
+	OldValue  *string   `json:"oldValue,omitempty"`
+	NewValue  *string   `json:"newValue,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 }
 
+// TaskTreeNode - задача в дереве подзадач (должен соответствовать TaskTreeNode в taskService).
+// Closed - задача в статусе без исходящих переходов workflow, Progress - процент выполнения с учётом подзадач,
+// Hidden - задача скрыта от пользователя и передаётся без названия, статуса и исполнителя
+type TaskTreeNode struct {
+	ID           int             `json:"id"`
+	Title        string          `json:"title"`
+	ParentTaskID *int            `json:"parentTaskID,omitempty"`
+	Status       string          `json:"status"`
+	Priority     string          `json:"priority"`
+	ExecutorID   uuid.UUID       `json:"executorID"`
+	Closed       bool            `json:"closed"`
+	Hidden       bool            `json:"hidden,omitempty"`
+	Progress     int             `json:"progress"`
+	Subtasks     []*TaskTreeNode `json:"subtasks"`
+}
+
+// TaskDependencyGraph - задачи, которые прямо или транзитивно блокируют задачу или блокируются ею
+type TaskDependencyGraph struct {
+	TaskID int                  `json:"taskID"`
+	Nodes  []TaskGraphNode      `json:"nodes"`
+	Edges  []TaskDependencyEdge `json:"edges"`
+}
+
+// TaskGraphNode - задача в графе зависимостей; Hidden - задача скрыта от пользователя и передаётся без названия и статуса
+type TaskGraphNode struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Closed bool   `json:"closed"`
+	Hidden bool   `json:"hidden,omitempty"`
+}
+
+// TaskDependencyEdge - BlockerTaskID блокирует BlockedTaskID
+type TaskDependencyEdge struct {
+	BlockerTaskID int `json:"blockerTaskID"`
+	BlockedTaskID int `json:"blockedTaskID"`
+}
+
+// AddTaskDependencyRequest - новая блокирующая задача (должен соответствовать AddTaskDependencyDTO в taskService)
+type AddTaskDependencyRequest struct {
+	BlockerTaskID int `json:"blocker_task_id"`
+}
+
+// TaskComment - комментарий к задаче (должен соответствовать TaskComment в taskService)
+type TaskComment struct {
+	ID        int                  `json:"id"`
+	TaskID    int                  `json:"taskID"`
+	AuthorID  uuid.UUID            `json:"authorID"`
+	Body      string               `json:"body"`
+	CreatedAt time.Time            `json:"createdAt"`
+	UpdatedAt *time.Time           `json:"updatedAt,omitempty"`
+	Files     []TaskCommentFile    `json:"files"`
+	Mentions  []TaskCommentMention `json:"mentions"`
+}
+
+// TaskCommentFile - вложение комментария
+type TaskCommentFile struct {
+	FileID int `json:"fileID"`
+}
+
+// TaskCommentMention - пользователь, упомянутый в комментарии через @username
+type TaskCommentMention struct {
+	UserID uuid.UUID `json:"userID"`
+}
+
+// CreateTaskCommentRequest - новый комментарий (должен соответствовать CreateTaskCommentDTO в taskService)
+type CreateTaskCommentRequest struct {
+	Body    string `json:"body"`
+	FileIDs []int  `json:"file_ids,omitempty"`
+}
+
+// UpdateTaskCommentRequest - правка комментария (должен соответствовать UpdateTaskCommentDTO в taskService)
+type UpdateTaskCommentRequest struct {
+	Body          *string `json:"body,omitempty"`
+	AddFileIDs    []int   `json:"add_file_ids,omitempty"`
+	RemoveFileIDs []int   `json:"remove_file_ids,omitempty"`
+}
+
 // TaskListResponse - ответ со списком задач
 type TaskListResponse struct {
 	Tasks []TaskToList `json:"tasks"`
@@ -65,3 +248,307 @@ type TaskServiceResponse struct {
 type CreateStatusRequest struct {
 	Name string `json:"name" binding:"required"`
 }
+
+// TaskWorkflow - workflow задач: упорядоченные статусы и разрешённые переходы между ними
+type TaskWorkflow struct {
+	ID          int                      `json:"id"`
+	Name        string                   `json:"name"`
+	IsDefault   bool                     `json:"isDefault"`
+	CreatedAt   time.Time                `json:"createdAt"`
+	Statuses    []TaskWorkflowStatus     `json:"statuses"`
+	Transitions []TaskWorkflowTransition `json:"transitions"`
+}
+
+// TaskWorkflowStatus - статус в составе workflow
+type TaskWorkflowStatus struct {
+	StatusID int         `json:"statusID"`
+	Position int         `json:"position"`
+	Status   *TaskStatus `json:"status,omitempty"`
+}
+
+// TaskWorkflowTransition - разрешённый переход; AllowedRole: creator, executor или any
+type TaskWorkflowTransition struct {
+	FromStatusID int    `json:"fromStatusID"`
+	ToStatusID   int    `json:"toStatusID"`
+	AllowedRole  string `json:"allowedRole"`
+}
+
+// SaveWorkflowRequest - создание или полная замена workflow (должен соответствовать SaveWorkflowDTO в taskService)
+type SaveWorkflowRequest struct {
+	Name        string                      `json:"name" binding:"required,max=100"`
+	IsDefault   bool                        `json:"is_default"`
+	StatusIDs   []int                       `json:"status_ids" binding:"required,min=1"`
+	Transitions []WorkflowTransitionRequest `json:"transitions" binding:"dive"`
+}
+
+// WorkflowTransitionRequest - переход в запросе на сохранение workflow
+type WorkflowTransitionRequest struct {
+	FromStatusID int    `json:"from_status_id" binding:"required"`
+	ToStatusID   int    `json:"to_status_id" binding:"required"`
+	AllowedRole  string `json:"allowed_role" binding:"required,oneof=creator executor any"`
+}
+
+// Label - метка задач; UsageCount - число неудалённых задач, которым она назначена
+type Label struct {
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	Color      string    `json:"color"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UsageCount int64     `json:"usageCount"`
+}
+
+// TaskLabel - метка, назначенная задаче
+type TaskLabel struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SaveLabelRequest - создание или замена метки (должен соответствовать SaveLabelDTO в taskService)
+type SaveLabelRequest struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color" binding:"required,hexcolor"`
+}
+
+// TaskBoard - канбан-доска задач (должен соответствовать TaskBoard в taskService)
+type TaskBoard struct {
+	Columns []TaskBoardColumn `json:"columns"`
+}
+
+// TaskBoardColumn - колонка доски; Total - число задач в колонке без учёта лимита
+type TaskBoardColumn struct {
+	StatusID int             `json:"statusId"`
+	Status   string          `json:"status"`
+	Total    int64           `json:"total"`
+	Tasks    []TaskBoardCard `json:"tasks"`
+}
+
+// TaskBoardCard - карточка задачи; карточки в колонке упорядочены по Rank
+type TaskBoardCard struct {
+	TaskToList
+	Rank string `json:"rank"`
+}
+
+// MoveTaskRequest - перенос карточки в колонку status_id между after_task_id (выше) и before_task_id (ниже)
+// (должен соответствовать MoveTaskDTO в taskService)
+type MoveTaskRequest struct {
+	StatusID     int  `json:"status_id" binding:"required,min=1"`
+	AfterTaskID  *int `json:"after_task_id,omitempty" binding:"omitempty,min=1"`
+	BeforeTaskID *int `json:"before_task_id,omitempty" binding:"omitempty,min=1"`
+}
+
+// TaskRecurrence - серия повторяющихся задач: шаблон задачи и правило RRULE (должен соответствовать
+// TaskRecurrence в taskService); NextOccurrenceAt - начало следующего ещё не созданного экземпляра
+type TaskRecurrence struct {
+	ID               int        `json:"id"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description"`
+	CreatorID        uuid.UUID  `json:"creatorID"`
+	ExecutorID       uuid.UUID  `json:"executorID"`
+	ChatID           uuid.UUID  `json:"chatID"`
+	WorkflowID       *int       `json:"workflowID,omitempty"`
+	Priority         string     `json:"priority"`
+	Rule             string     `json:"rule"`
+	StartsAt         time.Time  `json:"startsAt"`
+	DueAfterMinutes  *int       `json:"dueAfterMinutes,omitempty"`
+	NextOccurrenceAt *time.Time `json:"nextOccurrenceAt,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        *time.Time `json:"updatedAt,omitempty"`
+}
+
+// SaveTaskRecurrenceRequest - создание или замена серии (должен соответствовать SaveTaskRecurrenceDTO в taskService)
+type SaveTaskRecurrenceRequest struct {
+	Title           string    `json:"title" binding:"required,max=255"`
+	Description     string    `json:"description"`
+	ExecutorID      uuid.UUID `json:"executor_id" binding:"required"`
+	ChatID          uuid.UUID `json:"chat_id"`
+	WorkflowID      *int      `json:"workflow_id,omitempty"`
+	Priority        string    `json:"priority,omitempty" binding:"omitempty,oneof=low normal high urgent"`
+	Rule            string    `json:"rule" binding:"required,max=255"`
+	StartsAt        time.Time `json:"starts_at" binding:"required"`
+	DueAfterMinutes *int      `json:"due_after_minutes,omitempty" binding:"omitempty,min=1"`
+}
+
+// TaskTimeLog - затраченное на задачу время (должен соответствовать TaskTimeLog в taskService).
+// EndedAt == nil у запущенного таймера
+type TaskTimeLog struct {
+	ID          int        `json:"id"`
+	TaskID      int        `json:"taskID"`
+	UserID      uuid.UUID  `json:"userID"`
+	StartedAt   time.Time  `json:"startedAt"`
+	EndedAt     *time.Time `json:"endedAt,omitempty"`
+	Minutes     int        `json:"minutes"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+// CreateTaskTimeLogRequest - ручная запись времени (должен соответствовать CreateTaskTimeLogDTO в taskService)
+type CreateTaskTimeLogRequest struct {
+	StartedAt   time.Time `json:"started_at" binding:"required"`
+	Minutes     int       `json:"minutes" binding:"required,min=1,max=1440"`
+	Description string    `json:"description" binding:"max=1000"`
+}
+
+// UpdateTaskTimeLogRequest - частичное обновление записи времени (должен соответствовать UpdateTaskTimeLogDTO в taskService)
+type UpdateTaskTimeLogRequest struct {
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	Minutes     *int       `json:"minutes,omitempty" binding:"omitempty,min=1,max=1440"`
+	Description *string    `json:"description,omitempty" binding:"omitempty,max=1000"`
+}
+
+// TimeSpent - строка отчёта о затраченном времени (должен соответствовать TimeSpent в taskService)
+type TimeSpent struct {
+	TaskID          *int       `json:"taskID,omitempty"`
+	TaskTitle       string     `json:"taskTitle,omitempty"`
+	EstimateMinutes *int       `json:"estimateMinutes,omitempty"`
+	UserID          *uuid.UUID `json:"userID,omitempty"`
+	ChatID          *uuid.UUID `json:"chatID,omitempty"`
+	Minutes         int64      `json:"minutes"`
+	Entries         int64      `json:"entries"`
+}
+
+// Действия массовой операции над задачами (должны соответствовать константам TaskBulk* в taskService)
+const (
+	TaskBulkChangeStatus = "change_status"
+	TaskBulkReassign     = "reassign"
+	TaskBulkAddLabels    = "add_labels"
+	TaskBulkDelete       = "delete"
+)
+
+// TaskBulkRequest - одно действие над списком задач (должен соответствовать TaskBulkDTO в taskService).
+// Параметр действия: status_id для change_status, executor_id для reassign, label_ids для add_labels
+type TaskBulkRequest struct {
+	TaskIDs    []int      `json:"task_ids" binding:"required,min=1,max=100,dive,min=1"`
+	Action     string     `json:"action" binding:"required,oneof=change_status reassign add_labels delete"`
+	StatusID   *int       `json:"status_id,omitempty" binding:"omitempty,min=1"`
+	ExecutorID *uuid.UUID `json:"executor_id,omitempty"`
+	LabelIDs   []int      `json:"label_ids,omitempty" binding:"omitempty,max=20,dive,min=1"`
+	Atomic     bool       `json:"atomic"`
+}
+
+// TaskBulkResult - итог массовой операции с результатом по каждой задаче (должен соответствовать TaskBulkResult в taskService)
+type TaskBulkResult struct {
+	Atomic    bool                 `json:"atomic"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Items     []TaskBulkItemResult `json:"items"`
+}
+
+// TaskBulkItemResult - результат для одной задачи; Error заполнен, если задача не изменена
+type TaskBulkItemResult struct {
+	TaskID  int    `json:"taskId"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// TaskTemplate - шаблон задачи (должен соответствовать TaskTemplate в taskService). TitlePattern может
+// содержать подстановки {name}; ExecutorID - исполнитель по умолчанию
+type TaskTemplate struct {
+	ID             int                         `json:"id"`
+	Name           string                      `json:"name"`
+	TitlePattern   string                      `json:"titlePattern"`
+	Description    string                      `json:"description"`
+	CreatorID      uuid.UUID                   `json:"creatorID"`
+	ExecutorID     *uuid.UUID                  `json:"executorID,omitempty"`
+	Priority       string                      `json:"priority"`
+	Labels         []TaskTemplateLabel         `json:"labels"`
+	ChecklistItems []TaskTemplateChecklistItem `json:"checklistItems"`
+	CreatedAt      time.Time                   `json:"createdAt"`
+	UpdatedAt      *time.Time                  `json:"updatedAt,omitempty"`
+}
+
+// TaskTemplateLabel - метка, которую получает задача, созданная по шаблону
+type TaskTemplateLabel struct {
+	LabelID int        `json:"labelID"`
+	Label   *TaskLabel `json:"label,omitempty"`
+}
+
+// TaskTemplateChecklistItem - пункт чек-листа, который копируется в задачу, созданную по шаблону
+type TaskTemplateChecklistItem struct {
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	Position int    `json:"position"`
+}
+
+// SaveTaskTemplateRequest - создание или замена шаблона (должен соответствовать SaveTaskTemplateDTO в taskService)
+type SaveTaskTemplateRequest struct {
+	Name           string     `json:"name" binding:"required,max=100"`
+	TitlePattern   string     `json:"title_pattern" binding:"required,max=255"`
+	Description    string     `json:"description"`
+	ExecutorID     *uuid.UUID `json:"executor_id,omitempty"`
+	Priority       string     `json:"priority,omitempty" binding:"omitempty,oneof=low normal high urgent"`
+	LabelIDs       []int      `json:"label_ids,omitempty" binding:"max=20,dive,min=1"`
+	ChecklistItems []string   `json:"checklist_items,omitempty" binding:"max=50,dive,required,max=255"`
+}
+
+// CreateTaskFromTemplateRequest - задача по шаблону (должен соответствовать CreateTaskFromTemplateDTO в taskService).
+// ExecutorID и Priority заменяют значения шаблона
+type CreateTaskFromTemplateRequest struct {
+	Variables       map[string]string `json:"variables,omitempty"`
+	ExecutorID      *uuid.UUID        `json:"executor_id,omitempty"`
+	ChatID          uuid.UUID         `json:"chat_id"`
+	FileIDs         []int             `json:"file_ids,omitempty"`
+	WorkflowID      *int              `json:"workflow_id,omitempty"`
+	ParentTaskID    *int              `json:"parent_task_id,omitempty"`
+	Priority        string            `json:"priority,omitempty" binding:"omitempty,oneof=low normal high urgent"`
+	StartAt         *time.Time        `json:"start_at,omitempty"`
+	DueAt           *time.Time        `json:"due_at,omitempty"`
+	EstimateMinutes *int              `json:"estimate_minutes,omitempty" binding:"omitempty,min=0"`
+}
+
+// TaskChecklistItem - пункт чек-листа задачи (должен соответствовать TaskChecklistItem в taskService).
+// DoneBy и DoneAt заполнены у отмеченных пунктов
+type TaskChecklistItem struct {
+	ID        int        `json:"id"`
+	TaskID    int        `json:"taskID"`
+	Title     string     `json:"title"`
+	Position  int        `json:"position"`
+	Done      bool       `json:"done"`
+	DoneBy    *uuid.UUID `json:"doneBy,omitempty"`
+	DoneAt    *time.Time `json:"doneAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// AddChecklistItemRequest - новый пункт чек-листа (должен соответствовать AddChecklistItemDTO в taskService)
+type AddChecklistItemRequest struct {
+	Title string `json:"title" binding:"required,max=255"`
+}
+
+// UpdateChecklistItemRequest - частичное обновление пункта (должен соответствовать UpdateChecklistItemDTO в taskService)
+type UpdateChecklistItemRequest struct {
+	Title    *string `json:"title,omitempty" binding:"omitempty,min=1,max=255"`
+	Done     *bool   `json:"done,omitempty"`
+	Position *int    `json:"position,omitempty" binding:"omitempty,min=0"`
+}
+
+// TaskThroughput - задачи, созданные и завершённые за период (должен соответствовать TaskThroughput в taskService)
+type TaskThroughput struct {
+	Period    time.Time `json:"period"`
+	Created   int64     `json:"created"`
+	Completed int64     `json:"completed"`
+}
+
+// TaskStatusDuration - среднее время пребывания в статусе (должен соответствовать TaskStatusDuration в taskService)
+type TaskStatusDuration struct {
+	StatusID   *int    `json:"statusID,omitempty"`
+	Status     string  `json:"status"`
+	Stays      int64   `json:"stays"`
+	AvgMinutes float64 `json:"avgMinutes"`
+}
+
+// TaskOverdueCount - просроченные задачи исполнителя (должен соответствовать TaskOverdueCount в taskService)
+type TaskOverdueCount struct {
+	ExecutorID  uuid.UUID `json:"executorID"`
+	Overdue     int64     `json:"overdue"`
+	OldestDueAt time.Time `json:"oldestDueAt"`
+}
+
+// TaskCumulativeFlow - задачи в статусе на конец дня (должен соответствовать TaskCumulativeFlow в taskService)
+type TaskCumulativeFlow struct {
+	Day    time.Time `json:"day"`
+	Status string    `json:"status"`
+	Tasks  int64     `json:"tasks"`
+}
@@ -13,3 +13,8 @@ type Chat struct {
 	AvatarFileID *int
 	CreatedAt    time.Time
 }
+
+// UserChatMemberships - чаты, в которых пользователь состоит и не заблокирован
+type UserChatMemberships struct {
+	ChatIDs []uuid.UUID `json:"chatIDs"`
+}
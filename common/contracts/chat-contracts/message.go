@@ -0,0 +1,24 @@
+package chat_contracts
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Message - сообщение чата для других сервисов: содержимое и ID вложений
+type Message struct {
+	ID        uuid.UUID  `json:"id"`
+	ChatID    uuid.UUID  `json:"chatID"`
+	SenderID  *uuid.UUID `json:"senderID"`
+	Content   string     `json:"content"`
+	FileIDs   []int      `json:"fileIDs"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// CreateSystemMessageRequest - системное сообщение без отправителя; TaskID - задача, на которую оно ссылается.
+// Повторный запрос с тем же EventID не создает второе сообщение
+type CreateSystemMessageRequest struct {
+	Content string  `json:"content" binding:"required"`
+	TaskID  *int    `json:"task_id,omitempty"`
+	EventID *string `json:"event_id,omitempty"`
+}
@@ -11,3 +11,13 @@ type File struct {
 	CreatedAt  time.Time `json:"created_at"`
 	FileType   FileType  `json:"file_type"`
 }
+
+// GetFilesByIDsRequest - запрос пакетного получения файлов по ID
+type GetFilesByIDsRequest struct {
+	IDs []int `json:"ids"`
+}
+
+// FilesResponse - ответ пакетного получения файлов
+type FilesResponse struct {
+	Files []*File `json:"files"`
+}
@@ -7,3 +7,8 @@ type Response struct {
 	File  *fc.File `json:"file"`
 	Error *string  `json:"error"`
 }
+
+// UsersResponse - ответ пакетного получения пользователей по ID
+type UsersResponse struct {
+	Users []*User `json:"users"`
+}
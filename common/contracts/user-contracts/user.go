@@ -20,11 +20,24 @@ type Role struct {
 }
 
 type User struct {
-	ID          uuid.UUID
-	Username    string
-	Email       string
-	Description *string
-	Gender      *string
-	Age         *int
-	Role        Role
+	ID           uuid.UUID
+	Username     string
+	Email        string
+	Description  *string
+	Gender       *string
+	Age          *int
+	AvatarFileID *int
+	Role         Role
+}
+
+// GetUsersByIDsRequest - запрос пакетного получения пользователей по ID
+type GetUsersByIDsRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+	// UsernameQuery - если задан, возвращаются только пользователи с этой подстрокой в имени
+	UsernameQuery string `json:"username_query,omitempty"`
+}
+
+// GetUsersByUsernamesRequest - запрос пакетного получения пользователей по точным именам
+type GetUsersByUsernamesRequest struct {
+	Usernames []string `json:"usernames"`
 }
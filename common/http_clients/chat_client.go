@@ -1,15 +1,24 @@
 package http_clients
 
 import (
+	"bytes"
 	"common/config"
 	cc "common/contracts/chat-contracts"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	"io"
 	"net/http"
 )
 
+var (
+	// ErrMessageNotFound - сообщение не найдено в чат-сервисе
+	ErrMessageNotFound = errors.New("message not found")
+	// ErrMessageAccessDenied - у пользователя нет права view_messages в чате сообщения
+	ErrMessageAccessDenied = errors.New("access denied: no permission to view messages in this chat")
+)
+
 // UserRoleInChatResponse - ответ с ролью пользователя в чате
 type UserRoleInChatResponse struct {
 	RoleName string `json:"roleName"`
@@ -81,3 +90,89 @@ func GetUserRoleInChat(chatID, userID, requesterID string) (*UserRoleInChatRespo
 
 	return &roleResponse, nil
 }
+
+// GetMessageByID получает сообщение с ID вложений от имени requesterID.
+// Чат-сервис проверяет право view_messages пользователя в чате сообщения
+func GetMessageByID(messageID, requesterID string) (*cc.Message, error) {
+	baseURL := config.GetEnvOrDefault("CHAT_SERVICE_URL", "http://localhost:8083")
+	url := fmt.Sprintf("%s/api/v1/chats/messages/by-id/%s", baseURL, messageID)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("X-User-ID", requesterID)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error in request's processing: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrMessageNotFound
+	case http.StatusForbidden:
+		return nil, ErrMessageAccessDenied
+	default:
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("can't get message: %s - %s", resp.Status, string(bodyBytes))
+	}
+
+	var message cc.Message
+	if err := json.NewDecoder(resp.Body).Decode(&message); err != nil {
+		return nil, fmt.Errorf("error of JSON decoding: %w", err)
+	}
+
+	return &message, nil
+}
+
+// CreateSystemMessage публикует в чате системное сообщение без отправителя
+func CreateSystemMessage(chatID string, request *cc.CreateSystemMessageRequest) error {
+	baseURL := config.GetEnvOrDefault("CHAT_SERVICE_URL", "http://localhost:8083")
+	url := fmt.Sprintf("%s/api/v1/chats/%s/system-messages", baseURL, chatID)
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("error of JSON encoding: %w", err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("error in request's processing: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("can't create system message: %s - %s", resp.Status, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// GetUserChatIDs возвращает ID чатов, в которых пользователь состоит и не заблокирован
+func GetUserChatIDs(userID string) ([]uuid.UUID, error) {
+	baseURL := config.GetEnvOrDefault("CHAT_SERVICE_URL", "http://localhost:8083")
+	url := fmt.Sprintf("%s/api/v1/chats/user/%s/memberships", baseURL, userID)
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error in request's processing: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("can't get user chats: %s - %s", resp.Status, string(bodyBytes))
+	}
+
+	var memberships cc.UserChatMemberships
+	if err := json.NewDecoder(resp.Body).Decode(&memberships); err != nil {
+		return nil, fmt.Errorf("error of JSON decoding: %w", err)
+	}
+
+	return memberships.ChatIDs, nil
+}
@@ -1,6 +1,7 @@
 package http_clients
 
 import (
+	"bytes"
 	"common/config"
 	fc "common/contracts/file-contracts"
 	"encoding/json"
@@ -31,3 +32,31 @@ func GetFileByID(fileID int) (*fc.File, error) {
 
 	return &file, nil
 }
+
+// GetFilesByIDs получает файлы пакетно одним HTTP-запросом к файловому сервису; ненайденные ID пропускаются
+func GetFilesByIDs(fileIDs []int) (*fc.FilesResponse, error) {
+	baseURL := config.GetEnvOrDefault("FILE_SERVICE_URL", "http://localhost:8080")
+	url := fmt.Sprintf("%s/api/v1/files/batch", baseURL)
+
+	payload, err := json.Marshal(fc.GetFilesByIDsRequest{IDs: fileIDs})
+	if err != nil {
+		return nil, fmt.Errorf("error of JSON encoding: %w", err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error in request's processing: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("can't get files: " + resp.Status)
+	}
+
+	var dtoResp fc.FilesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&dtoResp); err != nil {
+		return nil, fmt.Errorf("error of JSON encoding: %w", err)
+	}
+
+	return &dtoResp, nil
+}
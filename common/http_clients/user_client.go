@@ -1,6 +1,7 @@
 package http_clients
 
 import (
+	"bytes"
 	"common/config"
 	cuc "common/contracts/user-contracts"
 	"encoding/json"
@@ -32,3 +33,68 @@ func GetUserByID(userID *uuid.UUID) (*cuc.Response, error) {
 
 	return &dtoResp, nil
 }
+
+// GetUsersByIDs получает пользователей пакетно одним HTTP-запросом к пользовательскому сервису
+func GetUsersByIDs(userIDs []uuid.UUID) (*cuc.UsersResponse, error) {
+	return postUsersBatch(cuc.GetUsersByIDsRequest{IDs: userIDs})
+}
+
+// SearchUsersByIDs возвращает пользователей из userIDs, в имени которых есть подстрока query
+func SearchUsersByIDs(userIDs []uuid.UUID, query string) (*cuc.UsersResponse, error) {
+	return postUsersBatch(cuc.GetUsersByIDsRequest{IDs: userIDs, UsernameQuery: query})
+}
+
+func postUsersBatch(request cuc.GetUsersByIDsRequest) (*cuc.UsersResponse, error) {
+	baseURL := config.GetEnvOrDefault("USER_SERVICE_URL", "http://localhost:8082")
+	url := fmt.Sprintf("%s/api/v1/users/batch", baseURL)
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error of JSON encoding: %w", err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error in request's processing: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("can't get users: " + resp.Status)
+	}
+
+	var dtoResp cuc.UsersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&dtoResp); err != nil {
+		return nil, fmt.Errorf("error of JSON encoding: %w", err)
+	}
+
+	return &dtoResp, nil
+}
+
+// GetUsersByUsernames получает пользователей по точным именам одним HTTP-запросом; неизвестные имена пропускаются
+func GetUsersByUsernames(usernames []string) (*cuc.UsersResponse, error) {
+	baseURL := config.GetEnvOrDefault("USER_SERVICE_URL", "http://localhost:8082")
+	url := fmt.Sprintf("%s/api/v1/users/by-usernames", baseURL)
+
+	payload, err := json.Marshal(cuc.GetUsersByUsernamesRequest{Usernames: usernames})
+	if err != nil {
+		return nil, fmt.Errorf("error of JSON encoding: %w", err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error in request's processing: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("can't get users: " + resp.Status)
+	}
+
+	var dtoResp cuc.UsersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&dtoResp); err != nil {
+		return nil, fmt.Errorf("error of JSON encoding: %w", err)
+	}
+
+	return &dtoResp, nil
+}
@@ -37,3 +37,12 @@ func GetKeyUpdatesTopic() string {
 	}
 	return topic
 }
+
+// GetTaskEventsTopic получает топик для доменных событий задач из переменных окружения
+func GetTaskEventsTopic() string {
+	topic := os.Getenv("KAFKA_TOPIC_TASK_EVENTS")
+	if topic == "" {
+		return "task_events" // значение по умолчанию
+	}
+	return topic
+}
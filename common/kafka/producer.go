@@ -39,9 +39,15 @@ func (p *NotificationProducer) SendNotification(notification interface{}) error
 	var notificationType models.NotificationType
 
 	// Определяем тип уведомления
-	switch notification.(type) {
+	switch n := notification.(type) {
 	case *models.NewTaskNotification:
 		notificationType = models.NotificationNewTask
+	case *models.TaskDeadlineNotification:
+		// Один payload для напоминаний task_due_soon и task_overdue, тип задаётся отправителем
+		notificationType = n.Type
+	case *models.TaskCommentNotification:
+		// task_comment и task_mention отличаются только шаблоном письма
+		notificationType = n.Type
 	case *models.NewChatNotification:
 		notificationType = models.NotificationNewChat
 	case *models.LoginNotification:
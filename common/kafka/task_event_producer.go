@@ -0,0 +1,56 @@
+package kafka
+
+import (
+	"common/models"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/IBM/sarama"
+)
+
+// TaskEventProducer отправляет доменные события задач. Ключ сообщения - ID задачи,
+// поэтому события одной задачи попадают в одну партицию и читаются по порядку
+type TaskEventProducer struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func NewTaskEventProducer(config *ProducerConfig) (*TaskEventProducer, error) {
+	kafkaConfig := sarama.NewConfig()
+	kafkaConfig.Producer.Return.Successes = true
+	kafkaConfig.Producer.Return.Errors = true
+	kafkaConfig.Producer.RequiredAcks = sarama.WaitForAll
+
+	producer, err := sarama.NewSyncProducer(config.Brokers, kafkaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka task event producer: %w", err)
+	}
+
+	return &TaskEventProducer{
+		producer: producer,
+		topic:    config.Topic,
+	}, nil
+}
+
+func (p *TaskEventProducer) SendTaskEvent(event *models.TaskDomainEvent) error {
+	messageBytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task event: %w", err)
+	}
+
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: p.topic,
+		Key:   sarama.StringEncoder(strconv.Itoa(event.TaskID)),
+		Value: sarama.StringEncoder(messageBytes),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send task event to kafka: %w", err)
+	}
+
+	return nil
+}
+
+func (p *TaskEventProducer) Close() error {
+	return p.producer.Close()
+}
@@ -12,6 +12,14 @@ const (
 	NotificationNewTask NotificationType = "new_task"
 	NotificationNewChat NotificationType = "new_chat"
 	NotificationLogin   NotificationType = "user_login"
+
+	NotificationTaskDueSoon NotificationType = "task_due_soon"
+	NotificationTaskOverdue NotificationType = "task_overdue"
+
+	NotificationTaskComment NotificationType = "task_comment"
+	NotificationTaskMention NotificationType = "task_mention"
+
+	NotificationTaskStatusChanged NotificationType = "task_status_changed"
 )
 
 // BaseNotification базовая структура уведомления
@@ -31,6 +39,40 @@ type NewTaskNotification struct {
 	ExecutorID  uuid.UUID `json:"executor_id,omitempty"`
 }
 
+// TaskDeadlineNotification уведомление о приближении или нарушении срока задачи
+// (типы task_due_soon и task_overdue)
+type TaskDeadlineNotification struct {
+	BaseNotification
+	TaskID     int       `json:"task_id"`
+	TaskTitle  string    `json:"task_title"`
+	ExecutorID uuid.UUID `json:"executor_id"`
+	Priority   string    `json:"priority"`
+	DueAt      time.Time `json:"due_at"`
+}
+
+// TaskCommentNotification уведомление о новом комментарии к задаче (типы task_comment и task_mention;
+// task_mention получают пользователи, упомянутые в комментарии)
+type TaskCommentNotification struct {
+	BaseNotification
+	TaskID      int       `json:"task_id"`
+	TaskTitle   string    `json:"task_title"`
+	CommentID   int       `json:"comment_id"`
+	AuthorName  string    `json:"author_name"`
+	Body        string    `json:"body"`
+	RecipientID uuid.UUID `json:"recipient_id"`
+}
+
+// TaskStatusChangedNotification уведомление о смене статуса задачи для её исполнителей и наблюдателей
+type TaskStatusChangedNotification struct {
+	BaseNotification
+	TaskID      int       `json:"task_id"`
+	TaskTitle   string    `json:"task_title"`
+	ActorName   string    `json:"actor_name"`
+	OldStatus   string    `json:"old_status"`
+	NewStatus   string    `json:"new_status"`
+	RecipientID uuid.UUID `json:"recipient_id"`
+}
+
 // NewChatNotification уведомление о новом чате
 type NewChatNotification struct {
 	BaseNotification
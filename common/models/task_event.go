@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskDomainEventType тип доменного события задачи
+type TaskDomainEventType string
+
+const (
+	TaskDomainEventCreated       TaskDomainEventType = "task_created"
+	TaskDomainEventStatusChanged TaskDomainEventType = "task_status_changed"
+	TaskDomainEventReassigned    TaskDomainEventType = "task_reassigned"
+)
+
+// TaskDomainEvent доменное событие задачи, привязанной к чату.
+// EventID одинаков при повторных доставках и служит ключом идемпотентности у потребителей.
+// OldValue и NewValue - названия статусов для task_status_changed и UUID исполнителей для task_reassigned
+type TaskDomainEvent struct {
+	EventID    string              `json:"event_id"`
+	Type       TaskDomainEventType `json:"type"`
+	TaskID     int                 `json:"task_id"`
+	TaskTitle  string              `json:"task_title"`
+	ChatID     uuid.UUID           `json:"chat_id"`
+	ActorID    uuid.UUID           `json:"actor_id"`
+	OldValue   string              `json:"old_value,omitempty"`
+	NewValue   string              `json:"new_value,omitempty"`
+	OccurredAt time.Time           `json:"occurred_at"`
+}
+
+// TaskCreatedEventID ключ идемпотентности события о создании задачи: задача создаётся один раз,
+// поэтому ключ совпадает у события из Kafka и у ссылки на задачу, отправленной в чат напрямую
+func TaskCreatedEventID(taskID int) string {
+	return fmt.Sprintf("task:%d:created", taskID)
+}
+
+// TaskHistoryEventID ключ идемпотентности события, записанного в историю задачи
+func TaskHistoryEventID(taskEventID int64) string {
+	return fmt.Sprintf("task_event:%d", taskEventID)
+}
@@ -129,6 +129,31 @@ func (c *FileController) GetFile(id int) (*fc.File, error) {
 	return fileContract, nil
 }
 
+// GetFilesByIDs возвращает файлы пакетно. В отличие от GetFile наличие объектов в MinIO не проверяется
+// и URL в БД не обновляется: пакет нужен для списков (аватары участников), где битая ссылка не критична
+func (c *FileController) GetFilesByIDs(ids []int) ([]*fc.File, error) {
+	files, err := c.repo.GetFilesByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*fc.File, 0, len(files))
+	for _, file := range files {
+		result = append(result, &fc.File{
+			ID:         file.ID,
+			Name:       file.Name,
+			FileTypeID: file.FileTypeID,
+			URL:        fmt.Sprintf("http://%s/%s/%s", c.getExternalHost(), c.minioConfig.Bucket, file.Name),
+			CreatedAt:  file.CreatedAt,
+			FileType: fc.FileType{
+				ID:   file.FileTypeID,
+				Name: file.FileType.Name,
+			},
+		})
+	}
+	return result, nil
+}
+
 // RenameFile изменяет имя файла в MinIO и обновляет БД
 func (c *FileController) RenameFile(id int, newName string) (*models.File, error) {
 	file, err := c.repo.GetFileByID(id)
@@ -11,6 +11,7 @@ import (
 type FileControllerInterface interface {
 	UploadFile(fileHeader *multipart.FileHeader) (*models.File, error)
 	GetFile(id int) (*fc.File, error)
+	GetFilesByIDs(ids []int) ([]*fc.File, error)
 	RenameFile(id int, newName string) (*models.File, error)
 	GetFileNamesWithPagination(limit, offset int) (*[]dto.FileInformation, error)
 }
@@ -0,0 +1,6 @@
+package dto
+
+// GetFilesByIDsRequest - запрос на пакетное получение файлов по ID
+type GetFilesByIDsRequest struct {
+	IDs []int `json:"ids" binding:"required,min=1,max=100"`
+}
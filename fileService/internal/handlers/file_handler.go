@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fileService/internal/controllers"
+	"fileService/internal/dto"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
@@ -89,6 +90,33 @@ func (h *FileHandler) GetFileHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, file)
 }
 
+// GetFilesBatchHandler обрабатывает пакетное получение информации о файлах
+// @Summary Пакетное получение информации о файлах
+// @Description Возвращает файлы с указанными ID одним запросом. Несуществующие ID пропускаются
+// @Tags files
+// @Accept json
+// @Produce json
+// @Param request body dto.GetFilesByIDsRequest true "Список ID файлов (до 100)"
+// @Success 200 {object} map[string]interface{} "Найденные файлы"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /files/batch [post]
+func (h *FileHandler) GetFilesBatchHandler(c *gin.Context) {
+	var req dto.GetFilesByIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	files, err := h.controller.GetFilesByIDs(req.IDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"files": files})
+}
+
 // RenameFileHandler обрабатывает переименование файла
 // @Summary Переименование файла
 // @Description Переименовывает файл в MinIO и обновляет информацию в БД
@@ -24,6 +24,13 @@ func (r *FileRepository) GetFileByID(id int) (*models.File, error) {
 	return &file, err
 }
 
+// GetFilesByIDs возвращает файлы с указанными ID одним запросом; несуществующие ID пропускаются
+func (r *FileRepository) GetFilesByIDs(ids []int) ([]*models.File, error) {
+	var files []*models.File
+	err := r.db.Preload("FileType").Where("id IN ?", ids).Find(&files).Error
+	return files, err
+}
+
 func (r *FileRepository) GetFileByName(name string) (*models.File, error) {
 	var file models.File
 	err := r.db.Preload("FileType").First(&file, "name = ?", name).Error
@@ -9,6 +9,7 @@ import (
 type FileRepositoryInterface interface {
 	CreateFile(file *models.File) error
 	GetFileByID(id int) (*models.File, error)
+	GetFilesByIDs(ids []int) ([]*models.File, error)
 	GetFileByName(name string) (*models.File, error)
 	UpdateFile(file *models.File) error
 	GetFileNamesWithPagination(limit, offset int) (*[]dto.FileInformation, error)
@@ -39,6 +39,14 @@ func (m *MockFileRepository) GetFileByID(id int) (*models.File, error) {
 	return args.Get(0).(*models.File), args.Error(1)
 }
 
+func (m *MockFileRepository) GetFilesByIDs(ids []int) ([]*models.File, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.File), args.Error(1)
+}
+
 func (m *MockFileRepository) GetFileByName(name string) (*models.File, error) {
 	args := m.Called(name)
 	if args.Get(0) == nil {
@@ -535,6 +543,35 @@ func TestFileController_GetFile_Success(t *testing.T) {
 	mockMinIOClient.AssertExpectations(t)
 }
 
+func TestFileController_GetFilesByIDs_Success(t *testing.T) {
+	// Arrange
+	mockFileRepo := new(MockFileRepository)
+	mockFileTypeRepo := new(MockFileTypeRepository)
+	mockMinIOClient := new(MockMinIOClient)
+	minioConfig := createTestMinIOConfig()
+
+	controller := ctrl.NewFileController(mockFileRepo, mockFileTypeRepo, mockMinIOClient, minioConfig)
+
+	file := createTestFile()
+	file.URL = "http://old-host:9000/test-bucket/" + file.Name
+	mockFileRepo.On("GetFilesByIDs", []int{file.ID, 42}).Return([]*models.File{file}, nil)
+
+	// Act
+	result, err := controller.GetFilesByIDs([]int{file.ID, 42})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, file.ID, result[0].ID)
+	assert.Equal(t, "http://localhost:9000/test-bucket/"+file.Name, result[0].URL)
+	assert.Equal(t, file.FileType.Name, result[0].FileType.Name)
+
+	// Пакетное чтение не обращается к MinIO и не переписывает URL в БД
+	mockMinIOClient.AssertNotCalled(t, "StatObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockFileRepo.AssertNotCalled(t, "UpdateFile", mock.Anything)
+	mockFileRepo.AssertExpectations(t)
+}
+
 func TestFileController_GetFile_SuccessWithURLUpdate(t *testing.T) {
 	// Arrange
 	mockFileRepo := new(MockFileRepository)
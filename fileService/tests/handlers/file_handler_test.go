@@ -41,6 +41,14 @@ func (m *MockFileController) GetFile(id int) (*fc.File, error) {
 	return args.Get(0).(*fc.File), args.Error(1)
 }
 
+func (m *MockFileController) GetFilesByIDs(ids []int) ([]*fc.File, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*fc.File), args.Error(1)
+}
+
 func (m *MockFileController) RenameFile(id int, newName string) (*models.File, error) {
 	args := m.Called(id, newName)
 	if args.Get(0) == nil {
@@ -339,6 +347,64 @@ func TestFileHandler_GetFileHandler_Success(t *testing.T) {
 	mockController.AssertExpectations(t)
 }
 
+func TestFileHandler_GetFilesBatchHandler_Success(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockFileController)
+	handler := hndlrs.NewFileHandler(mockController)
+
+	router := gin.New()
+	router.POST("/files/batch", handler.GetFilesBatchHandler)
+
+	expectedFile := createTestFileContract()
+	mockController.On("GetFilesByIDs", []int{expectedFile.ID}).Return([]*fc.File{expectedFile}, nil)
+
+	body, _ := json.Marshal(dto.GetFilesByIDsRequest{IDs: []int{expectedFile.ID}})
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/files/batch", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response fc.FilesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Files, 1)
+	assert.Equal(t, expectedFile.ID, response.Files[0].ID)
+
+	mockController.AssertExpectations(t)
+}
+
+func TestFileHandler_GetFilesBatchHandler_TooManyIDs(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockFileController)
+	handler := hndlrs.NewFileHandler(mockController)
+
+	router := gin.New()
+	router.POST("/files/batch", handler.GetFilesBatchHandler)
+
+	ids := make([]int, 101)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+	body, _ := json.Marshal(dto.GetFilesByIDsRequest{IDs: ids})
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/files/batch", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetFilesByIDs", mock.Anything)
+}
+
 func TestFileHandler_GetFileHandler_InvalidFileID(t *testing.T) {
 	// Arrange
 	gin.SetMode(gin.TestMode)
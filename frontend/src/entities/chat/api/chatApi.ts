@@ -101,6 +101,26 @@ export interface ChatMemberResponse {
   userId: string;
   roleId: number;
   roleName: string;
+  username?: string;
+  avatarFile?: ApiFile;
+}
+
+/**
+ * Параметры выборки участников чата
+ */
+export interface GetChatMembersParams {
+  offset?: number;
+  limit?: number;
+  role?: string;
+  search?: string;
+}
+
+/**
+ * Страница участников чата
+ */
+export interface ChatMembersResponse {
+  members: ChatMemberResponse[];
+  total: number;
 }
 
 // ============================================================
@@ -192,8 +212,8 @@ export const chatApi = {
    * Получить список участников чата
    * GET /api/v1/chats/members/:chatId
    */
-  getChatMembers: (chatId: string) =>
-    apiClient.get<ChatMemberResponse[]>(`/chats/members/${chatId}`),
+  getChatMembers: (chatId: string, params?: GetChatMembersParams) =>
+    apiClient.get<ChatMembersResponse>(`/chats/members/${chatId}`, { params }),
 };
 
 // ============================================================
@@ -10,6 +10,7 @@ import {
   type UpdateChatRequest,
   type ChangeUserRoleRequest,
   type MyRoleResponse,
+  type GetChatMembersParams,
 } from './chatApi';
 import { useAuthStore } from '@/entities/session';
 import { toast } from '@/shared/ui';
@@ -179,13 +180,13 @@ export function useChatPermissions() {
 }
 
 /**
- * Получить список участников чата
+ * Получить страницу участников чата
  */
-export function useChatMembers(chatId: string | undefined) {
+export function useChatMembers(chatId: string | undefined, params?: GetChatMembersParams) {
   return useQuery({
-    queryKey: [...chatKeys.detail(chatId!), 'members'] as const,
+    queryKey: [...chatKeys.detail(chatId!), 'members', params] as const,
     queryFn: async () => {
-      const response = await chatApi.getChatMembers(chatId!);
+      const response = await chatApi.getChatMembers(chatId!, params);
       return response.data;
     },
     enabled: !!chatId,
@@ -19,6 +19,8 @@ export {
   type ChatRoleResponse,
   type SearchMessagesResponse,
   type ChatMemberResponse,
+  type ChatMembersResponse,
+  type GetChatMembersParams,
   type CreateChatRoleRequest,
   type UpdateChatRolePermissionsRequest,
   type CreateChatPermissionRequest,
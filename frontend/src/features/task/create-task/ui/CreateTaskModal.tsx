@@ -23,11 +23,11 @@ function ChatMembersLoader({
   chatId: string; 
   onMembersLoaded: (members: ChatMemberResponse[]) => void;
 }) {
-  const { data } = useChatMembers(chatId);
+  const { data } = useChatMembers(chatId, { limit: 100 });
   
   useEffect(() => {
     if (data) {
-      onMembersLoaded(data);
+      onMembersLoaded(data.members);
     }
   }, [data, onMembersLoaded]);
   
@@ -61,8 +61,9 @@ export function ChatSettingsOverlay({
     const hasAnyPermission = canEditChat || canDeleteChat || canBanUser || canChangeRole;
 
     // Загружаем участников чата только если есть права на бан или изменение ролей
-    const {data: members, isLoading: isLoadingMembers} = useChatMembers(
-        (canBanUser || canChangeRole) ? chat.id : undefined
+    const {data: membersPage, isLoading: isLoadingMembers} = useChatMembers(
+        (canBanUser || canChangeRole) ? chat.id : undefined,
+        {limit: 100}
     );
 
     // Модалки подтверждения
@@ -172,10 +173,11 @@ export function ChatSettingsOverlay({
                                             <div className="flex items-center justify-center py-8">
                                                 <Loader2 className="w-6 h-6 animate-spin text-primary-500"/>
                                             </div>
-                                        ) : members && members.length > 0 ? (
+                                        ) : membersPage && membersPage.members.length > 0 ? (
                                             <MembersSection
                                                 chatId={chat.id}
-                                                members={members}
+                                                members={membersPage.members}
+                                                total={membersPage.total}
                                                 canBanUser={canBanUser}
                                                 canChangeRole={canChangeRole}
                                                 onBanUser={setUserToBan}
@@ -413,6 +415,7 @@ function EditChatSection({chat, onClose}: { chat: Chat; onClose: () => void }) {
 function MembersSection({
                             chatId,
                             members,
+                            total,
                             canBanUser,
                             canChangeRole,
                             onBanUser,
@@ -422,6 +425,7 @@ function MembersSection({
                         }: {
     chatId: string;
     members: ChatMemberResponse[];
+    total: number;
     canBanUser: boolean;
     canChangeRole: boolean;
     onBanUser: (userId: string) => void;
@@ -433,7 +437,7 @@ function MembersSection({
         <div>
             <h3 className="text-sm font-medium text-neutral-300 mb-4 flex items-center gap-2">
                 <Users size={16}/>
-                Участники ({members.length})
+                Участники ({total})
             </h3>
 
             <ul className="space-y-2">
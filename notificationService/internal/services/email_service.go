@@ -52,6 +52,14 @@ func (s *EmailService) loadTemplates() error {
 		models.NotificationNewTask: "new_task.html",
 		models.NotificationNewChat: "new_chat.html",
 		models.NotificationLogin:   "login.html",
+
+		models.NotificationTaskDueSoon: "task_due_soon.html",
+		models.NotificationTaskOverdue: "task_overdue.html",
+
+		models.NotificationTaskComment: "task_comment.html",
+		models.NotificationTaskMention: "task_mention.html",
+
+		models.NotificationTaskStatusChanged: "task_status_changed.html",
 	}
 
 	for notificationType, filename := range templateFiles {
@@ -97,6 +105,38 @@ func (s *EmailService) SendNotification(notification interface{}) error {
 		templateData = n
 		tmplType = models.NotificationLogin
 
+	case *models.TaskDeadlineNotification:
+		email = n.Email
+		switch n.Type {
+		case models.NotificationTaskDueSoon:
+			subject = fmt.Sprintf("Скоро срок задачи: %s", n.TaskTitle)
+		case models.NotificationTaskOverdue:
+			subject = fmt.Sprintf("Задача просрочена: %s", n.TaskTitle)
+		default:
+			return fmt.Errorf("unknown task deadline notification type: %s", n.Type)
+		}
+		templateData = n
+		tmplType = n.Type
+
+	case *models.TaskCommentNotification:
+		email = n.Email
+		switch n.Type {
+		case models.NotificationTaskComment:
+			subject = fmt.Sprintf("Новый комментарий к задаче: %s", n.TaskTitle)
+		case models.NotificationTaskMention:
+			subject = fmt.Sprintf("%s упомянул(а) вас в задаче: %s", n.AuthorName, n.TaskTitle)
+		default:
+			return fmt.Errorf("unknown task comment notification type: %s", n.Type)
+		}
+		templateData = n
+		tmplType = n.Type
+
+	case *models.TaskStatusChangedNotification:
+		email = n.Email
+		subject = fmt.Sprintf("Статус задачи изменён: %s", n.TaskTitle)
+		templateData = n
+		tmplType = models.NotificationTaskStatusChanged
+
 	default:
 		return fmt.Errorf("unknown notification type: %T", notification)
 	}
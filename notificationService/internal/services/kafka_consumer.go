@@ -157,6 +157,29 @@ func (kc *KafkaConsumer) ParseNotification(kafkaMsg models.KafkaMessage) (interf
 		}
 		return &notification, nil
 
+	case models.NotificationTaskDueSoon, models.NotificationTaskOverdue:
+		var notification models.TaskDeadlineNotification
+		if err := json.Unmarshal(payloadBytes, &notification); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal task deadline notification: %w", err)
+		}
+		notification.Type = kafkaMsg.Type
+		return &notification, nil
+
+	case models.NotificationTaskComment, models.NotificationTaskMention:
+		var notification models.TaskCommentNotification
+		if err := json.Unmarshal(payloadBytes, &notification); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal task comment notification: %w", err)
+		}
+		notification.Type = kafkaMsg.Type
+		return &notification, nil
+
+	case models.NotificationTaskStatusChanged:
+		var notification models.TaskStatusChangedNotification
+		if err := json.Unmarshal(payloadBytes, &notification); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal task status changed notification: %w", err)
+		}
+		return &notification, nil
+
 	default:
 		return nil, fmt.Errorf("unknown notification type: %s", kafkaMsg.Type)
 	}
@@ -2,12 +2,14 @@ package services
 
 import (
 	"errors"
+	"mime"
 	"testing"
 	"time"
 
 	"common/config"
 	"common/models"
 	"github.com/google/uuid"
+	"gopkg.in/gomail.v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -267,3 +269,127 @@ func TestEmailService_NewEmailService_InvalidConfig_EmptyPassword(t *testing.T)
 	assert.Nil(t, service)
 	assert.Contains(t, err.Error(), "SMTP password is required")
 }
+
+func TestEmailService_SendNotification_TaskDeadlineNotifications_Success(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		notificationType models.NotificationType
+		subject          string
+	}{
+		{models.NotificationTaskDueSoon, "Скоро срок задачи: Quarterly report"},
+		{models.NotificationTaskOverdue, "Задача просрочена: Quarterly report"},
+	} {
+		t.Run(string(tt.notificationType), func(t *testing.T) {
+			mockSender := new(MockEmailSender)
+			emailService, err := services.NewEmailServiceWithSender(createTestEmailConfig(), mockSender)
+			require.NoError(t, err)
+
+			notification := &models.TaskDeadlineNotification{
+				BaseNotification: models.BaseNotification{
+					ID:        uuid.New(),
+					Type:      tt.notificationType,
+					Email:     "executor@example.com",
+					CreatedAt: time.Now(),
+				},
+				TaskID:     3,
+				TaskTitle:  "Quarterly report",
+				ExecutorID: uuid.New(),
+				Priority:   "urgent",
+				DueAt:      time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+			}
+
+			mockSender.On("DialAndSend", mock.MatchedBy(func(messages []*gomail.Message) bool {
+				// gomail кодирует заголовок в MIME encoded-word
+				subject, err := new(mime.WordDecoder).DecodeHeader(messages[0].GetHeader("Subject")[0])
+				return err == nil && subject == tt.subject
+			})).Return(nil)
+
+			require.NoError(t, emailService.SendNotification(notification))
+			mockSender.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmailService_SendNotification_TaskDeadlineNotification_UnknownType(t *testing.T) {
+	t.Parallel()
+	mockSender := new(MockEmailSender)
+	emailService, err := services.NewEmailServiceWithSender(createTestEmailConfig(), mockSender)
+	require.NoError(t, err)
+
+	err = emailService.SendNotification(&models.TaskDeadlineNotification{
+		BaseNotification: models.BaseNotification{Type: models.NotificationNewTask, Email: "executor@example.com"},
+	})
+
+	require.Error(t, err)
+	mockSender.AssertNotCalled(t, "DialAndSend", mock.Anything)
+}
+
+func TestEmailService_SendNotification_TaskCommentNotifications_Success(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		notificationType models.NotificationType
+		subject          string
+	}{
+		{models.NotificationTaskComment, "Новый комментарий к задаче: Quarterly report"},
+		{models.NotificationTaskMention, "alice упомянул(а) вас в задаче: Quarterly report"},
+	} {
+		t.Run(string(tt.notificationType), func(t *testing.T) {
+			mockSender := new(MockEmailSender)
+			emailService, err := services.NewEmailServiceWithSender(createTestEmailConfig(), mockSender)
+			require.NoError(t, err)
+
+			notification := &models.TaskCommentNotification{
+				BaseNotification: models.BaseNotification{
+					ID:        uuid.New(),
+					Type:      tt.notificationType,
+					Email:     "bob@example.com",
+					CreatedAt: time.Now(),
+				},
+				TaskID:      3,
+				TaskTitle:   "Quarterly report",
+				CommentID:   8,
+				AuthorName:  "alice",
+				Body:        "@bob <b>check</b> the numbers",
+				RecipientID: uuid.New(),
+			}
+
+			mockSender.On("DialAndSend", mock.MatchedBy(func(messages []*gomail.Message) bool {
+				subject, err := new(mime.WordDecoder).DecodeHeader(messages[0].GetHeader("Subject")[0])
+				return err == nil && subject == tt.subject
+			})).Return(nil)
+
+			require.NoError(t, emailService.SendNotification(notification))
+			mockSender.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmailService_SendNotification_TaskStatusChangedNotification_Success(t *testing.T) {
+	t.Parallel()
+	mockSender := new(MockEmailSender)
+	emailService, err := services.NewEmailServiceWithSender(createTestEmailConfig(), mockSender)
+	require.NoError(t, err)
+
+	notification := &models.TaskStatusChangedNotification{
+		BaseNotification: models.BaseNotification{
+			ID:        uuid.New(),
+			Type:      models.NotificationTaskStatusChanged,
+			Email:     "watcher@example.com",
+			CreatedAt: time.Now(),
+		},
+		TaskID:      4,
+		TaskTitle:   "Quarterly report",
+		ActorName:   "alice",
+		OldStatus:   "In progress",
+		NewStatus:   "Done",
+		RecipientID: uuid.New(),
+	}
+
+	mockSender.On("DialAndSend", mock.MatchedBy(func(messages []*gomail.Message) bool {
+		subject, err := new(mime.WordDecoder).DecodeHeader(messages[0].GetHeader("Subject")[0])
+		return err == nil && subject == "Статус задачи изменён: Quarterly report"
+	})).Return(nil)
+
+	require.NoError(t, emailService.SendNotification(notification))
+	mockSender.AssertExpectations(t)
+}
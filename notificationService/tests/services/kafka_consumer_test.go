@@ -437,3 +437,100 @@ func TestKafkaConsumer_ParseNotification_UnknownType(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "unknown notification type")
 }
+
+func TestKafkaConsumer_ParseNotification_TaskDeadlineNotification_Success(t *testing.T) {
+	t.Parallel()
+	consumer := &services.KafkaConsumer{EmailService: new(MockEmailService)}
+	dueAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	kafkaMsg := models.KafkaMessage{
+		Type: models.NotificationTaskOverdue,
+		Payload: &models.TaskDeadlineNotification{
+			BaseNotification: models.BaseNotification{
+				ID:        uuid.New(),
+				Type:      models.NotificationTaskOverdue,
+				Email:     "executor@example.com",
+				CreatedAt: time.Now(),
+			},
+			TaskID:    5,
+			TaskTitle: "Late task",
+			Priority:  "high",
+			DueAt:     dueAt,
+		},
+	}
+
+	result, err := consumer.ParseNotification(kafkaMsg)
+
+	require.NoError(t, err)
+	notif, ok := result.(*models.TaskDeadlineNotification)
+	require.True(t, ok)
+	assert.Equal(t, models.NotificationTaskOverdue, notif.Type)
+	assert.Equal(t, 5, notif.TaskID)
+	assert.True(t, dueAt.Equal(notif.DueAt))
+}
+
+func TestKafkaConsumer_ParseNotification_TaskCommentNotification_Success(t *testing.T) {
+	t.Parallel()
+	consumer := &services.KafkaConsumer{EmailService: new(MockEmailService)}
+	recipientID := uuid.New()
+
+	kafkaMsg := models.KafkaMessage{
+		Type: models.NotificationTaskMention,
+		Payload: &models.TaskCommentNotification{
+			BaseNotification: models.BaseNotification{
+				ID:        uuid.New(),
+				Type:      models.NotificationTaskMention,
+				Email:     "bob@example.com",
+				CreatedAt: time.Now(),
+			},
+			TaskID:      5,
+			TaskTitle:   "Discussed task",
+			CommentID:   9,
+			AuthorName:  "alice",
+			Body:        "@bob ping",
+			RecipientID: recipientID,
+		},
+	}
+
+	result, err := consumer.ParseNotification(kafkaMsg)
+
+	require.NoError(t, err)
+	notif, ok := result.(*models.TaskCommentNotification)
+	require.True(t, ok)
+	assert.Equal(t, models.NotificationTaskMention, notif.Type)
+	assert.Equal(t, 9, notif.CommentID)
+	assert.Equal(t, recipientID, notif.RecipientID)
+}
+
+func TestKafkaConsumer_ParseNotification_TaskStatusChangedNotification_Success(t *testing.T) {
+	t.Parallel()
+	consumer := &services.KafkaConsumer{EmailService: new(MockEmailService)}
+	recipientID := uuid.New()
+
+	kafkaMsg := models.KafkaMessage{
+		Type: models.NotificationTaskStatusChanged,
+		Payload: &models.TaskStatusChangedNotification{
+			BaseNotification: models.BaseNotification{
+				ID:        uuid.New(),
+				Type:      models.NotificationTaskStatusChanged,
+				Email:     "watcher@example.com",
+				CreatedAt: time.Now(),
+			},
+			TaskID:      6,
+			TaskTitle:   "Watched task",
+			ActorName:   "alice",
+			OldStatus:   "To do",
+			NewStatus:   "In progress",
+			RecipientID: recipientID,
+		},
+	}
+
+	result, err := consumer.ParseNotification(kafkaMsg)
+
+	require.NoError(t, err)
+	notif, ok := result.(*models.TaskStatusChangedNotification)
+	require.True(t, ok)
+	assert.Equal(t, 6, notif.TaskID)
+	assert.Equal(t, "In progress", notif.NewStatus)
+	assert.Equal(t, recipientID, notif.RecipientID)
+}
@@ -4,12 +4,15 @@ import (
 	"common/config"
 	"common/db"
 	"common/kafka"
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"log"
 	"strconv"
+	taskConfig "taskService/internal/config"
 	"taskService/internal/controllers"
 	"taskService/internal/handlers"
+	"taskService/internal/http_clients"
 	"taskService/internal/repositories"
 	"taskService/internal/routes"
 	"taskService/internal/services"
@@ -36,6 +39,21 @@ import (
 // @tag.name task-statuses
 // @tag.description Операции со статусами задач
 
+// @tag.name task-workflows
+// @tag.description Операции с workflow задач
+
+// @tag.name task-recurrences
+// @tag.description Операции с сериями повторяющихся задач
+
+// @tag.name task-templates
+// @tag.description Операции с шаблонами задач
+
+// @tag.name task-checklists
+// @tag.description Операции с чек-листами задач
+
+// @tag.name task-reports
+// @tag.description Отчёты по задачам
+
 func main() {
 	// Загружаем переменные окружения из .env файла (если существует)
 	if err := godotenv.Load(); err != nil {
@@ -72,18 +90,110 @@ func main() {
 		notificationService = nil
 	}
 
+	// Доменные события задач публикуются для чатов; без Kafka задачи работают как раньше
+	taskEventPublisher, err := services.NewTaskEventPublisher(&kafka.ProducerConfig{
+		Brokers: kafka.GetKafkaBrokers(),
+		Topic:   kafka.GetTaskEventsTopic(),
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to initialize task event publisher: %v", err)
+		taskEventPublisher = nil
+	}
+	var eventPublisher services.TaskEventPublisherInterface
+	if taskEventPublisher != nil {
+		eventPublisher = taskEventPublisher
+	}
+
 	//// Init repositories
 	taskRepo := repositories.NewTaskRepository(initDB)
 	taskFileRepo := repositories.NewTaskFileRepository(initDB)
 	taskStatusRepo := repositories.NewTaskStatusRepository(initDB)
+	taskWorkflowRepo := repositories.NewTaskWorkflowRepository(initDB)
+	taskEventRepo := repositories.NewTaskEventRepository(initDB)
+	taskCommentRepo := repositories.NewTaskCommentRepository(initDB)
+	taskDependencyRepo := repositories.NewTaskDependencyRepository(initDB)
+	labelRepo := repositories.NewLabelRepository(initDB)
+	taskMemberRepo := repositories.NewTaskMemberRepository(initDB)
+	taskRecurrenceRepo := repositories.NewTaskRecurrenceRepository(initDB)
+	taskTimeLogRepo := repositories.NewTaskTimeLogRepository(initDB)
+	taskBulkRepo := repositories.NewTaskBulkRepository(initDB)
+	taskTemplateRepo := repositories.NewTaskTemplateRepository(initDB)
+	taskChecklistRepo := repositories.NewTaskChecklistRepository(initDB)
+	taskReportRepo := repositories.NewTaskReportRepository(initDB)
+
+	//// Init services
+	chatMemberships := services.NewChatMembershipCache(http_clients.NewChatClientAdapter(), taskConfig.LoadChatMembershipCacheConfig())
 
 	//// Init controllers
-	taskController := controllers.NewTaskController(taskRepo, taskStatusRepo, taskFileRepo, notificationService)
+	taskController := controllers.NewTaskController(taskRepo, taskStatusRepo, taskFileRepo, taskWorkflowRepo, taskEventRepo, notificationService, chatMemberships)
+	taskController.EventPublisher = eventPublisher
 	taskStatusController := controllers.NewTaskStatusController(taskStatusRepo)
+	taskWorkflowController := controllers.NewTaskWorkflowController(taskWorkflowRepo, taskStatusRepo)
+	taskCommentController := controllers.NewTaskCommentController(taskCommentRepo, taskRepo, taskEventRepo, notificationService, chatMemberships)
+	taskDependencyController := controllers.NewTaskDependencyController(taskRepo, taskDependencyRepo, taskEventRepo, chatMemberships)
+	taskLabelController := controllers.NewTaskLabelController(labelRepo, taskRepo, taskEventRepo, chatMemberships)
+	taskMemberController := controllers.NewTaskMemberController(taskMemberRepo, taskRepo, taskEventRepo, notificationService, chatMemberships)
+	taskTimeLogController := controllers.NewTaskTimeLogController(taskTimeLogRepo, taskRepo, chatMemberships)
+	taskBulkController := controllers.NewTaskBulkController(taskController, labelRepo, taskBulkRepo)
+	taskTemplateController := controllers.NewTaskTemplateController(taskController, taskTemplateRepo, labelRepo)
+	taskChecklistController := controllers.NewTaskChecklistController(taskRepo, taskChecklistRepo, taskEventRepo, chatMemberships)
+	taskReportController := controllers.NewTaskReportController(taskReportRepo, chatMemberships)
+	taskRecurrenceController := controllers.NewTaskRecurrenceController(
+		taskRecurrenceRepo,
+		taskStatusRepo,
+		taskWorkflowRepo,
+		http_clients.NewUserClientAdapter(),
+		http_clients.NewChatClientAdapter(),
+		chatMemberships,
+	)
 
 	//// Init handlers
 	taskHandler := handlers.NewTaskHandler(taskController)
 	taskStatusHandler := handlers.NewTaskStatusHandler(taskStatusController)
+	taskWorkflowHandler := handlers.NewTaskWorkflowHandler(taskWorkflowController)
+	taskCommentHandler := handlers.NewTaskCommentHandler(taskCommentController)
+	taskDependencyHandler := handlers.NewTaskDependencyHandler(taskDependencyController)
+	taskLabelHandler := handlers.NewTaskLabelHandler(taskLabelController)
+	taskMemberHandler := handlers.NewTaskMemberHandler(taskMemberController)
+	taskRecurrenceHandler := handlers.NewTaskRecurrenceHandler(taskRecurrenceController)
+	taskTimeLogHandler := handlers.NewTaskTimeLogHandler(taskTimeLogController)
+	taskBulkHandler := handlers.NewTaskBulkHandler(taskBulkController)
+	taskTemplateHandler := handlers.NewTaskTemplateHandler(taskTemplateController)
+	taskChecklistHandler := handlers.NewTaskChecklistHandler(taskChecklistController)
+	taskReportHandler := handlers.NewTaskReportHandler(taskReportController)
+
+	// Напоминания о сроках задач отправляются только при доступной Kafka
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	if notificationService != nil {
+		deadlineScheduler := services.NewTaskDeadlineScheduler(
+			taskRepo,
+			http_clients.NewUserClientAdapter(),
+			notificationService,
+			taskConfig.LoadDeadlineSchedulerConfig(),
+		)
+		go deadlineScheduler.Start(schedulerCtx)
+	}
+
+	// Экземпляры повторяющихся задач создаются и без Kafka, но тогда без уведомлений исполнителю
+	var recurrenceNotifier services.NotificationServiceInterface
+	if notificationService != nil {
+		recurrenceNotifier = notificationService
+	}
+	recurrenceScheduler := services.NewTaskRecurrenceScheduler(
+		taskRecurrenceRepo,
+		taskStatusRepo,
+		taskWorkflowRepo,
+		taskEventRepo,
+		http_clients.NewUserClientAdapter(),
+		recurrenceNotifier,
+		eventPublisher,
+		taskConfig.LoadRecurrenceSchedulerConfig(),
+	)
+	go recurrenceScheduler.Start(schedulerCtx)
+
+	reportRefresher := services.NewTaskReportRefresher(taskReportRepo, taskConfig.LoadReportRefresherConfig())
+	go reportRefresher.Start(schedulerCtx)
 
 	r := gin.Default()
 
@@ -93,15 +203,31 @@ func main() {
 	})
 
 	routes.RegisterTaskStatusRoutes(r, taskStatusHandler)
+	routes.RegisterTaskWorkflowRoutes(r, taskWorkflowHandler)
 	routes.RegisterTaskRoutes(r, taskHandler)
-
-	// Graceful shutdown для Kafka producer
+	routes.RegisterTaskCommentRoutes(r, taskCommentHandler)
+	routes.RegisterTaskDependencyRoutes(r, taskDependencyHandler)
+	routes.RegisterTaskLabelRoutes(r, taskLabelHandler)
+	routes.RegisterTaskMemberRoutes(r, taskMemberHandler)
+	routes.RegisterTaskRecurrenceRoutes(r, taskRecurrenceHandler)
+	routes.RegisterTaskTimeLogRoutes(r, taskTimeLogHandler)
+	routes.RegisterTaskBulkRoutes(r, taskBulkHandler)
+	routes.RegisterTaskTemplateRoutes(r, taskTemplateHandler)
+	routes.RegisterTaskChecklistRoutes(r, taskChecklistHandler)
+	routes.RegisterTaskReportRoutes(r, taskReportHandler)
+
+	// Graceful shutdown для Kafka producers
 	defer func() {
 		if notificationService != nil {
 			if err := notificationService.Close(); err != nil {
 				log.Printf("Error closing notification service: %v", err)
 			}
 		}
+		if taskEventPublisher != nil {
+			if err := taskEventPublisher.Close(); err != nil {
+				log.Printf("Error closing task event publisher: %v", err)
+			}
+		}
 	}()
 
 	_ = r.Run(":" + strconv.Itoa(cfg.App.Port))
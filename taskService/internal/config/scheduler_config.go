@@ -0,0 +1,94 @@
+package config
+
+import (
+	commonConfig "common/config"
+	"log"
+	"strconv"
+	"time"
+)
+
+// DeadlineSchedulerConfig настройки планировщика напоминаний о сроках задач
+type DeadlineSchedulerConfig struct {
+	// Interval - период проверки сроков
+	Interval time.Duration
+	// DueSoonWindow - за сколько до срока отправляется напоминание "скоро срок"
+	DueSoonWindow time.Duration
+	// BatchSize - максимум задач каждого вида за одну проверку
+	BatchSize int
+}
+
+// LoadDeadlineSchedulerConfig читает настройки из TASK_DEADLINE_CHECK_INTERVAL, TASK_DUE_SOON_WINDOW
+// и TASK_DEADLINE_BATCH_SIZE; некорректные значения заменяются значениями по умолчанию
+func LoadDeadlineSchedulerConfig() DeadlineSchedulerConfig {
+	return DeadlineSchedulerConfig{
+		Interval:      durationFromEnv("TASK_DEADLINE_CHECK_INTERVAL", time.Minute),
+		DueSoonWindow: durationFromEnv("TASK_DUE_SOON_WINDOW", 24*time.Hour),
+		BatchSize:     intFromEnv("TASK_DEADLINE_BATCH_SIZE", 100),
+	}
+}
+
+// RecurrenceSchedulerConfig настройки планировщика экземпляров повторяющихся задач
+type RecurrenceSchedulerConfig struct {
+	// Interval - период проверки серий
+	Interval time.Duration
+	// Horizon - на сколько вперёд создаются экземпляры
+	Horizon time.Duration
+	// BatchSize - максимум серий за одну проверку
+	BatchSize int
+}
+
+// LoadRecurrenceSchedulerConfig читает настройки из TASK_RECURRENCE_CHECK_INTERVAL, TASK_RECURRENCE_HORIZON
+// и TASK_RECURRENCE_BATCH_SIZE; некорректные значения заменяются значениями по умолчанию
+func LoadRecurrenceSchedulerConfig() RecurrenceSchedulerConfig {
+	return RecurrenceSchedulerConfig{
+		Interval:  durationFromEnv("TASK_RECURRENCE_CHECK_INTERVAL", 5*time.Minute),
+		Horizon:   durationFromEnv("TASK_RECURRENCE_HORIZON", 7*24*time.Hour),
+		BatchSize: intFromEnv("TASK_RECURRENCE_BATCH_SIZE", 100),
+	}
+}
+
+// ReportRefresherConfig настройки обновления данных отчётов по задачам
+type ReportRefresherConfig struct {
+	// Interval - период обновления; на столько могут отставать отчёты по истории статусов
+	Interval time.Duration
+}
+
+// LoadReportRefresherConfig читает настройки из TASK_REPORT_REFRESH_INTERVAL; некорректное значение
+// заменяется значением по умолчанию
+func LoadReportRefresherConfig() ReportRefresherConfig {
+	return ReportRefresherConfig{
+		Interval: durationFromEnv("TASK_REPORT_REFRESH_INTERVAL", 10*time.Minute),
+	}
+}
+
+// ChatMembershipCacheConfig настройки кэша участия пользователей в чатах
+type ChatMembershipCacheConfig struct {
+	// TTL - сколько хранится список чатов пользователя; на столько может запаздывать доступ к задачам чата
+	TTL time.Duration
+}
+
+// LoadChatMembershipCacheConfig читает настройки из TASK_CHAT_MEMBERSHIP_CACHE_TTL; некорректное значение
+// заменяется значением по умолчанию
+func LoadChatMembershipCacheConfig() ChatMembershipCacheConfig {
+	return ChatMembershipCacheConfig{
+		TTL: durationFromEnv("TASK_CHAT_MEMBERSHIP_CACHE_TTL", time.Minute),
+	}
+}
+
+func durationFromEnv(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(commonConfig.GetEnvOrDefault(key, defaultValue.String()))
+	if err != nil || value <= 0 {
+		log.Printf("Invalid %s, using default %s", key, defaultValue)
+		return defaultValue
+	}
+	return value
+}
+
+func intFromEnv(key string, defaultValue int) int {
+	value, err := strconv.Atoi(commonConfig.GetEnvOrDefault(key, strconv.Itoa(defaultValue)))
+	if err != nil || value <= 0 {
+		log.Printf("Invalid %s, using default %d", key, defaultValue)
+		return defaultValue
+	}
+	return value
+}
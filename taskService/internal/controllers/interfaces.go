@@ -3,20 +3,128 @@ package controllers
 import (
 	"taskService/internal/handlers/dto"
 	"taskService/internal/models"
+
+	"github.com/google/uuid"
 )
 
 // TaskControllerInterface - интерфейс для TaskController для возможности мокирования
 type TaskControllerInterface interface {
-	Create(taskDTO *dto.CreateTaskDTO) (*models.Task, error)
-	UpdateStatus(taskID, statusID int) error
-	GetByID(taskID int) (*dto.TaskResponse, error)
-	GetUserTasks(userID string, limit, offset int) (*[]dto.TaskToList, error)
+	Create(actor *dto.Actor, taskDTO *dto.CreateTaskDTO) (*models.Task, error)
+	CreateFromMessage(actor *dto.Actor, fromMessageDTO *dto.CreateTaskFromMessageDTO) (*models.Task, error)
+	UpdateStatus(taskID, statusID int, actor *dto.Actor) error
+	GetByID(taskID int, actor *dto.Actor) (*dto.TaskResponse, error)
+	GetUserTasks(userID string, actor *dto.Actor, filter *dto.TaskListFilter, limit, offset int) (*[]dto.TaskToList, error)
+	QueryTasks(actor *dto.Actor, query *dto.TaskQuery) (*dto.TaskQueryResult, error)
+	GetBoard(actor *dto.Actor, query *dto.TaskBoardQuery) (*dto.TaskBoard, error)
+	MoveTask(taskID int, actor *dto.Actor, moveDTO *dto.MoveTaskDTO) error
+	Update(taskID int, actor *dto.Actor, updateDTO *dto.UpdateTaskDTO) (*models.Task, error)
+	Delete(taskID int, actor *dto.Actor) error
+	GetCreatedTasks(userID string, actor *dto.Actor, limit, offset int) (*[]dto.TaskToList, error)
+	GetChatTasks(chatID string, actor *dto.Actor, limit, offset int) (*[]dto.TaskToList, error)
+	GetHistory(taskID int, actor *dto.Actor, limit, offset int) (*[]dto.TaskActivity, error)
+	GetUserActivity(userID string, actor *dto.Actor, limit, offset int) (*[]dto.TaskActivity, error)
+}
+
+// TaskCommentControllerInterface - интерфейс для TaskCommentController для возможности мокирования
+type TaskCommentControllerInterface interface {
+	Create(taskID int, actor *dto.Actor, commentDTO *dto.CreateTaskCommentDTO) (*models.TaskComment, error)
+	Update(taskID, commentID int, actor *dto.Actor, updateDTO *dto.UpdateTaskCommentDTO) (*models.TaskComment, error)
+	Delete(taskID, commentID int, actor *dto.Actor) error
+	GetByTaskID(taskID int, actor *dto.Actor, limit, offset int) ([]models.TaskComment, error)
+}
+
+// TaskDependencyControllerInterface - интерфейс для TaskDependencyController для возможности мокирования
+type TaskDependencyControllerInterface interface {
+	GetTree(taskID int, actor *dto.Actor) (*dto.TaskTreeNode, error)
+	AddDependency(blockedTaskID int, actor *dto.Actor, dependencyDTO *dto.AddTaskDependencyDTO) error
+	RemoveDependency(blockedTaskID, blockerTaskID int, actor *dto.Actor) error
+	GetDependencyGraph(taskID int, actor *dto.Actor) (*dto.TaskDependencyGraph, error)
+}
+
+// TaskLabelControllerInterface - интерфейс для TaskLabelController для возможности мокирования
+type TaskLabelControllerInterface interface {
+	Create(labelDTO *dto.SaveLabelDTO) (*dto.LabelResponse, error)
+	Update(id int, labelDTO *dto.SaveLabelDTO) (*dto.LabelResponse, error)
+	GetByID(id int) (*dto.LabelResponse, error)
+	GetAll() ([]dto.LabelResponse, error)
+	DeleteByID(id int) error
+	AddToTask(taskID, labelID int, actor *dto.Actor) error
+	RemoveFromTask(taskID, labelID int, actor *dto.Actor) error
+	GetTaskLabels(taskID int, actor *dto.Actor) ([]models.Label, error)
+}
+
+// TaskMemberControllerInterface - интерфейс для TaskMemberController для возможности мокирования
+type TaskMemberControllerInterface interface {
+	AddAssignee(taskID int, userID uuid.UUID, actor *dto.Actor) error
+	RemoveAssignee(taskID int, userID uuid.UUID, actor *dto.Actor) error
+	AddWatcher(taskID int, userID uuid.UUID, actor *dto.Actor) error
+	RemoveWatcher(taskID int, userID uuid.UUID, actor *dto.Actor) error
+}
+
+// TaskTimeLogControllerInterface - интерфейс для TaskTimeLogController для возможности мокирования
+type TaskTimeLogControllerInterface interface {
+	StartTimer(taskID int, actor *dto.Actor) (*models.TaskTimeLog, error)
+	StopTimer(taskID int, actor *dto.Actor) (*models.TaskTimeLog, error)
+	Create(taskID int, actor *dto.Actor, logDTO *dto.CreateTaskTimeLogDTO) (*models.TaskTimeLog, error)
+	Update(taskID, logID int, actor *dto.Actor, updateDTO *dto.UpdateTaskTimeLogDTO) (*models.TaskTimeLog, error)
+	Delete(taskID, logID int, actor *dto.Actor) error
+	GetByTaskID(taskID int, actor *dto.Actor, limit, offset int) ([]models.TaskTimeLog, error)
+	GetTimeSpent(actor *dto.Actor, query *dto.TimeSpentQuery) ([]dto.TimeSpent, error)
+}
+
+// TaskReportControllerInterface - интерфейс для TaskReportController для возможности мокирования
+type TaskReportControllerInterface interface {
+	GetThroughput(actor *dto.Actor, query *dto.TaskReportQuery) ([]dto.TaskThroughput, error)
+	GetStatusDurations(actor *dto.Actor, query *dto.TaskReportQuery) ([]dto.TaskStatusDuration, error)
+	GetOverdue(actor *dto.Actor, chatID *uuid.UUID) ([]dto.TaskOverdueCount, error)
+	GetCumulativeFlow(actor *dto.Actor, query *dto.TaskReportQuery) ([]dto.TaskCumulativeFlow, error)
+}
+
+// TaskBulkControllerInterface - интерфейс для TaskBulkController для возможности мокирования
+type TaskBulkControllerInterface interface {
+	Apply(actor *dto.Actor, bulkDTO *dto.TaskBulkDTO) (*dto.TaskBulkResult, error)
 }
 
 // TaskStatusControllerInterface - интерфейс для TaskStatusController для возможности мокирования
 type TaskStatusControllerInterface interface {
 	Create(name string) (*models.TaskStatus, error)
 	GetByID(id int) (*models.TaskStatus, error)
-	DeleteByID(id int) error
+	GetUsage(id int) (*dto.TaskStatusUsage, error)
+	DeleteByID(id int, replacementID *int, actor *dto.Actor) error
 	GetAll() ([]models.TaskStatus, error)
 }
+
+// TaskWorkflowControllerInterface - интерфейс для TaskWorkflowController для возможности мокирования
+type TaskWorkflowControllerInterface interface {
+	Create(workflowDTO *dto.SaveWorkflowDTO) (*models.TaskWorkflow, error)
+	Update(id int, workflowDTO *dto.SaveWorkflowDTO) (*models.TaskWorkflow, error)
+	GetByID(id int) (*models.TaskWorkflow, error)
+	GetAll() ([]models.TaskWorkflow, error)
+	DeleteByID(id int) error
+}
+
+// TaskRecurrenceControllerInterface - интерфейс для TaskRecurrenceController для возможности мокирования
+type TaskRecurrenceControllerInterface interface {
+	Create(actor *dto.Actor, recurrenceDTO *dto.SaveTaskRecurrenceDTO) (*models.TaskRecurrence, error)
+	GetByID(id int, actor *dto.Actor) (*models.TaskRecurrence, error)
+	Update(id int, actor *dto.Actor, recurrenceDTO *dto.SaveTaskRecurrenceDTO) (*models.TaskRecurrence, error)
+	Delete(id int, actor *dto.Actor) error
+}
+
+// TaskTemplateControllerInterface - интерфейс для TaskTemplateController для возможности мокирования
+type TaskTemplateControllerInterface interface {
+	Create(actor *dto.Actor, templateDTO *dto.SaveTaskTemplateDTO) (*models.TaskTemplate, error)
+	GetByID(id int, actor *dto.Actor) (*models.TaskTemplate, error)
+	GetAll(actor *dto.Actor) ([]models.TaskTemplate, error)
+	Update(id int, actor *dto.Actor, templateDTO *dto.SaveTaskTemplateDTO) (*models.TaskTemplate, error)
+	Delete(id int, actor *dto.Actor) error
+	CreateTask(id int, actor *dto.Actor, fromTemplateDTO *dto.CreateTaskFromTemplateDTO) (*models.Task, error)
+}
+
+// TaskChecklistControllerInterface - интерфейс для TaskChecklistController для возможности мокирования
+type TaskChecklistControllerInterface interface {
+	GetByTaskID(taskID int, actor *dto.Actor) ([]models.TaskChecklistItem, error)
+	AddItem(taskID int, actor *dto.Actor, itemDTO *dto.AddChecklistItemDTO) (*models.TaskChecklistItem, error)
+	UpdateItem(taskID, itemID int, actor *dto.Actor, updateDTO *dto.UpdateChecklistItemDTO) (*models.TaskChecklistItem, error)
+	RemoveItem(taskID, itemID int, actor *dto.Actor) error
+}
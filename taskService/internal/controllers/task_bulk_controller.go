@@ -0,0 +1,293 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	customErrors "taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+// TaskBulkController применяет одно действие к списку задач. Каждая задача проверяется так же,
+// как одиночным запросом: права, правила workflow и блокирующие задачи
+type TaskBulkController struct {
+	tasks     *TaskController
+	labelRepo repositories.LabelRepository
+	bulkRepo  repositories.TaskBulkRepository
+}
+
+func NewTaskBulkController(
+	tasks *TaskController,
+	labelRepo repositories.LabelRepository,
+	bulkRepo repositories.TaskBulkRepository,
+) *TaskBulkController {
+	return &TaskBulkController{
+		tasks:     tasks,
+		labelRepo: labelRepo,
+		bulkRepo:  bulkRepo,
+	}
+}
+
+// bulkTarget - общий для всех задач параметр действия, проверенный до обработки задач
+type bulkTarget struct {
+	status        *models.TaskStatus
+	executorID    uuid.UUID
+	executorEmail string
+	labels        []dto.LabelResponse
+}
+
+// plannedChange - задача, прошедшая проверку; пустое change означает, что менять нечего
+type plannedChange struct {
+	item      int
+	task      *models.Task
+	oldStatus string
+	change    dto.TaskBulkChange
+}
+
+func (p *plannedChange) empty() bool {
+	return p.change.StatusID == nil && p.change.ExecutorID == nil && len(p.change.LabelIDs) == 0 && !p.change.Delete
+}
+
+// Apply применяет действие к задачам и возвращает результат по каждой из них. Повторы ID учитываются один раз.
+// Без atomic каждая задача меняется в своей транзакции и ошибка одной не мешает остальным.
+// С atomic изменения сохраняются одной транзакцией и только если проверку прошли все задачи:
+// иначе не меняется ни одна. Уведомления и доменные события отправляются после сохранения
+func (c *TaskBulkController) Apply(actor *dto.Actor, bulkDTO *dto.TaskBulkDTO) (*dto.TaskBulkResult, error) {
+	target, err := c.resolveTarget(bulkDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	var taskIDs []int
+	for _, taskID := range bulkDTO.TaskIDs {
+		if !slices.Contains(taskIDs, taskID) {
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+
+	result := &dto.TaskBulkResult{Atomic: bulkDTO.Atomic, Items: make([]dto.TaskBulkItemResult, len(taskIDs))}
+	var planned []*plannedChange
+	for i, taskID := range taskIDs {
+		result.Items[i].TaskID = taskID
+		change, err := c.plan(taskID, bulkDTO.Action, target, actor)
+		if err != nil {
+			result.Items[i].Error = err.Error()
+			continue
+		}
+		change.item = i
+		planned = append(planned, change)
+	}
+
+	var applied []*plannedChange
+	if bulkDTO.Atomic {
+		applied, err = c.applyAtomic(planned, result)
+	} else {
+		applied = c.applyEach(planned, result)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range applied {
+		result.Items[change.item].Success = true
+		c.changeApplied(change, target, actor)
+	}
+	for _, item := range result.Items {
+		if item.Success {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// resolveTarget проверяет параметр действия: статус, исполнителя или метки
+func (c *TaskBulkController) resolveTarget(bulkDTO *dto.TaskBulkDTO) (*bulkTarget, error) {
+	target := &bulkTarget{}
+	switch bulkDTO.Action {
+	case dto.TaskBulkChangeStatus:
+		if bulkDTO.StatusID == nil {
+			return nil, customErrors.NewInvalidTaskBulkError(bulkDTO.Action, "status_id is required")
+		}
+		status, err := c.tasks.TaskStatusRepo.GetByID(*bulkDTO.StatusID)
+		if err != nil {
+			return nil, customErrors.NewTaskStatusNotFoundError(strconv.Itoa(*bulkDTO.StatusID))
+		}
+		target.status = status
+	case dto.TaskBulkReassign:
+		if bulkDTO.ExecutorID == nil {
+			return nil, customErrors.NewInvalidTaskBulkError(bulkDTO.Action, "executor_id is required")
+		}
+		target.executorID = *bulkDTO.ExecutorID
+		if target.executorID != uuid.Nil {
+			executor, err := c.tasks.UserClient.GetUserByID(bulkDTO.ExecutorID)
+			if err != nil {
+				return nil, customErrors.NewGetUserHTTPError(bulkDTO.ExecutorID.String(), err.Error())
+			}
+			if executor.User != nil {
+				target.executorEmail = executor.User.Email
+			}
+		}
+	case dto.TaskBulkAddLabels:
+		if len(bulkDTO.LabelIDs) == 0 {
+			return nil, customErrors.NewInvalidTaskBulkError(bulkDTO.Action, "label_ids is required")
+		}
+		for _, labelID := range bulkDTO.LabelIDs {
+			if slices.ContainsFunc(target.labels, func(label dto.LabelResponse) bool { return label.ID == labelID }) {
+				continue
+			}
+			label, err := c.labelRepo.GetByID(labelID)
+			if err != nil {
+				return nil, customErrors.NewLabelNotFoundError(labelID)
+			}
+			target.labels = append(target.labels, *label)
+		}
+	}
+	return target, nil
+}
+
+// plan проверяет, что actor может применить действие к задаче, и готовит изменение с событиями истории
+func (c *TaskBulkController) plan(taskID int, action string, target *bulkTarget, actor *dto.Actor) (*plannedChange, error) {
+	if action == dto.TaskBulkChangeStatus {
+		task, err := findVisibleTask(c.tasks.TaskRepo, c.tasks.ChatMemberships, taskID, actor)
+		if err != nil {
+			return nil, err
+		}
+		planned := &plannedChange{task: task, change: dto.TaskBulkChange{TaskID: taskID}}
+		// Как и при одиночной смене, перевод в текущий статус ничего не меняет
+		if task.StatusID == target.status.ID {
+			return planned, nil
+		}
+		if err := c.tasks.checkStatusChange(task, target.status.ID, actor); err != nil {
+			return nil, err
+		}
+		planned.oldStatus = strconv.Itoa(task.StatusID)
+		if task.Status != nil {
+			planned.oldStatus = task.Status.Name
+		}
+		planned.change.StatusID = &target.status.ID
+		planned.change.FromStatusID = &task.StatusID
+		planned.change.Events = []models.TaskEvent{
+			newTaskEvent(taskID, actor.UserID, models.TaskEventStatusChanged, nil, planned.oldStatus, target.status.Name),
+		}
+		return planned, nil
+	}
+
+	task, err := findTaskForModification(c.tasks.TaskRepo, c.tasks.ChatMemberships, taskID, actor)
+	if err != nil {
+		return nil, err
+	}
+	planned := &plannedChange{task: task, change: dto.TaskBulkChange{TaskID: taskID}}
+
+	switch action {
+	case dto.TaskBulkReassign:
+		if task.ExecutorID == target.executorID {
+			return planned, nil
+		}
+		planned.change.ExecutorID = &target.executorID
+		planned.change.Events = []models.TaskEvent{
+			newTaskEvent(taskID, actor.UserID, models.TaskEventReassigned, nil, uuidValue(task.ExecutorID), uuidValue(target.executorID)),
+		}
+	case dto.TaskBulkAddLabels:
+		current, err := c.labelRepo.GetByTaskID(taskID)
+		if err != nil {
+			return nil, err
+		}
+		for _, label := range target.labels {
+			if slices.ContainsFunc(current, func(existing models.Label) bool { return existing.ID == label.ID }) {
+				continue
+			}
+			planned.change.LabelIDs = append(planned.change.LabelIDs, label.ID)
+			planned.change.Events = append(planned.change.Events,
+				newTaskEvent(taskID, actor.UserID, models.TaskEventLabelAdded, nil, "", label.Name))
+		}
+	case dto.TaskBulkDelete:
+		planned.change.Delete = true
+	}
+	return planned, nil
+}
+
+// applyEach сохраняет каждую задачу в своей транзакции
+func (c *TaskBulkController) applyEach(planned []*plannedChange, result *dto.TaskBulkResult) []*plannedChange {
+	var applied []*plannedChange
+	for _, change := range planned {
+		if !change.empty() {
+			if err := c.bulkRepo.Apply([]dto.TaskBulkChange{change.change}); err != nil {
+				result.Items[change.item].Error = bulkChangeReason(err)
+				continue
+			}
+		}
+		applied = append(applied, change)
+	}
+	return applied
+}
+
+// applyAtomic сохраняет все задачи одной транзакцией, если ни одна не провалила проверку
+func (c *TaskBulkController) applyAtomic(planned []*plannedChange, result *dto.TaskBulkResult) ([]*plannedChange, error) {
+	if len(planned) < len(result.Items) {
+		for _, change := range planned {
+			result.Items[change.item].Error = "not applied: other tasks failed validation"
+		}
+		return nil, nil
+	}
+
+	var changes []dto.TaskBulkChange
+	for _, change := range planned {
+		if !change.empty() {
+			changes = append(changes, change.change)
+		}
+	}
+	if len(changes) == 0 {
+		return planned, nil
+	}
+
+	err := c.bulkRepo.Apply(changes)
+	if err == nil {
+		return planned, nil
+	}
+	var changeErr *customErrors.TaskBulkChangeError
+	if !errors.As(err, &changeErr) {
+		return nil, err
+	}
+	for _, change := range planned {
+		if change.change.TaskID == changeErr.TaskID {
+			result.Items[change.item].Error = changeErr.Err.Error()
+		} else {
+			result.Items[change.item].Error = fmt.Sprintf("rolled back: change of task %d failed", changeErr.TaskID)
+		}
+	}
+	return nil, nil
+}
+
+// changeApplied публикует события сохранённого изменения и уведомляет участников задачи
+func (c *TaskBulkController) changeApplied(planned *plannedChange, target *bulkTarget, actor *dto.Actor) {
+	task := planned.task
+	if c.tasks.EventPublisher != nil && len(planned.change.Events) > 0 {
+		c.tasks.EventPublisher.Publish(task, planned.change.Events)
+	}
+
+	switch {
+	case planned.change.StatusID != nil:
+		c.tasks.notifyStatusChanged(task, planned.oldStatus, target.status.Name, actor)
+	case planned.change.ExecutorID != nil:
+		task.ExecutorID = target.executorID
+		if target.executorEmail != "" {
+			c.tasks.notifyReassigned(task, actor, target.executorEmail)
+		}
+	}
+}
+
+// bulkChangeReason возвращает причину, по которой изменение задачи не сохранено
+func bulkChangeReason(err error) string {
+	var changeErr *customErrors.TaskBulkChangeError
+	if errors.As(err, &changeErr) {
+		return changeErr.Err.Error()
+	}
+	return err.Error()
+}
@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"slices"
+	customErrors "taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+	"taskService/internal/services"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TaskChecklistController управляет чек-листом задачи. Менять чек-лист могут те же пользователи,
+// что и редактировать задачу
+type TaskChecklistController struct {
+	taskRepo      repositories.TaskRepository
+	checklistRepo repositories.TaskChecklistRepository
+	taskEventRepo repositories.TaskEventRepository
+	// chatMemberships - чаты пользователя для проверки видимости задачи
+	chatMemberships services.ChatMembershipServiceInterface
+}
+
+func NewTaskChecklistController(
+	taskRepo repositories.TaskRepository,
+	checklistRepo repositories.TaskChecklistRepository,
+	taskEventRepo repositories.TaskEventRepository,
+	chatMemberships services.ChatMembershipServiceInterface,
+) *TaskChecklistController {
+	return &TaskChecklistController{
+		taskRepo:        taskRepo,
+		checklistRepo:   checklistRepo,
+		taskEventRepo:   taskEventRepo,
+		chatMemberships: chatMemberships,
+	}
+}
+
+// GetByTaskID возвращает пункты чек-листа задачи по порядку
+func (c *TaskChecklistController) GetByTaskID(taskID int, actor *dto.Actor) ([]models.TaskChecklistItem, error) {
+	if _, err := findVisibleTask(c.taskRepo, c.chatMemberships, taskID, actor); err != nil {
+		return nil, err
+	}
+	return c.checklistRepo.GetByTaskID(taskID)
+}
+
+// AddItem добавляет пункт в конец чек-листа
+func (c *TaskChecklistController) AddItem(taskID int, actor *dto.Actor, itemDTO *dto.AddChecklistItemDTO) (*models.TaskChecklistItem, error) {
+	task, err := findTaskForModification(c.taskRepo, c.chatMemberships, taskID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return nil, err
+	}
+
+	item := &models.TaskChecklistItem{TaskID: taskID, Title: itemDTO.Title}
+	if err := c.checklistRepo.Create(item); err != nil {
+		return nil, err
+	}
+	c.recordEvents(newTaskEvent(taskID, actor.UserID, models.TaskEventChecklistAdded, nil, "", item.Title))
+	return item, nil
+}
+
+// UpdateItem переименовывает пункт, отмечает его выполненным или снимает отметку и переставляет его
+// на позицию Position; остальные пункты сдвигаются
+func (c *TaskChecklistController) UpdateItem(taskID, itemID int, actor *dto.Actor, updateDTO *dto.UpdateChecklistItemDTO) (*models.TaskChecklistItem, error) {
+	task, err := findTaskForModification(c.taskRepo, c.chatMemberships, taskID, actor)
+	if err != nil {
+		return nil, err
+	}
+	item, err := c.getItem(taskID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return nil, err
+	}
+
+	var events []models.TaskEvent
+	if updateDTO.Title != nil {
+		item.Title = *updateDTO.Title
+	}
+	if updateDTO.Done != nil && *updateDTO.Done != item.Done {
+		item.Done = *updateDTO.Done
+		if item.Done {
+			now := time.Now()
+			item.DoneBy = &actor.UserID
+			item.DoneAt = &now
+			events = append(events, newTaskEvent(taskID, actor.UserID, models.TaskEventChecklistDone, nil, "", item.Title))
+		} else {
+			item.DoneBy = nil
+			item.DoneAt = nil
+			events = append(events, newTaskEvent(taskID, actor.UserID, models.TaskEventChecklistReopened, nil, "", item.Title))
+		}
+	}
+
+	now := time.Now()
+	item.UpdatedAt = &now
+	if err := c.checklistRepo.Update(item); err != nil {
+		return nil, err
+	}
+
+	if updateDTO.Position != nil {
+		if err := c.move(item, *updateDTO.Position); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(events) > 0 {
+		c.recordEvents(events...)
+	}
+	return item, nil
+}
+
+// RemoveItem удаляет пункт из чек-листа
+func (c *TaskChecklistController) RemoveItem(taskID, itemID int, actor *dto.Actor) error {
+	task, err := findTaskForModification(c.taskRepo, c.chatMemberships, taskID, actor)
+	if err != nil {
+		return err
+	}
+	item, err := c.getItem(taskID, itemID)
+	if err != nil {
+		return err
+	}
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return err
+	}
+
+	if err := c.checklistRepo.Delete(taskID, itemID); err != nil {
+		return err
+	}
+	c.recordEvents(newTaskEvent(taskID, actor.UserID, models.TaskEventChecklistRemoved, nil, item.Title, ""))
+	return nil
+}
+
+func (c *TaskChecklistController) getItem(taskID, itemID int) (*models.TaskChecklistItem, error) {
+	item, err := c.checklistRepo.GetByID(taskID, itemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewChecklistItemNotFoundError(taskID, itemID)
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// move переставляет пункт на позицию position; позиция за концом списка означает конец
+func (c *TaskChecklistController) move(item *models.TaskChecklistItem, position int) error {
+	items, err := c.checklistRepo.GetByTaskID(item.TaskID)
+	if err != nil {
+		return err
+	}
+
+	itemIDs := make([]int, 0, len(items))
+	for _, existing := range items {
+		if existing.ID != item.ID {
+			itemIDs = append(itemIDs, existing.ID)
+		}
+	}
+	if position > len(itemIDs) {
+		position = len(itemIDs)
+	}
+	itemIDs = slices.Insert(itemIDs, position, item.ID)
+
+	if err := c.checklistRepo.Reorder(item.TaskID, itemIDs); err != nil {
+		return err
+	}
+	item.Position = position
+	return nil
+}
+
+// recordEvents сохраняет события истории; ошибка записи не отменяет изменение чек-листа
+func (c *TaskChecklistController) recordEvents(events ...models.TaskEvent) {
+	if err := c.taskEventRepo.Create(events); err != nil {
+		log.Printf("Failed to record task events: %v", err)
+	}
+}
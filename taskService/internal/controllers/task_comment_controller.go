@@ -0,0 +1,383 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	customErrors "taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/http_clients"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+	"taskService/internal/services"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxCommentMentions - не больше упоминаний, чем пользовательский сервис отдаёт одним пакетным запросом
+	maxCommentMentions = 100
+	// commentExcerptLength - сколько символов комментария попадает в письмо
+	commentExcerptLength = 500
+)
+
+type TaskCommentController struct {
+	commentRepo         repositories.TaskCommentRepository
+	taskRepo            repositories.TaskRepository
+	taskEventRepo       repositories.TaskEventRepository
+	notificationService services.NotificationServiceInterface
+	userClient          http_clients.UserClientInterface
+	fileClient          http_clients.FileClientInterface
+	chatMemberships     services.ChatMembershipServiceInterface
+}
+
+func NewTaskCommentController(
+	commentRepo repositories.TaskCommentRepository,
+	taskRepo repositories.TaskRepository,
+	taskEventRepo repositories.TaskEventRepository,
+	notificationService services.NotificationServiceInterface,
+	chatMemberships services.ChatMembershipServiceInterface,
+) *TaskCommentController {
+	return NewTaskCommentControllerWithClients(
+		commentRepo,
+		taskRepo,
+		taskEventRepo,
+		notificationService,
+		http_clients.NewUserClientAdapter(),
+		http_clients.NewFileClientAdapter(),
+		chatMemberships,
+	)
+}
+
+// NewTaskCommentControllerWithClients создает контроллер с указанными HTTP клиентами (для тестирования)
+func NewTaskCommentControllerWithClients(
+	commentRepo repositories.TaskCommentRepository,
+	taskRepo repositories.TaskRepository,
+	taskEventRepo repositories.TaskEventRepository,
+	notificationService services.NotificationServiceInterface,
+	userClient http_clients.UserClientInterface,
+	fileClient http_clients.FileClientInterface,
+	chatMemberships services.ChatMembershipServiceInterface,
+) *TaskCommentController {
+	return &TaskCommentController{
+		commentRepo:         commentRepo,
+		taskRepo:            taskRepo,
+		taskEventRepo:       taskEventRepo,
+		notificationService: notificationService,
+		userClient:          userClient,
+		fileClient:          fileClient,
+		chatMemberships:     chatMemberships,
+	}
+}
+
+// Create добавляет комментарий к задаче. Создатель, исполнители, наблюдатели и прежние участники обсуждения
+// получают уведомление о комментарии, упомянутые через @username - об упоминании
+func (c *TaskCommentController) Create(taskID int, actor *dto.Actor, commentDTO *dto.CreateTaskCommentDTO) (*models.TaskComment, error) {
+	task, err := findVisibleTask(c.taskRepo, c.chatMemberships, taskID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fileID := range commentDTO.FileIDs {
+		if _, errFile := c.fileClient.GetFileByID(fileID); errFile != nil {
+			return nil, customErrors.NewGetFileHTTPError(fileID, errFile.Error())
+		}
+	}
+
+	mentioned, err := c.resolveMentions(commentDTO.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := c.commentRepo.GetAuthorIDs(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &models.TaskComment{
+		TaskID:   taskID,
+		AuthorID: actor.UserID,
+		Body:     commentDTO.Body,
+	}
+	for _, fileID := range commentDTO.FileIDs {
+		comment.Files = append(comment.Files, models.TaskCommentFile{FileID: fileID})
+	}
+	for _, userID := range mentioned {
+		comment.Mentions = append(comment.Mentions, models.TaskCommentMention{UserID: userID})
+	}
+
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return nil, err
+	}
+	if err := c.commentRepo.Create(comment); err != nil {
+		return nil, err
+	}
+
+	c.recordEvents(newTaskEvent(taskID, actor.UserID, models.TaskEventCommented, nil, "", strconv.Itoa(comment.ID)))
+
+	subscribers := append([]uuid.UUID{task.CreatorID}, taskSubscribers(task)...)
+	subscribers = append(subscribers, participants...)
+	c.notify(task, comment, subscribers, mentioned)
+
+	return comment, nil
+}
+
+// Update редактирует комментарий. Доступно только автору; уведомление получают лишь
+// пользователи, впервые упомянутые при правке
+func (c *TaskCommentController) Update(taskID, commentID int, actor *dto.Actor, updateDTO *dto.UpdateTaskCommentDTO) (*models.TaskComment, error) {
+	task, comment, err := c.getComment(taskID, commentID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != actor.UserID {
+		return nil, customErrors.NewTaskCommentAccessDeniedError(commentID, actor.UserID.String())
+	}
+
+	existingFiles := make(map[int]bool, len(comment.Files))
+	for _, file := range comment.Files {
+		existingFiles[file.FileID] = true
+	}
+
+	var addFiles []models.TaskCommentFile
+	for _, fileID := range updateDTO.AddFileIDs {
+		if existingFiles[fileID] {
+			continue
+		}
+		if _, errFile := c.fileClient.GetFileByID(fileID); errFile != nil {
+			return nil, customErrors.NewGetFileHTTPError(fileID, errFile.Error())
+		}
+		existingFiles[fileID] = true
+		addFiles = append(addFiles, models.TaskCommentFile{CommentID: commentID, FileID: fileID})
+	}
+
+	var newlyMentioned []uuid.UUID
+	if updateDTO.Body != nil {
+		mentioned, err := c.resolveMentions(*updateDTO.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		previous := make(map[uuid.UUID]bool, len(comment.Mentions))
+		for _, mention := range comment.Mentions {
+			previous[mention.UserID] = true
+		}
+
+		comment.Body = *updateDTO.Body
+		comment.Mentions = nil
+		for _, userID := range mentioned {
+			comment.Mentions = append(comment.Mentions, models.TaskCommentMention{CommentID: commentID, UserID: userID})
+			if !previous[userID] {
+				newlyMentioned = append(newlyMentioned, userID)
+			}
+		}
+	}
+
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	comment.UpdatedAt = &now
+
+	if err := c.commentRepo.Update(comment, addFiles, updateDTO.RemoveFileIDs); err != nil {
+		return nil, err
+	}
+
+	removed := make(map[int]bool, len(updateDTO.RemoveFileIDs))
+	for _, fileID := range updateDTO.RemoveFileIDs {
+		removed[fileID] = true
+	}
+	files := make([]models.TaskCommentFile, 0, len(comment.Files)+len(addFiles))
+	for _, file := range append(comment.Files, addFiles...) {
+		if !removed[file.FileID] {
+			files = append(files, file)
+		}
+	}
+	comment.Files = files
+
+	c.notify(task, comment, nil, newlyMentioned)
+
+	return comment, nil
+}
+
+// Delete мягко удаляет комментарий. Доступно автору и пользователям с правом manage_all_tasks
+func (c *TaskCommentController) Delete(taskID, commentID int, actor *dto.Actor) error {
+	task, comment, err := c.getComment(taskID, commentID, actor)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != actor.UserID && !actor.HasPermission(dto.PermissionManageAllTasks) {
+		return customErrors.NewTaskCommentAccessDeniedError(commentID, actor.UserID.String())
+	}
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return err
+	}
+	return c.commentRepo.Delete(commentID)
+}
+
+// GetByTaskID возвращает комментарии задачи в порядке обсуждения
+func (c *TaskCommentController) GetByTaskID(taskID int, actor *dto.Actor, limit, offset int) ([]models.TaskComment, error) {
+	if _, err := findVisibleTask(c.taskRepo, c.chatMemberships, taskID, actor); err != nil {
+		return nil, err
+	}
+	return c.commentRepo.GetByTaskID(taskID, limit, offset)
+}
+
+// getComment загружает видимую actor задачу и её комментарий, проверяя, что он относится к этой задаче
+func (c *TaskCommentController) getComment(taskID, commentID int, actor *dto.Actor) (*models.Task, *models.TaskComment, error) {
+	task, err := findVisibleTask(c.taskRepo, c.chatMemberships, taskID, actor)
+	if err != nil {
+		return nil, nil, err
+	}
+	comment, err := c.commentRepo.GetByID(commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, customErrors.NewTaskCommentNotFoundError(commentID)
+		}
+		return nil, nil, err
+	}
+	if comment.TaskID != taskID {
+		return nil, nil, customErrors.NewTaskCommentNotFoundError(commentID)
+	}
+	return task, comment, nil
+}
+
+// resolveMentions находит пользователей, упомянутых в тексте; неизвестные имена остаются обычным текстом
+func (c *TaskCommentController) resolveMentions(body string) ([]uuid.UUID, error) {
+	usernames := parseMentions(body)
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	resp, err := c.userClient.GetUsersByUsernames(usernames)
+	if err != nil {
+		return nil, customErrors.NewGetUserHTTPError("@"+strings.Join(usernames, ", @"), err.Error())
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(resp.Users))
+	for _, user := range resp.Users {
+		if user != nil {
+			userIDs = append(userIDs, user.ID)
+		}
+	}
+	return userIDs, nil
+}
+
+// notify рассылает уведомления о комментарии подписчикам задачи и упомянутым пользователям.
+// Автор комментария уведомлений не получает, упомянутые пользователи, которые не видят задачу, - тоже;
+// ошибки только логируются
+func (c *TaskCommentController) notify(task *models.Task, comment *models.TaskComment, subscribers, mentioned []uuid.UUID) {
+	recipients := make(map[uuid.UUID]bool)
+	var recipientIDs []uuid.UUID
+	add := func(userID uuid.UUID, isMention bool) {
+		if userID == uuid.Nil || userID == comment.AuthorID {
+			return
+		}
+		if _, ok := recipients[userID]; !ok {
+			recipientIDs = append(recipientIDs, userID)
+		}
+		recipients[userID] = recipients[userID] || isMention
+	}
+	subscribed := make(map[uuid.UUID]bool, len(subscribers))
+	for _, userID := range subscribers {
+		subscribed[userID] = true
+		add(userID, false)
+	}
+	for _, userID := range mentioned {
+		add(userID, true)
+	}
+	if len(recipientIDs) == 0 {
+		return
+	}
+
+	users, err := fetchUsersByIDs(c.userClient, append([]uuid.UUID{comment.AuthorID}, recipientIDs...))
+	if err != nil {
+		log.Printf("Failed to get recipients of comment %d: %v", comment.ID, err)
+		return
+	}
+
+	authorName := "Unknown user"
+	if author, ok := users[comment.AuthorID]; ok && author.Username != "" {
+		authorName = author.Username
+	}
+
+	excerpt := commentExcerpt(comment.Body)
+	for _, userID := range recipientIDs {
+		user, ok := users[userID]
+		if !ok {
+			continue
+		}
+		if !subscribed[userID] {
+			visible, err := canUserViewTask(c.chatMemberships, task, user)
+			if err != nil {
+				log.Printf("Failed to check access of user %s to task %d: %v", userID, task.ID, err)
+				continue
+			}
+			if !visible {
+				continue
+			}
+		}
+		if err := c.notificationService.SendTaskCommentNotification(
+			task.ID,
+			task.Title,
+			comment.ID,
+			authorName,
+			excerpt,
+			userID,
+			user.Email,
+			recipients[userID],
+		); err != nil {
+			log.Printf("Failed to send task comment notification: %v", err)
+		}
+	}
+}
+
+// recordEvents сохраняет события истории; ошибка записи не отменяет уже сохранённый комментарий
+func (c *TaskCommentController) recordEvents(events ...models.TaskEvent) {
+	if err := c.taskEventRepo.Create(events); err != nil {
+		log.Printf("Failed to record task events: %v", err)
+	}
+}
+
+// parseMentions извлекает уникальные имена из @упоминаний по тем же правилам, что и разметка сообщений чата:
+// имя состоит из латиницы, цифр, "_", "." и "-" и не может заканчиваться точкой или дефисом
+func parseMentions(body string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	for i := 0; i < len(body) && len(usernames) < maxCommentMentions; i++ {
+		if body[i] != '@' || (i > 0 && isMentionChar(body[i-1])) {
+			continue
+		}
+		n := 0
+		for i+1+n < len(body) && isMentionChar(body[i+1+n]) {
+			n++
+		}
+		for n > 0 && (body[i+n] == '.' || body[i+n] == '-') {
+			n--
+		}
+		if n == 0 {
+			continue
+		}
+		username := body[i+1 : i+1+n]
+		if !seen[username] {
+			seen[username] = true
+			usernames = append(usernames, username)
+		}
+		i += n
+	}
+	return usernames
+}
+
+func isMentionChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-'
+}
+
+func commentExcerpt(body string) string {
+	if utf8.RuneCountInString(body) <= commentExcerptLength {
+		return body
+	}
+	return string([]rune(body)[:commentExcerptLength]) + "…"
+}
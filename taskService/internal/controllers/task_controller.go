@@ -1,24 +1,41 @@
 package controllers
 
 import (
+	"cmp"
+	cc "common/contracts/chat-contracts"
 	fc "common/contracts/file-contracts"
+	cuc "common/contracts/user-contracts"
+	commonHttpClients "common/http_clients"
+	commonModels "common/models"
+	"errors"
+	"fmt"
 	"log"
+	"slices"
 	"strconv"
+	"strings"
 	customErrors "taskService/internal/custom_errors"
 	"taskService/internal/handlers/dto"
 	"taskService/internal/http_clients"
 	"taskService/internal/models"
 	"taskService/internal/repositories"
 	"taskService/internal/services"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+// TaskController управляет задачами. EventPublisher публикует доменные события задач в Kafka
+// и равен nil, если Kafka недоступна; ChatMemberships даёт чаты пользователя для проверки видимости задач
 type TaskController struct {
 	TaskRepo            repositories.TaskRepository
 	TaskStatusRepo      repositories.TaskStatusRepository
 	TaskFileRepo        repositories.TaskFileRepository
+	TaskWorkflowRepo    repositories.TaskWorkflowRepository
+	TaskEventRepo       repositories.TaskEventRepository
 	NotificationService services.NotificationServiceInterface
+	EventPublisher      services.TaskEventPublisherInterface
+	ChatMemberships     services.ChatMembershipServiceInterface
 	UserClient          http_clients.UserClientInterface
 	ChatClient          http_clients.ChatClientInterface
 	FileClient          http_clients.FileClientInterface
@@ -28,13 +45,19 @@ func NewTaskController(
 	taskRepo repositories.TaskRepository,
 	taskStatusRepo repositories.TaskStatusRepository,
 	taskFileRepo repositories.TaskFileRepository,
+	taskWorkflowRepo repositories.TaskWorkflowRepository,
+	taskEventRepo repositories.TaskEventRepository,
 	notificationService services.NotificationServiceInterface,
+	chatMemberships services.ChatMembershipServiceInterface,
 ) *TaskController {
 	return &TaskController{
 		TaskRepo:            taskRepo,
 		TaskStatusRepo:      taskStatusRepo,
 		TaskFileRepo:        taskFileRepo,
+		TaskWorkflowRepo:    taskWorkflowRepo,
+		TaskEventRepo:       taskEventRepo,
 		NotificationService: notificationService,
+		ChatMemberships:     chatMemberships,
 		UserClient:          http_clients.NewUserClientAdapter(),
 		ChatClient:          http_clients.NewChatClientAdapter(),
 		FileClient:          http_clients.NewFileClientAdapter(),
@@ -46,32 +69,66 @@ func NewTaskControllerWithClients(
 	taskRepo repositories.TaskRepository,
 	taskStatusRepo repositories.TaskStatusRepository,
 	taskFileRepo repositories.TaskFileRepository,
+	taskWorkflowRepo repositories.TaskWorkflowRepository,
+	taskEventRepo repositories.TaskEventRepository,
 	notificationService services.NotificationServiceInterface,
 	userClient http_clients.UserClientInterface,
 	chatClient http_clients.ChatClientInterface,
 	fileClient http_clients.FileClientInterface,
+	chatMemberships services.ChatMembershipServiceInterface,
 ) *TaskController {
 	return &TaskController{
 		TaskRepo:            taskRepo,
 		TaskStatusRepo:      taskStatusRepo,
 		TaskFileRepo:        taskFileRepo,
+		TaskWorkflowRepo:    taskWorkflowRepo,
+		TaskEventRepo:       taskEventRepo,
 		NotificationService: notificationService,
+		ChatMemberships:     chatMemberships,
 		UserClient:          userClient,
 		ChatClient:          chatClient,
 		FileClient:          fileClient,
 	}
 }
 
-func (c *TaskController) Create(taskDTO *dto.CreateTaskDTO) (*models.Task, error) {
-	status, err := c.TaskStatusRepo.GetByName("created")
+// Create создаёт задачу от имени actor, который становится её создателем. Привязать задачу можно
+// только к чату, в котором actor состоит, а сделать подзадачей - только видимой ему задачи
+func (c *TaskController) Create(actor *dto.Actor, taskDTO *dto.CreateTaskDTO) (*models.Task, error) {
+	return c.create(actor, taskDTO, c.saveTask)
+}
+
+// taskSaver сохраняет проверенную новую задачу и её вложения; TaskID вложений заполняет сам
+type taskSaver func(task *models.Task, files []models.TaskFile) error
+
+// saveTask сохраняет задачу и привязывает к ней файлы
+func (c *TaskController) saveTask(task *models.Task, files []models.TaskFile) error {
+	if err := c.TaskRepo.Create(task); err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	for i := range files {
+		files[i].TaskID = task.ID
+	}
+	return c.TaskFileRepo.BulkCreate(files)
+}
+
+// create проверяет задачу, сохраняет её через save, записывает историю и уведомляет исполнителя
+func (c *TaskController) create(actor *dto.Actor, taskDTO *dto.CreateTaskDTO, save taskSaver) (*models.Task, error) {
+	if err := validateSchedule(taskDTO.StartAt, taskDTO.DueAt); err != nil {
+		return nil, err
+	}
+
+	status, err := services.InitialTaskStatus(c.TaskStatusRepo, c.TaskWorkflowRepo, taskDTO.WorkflowID)
 	if err != nil {
-		return nil, customErrors.NewTaskStatusNotFoundError("created")
+		return nil, err
 	}
 
 	// Получаем информацию о создателе
-	creator, errUser := c.UserClient.GetUserByID(&taskDTO.CreatorID)
+	creator, errUser := c.UserClient.GetUserByID(&actor.UserID)
 	if errUser != nil {
-		return nil, customErrors.NewGetUserHTTPError(taskDTO.CreatorID.String(), errUser.Error())
+		return nil, customErrors.NewGetUserHTTPError(actor.UserID.String(), errUser.Error())
 	}
 
 	var executorEmail string
@@ -90,6 +147,16 @@ func (c *TaskController) Create(taskDTO *dto.CreateTaskDTO) (*models.Task, error
 		if _, errChat := c.ChatClient.GetChatByID(taskDTO.ChatID.String()); errChat != nil {
 			return nil, customErrors.NewGetChatHTTPError(taskDTO.ChatID.String(), errChat.Error())
 		}
+		if err := checkChatMember(c.ChatMemberships, taskDTO.ChatID, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	var parent *models.Task
+	if taskDTO.ParentTaskID != nil {
+		if parent, err = c.checkParentVisible(*taskDTO.ParentTaskID, actor); err != nil {
+			return nil, err
+		}
 	}
 
 	var taskFiles []models.TaskFile
@@ -106,33 +173,48 @@ func (c *TaskController) Create(taskDTO *dto.CreateTaskDTO) (*models.Task, error
 		desc = *taskDTO.Description
 	}
 
-	task := &models.Task{
-		Title:       taskDTO.Title,
-		Description: desc,
-		CreatorID:   taskDTO.CreatorID,
-		ExecutorID:  taskDTO.ExecutorID,
-		ChatID:      taskDTO.ChatID,
-		Status:      status,
-		StatusID:    status.ID,
+	priority := taskDTO.Priority
+	if priority == "" {
+		priority = models.TaskPriorityNormal
 	}
 
-	if err := c.TaskRepo.Create(task); err != nil {
-		return nil, err
+	task := &models.Task{
+		Title:           taskDTO.Title,
+		Description:     desc,
+		CreatorID:       actor.UserID,
+		ExecutorID:      taskDTO.ExecutorID,
+		ChatID:          taskDTO.ChatID,
+		WorkflowID:      taskDTO.WorkflowID,
+		ParentTaskID:    taskDTO.ParentTaskID,
+		Priority:        priority,
+		StartAt:         taskDTO.StartAt,
+		DueAt:           taskDTO.DueAt,
+		EstimateMinutes: taskDTO.EstimateMinutes,
+		Status:          status,
+		StatusID:        status.ID,
 	}
 
 	for _, fileID := range taskDTO.FileIDs {
-		taskFiles = append(taskFiles, models.TaskFile{
-			TaskID: task.ID,
-			FileID: fileID,
-		})
+		taskFiles = append(taskFiles, models.TaskFile{FileID: fileID})
 	}
-	if len(taskFiles) > 0 {
-		if err := c.TaskFileRepo.BulkCreate(taskFiles); err != nil {
+	// Новая подзадача - правка родителя: экземпляр серии с подзадачами серия больше не меняет
+	if parent != nil {
+		if err := detachOccurrence(c.TaskRepo, parent); err != nil {
 			return nil, err
-		} else {
-			task.Files = taskFiles
 		}
 	}
+	if err := save(task, taskFiles); err != nil {
+		return nil, err
+	}
+	if len(taskFiles) > 0 {
+		task.Files = taskFiles
+	}
+
+	events := []models.TaskEvent{newTaskEvent(task.ID, task.CreatorID, models.TaskEventCreated, nil, "", task.Title)}
+	for _, file := range taskFiles {
+		events = append(events, newTaskEvent(task.ID, task.CreatorID, models.TaskEventAttachmentAdded, nil, "", strconv.Itoa(file.FileID)))
+	}
+	c.recordEvents(task, events...)
 
 	// Отправляем уведомление о новой задаче, если есть исполнитель
 	if taskDTO.ExecutorID != uuid.Nil && executorEmail != "" {
@@ -156,15 +238,200 @@ func (c *TaskController) Create(taskDTO *dto.CreateTaskDTO) (*models.Task, error
 	return task, nil
 }
 
-func (c *TaskController) UpdateStatus(taskID, statusID int) error {
-	if _, err := c.TaskStatusRepo.GetByID(statusID); err != nil {
+// maxMessageTaskTitleLength - ограничение названия задачи (size:255 в модели), в символах
+const maxMessageTaskTitleLength = 255
+
+// CreateFromMessage создает задачу из сообщения чата от имени actor. Чат-сервис отдаёт сообщение,
+// только если у actor есть право view_messages в его чате. После создания в чат публикуется
+// системное сообщение со ссылкой на задачу; ошибка публикации не отменяет создание задачи
+func (c *TaskController) CreateFromMessage(actor *dto.Actor, fromMessageDTO *dto.CreateTaskFromMessageDTO) (*models.Task, error) {
+	message, err := c.ChatClient.GetMessageByID(fromMessageDTO.MessageID, actor.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, commonHttpClients.ErrMessageNotFound):
+			return nil, customErrors.NewChatMessageNotFoundError(fromMessageDTO.MessageID.String())
+		case errors.Is(err, commonHttpClients.ErrMessageAccessDenied):
+			return nil, customErrors.NewChatMessageAccessDeniedError(fromMessageDTO.MessageID.String(), actor.UserID.String())
+		default:
+			return nil, customErrors.NewGetChatMessageHTTPError(fromMessageDTO.MessageID.String(), err.Error())
+		}
+	}
+
+	title := messageTaskTitle(message.Content)
+	if fromMessageDTO.Title != nil && strings.TrimSpace(*fromMessageDTO.Title) != "" {
+		title = strings.TrimSpace(*fromMessageDTO.Title)
+	}
+	description := message.Content
+
+	task, err := c.Create(actor, &dto.CreateTaskDTO{
+		Title:       title,
+		Description: &description,
+		ExecutorID:  fromMessageDTO.ExecutorID,
+		ChatID:      message.ChatID,
+		FileIDs:     message.FileIDs,
+		WorkflowID:  fromMessageDTO.WorkflowID,
+		Priority:    fromMessageDTO.Priority,
+		StartAt:     fromMessageDTO.StartAt,
+		DueAt:       fromMessageDTO.DueAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ключ совпадает с ключом события task_created, поэтому в чате остаётся одно сообщение о задаче
+	eventID := commonModels.TaskCreatedEventID(task.ID)
+	if err := c.ChatClient.CreateSystemMessage(message.ChatID, &cc.CreateSystemMessageRequest{
+		Content: fmt.Sprintf("Создана задача #%d: %s", task.ID, task.Title),
+		TaskID:  &task.ID,
+		EventID: &eventID,
+	}); err != nil {
+		log.Printf("Failed to post task %d reference to chat %s: %v", task.ID, message.ChatID, err)
+	}
+
+	return task, nil
+}
+
+// messageTaskTitle - первая непустая строка сообщения без разметки заголовков, цитат и списков
+func messageTaskTitle(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#>-* "))
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); len(runes) > maxMessageTaskTitleLength {
+			line = string(runes[:maxMessageTaskTitleLength])
+		}
+		return line
+	}
+	return "Задача из сообщения чата"
+}
+
+// UpdateStatus переводит задачу в новый статус. Если у задачи есть workflow (собственный или по умолчанию),
+// переход должен быть описан в нём и разрешён роли пользователя; право manage_all_tasks снимает только
+// ограничение по роли. Без workflow статус могут менять создатель, исполнитель и manage_all_tasks
+func (c *TaskController) UpdateStatus(taskID, statusID int, actor *dto.Actor) error {
+	task, err := findVisibleTask(c.TaskRepo, c.ChatMemberships, taskID, actor)
+	if err != nil {
+		return err
+	}
+	newStatus, err := c.TaskStatusRepo.GetByID(statusID)
+	if err != nil {
 		return customErrors.NewTaskStatusNotFoundError(strconv.Itoa(statusID))
 	}
-	return c.TaskRepo.UpdateStatus(taskID, statusID)
+	if task.StatusID == statusID {
+		return nil
+	}
+
+	if err := c.checkStatusChange(task, statusID, actor); err != nil {
+		return err
+	}
+	if err := detachOccurrence(c.TaskRepo, task); err != nil {
+		return err
+	}
+	return c.changeStatus(task, newStatus, actor)
 }
 
-func (c *TaskController) GetByID(taskID int) (*dto.TaskResponse, error) {
-	task, err := c.TaskRepo.GetByID(taskID)
+// checkStatusChange проверяет, что actor может перевести задачу в статус statusID по правилам её workflow
+func (c *TaskController) checkStatusChange(task *models.Task, statusID int, actor *dto.Actor) error {
+	workflow, err := c.resolveWorkflow(task)
+	if err != nil {
+		return err
+	}
+
+	if workflow == nil {
+		if !canModifyTask(task, actor) {
+			return customErrors.NewTaskAccessDeniedError(task.ID, actor.UserID.String())
+		}
+		return nil
+	}
+
+	transition := findTransition(workflow, task.StatusID, statusID)
+	if transition == nil {
+		return customErrors.NewIllegalStatusTransitionError(task.ID, task.StatusID, statusID)
+	}
+	if !actor.HasPermission(dto.PermissionManageAllTasks) && !transitionAllowedFor(transition, task, actor.UserID) {
+		return customErrors.NewStatusTransitionForbiddenError(task.ID, transition.AllowedRole)
+	}
+
+	// Закрыть задачу можно только после закрытия всех задач, которые её блокируют
+	if isClosingStatus(workflow, statusID) {
+		blockerIDs, err := c.TaskRepo.GetOpenBlockerIDs(task.ID)
+		if err != nil {
+			return err
+		}
+		if len(blockerIDs) > 0 {
+			return customErrors.NewTaskBlockedError(task.ID, blockerIDs)
+		}
+	}
+	return nil
+}
+
+// changeStatus сохраняет новый статус задачи
+func (c *TaskController) changeStatus(task *models.Task, newStatus *models.TaskStatus, actor *dto.Actor) error {
+	if err := c.TaskRepo.UpdateStatus(task.ID, newStatus.ID); err != nil {
+		return err
+	}
+
+	c.statusChanged(task, newStatus, actor)
+	return nil
+}
+
+// statusChanged записывает уже сохранённый переход в историю и уведомляет исполнителей и наблюдателей задачи
+func (c *TaskController) statusChanged(task *models.Task, newStatus *models.TaskStatus, actor *dto.Actor) {
+	oldStatus := strconv.Itoa(task.StatusID)
+	if task.Status != nil {
+		oldStatus = task.Status.Name
+	}
+	c.recordEvents(task, newTaskEvent(task.ID, actor.UserID, models.TaskEventStatusChanged, nil, oldStatus, newStatus.Name))
+	c.notifyStatusChanged(task, oldStatus, newStatus.Name, actor)
+}
+
+// notifyStatusChanged рассылает уведомление о смене статуса исполнителю, соисполнителям и наблюдателям задачи.
+// Автор изменения уведомления не получает; ошибки только логируются
+func (c *TaskController) notifyStatusChanged(task *models.Task, oldStatus, newStatus string, actor *dto.Actor) {
+	var recipientIDs []uuid.UUID
+	for _, userID := range taskSubscribers(task) {
+		if userID != actor.UserID {
+			recipientIDs = append(recipientIDs, userID)
+		}
+	}
+	if len(recipientIDs) == 0 {
+		return
+	}
+
+	users, err := fetchUsersByIDs(c.UserClient, append([]uuid.UUID{actor.UserID}, recipientIDs...))
+	if err != nil {
+		log.Printf("Failed to get recipients of task %d status change: %v", task.ID, err)
+		return
+	}
+
+	actorName := "Unknown user"
+	if user, ok := users[actor.UserID]; ok && user.Username != "" {
+		actorName = user.Username
+	}
+
+	for _, userID := range recipientIDs {
+		user, ok := users[userID]
+		if !ok {
+			continue
+		}
+		if err := c.NotificationService.SendTaskStatusChangedNotification(
+			task.ID,
+			task.Title,
+			actorName,
+			oldStatus,
+			newStatus,
+			userID,
+			user.Email,
+		); err != nil {
+			log.Printf("Failed to send task status changed notification: %v", err)
+		}
+	}
+}
+
+// GetByID возвращает задачу, если её видит actor; чужая задача неотличима от несуществующей
+func (c *TaskController) GetByID(taskID int, actor *dto.Actor) (*dto.TaskResponse, error) {
+	task, err := findVisibleTask(c.TaskRepo, c.ChatMemberships, taskID, actor)
 	if err != nil {
 		return nil, err
 	}
@@ -179,6 +446,751 @@ func (c *TaskController) GetByID(taskID int) (*dto.TaskResponse, error) {
 	return &dto.TaskResponse{Task: task, Files: &files}, nil
 }
 
-func (c *TaskController) GetUserTasks(userID string, limit, offset int) (*[]dto.TaskToList, error) {
-	return c.TaskRepo.GetUserTasks(userID, limit, offset)
+// GetUserTasks возвращает задачи исполнителя, которые видит actor; filter может быть nil
+func (c *TaskController) GetUserTasks(userID string, actor *dto.Actor, filter *dto.TaskListFilter, limit, offset int) (*[]dto.TaskToList, error) {
+	visibility, err := taskVisibility(c.ChatMemberships, actor)
+	if err != nil {
+		return nil, err
+	}
+	return c.TaskRepo.GetUserTasks(userID, filter, limit, offset, visibility)
+}
+
+// QueryTasks ищет задачи по фильтрам и полнотекстовому запросу. Следующая страница запрашивается
+// с курсором NextCursor в той же сортировке. Находятся только задачи, которые видит actor
+func (c *TaskController) QueryTasks(actor *dto.Actor, query *dto.TaskQuery) (*dto.TaskQueryResult, error) {
+	visibility, err := taskVisibility(c.ChatMemberships, actor)
+	if err != nil {
+		return nil, err
+	}
+	tasks, total, err := c.TaskRepo.QueryTasks(query, visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.TaskQueryResult{Tasks: tasks, Total: total}
+	if len(tasks) > query.Limit {
+		result.Tasks = tasks[:query.Limit]
+		last := result.Tasks[len(result.Tasks)-1]
+		cursor := &dto.TaskCursor{
+			SortBy:   query.SortBy,
+			SortDesc: query.SortDesc,
+			Value:    taskCursorValue(&last, query.SortBy),
+			ID:       last.ID,
+		}
+		next := cursor.Encode()
+		result.NextCursor = &next
+	}
+	if result.Tasks == nil {
+		result.Tasks = []dto.TaskToList{}
+	}
+	return result, nil
+}
+
+// GetBoard возвращает задачи чата или исполнителя, сгруппированные по статусам. Колонки идут в порядке
+// статусов workflow по умолчанию, остальные статусы - после них по ID; пустые колонки тоже возвращаются.
+// На доске только задачи, которые видит actor
+func (c *TaskController) GetBoard(actor *dto.Actor, query *dto.TaskBoardQuery) (*dto.TaskBoard, error) {
+	visibility, err := taskVisibility(c.ChatMemberships, actor)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := c.TaskStatusRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	positions := make(map[int]int)
+	workflow, err := c.TaskWorkflowRepo.GetDefault()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if workflow != nil {
+		for _, status := range workflow.Statuses {
+			positions[status.StatusID] = status.Position
+		}
+	}
+	slices.SortStableFunc(statuses, func(a, b models.TaskStatus) int {
+		pa, inA := positions[a.ID]
+		pb, inB := positions[b.ID]
+		switch {
+		case inA && inB:
+			return cmp.Compare(pa, pb)
+		case inA != inB:
+			if inA {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	cards, counts, err := c.TaskRepo.GetBoard(query, visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &dto.TaskBoard{Columns: make([]dto.TaskBoardColumn, len(statuses))}
+	columns := make(map[int]*dto.TaskBoardColumn, len(statuses))
+	for i, status := range statuses {
+		board.Columns[i] = dto.TaskBoardColumn{StatusID: status.ID, Status: status.Name, Tasks: []dto.TaskBoardCard{}}
+		columns[status.ID] = &board.Columns[i]
+	}
+	for _, count := range counts {
+		if column, ok := columns[count.StatusID]; ok {
+			column.Total = count.Total
+		}
+	}
+	for _, card := range cards {
+		if column, ok := columns[card.StatusID]; ok {
+			column.Tasks = append(column.Tasks, card)
+		}
+	}
+	return board, nil
+}
+
+// MoveTask переносит карточку на доске: меняет статус и позицию в колонке одним обновлением. Смена статуса
+// подчиняется тем же правилам workflow, что и UpdateStatus; перестановка внутри колонки доступна тем, кто
+// может редактировать задачу
+func (c *TaskController) MoveTask(taskID int, actor *dto.Actor, moveDTO *dto.MoveTaskDTO) error {
+	task, err := findVisibleTask(c.TaskRepo, c.ChatMemberships, taskID, actor)
+	if err != nil {
+		return err
+	}
+	newStatus, err := c.TaskStatusRepo.GetByID(moveDTO.StatusID)
+	if err != nil {
+		return customErrors.NewTaskStatusNotFoundError(strconv.Itoa(moveDTO.StatusID))
+	}
+
+	statusChanged := task.StatusID != newStatus.ID
+	if statusChanged {
+		if err := c.checkStatusChange(task, newStatus.ID, actor); err != nil {
+			return err
+		}
+	} else if !canModifyTask(task, actor) {
+		return customErrors.NewTaskAccessDeniedError(taskID, actor.UserID.String())
+	}
+
+	rank, err := c.boardRank(task, actor, moveDTO)
+	if err != nil {
+		return err
+	}
+	if err := detachOccurrence(c.TaskRepo, task); err != nil {
+		return err
+	}
+	if err := c.TaskRepo.MoveOnBoard(task.ID, task.StatusID, newStatus.ID, rank); err != nil {
+		return err
+	}
+
+	if statusChanged {
+		c.statusChanged(task, newStatus, actor)
+	}
+	return nil
+}
+
+// boardRank вычисляет ранг карточки между соседями. Без after_task_id карточка ставится в начало колонки,
+// без before_task_id - в конец; без обоих - в конец колонки
+func (c *TaskController) boardRank(task *models.Task, actor *dto.Actor, moveDTO *dto.MoveTaskDTO) (string, error) {
+	var prev, next string
+	if moveDTO.AfterTaskID != nil {
+		neighbour, err := c.boardNeighbour(task.ID, *moveDTO.AfterTaskID, moveDTO.StatusID, actor)
+		if err != nil {
+			return "", err
+		}
+		prev = neighbour.Rank
+	}
+	if moveDTO.BeforeTaskID != nil {
+		neighbour, err := c.boardNeighbour(task.ID, *moveDTO.BeforeTaskID, moveDTO.StatusID, actor)
+		if err != nil {
+			return "", err
+		}
+		next = neighbour.Rank
+	}
+	if moveDTO.AfterTaskID == nil && moveDTO.BeforeTaskID == nil {
+		last, err := c.TaskRepo.GetLastRank(moveDTO.StatusID)
+		if err != nil {
+			return "", err
+		}
+		prev = last
+	}
+
+	rank, err := models.RankBetween(prev, next)
+	if err != nil {
+		return "", customErrors.NewInvalidTaskMoveError(task.ID, "neighbour tasks are out of order, reload the board")
+	}
+	return rank, nil
+}
+
+// boardNeighbour загружает соседнюю карточку и проверяет, что она стоит в целевой колонке.
+// Карточка, которую actor не видит, считается ненайденной
+func (c *TaskController) boardNeighbour(taskID, neighbourID, statusID int, actor *dto.Actor) (*models.Task, error) {
+	if neighbourID == taskID {
+		return nil, customErrors.NewInvalidTaskMoveError(taskID, "task can't be its own neighbour")
+	}
+	neighbour, err := findVisibleTask(c.TaskRepo, c.ChatMemberships, neighbourID, actor)
+	if err != nil {
+		var taskErr *customErrors.TaskNotFoundError
+		if errors.As(err, &taskErr) {
+			return nil, customErrors.NewInvalidTaskMoveError(taskID, fmt.Sprintf("neighbour task %d not found", neighbourID))
+		}
+		return nil, err
+	}
+	if neighbour.StatusID != statusID {
+		return nil, customErrors.NewInvalidTaskMoveError(taskID, fmt.Sprintf("neighbour task %d is in another column", neighbourID))
+	}
+	return neighbour, nil
+}
+
+// Update редактирует задачу. Изменять задачу могут создатель, исполнитель и пользователи с правом manage_all_tasks
+func (c *TaskController) Update(taskID int, actor *dto.Actor, updateDTO *dto.UpdateTaskDTO) (*models.Task, error) {
+	task, err := c.getTaskForModification(taskID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []models.TaskEvent
+	if updateDTO.Title != nil && *updateDTO.Title != task.Title {
+		events = append(events, newTaskEvent(task.ID, actor.UserID, models.TaskEventEdited, stringPtr("title"), task.Title, *updateDTO.Title))
+		task.Title = *updateDTO.Title
+	}
+	if updateDTO.Description != nil && *updateDTO.Description != task.Description {
+		events = append(events, newTaskEvent(task.ID, actor.UserID, models.TaskEventEdited, stringPtr("description"), task.Description, *updateDTO.Description))
+		task.Description = *updateDTO.Description
+	}
+
+	var newExecutorEmail string
+	reassigned := updateDTO.ExecutorID != nil && *updateDTO.ExecutorID != task.ExecutorID
+	if reassigned && *updateDTO.ExecutorID != uuid.Nil {
+		executor, errUser := c.UserClient.GetUserByID(updateDTO.ExecutorID)
+		if errUser != nil {
+			return nil, customErrors.NewGetUserHTTPError(updateDTO.ExecutorID.String(), errUser.Error())
+		}
+		if executor.User != nil {
+			newExecutorEmail = executor.User.Email
+		}
+	}
+	if reassigned {
+		events = append(events, newTaskEvent(task.ID, actor.UserID, models.TaskEventReassigned, nil, uuidValue(task.ExecutorID), uuidValue(*updateDTO.ExecutorID)))
+	}
+	if updateDTO.ExecutorID != nil {
+		task.ExecutorID = *updateDTO.ExecutorID
+	}
+
+	if updateDTO.ChatID != nil && *updateDTO.ChatID != uuid.Nil && *updateDTO.ChatID != task.ChatID {
+		if _, errChat := c.ChatClient.GetChatByID(updateDTO.ChatID.String()); errChat != nil {
+			return nil, customErrors.NewGetChatHTTPError(updateDTO.ChatID.String(), errChat.Error())
+		}
+		// Перенос в чат открывает задачу его участникам, поэтому переносить можно только в свой чат
+		if err := checkChatMember(c.ChatMemberships, *updateDTO.ChatID, actor); err != nil {
+			return nil, err
+		}
+	}
+	if updateDTO.ChatID != nil && *updateDTO.ChatID != task.ChatID {
+		events = append(events, newTaskEvent(task.ID, actor.UserID, models.TaskEventEdited, stringPtr("chat_id"), uuidValue(task.ChatID), uuidValue(*updateDTO.ChatID)))
+		task.ChatID = *updateDTO.ChatID
+	}
+
+	parentTaskID := task.ParentTaskID
+	if updateDTO.ClearParentTaskID {
+		parentTaskID = nil
+	} else if updateDTO.ParentTaskID != nil {
+		parentTaskID = updateDTO.ParentTaskID
+	}
+	var newParent *models.Task
+	if !sameInt(parentTaskID, task.ParentTaskID) {
+		if parentTaskID != nil {
+			if newParent, err = c.checkParent(task.ID, *parentTaskID, actor); err != nil {
+				return nil, err
+			}
+		}
+		events = append(events, newTaskEvent(task.ID, actor.UserID, models.TaskEventEdited, stringPtr("parent_task_id"), intValue(task.ParentTaskID), intValue(parentTaskID)))
+		task.ParentTaskID = parentTaskID
+	}
+
+	if updateDTO.Priority != nil && *updateDTO.Priority != task.Priority {
+		events = append(events, newTaskEvent(task.ID, actor.UserID, models.TaskEventEdited, stringPtr("priority"), task.Priority, *updateDTO.Priority))
+		task.Priority = *updateDTO.Priority
+	}
+
+	estimate := task.EstimateMinutes
+	if updateDTO.ClearEstimate {
+		estimate = nil
+	} else if updateDTO.EstimateMinutes != nil {
+		estimate = updateDTO.EstimateMinutes
+	}
+	if !sameInt(estimate, task.EstimateMinutes) {
+		events = append(events, newTaskEvent(task.ID, actor.UserID, models.TaskEventEdited, stringPtr("estimate_minutes"), intValue(task.EstimateMinutes), intValue(estimate)))
+		task.EstimateMinutes = estimate
+	}
+
+	startAt := task.StartAt
+	if updateDTO.ClearStartAt {
+		startAt = nil
+	} else if updateDTO.StartAt != nil {
+		startAt = updateDTO.StartAt
+	}
+	dueAt := task.DueAt
+	if updateDTO.ClearDueAt {
+		dueAt = nil
+	} else if updateDTO.DueAt != nil {
+		dueAt = updateDTO.DueAt
+	}
+	if err := validateSchedule(startAt, dueAt); err != nil {
+		return nil, err
+	}
+	if !sameTime(startAt, task.StartAt) {
+		events = append(events, newTaskEvent(task.ID, actor.UserID, models.TaskEventEdited, stringPtr("start_at"), timeValue(task.StartAt), timeValue(startAt)))
+		task.StartAt = startAt
+	}
+	if !sameTime(dueAt, task.DueAt) {
+		events = append(events, newTaskEvent(task.ID, actor.UserID, models.TaskEventEdited, stringPtr("due_at"), timeValue(task.DueAt), timeValue(dueAt)))
+		task.DueAt = dueAt
+		// Для нового срока напоминания отправляются заново
+		task.DueSoonNotifiedAt = nil
+		task.OverdueNotifiedAt = nil
+	}
+
+	attached := make(map[int]bool, len(task.Files))
+	for _, file := range task.Files {
+		attached[file.FileID] = true
+	}
+	var newFiles []models.TaskFile
+	for _, fileID := range updateDTO.AddFileIDs {
+		if attached[fileID] {
+			continue
+		}
+		if _, errFile := c.FileClient.GetFileByID(fileID); errFile != nil {
+			return nil, customErrors.NewGetFileHTTPError(fileID, errFile.Error())
+		}
+		attached[fileID] = true
+		newFiles = append(newFiles, models.TaskFile{TaskID: task.ID, FileID: fileID})
+		events = append(events, newTaskEvent(task.ID, actor.UserID, models.TaskEventAttachmentAdded, nil, "", strconv.Itoa(fileID)))
+	}
+
+	if err := detachOccurrence(c.TaskRepo, task); err != nil {
+		return nil, err
+	}
+	if newParent != nil {
+		if err := detachOccurrence(c.TaskRepo, newParent); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	task.UpdatedAt = &now
+	if err := c.TaskRepo.Update(task); err != nil {
+		return nil, err
+	}
+
+	if len(updateDTO.RemoveFileIDs) > 0 {
+		if err := c.TaskFileRepo.DeleteByFileIDs(task.ID, updateDTO.RemoveFileIDs); err != nil {
+			return nil, err
+		}
+	}
+	if len(newFiles) > 0 {
+		if err := c.TaskFileRepo.BulkCreate(newFiles); err != nil {
+			return nil, err
+		}
+	}
+
+	c.recordEvents(task, events...)
+
+	updated, err := c.TaskRepo.GetByID(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reassigned && newExecutorEmail != "" {
+		c.notifyReassigned(updated, actor, newExecutorEmail)
+	}
+
+	return updated, nil
+}
+
+// notifyReassigned отправляет новому исполнителю такое же уведомление, как при создании задачи
+func (c *TaskController) notifyReassigned(task *models.Task, actor *dto.Actor, executorEmail string) {
+	actorName := "Unknown user"
+	if actorResp, errUser := c.UserClient.GetUserByID(&actor.UserID); errUser == nil && actorResp.User != nil && actorResp.User.Username != "" {
+		actorName = actorResp.User.Username
+	}
+	if err := c.NotificationService.SendTaskCreatedNotification(
+		task.ID,
+		task.Title,
+		actorName,
+		task.ExecutorID,
+		executorEmail,
+	); err != nil {
+		log.Printf("Failed to send task reassignment notification: %v", err)
+	}
+}
+
+// Delete мягко удаляет задачу. Права те же, что и на редактирование
+func (c *TaskController) Delete(taskID int, actor *dto.Actor) error {
+	if _, err := c.getTaskForModification(taskID, actor); err != nil {
+		return err
+	}
+	return c.TaskRepo.Delete(taskID)
+}
+
+// GetCreatedTasks возвращает задачи, созданные пользователем, которые видит actor
+func (c *TaskController) GetCreatedTasks(userID string, actor *dto.Actor, limit, offset int) (*[]dto.TaskToList, error) {
+	visibility, err := taskVisibility(c.ChatMemberships, actor)
+	if err != nil {
+		return nil, err
+	}
+	return c.TaskRepo.GetCreatedTasks(userID, limit, offset, visibility)
+}
+
+// GetChatTasks возвращает задачи чата, которые видит actor: участнику чата - все
+func (c *TaskController) GetChatTasks(chatID string, actor *dto.Actor, limit, offset int) (*[]dto.TaskToList, error) {
+	visibility, err := taskVisibility(c.ChatMemberships, actor)
+	if err != nil {
+		return nil, err
+	}
+	return c.TaskRepo.GetChatTasks(chatID, limit, offset, visibility)
+}
+
+// GetHistory возвращает историю задачи, новые события первыми
+func (c *TaskController) GetHistory(taskID int, actor *dto.Actor, limit, offset int) (*[]dto.TaskActivity, error) {
+	if _, err := findVisibleTask(c.TaskRepo, c.ChatMemberships, taskID, actor); err != nil {
+		return nil, err
+	}
+	return c.TaskEventRepo.GetByTaskID(taskID, limit, offset)
+}
+
+// GetUserActivity возвращает ленту событий по задачам пользователя и его собственным действиям;
+// в ленте только задачи, которые видит actor
+func (c *TaskController) GetUserActivity(userID string, actor *dto.Actor, limit, offset int) (*[]dto.TaskActivity, error) {
+	visibility, err := taskVisibility(c.ChatMemberships, actor)
+	if err != nil {
+		return nil, err
+	}
+	return c.TaskEventRepo.GetUserFeed(userID, limit, offset, visibility)
+}
+
+// getTaskForModification загружает задачу и проверяет, что пользователь может её изменять
+func (c *TaskController) getTaskForModification(taskID int, actor *dto.Actor) (*models.Task, error) {
+	return findTaskForModification(c.TaskRepo, c.ChatMemberships, taskID, actor)
+}
+
+// findTask загружает задачу, переводя отсутствие записи в TaskNotFoundError
+func findTask(taskRepo repositories.TaskRepository, taskID int) (*models.Task, error) {
+	task, err := taskRepo.GetByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewTaskNotFoundError(taskID)
+		}
+		return nil, err
+	}
+	return task, nil
+}
+
+// detachOccurrence отделяет экземпляр серии перед его правкой, чтобы изменение или удаление серии
+// не затёрло правку. Вызывается до сохранения: при ошибке правки экземпляр остаётся отделённым
+func detachOccurrence(taskRepo repositories.TaskRepository, task *models.Task) error {
+	if task.RecurrenceID == nil || task.Detached {
+		return nil
+	}
+	if err := taskRepo.Detach(task.ID); err != nil {
+		return err
+	}
+	task.Detached = true
+	return nil
+}
+
+// findVisibleTask загружает задачу, которую видит actor. Невидимая задача неотличима от несуществующей,
+// чтобы по ответу нельзя было узнать о задачах чужих чатов
+func findVisibleTask(
+	taskRepo repositories.TaskRepository,
+	chatMemberships services.ChatMembershipServiceInterface,
+	taskID int,
+	actor *dto.Actor,
+) (*models.Task, error) {
+	task, err := findTask(taskRepo, taskID)
+	if err != nil {
+		return nil, err
+	}
+	visible, err := canViewTask(chatMemberships, task, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, customErrors.NewTaskNotFoundError(taskID)
+	}
+	return task, nil
+}
+
+// findTaskForModification загружает видимую actor задачу и проверяет, что он может её менять
+func findTaskForModification(
+	taskRepo repositories.TaskRepository,
+	chatMemberships services.ChatMembershipServiceInterface,
+	taskID int,
+	actor *dto.Actor,
+) (*models.Task, error) {
+	task, err := findVisibleTask(taskRepo, chatMemberships, taskID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !canModifyTask(task, actor) {
+		return nil, customErrors.NewTaskAccessDeniedError(taskID, actor.UserID.String())
+	}
+	return task, nil
+}
+
+// canViewTask - задачу видят создатель, исполнитель, соисполнители, участники её чата и пользователи
+// с правом manage_all_tasks. Чат-сервис спрашивается, только если остальные правила не подошли
+func canViewTask(chatMemberships services.ChatMembershipServiceInterface, task *models.Task, actor *dto.Actor) (bool, error) {
+	if canModifyTask(task, actor) {
+		return true, nil
+	}
+	if task.ChatID == uuid.Nil {
+		return false, nil
+	}
+	chatIDs, err := userChatIDs(chatMemberships, actor)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(chatIDs, task.ChatID), nil
+}
+
+// canUserViewTask проверяет по правилам canViewTask, видит ли задачу другой пользователь (не автор запроса).
+// Его глобальные права берутся из роли, полученной от userService
+func canUserViewTask(chatMemberships services.ChatMembershipServiceInterface, task *models.Task, user *cuc.User) (bool, error) {
+	permissions := make([]string, 0, len(user.Role.Permissions))
+	for _, permission := range user.Role.Permissions {
+		permissions = append(permissions, permission.Name)
+	}
+	return canViewTask(chatMemberships, task, &dto.Actor{UserID: user.ID, Permissions: permissions})
+}
+
+// taskVisibility возвращает ограничение списков задач по правилам canViewTask; nil - actor видит все задачи
+func taskVisibility(chatMemberships services.ChatMembershipServiceInterface, actor *dto.Actor) (*dto.TaskVisibility, error) {
+	if actor.HasPermission(dto.PermissionManageAllTasks) {
+		return nil, nil
+	}
+	chatIDs, err := userChatIDs(chatMemberships, actor)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TaskVisibility{UserID: actor.UserID, ChatIDs: chatIDs}, nil
+}
+
+func userChatIDs(chatMemberships services.ChatMembershipServiceInterface, actor *dto.Actor) ([]uuid.UUID, error) {
+	chatIDs, err := chatMemberships.GetUserChatIDs(actor.UserID)
+	if err != nil {
+		return nil, customErrors.NewGetUserChatsHTTPError(actor.UserID.String(), err.Error())
+	}
+	return chatIDs, nil
+}
+
+// canModifyTask - задачу и её связи могут менять создатель, исполнитель, соисполнители
+// и пользователи с правом manage_all_tasks
+func canModifyTask(task *models.Task, actor *dto.Actor) bool {
+	return task.CreatorID == actor.UserID ||
+		task.IsExecutor(actor.UserID) ||
+		actor.HasPermission(dto.PermissionManageAllTasks)
+}
+
+// checkChatMember проверяет, что actor состоит в чате, к которому привязывается задача или серия задач
+func checkChatMember(chatMemberships services.ChatMembershipServiceInterface, chatID uuid.UUID, actor *dto.Actor) error {
+	chatIDs, err := userChatIDs(chatMemberships, actor)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(chatIDs, chatID) {
+		return customErrors.NewChatMembershipRequiredError(chatID.String(), actor.UserID.String())
+	}
+	return nil
+}
+
+// checkParentVisible проверяет, что будущая родительская задача существует и её видит actor, и возвращает её.
+// Невидимый родитель неотличим от несуществующего
+func (c *TaskController) checkParentVisible(parentTaskID int, actor *dto.Actor) (*models.Task, error) {
+	parent, err := findVisibleTask(c.TaskRepo, c.ChatMemberships, parentTaskID, actor)
+	if err != nil {
+		var taskErr *customErrors.TaskNotFoundError
+		if errors.As(err, &taskErr) {
+			return nil, customErrors.NewParentTaskNotFoundError(parentTaskID)
+		}
+		return nil, err
+	}
+	return parent, nil
+}
+
+// checkParent проверяет, что задачу taskID можно сделать подзадачей parentTaskID: родитель существует,
+// его видит actor и он не является самой задачей или одной из её подзадач. Возвращает родителя
+func (c *TaskController) checkParent(taskID, parentTaskID int, actor *dto.Actor) (*models.Task, error) {
+	if parentTaskID == taskID {
+		return nil, customErrors.NewTaskHierarchyCycleError(taskID, parentTaskID)
+	}
+	parent, err := c.checkParentVisible(parentTaskID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	subtree, err := c.TaskRepo.GetSubtree(taskID)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range subtree {
+		if node.ID == parentTaskID {
+			return nil, customErrors.NewTaskHierarchyCycleError(taskID, parentTaskID)
+		}
+	}
+	return parent, nil
+}
+
+// resolveWorkflow возвращает workflow задачи, workflow по умолчанию или nil, если ни одного нет
+func (c *TaskController) resolveWorkflow(task *models.Task) (*models.TaskWorkflow, error) {
+	if task.WorkflowID != nil {
+		workflow, err := c.TaskWorkflowRepo.GetByID(*task.WorkflowID)
+		if err == nil {
+			return workflow, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	workflow, err := c.TaskWorkflowRepo.GetDefault()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return workflow, nil
+}
+
+func findTransition(workflow *models.TaskWorkflow, fromStatusID, toStatusID int) *models.TaskWorkflowTransition {
+	for i := range workflow.Transitions {
+		transition := &workflow.Transitions[i]
+		if transition.FromStatusID == fromStatusID && transition.ToStatusID == toStatusID {
+			return transition
+		}
+	}
+	return nil
+}
+
+// isClosingStatus сообщает, закрывает ли статус задачу: из закрывающего статуса workflow не предусматривает
+// переходов. Так же закрытые задачи отбираются в репозитории
+func isClosingStatus(workflow *models.TaskWorkflow, statusID int) bool {
+	for _, transition := range workflow.Transitions {
+		if transition.FromStatusID == statusID {
+			return false
+		}
+	}
+	return true
+}
+
+func transitionAllowedFor(transition *models.TaskWorkflowTransition, task *models.Task, userID uuid.UUID) bool {
+	switch transition.AllowedRole {
+	case models.TransitionRoleCreator:
+		return task.CreatorID == userID
+	case models.TransitionRoleExecutor:
+		return task.IsExecutor(userID)
+	case models.TransitionRoleAny:
+		return task.CreatorID == userID || task.IsExecutor(userID)
+	}
+	return false
+}
+
+// recordEvents сохраняет события истории и публикует доменные события задачи;
+// ошибка записи не отменяет уже выполненное изменение задачи
+func (c *TaskController) recordEvents(task *models.Task, events ...models.TaskEvent) {
+	if err := c.TaskEventRepo.Create(events); err != nil {
+		log.Printf("Failed to record task events: %v", err)
+	}
+	if c.EventPublisher != nil {
+		c.EventPublisher.Publish(task, events)
+	}
+}
+
+func newTaskEvent(taskID int, actorID uuid.UUID, eventType string, field *string, oldValue, newValue string) models.TaskEvent {
+	event := models.TaskEvent{
+		TaskID:    taskID,
+		ActorID:   actorID,
+		EventType: eventType,
+		Field:     field,
+	}
+	if oldValue != "" {
+		event.OldValue = &oldValue
+	}
+	if newValue != "" {
+		event.NewValue = &newValue
+	}
+	return event
+}
+
+// uuidValue возвращает пустую строку для uuid.Nil, чтобы снятие исполнителя или чата сохранялось как NULL
+func uuidValue(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+// validateSchedule проверяет, что дата начала не позже срока задачи
+func validateSchedule(startAt, dueAt *time.Time) error {
+	if startAt != nil && dueAt != nil && startAt.After(*dueAt) {
+		return customErrors.NewInvalidTaskScheduleError(*startAt, *dueAt)
+	}
+	return nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+// timeValue форматирует дату для истории задачи; снятая дата сохраняется как NULL
+func timeValue(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func sameInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// intValue форматирует ID или число для истории задачи; снятое значение сохраняется как NULL
+func intValue(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+// taskCursorValue возвращает значение поля сортировки задачи для курсора
+func taskCursorValue(task *dto.TaskToList, sortBy string) *string {
+	formatTime := func(t *time.Time) *string {
+		if t == nil {
+			return nil
+		}
+		return stringPtr(t.Format(time.RFC3339Nano))
+	}
+
+	switch sortBy {
+	case dto.TaskSortByUpdatedAt:
+		return formatTime(&task.UpdatedAt)
+	case dto.TaskSortByDueAt:
+		return formatTime(task.DueAt)
+	case dto.TaskSortByStartAt:
+		return formatTime(task.StartAt)
+	case dto.TaskSortByPriority:
+		return stringPtr(task.Priority)
+	case dto.TaskSortByStatus:
+		return stringPtr(task.Status)
+	case dto.TaskSortByTitle:
+		return stringPtr(task.Title)
+	}
+	return formatTime(&task.CreatedAt)
 }
@@ -0,0 +1,231 @@
+package controllers
+
+import (
+	"log"
+	"slices"
+	"strconv"
+	customErrors "taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+	"taskService/internal/services"
+)
+
+// TaskDependencyController отвечает за дерево подзадач и зависимости "блокирует / заблокирована"
+type TaskDependencyController struct {
+	taskRepo       repositories.TaskRepository
+	dependencyRepo repositories.TaskDependencyRepository
+	taskEventRepo  repositories.TaskEventRepository
+	// chatMemberships - чаты пользователя для проверки видимости задач
+	chatMemberships services.ChatMembershipServiceInterface
+}
+
+func NewTaskDependencyController(
+	taskRepo repositories.TaskRepository,
+	dependencyRepo repositories.TaskDependencyRepository,
+	taskEventRepo repositories.TaskEventRepository,
+	chatMemberships services.ChatMembershipServiceInterface,
+) *TaskDependencyController {
+	return &TaskDependencyController{
+		taskRepo:        taskRepo,
+		dependencyRepo:  dependencyRepo,
+		taskEventRepo:   taskEventRepo,
+		chatMemberships: chatMemberships,
+	}
+}
+
+// GetTree возвращает задачу с деревом подзадач и прогрессом каждого узла. Подзадачи, которые actor
+// не видит, остаются в дереве для подсчёта прогресса, но без названия, статуса и исполнителя
+func (c *TaskDependencyController) GetTree(taskID int, actor *dto.Actor) (*dto.TaskTreeNode, error) {
+	if _, err := findVisibleTask(c.taskRepo, c.chatMemberships, taskID, actor); err != nil {
+		return nil, err
+	}
+	rows, err := c.taskRepo.GetSubtree(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, customErrors.NewTaskNotFoundError(taskID)
+	}
+
+	ids := make([]int, len(rows))
+	for i := range rows {
+		ids[i] = rows[i].ID
+	}
+	hidden, err := c.hiddenTaskIDs(ids, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[int]*dto.TaskTreeNode, len(rows))
+	for i := range rows {
+		rows[i].Subtasks = []*dto.TaskTreeNode{}
+		if hidden[rows[i].ID] {
+			rows[i] = dto.TaskTreeNode{
+				ID:           rows[i].ID,
+				ParentTaskID: rows[i].ParentTaskID,
+				Closed:       rows[i].Closed,
+				Hidden:       true,
+				Subtasks:     rows[i].Subtasks,
+			}
+		}
+		nodes[rows[i].ID] = &rows[i]
+	}
+
+	var root *dto.TaskTreeNode
+	for i := range rows {
+		node := &rows[i]
+		if node.ID == taskID {
+			root = node
+			continue
+		}
+		if node.ParentTaskID != nil {
+			if parent, ok := nodes[*node.ParentTaskID]; ok {
+				parent.Subtasks = append(parent.Subtasks, node)
+			}
+		}
+	}
+
+	rollUpProgress(root)
+	return root, nil
+}
+
+// AddDependency делает задачу blockerTaskID блокирующей для blockedTaskID. Менять зависимости задачи
+// могут те же пользователи, что и редактировать её; блокирующую задачу actor должен видеть
+func (c *TaskDependencyController) AddDependency(blockedTaskID int, actor *dto.Actor, dependencyDTO *dto.AddTaskDependencyDTO) error {
+	blockerTaskID := dependencyDTO.BlockerTaskID
+	if blockerTaskID == blockedTaskID {
+		return customErrors.NewTaskDependencyCycleError(blockerTaskID, blockedTaskID)
+	}
+
+	blocked, err := findTaskForModification(c.taskRepo, c.chatMemberships, blockedTaskID, actor)
+	if err != nil {
+		return err
+	}
+	blocker, err := findVisibleTask(c.taskRepo, c.chatMemberships, blockerTaskID, actor)
+	if err != nil {
+		return err
+	}
+
+	// Если blocked уже прямо или транзитивно блокирует blocker, новая связь замкнёт цикл
+	cycle, err := c.dependencyRepo.HasPath(blockedTaskID, blockerTaskID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return customErrors.NewTaskDependencyCycleError(blockerTaskID, blockedTaskID)
+	}
+
+	// Связь меняет обе задачи: удаление любой из них сериями удалило бы и её
+	for _, task := range []*models.Task{blocked, blocker} {
+		if err := detachOccurrence(c.taskRepo, task); err != nil {
+			return err
+		}
+	}
+	if err := c.dependencyRepo.Create(&models.TaskDependency{
+		BlockerTaskID: blockerTaskID,
+		BlockedTaskID: blockedTaskID,
+	}); err != nil {
+		return err
+	}
+
+	c.recordEvents(newTaskEvent(blockedTaskID, actor.UserID, models.TaskEventBlockerAdded, nil, "", strconv.Itoa(blockerTaskID)))
+	return nil
+}
+
+// RemoveDependency удаляет зависимость; права те же, что и на добавление
+func (c *TaskDependencyController) RemoveDependency(blockedTaskID, blockerTaskID int, actor *dto.Actor) error {
+	blocked, err := findTaskForModification(c.taskRepo, c.chatMemberships, blockedTaskID, actor)
+	if err != nil {
+		return err
+	}
+	if err := detachOccurrence(c.taskRepo, blocked); err != nil {
+		return err
+	}
+	if err := c.dependencyRepo.Delete(blockerTaskID, blockedTaskID); err != nil {
+		return err
+	}
+
+	c.recordEvents(newTaskEvent(blockedTaskID, actor.UserID, models.TaskEventBlockerRemoved, nil, strconv.Itoa(blockerTaskID), ""))
+	return nil
+}
+
+// GetDependencyGraph возвращает граф зависимостей вокруг задачи; у задач, которые actor не видит,
+// скрываются название и статус
+func (c *TaskDependencyController) GetDependencyGraph(taskID int, actor *dto.Actor) (*dto.TaskDependencyGraph, error) {
+	if _, err := findVisibleTask(c.taskRepo, c.chatMemberships, taskID, actor); err != nil {
+		return nil, err
+	}
+
+	nodes, edges, err := c.dependencyRepo.GetGraph(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if nodes == nil {
+		nodes = []dto.TaskGraphNode{}
+	}
+	ids := make([]int, len(nodes))
+	for i := range nodes {
+		ids[i] = nodes[i].ID
+	}
+	hidden, err := c.hiddenTaskIDs(ids, actor)
+	if err != nil {
+		return nil, err
+	}
+	for i := range nodes {
+		if hidden[nodes[i].ID] {
+			nodes[i] = dto.TaskGraphNode{ID: nodes[i].ID, Closed: nodes[i].Closed, Hidden: true}
+		}
+	}
+	if edges == nil {
+		edges = []dto.TaskDependencyEdge{}
+	}
+	return &dto.TaskDependencyGraph{TaskID: taskID, Nodes: nodes, Edges: edges}, nil
+}
+
+// hiddenTaskIDs возвращает задачи из ids, которые actor не видит
+func (c *TaskDependencyController) hiddenTaskIDs(ids []int, actor *dto.Actor) (map[int]bool, error) {
+	visibility, err := taskVisibility(c.chatMemberships, actor)
+	if err != nil || visibility == nil || len(ids) == 0 {
+		return nil, err
+	}
+	visibleIDs, err := c.taskRepo.GetVisibleIDs(ids, visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	hidden := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		hidden[id] = !slices.Contains(visibleIDs, id)
+	}
+	return hidden, nil
+}
+
+// recordEvents сохраняет события истории; ошибка записи не отменяет изменение зависимостей
+func (c *TaskDependencyController) recordEvents(events ...models.TaskEvent) {
+	if err := c.taskEventRepo.Create(events); err != nil {
+		log.Printf("Failed to record task events: %v", err)
+	}
+}
+
+// rollUpProgress считает прогресс узла: закрытая задача выполнена на 100%, открытая без подзадач - на 0%,
+// открытая с подзадачами - на средний прогресс подзадач
+func rollUpProgress(node *dto.TaskTreeNode) int {
+	if len(node.Subtasks) == 0 {
+		node.Progress = 0
+		if node.Closed {
+			node.Progress = 100
+		}
+		return node.Progress
+	}
+
+	total := 0
+	for _, subtask := range node.Subtasks {
+		total += rollUpProgress(subtask)
+	}
+	node.Progress = total / len(node.Subtasks)
+	if node.Closed {
+		node.Progress = 100
+	}
+	return node.Progress
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	customErrors "taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+	"taskService/internal/services"
+
+	"gorm.io/gorm"
+)
+
+// TaskLabelController управляет общими для всех задач метками и их назначением задачам
+type TaskLabelController struct {
+	labelRepo     repositories.LabelRepository
+	taskRepo      repositories.TaskRepository
+	taskEventRepo repositories.TaskEventRepository
+	// chatMemberships - чаты пользователя для проверки видимости задачи
+	chatMemberships services.ChatMembershipServiceInterface
+}
+
+func NewTaskLabelController(
+	labelRepo repositories.LabelRepository,
+	taskRepo repositories.TaskRepository,
+	taskEventRepo repositories.TaskEventRepository,
+	chatMemberships services.ChatMembershipServiceInterface,
+) *TaskLabelController {
+	return &TaskLabelController{
+		labelRepo:       labelRepo,
+		taskRepo:        taskRepo,
+		taskEventRepo:   taskEventRepo,
+		chatMemberships: chatMemberships,
+	}
+}
+
+func (c *TaskLabelController) Create(labelDTO *dto.SaveLabelDTO) (*dto.LabelResponse, error) {
+	existing, err := c.labelRepo.GetByName(labelDTO.Name)
+	if err == nil && existing != nil {
+		return nil, customErrors.ErrLabelAlreadyExists
+	}
+
+	label := &models.Label{Name: labelDTO.Name, Color: labelDTO.Color}
+	if err := c.labelRepo.Create(label); err != nil {
+		return nil, err
+	}
+	return c.GetByID(label.ID)
+}
+
+// Update заменяет название и цвет метки; задачи, которым она назначена, сразу видят изменения
+func (c *TaskLabelController) Update(id int, labelDTO *dto.SaveLabelDTO) (*dto.LabelResponse, error) {
+	existing, err := c.labelRepo.GetByName(labelDTO.Name)
+	if err == nil && existing != nil && existing.ID != id {
+		return nil, customErrors.ErrLabelAlreadyExists
+	}
+
+	if err := c.labelRepo.Update(&models.Label{ID: id, Name: labelDTO.Name, Color: labelDTO.Color}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewLabelNotFoundError(id)
+		}
+		return nil, err
+	}
+	return c.GetByID(id)
+}
+
+func (c *TaskLabelController) GetByID(id int) (*dto.LabelResponse, error) {
+	label, err := c.labelRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewLabelNotFoundError(id)
+		}
+		return nil, err
+	}
+	return label, nil
+}
+
+func (c *TaskLabelController) GetAll() ([]dto.LabelResponse, error) {
+	return c.labelRepo.GetAll()
+}
+
+func (c *TaskLabelController) DeleteByID(id int) error {
+	if err := c.labelRepo.DeleteByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customErrors.NewLabelNotFoundError(id)
+		}
+		return err
+	}
+	return nil
+}
+
+// AddToTask назначает метку задаче. Менять метки задачи могут те же пользователи, что и редактировать её;
+// повторное назначение ничего не меняет и не попадает в историю
+func (c *TaskLabelController) AddToTask(taskID, labelID int, actor *dto.Actor) error {
+	task, err := findTaskForModification(c.taskRepo, c.chatMemberships, taskID, actor)
+	if err != nil {
+		return err
+	}
+	label, err := c.GetByID(labelID)
+	if err != nil {
+		return err
+	}
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return err
+	}
+
+	added, err := c.labelRepo.AddToTask(taskID, labelID)
+	if err != nil {
+		return err
+	}
+	if added {
+		c.recordEvents(newTaskEvent(taskID, actor.UserID, models.TaskEventLabelAdded, nil, "", label.Name))
+	}
+	return nil
+}
+
+// RemoveFromTask снимает метку с задачи; права те же, что и на назначение
+func (c *TaskLabelController) RemoveFromTask(taskID, labelID int, actor *dto.Actor) error {
+	task, err := findTaskForModification(c.taskRepo, c.chatMemberships, taskID, actor)
+	if err != nil {
+		return err
+	}
+	label, err := c.GetByID(labelID)
+	if err != nil {
+		return err
+	}
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return err
+	}
+
+	if err := c.labelRepo.RemoveFromTask(taskID, labelID); err != nil {
+		return err
+	}
+	c.recordEvents(newTaskEvent(taskID, actor.UserID, models.TaskEventLabelRemoved, nil, label.Name, ""))
+	return nil
+}
+
+// GetTaskLabels возвращает метки задачи
+func (c *TaskLabelController) GetTaskLabels(taskID int, actor *dto.Actor) ([]models.Label, error) {
+	if _, err := findVisibleTask(c.taskRepo, c.chatMemberships, taskID, actor); err != nil {
+		return nil, err
+	}
+	return c.labelRepo.GetByTaskID(taskID)
+}
+
+// recordEvents сохраняет события истории; ошибка записи не отменяет изменение меток
+func (c *TaskLabelController) recordEvents(events ...models.TaskEvent) {
+	if err := c.taskEventRepo.Create(events); err != nil {
+		log.Printf("Failed to record task events: %v", err)
+	}
+}
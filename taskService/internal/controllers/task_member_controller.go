@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	cuc "common/contracts/user-contracts"
+	"log"
+	customErrors "taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/http_clients"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+	"taskService/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// TaskMemberController управляет соисполнителями и наблюдателями задач
+type TaskMemberController struct {
+	memberRepo          repositories.TaskMemberRepository
+	taskRepo            repositories.TaskRepository
+	taskEventRepo       repositories.TaskEventRepository
+	notificationService services.NotificationServiceInterface
+	userClient          http_clients.UserClientInterface
+	chatMemberships     services.ChatMembershipServiceInterface
+}
+
+func NewTaskMemberController(
+	memberRepo repositories.TaskMemberRepository,
+	taskRepo repositories.TaskRepository,
+	taskEventRepo repositories.TaskEventRepository,
+	notificationService services.NotificationServiceInterface,
+	chatMemberships services.ChatMembershipServiceInterface,
+) *TaskMemberController {
+	return NewTaskMemberControllerWithClients(
+		memberRepo,
+		taskRepo,
+		taskEventRepo,
+		notificationService,
+		http_clients.NewUserClientAdapter(),
+		chatMemberships,
+	)
+}
+
+// NewTaskMemberControllerWithClients создает контроллер с указанным HTTP клиентом (для тестирования)
+func NewTaskMemberControllerWithClients(
+	memberRepo repositories.TaskMemberRepository,
+	taskRepo repositories.TaskRepository,
+	taskEventRepo repositories.TaskEventRepository,
+	notificationService services.NotificationServiceInterface,
+	userClient http_clients.UserClientInterface,
+	chatMemberships services.ChatMembershipServiceInterface,
+) *TaskMemberController {
+	return &TaskMemberController{
+		memberRepo:          memberRepo,
+		taskRepo:            taskRepo,
+		taskEventRepo:       taskEventRepo,
+		notificationService: notificationService,
+		userClient:          userClient,
+		chatMemberships:     chatMemberships,
+	}
+}
+
+// AddAssignee назначает соисполнителя задачи. Права те же, что и на редактирование задачи; основной
+// исполнитель и уже назначенный соисполнитель ничего не меняют. Новый соисполнитель получает такое же
+// уведомление, как исполнитель при создании задачи
+func (c *TaskMemberController) AddAssignee(taskID int, userID uuid.UUID, actor *dto.Actor) error {
+	task, err := findTaskForModification(c.taskRepo, c.chatMemberships, taskID, actor)
+	if err != nil {
+		return err
+	}
+	if task.ExecutorID == userID {
+		return nil
+	}
+
+	assignee, err := c.userClient.GetUserByID(&userID)
+	if err != nil {
+		return customErrors.NewGetUserHTTPError(userID.String(), err.Error())
+	}
+
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return err
+	}
+	added, err := c.memberRepo.AddAssignee(taskID, userID)
+	if err != nil || !added {
+		return err
+	}
+	c.recordEvents(newTaskEvent(taskID, actor.UserID, models.TaskEventAssigneeAdded, nil, "", userID.String()))
+
+	if userID == actor.UserID || assignee.User == nil || assignee.User.Email == "" {
+		return nil
+	}
+	actorName := "Unknown user"
+	if actorResp, errUser := c.userClient.GetUserByID(&actor.UserID); errUser == nil && actorResp.User != nil && actorResp.User.Username != "" {
+		actorName = actorResp.User.Username
+	}
+	if err := c.notificationService.SendTaskCreatedNotification(task.ID, task.Title, actorName, userID, assignee.User.Email); err != nil {
+		log.Printf("Failed to send task assignee notification: %v", err)
+	}
+	return nil
+}
+
+// RemoveAssignee снимает соисполнителя с задачи; права те же, что и на назначение
+func (c *TaskMemberController) RemoveAssignee(taskID int, userID uuid.UUID, actor *dto.Actor) error {
+	task, err := findTaskForModification(c.taskRepo, c.chatMemberships, taskID, actor)
+	if err != nil {
+		return err
+	}
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return err
+	}
+	if err := c.memberRepo.RemoveAssignee(taskID, userID); err != nil {
+		return err
+	}
+	c.recordEvents(newTaskEvent(taskID, actor.UserID, models.TaskEventAssigneeRemoved, nil, userID.String(), ""))
+	return nil
+}
+
+// AddWatcher подписывает пользователя на уведомления о задаче. Подписаться сам может любой пользователь,
+// подписать другого - тот, кто может редактировать задачу, и только если подписываемый видит задачу. Подписки не попадают в историю задачи
+func (c *TaskMemberController) AddWatcher(taskID int, userID uuid.UUID, actor *dto.Actor) error {
+	task, err := c.checkWatcherChange(taskID, userID, actor)
+	if err != nil {
+		return err
+	}
+	if userID != actor.UserID {
+		watcher, err := c.userClient.GetUserByID(&userID)
+		if err != nil {
+			return customErrors.NewGetUserHTTPError(userID.String(), err.Error())
+		}
+		// Наблюдатель получает название задачи и комментарии в уведомлениях, поэтому подписать можно
+		// только того, кто и так видит задачу
+		if watcher.User == nil {
+			return customErrors.NewTaskAccessDeniedError(taskID, userID.String())
+		}
+		visible, err := canUserViewTask(c.chatMemberships, task, watcher.User)
+		if err != nil {
+			return err
+		}
+		if !visible {
+			return customErrors.NewTaskAccessDeniedError(taskID, userID.String())
+		}
+	}
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return err
+	}
+	_, err = c.memberRepo.AddWatcher(taskID, userID)
+	return err
+}
+
+// RemoveWatcher отписывает пользователя от задачи; права те же, что и на подписку
+func (c *TaskMemberController) RemoveWatcher(taskID int, userID uuid.UUID, actor *dto.Actor) error {
+	task, err := c.checkWatcherChange(taskID, userID, actor)
+	if err != nil {
+		return err
+	}
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return err
+	}
+	return c.memberRepo.RemoveWatcher(taskID, userID)
+}
+
+// checkWatcherChange загружает задачу и проверяет, что actor видит её и может менять подписку пользователя userID
+func (c *TaskMemberController) checkWatcherChange(taskID int, userID uuid.UUID, actor *dto.Actor) (*models.Task, error) {
+	task, err := findVisibleTask(c.taskRepo, c.chatMemberships, taskID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if userID != actor.UserID && !canModifyTask(task, actor) {
+		return nil, customErrors.NewTaskAccessDeniedError(taskID, actor.UserID.String())
+	}
+	return task, nil
+}
+
+// recordEvents сохраняет события истории; ошибка записи не отменяет изменение соисполнителей
+func (c *TaskMemberController) recordEvents(events ...models.TaskEvent) {
+	if err := c.taskEventRepo.Create(events); err != nil {
+		log.Printf("Failed to record task events: %v", err)
+	}
+}
+
+// taskSubscribers возвращает исполнителя, соисполнителей и наблюдателей задачи без повторов
+func taskSubscribers(task *models.Task) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool)
+	var userIDs []uuid.UUID
+	add := func(userID uuid.UUID) {
+		if userID != uuid.Nil && !seen[userID] {
+			seen[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+	add(task.ExecutorID)
+	for _, assignee := range task.Assignees {
+		add(assignee.UserID)
+	}
+	for _, watcher := range task.Watchers {
+		add(watcher.UserID)
+	}
+	return userIDs
+}
+
+// usersBatchSize - максимальное число идентификаторов в одном запросе к userService
+const usersBatchSize = 100
+
+// fetchUsersByIDs загружает пользователей пакетами по usersBatchSize и возвращает их по идентификатору
+func fetchUsersByIDs(userClient http_clients.UserClientInterface, userIDs []uuid.UUID) (map[uuid.UUID]*cuc.User, error) {
+	users := make(map[uuid.UUID]*cuc.User, len(userIDs))
+	for start := 0; start < len(userIDs); start += usersBatchSize {
+		end := min(start+usersBatchSize, len(userIDs))
+		resp, err := userClient.GetUsersByIDs(userIDs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range resp.Users {
+			if user != nil {
+				users[user.ID] = user
+			}
+		}
+	}
+	return users, nil
+}
@@ -0,0 +1,226 @@
+package controllers
+
+import (
+	"errors"
+	customErrors "taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/http_clients"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+	"taskService/internal/services"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaskRecurrenceController управляет сериями повторяющихся задач. Экземпляры серии создаёт
+// TaskRecurrenceScheduler; изменение одного экземпляра - обычное изменение задачи, изменение серии
+// переносится только в не начавшиеся и не изменённые отдельно экземпляры. Серию видят те же пользователи,
+// что и её экземпляры: создатель, исполнитель, участники её чата и пользователи с правом manage_all_tasks
+type TaskRecurrenceController struct {
+	recurrenceRepo repositories.TaskRecurrenceRepository
+	statusRepo     repositories.TaskStatusRepository
+	workflowRepo   repositories.TaskWorkflowRepository
+	userClient     http_clients.UserClientInterface
+	chatClient     http_clients.ChatClientInterface
+	// chatMemberships - чаты пользователя для проверки видимости серий
+	chatMemberships services.ChatMembershipServiceInterface
+}
+
+func NewTaskRecurrenceController(
+	recurrenceRepo repositories.TaskRecurrenceRepository,
+	statusRepo repositories.TaskStatusRepository,
+	workflowRepo repositories.TaskWorkflowRepository,
+	userClient http_clients.UserClientInterface,
+	chatClient http_clients.ChatClientInterface,
+	chatMemberships services.ChatMembershipServiceInterface,
+) *TaskRecurrenceController {
+	return &TaskRecurrenceController{
+		recurrenceRepo:  recurrenceRepo,
+		statusRepo:      statusRepo,
+		workflowRepo:    workflowRepo,
+		userClient:      userClient,
+		chatClient:      chatClient,
+		chatMemberships: chatMemberships,
+	}
+}
+
+// Create создаёт серию от имени actor; первый экземпляр появится при ближайшей проверке планировщика
+func (c *TaskRecurrenceController) Create(actor *dto.Actor, recurrenceDTO *dto.SaveTaskRecurrenceDTO) (*models.TaskRecurrence, error) {
+	rule, err := c.validate(recurrenceDTO, actor, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	first, ok := nextOccurrence(rule, recurrenceDTO.StartsAt, time.Now())
+	if !ok {
+		return nil, customErrors.NewInvalidRecurrenceRuleError(recurrenceDTO.Rule, "rule has no future occurrences")
+	}
+
+	recurrence := &models.TaskRecurrence{CreatorID: actor.UserID, NextOccurrenceAt: &first}
+	applyRecurrenceDTO(recurrence, recurrenceDTO)
+
+	if err := c.recurrenceRepo.Create(recurrence); err != nil {
+		return nil, err
+	}
+	return recurrence, nil
+}
+
+// GetByID возвращает серию, если её видит actor; чужая серия не отличается от несуществующей
+func (c *TaskRecurrenceController) GetByID(id int, actor *dto.Actor) (*models.TaskRecurrence, error) {
+	recurrence, err := c.find(id)
+	if err != nil {
+		return nil, err
+	}
+	// Серию видят те же пользователи, что и задачи, которые по ней создаются
+	visible, err := canViewTask(c.chatMemberships, &models.Task{
+		CreatorID:  recurrence.CreatorID,
+		ExecutorID: recurrence.ExecutorID,
+		ChatID:     recurrence.ChatID,
+	}, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, customErrors.NewTaskRecurrenceNotFoundError(id)
+	}
+	return recurrence, nil
+}
+
+func (c *TaskRecurrenceController) find(id int) (*models.TaskRecurrence, error) {
+	recurrence, err := c.recurrenceRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewTaskRecurrenceNotFoundError(id)
+		}
+		return nil, err
+	}
+	return recurrence, nil
+}
+
+// Update заменяет серию целиком. Новые шаблон задачи и срок переносятся в ещё не начавшиеся экземпляры,
+// которые не меняли отдельно. Если изменились правило, начало серии или workflow, такие экземпляры
+// удаляются и создаются заново по новому правилу, начиная с текущего момента
+func (c *TaskRecurrenceController) Update(id int, actor *dto.Actor, recurrenceDTO *dto.SaveTaskRecurrenceDTO) (*models.TaskRecurrence, error) {
+	recurrence, err := c.getForModification(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := c.validate(recurrenceDTO, actor, recurrence.ChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reschedule := recurrence.Rule != recurrenceDTO.Rule ||
+		!recurrence.StartsAt.Equal(recurrenceDTO.StartsAt) ||
+		!sameInt(recurrence.WorkflowID, recurrenceDTO.WorkflowID)
+
+	applyRecurrenceDTO(recurrence, recurrenceDTO)
+	recurrence.UpdatedAt = &now
+	if reschedule {
+		recurrence.NextOccurrenceAt = nil
+		if next, ok := nextOccurrence(rule, recurrence.StartsAt, now); ok {
+			recurrence.NextOccurrenceAt = &next
+		}
+	}
+
+	// Новое updated_at серии не даёт планировщику создать экземпляры по прочитанной до изменения версии
+	if err := c.recurrenceRepo.Update(recurrence); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewTaskRecurrenceNotFoundError(id)
+		}
+		return nil, err
+	}
+
+	if reschedule {
+		err = c.recurrenceRepo.DeletePendingOccurrences(id, now)
+	} else {
+		err = c.recurrenceRepo.UpdatePendingOccurrences(recurrence, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return recurrence, nil
+}
+
+// Delete удаляет серию и её не начавшиеся неизменённые экземпляры; остальные экземпляры остаются задачами
+func (c *TaskRecurrenceController) Delete(id int, actor *dto.Actor) error {
+	if _, err := c.getForModification(id, actor); err != nil {
+		return err
+	}
+	if err := c.recurrenceRepo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customErrors.NewTaskRecurrenceNotFoundError(id)
+		}
+		return err
+	}
+	return c.recurrenceRepo.DeletePendingOccurrences(id, time.Now())
+}
+
+// getForModification загружает видимую actor серию; менять её могут создатель и пользователи
+// с правом manage_all_tasks
+func (c *TaskRecurrenceController) getForModification(id int, actor *dto.Actor) (*models.TaskRecurrence, error) {
+	recurrence, err := c.GetByID(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if recurrence.CreatorID != actor.UserID && !actor.HasPermission(dto.PermissionManageAllTasks) {
+		return nil, customErrors.NewTaskRecurrenceAccessDeniedError(id, actor.UserID.String())
+	}
+	return recurrence, nil
+}
+
+// validate разбирает правило и проверяет workflow, исполнителя и чат так же, как при создании задачи.
+// Новый чат серии (отличный от currentChatID) должен существовать, и actor должен в нём состоять:
+// экземпляры серии появятся в этом чате от его имени
+func (c *TaskRecurrenceController) validate(recurrenceDTO *dto.SaveTaskRecurrenceDTO, actor *dto.Actor, currentChatID uuid.UUID) (*models.RecurrenceRule, error) {
+	rule, err := models.ParseRecurrenceRule(recurrenceDTO.Rule)
+	if err != nil {
+		return nil, customErrors.NewInvalidRecurrenceRuleError(recurrenceDTO.Rule, err.Error())
+	}
+
+	if _, err := services.InitialTaskStatus(c.statusRepo, c.workflowRepo, recurrenceDTO.WorkflowID); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.userClient.GetUserByID(&recurrenceDTO.ExecutorID); err != nil {
+		return nil, customErrors.NewGetUserHTTPError(recurrenceDTO.ExecutorID.String(), err.Error())
+	}
+
+	if recurrenceDTO.ChatID != uuid.Nil && recurrenceDTO.ChatID != currentChatID {
+		if _, err := c.chatClient.GetChatByID(recurrenceDTO.ChatID.String()); err != nil {
+			return nil, customErrors.NewGetChatHTTPError(recurrenceDTO.ChatID.String(), err.Error())
+		}
+		if err := checkChatMember(c.chatMemberships, recurrenceDTO.ChatID, actor); err != nil {
+			return nil, err
+		}
+	}
+	return rule, nil
+}
+
+// nextOccurrence возвращает первый экземпляр серии не раньше now: прошедшие экземпляры не создаются
+func nextOccurrence(rule *models.RecurrenceRule, startsAt, now time.Time) (time.Time, bool) {
+	from := startsAt
+	if now.After(from) {
+		from = now
+	}
+	return rule.Next(startsAt, from)
+}
+
+func applyRecurrenceDTO(recurrence *models.TaskRecurrence, recurrenceDTO *dto.SaveTaskRecurrenceDTO) {
+	priority := recurrenceDTO.Priority
+	if priority == "" {
+		priority = models.TaskPriorityNormal
+	}
+
+	recurrence.Title = recurrenceDTO.Title
+	recurrence.Description = recurrenceDTO.Description
+	recurrence.ExecutorID = recurrenceDTO.ExecutorID
+	recurrence.ChatID = recurrenceDTO.ChatID
+	recurrence.WorkflowID = recurrenceDTO.WorkflowID
+	recurrence.Priority = priority
+	recurrence.Rule = recurrenceDTO.Rule
+	recurrence.StartsAt = recurrenceDTO.StartsAt
+	recurrence.DueAfterMinutes = recurrenceDTO.DueAfterMinutes
+}
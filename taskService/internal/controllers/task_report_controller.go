@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+	customErrors "taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/repositories"
+	"taskService/internal/services"
+	"time"
+)
+
+// MaxTaskReportPeriod - самый длинный период отчёта; ограничивает число строк отчётов по дням
+const MaxTaskReportPeriod = 366 * 24 * time.Hour
+
+// TaskReportController строит отчёты по задачам для руководителей команд. Отчёты считаются только
+// по задачам, которые видит actor; отчёт по чату доступен участникам чата и пользователям
+// с правом manage_all_tasks
+type TaskReportController struct {
+	reportRepo repositories.TaskReportRepository
+	// chatMemberships - чаты пользователя для проверки видимости задач
+	chatMemberships services.ChatMembershipServiceInterface
+}
+
+func NewTaskReportController(
+	reportRepo repositories.TaskReportRepository,
+	chatMemberships services.ChatMembershipServiceInterface,
+) *TaskReportController {
+	return &TaskReportController{reportRepo: reportRepo, chatMemberships: chatMemberships}
+}
+
+// GetThroughput возвращает число созданных и завершённых задач по дням или неделям
+func (c *TaskReportController) GetThroughput(actor *dto.Actor, query *dto.TaskReportQuery) ([]dto.TaskThroughput, error) {
+	if query.Interval != dto.ReportIntervalDay && query.Interval != dto.ReportIntervalWeek {
+		return nil, customErrors.NewInvalidTaskReportError("interval must be day or week")
+	}
+	if err := validateReportPeriod(query); err != nil {
+		return nil, err
+	}
+	visibility, err := c.reportVisibility(actor, query.ChatID)
+	if err != nil {
+		return nil, err
+	}
+	return c.reportRepo.GetThroughput(query, visibility)
+}
+
+// GetStatusDurations возвращает среднее время пребывания задач в каждом статусе по истории смены статусов
+func (c *TaskReportController) GetStatusDurations(actor *dto.Actor, query *dto.TaskReportQuery) ([]dto.TaskStatusDuration, error) {
+	if err := validateReportPeriod(query); err != nil {
+		return nil, err
+	}
+	visibility, err := c.reportVisibility(actor, query.ChatID)
+	if err != nil {
+		return nil, err
+	}
+	return c.reportRepo.GetStatusDurations(query, visibility)
+}
+
+// GetOverdue возвращает число просроченных незакрытых задач по исполнителям на текущий момент
+func (c *TaskReportController) GetOverdue(actor *dto.Actor, chatID *uuid.UUID) ([]dto.TaskOverdueCount, error) {
+	visibility, err := c.reportVisibility(actor, chatID)
+	if err != nil {
+		return nil, err
+	}
+	return c.reportRepo.GetOverdueByExecutor(time.Now(), chatID, visibility)
+}
+
+// GetCumulativeFlow возвращает число задач чата в каждом статусе на конец каждого дня периода
+func (c *TaskReportController) GetCumulativeFlow(actor *dto.Actor, query *dto.TaskReportQuery) ([]dto.TaskCumulativeFlow, error) {
+	if query.ChatID == nil {
+		return nil, customErrors.NewInvalidTaskReportError("chat_id is required")
+	}
+	if err := validateReportPeriod(query); err != nil {
+		return nil, err
+	}
+	visibility, err := c.reportVisibility(actor, query.ChatID)
+	if err != nil {
+		return nil, err
+	}
+	return c.reportRepo.GetCumulativeFlow(query, visibility)
+}
+
+// reportVisibility возвращает ограничение отчёта задачами, видимыми actor; отчёт по чату chatID
+// строится только для участников чата. nil - actor видит все задачи
+func (c *TaskReportController) reportVisibility(actor *dto.Actor, chatID *uuid.UUID) (*dto.TaskVisibility, error) {
+	visibility, err := taskVisibility(c.chatMemberships, actor)
+	if err != nil {
+		return nil, err
+	}
+	if visibility != nil && chatID != nil && !slices.Contains(visibility.ChatIDs, *chatID) {
+		return nil, customErrors.NewChatMembershipRequiredError(chatID.String(), actor.UserID.String())
+	}
+	return visibility, nil
+}
+
+func validateReportPeriod(query *dto.TaskReportQuery) error {
+	if !query.From.Before(query.To) {
+		return customErrors.NewInvalidTaskReportError("from must be before to")
+	}
+	if query.To.Sub(query.From) > MaxTaskReportPeriod {
+		return customErrors.NewInvalidTaskReportError(fmt.Sprintf("period must not exceed %d days", int(MaxTaskReportPeriod.Hours()/24)))
+	}
+	return nil
+}
@@ -1,7 +1,13 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
 	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
 	"taskService/internal/models"
 	"taskService/internal/repositories"
 )
@@ -30,10 +36,103 @@ func (c *TaskStatusController) GetByID(id int) (*models.TaskStatus, error) {
 	return status, nil
 }
 
-func (c *TaskStatusController) DeleteByID(id int) error {
-	return c.repo.DeleteByID(id)
+// GetUsage возвращает число задач в статусе и workflow, в которых он используется
+func (c *TaskStatusController) GetUsage(id int) (*dto.TaskStatusUsage, error) {
+	status, err := c.findStatus(id)
+	if err != nil {
+		return nil, err
+	}
+	usage, _, err := c.getUsage(status)
+	return usage, err
+}
+
+// DeleteByID удаляет статус. Стартовый статус задач без workflow и статусы, используемые в workflow, не удаляются.
+// Задачи статуса, включая удалённые, переносятся в статус replacementID, без него статус с задачами не удаляется.
+// Статус replacementID должен входить в workflow каждой переносимой задачи: собственный или workflow по умолчанию.
+// Перенос - административная операция: уведомления и доменные события не отправляются, он виден только в истории задач
+func (c *TaskStatusController) DeleteByID(id int, replacementID *int, actor *dto.Actor) error {
+	status, err := c.findStatus(id)
+	if err != nil {
+		return err
+	}
+	usage, reason, err := c.getUsage(status)
+	if err != nil {
+		return err
+	}
+	if usage.Protected {
+		return custom_errors.NewTaskStatusProtectedError(id, reason)
+	}
+
+	var replacement *models.TaskStatus
+	if replacementID != nil {
+		if *replacementID == id {
+			return custom_errors.NewInvalidStatusReplacementError("status can't be replaced by itself")
+		}
+		replacement, err = c.repo.GetByID(*replacementID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return custom_errors.NewInvalidStatusReplacementError(fmt.Sprintf("status %d not found", *replacementID))
+			}
+			return err
+		}
+	}
+
+	// Задачи считаются заново в транзакции удаления: usage мог устареть
+	if err := c.repo.Delete(status, replacement, actor.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return custom_errors.NewTaskStatusNotFoundError(strconv.Itoa(id))
+		}
+		return err
+	}
+	return nil
 }
 
 func (c *TaskStatusController) GetAll() ([]models.TaskStatus, error) {
 	return c.repo.GetAll()
 }
+
+func (c *TaskStatusController) findStatus(id int) (*models.TaskStatus, error) {
+	status, err := c.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_errors.NewTaskStatusNotFoundError(strconv.Itoa(id))
+		}
+		return nil, err
+	}
+	return status, nil
+}
+
+// getUsage собирает использование статуса и причину, по которой его нельзя удалить
+func (c *TaskStatusController) getUsage(status *models.TaskStatus) (*dto.TaskStatusUsage, string, error) {
+	tasks, deletedTasks, err := c.repo.CountTasks(status.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	workflows, err := c.repo.GetWorkflowsUsing(status.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	usage := &dto.TaskStatusUsage{
+		StatusID:     status.ID,
+		Name:         status.Name,
+		Tasks:        tasks,
+		DeletedTasks: deletedTasks,
+		Workflows:    make([]dto.TaskStatusWorkflowRef, 0, len(workflows)),
+	}
+	names := make([]string, 0, len(workflows))
+	for _, workflow := range workflows {
+		usage.Workflows = append(usage.Workflows, dto.TaskStatusWorkflowRef{ID: workflow.ID, Name: workflow.Name})
+		names = append(names, workflow.Name)
+	}
+
+	reason := ""
+	switch {
+	case status.Name == models.TaskStatusCreated:
+		reason = "new tasks without workflow start in it"
+	case len(names) > 0:
+		reason = "used by workflows: " + strings.Join(names, ", ")
+	}
+	usage.Protected = reason != ""
+	return usage, reason, nil
+}
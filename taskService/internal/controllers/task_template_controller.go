@@ -0,0 +1,268 @@
+package controllers
+
+import (
+	"errors"
+	customErrors "taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaskTemplateController управляет шаблонами задач и создаёт задачи по ним. Шаблон видят его создатель,
+// исполнитель по умолчанию и пользователи с правом manage_all_tasks; менять его могут создатель
+// и пользователи с правом manage_all_tasks
+type TaskTemplateController struct {
+	tasks        *TaskController
+	templateRepo repositories.TaskTemplateRepository
+	labelRepo    repositories.LabelRepository
+}
+
+func NewTaskTemplateController(
+	tasks *TaskController,
+	templateRepo repositories.TaskTemplateRepository,
+	labelRepo repositories.LabelRepository,
+) *TaskTemplateController {
+	return &TaskTemplateController{
+		tasks:        tasks,
+		templateRepo: templateRepo,
+		labelRepo:    labelRepo,
+	}
+}
+
+// Create создаёт шаблон от имени actor
+func (c *TaskTemplateController) Create(actor *dto.Actor, templateDTO *dto.SaveTaskTemplateDTO) (*models.TaskTemplate, error) {
+	if err := c.validate(0, templateDTO); err != nil {
+		return nil, err
+	}
+
+	template := &models.TaskTemplate{CreatorID: actor.UserID}
+	applyTemplateDTO(template, templateDTO)
+	if err := c.templateRepo.Create(template); err != nil {
+		return nil, err
+	}
+	return c.find(template.ID)
+}
+
+// GetByID возвращает шаблон, если его видит actor; чужой шаблон не отличается от несуществующего
+func (c *TaskTemplateController) GetByID(id int, actor *dto.Actor) (*models.TaskTemplate, error) {
+	template, err := c.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewTemplate(template, actor) {
+		return nil, customErrors.NewTaskTemplateNotFoundError(id)
+	}
+	return template, nil
+}
+
+// GetAll возвращает шаблоны, которые видит actor
+func (c *TaskTemplateController) GetAll(actor *dto.Actor) ([]models.TaskTemplate, error) {
+	if actor.HasPermission(dto.PermissionManageAllTasks) {
+		return c.templateRepo.GetAll(nil)
+	}
+	return c.templateRepo.GetAll(&actor.UserID)
+}
+
+func (c *TaskTemplateController) find(id int) (*models.TaskTemplate, error) {
+	template, err := c.templateRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewTaskTemplateNotFoundError(id)
+		}
+		return nil, err
+	}
+	return template, nil
+}
+
+// Update заменяет шаблон целиком; задачи, уже созданные по нему, не меняются
+func (c *TaskTemplateController) Update(id int, actor *dto.Actor, templateDTO *dto.SaveTaskTemplateDTO) (*models.TaskTemplate, error) {
+	template, err := c.getForModification(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validate(id, templateDTO); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	applyTemplateDTO(template, templateDTO)
+	template.UpdatedAt = &now
+	if err := c.templateRepo.Update(template); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewTaskTemplateNotFoundError(id)
+		}
+		return nil, err
+	}
+	return c.find(id)
+}
+
+func (c *TaskTemplateController) Delete(id int, actor *dto.Actor) error {
+	if _, err := c.getForModification(id, actor); err != nil {
+		return err
+	}
+	if err := c.templateRepo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customErrors.NewTaskTemplateNotFoundError(id)
+		}
+		return err
+	}
+	return nil
+}
+
+// CreateTask создаёт задачу по шаблону от имени actor. Задача создаётся через TaskController.Create
+// с теми же проверками пользователей, чата, файлов и workflow; затем ей назначаются метки шаблона
+// и копируются пункты его чек-листа
+func (c *TaskTemplateController) CreateTask(id int, actor *dto.Actor, fromTemplateDTO *dto.CreateTaskFromTemplateDTO) (*models.Task, error) {
+	template, err := c.GetByID(id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := template.RenderTitle(fromTemplateDTO.Variables, time.Now())
+	if err != nil {
+		return nil, customErrors.NewInvalidTaskTemplateError(id, err.Error())
+	}
+
+	executorID := uuid.Nil
+	if template.ExecutorID != nil {
+		executorID = *template.ExecutorID
+	}
+	if fromTemplateDTO.ExecutorID != nil {
+		executorID = *fromTemplateDTO.ExecutorID
+	}
+	priority := template.Priority
+	if fromTemplateDTO.Priority != "" {
+		priority = fromTemplateDTO.Priority
+	}
+	description := template.Description
+
+	labelIDs := make([]int, 0, len(template.Labels))
+	for _, templateLabel := range template.Labels {
+		labelIDs = append(labelIDs, templateLabel.LabelID)
+	}
+	checklist := make([]models.TaskChecklistItem, 0, len(template.ChecklistItems))
+	for i, templateItem := range template.ChecklistItems {
+		checklist = append(checklist, models.TaskChecklistItem{Title: templateItem.Title, Position: i})
+	}
+
+	// Задача, её метки и чек-лист сохраняются одной транзакцией: задача без части шаблона не создаётся
+	task, err := c.tasks.create(actor, &dto.CreateTaskDTO{
+		Title:           title,
+		Description:     &description,
+		ExecutorID:      executorID,
+		ChatID:          fromTemplateDTO.ChatID,
+		FileIDs:         fromTemplateDTO.FileIDs,
+		WorkflowID:      fromTemplateDTO.WorkflowID,
+		ParentTaskID:    fromTemplateDTO.ParentTaskID,
+		Priority:        priority,
+		StartAt:         fromTemplateDTO.StartAt,
+		DueAt:           fromTemplateDTO.DueAt,
+		EstimateMinutes: fromTemplateDTO.EstimateMinutes,
+	}, func(task *models.Task, files []models.TaskFile) error {
+		return c.templateRepo.CreateTask(task, files, labelIDs, checklist)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []models.TaskEvent
+	for _, templateLabel := range template.Labels {
+		if templateLabel.Label != nil {
+			events = append(events, newTaskEvent(task.ID, actor.UserID, models.TaskEventLabelAdded, nil, "", templateLabel.Label.Name))
+		}
+	}
+	if len(checklist) > 0 {
+		for _, item := range checklist {
+			events = append(events, newTaskEvent(task.ID, actor.UserID, models.TaskEventChecklistAdded, nil, "", item.Title))
+		}
+		task.Checklist = checklist
+	}
+
+	if len(events) > 0 {
+		c.tasks.recordEvents(task, events...)
+	}
+	return task, nil
+}
+
+// getForModification загружает видимый actor шаблон; менять его могут создатель и пользователи
+// с правом manage_all_tasks
+func (c *TaskTemplateController) getForModification(id int, actor *dto.Actor) (*models.TaskTemplate, error) {
+	template, err := c.GetByID(id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if template.CreatorID != actor.UserID && !actor.HasPermission(dto.PermissionManageAllTasks) {
+		return nil, customErrors.NewTaskTemplateAccessDeniedError(id, actor.UserID.String())
+	}
+	return template, nil
+}
+
+// validate проверяет уникальность названия, метки и исполнителя по умолчанию; id - изменяемый шаблон или 0
+func (c *TaskTemplateController) validate(id int, templateDTO *dto.SaveTaskTemplateDTO) error {
+	existing, err := c.templateRepo.GetByName(templateDTO.Name)
+	if err == nil && existing != nil && existing.ID != id {
+		return customErrors.ErrTaskTemplateAlreadyExists
+	}
+
+	for _, labelID := range templateDTO.LabelIDs {
+		if _, err := c.labelRepo.GetByID(labelID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customErrors.NewLabelNotFoundError(labelID)
+			}
+			return err
+		}
+	}
+
+	if templateDTO.ExecutorID != nil && *templateDTO.ExecutorID != uuid.Nil {
+		if _, err := c.tasks.UserClient.GetUserByID(templateDTO.ExecutorID); err != nil {
+			return customErrors.NewGetUserHTTPError(templateDTO.ExecutorID.String(), err.Error())
+		}
+	}
+	return nil
+}
+
+func canViewTemplate(template *models.TaskTemplate, actor *dto.Actor) bool {
+	return template.CreatorID == actor.UserID ||
+		(template.ExecutorID != nil && *template.ExecutorID == actor.UserID) ||
+		actor.HasPermission(dto.PermissionManageAllTasks)
+}
+
+func applyTemplateDTO(template *models.TaskTemplate, templateDTO *dto.SaveTaskTemplateDTO) {
+	priority := templateDTO.Priority
+	if priority == "" {
+		priority = models.TaskPriorityNormal
+	}
+
+	template.Name = templateDTO.Name
+	template.TitlePattern = templateDTO.TitlePattern
+	template.Description = templateDTO.Description
+	template.ExecutorID = nil
+	if templateDTO.ExecutorID != nil && *templateDTO.ExecutorID != uuid.Nil {
+		template.ExecutorID = templateDTO.ExecutorID
+	}
+	template.Priority = priority
+
+	template.Labels = nil
+	for _, labelID := range templateDTO.LabelIDs {
+		if !templateHasLabel(template, labelID) {
+			template.Labels = append(template.Labels, models.TaskTemplateLabel{LabelID: labelID})
+		}
+	}
+	template.ChecklistItems = nil
+	for i, title := range templateDTO.ChecklistItems {
+		template.ChecklistItems = append(template.ChecklistItems, models.TaskTemplateChecklistItem{Title: title, Position: i})
+	}
+}
+
+func templateHasLabel(template *models.TaskTemplate, labelID int) bool {
+	for _, label := range template.Labels {
+		if label.LabelID == labelID {
+			return true
+		}
+	}
+	return false
+}
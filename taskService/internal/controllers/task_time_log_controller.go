@@ -0,0 +1,238 @@
+package controllers
+
+import (
+	"errors"
+	customErrors "taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+	"taskService/internal/services"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TaskTimeLogController ведёт учёт затраченного на задачи времени: таймер и ручные записи
+type TaskTimeLogController struct {
+	timeLogRepo     repositories.TaskTimeLogRepository
+	taskRepo        repositories.TaskRepository
+	chatMemberships services.ChatMembershipServiceInterface
+}
+
+func NewTaskTimeLogController(
+	timeLogRepo repositories.TaskTimeLogRepository,
+	taskRepo repositories.TaskRepository,
+	chatMemberships services.ChatMembershipServiceInterface,
+) *TaskTimeLogController {
+	return &TaskTimeLogController{
+		timeLogRepo:     timeLogRepo,
+		taskRepo:        taskRepo,
+		chatMemberships: chatMemberships,
+	}
+}
+
+// StartTimer запускает таймер пользователя на задаче. Списывать время могут только исполнитель
+// и соисполнители задачи; одновременно у пользователя может быть запущен один таймер.
+// Экземпляр серии отделяется от неё уже при запуске, поэтому остановка таймера его не отделяет
+func (c *TaskTimeLogController) StartTimer(taskID int, actor *dto.Actor) (*models.TaskTimeLog, error) {
+	task, err := c.checkCanLogTime(taskID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return nil, err
+	}
+
+	log := &models.TaskTimeLog{
+		TaskID:    taskID,
+		UserID:    actor.UserID,
+		StartedAt: time.Now(),
+	}
+	started, err := c.timeLogRepo.StartTimer(log)
+	if err != nil {
+		return nil, err
+	}
+	if !started {
+		runningTaskID := 0
+		if running, errRunning := c.timeLogRepo.GetRunning(actor.UserID); errRunning == nil {
+			runningTaskID = running.TaskID
+		}
+		return nil, customErrors.NewTaskTimerAlreadyRunningError(actor.UserID.String(), runningTaskID)
+	}
+	return log, nil
+}
+
+// StopTimer останавливает запущенный пользователем таймер задачи и фиксирует длительность в минутах
+func (c *TaskTimeLogController) StopTimer(taskID int, actor *dto.Actor) (*models.TaskTimeLog, error) {
+	log, err := c.timeLogRepo.GetRunning(actor.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewTaskTimerNotRunningError(taskID, actor.UserID.String())
+		}
+		return nil, err
+	}
+	if log.TaskID != taskID {
+		return nil, customErrors.NewTaskTimerNotRunningError(taskID, actor.UserID.String())
+	}
+
+	now := time.Now()
+	if now.Before(log.StartedAt) {
+		now = log.StartedAt
+	}
+	log.EndedAt = &now
+	log.Minutes = int(now.Sub(log.StartedAt).Round(time.Minute) / time.Minute)
+	log.UpdatedAt = &now
+	if err := c.timeLogRepo.Update(log); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// Create добавляет запись о затраченном времени вручную; права те же, что и на запуск таймера
+func (c *TaskTimeLogController) Create(taskID int, actor *dto.Actor, logDTO *dto.CreateTaskTimeLogDTO) (*models.TaskTimeLog, error) {
+	task, err := c.checkCanLogTime(taskID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	startedAt := logDTO.StartedAt
+	endedAt := startedAt.Add(time.Duration(logDTO.Minutes) * time.Minute)
+	if err := c.validateInterval(endedAt); err != nil {
+		return nil, err
+	}
+
+	log := &models.TaskTimeLog{
+		TaskID:      taskID,
+		UserID:      actor.UserID,
+		StartedAt:   startedAt,
+		EndedAt:     &endedAt,
+		Minutes:     logDTO.Minutes,
+		Description: logDTO.Description,
+	}
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return nil, err
+	}
+	if err := c.timeLogRepo.Create(log); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// Update изменяет запись. Доступно автору записи и пользователям с правом manage_all_tasks
+func (c *TaskTimeLogController) Update(taskID, logID int, actor *dto.Actor, updateDTO *dto.UpdateTaskTimeLogDTO) (*models.TaskTimeLog, error) {
+	task, log, err := c.getLogForModification(taskID, logID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateDTO.Minutes != nil && log.IsRunning() {
+		return nil, customErrors.NewInvalidTaskTimeLogError("stop the timer before changing its duration")
+	}
+	if updateDTO.StartedAt != nil {
+		log.StartedAt = *updateDTO.StartedAt
+	}
+	if updateDTO.Minutes != nil {
+		log.Minutes = *updateDTO.Minutes
+	}
+	if updateDTO.Description != nil {
+		log.Description = *updateDTO.Description
+	}
+
+	if log.IsRunning() {
+		if log.StartedAt.After(time.Now()) {
+			return nil, customErrors.NewInvalidTaskTimeLogError("timer cannot start in the future")
+		}
+	} else {
+		endedAt := log.StartedAt.Add(time.Duration(log.Minutes) * time.Minute)
+		if err := c.validateInterval(endedAt); err != nil {
+			return nil, err
+		}
+		log.EndedAt = &endedAt
+	}
+
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	log.UpdatedAt = &now
+	if err := c.timeLogRepo.Update(log); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// Delete удаляет запись; права те же, что и на изменение
+func (c *TaskTimeLogController) Delete(taskID, logID int, actor *dto.Actor) error {
+	task, _, err := c.getLogForModification(taskID, logID, actor)
+	if err != nil {
+		return err
+	}
+	if err := detachOccurrence(c.taskRepo, task); err != nil {
+		return err
+	}
+	return c.timeLogRepo.Delete(logID)
+}
+
+// GetByTaskID возвращает записи задачи, последние первыми
+func (c *TaskTimeLogController) GetByTaskID(taskID int, actor *dto.Actor, limit, offset int) ([]models.TaskTimeLog, error) {
+	if _, err := findVisibleTask(c.taskRepo, c.chatMemberships, taskID, actor); err != nil {
+		return nil, err
+	}
+	return c.timeLogRepo.GetByTaskID(taskID, limit, offset)
+}
+
+// GetTimeSpent возвращает затраченное время по задачам, пользователям или чатам за период;
+// учитываются только задачи, которые видит actor
+func (c *TaskTimeLogController) GetTimeSpent(actor *dto.Actor, query *dto.TimeSpentQuery) ([]dto.TimeSpent, error) {
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return nil, customErrors.NewInvalidTaskTimeLogError("from must be before to")
+	}
+	visibility, err := taskVisibility(c.chatMemberships, actor)
+	if err != nil {
+		return nil, err
+	}
+	return c.timeLogRepo.GetTimeSpent(query, visibility)
+}
+
+// checkCanLogTime загружает задачу и проверяет, что пользователь - её исполнитель или соисполнитель
+func (c *TaskTimeLogController) checkCanLogTime(taskID int, actor *dto.Actor) (*models.Task, error) {
+	task, err := findVisibleTask(c.taskRepo, c.chatMemberships, taskID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !task.IsExecutor(actor.UserID) {
+		return nil, customErrors.NewTaskAccessDeniedError(taskID, actor.UserID.String())
+	}
+	return task, nil
+}
+
+// getLogForModification загружает видимую actor задачу и её запись и проверяет, что actor - автор записи
+// или администратор
+func (c *TaskTimeLogController) getLogForModification(taskID, logID int, actor *dto.Actor) (*models.Task, *models.TaskTimeLog, error) {
+	task, err := findVisibleTask(c.taskRepo, c.chatMemberships, taskID, actor)
+	if err != nil {
+		return nil, nil, err
+	}
+	log, err := c.timeLogRepo.GetByID(logID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, customErrors.NewTaskTimeLogNotFoundError(logID)
+		}
+		return nil, nil, err
+	}
+	if log.TaskID != taskID {
+		return nil, nil, customErrors.NewTaskTimeLogNotFoundError(logID)
+	}
+	if log.UserID != actor.UserID && !actor.HasPermission(dto.PermissionManageAllTasks) {
+		return nil, nil, customErrors.NewTaskTimeLogAccessDeniedError(logID, actor.UserID.String())
+	}
+	return task, log, nil
+}
+
+// validateInterval запрещает записи, которые заканчиваются в будущем
+func (c *TaskTimeLogController) validateInterval(endedAt time.Time) error {
+	if endedAt.After(time.Now()) {
+		return customErrors.NewInvalidTaskTimeLogError("time log cannot end in the future")
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+type TaskWorkflowController struct {
+	repo       repositories.TaskWorkflowRepository
+	statusRepo repositories.TaskStatusRepository
+}
+
+func NewTaskWorkflowController(
+	repo repositories.TaskWorkflowRepository,
+	statusRepo repositories.TaskStatusRepository,
+) *TaskWorkflowController {
+	return &TaskWorkflowController{repo: repo, statusRepo: statusRepo}
+}
+
+func (c *TaskWorkflowController) Create(workflowDTO *dto.SaveWorkflowDTO) (*models.TaskWorkflow, error) {
+	existing, err := c.repo.GetByName(workflowDTO.Name)
+	if err == nil && existing != nil {
+		return nil, custom_errors.ErrWorkflowAlreadyExists
+	}
+
+	workflow, err := c.buildWorkflow(workflowDTO)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.repo.Create(workflow); err != nil {
+		return nil, err
+	}
+	return c.repo.GetByID(workflow.ID)
+}
+
+// Update полностью заменяет описание workflow
+func (c *TaskWorkflowController) Update(id int, workflowDTO *dto.SaveWorkflowDTO) (*models.TaskWorkflow, error) {
+	existing, err := c.repo.GetByName(workflowDTO.Name)
+	if err == nil && existing != nil && existing.ID != id {
+		return nil, custom_errors.ErrWorkflowAlreadyExists
+	}
+
+	workflow, err := c.buildWorkflow(workflowDTO)
+	if err != nil {
+		return nil, err
+	}
+	workflow.ID = id
+	if err := c.repo.Replace(workflow); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_errors.NewWorkflowNotFoundError(id)
+		}
+		return nil, err
+	}
+	return c.repo.GetByID(id)
+}
+
+func (c *TaskWorkflowController) GetByID(id int) (*models.TaskWorkflow, error) {
+	workflow, err := c.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, custom_errors.NewWorkflowNotFoundError(id)
+		}
+		return nil, err
+	}
+	return workflow, nil
+}
+
+func (c *TaskWorkflowController) GetAll() ([]models.TaskWorkflow, error) {
+	return c.repo.GetAll()
+}
+
+func (c *TaskWorkflowController) DeleteByID(id int) error {
+	if err := c.repo.DeleteByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return custom_errors.NewWorkflowNotFoundError(id)
+		}
+		return err
+	}
+	return nil
+}
+
+// buildWorkflow проверяет статусы и переходы и собирает модель workflow
+func (c *TaskWorkflowController) buildWorkflow(workflowDTO *dto.SaveWorkflowDTO) (*models.TaskWorkflow, error) {
+	workflow := &models.TaskWorkflow{
+		Name:      workflowDTO.Name,
+		IsDefault: workflowDTO.IsDefault,
+	}
+
+	inWorkflow := make(map[int]bool, len(workflowDTO.StatusIDs))
+	for i, statusID := range workflowDTO.StatusIDs {
+		if inWorkflow[statusID] {
+			return nil, custom_errors.NewInvalidWorkflowError(fmt.Sprintf("status %d is listed twice", statusID))
+		}
+		if _, err := c.statusRepo.GetByID(statusID); err != nil {
+			return nil, custom_errors.NewInvalidWorkflowError(fmt.Sprintf("status %d not found", statusID))
+		}
+		inWorkflow[statusID] = true
+		workflow.Statuses = append(workflow.Statuses, models.TaskWorkflowStatus{
+			StatusID: statusID,
+			Position: i + 1,
+		})
+	}
+
+	type pair struct{ from, to int }
+	seen := make(map[pair]bool, len(workflowDTO.Transitions))
+	for _, t := range workflowDTO.Transitions {
+		if !inWorkflow[t.FromStatusID] || !inWorkflow[t.ToStatusID] {
+			return nil, custom_errors.NewInvalidWorkflowError(
+				fmt.Sprintf("transition %d -> %d uses a status outside of the workflow", t.FromStatusID, t.ToStatusID))
+		}
+		if t.FromStatusID == t.ToStatusID {
+			return nil, custom_errors.NewInvalidWorkflowError(
+				fmt.Sprintf("transition %d -> %d doesn't change the status", t.FromStatusID, t.ToStatusID))
+		}
+		key := pair{t.FromStatusID, t.ToStatusID}
+		if seen[key] {
+			return nil, custom_errors.NewInvalidWorkflowError(
+				fmt.Sprintf("transition %d -> %d is listed twice", t.FromStatusID, t.ToStatusID))
+		}
+		seen[key] = true
+		workflow.Transitions = append(workflow.Transitions, models.TaskWorkflowTransition{
+			FromStatusID: t.FromStatusID,
+			ToStatusID:   t.ToStatusID,
+			AllowedRole:  t.AllowedRole,
+		})
+	}
+
+	return workflow, nil
+}
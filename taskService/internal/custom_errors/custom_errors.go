@@ -3,6 +3,7 @@ package custom_errors
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 var (
@@ -54,6 +55,73 @@ func NewGetChatHTTPError(chatID string, httpError string) *GetChatHTTPError {
 	return &GetChatHTTPError{ChatID: chatID, httpError: httpError}
 }
 
+// GetUserChatsHTTPError - не удалось получить чаты пользователя для проверки доступа к задачам
+type GetUserChatsHTTPError struct {
+	httpError string
+	UserID    string
+}
+
+func (e *GetUserChatsHTTPError) Error() string {
+	return fmt.Sprintf("can't get chats of user with id: %s, error: %s", e.UserID, e.httpError)
+}
+
+func NewGetUserChatsHTTPError(userID string, httpError string) *GetUserChatsHTTPError {
+	return &GetUserChatsHTTPError{UserID: userID, httpError: httpError}
+}
+
+type ChatMessageNotFoundError struct {
+	MessageID string
+}
+
+func (e *ChatMessageNotFoundError) Error() string {
+	return fmt.Sprintf("chat message with id %s not found", e.MessageID)
+}
+
+func NewChatMessageNotFoundError(messageID string) error {
+	return &ChatMessageNotFoundError{MessageID: messageID}
+}
+
+// ChatMessageAccessDeniedError - у пользователя нет права view_messages в чате сообщения
+type ChatMessageAccessDeniedError struct {
+	MessageID string
+	UserID    string
+}
+
+func (e *ChatMessageAccessDeniedError) Error() string {
+	return fmt.Sprintf("user %s has no permission to view chat message %s", e.UserID, e.MessageID)
+}
+
+func NewChatMessageAccessDeniedError(messageID, userID string) error {
+	return &ChatMessageAccessDeniedError{MessageID: messageID, UserID: userID}
+}
+
+// ChatMembershipRequiredError - создатель задачи не состоит в чате, к которому её привязывают
+type ChatMembershipRequiredError struct {
+	ChatID string
+	UserID string
+}
+
+func (e *ChatMembershipRequiredError) Error() string {
+	return fmt.Sprintf("user %s is not a member of chat %s", e.UserID, e.ChatID)
+}
+
+func NewChatMembershipRequiredError(chatID, userID string) error {
+	return &ChatMembershipRequiredError{ChatID: chatID, UserID: userID}
+}
+
+type GetChatMessageHTTPError struct {
+	httpError string
+	MessageID string
+}
+
+func (e *GetChatMessageHTTPError) Error() string {
+	return fmt.Sprintf("can't get chat message with id: %s, error: %s", e.MessageID, e.httpError)
+}
+
+func NewGetChatMessageHTTPError(messageID string, httpError string) *GetChatMessageHTTPError {
+	return &GetChatMessageHTTPError{MessageID: messageID, httpError: httpError}
+}
+
 // ============ Task Status ============
 
 type TaskStatusNotFoundError struct {
@@ -68,6 +136,46 @@ func NewTaskStatusNotFoundError(statusName string) error {
 	return &TaskStatusNotFoundError{StatusName: statusName}
 }
 
+// TaskStatusProtectedError - статус нельзя удалить: от него зависят workflow или создание задач
+type TaskStatusProtectedError struct {
+	StatusID int
+	Reason   string
+}
+
+func (e *TaskStatusProtectedError) Error() string {
+	return fmt.Sprintf("task status %d can't be deleted: %s", e.StatusID, e.Reason)
+}
+
+func NewTaskStatusProtectedError(statusID int, reason string) error {
+	return &TaskStatusProtectedError{StatusID: statusID, Reason: reason}
+}
+
+// TaskStatusReplacementRequiredError - в статусе есть задачи, а статус для их переноса не указан
+type TaskStatusReplacementRequiredError struct {
+	StatusID int
+	Tasks    int64
+}
+
+func (e *TaskStatusReplacementRequiredError) Error() string {
+	return fmt.Sprintf("task status %d is used by %d tasks, replacement status is required", e.StatusID, e.Tasks)
+}
+
+func NewTaskStatusReplacementRequiredError(statusID int, tasks int64) error {
+	return &TaskStatusReplacementRequiredError{StatusID: statusID, Tasks: tasks}
+}
+
+type InvalidStatusReplacementError struct {
+	Reason string
+}
+
+func (e *InvalidStatusReplacementError) Error() string {
+	return fmt.Sprintf("invalid replacement status: %s", e.Reason)
+}
+
+func NewInvalidStatusReplacementError(reason string) error {
+	return &InvalidStatusReplacementError{Reason: reason}
+}
+
 // ============ Task Status ============
 
 type TaskNotFoundError struct {
@@ -81,3 +189,495 @@ func (e *TaskNotFoundError) Error() string {
 func NewTaskNotFoundError(taskID int) error {
 	return &TaskNotFoundError{TaskID: taskID}
 }
+
+// ============ Task Schedule ============
+
+// InvalidTaskScheduleError - дата начала задачи позже её срока
+type InvalidTaskScheduleError struct {
+	StartAt time.Time
+	DueAt   time.Time
+}
+
+func (e *InvalidTaskScheduleError) Error() string {
+	return fmt.Sprintf("task start %s is after due date %s", e.StartAt.Format(time.RFC3339), e.DueAt.Format(time.RFC3339))
+}
+
+func NewInvalidTaskScheduleError(startAt, dueAt time.Time) error {
+	return &InvalidTaskScheduleError{StartAt: startAt, DueAt: dueAt}
+}
+
+// ============ Task Access ============
+
+type TaskAccessDeniedError struct {
+	TaskID int
+	UserID string
+}
+
+func (e *TaskAccessDeniedError) Error() string {
+	return fmt.Sprintf("user %s has no access to task %d", e.UserID, e.TaskID)
+}
+
+func NewTaskAccessDeniedError(taskID int, userID string) error {
+	return &TaskAccessDeniedError{TaskID: taskID, UserID: userID}
+}
+
+// ============ Task Hierarchy ============
+
+type ParentTaskNotFoundError struct {
+	ParentTaskID int
+}
+
+func (e *ParentTaskNotFoundError) Error() string {
+	return fmt.Sprintf("parent task with id %d not found", e.ParentTaskID)
+}
+
+func NewParentTaskNotFoundError(parentTaskID int) error {
+	return &ParentTaskNotFoundError{ParentTaskID: parentTaskID}
+}
+
+// TaskHierarchyCycleError - новая родительская задача является самой задачей или её подзадачей
+type TaskHierarchyCycleError struct {
+	TaskID       int
+	ParentTaskID int
+}
+
+func (e *TaskHierarchyCycleError) Error() string {
+	return fmt.Sprintf("task %d can't become a subtask of task %d: hierarchy would contain a cycle", e.TaskID, e.ParentTaskID)
+}
+
+func NewTaskHierarchyCycleError(taskID, parentTaskID int) error {
+	return &TaskHierarchyCycleError{TaskID: taskID, ParentTaskID: parentTaskID}
+}
+
+// ============ Task Dependency ============
+
+// TaskDependencyCycleError - задача уже прямо или транзитивно блокирует своего будущего блокировщика
+type TaskDependencyCycleError struct {
+	BlockerTaskID int
+	BlockedTaskID int
+}
+
+func (e *TaskDependencyCycleError) Error() string {
+	return fmt.Sprintf("task %d can't block task %d: dependencies would contain a cycle", e.BlockerTaskID, e.BlockedTaskID)
+}
+
+func NewTaskDependencyCycleError(blockerTaskID, blockedTaskID int) error {
+	return &TaskDependencyCycleError{BlockerTaskID: blockerTaskID, BlockedTaskID: blockedTaskID}
+}
+
+type TaskDependencyNotFoundError struct {
+	BlockerTaskID int
+	BlockedTaskID int
+}
+
+func (e *TaskDependencyNotFoundError) Error() string {
+	return fmt.Sprintf("task %d doesn't block task %d", e.BlockerTaskID, e.BlockedTaskID)
+}
+
+func NewTaskDependencyNotFoundError(blockerTaskID, blockedTaskID int) error {
+	return &TaskDependencyNotFoundError{BlockerTaskID: blockerTaskID, BlockedTaskID: blockedTaskID}
+}
+
+// TaskBlockedError - задачу нельзя закрыть, пока открыты блокирующие её задачи
+type TaskBlockedError struct {
+	TaskID     int
+	BlockerIDs []int
+}
+
+func (e *TaskBlockedError) Error() string {
+	return fmt.Sprintf("task %d is blocked by open tasks %v", e.TaskID, e.BlockerIDs)
+}
+
+func NewTaskBlockedError(taskID int, blockerIDs []int) error {
+	return &TaskBlockedError{TaskID: taskID, BlockerIDs: blockerIDs}
+}
+
+// ============ Task Comment ============
+
+type TaskCommentNotFoundError struct {
+	CommentID int
+}
+
+func (e *TaskCommentNotFoundError) Error() string {
+	return fmt.Sprintf("task comment with id %d not found", e.CommentID)
+}
+
+func NewTaskCommentNotFoundError(commentID int) error {
+	return &TaskCommentNotFoundError{CommentID: commentID}
+}
+
+// TaskCommentAccessDeniedError - комментарий может менять только его автор (удалять - ещё и manage_all_tasks)
+type TaskCommentAccessDeniedError struct {
+	CommentID int
+	UserID    string
+}
+
+func (e *TaskCommentAccessDeniedError) Error() string {
+	return fmt.Sprintf("user %s has no access to task comment %d", e.UserID, e.CommentID)
+}
+
+func NewTaskCommentAccessDeniedError(commentID int, userID string) error {
+	return &TaskCommentAccessDeniedError{CommentID: commentID, UserID: userID}
+}
+
+// ============ Task Workflow ============
+
+var ErrWorkflowAlreadyExists = errors.New("task workflow with this name already exists")
+
+type WorkflowNotFoundError struct {
+	WorkflowID int
+}
+
+func (e *WorkflowNotFoundError) Error() string {
+	return fmt.Sprintf("task workflow with id %d not found", e.WorkflowID)
+}
+
+func NewWorkflowNotFoundError(workflowID int) error {
+	return &WorkflowNotFoundError{WorkflowID: workflowID}
+}
+
+type InvalidWorkflowError struct {
+	Reason string
+}
+
+func (e *InvalidWorkflowError) Error() string {
+	return fmt.Sprintf("invalid task workflow: %s", e.Reason)
+}
+
+func NewInvalidWorkflowError(reason string) error {
+	return &InvalidWorkflowError{Reason: reason}
+}
+
+type IllegalStatusTransitionError struct {
+	TaskID       int
+	FromStatusID int
+	ToStatusID   int
+}
+
+func (e *IllegalStatusTransitionError) Error() string {
+	return fmt.Sprintf("task %d can't move from status %d to status %d", e.TaskID, e.FromStatusID, e.ToStatusID)
+}
+
+func NewIllegalStatusTransitionError(taskID, fromStatusID, toStatusID int) error {
+	return &IllegalStatusTransitionError{TaskID: taskID, FromStatusID: fromStatusID, ToStatusID: toStatusID}
+}
+
+type StatusTransitionForbiddenError struct {
+	TaskID      int
+	AllowedRole string
+}
+
+func (e *StatusTransitionForbiddenError) Error() string {
+	return fmt.Sprintf("status transition of task %d is allowed only for role: %s", e.TaskID, e.AllowedRole)
+}
+
+func NewStatusTransitionForbiddenError(taskID int, allowedRole string) error {
+	return &StatusTransitionForbiddenError{TaskID: taskID, AllowedRole: allowedRole}
+}
+
+// ============ Labels ============
+
+var ErrLabelAlreadyExists = errors.New("label with this name already exists")
+
+type LabelNotFoundError struct {
+	LabelID int
+}
+
+func (e *LabelNotFoundError) Error() string {
+	return fmt.Sprintf("label with id %d not found", e.LabelID)
+}
+
+func NewLabelNotFoundError(labelID int) error {
+	return &LabelNotFoundError{LabelID: labelID}
+}
+
+// TaskLabelNotFoundError - метка не назначена задаче
+type TaskLabelNotFoundError struct {
+	TaskID  int
+	LabelID int
+}
+
+func (e *TaskLabelNotFoundError) Error() string {
+	return fmt.Sprintf("label %d is not assigned to task %d", e.LabelID, e.TaskID)
+}
+
+func NewTaskLabelNotFoundError(taskID, labelID int) error {
+	return &TaskLabelNotFoundError{TaskID: taskID, LabelID: labelID}
+}
+
+// ============ Assignees and watchers ============
+
+// TaskAssigneeNotFoundError - пользователь не является соисполнителем задачи
+type TaskAssigneeNotFoundError struct {
+	TaskID int
+	UserID string
+}
+
+func (e *TaskAssigneeNotFoundError) Error() string {
+	return fmt.Sprintf("user %s is not an assignee of task %d", e.UserID, e.TaskID)
+}
+
+func NewTaskAssigneeNotFoundError(taskID int, userID string) error {
+	return &TaskAssigneeNotFoundError{TaskID: taskID, UserID: userID}
+}
+
+// TaskWatcherNotFoundError - пользователь не наблюдает за задачей
+type TaskWatcherNotFoundError struct {
+	TaskID int
+	UserID string
+}
+
+func (e *TaskWatcherNotFoundError) Error() string {
+	return fmt.Sprintf("user %s is not watching task %d", e.UserID, e.TaskID)
+}
+
+func NewTaskWatcherNotFoundError(taskID int, userID string) error {
+	return &TaskWatcherNotFoundError{TaskID: taskID, UserID: userID}
+}
+
+// ============ Board ============
+
+// InvalidTaskMoveError - карточку нельзя поставить в указанное место: соседние карточки не найдены,
+// стоят в другой колонке или в другом порядке, либо статус задачи успел измениться
+type InvalidTaskMoveError struct {
+	TaskID int
+	Reason string
+}
+
+func (e *InvalidTaskMoveError) Error() string {
+	return fmt.Sprintf("cannot move task %d: %s", e.TaskID, e.Reason)
+}
+
+func NewInvalidTaskMoveError(taskID int, reason string) error {
+	return &InvalidTaskMoveError{TaskID: taskID, Reason: reason}
+}
+
+// ============ Recurrence ============
+
+type TaskRecurrenceNotFoundError struct {
+	RecurrenceID int
+}
+
+func (e *TaskRecurrenceNotFoundError) Error() string {
+	return fmt.Sprintf("task recurrence with id %d not found", e.RecurrenceID)
+}
+
+func NewTaskRecurrenceNotFoundError(recurrenceID int) error {
+	return &TaskRecurrenceNotFoundError{RecurrenceID: recurrenceID}
+}
+
+// InvalidRecurrenceRuleError - правило повторения не разобрано или не даёт ни одного экземпляра
+type InvalidRecurrenceRuleError struct {
+	Rule   string
+	Reason string
+}
+
+func (e *InvalidRecurrenceRuleError) Error() string {
+	return fmt.Sprintf("invalid recurrence rule %q: %s", e.Rule, e.Reason)
+}
+
+func NewInvalidRecurrenceRuleError(rule, reason string) error {
+	return &InvalidRecurrenceRuleError{Rule: rule, Reason: reason}
+}
+
+type TaskRecurrenceAccessDeniedError struct {
+	RecurrenceID int
+	UserID       string
+}
+
+func (e *TaskRecurrenceAccessDeniedError) Error() string {
+	return fmt.Sprintf("user %s has no access to task recurrence %d", e.UserID, e.RecurrenceID)
+}
+
+func NewTaskRecurrenceAccessDeniedError(recurrenceID int, userID string) error {
+	return &TaskRecurrenceAccessDeniedError{RecurrenceID: recurrenceID, UserID: userID}
+}
+
+// ============ Time tracking ============
+
+type TaskTimeLogNotFoundError struct {
+	LogID int
+}
+
+func (e *TaskTimeLogNotFoundError) Error() string {
+	return fmt.Sprintf("time log with id %d not found", e.LogID)
+}
+
+func NewTaskTimeLogNotFoundError(logID int) error {
+	return &TaskTimeLogNotFoundError{LogID: logID}
+}
+
+type TaskTimeLogAccessDeniedError struct {
+	LogID  int
+	UserID string
+}
+
+func (e *TaskTimeLogAccessDeniedError) Error() string {
+	return fmt.Sprintf("user %s has no access to time log %d", e.UserID, e.LogID)
+}
+
+func NewTaskTimeLogAccessDeniedError(logID int, userID string) error {
+	return &TaskTimeLogAccessDeniedError{LogID: logID, UserID: userID}
+}
+
+// InvalidTaskTimeLogError - запись времени противоречит сама себе: например, заканчивается в будущем
+// или у запущенного таймера меняется длительность
+type InvalidTaskTimeLogError struct {
+	Reason string
+}
+
+func (e *InvalidTaskTimeLogError) Error() string {
+	return fmt.Sprintf("invalid time log: %s", e.Reason)
+}
+
+func NewInvalidTaskTimeLogError(reason string) error {
+	return &InvalidTaskTimeLogError{Reason: reason}
+}
+
+// TaskTimerAlreadyRunningError - у пользователя уже запущен таймер; RunningTaskID равен 0, если задачу
+// запущенного таймера определить не удалось
+type TaskTimerAlreadyRunningError struct {
+	UserID        string
+	RunningTaskID int
+}
+
+func (e *TaskTimerAlreadyRunningError) Error() string {
+	return fmt.Sprintf("user %s already has a running timer on task %d", e.UserID, e.RunningTaskID)
+}
+
+func NewTaskTimerAlreadyRunningError(userID string, runningTaskID int) error {
+	return &TaskTimerAlreadyRunningError{UserID: userID, RunningTaskID: runningTaskID}
+}
+
+type TaskTimerNotRunningError struct {
+	TaskID int
+	UserID string
+}
+
+func (e *TaskTimerNotRunningError) Error() string {
+	return fmt.Sprintf("user %s has no running timer on task %d", e.UserID, e.TaskID)
+}
+
+func NewTaskTimerNotRunningError(taskID int, userID string) error {
+	return &TaskTimerNotRunningError{TaskID: taskID, UserID: userID}
+}
+
+// ============ Bulk operations ============
+
+// InvalidTaskBulkError - у массовой операции нет параметра, нужного её действию
+type InvalidTaskBulkError struct {
+	Action string
+	Reason string
+}
+
+func (e *InvalidTaskBulkError) Error() string {
+	return fmt.Sprintf("invalid bulk %s: %s", e.Action, e.Reason)
+}
+
+func NewInvalidTaskBulkError(action, reason string) error {
+	return &InvalidTaskBulkError{Action: action, Reason: reason}
+}
+
+// TaskBulkChangeError - изменение задачи TaskID в массовой операции не сохранено; транзакция отменена
+type TaskBulkChangeError struct {
+	TaskID int
+	Err    error
+}
+
+func (e *TaskBulkChangeError) Error() string {
+	return fmt.Sprintf("bulk change of task %d failed: %v", e.TaskID, e.Err)
+}
+
+func (e *TaskBulkChangeError) Unwrap() error {
+	return e.Err
+}
+
+func NewTaskBulkChangeError(taskID int, err error) error {
+	return &TaskBulkChangeError{TaskID: taskID, Err: err}
+}
+
+// TaskStatusChangedError - статус задачи изменился после проверки перехода, и проверенный переход больше не применим
+type TaskStatusChangedError struct {
+	TaskID         int
+	ExpectedStatus int
+}
+
+func (e *TaskStatusChangedError) Error() string {
+	return fmt.Sprintf("status of task %d was changed concurrently: it is no longer %d", e.TaskID, e.ExpectedStatus)
+}
+
+func NewTaskStatusChangedError(taskID, expectedStatus int) error {
+	return &TaskStatusChangedError{TaskID: taskID, ExpectedStatus: expectedStatus}
+}
+
+// ============ Templates and checklists ============
+
+var ErrTaskTemplateAlreadyExists = errors.New("task template with this name already exists")
+
+type TaskTemplateNotFoundError struct {
+	TemplateID int
+}
+
+func (e *TaskTemplateNotFoundError) Error() string {
+	return fmt.Sprintf("task template with id %d not found", e.TemplateID)
+}
+
+func NewTaskTemplateNotFoundError(templateID int) error {
+	return &TaskTemplateNotFoundError{TemplateID: templateID}
+}
+
+type TaskTemplateAccessDeniedError struct {
+	TemplateID int
+	UserID     string
+}
+
+func (e *TaskTemplateAccessDeniedError) Error() string {
+	return fmt.Sprintf("user %s has no access to task template %d", e.UserID, e.TemplateID)
+}
+
+func NewTaskTemplateAccessDeniedError(templateID int, userID string) error {
+	return &TaskTemplateAccessDeniedError{TemplateID: templateID, UserID: userID}
+}
+
+// InvalidTaskTemplateError - по шаблону нельзя создать задачу: например, для подстановки нет значения
+type InvalidTaskTemplateError struct {
+	TemplateID int
+	Reason     string
+}
+
+func (e *InvalidTaskTemplateError) Error() string {
+	return fmt.Sprintf("invalid task template %d: %s", e.TemplateID, e.Reason)
+}
+
+func NewInvalidTaskTemplateError(templateID int, reason string) error {
+	return &InvalidTaskTemplateError{TemplateID: templateID, Reason: reason}
+}
+
+// ChecklistItemNotFoundError - пункта нет в чек-листе задачи
+type ChecklistItemNotFoundError struct {
+	TaskID int
+	ItemID int
+}
+
+func (e *ChecklistItemNotFoundError) Error() string {
+	return fmt.Sprintf("checklist item %d not found in task %d", e.ItemID, e.TaskID)
+}
+
+func NewChecklistItemNotFoundError(taskID, itemID int) error {
+	return &ChecklistItemNotFoundError{TaskID: taskID, ItemID: itemID}
+}
+
+// ============ Reports ============
+
+// InvalidTaskReportError - некорректные параметры отчёта: например, период пуст или слишком длинный
+type InvalidTaskReportError struct {
+	Reason string
+}
+
+func (e *InvalidTaskReportError) Error() string {
+	return fmt.Sprintf("invalid report: %s", e.Reason)
+}
+
+func NewInvalidTaskReportError(reason string) error {
+	return &InvalidTaskReportError{Reason: reason}
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "github.com/google/uuid"
+
+// PermissionManageAllTasks - глобальное право на изменение и удаление любых задач
+const PermissionManageAllTasks = "manage_all_tasks"
+
+// Actor - пользователь, от имени которого выполняется запрос (заголовки X-User-ID и X-User-Permissions)
+type Actor struct {
+	UserID      uuid.UUID
+	Permissions []string
+}
+
+// HasPermission проверяет наличие у пользователя глобального права
+func (a *Actor) HasPermission(permission string) bool {
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
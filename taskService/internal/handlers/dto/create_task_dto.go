@@ -1,12 +1,22 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
+// CreateTaskDTO - параметры новой задачи; создателем становится пользователь из X-User-ID
 type CreateTaskDTO struct {
-	Title       string    `json:"title" binding:"required"`
-	Description *string   `json:"description"`
-	CreatorID   uuid.UUID `json:"creator_id" binding:"required"`
-	ExecutorID  uuid.UUID `json:"executor_id" binding:"required"`
-	ChatID      uuid.UUID `json:"chat_id"`
-	FileIDs     []int     `json:"file_ids"`
+	Title        string     `json:"title" binding:"required"`
+	Description  *string    `json:"description"`
+	ExecutorID   uuid.UUID  `json:"executor_id" binding:"required"`
+	ChatID       uuid.UUID  `json:"chat_id"`
+	FileIDs      []int      `json:"file_ids"`
+	WorkflowID   *int       `json:"workflow_id"`
+	ParentTaskID *int       `json:"parent_task_id"`
+	Priority     string     `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	StartAt      *time.Time `json:"start_at"`
+	DueAt        *time.Time `json:"due_at"`
+	// EstimateMinutes - оценка трудозатрат в минутах
+	EstimateMinutes *int `json:"estimate_minutes" binding:"omitempty,min=0"`
 }
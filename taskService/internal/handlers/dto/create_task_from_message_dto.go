@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CreateTaskFromMessageDTO - задача из сообщения чата. Название (если не задано) и описание берутся
+// из текста сообщения, вложения сообщения становятся файлами задачи, задача привязывается к чату сообщения
+type CreateTaskFromMessageDTO struct {
+	MessageID  uuid.UUID  `json:"message_id" binding:"required"`
+	ExecutorID uuid.UUID  `json:"executor_id" binding:"required"`
+	Title      *string    `json:"title" binding:"omitempty,max=255"`
+	WorkflowID *int       `json:"workflow_id"`
+	Priority   string     `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	StartAt    *time.Time `json:"start_at"`
+	DueAt      *time.Time `json:"due_at"`
+}
@@ -3,8 +3,17 @@ package dto
 import "time"
 
 type TaskToList struct {
-	ID        int       `json:"id" gorm:"column:id"`
-	Title     string    `json:"title" gorm:"column:title"`
-	Status    string    `json:"status" gorm:"column:status"`
-	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+	ID       int        `json:"id" gorm:"column:id"`
+	Title    string     `json:"title" gorm:"column:title"`
+	Status   string     `json:"status" gorm:"column:status"`
+	Priority string     `json:"priority" gorm:"column:priority"`
+	StartAt  *time.Time `json:"startAt,omitempty" gorm:"column:start_at"`
+	DueAt    *time.Time `json:"dueAt,omitempty" gorm:"column:due_at"`
+	// EstimateMinutes - оценка трудозатрат в минутах, если задана
+	EstimateMinutes *int `json:"estimateMinutes,omitempty" gorm:"column:estimate_minutes"`
+	// ChecklistProgress - доля выполненных пунктов чек-листа в процентах; nil, если чек-листа нет
+	ChecklistProgress *int      `json:"checklistProgress,omitempty" gorm:"column:checklist_progress"`
+	CreatedAt         time.Time `json:"createdAt" gorm:"column:created_at"`
+	// UpdatedAt - время последнего изменения; для неизменявшихся задач совпадает с CreatedAt
+	UpdatedAt time.Time `json:"updatedAt" gorm:"column:updated_at"`
 }
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// SaveLabelDTO - создание или полная замена метки
+type SaveLabelDTO struct {
+	Name  string `json:"name" binding:"required,max=50"`
+	Color string `json:"color" binding:"required,hexcolor"`
+}
+
+// LabelResponse - метка с числом неудалённых задач, которым она назначена
+type LabelResponse struct {
+	ID         int       `json:"id" gorm:"column:id"`
+	Name       string    `json:"name" gorm:"column:name"`
+	Color      string    `json:"color" gorm:"column:color"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"column:created_at"`
+	UsageCount int64     `json:"usageCount" gorm:"column:usage_count"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+// SaveWorkflowDTO - описание workflow при создании и полной замене.
+// Порядок StatusIDs задаёт порядок статусов, первый статус становится стартовым для новых задач
+type SaveWorkflowDTO struct {
+	Name        string                  `json:"name" binding:"required,max=100"`
+	IsDefault   bool                    `json:"is_default"`
+	StatusIDs   []int                   `json:"status_ids" binding:"required,min=1"`
+	Transitions []WorkflowTransitionDTO `json:"transitions" binding:"dive"`
+}
+
+type WorkflowTransitionDTO struct {
+	FromStatusID int    `json:"from_status_id" binding:"required"`
+	ToStatusID   int    `json:"to_status_id" binding:"required"`
+	AllowedRole  string `json:"allowed_role" binding:"required,oneof=creator executor any"`
+}
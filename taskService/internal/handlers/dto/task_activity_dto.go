@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TaskActivity - событие из истории задачи вместе с названием задачи, используется в истории и ленте активности
+type TaskActivity struct {
+	ID        int64     `json:"id" gorm:"column:id"`
+	TaskID    int       `json:"taskID" gorm:"column:task_id"`
+	TaskTitle string    `json:"taskTitle" gorm:"column:task_title"`
+	ActorID   uuid.UUID `json:"actorID" gorm:"column:actor_id"`
+	EventType string    `json:"eventType" gorm:"column:event_type"`
+	Field     *string   `json:"field,omitempty" gorm:"column:field"`
+	OldValue  *string   `json:"oldValue,omitempty" gorm:"column:old_value"`
+	NewValue  *string   `json:"newValue,omitempty" gorm:"column:new_value"`
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+}
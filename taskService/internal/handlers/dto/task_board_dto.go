@@ -0,0 +1,45 @@
+package dto
+
+import "github.com/google/uuid"
+
+// TaskBoardQuery - задачи доски: задачи чата или задачи исполнителя; задан ровно один из ID
+type TaskBoardQuery struct {
+	ChatID     *uuid.UUID
+	ExecutorID *uuid.UUID
+	// Limit - максимум карточек в колонке
+	Limit int
+}
+
+// TaskBoard - канбан-доска: колонки в порядке статусов workflow по умолчанию, затем остальные статусы
+type TaskBoard struct {
+	Columns []TaskBoardColumn `json:"columns"`
+}
+
+// TaskBoardColumn - колонка доски; Total - число задач в колонке без учёта лимита
+type TaskBoardColumn struct {
+	StatusID int             `json:"statusId"`
+	Status   string          `json:"status"`
+	Total    int64           `json:"total"`
+	Tasks    []TaskBoardCard `json:"tasks"`
+}
+
+// TaskBoardCard - карточка задачи в порядке Rank
+type TaskBoardCard struct {
+	TaskToList
+	StatusID int    `json:"-" gorm:"column:status_id"`
+	Rank     string `json:"rank" gorm:"column:rank"`
+}
+
+// TaskBoardCount - число задач доски в статусе
+type TaskBoardCount struct {
+	StatusID int   `gorm:"column:status_id"`
+	Total    int64 `gorm:"column:total"`
+}
+
+// MoveTaskDTO - перенос карточки в колонку status_id между задачами after_task_id (выше) и before_task_id (ниже).
+// Без соседей карточка ставится в конец колонки
+type MoveTaskDTO struct {
+	StatusID     int  `json:"status_id" binding:"required,min=1"`
+	AfterTaskID  *int `json:"after_task_id" binding:"omitempty,min=1"`
+	BeforeTaskID *int `json:"before_task_id" binding:"omitempty,min=1"`
+}
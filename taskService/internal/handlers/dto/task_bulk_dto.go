@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"taskService/internal/models"
+)
+
+// Действия массовой операции над задачами
+const (
+	TaskBulkChangeStatus = "change_status"
+	TaskBulkReassign     = "reassign"
+	TaskBulkAddLabels    = "add_labels"
+	TaskBulkDelete       = "delete"
+)
+
+// TaskBulkDTO - одно действие над списком задач. Параметр действия обязателен: status_id для change_status,
+// executor_id для reassign (uuid.Nil снимает исполнителя), label_ids для add_labels.
+// При atomic=true изменения применяются в одной транзакции и только если проверку прошли все задачи
+type TaskBulkDTO struct {
+	TaskIDs    []int      `json:"task_ids" binding:"required,min=1,max=100,dive,min=1"`
+	Action     string     `json:"action" binding:"required,oneof=change_status reassign add_labels delete"`
+	StatusID   *int       `json:"status_id" binding:"omitempty,min=1"`
+	ExecutorID *uuid.UUID `json:"executor_id"`
+	LabelIDs   []int      `json:"label_ids" binding:"omitempty,max=20,dive,min=1"`
+	Atomic     bool       `json:"atomic"`
+}
+
+// TaskBulkResult - итог массовой операции: результат по каждой задаче в порядке запроса
+type TaskBulkResult struct {
+	Atomic    bool                 `json:"atomic"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Items     []TaskBulkItemResult `json:"items"`
+}
+
+// TaskBulkItemResult - результат для одной задачи; Error заполнен, если задача не изменена
+type TaskBulkItemResult struct {
+	TaskID  int    `json:"taskId"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// TaskBulkChange - проверенное изменение одной задачи вместе с событиями истории,
+// которые сохраняются в той же транзакции. FromStatusID - статус, для которого проверен переход:
+// если к моменту сохранения задача в другом статусе, изменение не сохраняется
+type TaskBulkChange struct {
+	TaskID       int
+	StatusID     *int
+	FromStatusID *int
+	ExecutorID   *uuid.UUID
+	LabelIDs     []int
+	Delete       bool
+	Events       []models.TaskEvent
+}
@@ -0,0 +1,14 @@
+package dto
+
+// AddChecklistItemDTO - новый пункт чек-листа; добавляется в конец списка
+type AddChecklistItemDTO struct {
+	Title string `json:"title" binding:"required,max=255"`
+}
+
+// UpdateChecklistItemDTO - частичное обновление пункта; nil-поля не изменяются.
+// Done отмечает пункт выполненным или снимает отметку, Position переставляет пункт
+type UpdateChecklistItemDTO struct {
+	Title    *string `json:"title" binding:"omitempty,min=1,max=255"`
+	Done     *bool   `json:"done"`
+	Position *int    `json:"position" binding:"omitempty,min=0"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+// CreateTaskCommentDTO - новый комментарий к задаче; упоминания @username извлекаются из текста
+type CreateTaskCommentDTO struct {
+	Body    string `json:"body" binding:"required,max=10000"`
+	FileIDs []int  `json:"file_ids"`
+}
+
+// UpdateTaskCommentDTO - частичное обновление комментария; nil-поля не изменяются
+type UpdateTaskCommentDTO struct {
+	Body          *string `json:"body" binding:"omitempty,min=1,max=10000"`
+	AddFileIDs    []int   `json:"add_file_ids"`
+	RemoveFileIDs []int   `json:"remove_file_ids"`
+}
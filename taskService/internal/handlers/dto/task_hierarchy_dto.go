@@ -0,0 +1,46 @@
+package dto
+
+import "github.com/google/uuid"
+
+// TaskTreeNode - задача в дереве подзадач. Closed - задача в статусе без исходящих переходов workflow,
+// Progress - процент выполнения с учётом подзадач. У скрытой от пользователя задачи (Hidden) остаются
+// только идентификаторы, Closed и Progress
+type TaskTreeNode struct {
+	ID           int             `json:"id" gorm:"column:id"`
+	Title        string          `json:"title" gorm:"column:title"`
+	ParentTaskID *int            `json:"parentTaskID,omitempty" gorm:"column:parent_task_id"`
+	Status       string          `json:"status" gorm:"column:status"`
+	Priority     string          `json:"priority" gorm:"column:priority"`
+	ExecutorID   uuid.UUID       `json:"executorID" gorm:"column:executor_id"`
+	Closed       bool            `json:"closed" gorm:"column:closed"`
+	Hidden       bool            `json:"hidden,omitempty" gorm:"-"`
+	Progress     int             `json:"progress" gorm:"-"`
+	Subtasks     []*TaskTreeNode `json:"subtasks" gorm:"-"`
+}
+
+// TaskGraphNode - задача в графе зависимостей; у скрытой от пользователя задачи (Hidden) нет названия и статуса
+type TaskGraphNode struct {
+	ID     int    `json:"id" gorm:"column:id"`
+	Title  string `json:"title" gorm:"column:title"`
+	Status string `json:"status" gorm:"column:status"`
+	Closed bool   `json:"closed" gorm:"column:closed"`
+	Hidden bool   `json:"hidden,omitempty" gorm:"-"`
+}
+
+// TaskDependencyEdge - ребро графа: BlockerTaskID блокирует BlockedTaskID
+type TaskDependencyEdge struct {
+	BlockerTaskID int `json:"blockerTaskID" gorm:"column:blocker_task_id"`
+	BlockedTaskID int `json:"blockedTaskID" gorm:"column:blocked_task_id"`
+}
+
+// TaskDependencyGraph - все задачи, которые прямо или транзитивно блокируют задачу или блокируются ею
+type TaskDependencyGraph struct {
+	TaskID int                  `json:"taskID"`
+	Nodes  []TaskGraphNode      `json:"nodes"`
+	Edges  []TaskDependencyEdge `json:"edges"`
+}
+
+// AddTaskDependencyDTO - задача BlockerTaskID начинает блокировать задачу из пути запроса
+type AddTaskDependencyDTO struct {
+	BlockerTaskID int `json:"blocker_task_id" binding:"required,min=1"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// Поля, по которым можно сортировать список задач
+const (
+	TaskSortByCreatedAt = "created_at"
+	TaskSortByDueAt     = "due_at"
+	TaskSortByStartAt   = "start_at"
+	TaskSortByPriority  = "priority"
+)
+
+// TaskListFilter - фильтры и сортировка списка задач пользователя; nil означает сортировку по дате создания
+type TaskListFilter struct {
+	// Priorities - допустимые приоритеты; пустой список не ограничивает выборку
+	Priorities []string
+	// LabelIDs - задача должна иметь хотя бы одну из меток; пустой список не ограничивает выборку
+	LabelIDs []int
+	// DueFrom, DueTo - границы срока включительно; задачи без срока в выборку не попадают
+	DueFrom *time.Time
+	DueTo   *time.Time
+	// Overdue - только задачи с истёкшим сроком
+	Overdue  bool
+	SortBy   string
+	SortDesc bool
+}
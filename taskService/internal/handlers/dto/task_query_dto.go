@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Поля, по которым дополнительно можно сортировать результаты поиска задач
+const (
+	TaskSortByUpdatedAt = "updated_at"
+	TaskSortByStatus    = "status"
+	TaskSortByTitle     = "title"
+)
+
+// TaskQuery - фильтры, полнотекстовый поиск, сортировка и курсор для поиска задач.
+// Пустые поля не ограничивают выборку
+type TaskQuery struct {
+	TaskListFilter
+
+	StatusIDs []int
+	CreatorID *uuid.UUID
+	// ExecutorID отбирает задачи, где пользователь исполнитель или соисполнитель
+	ExecutorID *uuid.UUID
+	WatcherID  *uuid.UUID
+	ChatID     *uuid.UUID
+	// StartFrom, StartTo, CreatedFrom, CreatedTo - границы дат включительно
+	StartFrom   *time.Time
+	StartTo     *time.Time
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// Search - полнотекстовый запрос по названию и описанию
+	Search string
+	Limit  int
+	// Cursor - позиция последней задачи предыдущей страницы; nil для первой страницы
+	Cursor *TaskCursor
+}
+
+// TaskCursor - позиция задачи в выбранной сортировке. Value - значение поля сортировки
+// в строковом виде; nil у задач без срока или даты начала
+type TaskCursor struct {
+	SortBy   string  `json:"s"`
+	SortDesc bool    `json:"d"`
+	Value    *string `json:"v,omitempty"`
+	ID       int     `json:"id"`
+}
+
+// TaskQueryResult - страница результатов поиска задач
+type TaskQueryResult struct {
+	Tasks []TaskToList `json:"tasks"`
+	// Total - число задач, подходящих под фильтры, без учёта курсора и лимита
+	Total int64 `json:"total"`
+	// NextCursor - курсор следующей страницы; отсутствует на последней странице
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// Encode кодирует курсор в непрозрачную строку для клиента
+func (c *TaskCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeTaskCursor разбирает курсор, выданный Encode
+func DecodeTaskCursor(raw string) (*TaskCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	var cursor TaskCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID <= 0 {
+		return nil, errors.New("invalid cursor")
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SaveTaskRecurrenceDTO - создание или полная замена серии повторяющихся задач.
+// Rule - правило RRULE (FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY, BYMONTHDAY, COUNT, UNTIL);
+// время суток starts_at задаёт время начала каждого экземпляра, due_after_minutes - его срок
+type SaveTaskRecurrenceDTO struct {
+	Title           string    `json:"title" binding:"required,max=255"`
+	Description     string    `json:"description"`
+	ExecutorID      uuid.UUID `json:"executor_id" binding:"required"`
+	ChatID          uuid.UUID `json:"chat_id"`
+	WorkflowID      *int      `json:"workflow_id"`
+	Priority        string    `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	Rule            string    `json:"rule" binding:"required,max=255"`
+	StartsAt        time.Time `json:"starts_at" binding:"required"`
+	DueAfterMinutes *int      `json:"due_after_minutes" binding:"omitempty,min=1"`
+}
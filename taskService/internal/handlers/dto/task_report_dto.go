@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Шаг периодов отчёта о пропускной способности
+const (
+	ReportIntervalDay  = "day"
+	ReportIntervalWeek = "week"
+)
+
+// TaskReportQuery - параметры отчётов по задачам за период [From, To). Отчёты считаются в UTC по
+// неудалённым задачам; ChatID ограничивает отчёт задачами чата
+type TaskReportQuery struct {
+	Interval string
+	From     time.Time
+	To       time.Time
+	ChatID   *uuid.UUID
+}
+
+// TaskThroughput - число задач, созданных и завершённых за период, начинающийся в Period.
+// Завершённой считается задача, которая сейчас в статусе без исходящих переходов своего workflow;
+// завершением считается переход в этот статус
+type TaskThroughput struct {
+	Period    time.Time `json:"period" gorm:"column:period"`
+	Created   int64     `json:"created" gorm:"column:created"`
+	Completed int64     `json:"completed" gorm:"column:completed"`
+}
+
+// TaskStatusDuration - сколько в среднем задачи находились в статусе; Stays - число завершённых
+// пребываний в статусе, по которым посчитано среднее
+type TaskStatusDuration struct {
+	StatusID   *int    `json:"statusID,omitempty" gorm:"column:status_id"`
+	Status     string  `json:"status" gorm:"column:status"`
+	Stays      int64   `json:"stays" gorm:"column:stays"`
+	AvgMinutes float64 `json:"avgMinutes" gorm:"column:avg_minutes"`
+}
+
+// TaskOverdueCount - число незакрытых задач исполнителя с истёкшим сроком и самый давний из сроков
+type TaskOverdueCount struct {
+	ExecutorID  uuid.UUID `json:"executorID" gorm:"column:executor_id"`
+	Overdue     int64     `json:"overdue" gorm:"column:overdue"`
+	OldestDueAt time.Time `json:"oldestDueAt" gorm:"column:oldest_due_at"`
+}
+
+// TaskCumulativeFlow - число задач в статусе на конец дня Day; статусы без задач не возвращаются
+type TaskCumulativeFlow struct {
+	Day    time.Time `json:"day" gorm:"column:day"`
+	Status string    `json:"status" gorm:"column:status"`
+	Tasks  int64     `json:"tasks" gorm:"column:tasks"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+// TaskStatusUsage - где используется статус задачи. Tasks и DeletedTasks - число задач и удалённых задач
+// в статусе; Protected - статус нельзя удалить, так как от него зависят workflow или создание задач
+type TaskStatusUsage struct {
+	StatusID     int                     `json:"statusID"`
+	Name         string                  `json:"name"`
+	Tasks        int64                   `json:"tasks"`
+	DeletedTasks int64                   `json:"deletedTasks"`
+	Workflows    []TaskStatusWorkflowRef `json:"workflows"`
+	Protected    bool                    `json:"protected"`
+}
+
+// TaskStatusWorkflowRef - workflow, в статусах или переходах которого есть статус
+type TaskStatusWorkflowRef struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
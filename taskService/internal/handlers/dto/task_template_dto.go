@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SaveTaskTemplateDTO - создание или полная замена шаблона задачи. TitlePattern может содержать подстановки
+// {name}, их значения передаются при создании задачи; {date} без значения заменяется текущей датой.
+// ExecutorID - исполнитель по умолчанию, nil - без исполнителя
+type SaveTaskTemplateDTO struct {
+	Name           string     `json:"name" binding:"required,max=100"`
+	TitlePattern   string     `json:"title_pattern" binding:"required,max=255"`
+	Description    string     `json:"description"`
+	ExecutorID     *uuid.UUID `json:"executor_id"`
+	Priority       string     `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	LabelIDs       []int      `json:"label_ids" binding:"max=20,dive,min=1"`
+	ChecklistItems []string   `json:"checklist_items" binding:"max=50,dive,required,max=255"`
+}
+
+// CreateTaskFromTemplateDTO - создание задачи по шаблону. Variables - значения подстановок названия;
+// ExecutorID заменяет исполнителя шаблона (uuid.Nil - без исполнителя), Priority - приоритет шаблона.
+// Остальные поля такие же, как при обычном создании задачи
+type CreateTaskFromTemplateDTO struct {
+	Variables       map[string]string `json:"variables"`
+	ExecutorID      *uuid.UUID        `json:"executor_id"`
+	ChatID          uuid.UUID         `json:"chat_id"`
+	FileIDs         []int             `json:"file_ids"`
+	WorkflowID      *int              `json:"workflow_id"`
+	ParentTaskID    *int              `json:"parent_task_id"`
+	Priority        string            `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	StartAt         *time.Time        `json:"start_at"`
+	DueAt           *time.Time        `json:"due_at"`
+	EstimateMinutes *int              `json:"estimate_minutes" binding:"omitempty,min=0"`
+}
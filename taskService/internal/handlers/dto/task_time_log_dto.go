@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Группировка отчёта о затраченном времени
+const (
+	TimeSpentByTask = "task"
+	TimeSpentByUser = "user"
+	TimeSpentByChat = "chat"
+)
+
+// CreateTaskTimeLogDTO - ручная запись затраченного времени: интервал от StartedAt длиной Minutes
+type CreateTaskTimeLogDTO struct {
+	StartedAt   time.Time `json:"started_at" binding:"required"`
+	Minutes     int       `json:"minutes" binding:"required,min=1,max=1440"`
+	Description string    `json:"description" binding:"max=1000"`
+}
+
+// UpdateTaskTimeLogDTO - частичное обновление записи; nil-поля не изменяются.
+// Длительность запущенного таймера изменить нельзя - его нужно сначала остановить
+type UpdateTaskTimeLogDTO struct {
+	StartedAt   *time.Time `json:"started_at"`
+	Minutes     *int       `json:"minutes" binding:"omitempty,min=1,max=1440"`
+	Description *string    `json:"description" binding:"omitempty,max=1000"`
+}
+
+// TimeSpentQuery - отчёт о затраченном времени по записям, начатым в [From, To).
+// Запущенные таймеры в отчёт не попадают
+type TimeSpentQuery struct {
+	GroupBy string
+	From    *time.Time
+	To      *time.Time
+	TaskID  *int
+	UserID  *uuid.UUID
+	ChatID  *uuid.UUID
+}
+
+// TimeSpent - строка отчёта; заполнены поля группы: задача с её оценкой, пользователь или чат
+type TimeSpent struct {
+	TaskID          *int       `json:"taskID,omitempty" gorm:"column:task_id"`
+	TaskTitle       string     `json:"taskTitle,omitempty" gorm:"column:task_title"`
+	EstimateMinutes *int       `json:"estimateMinutes,omitempty" gorm:"column:estimate_minutes"`
+	UserID          *uuid.UUID `json:"userID,omitempty" gorm:"column:user_id"`
+	ChatID          *uuid.UUID `json:"chatID,omitempty" gorm:"column:chat_id"`
+	Minutes         int64      `json:"minutes" gorm:"column:minutes"`
+	Entries         int64      `json:"entries" gorm:"column:entries"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/google/uuid"
+
+// TaskVisibility ограничивает выборку задачами, которые видит пользователь UserID: созданными им,
+// где он исполнитель или соисполнитель, и задачами чатов ChatIDs. nil - пользователь видит все задачи
+type TaskVisibility struct {
+	UserID  uuid.UUID
+	ChatIDs []uuid.UUID
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// UpdateTaskDTO - частичное обновление задачи; nil-поля не изменяются.
+// uuid.Nil в ExecutorID или ChatID снимает исполнителя или отвязывает задачу от чата,
+// ClearStartAt и ClearDueAt снимают дату начала и срок, ClearEstimate - оценку трудозатрат,
+// ClearParentTaskID делает задачу задачей верхнего уровня
+type UpdateTaskDTO struct {
+	Title             *string    `json:"title" binding:"omitempty,min=1,max=255"`
+	Description       *string    `json:"description"`
+	ExecutorID        *uuid.UUID `json:"executor_id"`
+	ChatID            *uuid.UUID `json:"chat_id"`
+	AddFileIDs        []int      `json:"add_file_ids"`
+	RemoveFileIDs     []int      `json:"remove_file_ids"`
+	Priority          *string    `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	StartAt           *time.Time `json:"start_at"`
+	DueAt             *time.Time `json:"due_at"`
+	ClearStartAt      bool       `json:"clear_start_at"`
+	ClearDueAt        bool       `json:"clear_due_at"`
+	EstimateMinutes   *int       `json:"estimate_minutes" binding:"omitempty,min=0"`
+	ClearEstimate     bool       `json:"clear_estimate"`
+	ParentTaskID      *int       `json:"parent_task_id"`
+	ClearParentTaskID bool       `json:"clear_parent_task_id"`
+}
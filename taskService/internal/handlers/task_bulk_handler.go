@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+)
+
+type TaskBulkHandler struct {
+	Controller controllers.TaskBulkControllerInterface
+}
+
+func NewTaskBulkHandler(controller controllers.TaskBulkControllerInterface) *TaskBulkHandler {
+	return &TaskBulkHandler{Controller: controller}
+}
+
+// ApplyBulk Массовая операция над задачами
+// @Summary Изменить несколько задач
+// @Description Меняет статус, исполнителя, добавляет метки или удаляет до 100 задач одним запросом. Каждая задача проверяется как при одиночном изменении, результат возвращается по каждой задаче. С atomic=true изменения применяются одной транзакцией и только если проверку прошли все задачи
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param bulk body dto.TaskBulkDTO true "Задачи, действие и его параметр"
+// @Success 200 {object} dto.TaskBulkResult "Результат по каждой задаче"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или параметр действия"
+// @Failure 404 {object} map[string]interface{} "Статус или метка не найдены"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису пользователей"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/bulk [post]
+func (h *TaskBulkHandler) ApplyBulk(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var bulkDTO dto.TaskBulkDTO
+	if err := c.ShouldBindJSON(&bulkDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	result, err := h.Controller.Apply(actor, &bulkDTO)
+	if err != nil {
+		var invalidErr *custom_errors.InvalidTaskBulkError
+		var statusErr *custom_errors.TaskStatusNotFoundError
+		var labelErr *custom_errors.LabelNotFoundError
+		var userErr *custom_errors.GetUserHTTPError
+
+		switch {
+		case errors.As(err, &invalidErr):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.As(err, &statusErr),
+			errors.As(err, &labelErr):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.As(err, &userErr):
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
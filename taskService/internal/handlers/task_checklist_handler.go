@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+)
+
+type TaskChecklistHandler struct {
+	Controller controllers.TaskChecklistControllerInterface
+}
+
+func NewTaskChecklistHandler(controller controllers.TaskChecklistControllerInterface) *TaskChecklistHandler {
+	return &TaskChecklistHandler{Controller: controller}
+}
+
+// GetByTaskID Получение чек-листа задачи
+// @Summary Получить чек-лист задачи
+// @Description Возвращает пункты чек-листа задачи по порядку
+// @Tags task-checklists
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 200 {array} models.TaskChecklistItem "Пункты чек-листа"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/checklist [get]
+func (h *TaskChecklistHandler) GetByTaskID(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items, err := h.Controller.GetByTaskID(taskID, actor)
+	if err != nil {
+		respondChecklistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// AddItem Добавление пункта чек-листа
+// @Summary Добавить пункт чек-листа
+// @Description Добавляет пункт в конец чек-листа задачи. Доступно создателю, исполнителю задачи и пользователям с правом manage_all_tasks
+// @Tags task-checklists
+// @Accept json
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param item body dto.AddChecklistItemDTO true "Пункт чек-листа"
+// @Success 201 {object} models.TaskChecklistItem "Пункт добавлен"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/checklist [post]
+func (h *TaskChecklistHandler) AddItem(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	var itemDTO dto.AddChecklistItemDTO
+	if err := c.ShouldBindJSON(&itemDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	item, err := h.Controller.AddItem(taskID, actor, &itemDTO)
+	if err != nil {
+		respondChecklistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// UpdateItem Изменение пункта чек-листа
+// @Summary Изменить пункт чек-листа
+// @Description Переименовывает пункт, отмечает его выполненным (done=true) или снимает отметку и переставляет на позицию position. Переданные поля заменяются, остальные не меняются. Права те же, что и на добавление пункта
+// @Tags task-checklists
+// @Accept json
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param item_id path int true "ID пункта"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param item body dto.UpdateChecklistItemDTO true "Изменяемые поля пункта"
+// @Success 200 {object} models.TaskChecklistItem "Пункт изменен"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача или пункт не найдены"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/checklist/{item_id} [patch]
+func (h *TaskChecklistHandler) UpdateItem(c *gin.Context) {
+	actor, taskID, itemID, ok := parseChecklistItemRequest(c)
+	if !ok {
+		return
+	}
+
+	var updateDTO dto.UpdateChecklistItemDTO
+	if err := c.ShouldBindJSON(&updateDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	item, err := h.Controller.UpdateItem(taskID, itemID, actor, &updateDTO)
+	if err != nil {
+		respondChecklistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// RemoveItem Удаление пункта чек-листа
+// @Summary Удалить пункт чек-листа
+// @Description Удаляет пункт из чек-листа задачи. Права те же, что и на добавление пункта
+// @Tags task-checklists
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param item_id path int true "ID пункта"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Пункт удален"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи, пункта или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача или пункт не найдены"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/checklist/{item_id} [delete]
+func (h *TaskChecklistHandler) RemoveItem(c *gin.Context) {
+	actor, taskID, itemID, ok := parseChecklistItemRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.Controller.RemoveItem(taskID, itemID, actor); err != nil {
+		respondChecklistError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseChecklistItemRequest разбирает actor, ID задачи и пункта; при ошибке сам отвечает клиенту
+func parseChecklistItemRequest(c *gin.Context) (*dto.Actor, int, int, bool) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, 0, 0, false
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return nil, 0, 0, false
+	}
+
+	itemID, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid checklist item ID"})
+		return nil, 0, 0, false
+	}
+	return actor, taskID, itemID, true
+}
+
+func respondChecklistError(c *gin.Context, err error) {
+	var taskErr *custom_errors.TaskNotFoundError
+	var itemErr *custom_errors.ChecklistItemNotFoundError
+	var accessErr *custom_errors.TaskAccessDeniedError
+	var chatsErr *custom_errors.GetUserChatsHTTPError
+
+	switch {
+	case errors.As(err, &taskErr),
+		errors.As(err, &itemErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &accessErr):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &chatsErr):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+)
+
+type TaskCommentHandler struct {
+	Controller controllers.TaskCommentControllerInterface
+}
+
+func NewTaskCommentHandler(controller controllers.TaskCommentControllerInterface) *TaskCommentHandler {
+	return &TaskCommentHandler{Controller: controller}
+}
+
+// Create Добавление комментария к задаче
+// @Summary Добавить комментарий к задаче
+// @Description Добавляет комментарий с вложениями. Упомянутые через @username пользователи получают уведомление об упоминании, создатель, исполнитель и прежние участники обсуждения - о новом комментарии
+// @Tags task-comments
+// @Accept json
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param comment body dto.CreateTaskCommentDTO true "Текст комментария и вложения"
+// @Success 201 {object} models.TaskComment "Комментарий добавлен"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/comments [post]
+func (h *TaskCommentHandler) Create(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	var commentDTO dto.CreateTaskCommentDTO
+	if err := c.ShouldBindJSON(&commentDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	comment, err := h.Controller.Create(taskID, actor, &commentDTO)
+	if err != nil {
+		respondTaskCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// Update Редактирование комментария
+// @Summary Редактировать комментарий
+// @Description Изменяет текст и вложения комментария. Доступно только автору; уведомление получают только впервые упомянутые пользователи
+// @Tags task-comments
+// @Accept json
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param comment_id path int true "ID комментария"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param comment body dto.UpdateTaskCommentDTO true "Изменяемые поля комментария"
+// @Success 200 {object} models.TaskComment "Комментарий обновлён"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 403 {object} map[string]interface{} "Комментарий может редактировать только автор"
+// @Failure 404 {object} map[string]interface{} "Задача или комментарий не найдены"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/comments/{comment_id} [patch]
+func (h *TaskCommentHandler) Update(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, commentID, ok := parseCommentPath(c)
+	if !ok {
+		return
+	}
+
+	var updateDTO dto.UpdateTaskCommentDTO
+	if err := c.ShouldBindJSON(&updateDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	comment, err := h.Controller.Update(taskID, commentID, actor, &updateDTO)
+	if err != nil {
+		respondTaskCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// Delete Удаление комментария
+// @Summary Удалить комментарий
+// @Description Мягко удаляет комментарий. Доступно автору и пользователям с правом manage_all_tasks
+// @Tags task-comments
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param comment_id path int true "ID комментария"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Комментарий удалён"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи, комментария или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав на удаление комментария"
+// @Failure 404 {object} map[string]interface{} "Комментарий не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/comments/{comment_id} [delete]
+func (h *TaskCommentHandler) Delete(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, commentID, ok := parseCommentPath(c)
+	if !ok {
+		return
+	}
+
+	if err := h.Controller.Delete(taskID, commentID, actor); err != nil {
+		respondTaskCommentError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetByTaskID Получение комментариев задачи
+// @Summary Получить комментарии задачи
+// @Description Возвращает комментарии задачи с вложениями и упоминаниями, старые первыми
+// @Tags task-comments
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param limit query int false "Количество комментариев на странице" default(20)
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Success 200 {array} models.TaskComment "Комментарии задачи"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи, пользователя или параметры пагинации"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/comments [get]
+func (h *TaskCommentHandler) GetByTaskID(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	limit, offset, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comments, err := h.Controller.GetByTaskID(taskID, actor, limit, offset)
+	if err != nil {
+		respondTaskCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// parseCommentPath разбирает ID задачи и комментария из пути; при ошибке сам отвечает клиенту
+func parseCommentPath(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return 0, 0, false
+	}
+
+	commentID, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment ID"})
+		return 0, 0, false
+	}
+	return taskID, commentID, true
+}
+
+func respondTaskCommentError(c *gin.Context, err error) {
+	var taskErr *custom_errors.TaskNotFoundError
+	var commentErr *custom_errors.TaskCommentNotFoundError
+	var accessErr *custom_errors.TaskCommentAccessDeniedError
+	var userErr *custom_errors.GetUserHTTPError
+	var fileErr *custom_errors.GetFileHTTPError
+	var chatsErr *custom_errors.GetUserChatsHTTPError
+
+	switch {
+	case errors.As(err, &taskErr),
+		errors.As(err, &commentErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &accessErr):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &userErr),
+		errors.As(err, &fileErr),
+		errors.As(err, &chatsErr):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
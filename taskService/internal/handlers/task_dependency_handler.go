@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+)
+
+type TaskDependencyHandler struct {
+	Controller controllers.TaskDependencyControllerInterface
+}
+
+func NewTaskDependencyHandler(controller controllers.TaskDependencyControllerInterface) *TaskDependencyHandler {
+	return &TaskDependencyHandler{Controller: controller}
+}
+
+// GetTree Получение дерева подзадач
+// @Summary Получить дерево подзадач
+// @Description Возвращает задачу со всеми подзадачами любой вложенности. Прогресс закрытой задачи - 100%, открытой - средний прогресс её подзадач
+// @Tags task-dependencies
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 200 {object} dto.TaskTreeNode "Дерево подзадач"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/tree [get]
+func (h *TaskDependencyHandler) GetTree(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tree, err := h.Controller.GetTree(taskID, actor)
+	if err != nil {
+		respondTaskDependencyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// GetDependencyGraph Получение графа зависимостей
+// @Summary Получить граф зависимостей задачи
+// @Description Возвращает задачи, которые прямо или транзитивно блокируют задачу или блокируются ею, и связи между ними
+// @Tags task-dependencies
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 200 {object} dto.TaskDependencyGraph "Граф зависимостей"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/dependencies [get]
+func (h *TaskDependencyHandler) GetDependencyGraph(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	graph, err := h.Controller.GetDependencyGraph(taskID, actor)
+	if err != nil {
+		respondTaskDependencyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, graph)
+}
+
+// AddDependency Добавление блокирующей задачи
+// @Summary Добавить блокирующую задачу
+// @Description Задача blocker_task_id начинает блокировать задачу из пути: пока блокирующая задача открыта, заблокированную нельзя перевести в закрывающий статус. Доступно создателю, исполнителю заблокированной задачи и пользователям с правом manage_all_tasks
+// @Tags task-dependencies
+// @Accept json
+// @Produce json
+// @Param task_id path int true "ID заблокированной задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param dependency body dto.AddTaskDependencyDTO true "Блокирующая задача"
+// @Success 204 "Зависимость добавлена"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 409 {object} map[string]interface{} "Зависимость образует цикл"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/dependencies [post]
+func (h *TaskDependencyHandler) AddDependency(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	var dependencyDTO dto.AddTaskDependencyDTO
+	if err := c.ShouldBindJSON(&dependencyDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.Controller.AddDependency(taskID, actor, &dependencyDTO); err != nil {
+		respondTaskDependencyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveDependency Удаление блокирующей задачи
+// @Summary Удалить блокирующую задачу
+// @Description Задача blocker_task_id перестаёт блокировать задачу из пути. Права те же, что и на добавление
+// @Tags task-dependencies
+// @Produce json
+// @Param task_id path int true "ID заблокированной задачи"
+// @Param blocker_task_id path int true "ID блокирующей задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Зависимость удалена"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача или зависимость не найдены"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/dependencies/{blocker_task_id} [delete]
+func (h *TaskDependencyHandler) RemoveDependency(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	blockerTaskID, err := strconv.Atoi(c.Param("blocker_task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid blocker task ID"})
+		return
+	}
+
+	if err := h.Controller.RemoveDependency(taskID, blockerTaskID, actor); err != nil {
+		respondTaskDependencyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondTaskDependencyError(c *gin.Context, err error) {
+	var taskErr *custom_errors.TaskNotFoundError
+	var dependencyErr *custom_errors.TaskDependencyNotFoundError
+	var accessErr *custom_errors.TaskAccessDeniedError
+	var cycleErr *custom_errors.TaskDependencyCycleError
+	var chatsErr *custom_errors.GetUserChatsHTTPError
+
+	switch {
+	case errors.As(err, &taskErr),
+		errors.As(err, &dependencyErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &accessErr):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &cycleErr):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.As(err, &chatsErr):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
@@ -3,11 +3,16 @@ package handlers
 import (
 	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"taskService/internal/controllers"
 	"taskService/internal/custom_errors"
 	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+	"time"
 )
 
 type TaskHandler struct {
@@ -24,51 +29,124 @@ func NewTaskHandler(controller controllers.TaskControllerInterface) *TaskHandler
 // @Tags tasks
 // @Accept json
 // @Produce json
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос; он становится создателем задачи"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
 // @Param task body dto.CreateTaskDTO true "Данные для создания задачи"
 // @Success 200 {object} models.Task "Задача успешно создана"
-// @Failure 400 {object} map[string]interface{} "Некорректный запрос или статус не найден"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос, статус, workflow или родительская задача не найдены, дата начала позже срока"
+// @Failure 403 {object} map[string]interface{} "Пользователь не состоит в чате задачи"
 // @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /tasks [post]
 func (h *TaskHandler) CreateTask(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	var taskDTO dto.CreateTaskDTO
 	if err := c.ShouldBindJSON(&taskDTO); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 		return
 	}
 
-	task, err := h.TaskController.Create(&taskDTO)
+	task, err := h.TaskController.Create(actor, &taskDTO)
 	if err != nil {
-		var userErr *custom_errors.GetUserHTTPError
-		var chatErr *custom_errors.GetChatHTTPError
-		var fileErr *custom_errors.GetFileHTTPError
-		var statusErr *custom_errors.TaskStatusNotFoundError
-
-		switch {
-		case errors.As(err, &userErr),
-			errors.As(err, &chatErr),
-			errors.As(err, &fileErr):
-			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
-		case errors.As(err, &statusErr):
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
-		}
+		respondCreateTaskError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, task)
 }
 
+// CreateTaskFromMessage Создание задачи из сообщения чата
+// @Summary Создать задачу из сообщения чата
+// @Description Создает задачу по сообщению чата: название (если не передано) берется из первой строки сообщения, описание - из всего текста, вложения сообщения прикрепляются к задаче, задача привязывается к чату. Пользователь должен иметь право view_messages в чате. После создания в чат публикуется системное сообщение со ссылкой на задачу
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param task body dto.CreateTaskFromMessageDTO true "Сообщение, исполнитель и параметры задачи"
+// @Success 201 {object} models.Task "Задача успешно создана"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос, статус или workflow не найдены, дата начала позже срока"
+// @Failure 403 {object} map[string]interface{} "Нет права view_messages в чате сообщения"
+// @Failure 404 {object} map[string]interface{} "Сообщение не найдено"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/from-message [post]
+func (h *TaskHandler) CreateTaskFromMessage(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var fromMessageDTO dto.CreateTaskFromMessageDTO
+	if err := c.ShouldBindJSON(&fromMessageDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	task, err := h.TaskController.CreateFromMessage(actor, &fromMessageDTO)
+	if err != nil {
+		respondCreateTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
+// respondCreateTaskError отвечает на ошибку создания задачи, в том числе из сообщения чата
+func respondCreateTaskError(c *gin.Context, err error) {
+	var messageNotFoundErr *custom_errors.ChatMessageNotFoundError
+	var messageAccessErr *custom_errors.ChatMessageAccessDeniedError
+	var membershipErr *custom_errors.ChatMembershipRequiredError
+	var userErr *custom_errors.GetUserHTTPError
+	var chatErr *custom_errors.GetChatHTTPError
+	var messageErr *custom_errors.GetChatMessageHTTPError
+	var fileErr *custom_errors.GetFileHTTPError
+	var statusErr *custom_errors.TaskStatusNotFoundError
+	var workflowErr *custom_errors.WorkflowNotFoundError
+	var invalidWorkflowErr *custom_errors.InvalidWorkflowError
+	var scheduleErr *custom_errors.InvalidTaskScheduleError
+	var parentErr *custom_errors.ParentTaskNotFoundError
+
+	switch {
+	case errors.As(err, &messageNotFoundErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &messageAccessErr),
+		errors.As(err, &membershipErr):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &userErr),
+		errors.As(err, &chatErr),
+		errors.As(err, &messageErr),
+		errors.As(err, &fileErr):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	case errors.As(err, &statusErr),
+		errors.As(err, &workflowErr),
+		errors.As(err, &invalidWorkflowErr),
+		errors.As(err, &scheduleErr),
+		errors.As(err, &parentErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
 // UpdateTaskStatus Обновление статуса задачи
 // @Summary Обновить статус задачи
-// @Description Изменяет статус задачи на указанный
+// @Description Изменяет статус задачи на указанный. Если у задачи есть workflow, переход должен быть в нём разрешён для роли пользователя. В закрывающий статус (без исходящих переходов) задачу можно перевести, только когда закрыты все блокирующие её задачи
 // @Tags tasks
 // @Produce json
 // @Param task_id path int true "ID задачи"
 // @Param status_id path int true "ID статуса"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
 // @Success 200 "Статус задачи успешно обновлен"
 // @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или статуса, или статус/задача не найдены"
+// @Failure 403 {object} map[string]interface{} "Переход не разрешён для роли пользователя"
+// @Failure 409 {object} map[string]interface{} "Переход не предусмотрен workflow или задачу блокируют незакрытые задачи"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /tasks/{task_id}/status/{status_id} [patch]
 func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
@@ -84,18 +162,33 @@ func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
 		return
 	}
 
-	err = h.TaskController.UpdateStatus(taskID, statusID)
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.TaskController.UpdateStatus(taskID, statusID, actor)
 	if err != nil {
 		var statusErr *custom_errors.TaskStatusNotFoundError
 		var taskErr *custom_errors.TaskNotFoundError
-		if errors.As(err, &statusErr) {
+		var accessErr *custom_errors.TaskAccessDeniedError
+		var forbiddenErr *custom_errors.StatusTransitionForbiddenError
+		var transitionErr *custom_errors.IllegalStatusTransitionError
+		var blockedErr *custom_errors.TaskBlockedError
+
+		switch {
+		case errors.As(err, &statusErr),
+			errors.As(err, &taskErr):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		} else {
-			if errors.As(err, &taskErr) {
-				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
-			}
+		case errors.As(err, &accessErr),
+			errors.As(err, &forbiddenErr):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.As(err, &transitionErr),
+			errors.As(err, &blockedErr):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		}
 		return
 	}
@@ -105,13 +198,16 @@ func (h *TaskHandler) UpdateTaskStatus(c *gin.Context) {
 
 // GetTaskByID Получение задачи по ID
 // @Summary Получить задачу по ID
-// @Description Возвращает информацию о задаче и прикрепленных файлах по ID
+// @Description Возвращает информацию о задаче и прикрепленных файлах по ID. Задачу видят создатель, исполнитель, соисполнители, участники чата задачи и пользователи с правом manage_all_tasks; для остальных она не найдена
 // @Tags tasks
 // @Produce json
 // @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
 // @Success 200 {object} dto.TaskResponse "Информация о задаче"
-// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи"
-// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису файлов"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису файлов или чатов"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /tasks/{task_id} [get]
 func (h *TaskHandler) GetTaskByID(c *gin.Context) {
@@ -121,14 +217,15 @@ func (h *TaskHandler) GetTaskByID(c *gin.Context) {
 		return
 	}
 
-	task, err := h.TaskController.GetByID(taskID)
+	actor, err := getActor(c)
 	if err != nil {
-		var httpClientError *custom_errors.GetFileHTTPError
-		if errors.As(err, &httpClientError) {
-			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
-		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
-		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.TaskController.GetByID(taskID, actor)
+	if err != nil {
+		respondTaskReadError(c, err)
 		return
 	}
 
@@ -137,14 +234,24 @@ func (h *TaskHandler) GetTaskByID(c *gin.Context) {
 
 // GetUserTasks Получение списка задач пользователя
 // @Summary Получить список задач пользователя
-// @Description Возвращает список задач указанного пользователя с пагинацией
+// @Description Возвращает задачи, где пользователь исполнитель, соисполнитель или наблюдатель, с пагинацией, фильтрами по приоритету и сроку и сортировкой. В список попадают только задачи, которые видит пользователь из X-User-ID
 // @Tags tasks
 // @Produce json
 // @Param user_id path string true "UUID пользователя"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
 // @Param limit query int false "Количество задач на странице" default(20)
 // @Param offset query int false "Смещение для пагинации" default(0)
+// @Param priority query string false "Приоритеты через запятую: low, normal, high, urgent"
+// @Param label query string false "ID меток через запятую; задача должна иметь хотя бы одну из них"
+// @Param due_from query string false "Срок не раньше (RFC3339)"
+// @Param due_to query string false "Срок не позже (RFC3339)"
+// @Param overdue query bool false "Только незакрытые задачи с истёкшим сроком"
+// @Param sort_by query string false "Поле сортировки" Enums(created_at, due_at, start_at, priority) default(created_at)
+// @Param order query string false "Направление сортировки" Enums(asc, desc) default(desc)
 // @Success 200 {array} dto.TaskToList "Список задач пользователя"
-// @Failure 400 {object} map[string]interface{} "Некорректный UUID пользователя или параметры пагинации"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID пользователя, параметры пагинации или фильтры"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /users/{user_id}/tasks [get]
 func (h *TaskHandler) GetUserTasks(c *gin.Context) {
@@ -154,23 +261,732 @@ func (h *TaskHandler) GetUserTasks(c *gin.Context) {
 		return
 	}
 
+	limit, offset, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	filter, ok := parseTaskListFilter(c)
+	if !ok {
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tasks, err := h.TaskController.GetUserTasks(userID, actor, filter, limit, offset)
+	if err != nil {
+		respondTaskReadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// SearchTasks Поиск задач
+// @Summary Поиск задач
+// @Description Ищет задачи по набору статусов, создателю, исполнителю, наблюдателю, чату, приоритету, диапазонам дат и полнотекстовому запросу по названию и описанию. Возвращает общее число найденных задач и курсор следующей страницы; курсор действителен только для той же сортировки. Ищутся только задачи, которые видит пользователь из X-User-ID
+// @Tags tasks
+// @Produce json
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param q query string false "Полнотекстовый запрос по названию и описанию"
+// @Param status query string false "ID статусов через запятую"
+// @Param creator_id query string false "UUID создателя"
+// @Param executor_id query string false "UUID исполнителя или соисполнителя"
+// @Param watcher_id query string false "UUID наблюдателя"
+// @Param chat_id query string false "UUID чата"
+// @Param priority query string false "Приоритеты через запятую: low, normal, high, urgent"
+// @Param label query string false "ID меток через запятую; задача должна иметь хотя бы одну из них"
+// @Param due_from query string false "Срок не раньше (RFC3339)"
+// @Param due_to query string false "Срок не позже (RFC3339)"
+// @Param start_from query string false "Дата начала не раньше (RFC3339)"
+// @Param start_to query string false "Дата начала не позже (RFC3339)"
+// @Param created_from query string false "Создана не раньше (RFC3339)"
+// @Param created_to query string false "Создана не позже (RFC3339)"
+// @Param overdue query bool false "Только незакрытые задачи с истёкшим сроком"
+// @Param sort_by query string false "Поле сортировки" Enums(created_at, updated_at, due_at, start_at, priority, status, title) default(created_at)
+// @Param order query string false "Направление сортировки" Enums(asc, desc) default(desc)
+// @Param limit query int false "Количество задач на странице" default(20)
+// @Param cursor query string false "Курсор следующей страницы из nextCursor"
+// @Success 200 {object} dto.TaskQueryResult "Страница найденных задач"
+// @Failure 400 {object} map[string]interface{} "Некорректные фильтры, сортировка, курсор или ID пользователя"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/search [get]
+func (h *TaskHandler) SearchTasks(c *gin.Context) {
+	query, ok := parseTaskQuery(c)
+	if !ok {
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.TaskController.QueryTasks(actor, query)
+	if err != nil {
+		respondTaskReadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetBoard Канбан-доска задач
+// @Summary Получить доску задач
+// @Description Возвращает задачи чата или исполнителя, сгруппированные по статусам. Колонки идут в порядке статусов workflow по умолчанию, остальные статусы - после них; карточки в колонке упорядочены по rank. Нужно указать ровно один из chat_id и executor_id. На доске только задачи, которые видит пользователь из X-User-ID
+// @Tags tasks
+// @Produce json
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param chat_id query string false "UUID чата"
+// @Param executor_id query string false "UUID исполнителя или соисполнителя"
+// @Param limit query int false "Максимум карточек в колонке (1-200)" default(50)
+// @Success 200 {object} dto.TaskBoard "Доска задач"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/board [get]
+func (h *TaskHandler) GetBoard(c *gin.Context) {
+	query := &dto.TaskBoardQuery{}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > 200 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+	query.Limit = limit
+
+	for _, param := range []struct {
+		name   string
+		target **uuid.UUID
+	}{{"chat_id", &query.ChatID}, {"executor_id", &query.ExecutorID}} {
+		raw := c.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param.name})
+			return
+		}
+		*param.target = &parsed
+	}
+	if (query.ChatID == nil) == (query.ExecutorID == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "exactly one of chat_id and executor_id is required"})
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	board, err := h.TaskController.GetBoard(actor, query)
+	if err != nil {
+		var chatsErr *custom_errors.GetUserChatsHTTPError
+		if errors.As(err, &chatsErr) {
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not get task board"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+// MoveTask Перенос карточки на доске
+// @Summary Перенести карточку задачи
+// @Description Ставит задачу в колонку status_id между карточками after_task_id (выше) и before_task_id (ниже); без after_task_id - в начало колонки, без before_task_id - в конец, без обоих - в конец колонки. Статус и позиция меняются одним обновлением. Смена статуса подчиняется правилам workflow, как при обновлении статуса; перестановка внутри колонки доступна создателю, исполнителю и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param move body dto.MoveTaskDTO true "Новая колонка и соседние карточки"
+// @Success 204 "Карточка перенесена"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или статус не найден"
+// @Failure 403 {object} map[string]interface{} "Нет прав на перенос или переход не разрешён для роли пользователя"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 409 {object} map[string]interface{} "Переход не предусмотрен workflow, задачу блокируют незакрытые задачи или доска устарела"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/move [post]
+func (h *TaskHandler) MoveTask(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	var moveDTO dto.MoveTaskDTO
+	if err := c.ShouldBindJSON(&moveDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.TaskController.MoveTask(taskID, actor, &moveDTO); err != nil {
+		var taskErr *custom_errors.TaskNotFoundError
+		var statusErr *custom_errors.TaskStatusNotFoundError
+		var accessErr *custom_errors.TaskAccessDeniedError
+		var forbiddenErr *custom_errors.StatusTransitionForbiddenError
+		var transitionErr *custom_errors.IllegalStatusTransitionError
+		var blockedErr *custom_errors.TaskBlockedError
+		var moveErr *custom_errors.InvalidTaskMoveError
+
+		switch {
+		case errors.As(err, &taskErr):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.As(err, &statusErr):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.As(err, &accessErr),
+			errors.As(err, &forbiddenErr):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.As(err, &transitionErr),
+			errors.As(err, &blockedErr),
+			errors.As(err, &moveErr):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateTask Редактирование задачи
+// @Summary Редактировать задачу
+// @Description Частично обновляет задачу: название, описание, исполнителя, чат, вложения, приоритет, дату начала и срок. Доступно создателю, исполнителю и пользователям с правом manage_all_tasks. Для экземпляра повторяющейся задачи меняет только этот экземпляр: последующие изменения серии его больше не затрагивают
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param task body dto.UpdateTaskDTO true "Изменяемые поля задачи"
+// @Success 200 {object} models.Task "Задача успешно обновлена"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос, дата начала позже срока или родительская задача не найдена"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи или пользователь не состоит в новом чате задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 409 {object} map[string]interface{} "Родительская задача - сама задача или её подзадача"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id} [patch]
+func (h *TaskHandler) UpdateTask(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	var updateDTO dto.UpdateTaskDTO
+	if err := c.ShouldBindJSON(&updateDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	task, err := h.TaskController.Update(taskID, actor, &updateDTO)
+	if err != nil {
+		var userErr *custom_errors.GetUserHTTPError
+		var chatErr *custom_errors.GetChatHTTPError
+		var fileErr *custom_errors.GetFileHTTPError
+		var scheduleErr *custom_errors.InvalidTaskScheduleError
+		var parentErr *custom_errors.ParentTaskNotFoundError
+		var cycleErr *custom_errors.TaskHierarchyCycleError
+		var membershipErr *custom_errors.ChatMembershipRequiredError
+
+		switch {
+		case errors.As(err, &membershipErr):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.As(err, &userErr),
+			errors.As(err, &chatErr),
+			errors.As(err, &fileErr):
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		case errors.As(err, &scheduleErr),
+			errors.As(err, &parentErr):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.As(err, &cycleErr):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			respondTaskModificationError(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// DeleteTask Удаление задачи
+// @Summary Удалить задачу
+// @Description Мягко удаляет задачу. Доступно создателю, исполнителю и пользователям с правом manage_all_tasks
+// @Tags tasks
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Задача успешно удалена"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав на удаление задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id} [delete]
+func (h *TaskHandler) DeleteTask(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	if err := h.TaskController.Delete(taskID, actor); err != nil {
+		respondTaskModificationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCreatedTasks Получение задач, созданных пользователем
+// @Summary Получить задачи, созданные пользователем
+// @Description Возвращает список задач, созданных указанным пользователем, с пагинацией. В список попадают только задачи, которые видит пользователь из X-User-ID
+// @Tags tasks
+// @Produce json
+// @Param user_id path string true "UUID пользователя"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param limit query int false "Количество задач на странице" default(20)
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Success 200 {array} dto.TaskToList "Список задач"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID пользователя или параметры пагинации"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /users/{user_id}/tasks/created [get]
+func (h *TaskHandler) GetCreatedTasks(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	limit, offset, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tasks, err := h.TaskController.GetCreatedTasks(userID.String(), actor, limit, offset)
+	if err != nil {
+		respondTaskReadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetChatTasks Получение задач чата
+// @Summary Получить задачи чата
+// @Description Возвращает список задач, привязанных к указанному чату, с пагинацией. В список попадают только задачи, которые видит пользователь из X-User-ID
+// @Tags tasks
+// @Produce json
+// @Param chat_id path string true "UUID чата"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param limit query int false "Количество задач на странице" default(20)
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Success 200 {array} dto.TaskToList "Список задач"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID чата или параметры пагинации"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /chats/{chat_id}/tasks [get]
+func (h *TaskHandler) GetChatTasks(c *gin.Context) {
+	chatID, err := uuid.Parse(c.Param("chat_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chat ID"})
+		return
+	}
+
+	limit, offset, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tasks, err := h.TaskController.GetChatTasks(chatID.String(), actor, limit, offset)
+	if err != nil {
+		respondTaskReadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetTaskHistory Получение истории задачи
+// @Summary Получить историю задачи
+// @Description Возвращает события задачи (создание, смена статуса, переназначение, правки, вложения, комментарии) с автором и значениями до и после, новые первыми
+// @Tags tasks
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param limit query int false "Количество событий на странице" default(20)
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Success 200 {array} dto.TaskActivity "История задачи"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или параметры пагинации"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/history [get]
+func (h *TaskHandler) GetTaskHistory(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	limit, offset, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	events, err := h.TaskController.GetHistory(taskID, actor, limit, offset)
+	if err != nil {
+		respondTaskReadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// GetUserActivity Получение ленты активности пользователя
+// @Summary Получить ленту активности пользователя
+// @Description Объединяет события задач, где пользователь создатель или исполнитель, и его собственные действия, новые первыми. В ленту попадают только события задач, которые видит пользователь из X-User-ID
+// @Tags tasks
+// @Produce json
+// @Param user_id path string true "UUID пользователя"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param limit query int false "Количество событий на странице" default(20)
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Success 200 {array} dto.TaskActivity "Лента активности"
+// @Failure 400 {object} map[string]interface{} "Некорректный UUID пользователя или параметры пагинации"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /users/{user_id}/activity [get]
+func (h *TaskHandler) GetUserActivity(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	limit, offset, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	events, err := h.TaskController.GetUserActivity(userID.String(), actor, limit, offset)
+	if err != nil {
+		respondTaskReadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// getActor читает пользователя и его глобальные права из заголовков, выставляемых apiService
+func getActor(c *gin.Context) (*dto.Actor, error) {
+	userID, err := uuid.Parse(c.GetHeader("X-User-ID"))
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	var permissions []string
+	for _, p := range strings.Split(c.GetHeader("X-User-Permissions"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			permissions = append(permissions, p)
+		}
+	}
+	return &dto.Actor{UserID: userID, Permissions: permissions}, nil
+}
+
+// parsePagination разбирает limit и offset; при ошибке сам отвечает клиенту
+func parsePagination(c *gin.Context) (int, int, bool) {
 	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
 	if err != nil || limit <= 0 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
-		return
+		return 0, 0, false
 	}
 
 	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
 	if err != nil || offset < 0 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
-		return
+		return 0, 0, false
 	}
+	return limit, offset, true
+}
 
-	tasks, err := h.TaskController.GetUserTasks(userID, limit, offset)
-	if err != nil {
+// Поля сортировки списка задач пользователя и поиска задач
+var (
+	taskListSortFields  = []string{dto.TaskSortByCreatedAt, dto.TaskSortByDueAt, dto.TaskSortByStartAt, dto.TaskSortByPriority}
+	taskQuerySortFields = []string{dto.TaskSortByCreatedAt, dto.TaskSortByUpdatedAt, dto.TaskSortByDueAt, dto.TaskSortByStartAt,
+		dto.TaskSortByPriority, dto.TaskSortByStatus, dto.TaskSortByTitle}
+)
+
+// parseTaskListFilter разбирает фильтры и сортировку списка задач. Без параметров возвращает nil,
+// и список сортируется по дате создания, новые первыми
+func parseTaskListFilter(c *gin.Context) (*dto.TaskListFilter, bool) {
+	filter, empty, ok := parseListFilter(c, taskListSortFields)
+	if !ok || empty {
+		return nil, ok
+	}
+	return filter, true
+}
+
+// parseListFilter разбирает фильтры по приоритету и сроку и сортировку по одному из sortFields;
+// empty сообщает, что ни один из параметров не задан
+func parseListFilter(c *gin.Context, sortFields []string) (filter *dto.TaskListFilter, empty bool, ok bool) {
+	filter = &dto.TaskListFilter{SortBy: dto.TaskSortByCreatedAt, SortDesc: true}
+	empty = true
+
+	if raw := c.Query("priority"); raw != "" {
+		empty = false
+		for _, priority := range strings.Split(raw, ",") {
+			priority = strings.TrimSpace(priority)
+			switch priority {
+			case models.TaskPriorityLow, models.TaskPriorityNormal, models.TaskPriorityHigh, models.TaskPriorityUrgent:
+				filter.Priorities = append(filter.Priorities, priority)
+			default:
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid priority: " + priority})
+				return nil, false, false
+			}
+		}
+	}
+
+	if raw := c.Query("label"); raw != "" {
+		empty = false
+		for _, part := range strings.Split(raw, ",") {
+			labelID, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || labelID <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label: " + part})
+				return nil, false, false
+			}
+			filter.LabelIDs = append(filter.LabelIDs, labelID)
+		}
+	}
+
+	for _, bound := range []struct {
+		param  string
+		target **time.Time
+	}{{"due_from", &filter.DueFrom}, {"due_to", &filter.DueTo}} {
+		param, target := bound.param, bound.target
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		empty = false
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param})
+			return nil, false, false
+		}
+		*target = &parsed
+	}
+
+	if raw := c.Query("overdue"); raw != "" {
+		empty = false
+		overdue, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid overdue"})
+			return nil, false, false
+		}
+		filter.Overdue = overdue
+	}
+
+	if sortBy := c.Query("sort_by"); sortBy != "" {
+		empty = false
+		if !slices.Contains(sortFields, sortBy) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sort_by"})
+			return nil, false, false
+		}
+		filter.SortBy = sortBy
+	}
+
+	if order := c.Query("order"); order != "" {
+		empty = false
+		switch order {
+		case "asc":
+			filter.SortDesc = false
+		case "desc":
+			filter.SortDesc = true
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order"})
+			return nil, false, false
+		}
+	}
+
+	return filter, empty, true
+}
+
+func respondTaskModificationError(c *gin.Context, err error) {
+	var taskErr *custom_errors.TaskNotFoundError
+	var accessErr *custom_errors.TaskAccessDeniedError
+	var chatsErr *custom_errors.GetUserChatsHTTPError
+
+	switch {
+	case errors.As(err, &taskErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &accessErr):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &chatsErr):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
-		return
 	}
+}
 
-	c.JSON(http.StatusOK, tasks)
+// respondTaskReadError отвечает на ошибку чтения задач: невидимая задача не найдена, ошибки сервисов
+// файлов и чатов - 502
+func respondTaskReadError(c *gin.Context, err error) {
+	var taskErr *custom_errors.TaskNotFoundError
+	var fileErr *custom_errors.GetFileHTTPError
+	var chatsErr *custom_errors.GetUserChatsHTTPError
+
+	switch {
+	case errors.As(err, &taskErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &fileErr),
+		errors.As(err, &chatsErr):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// parseTaskQuery разбирает параметры поиска задач; при ошибке сам отвечает клиенту
+func parseTaskQuery(c *gin.Context) (*dto.TaskQuery, bool) {
+	filter, _, ok := parseListFilter(c, taskQuerySortFields)
+	if !ok {
+		return nil, false
+	}
+	query := &dto.TaskQuery{TaskListFilter: *filter, Search: strings.TrimSpace(c.Query("q"))}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return nil, false
+	}
+	query.Limit = limit
+
+	if raw := c.Query("status"); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			statusID, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || statusID <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status: " + part})
+				return nil, false
+			}
+			query.StatusIDs = append(query.StatusIDs, statusID)
+		}
+	}
+
+	for _, param := range []struct {
+		name   string
+		target **uuid.UUID
+	}{
+		{"creator_id", &query.CreatorID}, {"executor_id", &query.ExecutorID},
+		{"watcher_id", &query.WatcherID}, {"chat_id", &query.ChatID},
+	} {
+		raw := c.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param.name})
+			return nil, false
+		}
+		*param.target = &parsed
+	}
+
+	for _, bound := range []struct {
+		param  string
+		target **time.Time
+	}{
+		{"start_from", &query.StartFrom}, {"start_to", &query.StartTo},
+		{"created_from", &query.CreatedFrom}, {"created_to", &query.CreatedTo},
+	} {
+		raw := c.Query(bound.param)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + bound.param})
+			return nil, false
+		}
+		*bound.target = &parsed
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := dto.DecodeTaskCursor(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return nil, false
+		}
+		// Курсор хранит позицию в конкретной сортировке и не применим к другой
+		if cursor.SortBy != query.SortBy || cursor.SortDesc != query.SortDesc {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cursor does not match sort_by and order"})
+			return nil, false
+		}
+		query.Cursor = cursor
+	}
+
+	return query, true
 }
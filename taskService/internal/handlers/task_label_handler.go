@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+)
+
+type TaskLabelHandler struct {
+	Controller controllers.TaskLabelControllerInterface
+}
+
+func NewTaskLabelHandler(controller controllers.TaskLabelControllerInterface) *TaskLabelHandler {
+	return &TaskLabelHandler{Controller: controller}
+}
+
+// Create Создание метки задач
+// @Summary Создать метку
+// @Description Создает метку с названием и цветом; метки общие для всех задач
+// @Tags task-labels
+// @Accept json
+// @Produce json
+// @Param label body dto.SaveLabelDTO true "Название и цвет (#RGB или #RRGGBB)"
+// @Success 201 {object} dto.LabelResponse "Метка успешно создана"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 409 {object} map[string]interface{} "Метка с таким названием уже существует"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/labels [post]
+func (h *TaskLabelHandler) Create(c *gin.Context) {
+	var labelDTO dto.SaveLabelDTO
+	if err := c.ShouldBindJSON(&labelDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	label, err := h.Controller.Create(&labelDTO)
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, label)
+}
+
+// Update Изменение метки задач
+// @Summary Обновить метку
+// @Description Заменяет название и цвет метки
+// @Tags task-labels
+// @Accept json
+// @Produce json
+// @Param label_id path int true "ID метки"
+// @Param label body dto.SaveLabelDTO true "Название и цвет (#RGB или #RRGGBB)"
+// @Success 200 {object} dto.LabelResponse "Метка успешно обновлена"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 404 {object} map[string]interface{} "Метка не найдена"
+// @Failure 409 {object} map[string]interface{} "Метка с таким названием уже существует"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/labels/{label_id} [put]
+func (h *TaskLabelHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("label_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label ID"})
+		return
+	}
+
+	var labelDTO dto.SaveLabelDTO
+	if err := c.ShouldBindJSON(&labelDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	label, err := h.Controller.Update(id, &labelDTO)
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, label)
+}
+
+// GetByID Получение метки по ID
+// @Summary Получить метку по ID
+// @Description Возвращает метку и число задач, которым она назначена
+// @Tags task-labels
+// @Produce json
+// @Param label_id path int true "ID метки"
+// @Success 200 {object} dto.LabelResponse "Информация о метке"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID"
+// @Failure 404 {object} map[string]interface{} "Метка не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/labels/{label_id} [get]
+func (h *TaskLabelHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("label_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label ID"})
+		return
+	}
+
+	label, err := h.Controller.GetByID(id)
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, label)
+}
+
+// GetAll Получение всех меток
+// @Summary Получить все метки
+// @Description Возвращает все метки по названию вместе с числом задач, которым назначена каждая
+// @Tags task-labels
+// @Produce json
+// @Success 200 {array} dto.LabelResponse "Список меток"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/labels [get]
+func (h *TaskLabelHandler) GetAll(c *gin.Context) {
+	labels, err := h.Controller.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not get labels"})
+		return
+	}
+
+	c.JSON(http.StatusOK, labels)
+}
+
+// DeleteByID Удаление метки
+// @Summary Удалить метку
+// @Description Удаляет метку и снимает её со всех задач
+// @Tags task-labels
+// @Produce json
+// @Param label_id path int true "ID метки"
+// @Success 204 "Метка успешно удалена"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID"
+// @Failure 404 {object} map[string]interface{} "Метка не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/labels/{label_id} [delete]
+func (h *TaskLabelHandler) DeleteByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("label_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label ID"})
+		return
+	}
+
+	if err := h.Controller.DeleteByID(id); err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetTaskLabels Получение меток задачи
+// @Summary Получить метки задачи
+// @Description Возвращает метки, назначенные задаче, по названию
+// @Tags task-labels
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 200 {array} models.Label "Метки задачи"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/labels [get]
+func (h *TaskLabelHandler) GetTaskLabels(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	labels, err := h.Controller.GetTaskLabels(taskID, actor)
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, labels)
+}
+
+// AddToTask Назначение метки задаче
+// @Summary Назначить метку задаче
+// @Description Назначает метку задаче; повторное назначение ничего не меняет. Доступно создателю, исполнителю задачи и пользователям с правом manage_all_tasks
+// @Tags task-labels
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param label_id path int true "ID метки"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Метка назначена"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи, метки или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача или метка не найдены"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/labels/{label_id} [post]
+func (h *TaskLabelHandler) AddToTask(c *gin.Context) {
+	h.changeTaskLabel(c, h.Controller.AddToTask)
+}
+
+// RemoveFromTask Снятие метки с задачи
+// @Summary Снять метку с задачи
+// @Description Снимает метку с задачи. Права те же, что и на назначение
+// @Tags task-labels
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param label_id path int true "ID метки"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Метка снята"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи, метки или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача или метка не найдены, либо метка не назначена задаче"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/labels/{label_id} [delete]
+func (h *TaskLabelHandler) RemoveFromTask(c *gin.Context) {
+	h.changeTaskLabel(c, h.Controller.RemoveFromTask)
+}
+
+// changeTaskLabel разбирает actor и ID из запроса и вызывает назначение или снятие метки
+func (h *TaskLabelHandler) changeTaskLabel(c *gin.Context, change func(taskID, labelID int, actor *dto.Actor) error) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	labelID, err := strconv.Atoi(c.Param("label_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label ID"})
+		return
+	}
+
+	if err := change(taskID, labelID, actor); err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondLabelError(c *gin.Context, err error) {
+	var labelErr *custom_errors.LabelNotFoundError
+	var taskErr *custom_errors.TaskNotFoundError
+	var taskLabelErr *custom_errors.TaskLabelNotFoundError
+	var accessErr *custom_errors.TaskAccessDeniedError
+	var chatsErr *custom_errors.GetUserChatsHTTPError
+
+	switch {
+	case errors.As(err, &labelErr),
+		errors.As(err, &taskErr),
+		errors.As(err, &taskLabelErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &accessErr):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, custom_errors.ErrLabelAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.As(err, &chatsErr):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+)
+
+type TaskMemberHandler struct {
+	Controller controllers.TaskMemberControllerInterface
+}
+
+func NewTaskMemberHandler(controller controllers.TaskMemberControllerInterface) *TaskMemberHandler {
+	return &TaskMemberHandler{Controller: controller}
+}
+
+// AddAssignee Назначение соисполнителя задачи
+// @Summary Назначить соисполнителя
+// @Description Добавляет пользователя в соисполнители задачи и отправляет ему уведомление; повторное назначение и назначение основного исполнителя ничего не меняют. Доступно создателю, исполнителям задачи и пользователям с правом manage_all_tasks
+// @Tags task-members
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param user_id path string true "UUID соисполнителя"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Соисполнитель назначен"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Failure 502 {object} map[string]interface{} "Ошибка при получении пользователя"
+// @Router /tasks/{task_id}/assignees/{user_id} [post]
+func (h *TaskMemberHandler) AddAssignee(c *gin.Context) {
+	h.changeTaskMember(c, h.Controller.AddAssignee)
+}
+
+// RemoveAssignee Снятие соисполнителя задачи
+// @Summary Снять соисполнителя
+// @Description Убирает пользователя из соисполнителей задачи. Права те же, что и на назначение
+// @Tags task-members
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param user_id path string true "UUID соисполнителя"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Соисполнитель снят"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена или пользователь не является соисполнителем"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/assignees/{user_id} [delete]
+func (h *TaskMemberHandler) RemoveAssignee(c *gin.Context) {
+	h.changeTaskMember(c, h.Controller.RemoveAssignee)
+}
+
+// AddWatcher Подписка на задачу
+// @Summary Добавить наблюдателя
+// @Description Подписывает пользователя на уведомления о смене статуса и комментариях задачи. Подписаться сам может любой пользователь, подписать другого - создатель, исполнители задачи и пользователи с правом manage_all_tasks
+// @Tags task-members
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param user_id path string true "UUID наблюдателя"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Наблюдатель добавлен"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав подписывать других пользователей или подписываемый не видит задачу"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Failure 502 {object} map[string]interface{} "Ошибка при получении пользователя"
+// @Router /tasks/{task_id}/watchers/{user_id} [post]
+func (h *TaskMemberHandler) AddWatcher(c *gin.Context) {
+	h.changeTaskMember(c, h.Controller.AddWatcher)
+}
+
+// RemoveWatcher Отписка от задачи
+// @Summary Убрать наблюдателя
+// @Description Отписывает пользователя от уведомлений задачи. Права те же, что и на подписку
+// @Tags task-members
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param user_id path string true "UUID наблюдателя"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Наблюдатель удалён"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав отписывать других пользователей"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена или пользователь не наблюдает за ней"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/watchers/{user_id} [delete]
+func (h *TaskMemberHandler) RemoveWatcher(c *gin.Context) {
+	h.changeTaskMember(c, h.Controller.RemoveWatcher)
+}
+
+// changeTaskMember разбирает actor и ID из запроса и вызывает изменение соисполнителей или наблюдателей
+func (h *TaskMemberHandler) changeTaskMember(c *gin.Context, change func(taskID int, userID uuid.UUID, actor *dto.Actor) error) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	if err := change(taskID, userID, actor); err != nil {
+		respondTaskMemberError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondTaskMemberError(c *gin.Context, err error) {
+	var taskErr *custom_errors.TaskNotFoundError
+	var assigneeErr *custom_errors.TaskAssigneeNotFoundError
+	var watcherErr *custom_errors.TaskWatcherNotFoundError
+	var accessErr *custom_errors.TaskAccessDeniedError
+	var userErr *custom_errors.GetUserHTTPError
+	var chatsErr *custom_errors.GetUserChatsHTTPError
+
+	switch {
+	case errors.As(err, &taskErr),
+		errors.As(err, &assigneeErr),
+		errors.As(err, &watcherErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &accessErr):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &userErr),
+		errors.As(err, &chatsErr):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+)
+
+type TaskRecurrenceHandler struct {
+	Controller controllers.TaskRecurrenceControllerInterface
+}
+
+func NewTaskRecurrenceHandler(controller controllers.TaskRecurrenceControllerInterface) *TaskRecurrenceHandler {
+	return &TaskRecurrenceHandler{Controller: controller}
+}
+
+// Create Создание серии повторяющихся задач
+// @Summary Создать серию повторяющихся задач
+// @Description Создает шаблон задачи с правилом повторения RRULE: FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY (для WEEKLY), BYMONTHDAY (для MONTHLY), COUNT или UNTIL. Экземпляры создаются заранее планировщиком; исполнитель получает уведомление о каждом. Создателем серии становится пользователь из X-User-ID
+// @Tags task-recurrences
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param recurrence body dto.SaveTaskRecurrenceDTO true "Шаблон задачи и правило повторения"
+// @Success 201 {object} models.TaskRecurrence "Серия успешно создана"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или правило, workflow не найден"
+// @Failure 403 {object} map[string]interface{} "Пользователь не состоит в чате серии"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/recurrences [post]
+func (h *TaskRecurrenceHandler) Create(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var recurrenceDTO dto.SaveTaskRecurrenceDTO
+	if err := c.ShouldBindJSON(&recurrenceDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	recurrence, err := h.Controller.Create(actor, &recurrenceDTO)
+	if err != nil {
+		respondRecurrenceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, recurrence)
+}
+
+// GetByID Получение серии повторяющихся задач
+// @Summary Получить серию повторяющихся задач
+// @Description Возвращает шаблон задачи, правило повторения и начало следующего ещё не созданного экземпляра. Серию видят её создатель, исполнитель, участники её чата и пользователи с правом manage_all_tasks
+// @Tags task-recurrences
+// @Produce json
+// @Param recurrence_id path int true "ID серии"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 200 {object} models.TaskRecurrence "Серия"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID серии или пользователя"
+// @Failure 404 {object} map[string]interface{} "Серия не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/recurrences/{recurrence_id} [get]
+func (h *TaskRecurrenceHandler) GetByID(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("recurrence_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recurrence ID"})
+		return
+	}
+
+	recurrence, err := h.Controller.GetByID(id, actor)
+	if err != nil {
+		respondRecurrenceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurrence)
+}
+
+// Update Изменение серии повторяющихся задач
+// @Summary Изменить серию повторяющихся задач
+// @Description Заменяет шаблон и правило серии. Изменения переносятся в ещё не начавшиеся экземпляры, которые не меняли отдельно; при смене правила, начала серии или workflow такие экземпляры пересоздаются по новому правилу. Чтобы изменить один экземпляр, измените задачу через PATCH /tasks/{task_id}. Доступно создателю серии и пользователям с правом manage_all_tasks
+// @Tags task-recurrences
+// @Accept json
+// @Produce json
+// @Param recurrence_id path int true "ID серии"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param recurrence body dto.SaveTaskRecurrenceDTO true "Шаблон задачи и правило повторения"
+// @Success 200 {object} models.TaskRecurrence "Серия успешно изменена"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или правило, workflow не найден"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение серии или пользователь не состоит в новом чате серии"
+// @Failure 404 {object} map[string]interface{} "Серия не найдена"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/recurrences/{recurrence_id} [put]
+func (h *TaskRecurrenceHandler) Update(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("recurrence_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recurrence ID"})
+		return
+	}
+
+	var recurrenceDTO dto.SaveTaskRecurrenceDTO
+	if err := c.ShouldBindJSON(&recurrenceDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	recurrence, err := h.Controller.Update(id, actor, &recurrenceDTO)
+	if err != nil {
+		respondRecurrenceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurrence)
+}
+
+// Delete Удаление серии повторяющихся задач
+// @Summary Удалить серию повторяющихся задач
+// @Description Удаляет серию и её ещё не начавшиеся экземпляры, которые не меняли отдельно; остальные экземпляры остаются обычными задачами. Доступно создателю серии и пользователям с правом manage_all_tasks
+// @Tags task-recurrences
+// @Produce json
+// @Param recurrence_id path int true "ID серии"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Серия удалена"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID серии или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав на удаление серии"
+// @Failure 404 {object} map[string]interface{} "Серия не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/recurrences/{recurrence_id} [delete]
+func (h *TaskRecurrenceHandler) Delete(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("recurrence_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recurrence ID"})
+		return
+	}
+
+	if err := h.Controller.Delete(id, actor); err != nil {
+		respondRecurrenceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondRecurrenceError(c *gin.Context, err error) {
+	var notFoundErr *custom_errors.TaskRecurrenceNotFoundError
+	var accessErr *custom_errors.TaskRecurrenceAccessDeniedError
+	var ruleErr *custom_errors.InvalidRecurrenceRuleError
+	var statusErr *custom_errors.TaskStatusNotFoundError
+	var workflowErr *custom_errors.WorkflowNotFoundError
+	var invalidWorkflowErr *custom_errors.InvalidWorkflowError
+	var userErr *custom_errors.GetUserHTTPError
+	var chatErr *custom_errors.GetChatHTTPError
+	var membershipErr *custom_errors.ChatMembershipRequiredError
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &accessErr),
+		errors.As(err, &membershipErr):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &ruleErr),
+		errors.As(err, &statusErr),
+		errors.As(err, &workflowErr),
+		errors.As(err, &invalidWorkflowErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.As(err, &userErr),
+		errors.As(err, &chatErr):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
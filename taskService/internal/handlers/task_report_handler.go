@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"time"
+)
+
+// defaultTaskReportPeriod - период отчёта, если from не задан
+const defaultTaskReportPeriod = 30 * 24 * time.Hour
+
+// Форматы ответа отчётов
+const (
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+)
+
+type TaskReportHandler struct {
+	Controller controllers.TaskReportControllerInterface
+}
+
+func NewTaskReportHandler(controller controllers.TaskReportControllerInterface) *TaskReportHandler {
+	return &TaskReportHandler{Controller: controller}
+}
+
+// GetThroughput Созданные и завершённые задачи
+// @Summary Получить пропускную способность
+// @Description Считает задачи, созданные и завершённые в каждом дне или неделе периода [from, to), включая периоды без задач. Завершённой считается задача, которая сейчас в статусе без исходящих переходов своего workflow, в период её перехода в этот статус. Завершения считаются по истории статусов и отстают от изменений до обновления отчётов (TASK_REPORT_REFRESH_INTERVAL). Учитываются только задачи, которые видит пользователь; отчёт по чату доступен его участникам и пользователям с правом manage_all_tasks
+// @Tags task-reports
+// @Produce json
+// @Produce text/csv
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param interval query string false "Шаг периодов: day или week (недели начинаются с понедельника)" default(day)
+// @Param from query string false "Начало периода (RFC3339), по умолчанию 30 дней до to"
+// @Param to query string false "Конец периода, не включительно (RFC3339), по умолчанию текущее время"
+// @Param chat_id query string false "Только задачи чата"
+// @Param format query string false "Формат ответа: json или csv" default(json)
+// @Success 200 {array} dto.TaskThroughput "Задачи по периодам"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры отчёта"
+// @Failure 403 {object} map[string]interface{} "Пользователь не состоит в чате"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /reports/throughput [get]
+func (h *TaskReportHandler) GetThroughput(c *gin.Context) {
+	format, ok := parseReportFormat(c)
+	if !ok {
+		return
+	}
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query, ok := parseTaskReportQuery(c)
+	if !ok {
+		return
+	}
+	query.Interval = c.DefaultQuery("interval", dto.ReportIntervalDay)
+
+	rows, err := h.Controller.GetThroughput(actor, query)
+	if err != nil {
+		respondTaskReportError(c, err)
+		return
+	}
+
+	respondTaskReport(c, format, "throughput", rows, []string{"period", "created", "completed"},
+		func(row dto.TaskThroughput) []string {
+			return []string{
+				row.Period.Format(time.DateOnly),
+				strconv.FormatInt(row.Created, 10),
+				strconv.FormatInt(row.Completed, 10),
+			}
+		})
+}
+
+// GetStatusDurations Время в статусах
+// @Summary Получить среднее время в статусах
+// @Description Усредняет по истории смены статусов длительность пребываний задач в каждом статусе, закончившихся в [from, to). Текущее пребывание задачи в статусе не учитывается. Удалённые статусы возвращаются без statusID. Отчёт отстаёт от изменений до обновления отчётов (TASK_REPORT_REFRESH_INTERVAL). Учитываются только задачи, которые видит пользователь; отчёт по чату доступен его участникам и пользователям с правом manage_all_tasks
+// @Tags task-reports
+// @Produce json
+// @Produce text/csv
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param from query string false "Начало периода (RFC3339), по умолчанию 30 дней до to"
+// @Param to query string false "Конец периода, не включительно (RFC3339), по умолчанию текущее время"
+// @Param chat_id query string false "Только задачи чата"
+// @Param format query string false "Формат ответа: json или csv" default(json)
+// @Success 200 {array} dto.TaskStatusDuration "Среднее время по статусам"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры отчёта"
+// @Failure 403 {object} map[string]interface{} "Пользователь не состоит в чате"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /reports/status-durations [get]
+func (h *TaskReportHandler) GetStatusDurations(c *gin.Context) {
+	format, ok := parseReportFormat(c)
+	if !ok {
+		return
+	}
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query, ok := parseTaskReportQuery(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.Controller.GetStatusDurations(actor, query)
+	if err != nil {
+		respondTaskReportError(c, err)
+		return
+	}
+
+	respondTaskReport(c, format, "status-durations", rows, []string{"status_id", "status", "stays", "avg_minutes"},
+		func(row dto.TaskStatusDuration) []string {
+			statusID := ""
+			if row.StatusID != nil {
+				statusID = strconv.Itoa(*row.StatusID)
+			}
+			return []string{
+				statusID,
+				row.Status,
+				strconv.FormatInt(row.Stays, 10),
+				strconv.FormatFloat(row.AvgMinutes, 'f', 1, 64),
+			}
+		})
+}
+
+// GetOverdue Просроченные задачи по исполнителям
+// @Summary Получить просроченные задачи по исполнителям
+// @Description Считает незакрытые задачи с истёкшим сроком у каждого исполнителя на текущий момент, больше всего просроченных первыми. Задачи без исполнителя не учитываются. Учитываются только задачи, которые видит пользователь; отчёт по чату доступен его участникам и пользователям с правом manage_all_tasks
+// @Tags task-reports
+// @Produce json
+// @Produce text/csv
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param chat_id query string false "Только задачи чата"
+// @Param format query string false "Формат ответа: json или csv" default(json)
+// @Success 200 {array} dto.TaskOverdueCount "Просроченные задачи по исполнителям"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры отчёта"
+// @Failure 403 {object} map[string]interface{} "Пользователь не состоит в чате"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /reports/overdue [get]
+func (h *TaskReportHandler) GetOverdue(c *gin.Context) {
+	format, ok := parseReportFormat(c)
+	if !ok {
+		return
+	}
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	chatID, ok := parseReportChatID(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.Controller.GetOverdue(actor, chatID)
+	if err != nil {
+		respondTaskReportError(c, err)
+		return
+	}
+
+	respondTaskReport(c, format, "overdue", rows, []string{"executor_id", "overdue", "oldest_due_at"},
+		func(row dto.TaskOverdueCount) []string {
+			return []string{
+				row.ExecutorID.String(),
+				strconv.FormatInt(row.Overdue, 10),
+				row.OldestDueAt.Format(time.RFC3339),
+			}
+		})
+}
+
+// GetCumulativeFlow Накопительная диаграмма потока
+// @Summary Получить накопительную диаграмму потока чата
+// @Description Для каждого дня периода [from, to) считает задачи чата в каждом статусе на конец дня (UTC). Статусы без задач не возвращаются. Отчёт строится по истории смены статусов и отстаёт от изменений до обновления отчётов (TASK_REPORT_REFRESH_INTERVAL). Учитываются только задачи, которые видит пользователь; отчёт по чату доступен его участникам и пользователям с правом manage_all_tasks
+// @Tags task-reports
+// @Produce json
+// @Produce text/csv
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param chat_id query string true "ID чата"
+// @Param from query string false "Начало периода (RFC3339), по умолчанию 30 дней до to"
+// @Param to query string false "Конец периода, не включительно (RFC3339), по умолчанию текущее время"
+// @Param format query string false "Формат ответа: json или csv" default(json)
+// @Success 200 {array} dto.TaskCumulativeFlow "Задачи по дням и статусам"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры отчёта"
+// @Failure 403 {object} map[string]interface{} "Пользователь не состоит в чате"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /reports/cumulative-flow [get]
+func (h *TaskReportHandler) GetCumulativeFlow(c *gin.Context) {
+	format, ok := parseReportFormat(c)
+	if !ok {
+		return
+	}
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query, ok := parseTaskReportQuery(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.Controller.GetCumulativeFlow(actor, query)
+	if err != nil {
+		respondTaskReportError(c, err)
+		return
+	}
+
+	respondTaskReport(c, format, "cumulative-flow", rows, []string{"day", "status", "tasks"},
+		func(row dto.TaskCumulativeFlow) []string {
+			return []string{row.Day.Format(time.DateOnly), row.Status, strconv.FormatInt(row.Tasks, 10)}
+		})
+}
+
+// parseTaskReportQuery разбирает период и чат отчёта; даты приводятся к UTC. При ошибке сам отвечает клиенту
+func parseTaskReportQuery(c *gin.Context) (*dto.TaskReportQuery, bool) {
+	to, ok := parseReportTime(c, "to")
+	if !ok {
+		return nil, false
+	}
+	from, ok := parseReportTime(c, "from")
+	if !ok {
+		return nil, false
+	}
+	chatID, ok := parseReportChatID(c)
+	if !ok {
+		return nil, false
+	}
+
+	query := &dto.TaskReportQuery{To: time.Now().UTC(), ChatID: chatID}
+	if to != nil {
+		query.To = *to
+	}
+	query.From = query.To.Add(-defaultTaskReportPeriod)
+	if from != nil {
+		query.From = *from
+	}
+	return query, true
+}
+
+func parseReportTime(c *gin.Context, param string) (*time.Time, bool) {
+	raw := c.Query(param)
+	if raw == "" {
+		return nil, true
+	}
+	parsed, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param})
+		return nil, false
+	}
+	parsed = parsed.UTC()
+	return &parsed, true
+}
+
+func parseReportChatID(c *gin.Context) (*uuid.UUID, bool) {
+	raw := c.Query("chat_id")
+	if raw == "" {
+		return nil, true
+	}
+	chatID, err := uuid.Parse(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid chat_id"})
+		return nil, false
+	}
+	return &chatID, true
+}
+
+func parseReportFormat(c *gin.Context) (string, bool) {
+	format := c.DefaultQuery("format", reportFormatJSON)
+	if format != reportFormatJSON && format != reportFormatCSV {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid format"})
+		return "", false
+	}
+	return format, true
+}
+
+// respondTaskReport отвечает строками отчёта в JSON или CSV-файлом name.csv с заголовком header
+func respondTaskReport[T any](c *gin.Context, format, name string, rows []T, header []string, record func(T) []string) {
+	if format != reportFormatCSV {
+		c.JSON(http.StatusOK, rows)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+name+`.csv"`)
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	_ = writer.Write(header)
+	for _, row := range rows {
+		_ = writer.Write(record(row))
+	}
+	writer.Flush()
+}
+
+func respondTaskReportError(c *gin.Context, err error) {
+	var invalidErr *custom_errors.InvalidTaskReportError
+	if errors.As(err, &invalidErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var membershipErr *custom_errors.ChatMembershipRequiredError
+	if errors.As(err, &membershipErr) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+}
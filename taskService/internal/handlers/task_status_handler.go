@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
 	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
 )
 
 type TaskStatusHandler struct {
@@ -71,14 +73,45 @@ func (h *TaskStatusHandler) GetByID(c *gin.Context) {
 	c.JSON(http.StatusOK, status)
 }
 
+// GetUsage Использование статуса задачи
+// @Summary Получить использование статуса задачи
+// @Description Возвращает число задач и удалённых задач в статусе и workflow, в которых он используется. protected - статус нельзя удалить
+// @Tags task-statuses
+// @Produce json
+// @Param id path int true "ID статуса"
+// @Success 200 {object} dto.TaskStatusUsage "Использование статуса"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID"
+// @Failure 404 {object} map[string]interface{} "Статус не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/statuses/{id}/usage [get]
+func (h *TaskStatusHandler) GetUsage(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	usage, err := h.Controller.GetUsage(id)
+	if err != nil {
+		respondTaskStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
 // DeleteByID Удаление статуса задачи
 // @Summary Удалить статус задачи
-// @Description Удаляет статус задачи по его ID
+// @Description Удаляет статус задачи по его ID. Статус "created" и статусы, используемые в workflow, удалить нельзя. Если в статусе есть задачи (включая удалённые), они переносятся в статус replacement_id с записью в историю; без replacement_id такой статус не удаляется. Статус replacement_id должен входить в workflow каждой переносимой задачи
 // @Tags task-statuses
 // @Produce json
 // @Param id path int true "ID статуса"
+// @Param replacement_id query int false "ID статуса, в который переносятся задачи удаляемого статуса"
+// @Param X-User-ID header string true "ID пользователя"
 // @Success 204 "Статус успешно удален"
-// @Failure 400 {object} map[string]interface{} "Некорректный ID"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID или статус для переноса"
+// @Failure 404 {object} map[string]interface{} "Статус не найден"
+// @Failure 409 {object} map[string]interface{} "Статус используется и не может быть удалён"
 // @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
 // @Router /tasks/statuses/{id} [delete]
 func (h *TaskStatusHandler) DeleteByID(c *gin.Context) {
@@ -88,9 +121,25 @@ func (h *TaskStatusHandler) DeleteByID(c *gin.Context) {
 		return
 	}
 
-	err = h.Controller.DeleteByID(id)
+	var replacementID *int
+	if raw := c.Query("replacement_id"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid replacement_id"})
+			return
+		}
+		replacementID = &parsed
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.Controller.DeleteByID(id, replacementID, actor)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not delete task status"})
+		respondTaskStatusError(c, err)
 		return
 	}
 
@@ -114,3 +163,21 @@ func (h *TaskStatusHandler) GetAll(c *gin.Context) {
 
 	c.JSON(http.StatusOK, statuses)
 }
+
+func respondTaskStatusError(c *gin.Context, err error) {
+	var notFoundErr *custom_errors.TaskStatusNotFoundError
+	var protectedErr *custom_errors.TaskStatusProtectedError
+	var replacementRequiredErr *custom_errors.TaskStatusReplacementRequiredError
+	var invalidReplacementErr *custom_errors.InvalidStatusReplacementError
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &protectedErr), errors.As(err, &replacementRequiredErr):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.As(err, &invalidReplacementErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+)
+
+type TaskTemplateHandler struct {
+	Controller controllers.TaskTemplateControllerInterface
+}
+
+func NewTaskTemplateHandler(controller controllers.TaskTemplateControllerInterface) *TaskTemplateHandler {
+	return &TaskTemplateHandler{Controller: controller}
+}
+
+// Create Создание шаблона задачи
+// @Summary Создать шаблон задачи
+// @Description Создает шаблон: название задачи с подстановками {name} ({date} без значения заменяется текущей датой), описание, приоритет, исполнитель по умолчанию, метки и пункты чек-листа. Создателем шаблона становится пользователь из X-User-ID
+// @Tags task-templates
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param template body dto.SaveTaskTemplateDTO true "Данные шаблона"
+// @Success 201 {object} models.TaskTemplate "Шаблон успешно создан"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 404 {object} map[string]interface{} "Метка не найдена"
+// @Failure 409 {object} map[string]interface{} "Шаблон с таким названием уже существует"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/templates [post]
+func (h *TaskTemplateHandler) Create(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var templateDTO dto.SaveTaskTemplateDTO
+	if err := c.ShouldBindJSON(&templateDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	template, err := h.Controller.Create(actor, &templateDTO)
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// GetAll Получение шаблонов задач
+// @Summary Получить шаблоны задач
+// @Description Возвращает по названию шаблоны задач, которые видит пользователь, вместе с метками и пунктами чек-листа. Шаблон видят его создатель, исполнитель по умолчанию и пользователи с правом manage_all_tasks
+// @Tags task-templates
+// @Produce json
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 200 {array} models.TaskTemplate "Шаблоны задач"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID пользователя"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/templates [get]
+func (h *TaskTemplateHandler) GetAll(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	templates, err := h.Controller.GetAll(actor)
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// GetByID Получение шаблона задачи
+// @Summary Получить шаблон задачи
+// @Description Возвращает шаблон вместе с метками и пунктами чек-листа. Шаблон видят его создатель, исполнитель по умолчанию и пользователи с правом manage_all_tasks
+// @Tags task-templates
+// @Produce json
+// @Param template_id path int true "ID шаблона"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 200 {object} models.TaskTemplate "Шаблон"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID шаблона или пользователя"
+// @Failure 404 {object} map[string]interface{} "Шаблон не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/templates/{template_id} [get]
+func (h *TaskTemplateHandler) GetByID(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template ID"})
+		return
+	}
+
+	template, err := h.Controller.GetByID(id, actor)
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// Update Изменение шаблона задачи
+// @Summary Изменить шаблон задачи
+// @Description Заменяет шаблон целиком, включая метки и пункты чек-листа. Задачи, уже созданные по шаблону, не меняются. Доступно создателю шаблона и пользователям с правом manage_all_tasks
+// @Tags task-templates
+// @Accept json
+// @Produce json
+// @Param template_id path int true "ID шаблона"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param template body dto.SaveTaskTemplateDTO true "Данные шаблона"
+// @Success 200 {object} models.TaskTemplate "Шаблон успешно изменен"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение шаблона"
+// @Failure 404 {object} map[string]interface{} "Шаблон или метка не найдены"
+// @Failure 409 {object} map[string]interface{} "Шаблон с таким названием уже существует"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/templates/{template_id} [put]
+func (h *TaskTemplateHandler) Update(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template ID"})
+		return
+	}
+
+	var templateDTO dto.SaveTaskTemplateDTO
+	if err := c.ShouldBindJSON(&templateDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	template, err := h.Controller.Update(id, actor, &templateDTO)
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// Delete Удаление шаблона задачи
+// @Summary Удалить шаблон задачи
+// @Description Удаляет шаблон; задачи, созданные по нему, остаются. Доступно создателю шаблона и пользователям с правом manage_all_tasks
+// @Tags task-templates
+// @Produce json
+// @Param template_id path int true "ID шаблона"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Шаблон удален"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID шаблона или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав на удаление шаблона"
+// @Failure 404 {object} map[string]interface{} "Шаблон не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/templates/{template_id} [delete]
+func (h *TaskTemplateHandler) Delete(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template ID"})
+		return
+	}
+
+	if err := h.Controller.Delete(id, actor); err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateTask Создание задачи по шаблону
+// @Summary Создать задачу по шаблону
+// @Description Создает задачу с названием из шаблона (значения подстановок передаются в variables), описанием, приоритетом и исполнителем шаблона; исполнителя и приоритет можно переопределить. Задача проходит те же проверки пользователей, чата и файлов, что и при обычном создании, получает метки шаблона и копию его чек-листа. Создателем задачи становится пользователь из X-User-ID
+// @Tags task-templates
+// @Accept json
+// @Produce json
+// @Param template_id path int true "ID шаблона"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param task body dto.CreateTaskFromTemplateDTO true "Значения подстановок и параметры задачи"
+// @Success 201 {object} models.Task "Задача успешно создана"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос, нет значения подстановки, статус, workflow или родительская задача не найдены, дата начала позже срока"
+// @Failure 403 {object} map[string]interface{} "Пользователь не состоит в чате задачи"
+// @Failure 404 {object} map[string]interface{} "Шаблон не найден"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к внешнему сервису"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/templates/{template_id}/tasks [post]
+func (h *TaskTemplateHandler) CreateTask(c *gin.Context) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid template ID"})
+		return
+	}
+
+	var fromTemplateDTO dto.CreateTaskFromTemplateDTO
+	if err := c.ShouldBindJSON(&fromTemplateDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	task, err := h.Controller.CreateTask(id, actor, &fromTemplateDTO)
+	if err != nil {
+		respondTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
+// respondTemplateError отвечает на ошибки шаблонов; остальные ошибки - это ошибки создания задачи
+func respondTemplateError(c *gin.Context, err error) {
+	var notFoundErr *custom_errors.TaskTemplateNotFoundError
+	var labelErr *custom_errors.LabelNotFoundError
+	var accessErr *custom_errors.TaskTemplateAccessDeniedError
+	var invalidErr *custom_errors.InvalidTaskTemplateError
+
+	switch {
+	case errors.As(err, &notFoundErr),
+		errors.As(err, &labelErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &accessErr):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &invalidErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, custom_errors.ErrTaskTemplateAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		respondCreateTaskError(c, err)
+	}
+}
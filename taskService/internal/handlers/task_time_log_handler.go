@@ -0,0 +1,378 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"slices"
+	"strconv"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"time"
+)
+
+type TaskTimeLogHandler struct {
+	Controller controllers.TaskTimeLogControllerInterface
+}
+
+func NewTaskTimeLogHandler(controller controllers.TaskTimeLogControllerInterface) *TaskTimeLogHandler {
+	return &TaskTimeLogHandler{Controller: controller}
+}
+
+// StartTimer Запуск таймера
+// @Summary Запустить таймер
+// @Description Запускает таймер затраченного на задачу времени. Доступно исполнителю и соисполнителям задачи; у пользователя может быть запущен только один таймер
+// @Tags task-time-logs
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Success 201 {object} models.TaskTimeLog "Таймер запущен"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 403 {object} map[string]interface{} "Пользователь не является исполнителем задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 409 {object} map[string]interface{} "У пользователя уже запущен таймер"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/time-logs/start [post]
+func (h *TaskTimeLogHandler) StartTimer(c *gin.Context) {
+	actor, taskID, ok := parseTimeLogTaskRequest(c)
+	if !ok {
+		return
+	}
+
+	log, err := h.Controller.StartTimer(taskID, actor)
+	if err != nil {
+		respondTaskTimeLogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, log)
+}
+
+// StopTimer Остановка таймера
+// @Summary Остановить таймер
+// @Description Останавливает запущенный пользователем таймер задачи; длительность округляется до минут
+// @Tags task-time-logs
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Success 200 {object} models.TaskTimeLog "Таймер остановлен"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи или пользователя"
+// @Failure 404 {object} map[string]interface{} "У пользователя нет запущенного таймера на этой задаче"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/time-logs/stop [post]
+func (h *TaskTimeLogHandler) StopTimer(c *gin.Context) {
+	actor, taskID, ok := parseTimeLogTaskRequest(c)
+	if !ok {
+		return
+	}
+
+	log, err := h.Controller.StopTimer(taskID, actor)
+	if err != nil {
+		respondTaskTimeLogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, log)
+}
+
+// Create Ручная запись затраченного времени
+// @Summary Списать время вручную
+// @Description Добавляет запись о затраченном времени длительностью minutes начиная с started_at; запись не может заканчиваться в будущем. Доступно исполнителю и соисполнителям задачи
+// @Tags task-time-logs
+// @Accept json
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param time_log body dto.CreateTaskTimeLogDTO true "Интервал и описание работы"
+// @Success 201 {object} models.TaskTimeLog "Время списано"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 403 {object} map[string]interface{} "Пользователь не является исполнителем задачи"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/time-logs [post]
+func (h *TaskTimeLogHandler) Create(c *gin.Context) {
+	actor, taskID, ok := parseTimeLogTaskRequest(c)
+	if !ok {
+		return
+	}
+
+	var logDTO dto.CreateTaskTimeLogDTO
+	if err := c.ShouldBindJSON(&logDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	log, err := h.Controller.Create(taskID, actor, &logDTO)
+	if err != nil {
+		respondTaskTimeLogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, log)
+}
+
+// Update Изменение записи времени
+// @Summary Изменить запись времени
+// @Description Изменяет начало, длительность и описание записи. Доступно автору записи и пользователям с правом manage_all_tasks; длительность запущенного таймера изменить нельзя
+// @Tags task-time-logs
+// @Accept json
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param log_id path int true "ID записи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param time_log body dto.UpdateTaskTimeLogDTO true "Изменяемые поля записи"
+// @Success 200 {object} models.TaskTimeLog "Запись обновлена"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 403 {object} map[string]interface{} "Нет прав на изменение записи"
+// @Failure 404 {object} map[string]interface{} "Запись не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/time-logs/{log_id} [patch]
+func (h *TaskTimeLogHandler) Update(c *gin.Context) {
+	actor, taskID, ok := parseTimeLogTaskRequest(c)
+	if !ok {
+		return
+	}
+	logID, ok := parseTimeLogID(c)
+	if !ok {
+		return
+	}
+
+	var updateDTO dto.UpdateTaskTimeLogDTO
+	if err := c.ShouldBindJSON(&updateDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	log, err := h.Controller.Update(taskID, logID, actor, &updateDTO)
+	if err != nil {
+		respondTaskTimeLogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, log)
+}
+
+// Delete Удаление записи времени
+// @Summary Удалить запись времени
+// @Description Удаляет запись или запущенный таймер. Доступно автору записи и пользователям с правом manage_all_tasks
+// @Tags task-time-logs
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param log_id path int true "ID записи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Success 204 "Запись удалена"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи, записи или пользователя"
+// @Failure 403 {object} map[string]interface{} "Нет прав на удаление записи"
+// @Failure 404 {object} map[string]interface{} "Запись не найдена"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/time-logs/{log_id} [delete]
+func (h *TaskTimeLogHandler) Delete(c *gin.Context) {
+	actor, taskID, ok := parseTimeLogTaskRequest(c)
+	if !ok {
+		return
+	}
+	logID, ok := parseTimeLogID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.Controller.Delete(taskID, logID, actor); err != nil {
+		respondTaskTimeLogError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetByTaskID Получение записей времени задачи
+// @Summary Получить записи времени задачи
+// @Description Возвращает записи затраченного на задачу времени, включая запущенные таймеры, последние первыми
+// @Tags task-time-logs
+// @Produce json
+// @Param task_id path int true "ID задачи"
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param limit query int false "Количество записей на странице" default(20)
+// @Param offset query int false "Смещение для пагинации" default(0)
+// @Success 200 {array} models.TaskTimeLog "Записи времени"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID задачи, пользователя или параметры пагинации"
+// @Failure 404 {object} map[string]interface{} "Задача не найдена"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/{task_id}/time-logs [get]
+func (h *TaskTimeLogHandler) GetByTaskID(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+
+	limit, offset, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	logs, err := h.Controller.GetByTaskID(taskID, actor, limit, offset)
+	if err != nil {
+		respondTaskTimeLogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, logs)
+}
+
+// GetTimeSpent Отчёт о затраченном времени
+// @Summary Получить затраченное время
+// @Description Суммирует остановленные записи времени по задачам (вместе с оценкой), пользователям или чатам. Учитываются записи, начатые в [from, to); запущенные таймеры не учитываются. Учитываются только задачи, которые видит пользователь из X-User-ID
+// @Tags task-time-logs
+// @Produce json
+// @Param X-User-ID header string true "UUID пользователя, выполняющего запрос"
+// @Param X-User-Permissions header string false "Глобальные права пользователя через запятую"
+// @Param group_by query string false "Группировка: task, user или chat" default(task)
+// @Param from query string false "Начало периода (RFC3339)"
+// @Param to query string false "Конец периода, не включительно (RFC3339)"
+// @Param task_id query int false "Только записи задачи"
+// @Param user_id query string false "Только записи пользователя"
+// @Param chat_id query string false "Только записи задач чата"
+// @Success 200 {array} dto.TimeSpent "Затраченное время, больше всего первыми"
+// @Failure 400 {object} map[string]interface{} "Некорректные параметры отчёта или ID пользователя"
+// @Failure 502 {object} map[string]interface{} "Ошибка при обращении к сервису чатов"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /time-spent [get]
+func (h *TaskTimeLogHandler) GetTimeSpent(c *gin.Context) {
+	query, ok := parseTimeSpentQuery(c)
+	if !ok {
+		return
+	}
+
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rows, err := h.Controller.GetTimeSpent(actor, query)
+	if err != nil {
+		respondTaskTimeLogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rows)
+}
+
+// parseTimeLogTaskRequest разбирает пользователя и ID задачи; при ошибке сам отвечает клиенту
+func parseTimeLogTaskRequest(c *gin.Context) (*dto.Actor, int, bool) {
+	actor, err := getActor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, 0, false
+	}
+
+	taskID, err := strconv.Atoi(c.Param("task_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return nil, 0, false
+	}
+	return actor, taskID, true
+}
+
+func parseTimeLogID(c *gin.Context) (int, bool) {
+	logID, err := strconv.Atoi(c.Param("log_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid time log ID"})
+		return 0, false
+	}
+	return logID, true
+}
+
+var timeSpentGroups = []string{dto.TimeSpentByTask, dto.TimeSpentByUser, dto.TimeSpentByChat}
+
+// parseTimeSpentQuery разбирает параметры отчёта; при ошибке сам отвечает клиенту
+func parseTimeSpentQuery(c *gin.Context) (*dto.TimeSpentQuery, bool) {
+	query := &dto.TimeSpentQuery{GroupBy: c.DefaultQuery("group_by", dto.TimeSpentByTask)}
+	if !slices.Contains(timeSpentGroups, query.GroupBy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group_by"})
+		return nil, false
+	}
+
+	for _, bound := range []struct {
+		param  string
+		target **time.Time
+	}{{"from", &query.From}, {"to", &query.To}} {
+		raw := c.Query(bound.param)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + bound.param})
+			return nil, false
+		}
+		*bound.target = &parsed
+	}
+
+	if raw := c.Query("task_id"); raw != "" {
+		taskID, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task_id"})
+			return nil, false
+		}
+		query.TaskID = &taskID
+	}
+
+	for _, param := range []struct {
+		name   string
+		target **uuid.UUID
+	}{{"user_id", &query.UserID}, {"chat_id", &query.ChatID}} {
+		raw := c.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param.name})
+			return nil, false
+		}
+		*param.target = &parsed
+	}
+	return query, true
+}
+
+func respondTaskTimeLogError(c *gin.Context, err error) {
+	var taskErr *custom_errors.TaskNotFoundError
+	var logErr *custom_errors.TaskTimeLogNotFoundError
+	var notRunningErr *custom_errors.TaskTimerNotRunningError
+	var accessErr *custom_errors.TaskAccessDeniedError
+	var logAccessErr *custom_errors.TaskTimeLogAccessDeniedError
+	var invalidErr *custom_errors.InvalidTaskTimeLogError
+	var runningErr *custom_errors.TaskTimerAlreadyRunningError
+	var chatsErr *custom_errors.GetUserChatsHTTPError
+
+	switch {
+	case errors.As(err, &taskErr),
+		errors.As(err, &logErr),
+		errors.As(err, &notRunningErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &accessErr),
+		errors.As(err, &logAccessErr):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &invalidErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.As(err, &runningErr):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.As(err, &chatsErr):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
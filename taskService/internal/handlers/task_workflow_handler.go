@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+)
+
+type TaskWorkflowHandler struct {
+	Controller controllers.TaskWorkflowControllerInterface
+}
+
+func NewTaskWorkflowHandler(controller controllers.TaskWorkflowControllerInterface) *TaskWorkflowHandler {
+	return &TaskWorkflowHandler{Controller: controller}
+}
+
+// Create Создание workflow задач
+// @Summary Создать workflow
+// @Description Создает workflow: упорядоченный набор статусов и разрешённых переходов между ними с ограничением по роли (creator, executor, any)
+// @Tags task-workflows
+// @Accept json
+// @Produce json
+// @Param workflow body dto.SaveWorkflowDTO true "Описание workflow"
+// @Success 201 {object} models.TaskWorkflow "Workflow успешно создан"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или workflow"
+// @Failure 409 {object} map[string]interface{} "Workflow с таким названием уже существует"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/workflows [post]
+func (h *TaskWorkflowHandler) Create(c *gin.Context) {
+	var workflowDTO dto.SaveWorkflowDTO
+	if err := c.ShouldBindJSON(&workflowDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	workflow, err := h.Controller.Create(&workflowDTO)
+	if err != nil {
+		respondWorkflowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, workflow)
+}
+
+// Update Замена workflow задач
+// @Summary Обновить workflow
+// @Description Полностью заменяет название, статусы и переходы workflow
+// @Tags task-workflows
+// @Accept json
+// @Produce json
+// @Param workflow_id path int true "ID workflow"
+// @Param workflow body dto.SaveWorkflowDTO true "Описание workflow"
+// @Success 200 {object} models.TaskWorkflow "Workflow успешно обновлен"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос или workflow"
+// @Failure 404 {object} map[string]interface{} "Workflow не найден"
+// @Failure 409 {object} map[string]interface{} "Workflow с таким названием уже существует"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/workflows/{workflow_id} [put]
+func (h *TaskWorkflowHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("workflow_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid workflow ID"})
+		return
+	}
+
+	var workflowDTO dto.SaveWorkflowDTO
+	if err := c.ShouldBindJSON(&workflowDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	workflow, err := h.Controller.Update(id, &workflowDTO)
+	if err != nil {
+		respondWorkflowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workflow)
+}
+
+// GetByID Получение workflow по ID
+// @Summary Получить workflow по ID
+// @Description Возвращает workflow со статусами в заданном порядке и переходами
+// @Tags task-workflows
+// @Produce json
+// @Param workflow_id path int true "ID workflow"
+// @Success 200 {object} models.TaskWorkflow "Информация о workflow"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID"
+// @Failure 404 {object} map[string]interface{} "Workflow не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/workflows/{workflow_id} [get]
+func (h *TaskWorkflowHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("workflow_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid workflow ID"})
+		return
+	}
+
+	workflow, err := h.Controller.GetByID(id)
+	if err != nil {
+		respondWorkflowError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workflow)
+}
+
+// GetAll Получение всех workflow
+// @Summary Получить все workflow
+// @Description Возвращает список всех workflow задач
+// @Tags task-workflows
+// @Produce json
+// @Success 200 {array} models.TaskWorkflow "Список workflow"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/workflows [get]
+func (h *TaskWorkflowHandler) GetAll(c *gin.Context) {
+	workflows, err := h.Controller.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not get workflows"})
+		return
+	}
+
+	c.JSON(http.StatusOK, workflows)
+}
+
+// DeleteByID Удаление workflow
+// @Summary Удалить workflow
+// @Description Удаляет workflow; задачи, привязанные к нему, переходят на workflow по умолчанию
+// @Tags task-workflows
+// @Produce json
+// @Param workflow_id path int true "ID workflow"
+// @Success 204 "Workflow успешно удален"
+// @Failure 400 {object} map[string]interface{} "Некорректный ID"
+// @Failure 404 {object} map[string]interface{} "Workflow не найден"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /tasks/workflows/{workflow_id} [delete]
+func (h *TaskWorkflowHandler) DeleteByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("workflow_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid workflow ID"})
+		return
+	}
+
+	if err := h.Controller.DeleteByID(id); err != nil {
+		respondWorkflowError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondWorkflowError(c *gin.Context, err error) {
+	var notFoundErr *custom_errors.WorkflowNotFoundError
+	var invalidErr *custom_errors.InvalidWorkflowError
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &invalidErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, custom_errors.ErrWorkflowAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
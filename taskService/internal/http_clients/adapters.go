@@ -19,7 +19,15 @@ func (a *UserClientAdapter) GetUserByID(userID *uuid.UUID) (*cuc.Response, error
 	return commonHttpClients.GetUserByID(userID)
 }
 
-// ChatClientAdapter - адаптер для common/http_clients.GetChatByID
+func (a *UserClientAdapter) GetUsersByIDs(userIDs []uuid.UUID) (*cuc.UsersResponse, error) {
+	return commonHttpClients.GetUsersByIDs(userIDs)
+}
+
+func (a *UserClientAdapter) GetUsersByUsernames(usernames []string) (*cuc.UsersResponse, error) {
+	return commonHttpClients.GetUsersByUsernames(usernames)
+}
+
+// ChatClientAdapter - адаптер для функций чат-сервиса из common/http_clients
 type ChatClientAdapter struct{}
 
 func NewChatClientAdapter() ChatClientInterface {
@@ -30,6 +38,18 @@ func (a *ChatClientAdapter) GetChatByID(chatID string) (*cc.Chat, error) {
 	return commonHttpClients.GetChatByID(chatID)
 }
 
+func (a *ChatClientAdapter) GetMessageByID(messageID, requesterID uuid.UUID) (*cc.Message, error) {
+	return commonHttpClients.GetMessageByID(messageID.String(), requesterID.String())
+}
+
+func (a *ChatClientAdapter) CreateSystemMessage(chatID uuid.UUID, request *cc.CreateSystemMessageRequest) error {
+	return commonHttpClients.CreateSystemMessage(chatID.String(), request)
+}
+
+func (a *ChatClientAdapter) GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	return commonHttpClients.GetUserChatIDs(userID.String())
+}
+
 // FileClientAdapter - адаптер для common/http_clients.GetFileByID
 type FileClientAdapter struct{}
 
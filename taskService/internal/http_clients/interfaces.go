@@ -10,11 +10,16 @@ import (
 // UserClientInterface - интерфейс для HTTP клиента пользовательского сервиса для возможности мокирования
 type UserClientInterface interface {
 	GetUserByID(userID *uuid.UUID) (*cuc.Response, error)
+	GetUsersByIDs(userIDs []uuid.UUID) (*cuc.UsersResponse, error)
+	GetUsersByUsernames(usernames []string) (*cuc.UsersResponse, error)
 }
 
 // ChatClientInterface - интерфейс для HTTP клиента чат-сервиса для возможности мокирования
 type ChatClientInterface interface {
 	GetChatByID(chatID string) (*cc.Chat, error)
+	GetMessageByID(messageID, requesterID uuid.UUID) (*cc.Message, error)
+	CreateSystemMessage(chatID uuid.UUID, request *cc.CreateSystemMessageRequest) error
+	GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error)
 }
 
 // FileClientInterface - интерфейс для HTTP клиента файлового сервиса для возможности мокирования
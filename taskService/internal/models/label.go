@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Label - метка задач, общая для всего workspace
+type Label struct {
+	ID        int       `gorm:"primaryKey;autoIncrement"`
+	Name      string    `gorm:"size:50;not null;unique"`
+	Color     string    `gorm:"size:9;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (Label) TableName() string {
+	return "task_service.labels"
+}
+
+// TaskLabel - назначение метки задаче
+type TaskLabel struct {
+	TaskID    int       `gorm:"primaryKey"`
+	LabelID   int       `gorm:"primaryKey"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (TaskLabel) TableName() string {
+	return "task_service.task_labels"
+}
@@ -2,23 +2,68 @@ package models
 
 import (
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 	"time"
 )
 
+// Приоритеты задачи в порядке возрастания важности
+const (
+	TaskPriorityLow    = "low"
+	TaskPriorityNormal = "normal"
+	TaskPriorityHigh   = "high"
+	TaskPriorityUrgent = "urgent"
+)
+
 type Task struct {
-	ID          int       `gorm:"primaryKey;autoIncrement"`
-	Title       string    `gorm:"size:255;not null"`
-	Description string    `gorm:"type:text"`
-	StatusID    int       `gorm:"column:status"`
-	CreatorID   uuid.UUID `gorm:"type:uuid"`
-	ExecutorID  uuid.UUID `gorm:"type:uuid"`
-	ChatID      uuid.UUID `gorm:"type:uuid"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-
-	Status *TaskStatus `gorm:"foreignKey:StatusID"`
-	Files  []TaskFile  `gorm:"foreignKey:TaskID"`
+	ID           int       `gorm:"primaryKey;autoIncrement"`
+	Title        string    `gorm:"size:255;not null"`
+	Description  string    `gorm:"type:text"`
+	StatusID     int       `gorm:"column:status"`
+	CreatorID    uuid.UUID `gorm:"type:uuid"`
+	ExecutorID   uuid.UUID `gorm:"type:uuid"`
+	ChatID       uuid.UUID `gorm:"type:uuid"`
+	WorkflowID   *int
+	ParentTaskID *int
+	Priority     string `gorm:"size:10;not null;default:normal"`
+	// EstimateMinutes - оценка трудозатрат; фактически затраченное время хранится в TaskTimeLog
+	EstimateMinutes *int
+	// Rank - позиция карточки в колонке доски своего статуса, см. RankBetween
+	Rank      string `gorm:"size:255;not null"`
+	StartAt   *time.Time
+	DueAt     *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt *time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	DueSoonNotifiedAt *time.Time `json:"-"`
+	OverdueNotifiedAt *time.Time `json:"-"`
+
+	// RecurrenceID - серия, экземпляром которой является задача; OccurrenceAt - плановое начало экземпляра.
+	// Detached - экземпляр изменён отдельно, и изменение или удаление серии его не затрагивают
+	RecurrenceID *int
+	OccurrenceAt *time.Time
+	Detached     bool `gorm:"not null;default:false"`
+
+	Status    *TaskStatus         `gorm:"foreignKey:StatusID"`
+	Files     []TaskFile          `gorm:"foreignKey:TaskID"`
+	Assignees []TaskAssignee      `gorm:"foreignKey:TaskID"`
+	Watchers  []TaskWatcher       `gorm:"foreignKey:TaskID"`
+	Checklist []TaskChecklistItem `gorm:"foreignKey:TaskID"`
 }
 
 func (Task) TableName() string {
 	return "task_service.tasks"
 }
+
+// IsExecutor сообщает, является ли пользователь исполнителем или соисполнителем задачи
+func (t *Task) IsExecutor(userID uuid.UUID) bool {
+	if t.ExecutorID == userID {
+		return true
+	}
+	for _, assignee := range t.Assignees {
+		if assignee.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TaskChecklistItem - пункт чек-листа задачи. DoneBy и DoneAt заполнены у отмеченных пунктов
+type TaskChecklistItem struct {
+	ID        int    `gorm:"primaryKey;autoIncrement"`
+	TaskID    int    `gorm:"not null"`
+	Title     string `gorm:"size:255;not null"`
+	Position  int    `gorm:"not null"`
+	Done      bool   `gorm:"not null"`
+	DoneBy    *uuid.UUID
+	DoneAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt *time.Time
+}
+
+func (TaskChecklistItem) TableName() string {
+	return "task_service.task_checklist_items"
+}
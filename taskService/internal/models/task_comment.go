@@ -0,0 +1,43 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// TaskComment - комментарий к задаче. Mentions хранит пользователей, упомянутых в тексте через @username
+type TaskComment struct {
+	ID        int       `gorm:"primaryKey;autoIncrement"`
+	TaskID    int       `gorm:"not null"`
+	AuthorID  uuid.UUID `gorm:"type:uuid;not null"`
+	Body      string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt *time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Files    []TaskCommentFile    `gorm:"foreignKey:CommentID"`
+	Mentions []TaskCommentMention `gorm:"foreignKey:CommentID"`
+}
+
+func (TaskComment) TableName() string {
+	return "task_service.task_comments"
+}
+
+type TaskCommentFile struct {
+	CommentID int `gorm:"primaryKey"`
+	FileID    int `gorm:"primaryKey"`
+}
+
+func (TaskCommentFile) TableName() string {
+	return "task_service.task_comment_files"
+}
+
+type TaskCommentMention struct {
+	CommentID int       `gorm:"primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+}
+
+func (TaskCommentMention) TableName() string {
+	return "task_service.task_comment_mentions"
+}
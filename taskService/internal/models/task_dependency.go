@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// TaskDependency - связь "BlockerTaskID блокирует BlockedTaskID"
+type TaskDependency struct {
+	BlockerTaskID int       `gorm:"primaryKey"`
+	BlockedTaskID int       `gorm:"primaryKey"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+func (TaskDependency) TableName() string {
+	return "task_service.task_dependencies"
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Типы событий в истории задачи
+const (
+	TaskEventCreated           = "created"
+	TaskEventStatusChanged     = "status_changed"
+	TaskEventReassigned        = "reassigned"
+	TaskEventEdited            = "edited"
+	TaskEventAttachmentAdded   = "attachment_added"
+	TaskEventCommented         = "commented"
+	TaskEventBlockerAdded      = "blocker_added"
+	TaskEventBlockerRemoved    = "blocker_removed"
+	TaskEventLabelAdded        = "label_added"
+	TaskEventLabelRemoved      = "label_removed"
+	TaskEventAssigneeAdded     = "assignee_added"
+	TaskEventAssigneeRemoved   = "assignee_removed"
+	TaskEventChecklistAdded    = "checklist_item_added"
+	TaskEventChecklistDone     = "checklist_item_done"
+	TaskEventChecklistReopened = "checklist_item_reopened"
+	TaskEventChecklistRemoved  = "checklist_item_removed"
+)
+
+// TaskEvent - запись в истории задачи. Field заполняется для edited (title, description, chat_id, parent_task_id),
+// OldValue и NewValue хранят значения до и после изменения в текстовом виде
+type TaskEvent struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement"`
+	TaskID    int       `gorm:"not null"`
+	ActorID   uuid.UUID `gorm:"type:uuid;not null"`
+	EventType string    `gorm:"size:30;not null"`
+	Field     *string   `gorm:"size:50"`
+	OldValue  *string   `gorm:"type:text"`
+	NewValue  *string   `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (TaskEvent) TableName() string {
+	return "task_service.task_events"
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TaskAssignee - соисполнитель задачи в дополнение к основному исполнителю Task.ExecutorID
+type TaskAssignee struct {
+	TaskID    int       `gorm:"primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (TaskAssignee) TableName() string {
+	return "task_service.task_assignees"
+}
+
+// TaskWatcher - наблюдатель задачи: получает уведомления о смене статуса и комментариях
+type TaskWatcher struct {
+	TaskID    int       `gorm:"primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (TaskWatcher) TableName() string {
+	return "task_service.task_watchers"
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rankAlphabet - цифры ранга в порядке возрастания; порядок совпадает с побайтовым сравнением строк
+const rankAlphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// RankBetween возвращает ранг строго между prev и next. Пустой prev означает начало колонки, пустой next -
+// её конец. Результат никогда не заканчивается на "0", поэтому перед любым рангом всегда остаётся место
+func RankBetween(prev, next string) (string, error) {
+	if next != "" && prev >= next {
+		return "", fmt.Errorf("rank %q is not before %q", prev, next)
+	}
+	if err := validateRank(prev); err != nil {
+		return "", err
+	}
+	if err := validateRank(next); err != nil {
+		return "", err
+	}
+
+	base := len(rankAlphabet)
+	bounded := next != ""
+	var rank strings.Builder
+	for i := 0; ; i++ {
+		lo := 0
+		if i < len(prev) {
+			lo = strings.IndexByte(rankAlphabet, prev[i])
+		}
+		hi := base
+		if bounded {
+			// next закончился на общем префиксе: между ними нет рангов без "0" в конце
+			if i >= len(next) {
+				return "", fmt.Errorf("no rank between %q and %q", prev, next)
+			}
+			hi = strings.IndexByte(rankAlphabet, next[i])
+		}
+
+		switch {
+		case hi-lo > 1:
+			rank.WriteByte(rankAlphabet[(lo+hi)/2])
+			return rank.String(), nil
+		case hi-lo == 1:
+			// Соседние цифры: берём меньшую, дальше ранг ограничен только снизу
+			rank.WriteByte(rankAlphabet[lo])
+			bounded = false
+		default:
+			rank.WriteByte(rankAlphabet[lo])
+		}
+	}
+}
+
+func validateRank(rank string) error {
+	for i := 0; i < len(rank); i++ {
+		if strings.IndexByte(rankAlphabet, rank[i]) < 0 {
+			return fmt.Errorf("invalid rank %q", rank)
+		}
+	}
+	return nil
+}
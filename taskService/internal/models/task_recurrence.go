@@ -0,0 +1,267 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Частоты правила повторения (FREQ)
+const (
+	RecurrenceDaily   = "DAILY"
+	RecurrenceWeekly  = "WEEKLY"
+	RecurrenceMonthly = "MONTHLY"
+)
+
+// maxIdleRecurrencePeriods - сколько периодов подряд без экземпляров допускается при обходе правила;
+// например, "31-е число каждые 12 месяцев" с началом в феврале не даёт ни одного экземпляра
+const maxIdleRecurrencePeriods = 1000
+
+// TaskRecurrence - серия повторяющихся задач: шаблон задачи и правило повторения. Планировщик заранее
+// создаёт по шаблону экземпляры (задачи с RecurrenceID) до NextOccurrenceAt включительно и сдвигает его вперёд
+type TaskRecurrence struct {
+	ID          int       `gorm:"primaryKey;autoIncrement"`
+	Title       string    `gorm:"size:255;not null"`
+	Description string    `gorm:"type:text"`
+	CreatorID   uuid.UUID `gorm:"type:uuid;not null"`
+	ExecutorID  uuid.UUID `gorm:"type:uuid;not null"`
+	ChatID      uuid.UUID `gorm:"type:uuid"`
+	WorkflowID  *int
+	Priority    string `gorm:"size:10;not null;default:normal"`
+	// Rule - правило повторения в формате RRULE, см. ParseRecurrenceRule
+	Rule string `gorm:"size:255;not null"`
+	// StartsAt - начало серии; время суток задаёт время начала каждого экземпляра
+	StartsAt time.Time `gorm:"not null"`
+	// DueAfterMinutes - срок экземпляра в минутах после его начала; nil - экземпляры без срока
+	DueAfterMinutes *int
+	// NextOccurrenceAt - начало следующего ещё не созданного экземпляра; nil - серия закончилась
+	NextOccurrenceAt *time.Time
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+	UpdatedAt        *time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (TaskRecurrence) TableName() string {
+	return "task_service.task_recurrences"
+}
+
+// NewOccurrence возвращает экземпляр серии, который начинается в occurrenceAt
+func (r *TaskRecurrence) NewOccurrence(occurrenceAt time.Time, statusID int) Task {
+	task := Task{
+		Title:        r.Title,
+		Description:  r.Description,
+		StatusID:     statusID,
+		CreatorID:    r.CreatorID,
+		ExecutorID:   r.ExecutorID,
+		ChatID:       r.ChatID,
+		WorkflowID:   r.WorkflowID,
+		Priority:     r.Priority,
+		RecurrenceID: &r.ID,
+		OccurrenceAt: &occurrenceAt,
+		StartAt:      &occurrenceAt,
+	}
+	if r.DueAfterMinutes != nil {
+		dueAt := occurrenceAt.Add(time.Duration(*r.DueAfterMinutes) * time.Minute)
+		task.DueAt = &dueAt
+	}
+	return task
+}
+
+// RecurrenceRule - поддерживаемое подмножество RRULE (RFC 5545): FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL,
+// BYDAY (только для WEEKLY, без номеров недель), BYMONTHDAY (только для MONTHLY, 1-31), COUNT и UNTIL
+type RecurrenceRule struct {
+	Frequency string
+	Interval  int
+	Weekdays  []time.Weekday
+	MonthDay  int
+	Count     int
+	Until     *time.Time
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// ParseRecurrenceRule разбирает правило вида "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;UNTIL=20261231T000000Z".
+// Префикс "RRULE:" допускается; UNTIL задаётся как дата (20261231) или время UTC (20261231T000000Z)
+func ParseRecurrenceRule(rule string) (*RecurrenceRule, error) {
+	parsed := &RecurrenceRule{Interval: 1}
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate rule part %s", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			if value != RecurrenceDaily && value != RecurrenceWeekly && value != RecurrenceMonthly {
+				return nil, fmt.Errorf("unsupported FREQ %s", value)
+			}
+			parsed.Frequency = value
+		case "INTERVAL":
+			parsed.Interval, err = parseRulePositive(key, value, 1000)
+		case "COUNT":
+			parsed.Count, err = parseRulePositive(key, value, 10000)
+		case "BYMONTHDAY":
+			parsed.MonthDay, err = parseRulePositive(key, value, 31)
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY %s", day)
+				}
+				if !slices.Contains(parsed.Weekdays, weekday) {
+					parsed.Weekdays = append(parsed.Weekdays, weekday)
+				}
+			}
+		case "UNTIL":
+			until, err := parseRuleUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			parsed.Until = &until
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case parsed.Frequency == "":
+		return nil, fmt.Errorf("FREQ is required")
+	case len(parsed.Weekdays) > 0 && parsed.Frequency != RecurrenceWeekly:
+		return nil, fmt.Errorf("BYDAY is supported only with FREQ=WEEKLY")
+	case parsed.MonthDay > 0 && parsed.Frequency != RecurrenceMonthly:
+		return nil, fmt.Errorf("BYMONTHDAY is supported only with FREQ=MONTHLY")
+	case parsed.Count > 0 && parsed.Until != nil:
+		return nil, fmt.Errorf("COUNT and UNTIL can't be used together")
+	}
+	return parsed, nil
+}
+
+func parseRulePositive(key, value string, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 || n > max {
+		return 0, fmt.Errorf("%s must be between 1 and %d", key, max)
+	}
+	return n, nil
+}
+
+func parseRuleUntil(value string) (time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+	until, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid UNTIL %s", value)
+	}
+	// Дата без времени включает весь день
+	return until.Add(24*time.Hour - time.Nanosecond), nil
+}
+
+// Next возвращает первый экземпляр серии, начинающейся в startsAt, не раньше from; false - экземпляров больше нет
+func (r *RecurrenceRule) Next(startsAt, from time.Time) (time.Time, bool) {
+	occurrences := r.Between(startsAt, from, time.Time{}, 1)
+	if len(occurrences) == 0 {
+		return time.Time{}, false
+	}
+	return occurrences[0], true
+}
+
+// Between возвращает до limit экземпляров серии в промежутке [from, to]; нулевой to - без верхней границы
+func (r *RecurrenceRule) Between(startsAt, from, to time.Time, limit int) []time.Time {
+	var occurrences []time.Time
+	r.iterate(startsAt, func(occurrence time.Time) bool {
+		if !to.IsZero() && occurrence.After(to) {
+			return false
+		}
+		if !occurrence.Before(from) {
+			occurrences = append(occurrences, occurrence)
+		}
+		return len(occurrences) < limit
+	})
+	return occurrences
+}
+
+// iterate перебирает экземпляры серии по возрастанию, пока yield возвращает true, с учётом COUNT и UNTIL
+func (r *RecurrenceRule) iterate(startsAt time.Time, yield func(time.Time) bool) {
+	emitted := 0
+	emit := func(occurrence time.Time) bool {
+		if occurrence.Before(startsAt) {
+			return true
+		}
+		if r.Until != nil && occurrence.After(*r.Until) {
+			return false
+		}
+		emitted++
+		return yield(occurrence) && (r.Count == 0 || emitted < r.Count)
+	}
+
+	year, month, day := startsAt.Date()
+	hour, minute, second := startsAt.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, startsAt.Nanosecond(), startsAt.Location())
+	}
+
+	switch r.Frequency {
+	case RecurrenceDaily:
+		for period := 0; ; period++ {
+			if !emit(at(year, month, day+period*r.Interval)) {
+				return
+			}
+		}
+	case RecurrenceWeekly:
+		weekdays := r.Weekdays
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{startsAt.Weekday()}
+		}
+		// Неделя начинается с понедельника (WKST=MO)
+		offsets := make([]int, 0, len(weekdays))
+		for _, weekday := range weekdays {
+			offsets = append(offsets, (int(weekday)+6)%7)
+		}
+		slices.Sort(offsets)
+		monday := day - (int(startsAt.Weekday())+6)%7
+		for period := 0; ; period++ {
+			for _, offset := range offsets {
+				if !emit(at(year, month, monday+period*7*r.Interval+offset)) {
+					return
+				}
+			}
+		}
+	case RecurrenceMonthly:
+		monthDay := r.MonthDay
+		if monthDay == 0 {
+			monthDay = day
+		}
+		idle := 0
+		for period := 0; idle < maxIdleRecurrencePeriods; period++ {
+			// В месяцах без такого числа экземпляра нет, как и в RRULE
+			occurrence := at(year, month+time.Month(period*r.Interval), monthDay)
+			if occurrence.Day() != monthDay {
+				idle++
+				continue
+			}
+			idle = 0
+			if !emit(occurrence) {
+				return
+			}
+		}
+	}
+}
@@ -1,5 +1,8 @@
 package models
 
+// TaskStatusCreated - название стартового статуса задач без workflow
+const TaskStatusCreated = "created"
+
 type TaskStatus struct {
 	ID   int    `gorm:"primaryKey;autoIncrement"`
 	Name string `gorm:"size:50;not null;unique"`
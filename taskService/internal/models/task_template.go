@@ -0,0 +1,97 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TemplateDateVariable - подстановка, которая без переданного значения заменяется текущей датой
+const TemplateDateVariable = "date"
+
+// templatePlaceholder - подстановка {name} в названии шаблона
+var templatePlaceholder = regexp.MustCompile(`\{([a-z][a-z0-9_]*)\}`)
+
+// TaskTemplate - шаблон задачи: название с подстановками, описание, приоритет, исполнитель по умолчанию
+// (nil - без исполнителя), метки и пункты чек-листа, которые получает созданная по шаблону задача
+type TaskTemplate struct {
+	ID           int       `gorm:"primaryKey;autoIncrement"`
+	Name         string    `gorm:"size:100;not null"`
+	TitlePattern string    `gorm:"size:255;not null"`
+	Description  string    `gorm:"type:text;not null"`
+	CreatorID    uuid.UUID `gorm:"type:uuid;not null"`
+	ExecutorID   *uuid.UUID
+	Priority     string    `gorm:"size:10;not null;default:normal"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    *time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Labels         []TaskTemplateLabel         `gorm:"foreignKey:TemplateID"`
+	ChecklistItems []TaskTemplateChecklistItem `gorm:"foreignKey:TemplateID"`
+}
+
+func (TaskTemplate) TableName() string {
+	return "task_service.task_templates"
+}
+
+// TaskTemplateLabel - метка шаблона
+type TaskTemplateLabel struct {
+	TemplateID int    `gorm:"primaryKey" json:"-"`
+	LabelID    int    `gorm:"primaryKey"`
+	Label      *Label `gorm:"foreignKey:LabelID"`
+}
+
+func (TaskTemplateLabel) TableName() string {
+	return "task_service.task_template_labels"
+}
+
+// TaskTemplateChecklistItem - пункт чек-листа шаблона
+type TaskTemplateChecklistItem struct {
+	ID         int    `gorm:"primaryKey;autoIncrement"`
+	TemplateID int    `gorm:"not null" json:"-"`
+	Title      string `gorm:"size:255;not null"`
+	Position   int    `gorm:"not null"`
+}
+
+func (TaskTemplateChecklistItem) TableName() string {
+	return "task_service.task_template_checklist_items"
+}
+
+// TemplateVariables возвращает имена подстановок названия шаблона в порядке появления, без повторов
+func TemplateVariables(pattern string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range templatePlaceholder.FindAllStringSubmatch(pattern, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// RenderTitle подставляет variables в название шаблона; {date} без значения заменяется датой now.
+// Подстановка без значения - ошибка
+func (t *TaskTemplate) RenderTitle(variables map[string]string, now time.Time) (string, error) {
+	var missing string
+	title := templatePlaceholder.ReplaceAllStringFunc(t.TitlePattern, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		if value, ok := variables[name]; ok {
+			return value
+		}
+		if name == TemplateDateVariable {
+			return now.Format("2006-01-02")
+		}
+		if missing == "" {
+			missing = name
+		}
+		return placeholder
+	})
+	if missing != "" {
+		return "", fmt.Errorf("no value for {%s}", missing)
+	}
+	return title, nil
+}
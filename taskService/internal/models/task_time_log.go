@@ -0,0 +1,29 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// TaskTimeLog - затраченное на задачу время. EndedAt == nil у запущенного таймера: Minutes у него 0,
+// пока таймер не остановлен
+type TaskTimeLog struct {
+	ID          int       `gorm:"primaryKey;autoIncrement"`
+	TaskID      int       `gorm:"not null"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null"`
+	StartedAt   time.Time `gorm:"not null"`
+	EndedAt     *time.Time
+	Minutes     int       `gorm:"not null"`
+	Description string    `gorm:"type:text;not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   *time.Time
+}
+
+func (TaskTimeLog) TableName() string {
+	return "task_service.task_time_logs"
+}
+
+// IsRunning сообщает, что запись - ещё не остановленный таймер
+func (l *TaskTimeLog) IsRunning() bool {
+	return l.EndedAt == nil
+}
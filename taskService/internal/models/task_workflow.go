@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Роли участников задачи, которым разрешён переход между статусами
+const (
+	TransitionRoleCreator  = "creator"
+	TransitionRoleExecutor = "executor"
+	TransitionRoleAny      = "any"
+)
+
+type TaskWorkflow struct {
+	ID        int       `gorm:"primaryKey;autoIncrement"`
+	Name      string    `gorm:"size:100;not null;unique"`
+	IsDefault bool      `gorm:"not null;default:false"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+
+	Statuses    []TaskWorkflowStatus     `gorm:"foreignKey:WorkflowID"`
+	Transitions []TaskWorkflowTransition `gorm:"foreignKey:WorkflowID"`
+}
+
+func (TaskWorkflow) TableName() string {
+	return "task_service.task_workflows"
+}
+
+type TaskWorkflowStatus struct {
+	WorkflowID int `gorm:"primaryKey"`
+	StatusID   int `gorm:"primaryKey"`
+	Position   int `gorm:"not null"`
+
+	Status *TaskStatus `gorm:"foreignKey:StatusID"`
+}
+
+func (TaskWorkflowStatus) TableName() string {
+	return "task_service.task_workflow_statuses"
+}
+
+type TaskWorkflowTransition struct {
+	ID           int    `gorm:"primaryKey;autoIncrement"`
+	WorkflowID   int    `gorm:"not null"`
+	FromStatusID int    `gorm:"not null"`
+	ToStatusID   int    `gorm:"not null"`
+	AllowedRole  string `gorm:"size:20;not null"`
+}
+
+func (TaskWorkflowTransition) TableName() string {
+	return "task_service.task_workflow_transitions"
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+// labelUsageColumns выбирает метку с числом неудалённых задач, которым она назначена
+const labelUsageColumns = `l.id, l.name, l.color, l.created_at,
+	(SELECT COUNT(*) FROM task_service.task_labels tl
+		JOIN task_service.tasks t ON t.id = tl.task_id AND t.deleted_at IS NULL
+		WHERE tl.label_id = l.id) AS usage_count`
+
+type LabelRepository interface {
+	Create(label *models.Label) error
+	Update(label *models.Label) error
+	GetByID(id int) (*dto.LabelResponse, error)
+	GetByName(name string) (*models.Label, error)
+	GetAll() ([]dto.LabelResponse, error)
+	DeleteByID(id int) error
+	// AddToTask назначает метку задаче; false означает, что метка уже была назначена
+	AddToTask(taskID, labelID int) (bool, error)
+	RemoveFromTask(taskID, labelID int) error
+	GetByTaskID(taskID int) ([]models.Label, error)
+}
+
+type labelRepository struct {
+	db *gorm.DB
+}
+
+func NewLabelRepository(db *gorm.DB) LabelRepository {
+	return &labelRepository{db: db}
+}
+
+func (r *labelRepository) Create(label *models.Label) error {
+	return r.db.Create(label).Error
+}
+
+// Update заменяет название и цвет метки
+func (r *labelRepository) Update(label *models.Label) error {
+	result := r.db.Model(&models.Label{ID: label.ID}).
+		Select("Name", "Color").
+		Updates(label)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *labelRepository) GetByID(id int) (*dto.LabelResponse, error) {
+	var label dto.LabelResponse
+	err := r.db.
+		Table("task_service.labels AS l").
+		Select(labelUsageColumns).
+		Where("l.id = ?", id).
+		Take(&label).Error
+	if err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+func (r *labelRepository) GetByName(name string) (*models.Label, error) {
+	var label models.Label
+	if err := r.db.Where("name = ?", name).First(&label).Error; err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+func (r *labelRepository) GetAll() ([]dto.LabelResponse, error) {
+	labels := []dto.LabelResponse{}
+	err := r.db.
+		Table("task_service.labels AS l").
+		Select(labelUsageColumns).
+		Order("l.name").
+		Scan(&labels).Error
+	return labels, err
+}
+
+// DeleteByID удаляет метку; назначения задачам удаляются каскадно
+func (r *labelRepository) DeleteByID(id int) error {
+	result := r.db.Delete(&models.Label{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *labelRepository) AddToTask(taskID, labelID int) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.TaskLabel{TaskID: taskID, LabelID: labelID})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *labelRepository) RemoveFromTask(taskID, labelID int) error {
+	result := r.db.
+		Where("task_id = ? AND label_id = ?", taskID, labelID).
+		Delete(&models.TaskLabel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return custom_errors.NewTaskLabelNotFoundError(taskID, labelID)
+	}
+	return nil
+}
+
+// GetByTaskID возвращает метки задачи по названию
+func (r *labelRepository) GetByTaskID(taskID int) ([]models.Label, error) {
+	labels := []models.Label{}
+	err := r.db.
+		Table("task_service.labels AS l").
+		Select("l.*").
+		Joins("JOIN task_service.task_labels tl ON tl.label_id = l.id").
+		Where("tl.task_id = ?", taskID).
+		Order("l.name").
+		Find(&labels).Error
+	return labels, err
+}
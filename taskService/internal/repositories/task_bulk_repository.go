@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+	"time"
+)
+
+type TaskBulkRepository interface {
+	// Apply сохраняет изменения задач и их события в одной транзакции: ошибка любого изменения
+	// отменяет все и возвращается как TaskBulkChangeError. Смена статуса сохраняется, только если задача
+	// всё ещё в статусе FromStatusID, иначе возвращается TaskStatusChangedError
+	Apply(changes []dto.TaskBulkChange) error
+}
+
+type taskBulkRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskBulkRepository(db *gorm.DB) TaskBulkRepository {
+	return &taskBulkRepository{db: db}
+}
+
+func (r *taskBulkRepository) Apply(changes []dto.TaskBulkChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		for _, change := range changes {
+			if err := applyTaskChange(tx, change, now); err != nil {
+				return custom_errors.NewTaskBulkChangeError(change.TaskID, err)
+			}
+		}
+		return nil
+	})
+}
+
+func applyTaskChange(tx *gorm.DB, change dto.TaskBulkChange, now time.Time) error {
+	// Массовое изменение - тоже правка экземпляра серии: серия больше его не меняет
+	if err := NewTaskRepository(tx).Detach(change.TaskID); err != nil {
+		return err
+	}
+	if change.Delete {
+		result := tx.Delete(&models.Task{}, change.TaskID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return custom_errors.NewTaskNotFoundError(change.TaskID)
+		}
+		return createEvents(tx, change.Events)
+	}
+
+	updates := map[string]interface{}{}
+	if change.StatusID != nil {
+		updates["status"] = *change.StatusID
+	}
+	if change.ExecutorID != nil {
+		updates["executor_id"] = *change.ExecutorID
+	}
+	if len(updates) > 0 {
+		updates["updated_at"] = now
+		query := tx.Model(&models.Task{}).Where("id = ?", change.TaskID)
+		// Переход проверен для статуса на момент планирования: если задачу успели перевести,
+		// условие не совпадёт и изменение не сохранится
+		if change.StatusID != nil && change.FromStatusID != nil {
+			query = query.Where("status = ?", *change.FromStatusID)
+		}
+		result := query.Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return missingTaskError(tx, change)
+		}
+	}
+
+	if len(change.LabelIDs) > 0 {
+		taskLabels := make([]models.TaskLabel, 0, len(change.LabelIDs))
+		for _, labelID := range change.LabelIDs {
+			taskLabels = append(taskLabels, models.TaskLabel{TaskID: change.TaskID, LabelID: labelID})
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&taskLabels).Error; err != nil {
+			return err
+		}
+	}
+	return createEvents(tx, change.Events)
+}
+
+// missingTaskError объясняет, почему обновление не затронуло задачу: её нет или сменился статус
+func missingTaskError(tx *gorm.DB, change dto.TaskBulkChange) error {
+	if change.FromStatusID != nil {
+		var count int64
+		if err := tx.Model(&models.Task{}).Where("id = ?", change.TaskID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return custom_errors.NewTaskStatusChangedError(change.TaskID, *change.FromStatusID)
+		}
+	}
+	return custom_errors.NewTaskNotFoundError(change.TaskID)
+}
+
+func createEvents(tx *gorm.DB, events []models.TaskEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.Create(&events).Error
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"taskService/internal/custom_errors"
+	"taskService/internal/models"
+)
+
+type TaskChecklistRepository interface {
+	// Create добавляет пункт в конец чек-листа задачи
+	Create(item *models.TaskChecklistItem) error
+	// Update заменяет название и отметку пункта
+	Update(item *models.TaskChecklistItem) error
+	// Reorder выставляет пунктам задачи позиции по порядку itemIDs
+	Reorder(taskID int, itemIDs []int) error
+	GetByID(taskID, itemID int) (*models.TaskChecklistItem, error)
+	GetByTaskID(taskID int) ([]models.TaskChecklistItem, error)
+	Delete(taskID, itemID int) error
+}
+
+type taskChecklistRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskChecklistRepository(db *gorm.DB) TaskChecklistRepository {
+	return &taskChecklistRepository{db: db}
+}
+
+func (r *taskChecklistRepository) Create(item *models.TaskChecklistItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.TaskChecklistItem{}).
+			Select("COALESCE(MAX(position) + 1, 0)").
+			Where("task_id = ?", item.TaskID).
+			Scan(&item.Position).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(item).Error
+	})
+}
+
+func (r *taskChecklistRepository) Update(item *models.TaskChecklistItem) error {
+	result := r.db.Model(&models.TaskChecklistItem{ID: item.ID}).
+		Where("task_id = ?", item.TaskID).
+		Select("Title", "Done", "DoneBy", "DoneAt", "UpdatedAt").
+		Updates(item)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return custom_errors.NewChecklistItemNotFoundError(item.TaskID, item.ID)
+	}
+	return nil
+}
+
+func (r *taskChecklistRepository) Reorder(taskID int, itemIDs []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for position, itemID := range itemIDs {
+			err := tx.Model(&models.TaskChecklistItem{}).
+				Where("id = ? AND task_id = ?", itemID, taskID).
+				Update("position", position).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *taskChecklistRepository) GetByID(taskID, itemID int) (*models.TaskChecklistItem, error) {
+	var item models.TaskChecklistItem
+	if err := r.db.Where("id = ? AND task_id = ?", itemID, taskID).First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetByTaskID возвращает пункты чек-листа задачи по порядку
+func (r *taskChecklistRepository) GetByTaskID(taskID int) ([]models.TaskChecklistItem, error) {
+	items := []models.TaskChecklistItem{}
+	err := r.db.Where("task_id = ?", taskID).Order("position, id").Find(&items).Error
+	return items, err
+}
+
+func (r *taskChecklistRepository) Delete(taskID, itemID int) error {
+	result := r.db.Where("id = ? AND task_id = ?", itemID, taskID).Delete(&models.TaskChecklistItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return custom_errors.NewChecklistItemNotFoundError(taskID, itemID)
+	}
+	return nil
+}
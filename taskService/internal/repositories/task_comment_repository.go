@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"taskService/internal/custom_errors"
+	"taskService/internal/models"
+)
+
+type TaskCommentRepository interface {
+	Create(comment *models.TaskComment) error
+	Update(comment *models.TaskComment, addFiles []models.TaskCommentFile, removeFileIDs []int) error
+	Delete(commentID int) error
+	GetByID(commentID int) (*models.TaskComment, error)
+	GetByTaskID(taskID int, limit, offset int) ([]models.TaskComment, error)
+	GetAuthorIDs(taskID int) ([]uuid.UUID, error)
+}
+
+type taskCommentRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskCommentRepository(db *gorm.DB) TaskCommentRepository {
+	return &taskCommentRepository{db: db}
+}
+
+// Create сохраняет комментарий вместе с вложениями и упоминаниями
+func (r *taskCommentRepository) Create(comment *models.TaskComment) error {
+	return r.db.Create(comment).Error
+}
+
+// Update сохраняет текст комментария, добавляет и удаляет вложения и полностью заменяет упоминания
+func (r *taskCommentRepository) Update(comment *models.TaskComment, addFiles []models.TaskCommentFile, removeFileIDs []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.TaskComment{ID: comment.ID}).
+			Updates(map[string]interface{}{"body": comment.Body, "updated_at": comment.UpdatedAt})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return custom_errors.NewTaskCommentNotFoundError(comment.ID)
+		}
+
+		if len(removeFileIDs) > 0 {
+			if err := tx.Where("comment_id = ? AND file_id IN ?", comment.ID, removeFileIDs).
+				Delete(&models.TaskCommentFile{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(addFiles) > 0 {
+			if err := tx.Create(&addFiles).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&models.TaskCommentMention{}).Error; err != nil {
+			return err
+		}
+		if len(comment.Mentions) > 0 {
+			return tx.Create(&comment.Mentions).Error
+		}
+		return nil
+	})
+}
+
+func (r *taskCommentRepository) Delete(commentID int) error {
+	result := r.db.Delete(&models.TaskComment{}, commentID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return custom_errors.NewTaskCommentNotFoundError(commentID)
+	}
+	return nil
+}
+
+func (r *taskCommentRepository) GetByID(commentID int) (*models.TaskComment, error) {
+	var comment models.TaskComment
+	err := r.db.Preload("Files").Preload("Mentions").First(&comment, commentID).Error
+	return &comment, err
+}
+
+// GetByTaskID возвращает комментарии задачи в порядке обсуждения, старые первыми
+func (r *taskCommentRepository) GetByTaskID(taskID int, limit, offset int) ([]models.TaskComment, error) {
+	var comments []models.TaskComment
+	err := r.db.
+		Preload("Files").
+		Preload("Mentions").
+		Where("task_id = ?", taskID).
+		Order("created_at, id").
+		Limit(limit).
+		Offset(offset).
+		Find(&comments).Error
+	return comments, err
+}
+
+// GetAuthorIDs возвращает всех, кто уже участвовал в обсуждении задачи
+func (r *taskCommentRepository) GetAuthorIDs(taskID int) ([]uuid.UUID, error) {
+	var authorIDs []uuid.UUID
+	err := r.db.Model(&models.TaskComment{}).
+		Where("task_id = ?", taskID).
+		Distinct().
+		Pluck("author_id", &authorIDs).Error
+	return authorIDs, err
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+// liveDependencies - зависимости, обе задачи которых не удалены
+const liveDependencies = `SELECT d.blocker_task_id, d.blocked_task_id
+	FROM task_service.task_dependencies d
+	JOIN task_service.tasks a ON a.id = d.blocker_task_id AND a.deleted_at IS NULL
+	JOIN task_service.tasks b ON b.id = d.blocked_task_id AND b.deleted_at IS NULL`
+
+type TaskDependencyRepository interface {
+	Create(dependency *models.TaskDependency) error
+	Delete(blockerTaskID, blockedTaskID int) error
+	HasPath(fromTaskID, toTaskID int) (bool, error)
+	GetGraph(taskID int) ([]dto.TaskGraphNode, []dto.TaskDependencyEdge, error)
+}
+
+type taskDependencyRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskDependencyRepository(db *gorm.DB) TaskDependencyRepository {
+	return &taskDependencyRepository{db: db}
+}
+
+// Create добавляет зависимость; повторное добавление существующей зависимости ничего не меняет
+func (r *taskDependencyRepository) Create(dependency *models.TaskDependency) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(dependency).Error
+}
+
+func (r *taskDependencyRepository) Delete(blockerTaskID, blockedTaskID int) error {
+	result := r.db.
+		Where("blocker_task_id = ? AND blocked_task_id = ?", blockerTaskID, blockedTaskID).
+		Delete(&models.TaskDependency{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return custom_errors.NewTaskDependencyNotFoundError(blockerTaskID, blockedTaskID)
+	}
+	return nil
+}
+
+// HasPath сообщает, блокирует ли fromTaskID задачу toTaskID напрямую или через цепочку зависимостей
+func (r *taskDependencyRepository) HasPath(fromTaskID, toTaskID int) (bool, error) {
+	var exists bool
+	err := r.db.Raw(`
+		WITH RECURSIVE reachable AS (
+			SELECT blocked_task_id AS id FROM task_service.task_dependencies WHERE blocker_task_id = ?
+			UNION
+			SELECT d.blocked_task_id FROM task_service.task_dependencies d
+			JOIN reachable r ON d.blocker_task_id = r.id
+		)
+		SELECT EXISTS (SELECT 1 FROM reachable WHERE id = ?)`, fromTaskID, toTaskID).
+		Scan(&exists).Error
+	return exists, err
+}
+
+// GetGraph возвращает задачи и зависимости, достижимые от taskID в обе стороны: всё, что прямо или
+// транзитивно блокирует задачу, и всё, что блокирует она. Сама задача всегда входит в узлы графа
+func (r *taskDependencyRepository) GetGraph(taskID int) ([]dto.TaskGraphNode, []dto.TaskDependencyEdge, error) {
+	var edges []dto.TaskDependencyEdge
+	err := r.db.Raw(`
+		WITH RECURSIVE live AS (`+liveDependencies+`),
+		upstream AS (
+			SELECT blocker_task_id, blocked_task_id FROM live WHERE blocked_task_id = ?
+			UNION
+			SELECT l.blocker_task_id, l.blocked_task_id FROM live l
+			JOIN upstream u ON l.blocked_task_id = u.blocker_task_id
+		),
+		downstream AS (
+			SELECT blocker_task_id, blocked_task_id FROM live WHERE blocker_task_id = ?
+			UNION
+			SELECT l.blocker_task_id, l.blocked_task_id FROM live l
+			JOIN downstream d ON l.blocker_task_id = d.blocked_task_id
+		)
+		SELECT blocker_task_id, blocked_task_id FROM upstream
+		UNION
+		SELECT blocker_task_id, blocked_task_id FROM downstream
+		ORDER BY blocker_task_id, blocked_task_id`, taskID, taskID).
+		Scan(&edges).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := []int{taskID}
+	for _, edge := range edges {
+		ids = append(ids, edge.BlockerTaskID, edge.BlockedTaskID)
+	}
+
+	var nodes []dto.TaskGraphNode
+	err = r.db.
+		Table("task_service.tasks AS t").
+		Select("t.id, t.title, s.name AS status, NOT ("+openTaskCondition+") AS closed").
+		Joins("JOIN task_service.task_statuses s ON s.id = t.status").
+		Where("t.id IN ?", ids).
+		Where("t.deleted_at IS NULL").
+		Order("t.id").
+		Scan(&nodes).Error
+	return nodes, edges, err
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"database/sql"
+	"gorm.io/gorm"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type TaskEventRepository interface {
+	Create(events []models.TaskEvent) error
+	GetByTaskID(taskID int, limit, offset int) (*[]dto.TaskActivity, error)
+	GetUserFeed(userID string, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskActivity, error)
+}
+
+type taskEventRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskEventRepository(db *gorm.DB) TaskEventRepository {
+	return &taskEventRepository{db: db}
+}
+
+func (r *taskEventRepository) Create(events []models.TaskEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.Create(&events).Error
+}
+
+// GetByTaskID возвращает историю задачи, новые события первыми
+func (r *taskEventRepository) GetByTaskID(taskID int, limit, offset int) (*[]dto.TaskActivity, error) {
+	return r.listEvents(r.db.Where("e.task_id = ?", taskID), limit, offset, nil)
+}
+
+// GetUserFeed объединяет события задач, где пользователь создатель, исполнитель, соисполнитель
+// или наблюдатель, и его собственные действия. Остаются только события задач, видимых по visibility
+func (r *taskEventRepository) GetUserFeed(userID string, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskActivity, error) {
+	return r.listEvents(
+		r.db.Where(`t.creator_id = @user OR t.executor_id = @user OR e.actor_id = @user
+			OR EXISTS (SELECT 1 FROM task_service.task_assignees ta WHERE ta.task_id = t.id AND ta.user_id = @user)
+			OR EXISTS (SELECT 1 FROM task_service.task_watchers tw WHERE tw.task_id = t.id AND tw.user_id = @user)`,
+			sql.Named("user", userID)),
+		limit, offset, visibility,
+	)
+}
+
+func (r *taskEventRepository) listEvents(condition *gorm.DB, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskActivity, error) {
+	var events []dto.TaskActivity
+
+	query := r.db.
+		Table("task_service.task_events AS e").
+		Select("e.id, e.task_id, t.title AS task_title, e.actor_id, e.event_type, e.field, e.old_value, e.new_value, e.created_at").
+		Joins("JOIN task_service.tasks t ON t.id = e.task_id").
+		Where(condition).
+		Where("t.deleted_at IS NULL")
+
+	err := applyVisibility(query, visibility).
+		Order("e.created_at DESC, e.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&events).Error
+
+	return &events, err
+}
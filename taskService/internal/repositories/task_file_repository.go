@@ -7,6 +7,7 @@ import (
 
 type TaskFileRepository interface {
 	BulkCreate(taskFiles []models.TaskFile) error
+	DeleteByFileIDs(taskID int, fileIDs []int) error
 }
 
 type taskFileRepository struct {
@@ -20,3 +21,7 @@ func NewTaskFileRepository(db *gorm.DB) TaskFileRepository {
 func (r *taskFileRepository) BulkCreate(taskFiles []models.TaskFile) error {
 	return r.db.Create(&taskFiles).Error
 }
+
+func (r *taskFileRepository) DeleteByFileIDs(taskID int, fileIDs []int) error {
+	return r.db.Where("task_id = ? AND file_id IN ?", taskID, fileIDs).Delete(&models.TaskFile{}).Error
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"taskService/internal/custom_errors"
+	"taskService/internal/models"
+)
+
+// TaskMemberRepository хранит соисполнителей и наблюдателей задач
+type TaskMemberRepository interface {
+	// AddAssignee назначает соисполнителя; false означает, что пользователь уже назначен
+	AddAssignee(taskID int, userID uuid.UUID) (bool, error)
+	RemoveAssignee(taskID int, userID uuid.UUID) error
+	// AddWatcher подписывает наблюдателя; false означает, что пользователь уже наблюдает за задачей
+	AddWatcher(taskID int, userID uuid.UUID) (bool, error)
+	RemoveWatcher(taskID int, userID uuid.UUID) error
+}
+
+type taskMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskMemberRepository(db *gorm.DB) TaskMemberRepository {
+	return &taskMemberRepository{db: db}
+}
+
+func (r *taskMemberRepository) AddAssignee(taskID int, userID uuid.UUID) (bool, error) {
+	return r.add(&models.TaskAssignee{TaskID: taskID, UserID: userID})
+}
+
+func (r *taskMemberRepository) RemoveAssignee(taskID int, userID uuid.UUID) error {
+	removed, err := r.remove(&models.TaskAssignee{}, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return custom_errors.NewTaskAssigneeNotFoundError(taskID, userID.String())
+	}
+	return nil
+}
+
+func (r *taskMemberRepository) AddWatcher(taskID int, userID uuid.UUID) (bool, error) {
+	return r.add(&models.TaskWatcher{TaskID: taskID, UserID: userID})
+}
+
+func (r *taskMemberRepository) RemoveWatcher(taskID int, userID uuid.UUID) error {
+	removed, err := r.remove(&models.TaskWatcher{}, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return custom_errors.NewTaskWatcherNotFoundError(taskID, userID.String())
+	}
+	return nil
+}
+
+func (r *taskMemberRepository) add(member interface{}) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(member)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *taskMemberRepository) remove(model interface{}, taskID int, userID uuid.UUID) (bool, error) {
+	result := r.db.Where("task_id = ? AND user_id = ?", taskID, userID).Delete(model)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"taskService/internal/models"
+)
+
+// pendingOccurrences - экземпляры серии, которые ещё не начались и не изменялись отдельно (см. Task.Detached).
+// Только их затрагивают изменение и удаление серии
+const pendingOccurrences = "recurrence_id = ? AND occurrence_at > ? AND NOT detached"
+
+type TaskRecurrenceRepository interface {
+	Create(recurrence *models.TaskRecurrence) error
+	Update(recurrence *models.TaskRecurrence) error
+	GetByID(id int) (*models.TaskRecurrence, error)
+	Delete(id int) error
+	// GetDue возвращает серии, следующий экземпляр которых начинается не позже until
+	GetDue(until time.Time, limit int) ([]models.TaskRecurrence, error)
+	// CreateOccurrences в одной транзакции сдвигает NextOccurrenceAt серии на next и создаёт экземпляры.
+	// Возвращает false без изменений, если серия изменилась после чтения: её уже обработала другая реплика
+	// или серию отредактировали. Экземпляры, которые уже есть у серии, не создаются повторно
+	CreateOccurrences(recurrence *models.TaskRecurrence, next *time.Time, occurrences []models.Task) (bool, error)
+	// UpdatePendingOccurrences переносит шаблон серии в её не начавшиеся после after экземпляры
+	UpdatePendingOccurrences(recurrence *models.TaskRecurrence, after time.Time) error
+	// DeletePendingOccurrences удаляет не начавшиеся после after экземпляры серии
+	DeletePendingOccurrences(recurrenceID int, after time.Time) error
+}
+
+type taskRecurrenceRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskRecurrenceRepository(db *gorm.DB) TaskRecurrenceRepository {
+	return &taskRecurrenceRepository{db: db}
+}
+
+func (r *taskRecurrenceRepository) Create(recurrence *models.TaskRecurrence) error {
+	return r.db.Create(recurrence).Error
+}
+
+// Update заменяет шаблон, правило и следующий экземпляр серии
+func (r *taskRecurrenceRepository) Update(recurrence *models.TaskRecurrence) error {
+	result := r.db.Model(&models.TaskRecurrence{ID: recurrence.ID}).
+		Select("Title", "Description", "ExecutorID", "ChatID", "WorkflowID", "Priority", "Rule", "StartsAt",
+			"DueAfterMinutes", "NextOccurrenceAt", "UpdatedAt").
+		Updates(recurrence)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *taskRecurrenceRepository) GetByID(id int) (*models.TaskRecurrence, error) {
+	var recurrence models.TaskRecurrence
+	if err := r.db.First(&recurrence, id).Error; err != nil {
+		return nil, err
+	}
+	return &recurrence, nil
+}
+
+// Delete выполняет мягкое удаление серии; новые экземпляры больше не создаются
+func (r *taskRecurrenceRepository) Delete(id int) error {
+	result := r.db.Delete(&models.TaskRecurrence{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *taskRecurrenceRepository) GetDue(until time.Time, limit int) ([]models.TaskRecurrence, error) {
+	var recurrences []models.TaskRecurrence
+	err := r.db.
+		Where("next_occurrence_at <= ?", until).
+		Order("next_occurrence_at").
+		Limit(limit).
+		Find(&recurrences).Error
+	return recurrences, err
+}
+
+func (r *taskRecurrenceRepository) CreateOccurrences(recurrence *models.TaskRecurrence, next *time.Time, occurrences []models.Task) (bool, error) {
+	claimed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Условное обновление блокирует строку серии: конкурирующая реплика дождётся коммита и не найдёт
+		// серию с прежним NextOccurrenceAt. UpdateColumn не трогает updated_at - это время правки серии
+		result := tx.Model(&models.TaskRecurrence{}).
+			Where("id = ? AND next_occurrence_at = ? AND updated_at IS NOT DISTINCT FROM ?",
+				recurrence.ID, recurrence.NextOccurrenceAt, recurrence.UpdatedAt).
+			UpdateColumn("next_occurrence_at", next)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		// После смены правила серии изменённые вручную экземпляры остаются и могут совпасть с новыми
+		occurrenceTimes := make([]time.Time, 0, len(occurrences))
+		for _, occurrence := range occurrences {
+			occurrenceTimes = append(occurrenceTimes, *occurrence.OccurrenceAt)
+		}
+		var existing []time.Time
+		if err := tx.Model(&models.Task{}).
+			Where("recurrence_id = ? AND occurrence_at IN ?", recurrence.ID, occurrenceTimes).
+			Pluck("occurrence_at", &existing).Error; err != nil {
+			return err
+		}
+
+		taskRepo := NewTaskRepository(tx)
+		for i := range occurrences {
+			if slices.ContainsFunc(existing, occurrences[i].OccurrenceAt.Equal) {
+				continue
+			}
+			if err := taskRepo.Create(&occurrences[i]); err != nil {
+				return err
+			}
+		}
+		claimed = true
+		return nil
+	})
+	return claimed, err
+}
+
+// UpdatePendingOccurrences не заполняет updated_at экземпляров: правка серии не считается правкой экземпляра
+func (r *taskRecurrenceRepository) UpdatePendingOccurrences(recurrence *models.TaskRecurrence, after time.Time) error {
+	dueAt := gorm.Expr("NULL")
+	if recurrence.DueAfterMinutes != nil {
+		dueAt = gorm.Expr("occurrence_at + make_interval(mins => ?)", *recurrence.DueAfterMinutes)
+	}
+	return r.db.Model(&models.Task{}).
+		Where(pendingOccurrences, recurrence.ID, after).
+		UpdateColumns(map[string]interface{}{
+			"title":       recurrence.Title,
+			"description": recurrence.Description,
+			"executor_id": recurrence.ExecutorID,
+			"chat_id":     recurrence.ChatID,
+			"priority":    recurrence.Priority,
+			"due_at":      dueAt,
+		}).Error
+}
+
+func (r *taskRecurrenceRepository) DeletePendingOccurrences(recurrenceID int, after time.Time) error {
+	return r.db.
+		Where(pendingOccurrences, recurrenceID, after).
+		Delete(&models.Task{}).Error
+}
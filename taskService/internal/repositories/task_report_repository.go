@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"taskService/internal/handlers/dto"
+	"time"
+)
+
+// closedIntervalCondition отбирает интервалы task_status_intervals i в статусе без исходящих переходов
+// workflow задачи t; интервалы удалённых статусов закрытыми не считаются
+const closedIntervalCondition = `i.status_id IS NOT NULL AND EXISTS (
+	SELECT 1 FROM task_service.task_workflows w
+	WHERE w.id = COALESCE(t.workflow_id, (SELECT id FROM task_service.task_workflows WHERE is_default LIMIT 1))
+	  AND NOT EXISTS (
+		SELECT 1 FROM task_service.task_workflow_transitions tr
+		WHERE tr.workflow_id = w.id AND tr.from_status_id = i.status_id
+	  )
+)`
+
+// TaskReportRepository строит агрегированные отчёты по задачам. Отчёты по истории статусов читают
+// материализованное представление task_status_intervals и отстают от изменений до его обновления.
+// Отчёты учитывают только задачи, видимые по visibility; nil не ограничивает выборку
+type TaskReportRepository interface {
+	GetThroughput(query *dto.TaskReportQuery, visibility *dto.TaskVisibility) ([]dto.TaskThroughput, error)
+	GetStatusDurations(query *dto.TaskReportQuery, visibility *dto.TaskVisibility) ([]dto.TaskStatusDuration, error)
+	GetOverdueByExecutor(now time.Time, chatID *uuid.UUID, visibility *dto.TaskVisibility) ([]dto.TaskOverdueCount, error)
+	GetCumulativeFlow(query *dto.TaskReportQuery, visibility *dto.TaskVisibility) ([]dto.TaskCumulativeFlow, error)
+	// RefreshStatusIntervals пересчитывает task_status_intervals, не блокируя чтение отчётов
+	RefreshStatusIntervals() error
+}
+
+type taskReportRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskReportRepository(db *gorm.DB) TaskReportRepository {
+	return &taskReportRepository{db: db}
+}
+
+// GetThroughput возвращает все периоды шага query.Interval, пересекающие [From, To), включая пустые.
+// Созданные задачи считаются по задачам, завершённые - по представлению
+func (r *taskReportRepository) GetThroughput(query *dto.TaskReportQuery, visibility *dto.TaskVisibility) ([]dto.TaskThroughput, error) {
+	tasks, args := reportTaskCondition(query.ChatID, visibility)
+	args = append(args,
+		sql.Named("unit", query.Interval),
+		sql.Named("step", "1 "+query.Interval),
+		sql.Named("from", query.From),
+		sql.Named("to", query.To),
+	)
+
+	rows := []dto.TaskThroughput{}
+	err := r.db.Raw(`
+		WITH periods AS (
+			SELECT generate_series(
+				date_trunc(@unit, @from::TIMESTAMP),
+				@to::TIMESTAMP - INTERVAL '1 microsecond',
+				@step::INTERVAL
+			) AS period
+		),
+		created AS (
+			SELECT date_trunc(@unit, t.created_at) AS period, COUNT(*) AS n
+			FROM task_service.tasks t
+			WHERE t.deleted_at IS NULL AND t.created_at >= @from AND t.created_at < @to`+tasks+`
+			GROUP BY 1
+		),
+		completed AS (
+			SELECT date_trunc(@unit, i.entered_at) AS period, COUNT(*) AS n
+			FROM task_service.task_status_intervals i
+			JOIN task_service.tasks t ON t.id = i.task_id AND t.deleted_at IS NULL
+			WHERE i.left_at IS NULL AND i.entered_at >= @from AND i.entered_at < @to
+			  AND `+closedIntervalCondition+tasks+`
+			GROUP BY 1
+		)
+		SELECT p.period, COALESCE(c.n, 0) AS created, COALESCE(d.n, 0) AS completed
+		FROM periods p
+		LEFT JOIN created c ON c.period = p.period
+		LEFT JOIN completed d ON d.period = p.period
+		ORDER BY p.period`, args...).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetStatusDurations усредняет длительность пребываний в статусах, закончившихся в [From, To).
+// Статусы упорядочены по ID, удалённые - в конце
+func (r *taskReportRepository) GetStatusDurations(query *dto.TaskReportQuery, visibility *dto.TaskVisibility) ([]dto.TaskStatusDuration, error) {
+	tasks, args := reportTaskCondition(query.ChatID, visibility)
+	args = append(args, sql.Named("from", query.From), sql.Named("to", query.To))
+
+	rows := []dto.TaskStatusDuration{}
+	err := r.db.Raw(`
+		SELECT MIN(i.status_id) AS status_id,
+		       i.status,
+		       COUNT(*) AS stays,
+		       (AVG(EXTRACT(EPOCH FROM i.left_at - i.entered_at)) / 60)::FLOAT8 AS avg_minutes
+		FROM task_service.task_status_intervals i
+		JOIN task_service.tasks t ON t.id = i.task_id AND t.deleted_at IS NULL
+		WHERE i.left_at IS NOT NULL AND i.left_at >= @from AND i.left_at < @to`+tasks+`
+		GROUP BY i.status
+		ORDER BY MIN(i.status_id) NULLS LAST, i.status`, args...).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// GetOverdueByExecutor считает по текущему состоянию задач, больше всего просроченных первыми
+func (r *taskReportRepository) GetOverdueByExecutor(now time.Time, chatID *uuid.UUID, visibility *dto.TaskVisibility) ([]dto.TaskOverdueCount, error) {
+	scope := r.db.
+		Table("task_service.tasks AS t").
+		Select("t.executor_id, COUNT(*) AS overdue, MIN(t.due_at) AS oldest_due_at").
+		Where("t.deleted_at IS NULL").
+		Where("t.executor_id IS NOT NULL AND t.executor_id <> ?", uuid.Nil).
+		Where("t.due_at < ?", now).
+		Where(openTaskCondition)
+	if chatID != nil {
+		scope = scope.Where("t.chat_id = ?", *chatID)
+	}
+	scope = applyVisibility(scope, visibility)
+
+	rows := []dto.TaskOverdueCount{}
+	err := scope.Group("t.executor_id").Order("overdue DESC, oldest_due_at").Scan(&rows).Error
+	return rows, err
+}
+
+// GetCumulativeFlow для каждого дня [From, To) считает задачи чата по статусам на конец дня
+func (r *taskReportRepository) GetCumulativeFlow(query *dto.TaskReportQuery, visibility *dto.TaskVisibility) ([]dto.TaskCumulativeFlow, error) {
+	tasks, args := reportTaskCondition(query.ChatID, visibility)
+	args = append(args, sql.Named("from", query.From), sql.Named("to", query.To))
+
+	rows := []dto.TaskCumulativeFlow{}
+	err := r.db.Raw(`
+		WITH days AS (
+			SELECT generate_series(
+				date_trunc('day', @from::TIMESTAMP),
+				@to::TIMESTAMP - INTERVAL '1 microsecond',
+				INTERVAL '1 day'
+			) AS day
+		)
+		SELECT d.day, i.status, COUNT(*) AS tasks
+		FROM days d
+		JOIN task_service.task_status_intervals i
+		  ON i.entered_at < d.day + INTERVAL '1 day'
+		 AND (i.left_at IS NULL OR i.left_at >= d.day + INTERVAL '1 day')
+		JOIN task_service.tasks t ON t.id = i.task_id AND t.deleted_at IS NULL
+		WHERE TRUE`+tasks+`
+		GROUP BY d.day, i.status
+		ORDER BY d.day, MIN(i.status_id) NULLS LAST, i.status`, args...).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *taskReportRepository) RefreshStatusIntervals() error {
+	return r.db.Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY task_service.task_status_intervals").Error
+}
+
+// reportTaskCondition возвращает условие на чат и видимость задачи t для подстановки в запрос и его аргументы
+func reportTaskCondition(chatID *uuid.UUID, visibility *dto.TaskVisibility) (string, []interface{}) {
+	condition, args := "", []interface{}{}
+	if chatID != nil {
+		condition += " AND t.chat_id = @chat"
+		args = append(args, sql.Named("chat", *chatID))
+	}
+	if visibility != nil {
+		condition += " AND " + visibleCondition
+		args = append(args, sql.Named("viewer", visibility.UserID), sql.Named("chats", visibility.ChatIDs))
+	}
+	return condition, args
+}
@@ -1,17 +1,93 @@
 package repositories
 
 import (
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"taskService/internal/custom_errors"
 	"taskService/internal/handlers/dto"
 	"taskService/internal/models"
+	"time"
 )
 
+// openTaskCondition отбирает незакрытые задачи: закрытым считается статус, из которого
+// workflow задачи (или workflow по умолчанию) не предусматривает переходов
+const openTaskCondition = `NOT EXISTS (
+	SELECT 1 FROM task_service.task_workflows w
+	WHERE w.id = COALESCE(t.workflow_id, (SELECT id FROM task_service.task_workflows WHERE is_default LIMIT 1))
+	  AND NOT EXISTS (
+		SELECT 1 FROM task_service.task_workflow_transitions tr
+		WHERE tr.workflow_id = w.id AND tr.from_status_id = t.status
+	  )
+)`
+
+// executorCondition отбирает задачи, где @user - исполнитель или соисполнитель
+const executorCondition = `(t.executor_id = @user OR EXISTS (
+	SELECT 1 FROM task_service.task_assignees ta WHERE ta.task_id = t.id AND ta.user_id = @user
+))`
+
+// watcherCondition отбирает задачи, за которыми наблюдает @user
+const watcherCondition = `EXISTS (
+	SELECT 1 FROM task_service.task_watchers tw WHERE tw.task_id = t.id AND tw.user_id = @user
+)`
+
+// visibleCondition отбирает задачи, видимые @viewer: созданные им, где он исполнитель или соисполнитель,
+// и задачи чатов @chats
+const visibleCondition = `(t.creator_id = @viewer OR t.executor_id = @viewer OR EXISTS (
+	SELECT 1 FROM task_service.task_assignees va WHERE va.task_id = t.id AND va.user_id = @viewer
+) OR t.chat_id IN @chats)`
+
+// priorityRank упорядочивает приоритеты по важности, а не по алфавиту
+const priorityRank = "CASE t.priority WHEN 'low' THEN 1 WHEN 'normal' THEN 2 WHEN 'high' THEN 3 WHEN 'urgent' THEN 4 END"
+
+// priorityRanks - значения priorityRank для сравнения с курсором
+var priorityRanks = map[string]int{
+	models.TaskPriorityLow:    1,
+	models.TaskPriorityNormal: 2,
+	models.TaskPriorityHigh:   3,
+	models.TaskPriorityUrgent: 4,
+}
+
+// taskSearchVector - документ полнотекстового поиска; совпадает с выражением индекса tasks_search_idx
+const taskSearchVector = "to_tsvector('simple', t.title || ' ' || COALESCE(t.description, ''))"
+
+// checklistProgress - процент выполненных пунктов чек-листа задачи; NULL, если пунктов нет
+const checklistProgress = `(SELECT (100 * COUNT(*) FILTER (WHERE ci.done) / NULLIF(COUNT(*), 0))::int
+	FROM task_service.task_checklist_items ci WHERE ci.task_id = t.id) AS checklist_progress`
+
+const taskListColumns = "t.id, t.title, s.name AS status, t.priority, t.start_at, t.due_at, t.estimate_minutes, t.created_at, " +
+	"COALESCE(t.updated_at, t.created_at) AS updated_at, " + checklistProgress
+
 type TaskRepository interface {
 	Create(task *models.Task) error
+	Update(task *models.Task) error
 	UpdateStatus(taskID int, statusID int) error
+	Delete(taskID int) error
 	GetByID(taskID int) (*models.Task, error)
-	GetUserTasks(userID string, limit, offset int) (*[]dto.TaskToList, error)
+	// Списки задач возвращают только задачи, видимые по visibility; nil не ограничивает выборку
+	GetUserTasks(userID string, filter *dto.TaskListFilter, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskToList, error)
+	GetCreatedTasks(userID string, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskToList, error)
+	GetChatTasks(chatID string, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskToList, error)
+	GetDueSoon(now, until time.Time, limit int) ([]models.Task, error)
+	GetOverdue(now time.Time, limit int) ([]models.Task, error)
+	ClaimDueSoonReminder(taskID int, dueAt, now time.Time) (bool, error)
+	ClaimOverdueReminder(taskID int, dueAt, now time.Time) (bool, error)
+	GetSubtree(rootID int) ([]dto.TaskTreeNode, error)
+	GetOpenBlockerIDs(taskID int) ([]int, error)
+	// GetVisibleIDs возвращает задачи из ids, видимые по visibility
+	GetVisibleIDs(ids []int, visibility *dto.TaskVisibility) ([]int, error)
+	// QueryTasks возвращает до query.Limit+1 задач после курсора, чтобы вызывающий мог понять,
+	// есть ли следующая страница, и общее число задач, подходящих под фильтры
+	QueryTasks(query *dto.TaskQuery, visibility *dto.TaskVisibility) ([]dto.TaskToList, int64, error)
+	// GetBoard возвращает до query.Limit карточек каждой колонки в порядке ранга и число задач в колонках
+	GetBoard(query *dto.TaskBoardQuery, visibility *dto.TaskVisibility) ([]dto.TaskBoardCard, []dto.TaskBoardCount, error)
+	// GetLastRank возвращает наибольший ранг в колонке статуса; пустая строка - колонка пуста
+	GetLastRank(statusID int) (string, error)
+	// MoveOnBoard одним запросом меняет статус и ранг задачи, если её статус всё ещё fromStatusID
+	MoveOnBoard(taskID, fromStatusID, toStatusID int, rank string) error
+	// Detach отделяет экземпляр серии от неё; для задачи вне серии ничего не меняет
+	Detach(taskID int) error
 }
 
 type taskRepository struct {
@@ -22,10 +98,35 @@ func NewTaskRepository(db *gorm.DB) TaskRepository {
 	return &taskRepository{db: db}
 }
 
+// Create сохраняет задачу; без явного ранга она встаёт в конец колонки своего статуса
 func (r *taskRepository) Create(task *models.Task) error {
+	if task.Rank == "" {
+		last, err := r.GetLastRank(task.StatusID)
+		if err != nil {
+			return err
+		}
+		if task.Rank, err = models.RankBetween(last, ""); err != nil {
+			return err
+		}
+	}
 	return r.db.Omit("Status").Create(task).Error
 }
 
+// Update сохраняет редактируемые поля задачи, включая нулевые значения
+func (r *taskRepository) Update(task *models.Task) error {
+	result := r.db.Model(task).
+		Select("Title", "Description", "ExecutorID", "ChatID", "ParentTaskID", "Priority", "StartAt", "DueAt",
+			"EstimateMinutes", "DueSoonNotifiedAt", "OverdueNotifiedAt", "UpdatedAt").
+		Updates(task)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return custom_errors.NewTaskNotFoundError(task.ID)
+	}
+	return nil
+}
+
 func (r *taskRepository) UpdateStatus(taskID int, statusID int) error {
 	result := r.db.Model(&models.Task{}).Where("id = ?", taskID).Update("status", statusID)
 	if result.Error != nil {
@@ -37,24 +138,405 @@ func (r *taskRepository) UpdateStatus(taskID int, statusID int) error {
 	return nil
 }
 
+// Delete выполняет мягкое удаление задачи: запись остаётся в БД с заполненным deleted_at
+func (r *taskRepository) Delete(taskID int) error {
+	result := r.db.Delete(&models.Task{}, taskID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return custom_errors.NewTaskNotFoundError(taskID)
+	}
+	return nil
+}
+
 func (r *taskRepository) GetByID(taskID int) (*models.Task, error) {
 	var task models.Task
-	err := r.db.Preload("Status").Preload("Files").First(&task, taskID).Error
+	err := r.db.
+		Preload("Status").
+		Preload("Files").
+		Preload("Assignees", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		Preload("Watchers", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		Preload("Checklist", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") }).
+		First(&task, taskID).Error
 	return &task, err
 }
 
-func (r *taskRepository) GetUserTasks(userID string, limit, offset int) (*[]dto.TaskToList, error) {
-	var tasks []dto.TaskToList
+// GetUserTasks возвращает задачи, где пользователь исполнитель, соисполнитель или наблюдатель
+func (r *taskRepository) GetUserTasks(userID string, filter *dto.TaskListFilter, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskToList, error) {
+	return r.listTasks("("+executorCondition+" OR "+watcherCondition+")", sql.Named("user", userID), filter, limit, offset, visibility)
+}
+
+func (r *taskRepository) GetCreatedTasks(userID string, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskToList, error) {
+	return r.listTasks("t.creator_id = ?", userID, nil, limit, offset, visibility)
+}
+
+func (r *taskRepository) GetChatTasks(chatID string, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskToList, error) {
+	return r.listTasks("t.chat_id = ?", chatID, nil, limit, offset, visibility)
+}
 
+// GetDueSoon возвращает незакрытые задачи с исполнителем, срок которых наступает в (now, until]
+// и о которых ещё не напоминали
+func (r *taskRepository) GetDueSoon(now, until time.Time, limit int) ([]models.Task, error) {
+	var tasks []models.Task
 	err := r.db.
 		Table("task_service.tasks AS t").
-		Select("t.id, t.title, s.name AS status, t.created_at").
+		Where("t.deleted_at IS NULL").
+		Where("t.executor_id IS NOT NULL AND t.executor_id <> ?", uuid.Nil).
+		Where("t.due_at > ? AND t.due_at <= ?", now, until).
+		Where("t.due_soon_notified_at IS NULL").
+		Where(openTaskCondition).
+		Order("t.due_at ASC").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// GetOverdue возвращает незакрытые задачи с исполнителем и истёкшим сроком, о которых ещё не напоминали
+func (r *taskRepository) GetOverdue(now time.Time, limit int) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.
+		Table("task_service.tasks AS t").
+		Where("t.deleted_at IS NULL").
+		Where("t.executor_id IS NOT NULL AND t.executor_id <> ?", uuid.Nil).
+		Where("t.due_at <= ?", now).
+		Where("t.overdue_notified_at IS NULL").
+		Where(openTaskCondition).
+		Order("t.due_at ASC").
+		Limit(limit).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// ClaimDueSoonReminder атомарно отмечает напоминание о сроке как отправленное. Возвращает false, если
+// его уже забрала другая реплика или срок задачи успел измениться
+func (r *taskRepository) ClaimDueSoonReminder(taskID int, dueAt, now time.Time) (bool, error) {
+	return r.claimReminder("due_soon_notified_at", taskID, dueAt, now)
+}
+
+// ClaimOverdueReminder - то же, что ClaimDueSoonReminder, для напоминания о просроченной задаче
+func (r *taskRepository) ClaimOverdueReminder(taskID int, dueAt, now time.Time) (bool, error) {
+	return r.claimReminder("overdue_notified_at", taskID, dueAt, now)
+}
+
+func (r *taskRepository) claimReminder(column string, taskID int, dueAt, now time.Time) (bool, error) {
+	result := r.db.Model(&models.Task{}).
+		Where("id = ? AND due_at = ?", taskID, dueAt).
+		Where(column+" IS NULL").
+		Update(column, now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetSubtree возвращает задачу rootID и все её неудалённые подзадачи любой вложенности плоским списком.
+// Пустой результат означает, что задачи нет
+func (r *taskRepository) GetSubtree(rootID int) ([]dto.TaskTreeNode, error) {
+	var nodes []dto.TaskTreeNode
+	err := r.db.Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id FROM task_service.tasks WHERE id = ? AND deleted_at IS NULL
+			UNION
+			SELECT c.id FROM task_service.tasks c JOIN tree ON c.parent_task_id = tree.id
+			WHERE c.deleted_at IS NULL
+		)
+		SELECT t.id, t.title, t.parent_task_id, s.name AS status, t.priority, t.executor_id,
+		       NOT (`+openTaskCondition+`) AS closed
+		FROM task_service.tasks t
+		JOIN tree ON tree.id = t.id
+		JOIN task_service.task_statuses s ON s.id = t.status
+		ORDER BY t.created_at, t.id`, rootID).
+		Scan(&nodes).Error
+	return nodes, err
+}
+
+func (r *taskRepository) GetVisibleIDs(ids []int, visibility *dto.TaskVisibility) ([]int, error) {
+	var visible []int
+	err := applyVisibility(r.db.Table("task_service.tasks AS t").Where("t.id IN ?", ids), visibility).
+		Pluck("t.id", &visible).Error
+	return visible, err
+}
+
+// GetOpenBlockerIDs возвращает незакрытые неудалённые задачи, которые блокируют taskID
+func (r *taskRepository) GetOpenBlockerIDs(taskID int) ([]int, error) {
+	var ids []int
+	err := r.db.
+		Table("task_service.task_dependencies AS d").
+		Joins("JOIN task_service.tasks t ON t.id = d.blocker_task_id").
+		Where("d.blocked_task_id = ?", taskID).
+		Where("t.deleted_at IS NULL").
+		Where(openTaskCondition).
+		Order("t.id").
+		Pluck("t.id", &ids).Error
+	return ids, err
+}
+
+func (r *taskRepository) GetBoard(query *dto.TaskBoardQuery, visibility *dto.TaskVisibility) ([]dto.TaskBoardCard, []dto.TaskBoardCount, error) {
+	scope := func() *gorm.DB {
+		db := applyVisibility(r.db.Table("task_service.tasks AS t").Where("t.deleted_at IS NULL"), visibility)
+		if query.ChatID != nil {
+			db = db.Where("t.chat_id = ?", *query.ChatID)
+		}
+		if query.ExecutorID != nil {
+			db = db.Where(executorCondition, sql.Named("user", *query.ExecutorID))
+		}
+		return db
+	}
+
+	var counts []dto.TaskBoardCount
+	if err := scope().
+		Select("t.status AS status_id, COUNT(*) AS total").
+		Group("t.status").
+		Scan(&counts).Error; err != nil {
+		return nil, nil, err
+	}
+
+	ranked := scope().
+		Select(taskListColumns + ", t.status AS status_id, t.rank, " +
+			"ROW_NUMBER() OVER (PARTITION BY t.status ORDER BY t.rank, t.id) AS position").
+		Joins("JOIN task_service.task_statuses s ON t.status = s.id")
+
+	var cards []dto.TaskBoardCard
+	err := r.db.
+		Table("(?) AS ranked", ranked).
+		Where("ranked.position <= ?", query.Limit).
+		Order("ranked.status_id, ranked.position").
+		Scan(&cards).Error
+	return cards, counts, err
+}
+
+func (r *taskRepository) GetLastRank(statusID int) (string, error) {
+	var rank string
+	err := r.db.
+		Table("task_service.tasks").
+		Select("COALESCE(MAX(rank), '')").
+		Where("status = ? AND deleted_at IS NULL", statusID).
+		Scan(&rank).Error
+	return rank, err
+}
+
+func (r *taskRepository) MoveOnBoard(taskID, fromStatusID, toStatusID int, rank string) error {
+	result := r.db.Model(&models.Task{}).
+		Where("id = ? AND status = ?", taskID, fromStatusID).
+		Updates(map[string]interface{}{"status": toStatusID, "rank": rank})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return custom_errors.NewInvalidTaskMoveError(taskID, "task status has changed, reload the board")
+	}
+	return nil
+}
+
+// QueryTasks ищет задачи по фильтрам, полнотекстовому запросу и курсору
+func (r *taskRepository) QueryTasks(query *dto.TaskQuery, visibility *dto.TaskVisibility) ([]dto.TaskToList, int64, error) {
+	var total int64
+	if err := r.queryTasksScope(query, visibility).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := r.queryTasksScope(query, visibility).Select(taskListColumns)
+	if query.Cursor != nil {
+		condition, args, err := cursorCondition(query.Cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		page = page.Where(condition, args...)
+	}
+
+	var tasks []dto.TaskToList
+	err := page.
+		Order(sortOrder(query.SortBy, query.SortDesc)).
+		Limit(query.Limit + 1).
+		Scan(&tasks).Error
+	return tasks, total, err
+}
+
+// queryTasksScope применяет фильтры поиска без курсора, сортировки и лимита
+func (r *taskRepository) queryTasksScope(query *dto.TaskQuery, visibility *dto.TaskVisibility) *gorm.DB {
+	scope := r.db.
+		Table("task_service.tasks AS t").
 		Joins("JOIN task_service.task_statuses s ON t.status = s.id").
-		Where("t.executor_id = ?", userID).
-		Order("t.created_at DESC").
+		Where("t.deleted_at IS NULL")
+	scope = applyVisibility(scope, visibility)
+	scope = applyListFilter(scope, &query.TaskListFilter)
+
+	if len(query.StatusIDs) > 0 {
+		scope = scope.Where("t.status IN ?", query.StatusIDs)
+	}
+	if query.CreatorID != nil {
+		scope = scope.Where("t.creator_id = ?", *query.CreatorID)
+	}
+	if query.ExecutorID != nil {
+		scope = scope.Where(executorCondition, sql.Named("user", *query.ExecutorID))
+	}
+	if query.WatcherID != nil {
+		scope = scope.Where(watcherCondition, sql.Named("user", *query.WatcherID))
+	}
+	if query.ChatID != nil {
+		scope = scope.Where("t.chat_id = ?", *query.ChatID)
+	}
+	if query.StartFrom != nil {
+		scope = scope.Where("t.start_at >= ?", *query.StartFrom)
+	}
+	if query.StartTo != nil {
+		scope = scope.Where("t.start_at <= ?", *query.StartTo)
+	}
+	if query.CreatedFrom != nil {
+		scope = scope.Where("t.created_at >= ?", *query.CreatedFrom)
+	}
+	if query.CreatedTo != nil {
+		scope = scope.Where("t.created_at <= ?", *query.CreatedTo)
+	}
+	if query.Search != "" {
+		scope = scope.Where(taskSearchVector+" @@ plainto_tsquery('simple', ?)", query.Search)
+	}
+	return scope
+}
+
+func (r *taskRepository) listTasks(condition string, value interface{}, filter *dto.TaskListFilter, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskToList, error) {
+	var tasks []dto.TaskToList
+
+	query := r.db.
+		Table("task_service.tasks AS t").
+		Select(taskListColumns).
+		Joins("JOIN task_service.task_statuses s ON t.status = s.id").
+		Where(condition, value).
+		Where("t.deleted_at IS NULL")
+	query = applyVisibility(query, visibility)
+
+	order := "t.created_at DESC"
+	if filter != nil {
+		query = applyListFilter(query, filter)
+		order = sortOrder(filter.SortBy, filter.SortDesc)
+	}
+
+	err := query.
+		Order(order).
 		Limit(limit).
 		Offset(offset).
 		Scan(&tasks).Error
 
 	return &tasks, err
 }
+
+// applyVisibility оставляет задачи, видимые пользователю; nil не ограничивает выборку
+func applyVisibility(query *gorm.DB, visibility *dto.TaskVisibility) *gorm.DB {
+	if visibility == nil {
+		return query
+	}
+	return query.Where(visibleCondition, sql.Named("viewer", visibility.UserID), sql.Named("chats", visibility.ChatIDs))
+}
+
+// applyListFilter применяет фильтры по приоритету и сроку
+func applyListFilter(query *gorm.DB, filter *dto.TaskListFilter) *gorm.DB {
+	if len(filter.Priorities) > 0 {
+		query = query.Where("t.priority IN ?", filter.Priorities)
+	}
+	if len(filter.LabelIDs) > 0 {
+		query = query.Where(`EXISTS (SELECT 1 FROM task_service.task_labels tl
+			WHERE tl.task_id = t.id AND tl.label_id IN ?)`, filter.LabelIDs)
+	}
+	if filter.DueFrom != nil {
+		query = query.Where("t.due_at >= ?", *filter.DueFrom)
+	}
+	if filter.DueTo != nil {
+		query = query.Where("t.due_at <= ?", *filter.DueTo)
+	}
+	if filter.Overdue {
+		query = query.Where("t.due_at < ?", time.Now()).Where(openTaskCondition)
+	}
+	return query
+}
+
+// sortColumn возвращает выражение поля сортировки и то, может ли оно быть NULL
+func sortColumn(sortBy string) (string, bool, bool) {
+	switch sortBy {
+	case dto.TaskSortByCreatedAt:
+		return "t.created_at", false, true
+	case dto.TaskSortByUpdatedAt:
+		return "COALESCE(t.updated_at, t.created_at)", false, true
+	case dto.TaskSortByDueAt:
+		return "t.due_at", true, true
+	case dto.TaskSortByStartAt:
+		return "t.start_at", true, true
+	case dto.TaskSortByPriority:
+		return priorityRank, false, true
+	case dto.TaskSortByStatus:
+		return "s.name", false, true
+	case dto.TaskSortByTitle:
+		return "t.title", false, true
+	}
+	return "", false, false
+}
+
+// sortOrder строит ORDER BY по выбранному полю; задачи без срока или даты начала идут последними,
+// при равенстве значений порядок стабилен по id
+func sortOrder(sortBy string, desc bool) string {
+	column, nullable, ok := sortColumn(sortBy)
+	if !ok {
+		return "t.created_at DESC"
+	}
+
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+	if nullable {
+		return fmt.Sprintf("%s %s NULLS LAST, t.id %s", column, direction, direction)
+	}
+	return fmt.Sprintf("%s %s, t.id %s", column, direction, direction)
+}
+
+// cursorCondition отбирает задачи, которые в порядке sortOrder идут после курсора
+func cursorCondition(cursor *dto.TaskCursor) (string, []any, error) {
+	column, nullable, ok := sortColumn(cursor.SortBy)
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported cursor sort field %q", cursor.SortBy)
+	}
+
+	op := ">"
+	if cursor.SortDesc {
+		op = "<"
+	}
+
+	// Задачи с пустым значением стоят в конце при любом направлении сортировки
+	if cursor.Value == nil {
+		if !nullable {
+			return "", nil, fmt.Errorf("cursor without value for sort field %q", cursor.SortBy)
+		}
+		return fmt.Sprintf("%s IS NULL AND t.id %s ?", column, op), []any{cursor.ID}, nil
+	}
+
+	value, err := cursorSortValue(cursor.SortBy, *cursor.Value)
+	if err != nil {
+		return "", nil, err
+	}
+	condition := fmt.Sprintf("(%s %s ? OR (%s = ? AND t.id %s ?))", column, op, column, op)
+	if nullable {
+		condition = fmt.Sprintf("(%s OR %s IS NULL)", condition, column)
+	}
+	return condition, []any{value, value, cursor.ID}, nil
+}
+
+// cursorSortValue переводит значение курсора в тип поля сортировки
+func cursorSortValue(sortBy, value string) (any, error) {
+	switch sortBy {
+	case dto.TaskSortByCreatedAt, dto.TaskSortByUpdatedAt, dto.TaskSortByDueAt, dto.TaskSortByStartAt:
+		return time.Parse(time.RFC3339Nano, value)
+	case dto.TaskSortByPriority:
+		rank, ok := priorityRanks[value]
+		if !ok {
+			return nil, fmt.Errorf("invalid cursor priority %q", value)
+		}
+		return rank, nil
+	}
+	return value, nil
+}
+
+func (r *taskRepository) Detach(taskID int) error {
+	return r.db.Model(&models.Task{}).
+		Where("id = ? AND recurrence_id IS NOT NULL", taskID).
+		UpdateColumn("detached", true).Error
+}
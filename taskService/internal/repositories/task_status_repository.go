@@ -1,7 +1,12 @@
 package repositories
 
 import (
+	"fmt"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"taskService/internal/custom_errors"
 	"taskService/internal/models"
 )
 
@@ -11,6 +16,16 @@ type TaskStatusRepository interface {
 	Create(name string) (*models.TaskStatus, error)
 	DeleteByID(id int) error
 	GetAll() ([]models.TaskStatus, error)
+	// CountTasks возвращает число задач в статусе и число удалённых задач в нём
+	CountTasks(id int) (tasks int64, deletedTasks int64, err error)
+	// GetWorkflowsUsing возвращает workflow, в статусах или переходах которых есть статус
+	GetWorkflowsUsing(id int) ([]models.TaskWorkflow, error)
+	// Delete удаляет статус в одной транзакции с подсчётом его задач, чтобы задача, созданная одновременно
+	// с удалением, не осталась без статуса. Задачи, включая удалённые, переносятся в replacement с записью
+	// status_changed в историю каждой задачи. Без replacement статус с задачами не удаляется
+	// (TaskStatusReplacementRequiredError); replacement должен входить в workflow каждой задачи
+	// (InvalidStatusReplacementError)
+	Delete(status, replacement *models.TaskStatus, actorID uuid.UUID) error
 }
 
 type taskStatusRepository struct {
@@ -57,3 +72,103 @@ func (r *taskStatusRepository) GetAll() ([]models.TaskStatus, error) {
 	}
 	return statuses, nil
 }
+
+func (r *taskStatusRepository) CountTasks(id int) (int64, int64, error) {
+	var counts struct {
+		Tasks        int64
+		DeletedTasks int64
+	}
+	err := r.db.Unscoped().
+		Model(&models.Task{}).
+		Select("COUNT(*) FILTER (WHERE deleted_at IS NULL) AS tasks, COUNT(*) FILTER (WHERE deleted_at IS NOT NULL) AS deleted_tasks").
+		Where("status = ?", id).
+		Scan(&counts).Error
+	return counts.Tasks, counts.DeletedTasks, err
+}
+
+func (r *taskStatusRepository) GetWorkflowsUsing(id int) ([]models.TaskWorkflow, error) {
+	var workflows []models.TaskWorkflow
+	err := r.db.
+		Where("id IN (SELECT workflow_id FROM task_service.task_workflow_statuses WHERE status_id = ?)", id).
+		Or("id IN (SELECT workflow_id FROM task_service.task_workflow_transitions WHERE from_status_id = ? OR to_status_id = ?)", id, id).
+		Order("id").
+		Find(&workflows).Error
+	return workflows, err
+}
+
+func (r *taskStatusRepository) Delete(status, replacement *models.TaskStatus, actorID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Блокировка строки статуса ждёт транзакции, создающие задачи в нём, и не даёт начать новые до удаления
+		var locked models.TaskStatus
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&locked, status.ID).Error; err != nil {
+			return err
+		}
+
+		var tasks int64
+		if err := tx.Unscoped().Model(&models.Task{}).Where("status = ?", status.ID).Count(&tasks).Error; err != nil {
+			return err
+		}
+		if tasks > 0 {
+			if replacement == nil {
+				return custom_errors.NewTaskStatusReplacementRequiredError(status.ID, tasks)
+			}
+			if err := checkReplacementInWorkflows(tx, status, replacement); err != nil {
+				return err
+			}
+			if err := replaceTaskStatus(tx, status, replacement, actorID); err != nil {
+				return err
+			}
+		}
+
+		result := tx.Delete(&models.TaskStatus{}, status.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// checkReplacementInWorkflows проверяет, что replacement входит в workflow каждой задачи статуса:
+// в собственный workflow задачи или, если его нет, в workflow по умолчанию
+func checkReplacementInWorkflows(tx *gorm.DB, status, replacement *models.TaskStatus) error {
+	var workflows []string
+	err := tx.Raw(`
+		SELECT w.name FROM task_service.task_workflows w
+		WHERE w.id IN (
+			SELECT COALESCE(own.id, def.id) FROM task_service.tasks t
+			LEFT JOIN task_service.task_workflows own ON own.id = t.workflow_id
+			LEFT JOIN task_service.task_workflows def ON def.is_default
+			WHERE t.status = ?
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM task_service.task_workflow_statuses ws WHERE ws.workflow_id = w.id AND ws.status_id = ?
+		)
+		ORDER BY w.id`, status.ID, replacement.ID).Scan(&workflows).Error
+	if err != nil {
+		return err
+	}
+	if len(workflows) > 0 {
+		return custom_errors.NewInvalidStatusReplacementError(
+			fmt.Sprintf("status %d is not used by workflows of the tasks: %s", replacement.ID, strings.Join(workflows, ", ")))
+	}
+	return nil
+}
+
+// replaceTaskStatus переносит задачи статуса, включая удалённые, в replacement и пишет переход в их историю
+func replaceTaskStatus(tx *gorm.DB, status, replacement *models.TaskStatus, actorID uuid.UUID) error {
+	err := tx.Exec(`
+		INSERT INTO task_service.task_events (task_id, actor_id, event_type, old_value, new_value, created_at)
+		SELECT id, ?, ?, ?, ?, NOW() FROM task_service.tasks WHERE status = ?`,
+		actorID, models.TaskEventStatusChanged, status.Name, replacement.Name, status.ID).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Unscoped().
+		Model(&models.Task{}).
+		Where("status = ?", status.ID).
+		Update("status", replacement.ID).Error
+}
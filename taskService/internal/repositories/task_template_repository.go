@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"taskService/internal/models"
+)
+
+type TaskTemplateRepository interface {
+	// Create сохраняет шаблон вместе с метками и пунктами чек-листа
+	Create(template *models.TaskTemplate) error
+	// Update в одной транзакции заменяет поля, метки и пункты чек-листа шаблона
+	Update(template *models.TaskTemplate) error
+	GetByID(id int) (*models.TaskTemplate, error)
+	GetByName(name string) (*models.TaskTemplate, error)
+	// GetAll возвращает шаблоны, созданные viewerID или назначающие его исполнителем; nil - все шаблоны
+	GetAll(viewerID *uuid.UUID) ([]models.TaskTemplate, error)
+	Delete(id int) error
+	// CreateTask сохраняет задачу, созданную по шаблону, с вложениями, метками и чек-листом в одной транзакции;
+	// TaskID вложений и пунктов заполняется после сохранения задачи
+	CreateTask(task *models.Task, files []models.TaskFile, labelIDs []int, checklist []models.TaskChecklistItem) error
+}
+
+type taskTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskTemplateRepository(db *gorm.DB) TaskTemplateRepository {
+	return &taskTemplateRepository{db: db}
+}
+
+func (r *taskTemplateRepository) Create(template *models.TaskTemplate) error {
+	return r.db.Create(template).Error
+}
+
+func (r *taskTemplateRepository) Update(template *models.TaskTemplate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.TaskTemplate{ID: template.ID}).
+			Select("Name", "TitlePattern", "Description", "ExecutorID", "Priority", "UpdatedAt").
+			Updates(template)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Where("template_id = ?", template.ID).Delete(&models.TaskTemplateLabel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("template_id = ?", template.ID).Delete(&models.TaskTemplateChecklistItem{}).Error; err != nil {
+			return err
+		}
+		for i := range template.Labels {
+			template.Labels[i].TemplateID = template.ID
+		}
+		if len(template.Labels) > 0 {
+			if err := tx.Omit("Label").Create(&template.Labels).Error; err != nil {
+				return err
+			}
+		}
+		for i := range template.ChecklistItems {
+			template.ChecklistItems[i].TemplateID = template.ID
+		}
+		if len(template.ChecklistItems) > 0 {
+			return tx.Create(&template.ChecklistItems).Error
+		}
+		return nil
+	})
+}
+
+func (r *taskTemplateRepository) GetByID(id int) (*models.TaskTemplate, error) {
+	var template models.TaskTemplate
+	if err := r.withDetails().First(&template, id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *taskTemplateRepository) GetByName(name string) (*models.TaskTemplate, error) {
+	var template models.TaskTemplate
+	if err := r.db.Where("name = ?", name).First(&template).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetAll возвращает шаблоны по названию
+func (r *taskTemplateRepository) GetAll(viewerID *uuid.UUID) ([]models.TaskTemplate, error) {
+	query := r.withDetails()
+	if viewerID != nil {
+		query = query.Where("creator_id = ? OR executor_id = ?", *viewerID, *viewerID)
+	}
+
+	templates := []models.TaskTemplate{}
+	err := query.Order("name").Find(&templates).Error
+	return templates, err
+}
+
+// Delete выполняет мягкое удаление шаблона; созданные по нему задачи не меняются
+func (r *taskTemplateRepository) Delete(id int) error {
+	result := r.db.Delete(&models.TaskTemplate{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// withDetails подгружает метки и упорядоченные пункты чек-листа шаблона
+func (r *taskTemplateRepository) withDetails() *gorm.DB {
+	return r.db.
+		Preload("Labels.Label").
+		Preload("ChecklistItems", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") })
+}
+
+func (r *taskTemplateRepository) CreateTask(task *models.Task, files []models.TaskFile, labelIDs []int, checklist []models.TaskChecklistItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := NewTaskRepository(tx).Create(task); err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			for i := range files {
+				files[i].TaskID = task.ID
+			}
+			if err := tx.Create(&files).Error; err != nil {
+				return err
+			}
+		}
+		if len(labelIDs) > 0 {
+			taskLabels := make([]models.TaskLabel, 0, len(labelIDs))
+			for _, labelID := range labelIDs {
+				taskLabels = append(taskLabels, models.TaskLabel{TaskID: task.ID, LabelID: labelID})
+			}
+			if err := tx.Create(&taskLabels).Error; err != nil {
+				return err
+			}
+		}
+		if len(checklist) > 0 {
+			for i := range checklist {
+				checklist[i].TaskID = task.ID
+			}
+			if err := tx.Create(&checklist).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
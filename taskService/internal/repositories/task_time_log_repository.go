@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type TaskTimeLogRepository interface {
+	Create(log *models.TaskTimeLog) error
+	// StartTimer создаёт запущенный таймер; false означает, что у пользователя уже запущен другой таймер
+	StartTimer(log *models.TaskTimeLog) (bool, error)
+	Update(log *models.TaskTimeLog) error
+	Delete(logID int) error
+	GetByID(logID int) (*models.TaskTimeLog, error)
+	// GetRunning возвращает запущенный таймер пользователя или gorm.ErrRecordNotFound
+	GetRunning(userID uuid.UUID) (*models.TaskTimeLog, error)
+	GetByTaskID(taskID int, limit, offset int) ([]models.TaskTimeLog, error)
+	GetTimeSpent(query *dto.TimeSpentQuery, visibility *dto.TaskVisibility) ([]dto.TimeSpent, error)
+}
+
+type taskTimeLogRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskTimeLogRepository(db *gorm.DB) TaskTimeLogRepository {
+	return &taskTimeLogRepository{db: db}
+}
+
+func (r *taskTimeLogRepository) Create(log *models.TaskTimeLog) error {
+	return r.db.Create(log).Error
+}
+
+// StartTimer опирается на уникальный индекс task_time_logs_running_idx, поэтому два одновременных
+// запуска не создадут второй таймер
+func (r *taskTimeLogRepository) StartTimer(log *models.TaskTimeLog) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "user_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "ended_at IS NULL"}}},
+		DoNothing:   true,
+	}).Create(log)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *taskTimeLogRepository) Update(log *models.TaskTimeLog) error {
+	result := r.db.Model(&models.TaskTimeLog{ID: log.ID}).
+		Select("StartedAt", "EndedAt", "Minutes", "Description", "UpdatedAt").
+		Updates(log)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return custom_errors.NewTaskTimeLogNotFoundError(log.ID)
+	}
+	return nil
+}
+
+func (r *taskTimeLogRepository) Delete(logID int) error {
+	result := r.db.Delete(&models.TaskTimeLog{}, logID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return custom_errors.NewTaskTimeLogNotFoundError(logID)
+	}
+	return nil
+}
+
+func (r *taskTimeLogRepository) GetByID(logID int) (*models.TaskTimeLog, error) {
+	var log models.TaskTimeLog
+	if err := r.db.First(&log, logID).Error; err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+func (r *taskTimeLogRepository) GetRunning(userID uuid.UUID) (*models.TaskTimeLog, error) {
+	var log models.TaskTimeLog
+	if err := r.db.Where("user_id = ? AND ended_at IS NULL", userID).Take(&log).Error; err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+// GetByTaskID возвращает записи задачи, последние первыми
+func (r *taskTimeLogRepository) GetByTaskID(taskID int, limit, offset int) ([]models.TaskTimeLog, error) {
+	logs := []models.TaskTimeLog{}
+	err := r.db.
+		Where("task_id = ?", taskID).
+		Order("started_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&logs).Error
+	return logs, err
+}
+
+// GetTimeSpent суммирует остановленные записи неудалённых задач по группе query.GroupBy,
+// больше всего времени первыми. Учитываются только задачи, видимые по visibility
+func (r *taskTimeLogRepository) GetTimeSpent(query *dto.TimeSpentQuery, visibility *dto.TaskVisibility) ([]dto.TimeSpent, error) {
+	scope := r.db.
+		Table("task_service.task_time_logs AS l").
+		Joins("JOIN task_service.tasks t ON t.id = l.task_id AND t.deleted_at IS NULL").
+		Where("l.ended_at IS NOT NULL")
+	scope = applyVisibility(scope, visibility)
+	if query.From != nil {
+		scope = scope.Where("l.started_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		scope = scope.Where("l.started_at < ?", *query.To)
+	}
+	if query.TaskID != nil {
+		scope = scope.Where("l.task_id = ?", *query.TaskID)
+	}
+	if query.UserID != nil {
+		scope = scope.Where("l.user_id = ?", *query.UserID)
+	}
+	if query.ChatID != nil {
+		scope = scope.Where("t.chat_id = ?", *query.ChatID)
+	}
+
+	const totals = "SUM(l.minutes) AS minutes, COUNT(*) AS entries"
+	switch query.GroupBy {
+	case dto.TimeSpentByUser:
+		scope = scope.Select("l.user_id, " + totals).Group("l.user_id")
+	case dto.TimeSpentByChat:
+		scope = scope.Select("t.chat_id, "+totals).
+			Where("t.chat_id <> ?", uuid.Nil).
+			Group("t.chat_id")
+	default:
+		scope = scope.Select("t.id AS task_id, t.title AS task_title, t.estimate_minutes, " + totals).
+			Group("t.id")
+	}
+
+	rows := []dto.TimeSpent{}
+	err := scope.Order("minutes DESC").Scan(&rows).Error
+	return rows, err
+}
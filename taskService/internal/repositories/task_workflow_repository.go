@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"taskService/internal/models"
+)
+
+type TaskWorkflowRepository interface {
+	Create(workflow *models.TaskWorkflow) error
+	Replace(workflow *models.TaskWorkflow) error
+	GetByID(id int) (*models.TaskWorkflow, error)
+	GetByName(name string) (*models.TaskWorkflow, error)
+	GetDefault() (*models.TaskWorkflow, error)
+	GetAll() ([]models.TaskWorkflow, error)
+	DeleteByID(id int) error
+}
+
+type taskWorkflowRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskWorkflowRepository(db *gorm.DB) TaskWorkflowRepository {
+	return &taskWorkflowRepository{db: db}
+}
+
+// Create сохраняет workflow вместе со статусами и переходами
+func (r *taskWorkflowRepository) Create(workflow *models.TaskWorkflow) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if workflow.IsDefault {
+			if err := resetDefaultWorkflow(tx); err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Statuses.Status").Create(workflow).Error
+	})
+}
+
+// Replace полностью заменяет название, признак по умолчанию, статусы и переходы workflow
+func (r *taskWorkflowRepository) Replace(workflow *models.TaskWorkflow) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if workflow.IsDefault {
+			if err := resetDefaultWorkflow(tx); err != nil {
+				return err
+			}
+		}
+
+		result := tx.Model(&models.TaskWorkflow{ID: workflow.ID}).
+			Select("Name", "IsDefault").
+			Updates(workflow)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Where("workflow_id = ?", workflow.ID).Delete(&models.TaskWorkflowStatus{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workflow_id = ?", workflow.ID).Delete(&models.TaskWorkflowTransition{}).Error; err != nil {
+			return err
+		}
+
+		for i := range workflow.Statuses {
+			workflow.Statuses[i].WorkflowID = workflow.ID
+		}
+		for i := range workflow.Transitions {
+			workflow.Transitions[i].WorkflowID = workflow.ID
+		}
+		if len(workflow.Statuses) > 0 {
+			if err := tx.Omit("Status").Create(&workflow.Statuses).Error; err != nil {
+				return err
+			}
+		}
+		if len(workflow.Transitions) > 0 {
+			if err := tx.Create(&workflow.Transitions).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *taskWorkflowRepository) GetByID(id int) (*models.TaskWorkflow, error) {
+	var workflow models.TaskWorkflow
+	if err := r.withDefinition().First(&workflow, id).Error; err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+func (r *taskWorkflowRepository) GetByName(name string) (*models.TaskWorkflow, error) {
+	var workflow models.TaskWorkflow
+	if err := r.db.Where("name = ?", name).First(&workflow).Error; err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+func (r *taskWorkflowRepository) GetDefault() (*models.TaskWorkflow, error) {
+	var workflow models.TaskWorkflow
+	if err := r.withDefinition().Where("is_default").First(&workflow).Error; err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+func (r *taskWorkflowRepository) GetAll() ([]models.TaskWorkflow, error) {
+	var workflows []models.TaskWorkflow
+	if err := r.withDefinition().Order("id").Find(&workflows).Error; err != nil {
+		return nil, err
+	}
+	return workflows, nil
+}
+
+func (r *taskWorkflowRepository) DeleteByID(id int) error {
+	result := r.db.Delete(&models.TaskWorkflow{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *taskWorkflowRepository) withDefinition() *gorm.DB {
+	return r.db.
+		Preload("Statuses", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Preload("Statuses.Status").
+		Preload("Transitions")
+}
+
+func resetDefaultWorkflow(tx *gorm.DB) error {
+	return tx.Model(&models.TaskWorkflow{}).Where("is_default").Update("is_default", false).Error
+}
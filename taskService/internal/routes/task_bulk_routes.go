@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"taskService/internal/handlers"
+)
+
+func RegisterTaskBulkRoutes(r *gin.Engine, handler *handlers.TaskBulkHandler) {
+	v1 := r.Group("/api/v1")
+
+	v1.POST("/tasks/bulk", handler.ApplyBulk)
+}
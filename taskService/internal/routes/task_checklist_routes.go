@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"taskService/internal/handlers"
+)
+
+func RegisterTaskChecklistRoutes(r *gin.Engine, handler *handlers.TaskChecklistHandler) {
+	v1 := r.Group("/api/v1")
+
+	checklist := v1.Group("/tasks/:task_id/checklist")
+	{
+		checklist.GET("", handler.GetByTaskID)
+		checklist.POST("", handler.AddItem)
+		checklist.PATCH("/:item_id", handler.UpdateItem)
+		checklist.DELETE("/:item_id", handler.RemoveItem)
+	}
+}
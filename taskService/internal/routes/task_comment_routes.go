@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"taskService/internal/handlers"
+)
+
+func RegisterTaskCommentRoutes(r *gin.Engine, handler *handlers.TaskCommentHandler) {
+	v1 := r.Group("/api/v1")
+
+	comments := v1.Group("/tasks/:task_id/comments")
+	{
+		comments.POST("", handler.Create)
+		comments.GET("", handler.GetByTaskID)
+		comments.PATCH("/:comment_id", handler.Update)
+		comments.DELETE("/:comment_id", handler.Delete)
+	}
+}
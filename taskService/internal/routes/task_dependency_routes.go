@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"taskService/internal/handlers"
+)
+
+func RegisterTaskDependencyRoutes(r *gin.Engine, handler *handlers.TaskDependencyHandler) {
+	v1 := r.Group("/api/v1")
+
+	tasks := v1.Group("/tasks/:task_id")
+	{
+		tasks.GET("/tree", handler.GetTree)
+		tasks.GET("/dependencies", handler.GetDependencyGraph)
+		tasks.POST("/dependencies", handler.AddDependency)
+		tasks.DELETE("/dependencies/:blocker_task_id", handler.RemoveDependency)
+	}
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"taskService/internal/handlers"
+)
+
+func RegisterTaskLabelRoutes(r *gin.Engine, handler *handlers.TaskLabelHandler) {
+	v1 := r.Group("/api/v1")
+
+	labels := v1.Group("/tasks/labels")
+	{
+		labels.POST("", handler.Create)
+		labels.GET("", handler.GetAll)
+		labels.GET("/:label_id", handler.GetByID)
+		labels.PUT("/:label_id", handler.Update)
+		labels.DELETE("/:label_id", handler.DeleteByID)
+	}
+
+	tasks := v1.Group("/tasks/:task_id")
+	{
+		tasks.GET("/labels", handler.GetTaskLabels)
+		tasks.POST("/labels/:label_id", handler.AddToTask)
+		tasks.DELETE("/labels/:label_id", handler.RemoveFromTask)
+	}
+}
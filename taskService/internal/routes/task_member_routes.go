@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"taskService/internal/handlers"
+)
+
+func RegisterTaskMemberRoutes(r *gin.Engine, handler *handlers.TaskMemberHandler) {
+	v1 := r.Group("/api/v1")
+
+	tasks := v1.Group("/tasks/:task_id")
+	{
+		tasks.POST("/assignees/:user_id", handler.AddAssignee)
+		tasks.DELETE("/assignees/:user_id", handler.RemoveAssignee)
+		tasks.POST("/watchers/:user_id", handler.AddWatcher)
+		tasks.DELETE("/watchers/:user_id", handler.RemoveWatcher)
+	}
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"taskService/internal/handlers"
+)
+
+func RegisterTaskRecurrenceRoutes(r *gin.Engine, handler *handlers.TaskRecurrenceHandler) {
+	v1 := r.Group("/api/v1")
+
+	recurrences := v1.Group("/tasks/recurrences")
+	{
+		recurrences.POST("", handler.Create)
+		recurrences.GET("/:recurrence_id", handler.GetByID)
+		recurrences.PUT("/:recurrence_id", handler.Update)
+		recurrences.DELETE("/:recurrence_id", handler.Delete)
+	}
+}
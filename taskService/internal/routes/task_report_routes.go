@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"taskService/internal/handlers"
+)
+
+func RegisterTaskReportRoutes(r *gin.Engine, handler *handlers.TaskReportHandler) {
+	v1 := r.Group("/api/v1")
+
+	reports := v1.Group("/reports")
+	{
+		reports.GET("/throughput", handler.GetThroughput)
+		reports.GET("/status-durations", handler.GetStatusDurations)
+		reports.GET("/overdue", handler.GetOverdue)
+		reports.GET("/cumulative-flow", handler.GetCumulativeFlow)
+	}
+}
@@ -11,12 +11,26 @@ func RegisterTaskRoutes(r *gin.Engine, handler *handlers.TaskHandler) {
 	tasks := v1.Group("/tasks")
 	{
 		tasks.POST("", handler.CreateTask)
+		tasks.POST("/from-message", handler.CreateTaskFromMessage)
+		tasks.GET("/search", handler.SearchTasks)
+		tasks.GET("/board", handler.GetBoard)
+		tasks.POST("/:task_id/move", handler.MoveTask)
 		tasks.PATCH("/:task_id/status/:status_id", handler.UpdateTaskStatus)
 		tasks.GET("/:task_id", handler.GetTaskByID)
+		tasks.PATCH("/:task_id", handler.UpdateTask)
+		tasks.DELETE("/:task_id", handler.DeleteTask)
+		tasks.GET("/:task_id/history", handler.GetTaskHistory)
 	}
 
 	users := v1.Group("/users")
 	{
 		users.GET("/:user_id/tasks", handler.GetUserTasks)
+		users.GET("/:user_id/tasks/created", handler.GetCreatedTasks)
+		users.GET("/:user_id/activity", handler.GetUserActivity)
+	}
+
+	chats := v1.Group("/chats")
+	{
+		chats.GET("/:chat_id/tasks", handler.GetChatTasks)
 	}
 }
@@ -10,6 +10,7 @@ func RegisterTaskStatusRoutes(r *gin.Engine, handler *handlers.TaskStatusHandler
 
 	v1.POST("/tasks/statuses", handler.Create)
 	v1.GET("/tasks/statuses/:id", handler.GetByID)
+	v1.GET("/tasks/statuses/:id/usage", handler.GetUsage)
 	v1.DELETE("/tasks/statuses/:id", handler.DeleteByID)
 	v1.GET("/tasks/statuses", handler.GetAll)
 }
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"taskService/internal/handlers"
+)
+
+func RegisterTaskTemplateRoutes(r *gin.Engine, handler *handlers.TaskTemplateHandler) {
+	v1 := r.Group("/api/v1")
+
+	templates := v1.Group("/tasks/templates")
+	{
+		templates.POST("", handler.Create)
+		templates.GET("", handler.GetAll)
+		templates.GET("/:template_id", handler.GetByID)
+		templates.PUT("/:template_id", handler.Update)
+		templates.DELETE("/:template_id", handler.Delete)
+		templates.POST("/:template_id/tasks", handler.CreateTask)
+	}
+}
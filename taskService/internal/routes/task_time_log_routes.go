@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"taskService/internal/handlers"
+)
+
+func RegisterTaskTimeLogRoutes(r *gin.Engine, handler *handlers.TaskTimeLogHandler) {
+	v1 := r.Group("/api/v1")
+
+	timeLogs := v1.Group("/tasks/:task_id/time-logs")
+	{
+		timeLogs.POST("", handler.Create)
+		timeLogs.GET("", handler.GetByTaskID)
+		timeLogs.POST("/start", handler.StartTimer)
+		timeLogs.POST("/stop", handler.StopTimer)
+		timeLogs.PATCH("/:log_id", handler.Update)
+		timeLogs.DELETE("/:log_id", handler.Delete)
+	}
+
+	v1.GET("/time-spent", handler.GetTimeSpent)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"taskService/internal/handlers"
+)
+
+func RegisterTaskWorkflowRoutes(r *gin.Engine, handler *handlers.TaskWorkflowHandler) {
+	v1 := r.Group("/api/v1")
+
+	workflows := v1.Group("/tasks/workflows")
+	{
+		workflows.POST("", handler.Create)
+		workflows.GET("", handler.GetAll)
+		workflows.GET("/:workflow_id", handler.GetByID)
+		workflows.PUT("/:workflow_id", handler.Update)
+		workflows.DELETE("/:workflow_id", handler.DeleteByID)
+	}
+}
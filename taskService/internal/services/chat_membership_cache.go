@@ -0,0 +1,62 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"taskService/internal/config"
+	"taskService/internal/http_clients"
+)
+
+// ChatMembershipCache хранит чаты пользователей, полученные из чат-сервиса, в течение TTL. По ним
+// проверяется видимость задач, поэтому исключение из чата действует не позже чем через TTL
+type ChatMembershipCache struct {
+	chatClient http_clients.ChatClientInterface
+	config     config.ChatMembershipCacheConfig
+
+	mu      sync.Mutex
+	entries map[uuid.UUID]chatMembershipEntry
+}
+
+type chatMembershipEntry struct {
+	chatIDs   []uuid.UUID
+	expiresAt time.Time
+}
+
+func NewChatMembershipCache(chatClient http_clients.ChatClientInterface, cfg config.ChatMembershipCacheConfig) *ChatMembershipCache {
+	return &ChatMembershipCache{
+		chatClient: chatClient,
+		config:     cfg,
+		entries:    make(map[uuid.UUID]chatMembershipEntry),
+	}
+}
+
+// GetUserChatIDs возвращает чаты пользователя из кэша или из чат-сервиса. Ошибка чат-сервиса
+// не кэшируется
+func (s *ChatMembershipCache) GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	entry, ok := s.entries[userID]
+	s.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.chatIDs, nil
+	}
+
+	chatIDs, err := s.chatClient.GetUserChatIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Заодно убираем устаревшие записи, чтобы кэш не рос без ограничений
+	for id, e := range s.entries {
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, id)
+		}
+	}
+	s.entries[userID] = chatMembershipEntry{chatIDs: chatIDs, expiresAt: now.Add(s.config.TTL)}
+	return chatIDs, nil
+}
@@ -1,7 +1,15 @@
 package services
 
+import "common/models"
+
 // NotificationProducerInterface - интерфейс для Kafka NotificationProducer для возможности мокирования
 type NotificationProducerInterface interface {
 	SendNotification(notification interface{}) error
 	Close() error
 }
+
+// TaskEventProducerInterface - интерфейс для Kafka TaskEventProducer для возможности мокирования
+type TaskEventProducerInterface interface {
+	SendTaskEvent(event *models.TaskDomainEvent) error
+	Close() error
+}
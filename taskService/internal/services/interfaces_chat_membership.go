@@ -0,0 +1,8 @@
+package services
+
+import "github.com/google/uuid"
+
+// ChatMembershipServiceInterface - интерфейс для ChatMembershipCache для возможности мокирования
+type ChatMembershipServiceInterface interface {
+	GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error)
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -13,5 +15,26 @@ type NotificationServiceInterface interface {
 		executorID uuid.UUID,
 		executorEmail string,
 	) error
+	SendTaskDueSoonNotification(taskID int, taskTitle, priority string, executorID uuid.UUID, executorEmail string, dueAt time.Time) error
+	SendTaskOverdueNotification(taskID int, taskTitle, priority string, executorID uuid.UUID, executorEmail string, dueAt time.Time) error
+	SendTaskCommentNotification(
+		taskID int,
+		taskTitle string,
+		commentID int,
+		authorName string,
+		body string,
+		recipientID uuid.UUID,
+		recipientEmail string,
+		mentioned bool,
+	) error
+	SendTaskStatusChangedNotification(
+		taskID int,
+		taskTitle string,
+		actorName string,
+		oldStatus string,
+		newStatus string,
+		recipientID uuid.UUID,
+		recipientEmail string,
+	) error
 	Close() error
 }
@@ -0,0 +1,8 @@
+package services
+
+import "taskService/internal/models"
+
+// TaskEventPublisherInterface - интерфейс для TaskEventPublisher для возможности мокирования
+type TaskEventPublisherInterface interface {
+	Publish(task *models.Task, events []models.TaskEvent)
+}
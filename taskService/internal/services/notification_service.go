@@ -65,6 +65,149 @@ func (ns *NotificationService) SendTaskCreatedNotification(
 	return nil
 }
 
+// SendTaskDueSoonNotification напоминает исполнителю о приближении срока задачи
+func (ns *NotificationService) SendTaskDueSoonNotification(
+	taskID int,
+	taskTitle string,
+	priority string,
+	executorID uuid.UUID,
+	executorEmail string,
+	dueAt time.Time,
+) error {
+	return ns.sendTaskDeadlineNotification(models.NotificationTaskDueSoon, taskID, taskTitle, priority, executorID, executorEmail, dueAt)
+}
+
+// SendTaskOverdueNotification сообщает исполнителю, что срок задачи истёк
+func (ns *NotificationService) SendTaskOverdueNotification(
+	taskID int,
+	taskTitle string,
+	priority string,
+	executorID uuid.UUID,
+	executorEmail string,
+	dueAt time.Time,
+) error {
+	return ns.sendTaskDeadlineNotification(models.NotificationTaskOverdue, taskID, taskTitle, priority, executorID, executorEmail, dueAt)
+}
+
+func (ns *NotificationService) sendTaskDeadlineNotification(
+	notificationType models.NotificationType,
+	taskID int,
+	taskTitle string,
+	priority string,
+	executorID uuid.UUID,
+	executorEmail string,
+	dueAt time.Time,
+) error {
+	if executorEmail == "" {
+		log.Printf("No executor email provided for task %d, skipping %s notification", taskID, notificationType)
+		return nil
+	}
+
+	notification := &models.TaskDeadlineNotification{
+		BaseNotification: models.BaseNotification{
+			ID:        uuid.New(),
+			Type:      notificationType,
+			Email:     executorEmail,
+			CreatedAt: time.Now(),
+		},
+		TaskID:     taskID,
+		TaskTitle:  taskTitle,
+		ExecutorID: executorID,
+		Priority:   priority,
+		DueAt:      dueAt,
+	}
+
+	if err := ns.producer.SendNotification(notification); err != nil {
+		return fmt.Errorf("failed to send %s notification: %w", notificationType, err)
+	}
+
+	log.Printf("Task %s notification sent for task %d to %s", notificationType, taskID, executorEmail)
+	return nil
+}
+
+// SendTaskCommentNotification сообщает участнику задачи о новом комментарии.
+// Упомянутые в комментарии пользователи получают уведомление task_mention
+func (ns *NotificationService) SendTaskCommentNotification(
+	taskID int,
+	taskTitle string,
+	commentID int,
+	authorName string,
+	body string,
+	recipientID uuid.UUID,
+	recipientEmail string,
+	mentioned bool,
+) error {
+	notificationType := models.NotificationTaskComment
+	if mentioned {
+		notificationType = models.NotificationTaskMention
+	}
+
+	if recipientEmail == "" {
+		log.Printf("No recipient email provided for comment %d, skipping %s notification", commentID, notificationType)
+		return nil
+	}
+
+	notification := &models.TaskCommentNotification{
+		BaseNotification: models.BaseNotification{
+			ID:        uuid.New(),
+			Type:      notificationType,
+			Email:     recipientEmail,
+			CreatedAt: time.Now(),
+		},
+		TaskID:      taskID,
+		TaskTitle:   taskTitle,
+		CommentID:   commentID,
+		AuthorName:  authorName,
+		Body:        body,
+		RecipientID: recipientID,
+	}
+
+	if err := ns.producer.SendNotification(notification); err != nil {
+		return fmt.Errorf("failed to send %s notification: %w", notificationType, err)
+	}
+
+	log.Printf("Task %s notification sent for comment %d to %s", notificationType, commentID, recipientEmail)
+	return nil
+}
+
+// SendTaskStatusChangedNotification сообщает исполнителю, соисполнителю или наблюдателю о смене статуса задачи
+func (ns *NotificationService) SendTaskStatusChangedNotification(
+	taskID int,
+	taskTitle string,
+	actorName string,
+	oldStatus string,
+	newStatus string,
+	recipientID uuid.UUID,
+	recipientEmail string,
+) error {
+	if recipientEmail == "" {
+		log.Printf("No recipient email provided for task %d, skipping status changed notification", taskID)
+		return nil
+	}
+
+	notification := &models.TaskStatusChangedNotification{
+		BaseNotification: models.BaseNotification{
+			ID:        uuid.New(),
+			Type:      models.NotificationTaskStatusChanged,
+			Email:     recipientEmail,
+			CreatedAt: time.Now(),
+		},
+		TaskID:      taskID,
+		TaskTitle:   taskTitle,
+		ActorName:   actorName,
+		OldStatus:   oldStatus,
+		NewStatus:   newStatus,
+		RecipientID: recipientID,
+	}
+
+	if err := ns.producer.SendNotification(notification); err != nil {
+		return fmt.Errorf("failed to send task status changed notification: %w", err)
+	}
+
+	log.Printf("Task status changed notification sent for task %d to %s", taskID, recipientEmail)
+	return nil
+}
+
 func (ns *NotificationService) Close() error {
 	return ns.producer.Close()
 }
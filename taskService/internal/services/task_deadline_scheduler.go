@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"taskService/internal/config"
+	"taskService/internal/http_clients"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+)
+
+// TaskDeadlineScheduler периодически находит задачи с приближающимся или истёкшим сроком и отправляет
+// исполнителю напоминания через NotificationService. Перед отправкой напоминание атомарно отмечается
+// в БД, поэтому несколько реплик не дублируют уведомления; при ошибке Kafka напоминание не повторяется
+type TaskDeadlineScheduler struct {
+	taskRepo            repositories.TaskRepository
+	userClient          http_clients.UserClientInterface
+	notificationService NotificationServiceInterface
+	config              config.DeadlineSchedulerConfig
+}
+
+func NewTaskDeadlineScheduler(
+	taskRepo repositories.TaskRepository,
+	userClient http_clients.UserClientInterface,
+	notificationService NotificationServiceInterface,
+	cfg config.DeadlineSchedulerConfig,
+) *TaskDeadlineScheduler {
+	return &TaskDeadlineScheduler{
+		taskRepo:            taskRepo,
+		userClient:          userClient,
+		notificationService: notificationService,
+		config:              cfg,
+	}
+}
+
+// Start запускает проверку сразу и затем с периодом Interval до отмены контекста
+func (s *TaskDeadlineScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunOnce(time.Now()); err != nil {
+			log.Printf("Task deadline check failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Task deadline scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce выполняет одну проверку сроков на момент now (публичный для тестирования)
+func (s *TaskDeadlineScheduler) RunOnce(now time.Time) error {
+	dueSoon, err := s.taskRepo.GetDueSoon(now, now.Add(s.config.DueSoonWindow), s.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get tasks due soon: %w", err)
+	}
+	for i := range dueSoon {
+		s.remind(&dueSoon[i], now, s.taskRepo.ClaimDueSoonReminder, s.notificationService.SendTaskDueSoonNotification)
+	}
+
+	overdue, err := s.taskRepo.GetOverdue(now, s.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get overdue tasks: %w", err)
+	}
+	for i := range overdue {
+		s.remind(&overdue[i], now, s.taskRepo.ClaimOverdueReminder, s.notificationService.SendTaskOverdueNotification)
+	}
+	return nil
+}
+
+type reminderClaimFunc func(taskID int, dueAt, now time.Time) (bool, error)
+
+type reminderSendFunc func(taskID int, taskTitle, priority string, executorID uuid.UUID, executorEmail string, dueAt time.Time) error
+
+func (s *TaskDeadlineScheduler) remind(task *models.Task, now time.Time, claim reminderClaimFunc, send reminderSendFunc) {
+	if task.DueAt == nil {
+		return
+	}
+
+	claimed, err := claim(task.ID, *task.DueAt, now)
+	if err != nil {
+		log.Printf("Failed to claim deadline reminder for task %d: %v", task.ID, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	executor, err := s.userClient.GetUserByID(&task.ExecutorID)
+	if err != nil || executor.User == nil {
+		log.Printf("Failed to get executor %s for task %d reminder: %v", task.ExecutorID, task.ID, err)
+		return
+	}
+
+	if err := send(task.ID, task.Title, task.Priority, task.ExecutorID, executor.User.Email, *task.DueAt); err != nil {
+		log.Printf("Failed to send deadline reminder for task %d: %v", task.ID, err)
+	}
+}
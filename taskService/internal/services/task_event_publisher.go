@@ -0,0 +1,100 @@
+package services
+
+import (
+	"common/kafka"
+	commonModels "common/models"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"taskService/internal/models"
+)
+
+// taskDomainEventTypes - события истории, которые публикуются в Kafka, и их типы в топике
+var taskDomainEventTypes = map[string]commonModels.TaskDomainEventType{
+	models.TaskEventCreated:       commonModels.TaskDomainEventCreated,
+	models.TaskEventStatusChanged: commonModels.TaskDomainEventStatusChanged,
+	models.TaskEventReassigned:    commonModels.TaskDomainEventReassigned,
+}
+
+// TaskEventPublisher публикует доменные события задач, привязанных к чату.
+// Ключ идемпотентности берётся из записи истории, поэтому повторная отправка того же события
+// не создаёт у потребителей дубликатов
+type TaskEventPublisher struct {
+	producer TaskEventProducerInterface
+}
+
+func NewTaskEventPublisher(kafkaConfig *kafka.ProducerConfig) (*TaskEventPublisher, error) {
+	producer, err := kafka.NewTaskEventProducer(kafkaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task event producer: %w", err)
+	}
+
+	return &TaskEventPublisher{
+		producer: producer,
+	}, nil
+}
+
+// NewTaskEventPublisherWithProducer создает publisher с указанным producer (для тестирования)
+func NewTaskEventPublisherWithProducer(producer TaskEventProducerInterface) *TaskEventPublisher {
+	return &TaskEventPublisher{
+		producer: producer,
+	}
+}
+
+// Publish отправляет события создания, смены статуса и переназначения задачи; остальные события
+// и события задач без чата пропускаются. Ошибка отправки логируется и не влияет на изменение задачи
+func (p *TaskEventPublisher) Publish(task *models.Task, events []models.TaskEvent) {
+	if task.ChatID == uuid.Nil {
+		return
+	}
+
+	for _, event := range events {
+		eventType, ok := taskDomainEventTypes[event.EventType]
+		if !ok {
+			continue
+		}
+
+		domainEvent := &commonModels.TaskDomainEvent{
+			EventID:    taskDomainEventID(&event),
+			Type:       eventType,
+			TaskID:     task.ID,
+			TaskTitle:  task.Title,
+			ChatID:     task.ChatID,
+			ActorID:    event.ActorID,
+			OccurredAt: event.CreatedAt,
+		}
+		if domainEvent.OccurredAt.IsZero() {
+			domainEvent.OccurredAt = time.Now()
+		}
+		if eventType != commonModels.TaskDomainEventCreated {
+			if event.OldValue != nil {
+				domainEvent.OldValue = *event.OldValue
+			}
+			if event.NewValue != nil {
+				domainEvent.NewValue = *event.NewValue
+			}
+		}
+
+		if err := p.producer.SendTaskEvent(domainEvent); err != nil {
+			log.Printf("Failed to publish task event %s for task %d: %v", domainEvent.EventID, task.ID, err)
+		}
+	}
+}
+
+func (p *TaskEventPublisher) Close() error {
+	return p.producer.Close()
+}
+
+// taskDomainEventID возвращает ключ идемпотентности события. Для событий, которые не удалось
+// записать в историю, ключ генерируется заново
+func taskDomainEventID(event *models.TaskEvent) string {
+	if event.EventType == models.TaskEventCreated {
+		return commonModels.TaskCreatedEventID(event.TaskID)
+	}
+	if event.ID == 0 {
+		return uuid.NewString()
+	}
+	return commonModels.TaskHistoryEventID(event.ID)
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	customErrors "taskService/internal/custom_errors"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+)
+
+// InitialTaskStatus возвращает стартовый статус новой задачи: первый статус выбранного workflow или "created".
+// Используется и при создании задачи, и при создании экземпляров повторяющихся задач
+func InitialTaskStatus(
+	statusRepo repositories.TaskStatusRepository,
+	workflowRepo repositories.TaskWorkflowRepository,
+	workflowID *int,
+) (*models.TaskStatus, error) {
+	if workflowID == nil {
+		status, err := statusRepo.GetByName(models.TaskStatusCreated)
+		if err != nil {
+			return nil, customErrors.NewTaskStatusNotFoundError(models.TaskStatusCreated)
+		}
+		return status, nil
+	}
+
+	workflow, err := workflowRepo.GetByID(*workflowID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customErrors.NewWorkflowNotFoundError(*workflowID)
+		}
+		return nil, err
+	}
+	if len(workflow.Statuses) == 0 || workflow.Statuses[0].Status == nil {
+		return nil, customErrors.NewInvalidWorkflowError("workflow has no statuses")
+	}
+	return workflow.Statuses[0].Status, nil
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"taskService/internal/config"
+	"taskService/internal/http_clients"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+)
+
+// maxOccurrencesPerRun - максимум экземпляров одной серии за проверку; остальные создаются при следующих
+const maxOccurrencesPerRun = 100
+
+// TaskRecurrenceScheduler периодически создаёт экземпляры повторяющихся задач на Horizon вперёд.
+// Экземпляры создаются в одной транзакции со сдвигом NextOccurrenceAt серии, который выполняется только
+// при неизменной с момента чтения серии, поэтому несколько реплик не создают экземпляры повторно.
+// notificationService и eventPublisher могут быть nil: тогда экземпляры создаются без уведомлений и доменных событий
+type TaskRecurrenceScheduler struct {
+	recurrenceRepo      repositories.TaskRecurrenceRepository
+	statusRepo          repositories.TaskStatusRepository
+	workflowRepo        repositories.TaskWorkflowRepository
+	taskEventRepo       repositories.TaskEventRepository
+	userClient          http_clients.UserClientInterface
+	notificationService NotificationServiceInterface
+	eventPublisher      TaskEventPublisherInterface
+	config              config.RecurrenceSchedulerConfig
+}
+
+func NewTaskRecurrenceScheduler(
+	recurrenceRepo repositories.TaskRecurrenceRepository,
+	statusRepo repositories.TaskStatusRepository,
+	workflowRepo repositories.TaskWorkflowRepository,
+	taskEventRepo repositories.TaskEventRepository,
+	userClient http_clients.UserClientInterface,
+	notificationService NotificationServiceInterface,
+	eventPublisher TaskEventPublisherInterface,
+	cfg config.RecurrenceSchedulerConfig,
+) *TaskRecurrenceScheduler {
+	return &TaskRecurrenceScheduler{
+		recurrenceRepo:      recurrenceRepo,
+		statusRepo:          statusRepo,
+		workflowRepo:        workflowRepo,
+		taskEventRepo:       taskEventRepo,
+		userClient:          userClient,
+		notificationService: notificationService,
+		eventPublisher:      eventPublisher,
+		config:              cfg,
+	}
+}
+
+// Start запускает проверку сразу и затем с периодом Interval до отмены контекста
+func (s *TaskRecurrenceScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunOnce(time.Now()); err != nil {
+			log.Printf("Task recurrence check failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Task recurrence scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce создаёт экземпляры, которые начинаются до now+Horizon (публичный для тестирования)
+func (s *TaskRecurrenceScheduler) RunOnce(now time.Time) error {
+	until := now.Add(s.config.Horizon)
+	recurrences, err := s.recurrenceRepo.GetDue(until, s.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get due task recurrences: %w", err)
+	}
+	for i := range recurrences {
+		s.generate(&recurrences[i], until)
+	}
+	return nil
+}
+
+func (s *TaskRecurrenceScheduler) generate(recurrence *models.TaskRecurrence, until time.Time) {
+	if recurrence.NextOccurrenceAt == nil {
+		return
+	}
+
+	rule, err := models.ParseRecurrenceRule(recurrence.Rule)
+	if err != nil {
+		log.Printf("Invalid rule of task recurrence %d: %v", recurrence.ID, err)
+		return
+	}
+	status, err := InitialTaskStatus(s.statusRepo, s.workflowRepo, recurrence.WorkflowID)
+	if err != nil {
+		log.Printf("Failed to get initial status for task recurrence %d: %v", recurrence.ID, err)
+		return
+	}
+
+	occurrenceTimes := rule.Between(recurrence.StartsAt, *recurrence.NextOccurrenceAt, until, maxOccurrencesPerRun)
+	var next *time.Time
+	from := *recurrence.NextOccurrenceAt
+	if len(occurrenceTimes) > 0 {
+		from = occurrenceTimes[len(occurrenceTimes)-1].Add(time.Nanosecond)
+	}
+	if nextAt, ok := rule.Next(recurrence.StartsAt, from); ok {
+		next = &nextAt
+	}
+
+	occurrences := make([]models.Task, 0, len(occurrenceTimes))
+	for _, occurrenceAt := range occurrenceTimes {
+		occurrences = append(occurrences, recurrence.NewOccurrence(occurrenceAt, status.ID))
+	}
+
+	claimed, err := s.recurrenceRepo.CreateOccurrences(recurrence, next, occurrences)
+	if err != nil {
+		log.Printf("Failed to create occurrences of task recurrence %d: %v", recurrence.ID, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	var created []models.Task
+	var events []models.TaskEvent
+	for _, task := range occurrences {
+		// Экземпляры, которые у серии уже были, не создаются и остаются без ID
+		if task.ID == 0 {
+			continue
+		}
+		created = append(created, task)
+		title := task.Title
+		events = append(events, models.TaskEvent{
+			TaskID:    task.ID,
+			ActorID:   task.CreatorID,
+			EventType: models.TaskEventCreated,
+			NewValue:  &title,
+		})
+	}
+	if len(created) == 0 {
+		return
+	}
+	if err := s.taskEventRepo.Create(events); err != nil {
+		log.Printf("Failed to record task events: %v", err)
+	}
+	if s.eventPublisher != nil {
+		for i := range created {
+			s.eventPublisher.Publish(&created[i], events[i:i+1])
+		}
+	}
+	s.notify(recurrence, created)
+}
+
+// notify отправляет исполнителю уведомление о каждом созданном экземпляре, как при создании задачи
+func (s *TaskRecurrenceScheduler) notify(recurrence *models.TaskRecurrence, tasks []models.Task) {
+	if s.notificationService == nil {
+		return
+	}
+
+	executor, err := s.userClient.GetUserByID(&recurrence.ExecutorID)
+	if err != nil || executor.User == nil || executor.User.Email == "" {
+		log.Printf("Failed to get executor %s for task recurrence %d: %v", recurrence.ExecutorID, recurrence.ID, err)
+		return
+	}
+
+	creatorName := "Unknown user"
+	if creator, err := s.userClient.GetUserByID(&recurrence.CreatorID); err == nil && creator.User != nil && creator.User.Username != "" {
+		creatorName = creator.User.Username
+	}
+
+	for _, task := range tasks {
+		if err := s.notificationService.SendTaskCreatedNotification(
+			task.ID,
+			task.Title,
+			creatorName,
+			task.ExecutorID,
+			executor.User.Email,
+		); err != nil {
+			log.Printf("Failed to send task notification: %v", err)
+		}
+	}
+}
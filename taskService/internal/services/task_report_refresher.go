@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"taskService/internal/config"
+	"taskService/internal/repositories"
+)
+
+// TaskReportRefresher периодически пересчитывает историю статусов, по которой строятся отчёты.
+// Пересчёт не блокирует чтение отчётов; одновременные пересчёты нескольких реплик выполняются по очереди
+type TaskReportRefresher struct {
+	reportRepo repositories.TaskReportRepository
+	config     config.ReportRefresherConfig
+}
+
+func NewTaskReportRefresher(reportRepo repositories.TaskReportRepository, cfg config.ReportRefresherConfig) *TaskReportRefresher {
+	return &TaskReportRefresher{reportRepo: reportRepo, config: cfg}
+}
+
+// Start пересчитывает данные отчётов с периодом Interval до отмены контекста. Первый пересчёт
+// выполняется через Interval: при запуске данные уже есть после миграции или работы других реплик
+func (s *TaskReportRefresher) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Task report refresher stopped")
+			return
+		case <-ticker.C:
+		}
+
+		if err := s.RunOnce(); err != nil {
+			log.Printf("Task report refresh failed: %v", err)
+		}
+	}
+}
+
+// RunOnce выполняет один пересчёт (публичный для тестирования)
+func (s *TaskReportRefresher) RunOnce() error {
+	if err := s.reportRepo.RefreshStatusIntervals(); err != nil {
+		return fmt.Errorf("failed to refresh task status intervals: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS task_service.tasks_chat_id_idx;
+DROP INDEX IF EXISTS task_service.tasks_executor_id_idx;
+DROP INDEX IF EXISTS task_service.tasks_creator_id_idx;
+
+ALTER TABLE task_service.tasks DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE task_service.tasks DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,7 @@
+ALTER TABLE task_service.tasks ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP;
+ALTER TABLE task_service.tasks ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- Индексы для списков "созданные мной" и "задачи чата"; удалённые задачи в выборки не попадают
+CREATE INDEX IF NOT EXISTS tasks_creator_id_idx ON task_service.tasks (creator_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS tasks_executor_id_idx ON task_service.tasks (executor_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS tasks_chat_id_idx ON task_service.tasks (chat_id) WHERE deleted_at IS NULL;
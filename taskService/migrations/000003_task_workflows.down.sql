@@ -0,0 +1,5 @@
+ALTER TABLE task_service.tasks DROP COLUMN IF EXISTS workflow_id;
+
+DROP TABLE IF EXISTS task_service.task_workflow_transitions;
+DROP TABLE IF EXISTS task_service.task_workflow_statuses;
+DROP TABLE IF EXISTS task_service.task_workflows;
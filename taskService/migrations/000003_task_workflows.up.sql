@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS task_service.task_workflows (
+                                            id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+                                            name VARCHAR(100) UNIQUE NOT NULL,
+                                            is_default BOOLEAN NOT NULL DEFAULT FALSE,
+                                            created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Может существовать только один workflow по умолчанию
+CREATE UNIQUE INDEX IF NOT EXISTS task_workflows_default_uindex
+    ON task_service.task_workflows (is_default) WHERE is_default;
+
+-- Упорядоченный набор статусов workflow; статус с минимальной позицией назначается новой задаче
+CREATE TABLE IF NOT EXISTS task_service.task_workflow_statuses (
+                                            workflow_id INT REFERENCES task_service.task_workflows(id) ON DELETE CASCADE,
+                                            status_id INT REFERENCES task_service.task_statuses(id),
+                                            position INT NOT NULL,
+                                            PRIMARY KEY (workflow_id, status_id)
+);
+
+-- Разрешённые переходы и роль участника задачи, которая может их выполнять
+CREATE TABLE IF NOT EXISTS task_service.task_workflow_transitions (
+                                            id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+                                            workflow_id INT NOT NULL REFERENCES task_service.task_workflows(id) ON DELETE CASCADE,
+                                            from_status_id INT NOT NULL REFERENCES task_service.task_statuses(id),
+                                            to_status_id INT NOT NULL REFERENCES task_service.task_statuses(id),
+                                            allowed_role VARCHAR(20) NOT NULL CHECK (allowed_role IN ('creator', 'executor', 'any')),
+                                            UNIQUE (workflow_id, from_status_id, to_status_id)
+);
+
+ALTER TABLE task_service.tasks
+    ADD COLUMN IF NOT EXISTS workflow_id INT REFERENCES task_service.task_workflows(id) ON DELETE SET NULL;
+
+-- Workflow по умолчанию: созданную задачу может отменить её создатель
+INSERT INTO task_service.task_workflows (name, is_default) VALUES ('default', TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO task_service.task_workflow_statuses (workflow_id, status_id, position)
+SELECT w.id, s.id, CASE s.name WHEN 'created' THEN 1 ELSE 2 END
+FROM task_service.task_workflows w
+         JOIN task_service.task_statuses s ON s.name IN ('created', 'canseled')
+WHERE w.name = 'default'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO task_service.task_workflow_transitions (workflow_id, from_status_id, to_status_id, allowed_role)
+SELECT w.id, f.id, t.id, 'creator'
+FROM task_service.task_workflows w
+         JOIN task_service.task_statuses f ON f.name = 'created'
+         JOIN task_service.task_statuses t ON t.name = 'canseled'
+WHERE w.name = 'default'
+ON CONFLICT DO NOTHING;
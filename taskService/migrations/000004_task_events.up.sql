@@ -0,0 +1,15 @@
+-- Журнал событий задачи: кто, когда и что изменил
+CREATE TABLE IF NOT EXISTS task_service.task_events (
+                                            id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+                                            task_id INT NOT NULL REFERENCES task_service.tasks(id) ON DELETE CASCADE,
+                                            actor_id UUID NOT NULL,
+                                            event_type VARCHAR(30) NOT NULL CHECK (event_type IN
+                                                ('created', 'status_changed', 'reassigned', 'edited', 'attachment_added', 'commented')),
+                                            field VARCHAR(50),
+                                            old_value TEXT,
+                                            new_value TEXT,
+                                            created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS task_events_task_id_idx ON task_service.task_events (task_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS task_events_actor_id_idx ON task_service.task_events (actor_id, created_at DESC);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS task_service.tasks_executor_priority_idx;
+DROP INDEX IF EXISTS task_service.tasks_due_at_idx;
+
+ALTER TABLE task_service.tasks
+    DROP COLUMN IF EXISTS overdue_notified_at,
+    DROP COLUMN IF EXISTS due_soon_notified_at,
+    DROP COLUMN IF EXISTS due_at,
+    DROP COLUMN IF EXISTS start_at,
+    DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,12 @@
+-- Сроки и приоритет задач; *_notified_at отмечают уже отправленные напоминания,
+-- чтобы планировщик не дублировал их при перезапуске и на нескольких репликах
+ALTER TABLE task_service.tasks
+    ADD COLUMN IF NOT EXISTS priority VARCHAR(10) NOT NULL DEFAULT 'normal'
+        CHECK (priority IN ('low', 'normal', 'high', 'urgent')),
+    ADD COLUMN IF NOT EXISTS start_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS due_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS due_soon_notified_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS overdue_notified_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS tasks_due_at_idx ON task_service.tasks (due_at) WHERE deleted_at IS NULL AND due_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS tasks_executor_priority_idx ON task_service.tasks (executor_id, priority);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS task_service.task_comment_mentions;
+DROP TABLE IF EXISTS task_service.task_comment_files;
+DROP TABLE IF EXISTS task_service.task_comments;
@@ -0,0 +1,24 @@
+-- Обсуждение задачи: комментарии с вложениями и @упоминаниями
+CREATE TABLE IF NOT EXISTS task_service.task_comments (
+                                            id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+                                            task_id INT NOT NULL REFERENCES task_service.tasks(id) ON DELETE CASCADE,
+                                            author_id UUID NOT NULL,
+                                            body TEXT NOT NULL,
+                                            created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                            updated_at TIMESTAMP,
+                                            deleted_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS task_service.task_comment_files (
+                                            comment_id INT REFERENCES task_service.task_comments(id) ON DELETE CASCADE,
+                                            file_id INT,
+                                            PRIMARY KEY (comment_id, file_id)
+);
+
+CREATE TABLE IF NOT EXISTS task_service.task_comment_mentions (
+                                            comment_id INT REFERENCES task_service.task_comments(id) ON DELETE CASCADE,
+                                            user_id UUID,
+                                            PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS task_comments_task_id_idx ON task_service.task_comments (task_id, created_at) WHERE deleted_at IS NULL;
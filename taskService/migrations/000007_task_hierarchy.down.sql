@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS task_service.task_dependencies;
+
+DROP INDEX IF EXISTS task_service.tasks_parent_task_id_idx;
+ALTER TABLE task_service.tasks DROP COLUMN IF EXISTS parent_task_id;
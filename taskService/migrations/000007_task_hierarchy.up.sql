@@ -0,0 +1,16 @@
+-- Подзадачи: при удалении родительской записи подзадачи становятся задачами верхнего уровня
+ALTER TABLE task_service.tasks
+    ADD COLUMN IF NOT EXISTS parent_task_id INT REFERENCES task_service.tasks(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS tasks_parent_task_id_idx ON task_service.tasks (parent_task_id) WHERE deleted_at IS NULL;
+
+-- Зависимости "blocker блокирует blocked": blocked нельзя закрыть, пока открыт blocker
+CREATE TABLE IF NOT EXISTS task_service.task_dependencies (
+                                            blocker_task_id INT REFERENCES task_service.tasks(id) ON DELETE CASCADE,
+                                            blocked_task_id INT REFERENCES task_service.tasks(id) ON DELETE CASCADE,
+                                            created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                            PRIMARY KEY (blocker_task_id, blocked_task_id),
+                                            CHECK (blocker_task_id <> blocked_task_id)
+);
+
+CREATE INDEX IF NOT EXISTS task_dependencies_blocked_idx ON task_service.task_dependencies (blocked_task_id);
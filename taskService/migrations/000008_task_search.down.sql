@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS task_service.tasks_chat_id_idx;
+DROP INDEX IF EXISTS task_service.tasks_creator_id_idx;
+DROP INDEX IF EXISTS task_service.tasks_search_idx;
@@ -0,0 +1,6 @@
+-- Полнотекстовый поиск по названию и описанию задачи; выражение должно совпадать с условием поиска в репозитории
+CREATE INDEX IF NOT EXISTS tasks_search_idx ON task_service.tasks
+    USING GIN (to_tsvector('simple', title || ' ' || COALESCE(description, '')));
+
+CREATE INDEX IF NOT EXISTS tasks_creator_id_idx ON task_service.tasks (creator_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS tasks_chat_id_idx ON task_service.tasks (chat_id) WHERE deleted_at IS NULL;
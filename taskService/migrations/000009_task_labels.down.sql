@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS task_service.task_labels;
+DROP TABLE IF EXISTS task_service.labels;
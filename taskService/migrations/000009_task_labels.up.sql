@@ -0,0 +1,16 @@
+-- Метки задач: общие для всего workspace, назначаются задачам через task_labels
+CREATE TABLE IF NOT EXISTS task_service.labels (
+                                    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+                                    name VARCHAR(50) UNIQUE NOT NULL,
+                                    color VARCHAR(9) NOT NULL,
+                                    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS task_service.task_labels (
+                                         task_id INT REFERENCES task_service.tasks(id) ON DELETE CASCADE,
+                                         label_id INT REFERENCES task_service.labels(id) ON DELETE CASCADE,
+                                         created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                         PRIMARY KEY (task_id, label_id)
+);
+
+CREATE INDEX IF NOT EXISTS task_labels_label_id_idx ON task_service.task_labels (label_id);
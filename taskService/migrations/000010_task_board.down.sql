@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS task_service.tasks_status_rank_idx;
+
+ALTER TABLE task_service.tasks DROP COLUMN IF EXISTS rank;
@@ -0,0 +1,14 @@
+-- Порядок карточек в колонке доски: строки сравниваются побайтно, поэтому collation "C"
+ALTER TABLE task_service.tasks
+    ADD COLUMN IF NOT EXISTS rank VARCHAR(255) COLLATE "C" NOT NULL DEFAULT '';
+
+-- Существующие задачи выстраиваются в каждой колонке по дате создания
+UPDATE task_service.tasks t
+SET rank = r.rank
+FROM (
+    SELECT id, LPAD(ROW_NUMBER() OVER (PARTITION BY status ORDER BY created_at, id)::text, 8, '0') || 'i' AS rank
+    FROM task_service.tasks
+) r
+WHERE r.id = t.id;
+
+CREATE INDEX IF NOT EXISTS tasks_status_rank_idx ON task_service.tasks (status, rank) WHERE deleted_at IS NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS task_service.tasks_recurrence_occurrence_idx;
+
+ALTER TABLE task_service.tasks
+    DROP COLUMN IF EXISTS occurrence_at,
+    DROP COLUMN IF EXISTS recurrence_id;
+
+DROP TABLE IF EXISTS task_service.task_recurrences;
@@ -0,0 +1,31 @@
+-- Повторяющиеся задачи: серия хранит шаблон задачи и правило RRULE, планировщик заранее создаёт экземпляры.
+-- next_occurrence_at - начало следующего ещё не созданного экземпляра, NULL - серия закончилась
+CREATE TABLE IF NOT EXISTS task_service.task_recurrences (
+                                    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+                                    title VARCHAR(255) NOT NULL,
+                                    description TEXT,
+                                    creator_id UUID NOT NULL,
+                                    executor_id UUID NOT NULL,
+                                    chat_id UUID,
+                                    workflow_id INT REFERENCES task_service.task_workflows(id) ON DELETE SET NULL,
+                                    priority VARCHAR(10) NOT NULL DEFAULT 'normal'
+                                        CHECK (priority IN ('low', 'normal', 'high', 'urgent')),
+                                    rule VARCHAR(255) NOT NULL,
+                                    starts_at TIMESTAMP NOT NULL,
+                                    due_after_minutes INT CHECK (due_after_minutes > 0),
+                                    next_occurrence_at TIMESTAMP,
+                                    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                    updated_at TIMESTAMP,
+                                    deleted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS task_recurrences_next_occurrence_idx
+    ON task_service.task_recurrences (next_occurrence_at) WHERE deleted_at IS NULL;
+
+ALTER TABLE task_service.tasks
+    ADD COLUMN IF NOT EXISTS recurrence_id INT REFERENCES task_service.task_recurrences(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS occurrence_at TIMESTAMP;
+
+-- Страховка от дублей: один экземпляр серии на каждое начало
+CREATE UNIQUE INDEX IF NOT EXISTS tasks_recurrence_occurrence_idx
+    ON task_service.tasks (recurrence_id, occurrence_at) WHERE deleted_at IS NULL;
@@ -0,0 +1,9 @@
+DELETE FROM task_service.task_events WHERE event_type IN ('assignee_added', 'assignee_removed');
+
+ALTER TABLE task_service.task_events DROP CONSTRAINT IF EXISTS task_events_event_type_check;
+ALTER TABLE task_service.task_events ADD CONSTRAINT task_events_event_type_check CHECK (event_type IN
+    ('created', 'status_changed', 'reassigned', 'edited', 'attachment_added', 'commented',
+     'blocker_added', 'blocker_removed', 'label_added', 'label_removed'));
+
+DROP TABLE IF EXISTS task_service.task_watchers;
+DROP TABLE IF EXISTS task_service.task_assignees;
@@ -0,0 +1,24 @@
+-- Соисполнители задачи в дополнение к основному исполнителю tasks.executor_id
+CREATE TABLE IF NOT EXISTS task_service.task_assignees (
+                                            task_id INT REFERENCES task_service.tasks(id) ON DELETE CASCADE,
+                                            user_id UUID NOT NULL,
+                                            created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                            PRIMARY KEY (task_id, user_id)
+);
+
+-- Наблюдатели получают уведомления о смене статуса и комментариях
+CREATE TABLE IF NOT EXISTS task_service.task_watchers (
+                                           task_id INT REFERENCES task_service.tasks(id) ON DELETE CASCADE,
+                                           user_id UUID NOT NULL,
+                                           created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                           PRIMARY KEY (task_id, user_id)
+);
+
+-- Для выборок "мои задачи"
+CREATE INDEX IF NOT EXISTS task_assignees_user_id_idx ON task_service.task_assignees (user_id);
+CREATE INDEX IF NOT EXISTS task_watchers_user_id_idx ON task_service.task_watchers (user_id);
+
+ALTER TABLE task_service.task_events DROP CONSTRAINT IF EXISTS task_events_event_type_check;
+ALTER TABLE task_service.task_events ADD CONSTRAINT task_events_event_type_check CHECK (event_type IN
+    ('created', 'status_changed', 'reassigned', 'edited', 'attachment_added', 'commented',
+     'blocker_added', 'blocker_removed', 'label_added', 'label_removed', 'assignee_added', 'assignee_removed'));
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS task_service.task_time_logs;
+
+ALTER TABLE task_service.tasks
+    DROP COLUMN IF EXISTS estimate_minutes;
@@ -0,0 +1,24 @@
+-- Оценка трудозатрат задачи в минутах; NULL - оценка не задана
+ALTER TABLE task_service.tasks
+    ADD COLUMN IF NOT EXISTS estimate_minutes INT CHECK (estimate_minutes >= 0);
+
+-- Учёт затраченного времени. Запись с ended_at IS NULL - запущенный таймер, minutes у неё 0
+-- до остановки; у завершённых записей minutes = ended_at - started_at в минутах
+CREATE TABLE IF NOT EXISTS task_service.task_time_logs (
+                                            id SERIAL PRIMARY KEY,
+                                            task_id INT NOT NULL REFERENCES task_service.tasks(id) ON DELETE CASCADE,
+                                            user_id UUID NOT NULL,
+                                            started_at TIMESTAMP NOT NULL,
+                                            ended_at TIMESTAMP,
+                                            minutes INT NOT NULL DEFAULT 0 CHECK (minutes >= 0),
+                                            description TEXT NOT NULL DEFAULT '',
+                                            created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                            updated_at TIMESTAMP,
+                                            CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE INDEX IF NOT EXISTS task_time_logs_task_id_idx ON task_service.task_time_logs (task_id, started_at);
+CREATE INDEX IF NOT EXISTS task_time_logs_user_id_idx ON task_service.task_time_logs (user_id, started_at);
+
+-- У пользователя может быть запущен только один таймер
+CREATE UNIQUE INDEX IF NOT EXISTS task_time_logs_running_idx ON task_service.task_time_logs (user_id) WHERE ended_at IS NULL;
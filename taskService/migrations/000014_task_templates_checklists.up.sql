@@ -0,0 +1,58 @@
+-- Шаблоны задач. title_pattern может содержать подстановки {name}: значения передаются при создании задачи,
+-- {date} заменяется текущей датой. executor_id - исполнитель по умолчанию, NULL - без исполнителя
+CREATE TABLE IF NOT EXISTS task_service.task_templates (
+                                    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+                                    name VARCHAR(100) NOT NULL,
+                                    title_pattern VARCHAR(255) NOT NULL,
+                                    description TEXT NOT NULL DEFAULT '',
+                                    creator_id UUID NOT NULL,
+                                    executor_id UUID,
+                                    priority VARCHAR(10) NOT NULL DEFAULT 'normal'
+                                        CHECK (priority IN ('low', 'normal', 'high', 'urgent')),
+                                    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                    updated_at TIMESTAMP,
+                                    deleted_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS task_templates_name_idx
+    ON task_service.task_templates (name) WHERE deleted_at IS NULL;
+
+-- Метки, которые получает задача, созданная по шаблону
+CREATE TABLE IF NOT EXISTS task_service.task_template_labels (
+                                    template_id INT NOT NULL REFERENCES task_service.task_templates(id) ON DELETE CASCADE,
+                                    label_id INT NOT NULL REFERENCES task_service.labels(id) ON DELETE CASCADE,
+                                    PRIMARY KEY (template_id, label_id)
+);
+
+-- Пункты чек-листа, которые копируются в задачу, созданную по шаблону
+CREATE TABLE IF NOT EXISTS task_service.task_template_checklist_items (
+                                    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+                                    template_id INT NOT NULL REFERENCES task_service.task_templates(id) ON DELETE CASCADE,
+                                    title VARCHAR(255) NOT NULL,
+                                    position INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS task_template_checklist_items_template_idx
+    ON task_service.task_template_checklist_items (template_id, position);
+
+-- Чек-лист задачи: пункты упорядочены по position, отмеченный пункт хранит, кто и когда его отметил
+CREATE TABLE IF NOT EXISTS task_service.task_checklist_items (
+                                    id INT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+                                    task_id INT NOT NULL REFERENCES task_service.tasks(id) ON DELETE CASCADE,
+                                    title VARCHAR(255) NOT NULL,
+                                    position INT NOT NULL DEFAULT 0,
+                                    done BOOLEAN NOT NULL DEFAULT FALSE,
+                                    done_by UUID,
+                                    done_at TIMESTAMP,
+                                    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                    updated_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS task_checklist_items_task_idx
+    ON task_service.task_checklist_items (task_id, position);
+
+ALTER TABLE task_service.task_events DROP CONSTRAINT IF EXISTS task_events_event_type_check;
+ALTER TABLE task_service.task_events ADD CONSTRAINT task_events_event_type_check CHECK (event_type IN
+    ('created', 'status_changed', 'reassigned', 'edited', 'attachment_added', 'commented',
+     'blocker_added', 'blocker_removed', 'label_added', 'label_removed', 'assignee_added', 'assignee_removed',
+     'checklist_item_added', 'checklist_item_done', 'checklist_item_reopened', 'checklist_item_removed'));
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS task_service.tasks_created_at_idx;
+
+DROP MATERIALIZED VIEW IF EXISTS task_service.task_status_intervals;
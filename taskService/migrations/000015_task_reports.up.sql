@@ -0,0 +1,52 @@
+-- Интервалы пребывания задач в статусах, восстановленные по истории смены статусов. Строка seq = 0 -
+-- начальный статус с момента создания задачи; seq = n - статус после n-й смены. left_at IS NULL -
+-- задача оставалась в статусе на момент последнего обновления представления. События хранят название
+-- статуса (или его ID, если статус не был загружен); status_id - найденный по ним статус или NULL, если он удалён.
+-- Представление обновляет TaskReportRefresher (REFRESH MATERIALIZED VIEW CONCURRENTLY)
+CREATE MATERIALIZED VIEW IF NOT EXISTS task_service.task_status_intervals AS
+WITH changes AS (
+    SELECT e.task_id,
+           e.old_value,
+           e.new_value,
+           e.created_at,
+           ROW_NUMBER() OVER w AS seq,
+           LEAD(e.created_at) OVER w AS next_at
+    FROM task_service.task_events e
+    WHERE e.event_type = 'status_changed'
+    WINDOW w AS (PARTITION BY e.task_id ORDER BY e.created_at, e.id)
+),
+intervals AS (
+    SELECT t.id AS task_id,
+           0::BIGINT AS seq,
+           COALESCE(c.old_value, s.name) AS status,
+           t.created_at AS entered_at,
+           c.created_at AS left_at
+    FROM task_service.tasks t
+    LEFT JOIN task_service.task_statuses s ON s.id = t.status
+    LEFT JOIN changes c ON c.task_id = t.id AND c.seq = 1
+    UNION ALL
+    SELECT c.task_id, c.seq, c.new_value, c.created_at, c.next_at
+    FROM changes c
+)
+SELECT i.task_id, i.seq, COALESCE(st.name, i.status) AS status, st.id AS status_id, i.entered_at, i.left_at
+FROM intervals i
+LEFT JOIN LATERAL (
+    SELECT s.id, s.name
+    FROM task_service.task_statuses s
+    WHERE s.name = i.status OR s.id::TEXT = i.status
+    ORDER BY s.name = i.status DESC
+    LIMIT 1
+) st ON TRUE
+WHERE i.status IS NOT NULL AND i.status <> '';
+
+-- Уникальный индекс нужен для REFRESH ... CONCURRENTLY
+CREATE UNIQUE INDEX IF NOT EXISTS task_status_intervals_task_seq_idx
+    ON task_service.task_status_intervals (task_id, seq);
+CREATE INDEX IF NOT EXISTS task_status_intervals_left_at_idx
+    ON task_service.task_status_intervals (left_at) WHERE left_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS task_status_intervals_current_idx
+    ON task_service.task_status_intervals (entered_at) WHERE left_at IS NULL;
+CREATE INDEX IF NOT EXISTS task_status_intervals_entered_at_idx
+    ON task_service.task_status_intervals (entered_at);
+
+CREATE INDEX IF NOT EXISTS tasks_created_at_idx ON task_service.tasks (created_at) WHERE deleted_at IS NULL;
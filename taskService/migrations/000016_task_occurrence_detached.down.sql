@@ -0,0 +1,2 @@
+ALTER TABLE task_service.tasks
+    DROP COLUMN IF EXISTS detached;
@@ -0,0 +1,20 @@
+-- detached - экземпляр серии изменён отдельно от неё: правки и удаление серии его больше не затрагивают.
+-- Флаг выставляет любая правка экземпляра, включая участников, метки, чек-лист, комментарии и учёт времени
+ALTER TABLE task_service.tasks
+    ADD COLUMN IF NOT EXISTS detached BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Ранее изменённые экземпляры определялись по updated_at; дополнительно учитываем правки, которые
+-- не меняли саму задачу: историю, участников, подписчиков, чек-лист, учёт времени, зависимости и подзадачи
+UPDATE task_service.tasks t
+SET detached = TRUE
+WHERE t.recurrence_id IS NOT NULL
+  AND ((t.updated_at IS NOT NULL AND t.updated_at <> t.created_at)
+    OR EXISTS (SELECT 1 FROM task_service.task_events e WHERE e.task_id = t.id AND e.event_type <> 'created')
+    OR EXISTS (SELECT 1 FROM task_service.task_comments c WHERE c.task_id = t.id)
+    OR EXISTS (SELECT 1 FROM task_service.task_labels l WHERE l.task_id = t.id)
+    OR EXISTS (SELECT 1 FROM task_service.task_assignees a WHERE a.task_id = t.id)
+    OR EXISTS (SELECT 1 FROM task_service.task_watchers w WHERE w.task_id = t.id)
+    OR EXISTS (SELECT 1 FROM task_service.task_checklist_items ci WHERE ci.task_id = t.id)
+    OR EXISTS (SELECT 1 FROM task_service.task_time_logs tl WHERE tl.task_id = t.id)
+    OR EXISTS (SELECT 1 FROM task_service.task_dependencies d WHERE d.blocker_task_id = t.id OR d.blocked_task_id = t.id)
+    OR EXISTS (SELECT 1 FROM task_service.tasks s WHERE s.parent_task_id = t.id));
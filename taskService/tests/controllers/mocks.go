@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"taskService/internal/handlers/dto"
 	"taskService/internal/models"
+	"time"
 )
 
 // MockTaskRepository - мок для TaskRepository
@@ -33,14 +34,617 @@ func (m *MockTaskRepository) GetByID(taskID int) (*models.Task, error) {
 	return args.Get(0).(*models.Task), args.Error(1)
 }
 
-func (m *MockTaskRepository) GetUserTasks(userID string, limit, offset int) (*[]dto.TaskToList, error) {
-	args := m.Called(userID, limit, offset)
+func (m *MockTaskRepository) GetUserTasks(userID string, filter *dto.TaskListFilter, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskToList, error) {
+	args := m.Called(userID, filter, limit, offset, visibility)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*[]dto.TaskToList), args.Error(1)
 }
 
+func (m *MockTaskRepository) Update(task *models.Task) error {
+	args := m.Called(task)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Delete(taskID int) error {
+	args := m.Called(taskID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetCreatedTasks(userID string, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskToList, error) {
+	args := m.Called(userID, limit, offset, visibility)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]dto.TaskToList), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetChatTasks(chatID string, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskToList, error) {
+	args := m.Called(chatID, limit, offset, visibility)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]dto.TaskToList), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetDueSoon(now, until time.Time, limit int) ([]models.Task, error) {
+	args := m.Called(now, until, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetOverdue(now time.Time, limit int) ([]models.Task, error) {
+	args := m.Called(now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) ClaimDueSoonReminder(taskID int, dueAt, now time.Time) (bool, error) {
+	args := m.Called(taskID, dueAt, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) ClaimOverdueReminder(taskID int, dueAt, now time.Time) (bool, error) {
+	args := m.Called(taskID, dueAt, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetSubtree(rootID int) ([]dto.TaskTreeNode, error) {
+	args := m.Called(rootID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.TaskTreeNode), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetOpenBlockerIDs(taskID int) ([]int, error) {
+	args := m.Called(taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetVisibleIDs(ids []int, visibility *dto.TaskVisibility) ([]int, error) {
+	args := m.Called(ids, visibility)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockTaskRepository) QueryTasks(query *dto.TaskQuery, visibility *dto.TaskVisibility) ([]dto.TaskToList, int64, error) {
+	args := m.Called(query, visibility)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]dto.TaskToList), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTaskRepository) GetBoard(query *dto.TaskBoardQuery, visibility *dto.TaskVisibility) ([]dto.TaskBoardCard, []dto.TaskBoardCount, error) {
+	args := m.Called(query, visibility)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]dto.TaskBoardCard), args.Get(1).([]dto.TaskBoardCount), args.Error(2)
+}
+
+func (m *MockTaskRepository) GetLastRank(statusID int) (string, error) {
+	args := m.Called(statusID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) MoveOnBoard(taskID, fromStatusID, toStatusID int, rank string) error {
+	args := m.Called(taskID, fromStatusID, toStatusID, rank)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Detach(taskID int) error {
+	args := m.Called(taskID)
+	return args.Error(0)
+}
+
+// MockTaskDependencyRepository - мок для TaskDependencyRepository
+type MockTaskDependencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskDependencyRepository) Create(dependency *models.TaskDependency) error {
+	args := m.Called(dependency)
+	return args.Error(0)
+}
+
+func (m *MockTaskDependencyRepository) Delete(blockerTaskID, blockedTaskID int) error {
+	args := m.Called(blockerTaskID, blockedTaskID)
+	return args.Error(0)
+}
+
+func (m *MockTaskDependencyRepository) HasPath(fromTaskID, toTaskID int) (bool, error) {
+	args := m.Called(fromTaskID, toTaskID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskDependencyRepository) GetGraph(taskID int) ([]dto.TaskGraphNode, []dto.TaskDependencyEdge, error) {
+	args := m.Called(taskID)
+	var nodes []dto.TaskGraphNode
+	if args.Get(0) != nil {
+		nodes = args.Get(0).([]dto.TaskGraphNode)
+	}
+	var edges []dto.TaskDependencyEdge
+	if args.Get(1) != nil {
+		edges = args.Get(1).([]dto.TaskDependencyEdge)
+	}
+	return nodes, edges, args.Error(2)
+}
+
+// MockTaskWorkflowRepository - мок для TaskWorkflowRepository
+type MockTaskWorkflowRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskWorkflowRepository) Create(workflow *models.TaskWorkflow) error {
+	args := m.Called(workflow)
+	return args.Error(0)
+}
+
+func (m *MockTaskWorkflowRepository) Replace(workflow *models.TaskWorkflow) error {
+	args := m.Called(workflow)
+	return args.Error(0)
+}
+
+func (m *MockTaskWorkflowRepository) GetByID(id int) (*models.TaskWorkflow, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskWorkflowRepository) GetByName(name string) (*models.TaskWorkflow, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskWorkflowRepository) GetDefault() (*models.TaskWorkflow, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskWorkflowRepository) GetAll() ([]models.TaskWorkflow, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskWorkflowRepository) DeleteByID(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// MockLabelRepository - мок для LabelRepository
+type MockLabelRepository struct {
+	mock.Mock
+}
+
+func (m *MockLabelRepository) Create(label *models.Label) error {
+	args := m.Called(label)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) Update(label *models.Label) error {
+	args := m.Called(label)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) GetByID(id int) (*dto.LabelResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.LabelResponse), args.Error(1)
+}
+
+func (m *MockLabelRepository) GetByName(name string) (*models.Label, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) GetAll() ([]dto.LabelResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.LabelResponse), args.Error(1)
+}
+
+func (m *MockLabelRepository) DeleteByID(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) AddToTask(taskID, labelID int) (bool, error) {
+	args := m.Called(taskID, labelID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLabelRepository) RemoveFromTask(taskID, labelID int) error {
+	args := m.Called(taskID, labelID)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) GetByTaskID(taskID int) ([]models.Label, error) {
+	args := m.Called(taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Label), args.Error(1)
+}
+
+// MockTaskRecurrenceRepository - мок для TaskRecurrenceRepository
+type MockTaskMemberRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskMemberRepository) AddAssignee(taskID int, userID uuid.UUID) (bool, error) {
+	args := m.Called(taskID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskMemberRepository) RemoveAssignee(taskID int, userID uuid.UUID) error {
+	args := m.Called(taskID, userID)
+	return args.Error(0)
+}
+
+func (m *MockTaskMemberRepository) AddWatcher(taskID int, userID uuid.UUID) (bool, error) {
+	args := m.Called(taskID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskMemberRepository) RemoveWatcher(taskID int, userID uuid.UUID) error {
+	args := m.Called(taskID, userID)
+	return args.Error(0)
+}
+
+type MockTaskTimeLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskTimeLogRepository) Create(log *models.TaskTimeLog) error {
+	args := m.Called(log)
+	return args.Error(0)
+}
+
+func (m *MockTaskTimeLogRepository) StartTimer(log *models.TaskTimeLog) (bool, error) {
+	args := m.Called(log)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskTimeLogRepository) Update(log *models.TaskTimeLog) error {
+	args := m.Called(log)
+	return args.Error(0)
+}
+
+func (m *MockTaskTimeLogRepository) Delete(logID int) error {
+	args := m.Called(logID)
+	return args.Error(0)
+}
+
+func (m *MockTaskTimeLogRepository) GetByID(logID int) (*models.TaskTimeLog, error) {
+	args := m.Called(logID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskTimeLogRepository) GetRunning(userID uuid.UUID) (*models.TaskTimeLog, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskTimeLogRepository) GetByTaskID(taskID int, limit, offset int) ([]models.TaskTimeLog, error) {
+	args := m.Called(taskID, limit, offset)
+	return args.Get(0).([]models.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskTimeLogRepository) GetTimeSpent(query *dto.TimeSpentQuery, visibility *dto.TaskVisibility) ([]dto.TimeSpent, error) {
+	args := m.Called(query, visibility)
+	return args.Get(0).([]dto.TimeSpent), args.Error(1)
+}
+
+type MockTaskBulkRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskBulkRepository) Apply(changes []dto.TaskBulkChange) error {
+	args := m.Called(changes)
+	return args.Error(0)
+}
+
+type MockTaskReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskReportRepository) GetThroughput(query *dto.TaskReportQuery, visibility *dto.TaskVisibility) ([]dto.TaskThroughput, error) {
+	args := m.Called(query, visibility)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.TaskThroughput), args.Error(1)
+}
+
+func (m *MockTaskReportRepository) GetStatusDurations(query *dto.TaskReportQuery, visibility *dto.TaskVisibility) ([]dto.TaskStatusDuration, error) {
+	args := m.Called(query, visibility)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.TaskStatusDuration), args.Error(1)
+}
+
+func (m *MockTaskReportRepository) GetOverdueByExecutor(now time.Time, chatID *uuid.UUID, visibility *dto.TaskVisibility) ([]dto.TaskOverdueCount, error) {
+	args := m.Called(now, chatID, visibility)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.TaskOverdueCount), args.Error(1)
+}
+
+func (m *MockTaskReportRepository) GetCumulativeFlow(query *dto.TaskReportQuery, visibility *dto.TaskVisibility) ([]dto.TaskCumulativeFlow, error) {
+	args := m.Called(query, visibility)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.TaskCumulativeFlow), args.Error(1)
+}
+
+func (m *MockTaskReportRepository) RefreshStatusIntervals() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+type MockTaskTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskTemplateRepository) Create(template *models.TaskTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func (m *MockTaskTemplateRepository) Update(template *models.TaskTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func (m *MockTaskTemplateRepository) GetByID(id int) (*models.TaskTemplate, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskTemplateRepository) GetByName(name string) (*models.TaskTemplate, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskTemplateRepository) GetAll(viewerID *uuid.UUID) ([]models.TaskTemplate, error) {
+	args := m.Called(viewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskTemplateRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTaskTemplateRepository) CreateTask(task *models.Task, files []models.TaskFile, labelIDs []int, checklist []models.TaskChecklistItem) error {
+	args := m.Called(task, files, labelIDs, checklist)
+	return args.Error(0)
+}
+
+type MockTaskChecklistRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskChecklistRepository) Create(item *models.TaskChecklistItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockTaskChecklistRepository) Update(item *models.TaskChecklistItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockTaskChecklistRepository) Reorder(taskID int, itemIDs []int) error {
+	args := m.Called(taskID, itemIDs)
+	return args.Error(0)
+}
+
+func (m *MockTaskChecklistRepository) GetByID(taskID, itemID int) (*models.TaskChecklistItem, error) {
+	args := m.Called(taskID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskChecklistRepository) GetByTaskID(taskID int) ([]models.TaskChecklistItem, error) {
+	args := m.Called(taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskChecklistRepository) Delete(taskID, itemID int) error {
+	args := m.Called(taskID, itemID)
+	return args.Error(0)
+}
+
+type MockTaskRecurrenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskRecurrenceRepository) Create(recurrence *models.TaskRecurrence) error {
+	args := m.Called(recurrence)
+	return args.Error(0)
+}
+
+func (m *MockTaskRecurrenceRepository) Update(recurrence *models.TaskRecurrence) error {
+	args := m.Called(recurrence)
+	return args.Error(0)
+}
+
+func (m *MockTaskRecurrenceRepository) GetByID(id int) (*models.TaskRecurrence, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskRecurrence), args.Error(1)
+}
+
+func (m *MockTaskRecurrenceRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRecurrenceRepository) GetDue(until time.Time, limit int) ([]models.TaskRecurrence, error) {
+	args := m.Called(until, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskRecurrence), args.Error(1)
+}
+
+func (m *MockTaskRecurrenceRepository) CreateOccurrences(recurrence *models.TaskRecurrence, next *time.Time, occurrences []models.Task) (bool, error) {
+	args := m.Called(recurrence, next, occurrences)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskRecurrenceRepository) UpdatePendingOccurrences(recurrence *models.TaskRecurrence, after time.Time) error {
+	args := m.Called(recurrence, after)
+	return args.Error(0)
+}
+
+func (m *MockTaskRecurrenceRepository) DeletePendingOccurrences(recurrenceID int, after time.Time) error {
+	args := m.Called(recurrenceID, after)
+	return args.Error(0)
+}
+
+// MockTaskEventRepository - мок для TaskEventRepository
+type MockTaskEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskEventRepository) Create(events []models.TaskEvent) error {
+	args := m.Called(events)
+	return args.Error(0)
+}
+
+func (m *MockTaskEventRepository) GetByTaskID(taskID int, limit, offset int) (*[]dto.TaskActivity, error) {
+	args := m.Called(taskID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]dto.TaskActivity), args.Error(1)
+}
+
+func (m *MockTaskEventRepository) GetUserFeed(userID string, limit, offset int, visibility *dto.TaskVisibility) (*[]dto.TaskActivity, error) {
+	args := m.Called(userID, limit, offset, visibility)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]dto.TaskActivity), args.Error(1)
+}
+
+// MockTaskEventPublisher - мок для TaskEventPublisherInterface
+type MockTaskEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockTaskEventPublisher) Publish(task *models.Task, events []models.TaskEvent) {
+	m.Called(task, events)
+}
+
+// newTaskEventRepoStub - мок истории, принимающий любые события; для тестов, которые историю не проверяют
+func newTaskEventRepoStub() *MockTaskEventRepository {
+	m := new(MockTaskEventRepository)
+	m.On("Create", mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+// MockTaskCommentRepository - мок для TaskCommentRepository
+type MockTaskCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskCommentRepository) Create(comment *models.TaskComment) error {
+	args := m.Called(comment)
+	return args.Error(0)
+}
+
+func (m *MockTaskCommentRepository) Update(comment *models.TaskComment, addFiles []models.TaskCommentFile, removeFileIDs []int) error {
+	args := m.Called(comment, addFiles, removeFileIDs)
+	return args.Error(0)
+}
+
+func (m *MockTaskCommentRepository) Delete(commentID int) error {
+	args := m.Called(commentID)
+	return args.Error(0)
+}
+
+func (m *MockTaskCommentRepository) GetByID(commentID int) (*models.TaskComment, error) {
+	args := m.Called(commentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskComment), args.Error(1)
+}
+
+func (m *MockTaskCommentRepository) GetByTaskID(taskID int, limit, offset int) ([]models.TaskComment, error) {
+	args := m.Called(taskID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskComment), args.Error(1)
+}
+
+func (m *MockTaskCommentRepository) GetAuthorIDs(taskID int) ([]uuid.UUID, error) {
+	args := m.Called(taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 // MockTaskStatusRepository - мок для TaskStatusRepository
 type MockTaskStatusRepository struct {
 	mock.Mock
@@ -83,6 +687,24 @@ func (m *MockTaskStatusRepository) GetAll() ([]models.TaskStatus, error) {
 	return args.Get(0).([]models.TaskStatus), args.Error(1)
 }
 
+func (m *MockTaskStatusRepository) CountTasks(id int) (int64, int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTaskStatusRepository) GetWorkflowsUsing(id int) ([]models.TaskWorkflow, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskStatusRepository) Delete(status, replacement *models.TaskStatus, actorID uuid.UUID) error {
+	args := m.Called(status, replacement, actorID)
+	return args.Error(0)
+}
+
 // MockTaskFileRepository - мок для TaskFileRepository
 type MockTaskFileRepository struct {
 	mock.Mock
@@ -93,6 +715,11 @@ func (m *MockTaskFileRepository) BulkCreate(taskFiles []models.TaskFile) error {
 	return args.Error(0)
 }
 
+func (m *MockTaskFileRepository) DeleteByFileIDs(taskID int, fileIDs []int) error {
+	args := m.Called(taskID, fileIDs)
+	return args.Error(0)
+}
+
 // MockNotificationService - мок для NotificationServiceInterface
 type MockNotificationService struct {
 	mock.Mock
@@ -109,6 +736,43 @@ func (m *MockNotificationService) SendTaskCreatedNotification(
 	return args.Error(0)
 }
 
+func (m *MockNotificationService) SendTaskDueSoonNotification(taskID int, taskTitle, priority string, executorID uuid.UUID, executorEmail string, dueAt time.Time) error {
+	args := m.Called(taskID, taskTitle, priority, executorID, executorEmail, dueAt)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) SendTaskOverdueNotification(taskID int, taskTitle, priority string, executorID uuid.UUID, executorEmail string, dueAt time.Time) error {
+	args := m.Called(taskID, taskTitle, priority, executorID, executorEmail, dueAt)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) SendTaskCommentNotification(
+	taskID int,
+	taskTitle string,
+	commentID int,
+	authorName string,
+	body string,
+	recipientID uuid.UUID,
+	recipientEmail string,
+	mentioned bool,
+) error {
+	args := m.Called(taskID, taskTitle, commentID, authorName, body, recipientID, recipientEmail, mentioned)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) SendTaskStatusChangedNotification(
+	taskID int,
+	taskTitle string,
+	actorName string,
+	oldStatus string,
+	newStatus string,
+	recipientID uuid.UUID,
+	recipientEmail string,
+) error {
+	args := m.Called(taskID, taskTitle, actorName, oldStatus, newStatus, recipientID, recipientEmail)
+	return args.Error(0)
+}
+
 func (m *MockNotificationService) Close() error {
 	args := m.Called()
 	return args.Error(0)
@@ -127,6 +791,7 @@ func createTestTask() *models.Task {
 		StatusID:    statusID,
 		CreatorID:   creatorID,
 		ExecutorID:  executorID,
+		ChatID:      testChatID,
 		Status: &models.TaskStatus{
 			ID:   statusID,
 			Name: "created",
@@ -189,6 +854,22 @@ func (m *MockUserClient) GetUserByID(userID *uuid.UUID) (*cuc.Response, error) {
 	return args.Get(0).(*cuc.Response), args.Error(1)
 }
 
+func (m *MockUserClient) GetUsersByIDs(userIDs []uuid.UUID) (*cuc.UsersResponse, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cuc.UsersResponse), args.Error(1)
+}
+
+func (m *MockUserClient) GetUsersByUsernames(usernames []string) (*cuc.UsersResponse, error) {
+	args := m.Called(usernames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cuc.UsersResponse), args.Error(1)
+}
+
 // MockChatClient - мок для ChatClientInterface
 type MockChatClient struct {
 	mock.Mock
@@ -202,6 +883,51 @@ func (m *MockChatClient) GetChatByID(chatID string) (*cc.Chat, error) {
 	return args.Get(0).(*cc.Chat), args.Error(1)
 }
 
+func (m *MockChatClient) GetMessageByID(messageID, requesterID uuid.UUID) (*cc.Message, error) {
+	args := m.Called(messageID, requesterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cc.Message), args.Error(1)
+}
+
+func (m *MockChatClient) CreateSystemMessage(chatID uuid.UUID, request *cc.CreateSystemMessageRequest) error {
+	args := m.Called(chatID, request)
+	return args.Error(0)
+}
+
+func (m *MockChatClient) GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+// MockChatMembershipService - мок для ChatMembershipServiceInterface
+type MockChatMembershipService struct {
+	mock.Mock
+}
+
+func (m *MockChatMembershipService) GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+// testChatID - чат задач из createTestTask и workflowTask; в тестах любой пользователь - его участник,
+// поэтому задачи видны всем, а проверяются права на изменение
+var testChatID = uuid.New()
+
+// newChatMembershipStub - мок чатов пользователя, в котором любой пользователь состоит в testChatID
+func newChatMembershipStub() *MockChatMembershipService {
+	m := new(MockChatMembershipService)
+	m.On("GetUserChatIDs", mock.Anything).Return([]uuid.UUID{testChatID}, nil).Maybe()
+	return m
+}
+
 // MockFileClient - мок для FileClientInterface
 type MockFileClient struct {
 	mock.Mock
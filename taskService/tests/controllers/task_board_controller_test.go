@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type boardMocks struct {
+	taskRepo     *MockTaskRepository
+	statusRepo   *MockTaskStatusRepository
+	workflowRepo *MockTaskWorkflowRepository
+	events       *MockTaskEventRepository
+}
+
+func newBoardController() (*controllers.TaskController, *boardMocks) {
+	m := &boardMocks{
+		taskRepo:     new(MockTaskRepository),
+		statusRepo:   new(MockTaskStatusRepository),
+		workflowRepo: new(MockTaskWorkflowRepository),
+		events:       newTaskEventRepoStub(),
+	}
+	controller := controllers.NewTaskControllerWithClients(
+		m.taskRepo,
+		m.statusRepo,
+		new(MockTaskFileRepository),
+		m.workflowRepo,
+		m.events,
+		new(MockNotificationService),
+		new(MockUserClient),
+		new(MockChatClient),
+		new(MockFileClient),
+		newChatMembershipStub(),
+	)
+	return controller, m
+}
+
+func boardTask(id, statusID int, rank string) *models.Task {
+	task := workflowTask(statusID)
+	task.ID = id
+	task.Rank = rank
+	return task
+}
+
+// Тесты для TaskController.GetBoard
+
+func TestTaskController_GetBoard_GroupsByWorkflowOrder(t *testing.T) {
+	controller, m := newBoardController()
+	chatID := uuid.New()
+	query := &dto.TaskBoardQuery{ChatID: &chatID, Limit: 50}
+
+	// Статус 4 не входит в workflow по умолчанию и идёт после его статусов; статус 3 стоит в workflow раньше 2
+	workflow := createTestWorkflow()
+	workflow.Statuses[1].Position, workflow.Statuses[2].Position = 3, 2
+	m.statusRepo.On("GetAll").Return([]models.TaskStatus{
+		{ID: 4, Name: "archived"}, {ID: 1, Name: "created"}, {ID: 2, Name: "in_progress"}, {ID: 3, Name: "review"},
+	}, nil)
+	m.workflowRepo.On("GetDefault").Return(workflow, nil)
+	m.taskRepo.On("GetBoard", query, mock.Anything).Return([]dto.TaskBoardCard{
+		{TaskToList: dto.TaskToList{ID: 10}, StatusID: 1, Rank: "1"},
+		{TaskToList: dto.TaskToList{ID: 11}, StatusID: 1, Rank: "2"},
+		{TaskToList: dto.TaskToList{ID: 12}, StatusID: 2, Rank: "1"},
+	}, []dto.TaskBoardCount{{StatusID: 1, Total: 5}, {StatusID: 2, Total: 1}}, nil)
+
+	board, err := controller.GetBoard(&dto.Actor{UserID: uuid.New()}, query)
+
+	require.NoError(t, err)
+	require.Len(t, board.Columns, 4)
+	assert.Equal(t, []int{1, 3, 2, 4}, []int{
+		board.Columns[0].StatusID, board.Columns[1].StatusID, board.Columns[2].StatusID, board.Columns[3].StatusID,
+	})
+	assert.Equal(t, int64(5), board.Columns[0].Total)
+	assert.Len(t, board.Columns[0].Tasks, 2)
+	assert.NotNil(t, board.Columns[1].Tasks)
+	assert.Empty(t, board.Columns[1].Tasks)
+	assert.Equal(t, 12, board.Columns[2].Tasks[0].ID)
+}
+
+func TestTaskController_GetBoard_WithoutDefaultWorkflow(t *testing.T) {
+	controller, m := newBoardController()
+	executorID := uuid.New()
+	query := &dto.TaskBoardQuery{ExecutorID: &executorID, Limit: 50}
+
+	m.statusRepo.On("GetAll").Return([]models.TaskStatus{{ID: 2, Name: "done"}, {ID: 1, Name: "created"}}, nil)
+	m.workflowRepo.On("GetDefault").Return(nil, gorm.ErrRecordNotFound)
+	m.taskRepo.On("GetBoard", query, mock.Anything).Return([]dto.TaskBoardCard{}, []dto.TaskBoardCount{}, nil)
+
+	board, err := controller.GetBoard(&dto.Actor{UserID: uuid.New()}, query)
+
+	require.NoError(t, err)
+	require.Len(t, board.Columns, 2)
+	assert.Equal(t, 1, board.Columns[0].StatusID)
+	assert.Equal(t, 2, board.Columns[1].StatusID)
+}
+
+// Тесты для TaskController.MoveTask
+
+func TestTaskController_MoveTask_ChangesStatusBetweenNeighbours(t *testing.T) {
+	controller, m := newBoardController()
+	task := boardTask(1, 1, "5")
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.taskRepo.On("GetByID", 2).Return(boardTask(2, 2, "1"), nil)
+	m.taskRepo.On("GetByID", 3).Return(boardTask(3, 2, "2"), nil)
+	m.statusRepo.On("GetByID", 2).Return(createTestTaskStatusWithID(2, "in_progress"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+	m.taskRepo.On("MoveOnBoard", 1, 1, 2, "1i").Return(nil)
+
+	err := controller.MoveTask(1, &dto.Actor{UserID: task.ExecutorID}, &dto.MoveTaskDTO{
+		StatusID:     2,
+		AfterTaskID:  intPtr(2),
+		BeforeTaskID: intPtr(3),
+	})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertExpectations(t)
+	m.events.AssertCalled(t, "Create", mock.MatchedBy(func(events []models.TaskEvent) bool {
+		return len(events) == 1 && events[0].EventType == models.TaskEventStatusChanged && *events[0].NewValue == "in_progress"
+	}))
+}
+
+func TestTaskController_MoveTask_ToEndOfColumn(t *testing.T) {
+	controller, m := newBoardController()
+	task := boardTask(1, 1, "5")
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 2).Return(createTestTaskStatusWithID(2, "in_progress"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+	m.taskRepo.On("GetLastRank", 2).Return("3", nil)
+	m.taskRepo.On("MoveOnBoard", 1, 1, 2, "j").Return(nil)
+
+	err := controller.MoveTask(1, &dto.Actor{UserID: task.ExecutorID}, &dto.MoveTaskDTO{StatusID: 2})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertExpectations(t)
+}
+
+func TestTaskController_MoveTask_IllegalTransition(t *testing.T) {
+	controller, m := newBoardController()
+	task := boardTask(1, 1, "5")
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 3).Return(createTestTaskStatusWithID(3, "done"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+
+	err := controller.MoveTask(1, &dto.Actor{UserID: task.CreatorID}, &dto.MoveTaskDTO{StatusID: 3})
+
+	var transitionErr *custom_errors.IllegalStatusTransitionError
+	require.True(t, errors.As(err, &transitionErr))
+	m.taskRepo.AssertNotCalled(t, "MoveOnBoard", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskController_MoveTask_ReorderWithinColumn(t *testing.T) {
+	controller, m := newBoardController()
+	task := boardTask(1, 1, "5")
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.taskRepo.On("GetByID", 2).Return(boardTask(2, 1, "1"), nil)
+	m.statusRepo.On("GetByID", 1).Return(createTestTaskStatusWithID(1, "created"), nil)
+	m.taskRepo.On("MoveOnBoard", 1, 1, 1, "0i").Return(nil)
+
+	err := controller.MoveTask(1, &dto.Actor{UserID: task.CreatorID}, &dto.MoveTaskDTO{StatusID: 1, BeforeTaskID: intPtr(2)})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertExpectations(t)
+	m.workflowRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+	m.events.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskController_MoveTask_ReorderAccessDenied(t *testing.T) {
+	controller, m := newBoardController()
+
+	m.taskRepo.On("GetByID", 1).Return(boardTask(1, 1, "5"), nil)
+	m.statusRepo.On("GetByID", 1).Return(createTestTaskStatusWithID(1, "created"), nil)
+
+	err := controller.MoveTask(1, &dto.Actor{UserID: uuid.New()}, &dto.MoveTaskDTO{StatusID: 1})
+
+	var accessErr *custom_errors.TaskAccessDeniedError
+	require.True(t, errors.As(err, &accessErr))
+	m.taskRepo.AssertNotCalled(t, "MoveOnBoard", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskController_MoveTask_InvalidNeighbours(t *testing.T) {
+	tests := []struct {
+		name    string
+		moveDTO *dto.MoveTaskDTO
+	}{
+		{name: "self", moveDTO: &dto.MoveTaskDTO{StatusID: 1, AfterTaskID: intPtr(1)}},
+		{name: "another column", moveDTO: &dto.MoveTaskDTO{StatusID: 1, AfterTaskID: intPtr(2)}},
+		{name: "not found", moveDTO: &dto.MoveTaskDTO{StatusID: 1, BeforeTaskID: intPtr(9)}},
+		{name: "out of order", moveDTO: &dto.MoveTaskDTO{StatusID: 1, AfterTaskID: intPtr(4), BeforeTaskID: intPtr(3)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, m := newBoardController()
+			task := boardTask(1, 1, "5")
+
+			m.taskRepo.On("GetByID", 1).Return(task, nil)
+			m.taskRepo.On("GetByID", 2).Return(boardTask(2, 2, "1"), nil).Maybe()
+			m.taskRepo.On("GetByID", 3).Return(boardTask(3, 1, "2"), nil).Maybe()
+			m.taskRepo.On("GetByID", 4).Return(boardTask(4, 1, "3"), nil).Maybe()
+			m.taskRepo.On("GetByID", 9).Return(nil, gorm.ErrRecordNotFound).Maybe()
+			m.statusRepo.On("GetByID", 1).Return(createTestTaskStatusWithID(1, "created"), nil)
+
+			err := controller.MoveTask(1, &dto.Actor{UserID: task.CreatorID}, tt.moveDTO)
+
+			var moveErr *custom_errors.InvalidTaskMoveError
+			require.True(t, errors.As(err, &moveErr))
+			m.taskRepo.AssertNotCalled(t, "MoveOnBoard", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTaskController_MoveTask_StatusNotFound(t *testing.T) {
+	controller, m := newBoardController()
+
+	m.taskRepo.On("GetByID", 1).Return(boardTask(1, 1, "5"), nil)
+	m.statusRepo.On("GetByID", 42).Return(nil, gorm.ErrRecordNotFound)
+
+	err := controller.MoveTask(1, &dto.Actor{UserID: uuid.New()}, &dto.MoveTaskDTO{StatusID: 42})
+
+	var statusErr *custom_errors.TaskStatusNotFoundError
+	assert.True(t, errors.As(err, &statusErr))
+}
@@ -0,0 +1,246 @@
+package controllers
+
+import (
+	cuc "common/contracts/user-contracts"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type bulkMocks struct {
+	taskRepo     *MockTaskRepository
+	statusRepo   *MockTaskStatusRepository
+	workflowRepo *MockTaskWorkflowRepository
+	labelRepo    *MockLabelRepository
+	bulkRepo     *MockTaskBulkRepository
+	notification *MockNotificationService
+	userClient   *MockUserClient
+	publisher    *MockTaskEventPublisher
+}
+
+func newBulkController() (*controllers.TaskBulkController, *bulkMocks) {
+	m := &bulkMocks{
+		taskRepo:     new(MockTaskRepository),
+		statusRepo:   new(MockTaskStatusRepository),
+		workflowRepo: new(MockTaskWorkflowRepository),
+		labelRepo:    new(MockLabelRepository),
+		bulkRepo:     new(MockTaskBulkRepository),
+		notification: new(MockNotificationService),
+		userClient:   new(MockUserClient),
+		publisher:    new(MockTaskEventPublisher),
+	}
+	taskController := controllers.NewTaskControllerWithClients(
+		m.taskRepo,
+		m.statusRepo,
+		new(MockTaskFileRepository),
+		m.workflowRepo,
+		newTaskEventRepoStub(),
+		m.notification,
+		m.userClient,
+		new(MockChatClient),
+		new(MockFileClient),
+		newChatMembershipStub(),
+	)
+	taskController.EventPublisher = m.publisher
+	m.publisher.On("Publish", mock.Anything, mock.Anything).Maybe()
+	return controllers.NewTaskBulkController(taskController, m.labelRepo, m.bulkRepo), m
+}
+
+func bulkTestTask(id int, creatorID uuid.UUID) *models.Task {
+	task := createTestTask()
+	task.ID = id
+	task.CreatorID = creatorID
+	return task
+}
+
+func TestTaskBulkController_Reassign_ReportsEachTask(t *testing.T) {
+	controller, m := newBulkController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	own := bulkTestTask(1, actor.UserID)
+	foreign := bulkTestTask(2, uuid.New())
+	executorID := uuid.New()
+
+	m.userClient.On("GetUserByID", &executorID).Return(createTestUserResponseWithEmail("bob@example.com"), nil)
+	m.userClient.On("GetUserByID", &actor.UserID).Return(createTestUserResponse(), nil)
+	m.taskRepo.On("GetByID", 1).Return(own, nil)
+	m.taskRepo.On("GetByID", 2).Return(foreign, nil)
+	m.taskRepo.On("GetByID", 3).Return(nil, gorm.ErrRecordNotFound)
+	m.bulkRepo.On("Apply", mock.MatchedBy(func(changes []dto.TaskBulkChange) bool {
+		return len(changes) == 1 && changes[0].TaskID == 1 && *changes[0].ExecutorID == executorID &&
+			len(changes[0].Events) == 1 && changes[0].Events[0].EventType == models.TaskEventReassigned
+	})).Return(nil).Once()
+	m.notification.On("SendTaskCreatedNotification", 1, own.Title, mock.Anything, executorID, "bob@example.com").Return(nil)
+
+	result, err := controller.Apply(actor, &dto.TaskBulkDTO{
+		TaskIDs:    []int{1, 2, 3, 1},
+		Action:     dto.TaskBulkReassign,
+		ExecutorID: &executorID,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Items, 3)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 2, result.Failed)
+	assert.True(t, result.Items[0].Success)
+	assert.Contains(t, result.Items[1].Error, "no access")
+	assert.Contains(t, result.Items[2].Error, "not found")
+	m.bulkRepo.AssertExpectations(t)
+	m.notification.AssertExpectations(t)
+}
+
+func TestTaskBulkController_Atomic_ValidationFailureAppliesNothing(t *testing.T) {
+	controller, m := newBulkController()
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	m.taskRepo.On("GetByID", 1).Return(bulkTestTask(1, actor.UserID), nil)
+	m.taskRepo.On("GetByID", 2).Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := controller.Apply(actor, &dto.TaskBulkDTO{
+		TaskIDs: []int{1, 2},
+		Action:  dto.TaskBulkDelete,
+		Atomic:  true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Succeeded)
+	assert.Equal(t, 2, result.Failed)
+	assert.Contains(t, result.Items[0].Error, "not applied")
+	m.bulkRepo.AssertNotCalled(t, "Apply", mock.Anything)
+}
+
+func TestTaskBulkController_Atomic_RollsBackAll(t *testing.T) {
+	controller, m := newBulkController()
+	actor := &dto.Actor{UserID: uuid.New(), Permissions: []string{dto.PermissionManageAllTasks}}
+
+	m.taskRepo.On("GetByID", 1).Return(bulkTestTask(1, uuid.New()), nil)
+	m.taskRepo.On("GetByID", 2).Return(bulkTestTask(2, uuid.New()), nil)
+	m.bulkRepo.On("Apply", mock.MatchedBy(func(changes []dto.TaskBulkChange) bool {
+		return len(changes) == 2 && changes[0].Delete && changes[1].Delete
+	})).Return(custom_errors.NewTaskBulkChangeError(2, custom_errors.NewTaskNotFoundError(2)))
+
+	result, err := controller.Apply(actor, &dto.TaskBulkDTO{
+		TaskIDs: []int{1, 2},
+		Action:  dto.TaskBulkDelete,
+		Atomic:  true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Failed)
+	assert.Contains(t, result.Items[0].Error, "rolled back")
+	assert.Equal(t, "task with id 2 not found", result.Items[1].Error)
+}
+
+func TestTaskBulkController_Atomic_UnexpectedError(t *testing.T) {
+	controller, m := newBulkController()
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	m.taskRepo.On("GetByID", 1).Return(bulkTestTask(1, actor.UserID), nil)
+	m.bulkRepo.On("Apply", mock.Anything).Return(errors.New("commit failed"))
+
+	_, err := controller.Apply(actor, &dto.TaskBulkDTO{TaskIDs: []int{1}, Action: dto.TaskBulkDelete, Atomic: true})
+
+	assert.EqualError(t, err, "commit failed")
+}
+
+func TestTaskBulkController_ChangeStatus_NotifiesSubscribers(t *testing.T) {
+	controller, m := newBulkController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	task := bulkTestTask(1, actor.UserID)
+	unchanged := bulkTestTask(2, actor.UserID)
+	unchanged.StatusID = 2
+
+	m.statusRepo.On("GetByID", 2).Return(createTestTaskStatusWithID(2, "in_progress"), nil)
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.taskRepo.On("GetByID", 2).Return(unchanged, nil)
+	m.workflowRepo.On("GetDefault").Return(nil, gorm.ErrRecordNotFound)
+	m.bulkRepo.On("Apply", mock.MatchedBy(func(changes []dto.TaskBulkChange) bool {
+		event := changes[0].Events[0]
+		return len(changes) == 1 && *changes[0].StatusID == 2 && *changes[0].FromStatusID == 1 &&
+			event.EventType == models.TaskEventStatusChanged && *event.OldValue == "created" && *event.NewValue == "in_progress"
+	})).Return(nil)
+	m.userClient.On("GetUsersByIDs", []uuid.UUID{actor.UserID, task.ExecutorID}).Return(&cuc.UsersResponse{Users: []*cuc.User{
+		{ID: actor.UserID, Username: "alice"},
+		{ID: task.ExecutorID, Email: "bob@example.com"},
+	}}, nil)
+	m.notification.On("SendTaskStatusChangedNotification", 1, task.Title, "alice", "created", "in_progress", task.ExecutorID, "bob@example.com").Return(nil)
+
+	statusID := 2
+	result, err := controller.Apply(actor, &dto.TaskBulkDTO{TaskIDs: []int{1, 2}, Action: dto.TaskBulkChangeStatus, StatusID: &statusID})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Succeeded)
+	m.bulkRepo.AssertNumberOfCalls(t, "Apply", 1)
+	m.notification.AssertExpectations(t)
+	m.publisher.AssertNumberOfCalls(t, "Publish", 1)
+}
+
+func TestTaskBulkController_ChangeStatus_ConcurrentlyChanged(t *testing.T) {
+	controller, m := newBulkController()
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	m.statusRepo.On("GetByID", 2).Return(createTestTaskStatusWithID(2, "in_progress"), nil)
+	m.taskRepo.On("GetByID", 1).Return(bulkTestTask(1, actor.UserID), nil)
+	m.workflowRepo.On("GetDefault").Return(nil, gorm.ErrRecordNotFound)
+	// Пока шла проверка, задачу перевели в другой статус: обновление с условием на статус ничего не затронуло
+	m.bulkRepo.On("Apply", mock.Anything).
+		Return(custom_errors.NewTaskBulkChangeError(1, custom_errors.NewTaskStatusChangedError(1, 1)))
+
+	statusID := 2
+	result, err := controller.Apply(actor, &dto.TaskBulkDTO{TaskIDs: []int{1}, Action: dto.TaskBulkChangeStatus, StatusID: &statusID})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, "status of task 1 was changed concurrently: it is no longer 1", result.Items[0].Error)
+	m.notification.AssertNotCalled(t, "SendTaskStatusChangedNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	m.publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestTaskBulkController_AddLabels_SkipsAssigned(t *testing.T) {
+	controller, m := newBulkController()
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	m.labelRepo.On("GetByID", 1).Return(&dto.LabelResponse{ID: 1, Name: "bug"}, nil)
+	m.labelRepo.On("GetByID", 2).Return(&dto.LabelResponse{ID: 2, Name: "ui"}, nil)
+	m.taskRepo.On("GetByID", 1).Return(bulkTestTask(1, actor.UserID), nil)
+	m.labelRepo.On("GetByTaskID", 1).Return([]models.Label{{ID: 1, Name: "bug"}}, nil)
+	m.bulkRepo.On("Apply", mock.MatchedBy(func(changes []dto.TaskBulkChange) bool {
+		return len(changes) == 1 && assert.ObjectsAreEqual([]int{2}, changes[0].LabelIDs) &&
+			len(changes[0].Events) == 1 && *changes[0].Events[0].NewValue == "ui"
+	})).Return(nil)
+
+	result, err := controller.Apply(actor, &dto.TaskBulkDTO{TaskIDs: []int{1}, Action: dto.TaskBulkAddLabels, LabelIDs: []int{1, 2}})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	m.bulkRepo.AssertExpectations(t)
+}
+
+func TestTaskBulkController_MissingParameter(t *testing.T) {
+	controller, m := newBulkController()
+
+	_, err := controller.Apply(&dto.Actor{UserID: uuid.New()}, &dto.TaskBulkDTO{TaskIDs: []int{1}, Action: dto.TaskBulkChangeStatus})
+
+	var invalidErr *custom_errors.InvalidTaskBulkError
+	assert.ErrorAs(t, err, &invalidErr)
+	m.taskRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+}
+
+func TestTaskBulkController_LabelNotFound(t *testing.T) {
+	controller, m := newBulkController()
+
+	m.labelRepo.On("GetByID", 7).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := controller.Apply(&dto.Actor{UserID: uuid.New()}, &dto.TaskBulkDTO{TaskIDs: []int{1}, Action: dto.TaskBulkAddLabels, LabelIDs: []int{7}})
+
+	var labelErr *custom_errors.LabelNotFoundError
+	assert.ErrorAs(t, err, &labelErr)
+}
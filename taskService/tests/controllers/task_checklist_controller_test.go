@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+func newChecklistController() (*controllers.TaskChecklistController, *MockTaskRepository, *MockTaskChecklistRepository, *MockTaskEventRepository) {
+	taskRepo := new(MockTaskRepository)
+	checklistRepo := new(MockTaskChecklistRepository)
+	eventRepo := new(MockTaskEventRepository)
+	return controllers.NewTaskChecklistController(taskRepo, checklistRepo, eventRepo, newChatMembershipStub()), taskRepo, checklistRepo, eventRepo
+}
+
+func TestTaskChecklistController_AddItem(t *testing.T) {
+	controller, taskRepo, checklistRepo, eventRepo := newChecklistController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: task.ExecutorID}
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+	checklistRepo.On("Create", mock.MatchedBy(func(item *models.TaskChecklistItem) bool {
+		return item.TaskID == task.ID && item.Title == "Написать тесты"
+	})).Return(nil)
+	eventRepo.On("Create", mock.MatchedBy(func(events []models.TaskEvent) bool {
+		return len(events) == 1 && events[0].EventType == models.TaskEventChecklistAdded && *events[0].NewValue == "Написать тесты"
+	})).Return(nil)
+
+	item, err := controller.AddItem(task.ID, actor, &dto.AddChecklistItemDTO{Title: "Написать тесты"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Написать тесты", item.Title)
+	eventRepo.AssertExpectations(t)
+}
+
+func TestTaskChecklistController_AddItem_AccessDenied(t *testing.T) {
+	controller, taskRepo, checklistRepo, _ := newChecklistController()
+	task := createTestTask()
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+
+	_, err := controller.AddItem(task.ID, &dto.Actor{UserID: uuid.New()}, &dto.AddChecklistItemDTO{Title: "x"})
+
+	var accessErr *custom_errors.TaskAccessDeniedError
+	assert.ErrorAs(t, err, &accessErr)
+	checklistRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskChecklistController_UpdateItem_MarksDone(t *testing.T) {
+	controller, taskRepo, checklistRepo, eventRepo := newChecklistController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: task.CreatorID}
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+	checklistRepo.On("GetByID", task.ID, 7).Return(&models.TaskChecklistItem{ID: 7, TaskID: task.ID, Title: "Ревью"}, nil)
+	checklistRepo.On("Update", mock.MatchedBy(func(item *models.TaskChecklistItem) bool {
+		return item.Done && *item.DoneBy == actor.UserID && item.DoneAt != nil
+	})).Return(nil)
+	eventRepo.On("Create", mock.MatchedBy(func(events []models.TaskEvent) bool {
+		return len(events) == 1 && events[0].EventType == models.TaskEventChecklistDone
+	})).Return(nil)
+
+	done := true
+	item, err := controller.UpdateItem(task.ID, 7, actor, &dto.UpdateChecklistItemDTO{Done: &done})
+
+	require.NoError(t, err)
+	assert.True(t, item.Done)
+	eventRepo.AssertExpectations(t)
+}
+
+func TestTaskChecklistController_UpdateItem_ReopensAndMoves(t *testing.T) {
+	controller, taskRepo, checklistRepo, eventRepo := newChecklistController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: task.CreatorID}
+	doneBy := uuid.New()
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+	checklistRepo.On("GetByID", task.ID, 3).Return(&models.TaskChecklistItem{ID: 3, TaskID: task.ID, Title: "c", Position: 2, Done: true, DoneBy: &doneBy}, nil)
+	checklistRepo.On("Update", mock.MatchedBy(func(item *models.TaskChecklistItem) bool {
+		return !item.Done && item.DoneBy == nil && item.DoneAt == nil
+	})).Return(nil)
+	checklistRepo.On("GetByTaskID", task.ID).Return([]models.TaskChecklistItem{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+	checklistRepo.On("Reorder", task.ID, []int{3, 1, 2}).Return(nil)
+	eventRepo.On("Create", mock.MatchedBy(func(events []models.TaskEvent) bool {
+		return len(events) == 1 && events[0].EventType == models.TaskEventChecklistReopened
+	})).Return(nil)
+
+	done := false
+	position := 0
+	item, err := controller.UpdateItem(task.ID, 3, actor, &dto.UpdateChecklistItemDTO{Done: &done, Position: &position})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, item.Position)
+	checklistRepo.AssertExpectations(t)
+}
+
+func TestTaskChecklistController_UpdateItem_RenameRecordsNoEvent(t *testing.T) {
+	controller, taskRepo, checklistRepo, eventRepo := newChecklistController()
+	task := createTestTask()
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+	checklistRepo.On("GetByID", task.ID, 3).Return(&models.TaskChecklistItem{ID: 3, TaskID: task.ID, Title: "old"}, nil)
+	checklistRepo.On("Update", mock.Anything).Return(nil)
+
+	title := "new"
+	item, err := controller.UpdateItem(task.ID, 3, &dto.Actor{UserID: task.CreatorID}, &dto.UpdateChecklistItemDTO{Title: &title})
+
+	require.NoError(t, err)
+	assert.Equal(t, "new", item.Title)
+	eventRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskChecklistController_RemoveItem_NotFound(t *testing.T) {
+	controller, taskRepo, checklistRepo, _ := newChecklistController()
+	task := createTestTask()
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+	checklistRepo.On("GetByID", task.ID, 9).Return(nil, gorm.ErrRecordNotFound)
+
+	err := controller.RemoveItem(task.ID, 9, &dto.Actor{UserID: task.CreatorID})
+
+	var itemErr *custom_errors.ChecklistItemNotFoundError
+	assert.ErrorAs(t, err, &itemErr)
+	checklistRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestTaskChecklistController_RemoveItem(t *testing.T) {
+	controller, taskRepo, checklistRepo, eventRepo := newChecklistController()
+	task := createTestTask()
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+	checklistRepo.On("GetByID", task.ID, 9).Return(&models.TaskChecklistItem{ID: 9, TaskID: task.ID, Title: "Лишнее"}, nil)
+	checklistRepo.On("Delete", task.ID, 9).Return(nil)
+	eventRepo.On("Create", mock.MatchedBy(func(events []models.TaskEvent) bool {
+		return events[0].EventType == models.TaskEventChecklistRemoved && *events[0].OldValue == "Лишнее"
+	})).Return(nil)
+
+	require.NoError(t, controller.RemoveItem(task.ID, 9, &dto.Actor{UserID: task.CreatorID}))
+	eventRepo.AssertExpectations(t)
+}
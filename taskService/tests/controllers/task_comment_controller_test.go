@@ -0,0 +1,405 @@
+package controllers
+
+import (
+	cuc "common/contracts/user-contracts"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type commentMocks struct {
+	commentRepo  *MockTaskCommentRepository
+	taskRepo     *MockTaskRepository
+	events       *MockTaskEventRepository
+	notification *MockNotificationService
+	userClient   *MockUserClient
+	fileClient   *MockFileClient
+}
+
+func newCommentController() (*controllers.TaskCommentController, *commentMocks) {
+	return newCommentControllerWithMemberships(newChatMembershipStub())
+}
+
+// newCommentControllerWithMemberships создает контроллер с заданными чатами пользователей
+func newCommentControllerWithMemberships(chatMemberships *MockChatMembershipService) (*controllers.TaskCommentController, *commentMocks) {
+	m := &commentMocks{
+		commentRepo:  new(MockTaskCommentRepository),
+		taskRepo:     new(MockTaskRepository),
+		events:       newTaskEventRepoStub(),
+		notification: new(MockNotificationService),
+		userClient:   new(MockUserClient),
+		fileClient:   new(MockFileClient),
+	}
+	controller := controllers.NewTaskCommentControllerWithClients(
+		m.commentRepo,
+		m.taskRepo,
+		m.events,
+		m.notification,
+		m.userClient,
+		m.fileClient,
+		chatMemberships,
+	)
+	return controller, m
+}
+
+func testUser(id uuid.UUID, username string) *cuc.User {
+	return &cuc.User{ID: id, Username: username, Email: username + "@example.com"}
+}
+
+// Тесты для TaskCommentController.Create
+
+func TestTaskCommentController_Create_NotifiesParticipantsAndMentions(t *testing.T) {
+	controller, m := newCommentController()
+	task := createTestTask()
+	authorID := uuid.New()
+	participantID := uuid.New()
+	mentionedID := uuid.New()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.fileClient.On("GetFileByID", 4).Return(createTestFile(), nil)
+	m.userClient.On("GetUsersByUsernames", []string{"bob", "no.such"}).
+		Return(&cuc.UsersResponse{Users: []*cuc.User{testUser(mentionedID, "bob")}}, nil)
+	m.commentRepo.On("GetAuthorIDs", task.ID).Return([]uuid.UUID{participantID, authorID}, nil)
+	m.commentRepo.On("Create", mock.MatchedBy(func(comment *models.TaskComment) bool {
+		return comment.AuthorID == authorID &&
+			len(comment.Files) == 1 && comment.Files[0].FileID == 4 &&
+			len(comment.Mentions) == 1 && comment.Mentions[0].UserID == mentionedID
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.TaskComment).ID = 11
+	}).Return(nil)
+	m.userClient.On("GetUsersByIDs", []uuid.UUID{authorID, task.CreatorID, task.ExecutorID, participantID, mentionedID}).
+		Return(&cuc.UsersResponse{Users: []*cuc.User{
+			testUser(authorID, "alice"),
+			testUser(task.CreatorID, "creator"),
+			testUser(task.ExecutorID, "executor"),
+			testUser(participantID, "participant"),
+			testUser(mentionedID, "bob"),
+		}}, nil)
+	body := "@bob, please check. cc @no.such."
+	for _, recipient := range []struct {
+		id        uuid.UUID
+		email     string
+		mentioned bool
+	}{
+		{task.CreatorID, "creator@example.com", false},
+		{task.ExecutorID, "executor@example.com", false},
+		{participantID, "participant@example.com", false},
+		{mentionedID, "bob@example.com", true},
+	} {
+		m.notification.On("SendTaskCommentNotification",
+			task.ID, task.Title, 11, "alice", body, recipient.id, recipient.email, recipient.mentioned,
+		).Return(nil).Once()
+	}
+
+	comment, err := controller.Create(task.ID, &dto.Actor{UserID: authorID}, &dto.CreateTaskCommentDTO{
+		Body:    body,
+		FileIDs: []int{4},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 11, comment.ID)
+	m.notification.AssertExpectations(t)
+
+	commented := eventsOf(recordedEvents(m.events), models.TaskEventCommented)
+	require.Len(t, commented, 1)
+	assert.Equal(t, "11", *commented[0].NewValue)
+	assert.Equal(t, authorID, commented[0].ActorID)
+}
+
+func TestTaskCommentController_Create_NotifiesAssigneesAndWatchers(t *testing.T) {
+	controller, m := newCommentController()
+	task := createTestTask()
+	assigneeID := uuid.New()
+	watcherID := uuid.New()
+	task.Assignees = []models.TaskAssignee{{TaskID: task.ID, UserID: assigneeID}}
+	task.Watchers = []models.TaskWatcher{{TaskID: task.ID, UserID: watcherID}}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.commentRepo.On("GetAuthorIDs", task.ID).Return([]uuid.UUID{}, nil)
+	m.commentRepo.On("Create", mock.AnythingOfType("*models.TaskComment")).Return(nil)
+	m.userClient.On("GetUsersByIDs", []uuid.UUID{task.CreatorID, task.ExecutorID, assigneeID, watcherID}).
+		Return(&cuc.UsersResponse{Users: []*cuc.User{
+			testUser(task.CreatorID, "creator"),
+			testUser(task.ExecutorID, "executor"),
+			testUser(assigneeID, "assignee"),
+			testUser(watcherID, "watcher"),
+		}}, nil)
+	for _, recipient := range []struct {
+		id    uuid.UUID
+		email string
+	}{
+		{task.ExecutorID, "executor@example.com"},
+		{assigneeID, "assignee@example.com"},
+		{watcherID, "watcher@example.com"},
+	} {
+		m.notification.On("SendTaskCommentNotification",
+			task.ID, task.Title, 0, "creator", "status?", recipient.id, recipient.email, false,
+		).Return(nil).Once()
+	}
+
+	_, err := controller.Create(task.ID, &dto.Actor{UserID: task.CreatorID}, &dto.CreateTaskCommentDTO{Body: "status?"})
+
+	require.NoError(t, err)
+	m.notification.AssertExpectations(t)
+}
+
+func TestTaskCommentController_Create_MentionedExecutorGetsSingleMentionNotification(t *testing.T) {
+	controller, m := newCommentController()
+	task := createTestTask()
+	task.ExecutorID = uuid.New()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.userClient.On("GetUsersByUsernames", []string{"executor"}).
+		Return(&cuc.UsersResponse{Users: []*cuc.User{testUser(task.ExecutorID, "executor")}}, nil)
+	m.commentRepo.On("GetAuthorIDs", task.ID).Return([]uuid.UUID{}, nil)
+	m.commentRepo.On("Create", mock.AnythingOfType("*models.TaskComment")).Return(nil)
+	m.userClient.On("GetUsersByIDs", []uuid.UUID{task.CreatorID, task.ExecutorID}).
+		Return(&cuc.UsersResponse{Users: []*cuc.User{
+			testUser(task.CreatorID, "creator"),
+			testUser(task.ExecutorID, "executor"),
+		}}, nil)
+	m.notification.On("SendTaskCommentNotification",
+		task.ID, task.Title, 0, "creator", "@executor done?", task.ExecutorID, "executor@example.com", true,
+	).Return(nil).Once()
+
+	_, err := controller.Create(task.ID, &dto.Actor{UserID: task.CreatorID}, &dto.CreateTaskCommentDTO{Body: "@executor done?"})
+
+	require.NoError(t, err)
+	m.notification.AssertExpectations(t)
+	m.notification.AssertNumberOfCalls(t, "SendTaskCommentNotification", 1)
+}
+
+func TestTaskCommentController_Create_SkipsMentionOfUserWhoCannotSeeTask(t *testing.T) {
+	chatMemberships := new(MockChatMembershipService)
+	controller, m := newCommentControllerWithMemberships(chatMemberships)
+	task := createTestTask()
+	authorID := uuid.New()
+	outsiderID := uuid.New()
+
+	chatMemberships.On("GetUserChatIDs", authorID).Return([]uuid.UUID{testChatID}, nil)
+	chatMemberships.On("GetUserChatIDs", outsiderID).Return([]uuid.UUID{}, nil)
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.userClient.On("GetUsersByUsernames", []string{"mallory"}).
+		Return(&cuc.UsersResponse{Users: []*cuc.User{testUser(outsiderID, "mallory")}}, nil)
+	m.commentRepo.On("GetAuthorIDs", task.ID).Return([]uuid.UUID{}, nil)
+	m.commentRepo.On("Create", mock.AnythingOfType("*models.TaskComment")).Return(nil)
+	m.userClient.On("GetUsersByIDs", []uuid.UUID{authorID, task.CreatorID, task.ExecutorID, outsiderID}).
+		Return(&cuc.UsersResponse{Users: []*cuc.User{
+			testUser(authorID, "alice"),
+			testUser(task.CreatorID, "creator"),
+			testUser(task.ExecutorID, "executor"),
+			testUser(outsiderID, "mallory"),
+		}}, nil)
+	m.notification.On("SendTaskCommentNotification",
+		task.ID, task.Title, 0, "alice", "@mallory look", mock.Anything, mock.Anything, false,
+	).Return(nil)
+
+	_, err := controller.Create(task.ID, &dto.Actor{UserID: authorID}, &dto.CreateTaskCommentDTO{Body: "@mallory look"})
+
+	require.NoError(t, err)
+	m.notification.AssertNumberOfCalls(t, "SendTaskCommentNotification", 2)
+	m.notification.AssertNotCalled(t, "SendTaskCommentNotification",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, outsiderID, mock.Anything, mock.Anything)
+	chatMemberships.AssertExpectations(t)
+}
+
+func TestTaskCommentController_Create_IgnoresEmailsAndNotificationFailures(t *testing.T) {
+	controller, m := newCommentController()
+	task := createTestTask()
+	task.ExecutorID = uuid.Nil
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.commentRepo.On("GetAuthorIDs", task.ID).Return([]uuid.UUID{}, nil)
+	m.commentRepo.On("Create", mock.AnythingOfType("*models.TaskComment")).Return(nil)
+	m.userClient.On("GetUsersByIDs", mock.Anything).Return(nil, errors.New("user service down"))
+
+	_, err := controller.Create(task.ID, &dto.Actor{UserID: uuid.New()}, &dto.CreateTaskCommentDTO{Body: "mail me at a@b.c"})
+
+	require.NoError(t, err)
+	m.userClient.AssertNotCalled(t, "GetUsersByUsernames", mock.Anything)
+	m.notification.AssertNotCalled(t, "SendTaskCommentNotification",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskCommentController_Create_TaskNotFound(t *testing.T) {
+	controller, m := newCommentController()
+
+	m.taskRepo.On("GetByID", 404).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := controller.Create(404, &dto.Actor{UserID: uuid.New()}, &dto.CreateTaskCommentDTO{Body: "hi"})
+
+	var taskErr *custom_errors.TaskNotFoundError
+	assert.True(t, errors.As(err, &taskErr))
+	m.commentRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskCommentController_Create_FileHTTPError(t *testing.T) {
+	controller, m := newCommentController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.fileClient.On("GetFileByID", 9).Return(nil, errors.New("file service down"))
+
+	_, err := controller.Create(task.ID, &dto.Actor{UserID: task.CreatorID}, &dto.CreateTaskCommentDTO{Body: "see file", FileIDs: []int{9}})
+
+	var fileErr *custom_errors.GetFileHTTPError
+	assert.True(t, errors.As(err, &fileErr))
+	m.commentRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskCommentController_Create_MentionLookupError(t *testing.T) {
+	controller, m := newCommentController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.userClient.On("GetUsersByUsernames", []string{"bob"}).Return(nil, errors.New("user service down"))
+
+	_, err := controller.Create(task.ID, &dto.Actor{UserID: task.CreatorID}, &dto.CreateTaskCommentDTO{Body: "@bob"})
+
+	var userErr *custom_errors.GetUserHTTPError
+	assert.True(t, errors.As(err, &userErr))
+	m.commentRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// Тесты для TaskCommentController.Update
+
+func TestTaskCommentController_Update_NotifiesOnlyNewMentions(t *testing.T) {
+	controller, m := newCommentController()
+	task := createTestTask()
+	authorID := uuid.New()
+	oldMentionID := uuid.New()
+	newMentionID := uuid.New()
+	comment := &models.TaskComment{
+		ID:       5,
+		TaskID:   task.ID,
+		AuthorID: authorID,
+		Body:     "@old",
+		Files:    []models.TaskCommentFile{{CommentID: 5, FileID: 1}, {CommentID: 5, FileID: 2}},
+		Mentions: []models.TaskCommentMention{{CommentID: 5, UserID: oldMentionID}},
+	}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.commentRepo.On("GetByID", 5).Return(comment, nil)
+	m.fileClient.On("GetFileByID", 3).Return(createTestFile(), nil)
+	m.userClient.On("GetUsersByUsernames", []string{"old", "new"}).
+		Return(&cuc.UsersResponse{Users: []*cuc.User{testUser(oldMentionID, "old"), testUser(newMentionID, "new")}}, nil)
+	m.commentRepo.On("Update", mock.MatchedBy(func(updated *models.TaskComment) bool {
+		return updated.Body == "@old and @new" && len(updated.Mentions) == 2 && updated.UpdatedAt != nil
+	}), []models.TaskCommentFile{{CommentID: 5, FileID: 3}}, []int{2}).Return(nil)
+	m.userClient.On("GetUsersByIDs", []uuid.UUID{authorID, newMentionID}).
+		Return(&cuc.UsersResponse{Users: []*cuc.User{testUser(authorID, "alice"), testUser(newMentionID, "new")}}, nil)
+	m.notification.On("SendTaskCommentNotification",
+		task.ID, task.Title, 5, "alice", "@old and @new", newMentionID, "new@example.com", true,
+	).Return(nil).Once()
+
+	result, err := controller.Update(task.ID, 5, &dto.Actor{UserID: authorID}, &dto.UpdateTaskCommentDTO{
+		Body:          stringPtr("@old and @new"),
+		AddFileIDs:    []int{1, 3},
+		RemoveFileIDs: []int{2},
+	})
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{1, 3}, []int{result.Files[0].FileID, result.Files[1].FileID})
+	m.fileClient.AssertNotCalled(t, "GetFileByID", 1)
+	m.commentRepo.AssertExpectations(t)
+	m.notification.AssertExpectations(t)
+}
+
+func TestTaskCommentController_Update_OnlyAuthor(t *testing.T) {
+	controller, m := newCommentController()
+	task := createTestTask()
+	comment := &models.TaskComment{ID: 5, TaskID: task.ID, AuthorID: uuid.New(), Body: "text"}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.commentRepo.On("GetByID", 5).Return(comment, nil)
+
+	_, err := controller.Update(task.ID, 5, &dto.Actor{
+		UserID:      task.CreatorID,
+		Permissions: []string{dto.PermissionManageAllTasks},
+	}, &dto.UpdateTaskCommentDTO{Body: stringPtr("edited")})
+
+	var accessErr *custom_errors.TaskCommentAccessDeniedError
+	assert.True(t, errors.As(err, &accessErr))
+	m.commentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskCommentController_Update_CommentOfAnotherTask(t *testing.T) {
+	controller, m := newCommentController()
+	task := createTestTask()
+	authorID := uuid.New()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.commentRepo.On("GetByID", 5).Return(&models.TaskComment{ID: 5, TaskID: task.ID + 1, AuthorID: authorID}, nil)
+
+	_, err := controller.Update(task.ID, 5, &dto.Actor{UserID: authorID}, &dto.UpdateTaskCommentDTO{Body: stringPtr("x")})
+
+	var commentErr *custom_errors.TaskCommentNotFoundError
+	assert.True(t, errors.As(err, &commentErr))
+}
+
+// Тесты для TaskCommentController.Delete
+
+func TestTaskCommentController_Delete_WithManageAllTasksPermission(t *testing.T) {
+	controller, m := newCommentController()
+	comment := &models.TaskComment{ID: 5, TaskID: 1, AuthorID: uuid.New()}
+
+	m.taskRepo.On("GetByID", 1).Return(createTestTask(), nil)
+	m.commentRepo.On("GetByID", 5).Return(comment, nil)
+	m.commentRepo.On("Delete", 5).Return(nil)
+
+	err := controller.Delete(1, 5, &dto.Actor{UserID: uuid.New(), Permissions: []string{dto.PermissionManageAllTasks}})
+
+	require.NoError(t, err)
+	m.commentRepo.AssertExpectations(t)
+}
+
+func TestTaskCommentController_Delete_AccessDenied(t *testing.T) {
+	controller, m := newCommentController()
+	comment := &models.TaskComment{ID: 5, TaskID: 1, AuthorID: uuid.New()}
+
+	m.taskRepo.On("GetByID", 1).Return(createTestTask(), nil)
+	m.commentRepo.On("GetByID", 5).Return(comment, nil)
+
+	err := controller.Delete(1, 5, &dto.Actor{UserID: uuid.New()})
+
+	var accessErr *custom_errors.TaskCommentAccessDeniedError
+	assert.True(t, errors.As(err, &accessErr))
+	m.commentRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestTaskCommentController_Delete_NotFound(t *testing.T) {
+	controller, m := newCommentController()
+
+	m.taskRepo.On("GetByID", 1).Return(createTestTask(), nil)
+	m.commentRepo.On("GetByID", 5).Return(nil, gorm.ErrRecordNotFound)
+
+	err := controller.Delete(1, 5, &dto.Actor{UserID: uuid.New()})
+
+	var commentErr *custom_errors.TaskCommentNotFoundError
+	assert.True(t, errors.As(err, &commentErr))
+}
+
+// Тесты для TaskCommentController.GetByTaskID
+
+func TestTaskCommentController_GetByTaskID(t *testing.T) {
+	controller, m := newCommentController()
+	task := createTestTask()
+	comments := []models.TaskComment{{ID: 1, TaskID: task.ID, Body: "first"}}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.commentRepo.On("GetByTaskID", task.ID, 20, 0).Return(comments, nil)
+
+	result, err := controller.GetByTaskID(task.ID, &dto.Actor{UserID: task.CreatorID}, 20, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, comments, result)
+}
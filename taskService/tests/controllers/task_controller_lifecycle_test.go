@@ -0,0 +1,223 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type lifecycleMocks struct {
+	taskRepo     *MockTaskRepository
+	taskFileRepo *MockTaskFileRepository
+	notification *MockNotificationService
+	userClient   *MockUserClient
+	chatClient   *MockChatClient
+	fileClient   *MockFileClient
+	events       *MockTaskEventRepository
+}
+
+func newLifecycleController() (*controllers.TaskController, *lifecycleMocks) {
+	m := &lifecycleMocks{
+		taskRepo:     new(MockTaskRepository),
+		taskFileRepo: new(MockTaskFileRepository),
+		notification: new(MockNotificationService),
+		userClient:   new(MockUserClient),
+		chatClient:   new(MockChatClient),
+		fileClient:   new(MockFileClient),
+		events:       newTaskEventRepoStub(),
+	}
+	controller := controllers.NewTaskControllerWithClients(
+		m.taskRepo,
+		new(MockTaskStatusRepository),
+		m.taskFileRepo,
+		new(MockTaskWorkflowRepository),
+		m.events,
+		m.notification,
+		m.userClient,
+		m.chatClient,
+		m.fileClient,
+		newChatMembershipStub(),
+	)
+	return controller, m
+}
+
+// Тесты для TaskController.Update
+
+func TestTaskController_Update_ByCreator(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	task.Files = []models.TaskFile{{TaskID: task.ID, FileID: 1}}
+	task.ChatID = uuid.New()
+	actor := &dto.Actor{UserID: task.CreatorID}
+	// Задачу переносят в чат, где состоит actor
+	chatID := testChatID
+
+	updateDTO := &dto.UpdateTaskDTO{
+		Title:         stringPtr("New title"),
+		ChatID:        &chatID,
+		AddFileIDs:    []int{1, 2},
+		RemoveFileIDs: []int{3},
+	}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.chatClient.On("GetChatByID", chatID.String()).Return(createTestChat(), nil)
+	m.fileClient.On("GetFileByID", 2).Return(createTestFile(), nil)
+	m.taskRepo.On("Update", mock.MatchedBy(func(updated *models.Task) bool {
+		return updated.Title == "New title" && updated.ChatID == chatID && updated.UpdatedAt != nil
+	})).Return(nil)
+	m.taskFileRepo.On("DeleteByFileIDs", task.ID, []int{3}).Return(nil)
+	m.taskFileRepo.On("BulkCreate", []models.TaskFile{{TaskID: task.ID, FileID: 2}}).Return(nil)
+
+	result, err := controller.Update(task.ID, actor, updateDTO)
+
+	require.NoError(t, err)
+	assert.Equal(t, "New title", result.Title)
+	m.taskRepo.AssertExpectations(t)
+	m.taskFileRepo.AssertExpectations(t)
+	m.chatClient.AssertExpectations(t)
+	m.fileClient.AssertExpectations(t)
+	m.fileClient.AssertNotCalled(t, "GetFileByID", 1)
+}
+
+func TestTaskController_Update_ReassignNotifiesNewExecutor(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: task.ExecutorID}
+	newExecutorID := uuid.New()
+	newExecutor := createTestUserResponseWithEmail("new@example.com")
+	actorResp := createTestUserResponse()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.userClient.On("GetUserByID", &newExecutorID).Return(newExecutor, nil)
+	m.userClient.On("GetUserByID", &actor.UserID).Return(actorResp, nil)
+	m.taskRepo.On("Update", mock.AnythingOfType("*models.Task")).Return(nil)
+	m.notification.On("SendTaskCreatedNotification",
+		task.ID, task.Title, actorResp.User.Username, newExecutorID, "new@example.com",
+	).Return(nil)
+
+	result, err := controller.Update(task.ID, actor, &dto.UpdateTaskDTO{ExecutorID: &newExecutorID})
+
+	require.NoError(t, err)
+	assert.Equal(t, newExecutorID, result.ExecutorID)
+	m.notification.AssertExpectations(t)
+	m.taskFileRepo.AssertNotCalled(t, "BulkCreate", mock.Anything)
+}
+
+func TestTaskController_Update_WithManageAllTasksPermission(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: uuid.New(), Permissions: []string{dto.PermissionManageAllTasks}}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("Update", mock.AnythingOfType("*models.Task")).Return(nil)
+
+	_, err := controller.Update(task.ID, actor, &dto.UpdateTaskDTO{Description: stringPtr("")})
+
+	require.NoError(t, err)
+	assert.Equal(t, "", task.Description)
+	m.taskRepo.AssertExpectations(t)
+}
+
+func TestTaskController_Update_AccessDenied(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: uuid.New(), Permissions: []string{"process_tasks"}}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+
+	result, err := controller.Update(task.ID, actor, &dto.UpdateTaskDTO{Title: stringPtr("x")})
+
+	assert.Nil(t, result)
+	var accessErr *custom_errors.TaskAccessDeniedError
+	assert.True(t, errors.As(err, &accessErr))
+	m.taskRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTaskController_Update_TaskNotFound(t *testing.T) {
+	controller, m := newLifecycleController()
+
+	m.taskRepo.On("GetByID", 42).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := controller.Update(42, &dto.Actor{UserID: uuid.New()}, &dto.UpdateTaskDTO{})
+
+	var notFoundErr *custom_errors.TaskNotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+}
+
+func TestTaskController_Update_FileHTTPError(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.fileClient.On("GetFileByID", 5).Return(nil, errors.New("file service down"))
+
+	_, err := controller.Update(task.ID, &dto.Actor{UserID: task.CreatorID}, &dto.UpdateTaskDTO{AddFileIDs: []int{5}})
+
+	var fileErr *custom_errors.GetFileHTTPError
+	assert.True(t, errors.As(err, &fileErr))
+	m.taskRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+// Тесты для TaskController.Delete
+
+func TestTaskController_Delete_Success(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("Delete", task.ID).Return(nil)
+
+	err := controller.Delete(task.ID, &dto.Actor{UserID: task.CreatorID})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertExpectations(t)
+}
+
+func TestTaskController_Delete_AccessDenied(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+
+	err := controller.Delete(task.ID, &dto.Actor{UserID: uuid.New()})
+
+	var accessErr *custom_errors.TaskAccessDeniedError
+	assert.True(t, errors.As(err, &accessErr))
+	m.taskRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+// Тесты для списков задач
+
+func TestTaskController_GetCreatedTasks(t *testing.T) {
+	controller, m := newLifecycleController()
+	userID := uuid.New().String()
+	tasks := createTestTaskToList()
+
+	m.taskRepo.On("GetCreatedTasks", userID, 20, 0, mock.Anything).Return(tasks, nil)
+
+	result, err := controller.GetCreatedTasks(userID, &dto.Actor{UserID: uuid.New()}, 20, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, tasks, result)
+}
+
+func TestTaskController_GetChatTasks(t *testing.T) {
+	controller, m := newLifecycleController()
+	chatID := uuid.New().String()
+
+	m.taskRepo.On("GetChatTasks", chatID, 10, 10, mock.Anything).Return(nil, errors.New("db error"))
+
+	result, err := controller.GetChatTasks(chatID, &dto.Actor{UserID: uuid.New()}, 10, 10)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
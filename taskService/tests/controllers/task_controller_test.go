@@ -31,10 +31,13 @@ func TestTaskController_Create_Success(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	creatorID := uuid.New()
@@ -46,7 +49,6 @@ func TestTaskController_Create_Success(t *testing.T) {
 	taskDTO := &dto.CreateTaskDTO{
 		Title:       "Test Task",
 		Description: stringPtr("Test Description"),
-		CreatorID:   creatorID,
 		ExecutorID:  executorID,
 		FileIDs:     []int{1, 2},
 	}
@@ -78,7 +80,7 @@ func TestTaskController_Create_Success(t *testing.T) {
 	).Return(nil)
 
 	// Act
-	result, err := controller.Create(taskDTO)
+	result, err := controller.Create(&dto.Actor{UserID: creatorID}, taskDTO)
 
 	// Assert
 	require.NoError(t, err)
@@ -110,10 +112,13 @@ func TestTaskController_Create_WithoutExecutor(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	creatorID := uuid.New()
@@ -123,7 +128,6 @@ func TestTaskController_Create_WithoutExecutor(t *testing.T) {
 	taskDTO := &dto.CreateTaskDTO{
 		Title:       "Test Task",
 		Description: stringPtr("Test Description"),
-		CreatorID:   creatorID,
 		ExecutorID:  uuid.Nil,
 		FileIDs:     []int{},
 	}
@@ -144,7 +148,7 @@ func TestTaskController_Create_WithoutExecutor(t *testing.T) {
 	})
 
 	// Act
-	result, err := controller.Create(taskDTO)
+	result, err := controller.Create(&dto.Actor{UserID: creatorID}, taskDTO)
 
 	// Assert
 	require.NoError(t, err)
@@ -171,23 +175,25 @@ func TestTaskController_Create_StatusNotFound(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	creatorID := uuid.New()
 	taskDTO := &dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  creatorID,
 		ExecutorID: uuid.Nil,
 	}
 
 	mockTaskStatusRepo.On("GetByName", "created").Return(nil, gorm.ErrRecordNotFound)
 
 	// Act
-	result, err := controller.Create(taskDTO)
+	result, err := controller.Create(&dto.Actor{UserID: creatorID}, taskDTO)
 
 	// Assert
 	require.Error(t, err)
@@ -213,10 +219,13 @@ func TestTaskController_Create_GetCreatorError(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	creatorID := uuid.New()
@@ -225,7 +234,6 @@ func TestTaskController_Create_GetCreatorError(t *testing.T) {
 
 	taskDTO := &dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  creatorID,
 		ExecutorID: uuid.Nil,
 	}
 
@@ -233,7 +241,7 @@ func TestTaskController_Create_GetCreatorError(t *testing.T) {
 	mockUserClient.On("GetUserByID", &creatorID).Return(nil, userError)
 
 	// Act
-	result, err := controller.Create(taskDTO)
+	result, err := controller.Create(&dto.Actor{UserID: creatorID}, taskDTO)
 
 	// Assert
 	require.Error(t, err)
@@ -260,10 +268,13 @@ func TestTaskController_Create_GetExecutorError(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	creatorID := uuid.New()
@@ -274,7 +285,6 @@ func TestTaskController_Create_GetExecutorError(t *testing.T) {
 
 	taskDTO := &dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  creatorID,
 		ExecutorID: executorID,
 	}
 
@@ -283,7 +293,7 @@ func TestTaskController_Create_GetExecutorError(t *testing.T) {
 	mockUserClient.On("GetUserByID", &executorID).Return(nil, userError)
 
 	// Act
-	result, err := controller.Create(taskDTO)
+	result, err := controller.Create(&dto.Actor{UserID: creatorID}, taskDTO)
 
 	// Assert
 	require.Error(t, err)
@@ -310,10 +320,13 @@ func TestTaskController_Create_GetChatError(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	creatorID := uuid.New()
@@ -324,7 +337,6 @@ func TestTaskController_Create_GetChatError(t *testing.T) {
 
 	taskDTO := &dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  creatorID,
 		ExecutorID: uuid.Nil,
 		ChatID:     chatID,
 	}
@@ -334,7 +346,7 @@ func TestTaskController_Create_GetChatError(t *testing.T) {
 	mockChatClient.On("GetChatByID", chatID.String()).Return(nil, chatError)
 
 	// Act
-	result, err := controller.Create(taskDTO)
+	result, err := controller.Create(&dto.Actor{UserID: creatorID}, taskDTO)
 
 	// Assert
 	require.Error(t, err)
@@ -362,10 +374,13 @@ func TestTaskController_Create_GetFileError(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	creatorID := uuid.New()
@@ -375,7 +390,6 @@ func TestTaskController_Create_GetFileError(t *testing.T) {
 
 	taskDTO := &dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  creatorID,
 		ExecutorID: uuid.Nil,
 		FileIDs:    []int{1},
 	}
@@ -385,7 +399,7 @@ func TestTaskController_Create_GetFileError(t *testing.T) {
 	mockFileClient.On("GetFileByID", 1).Return(nil, fileError)
 
 	// Act
-	result, err := controller.Create(taskDTO)
+	result, err := controller.Create(&dto.Actor{UserID: creatorID}, taskDTO)
 
 	// Assert
 	require.Error(t, err)
@@ -413,10 +427,13 @@ func TestTaskController_Create_TaskRepoError(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	creatorID := uuid.New()
@@ -426,7 +443,6 @@ func TestTaskController_Create_TaskRepoError(t *testing.T) {
 
 	taskDTO := &dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  creatorID,
 		ExecutorID: uuid.Nil,
 		FileIDs:    []int{},
 	}
@@ -436,7 +452,7 @@ func TestTaskController_Create_TaskRepoError(t *testing.T) {
 	mockTaskRepo.On("Create", mock.Anything).Return(repoError)
 
 	// Act
-	result, err := controller.Create(taskDTO)
+	result, err := controller.Create(&dto.Actor{UserID: creatorID}, taskDTO)
 
 	// Assert
 	require.Error(t, err)
@@ -462,10 +478,13 @@ func TestTaskController_Create_TaskFileRepoError(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	creatorID := uuid.New()
@@ -475,7 +494,6 @@ func TestTaskController_Create_TaskFileRepoError(t *testing.T) {
 
 	taskDTO := &dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  creatorID,
 		ExecutorID: uuid.Nil,
 		FileIDs:    []int{1},
 	}
@@ -490,7 +508,7 @@ func TestTaskController_Create_TaskFileRepoError(t *testing.T) {
 	mockTaskFileRepo.On("BulkCreate", mock.Anything).Return(fileRepoError)
 
 	// Act
-	result, err := controller.Create(taskDTO)
+	result, err := controller.Create(&dto.Actor{UserID: creatorID}, taskDTO)
 
 	// Assert
 	require.Error(t, err)
@@ -518,10 +536,13 @@ func TestTaskController_Create_NotificationError(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	creatorID := uuid.New()
@@ -533,7 +554,6 @@ func TestTaskController_Create_NotificationError(t *testing.T) {
 
 	taskDTO := &dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  creatorID,
 		ExecutorID: executorID,
 		FileIDs:    []int{},
 	}
@@ -554,7 +574,7 @@ func TestTaskController_Create_NotificationError(t *testing.T) {
 	).Return(notificationError)
 
 	// Act
-	result, err := controller.Create(taskDTO)
+	result, err := controller.Create(&dto.Actor{UserID: creatorID}, taskDTO)
 
 	// Assert
 	// Ошибка уведомления не должна прерывать создание задачи
@@ -581,21 +601,23 @@ func TestTaskController_Create_WithChatID(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	creatorID := uuid.New()
-	chatID := uuid.New()
+	chatID := testChatID
 	status := createTestTaskStatus()
 	creator := createTestUserResponse()
 	chat := createTestChat()
 
 	taskDTO := &dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  creatorID,
 		ExecutorID: uuid.Nil,
 		ChatID:     chatID,
 		FileIDs:    []int{},
@@ -610,7 +632,7 @@ func TestTaskController_Create_WithChatID(t *testing.T) {
 	})
 
 	// Act
-	result, err := controller.Create(taskDTO)
+	result, err := controller.Create(&dto.Actor{UserID: creatorID}, taskDTO)
 
 	// Assert
 	require.NoError(t, err)
@@ -637,10 +659,13 @@ func TestTaskController_Create_NilDescription(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	creatorID := uuid.New()
@@ -650,7 +675,6 @@ func TestTaskController_Create_NilDescription(t *testing.T) {
 	taskDTO := &dto.CreateTaskDTO{
 		Title:       "Test Task",
 		Description: nil,
-		CreatorID:   creatorID,
 		ExecutorID:  uuid.Nil,
 		FileIDs:     []int{},
 	}
@@ -665,7 +689,7 @@ func TestTaskController_Create_NilDescription(t *testing.T) {
 	})
 
 	// Act
-	result, err := controller.Create(taskDTO)
+	result, err := controller.Create(&dto.Actor{UserID: creatorID}, taskDTO)
 
 	// Assert
 	require.NoError(t, err)
@@ -677,121 +701,6 @@ func TestTaskController_Create_NilDescription(t *testing.T) {
 	mockTaskRepo.AssertExpectations(t)
 }
 
-// Тесты для TaskController.UpdateStatus
-
-func TestTaskController_UpdateStatus_Success(t *testing.T) {
-	// Arrange
-	mockTaskRepo := new(MockTaskRepository)
-	mockTaskStatusRepo := new(MockTaskStatusRepository)
-	mockTaskFileRepo := new(MockTaskFileRepository)
-	mockNotificationService := new(MockNotificationService)
-	mockUserClient := new(MockUserClient)
-	mockChatClient := new(MockChatClient)
-	mockFileClient := new(MockFileClient)
-
-	controller := controllers.NewTaskControllerWithClients(
-		mockTaskRepo,
-		mockTaskStatusRepo,
-		mockTaskFileRepo,
-		mockNotificationService,
-		mockUserClient,
-		mockChatClient,
-		mockFileClient,
-	)
-
-	taskID := 1
-	statusID := 2
-	status := createTestTaskStatusWithID(statusID, "in_progress")
-
-	mockTaskStatusRepo.On("GetByID", statusID).Return(status, nil)
-	mockTaskRepo.On("UpdateStatus", taskID, statusID).Return(nil)
-
-	// Act
-	err := controller.UpdateStatus(taskID, statusID)
-
-	// Assert
-	require.NoError(t, err)
-
-	mockTaskStatusRepo.AssertExpectations(t)
-	mockTaskRepo.AssertExpectations(t)
-}
-
-func TestTaskController_UpdateStatus_StatusNotFound(t *testing.T) {
-	// Arrange
-	mockTaskRepo := new(MockTaskRepository)
-	mockTaskStatusRepo := new(MockTaskStatusRepository)
-	mockTaskFileRepo := new(MockTaskFileRepository)
-	mockNotificationService := new(MockNotificationService)
-	mockUserClient := new(MockUserClient)
-	mockChatClient := new(MockChatClient)
-	mockFileClient := new(MockFileClient)
-
-	controller := controllers.NewTaskControllerWithClients(
-		mockTaskRepo,
-		mockTaskStatusRepo,
-		mockTaskFileRepo,
-		mockNotificationService,
-		mockUserClient,
-		mockChatClient,
-		mockFileClient,
-	)
-
-	taskID := 1
-	statusID := 999
-
-	mockTaskStatusRepo.On("GetByID", statusID).Return(nil, gorm.ErrRecordNotFound)
-
-	// Act
-	err := controller.UpdateStatus(taskID, statusID)
-
-	// Assert
-	require.Error(t, err)
-	var statusNotFoundErr *custom_errors.TaskStatusNotFoundError
-	assert.True(t, errors.As(err, &statusNotFoundErr))
-
-	mockTaskStatusRepo.AssertExpectations(t)
-	mockTaskRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
-}
-
-func TestTaskController_UpdateStatus_TaskRepoError(t *testing.T) {
-	// Arrange
-	mockTaskRepo := new(MockTaskRepository)
-	mockTaskStatusRepo := new(MockTaskStatusRepository)
-	mockTaskFileRepo := new(MockTaskFileRepository)
-	mockNotificationService := new(MockNotificationService)
-	mockUserClient := new(MockUserClient)
-	mockChatClient := new(MockChatClient)
-	mockFileClient := new(MockFileClient)
-
-	controller := controllers.NewTaskControllerWithClients(
-		mockTaskRepo,
-		mockTaskStatusRepo,
-		mockTaskFileRepo,
-		mockNotificationService,
-		mockUserClient,
-		mockChatClient,
-		mockFileClient,
-	)
-
-	taskID := 1
-	statusID := 2
-	status := createTestTaskStatusWithID(statusID, "in_progress")
-	repoError := errors.New("database error")
-
-	mockTaskStatusRepo.On("GetByID", statusID).Return(status, nil)
-	mockTaskRepo.On("UpdateStatus", taskID, statusID).Return(repoError)
-
-	// Act
-	err := controller.UpdateStatus(taskID, statusID)
-
-	// Assert
-	require.Error(t, err)
-	assert.Equal(t, repoError, err)
-
-	mockTaskStatusRepo.AssertExpectations(t)
-	mockTaskRepo.AssertExpectations(t)
-}
-
 // Тесты для TaskController.GetByID
 
 func TestTaskController_GetByID_Success(t *testing.T) {
@@ -808,10 +717,13 @@ func TestTaskController_GetByID_Success(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	taskID := 1
@@ -830,7 +742,7 @@ func TestTaskController_GetByID_Success(t *testing.T) {
 	mockFileClient.On("GetFileByID", 2).Return(file2, nil)
 
 	// Act
-	result, err := controller.GetByID(taskID)
+	result, err := controller.GetByID(taskID, &dto.Actor{UserID: task.CreatorID})
 
 	// Assert
 	require.NoError(t, err)
@@ -857,17 +769,20 @@ func TestTaskController_GetByID_TaskNotFound(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	taskID := 999
 	mockTaskRepo.On("GetByID", taskID).Return(nil, gorm.ErrRecordNotFound)
 
 	// Act
-	result, err := controller.GetByID(taskID)
+	result, err := controller.GetByID(taskID, &dto.Actor{UserID: uuid.New()})
 
 	// Assert
 	require.Error(t, err)
@@ -891,10 +806,13 @@ func TestTaskController_GetByID_GetFileError(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	taskID := 1
@@ -908,7 +826,7 @@ func TestTaskController_GetByID_GetFileError(t *testing.T) {
 	mockFileClient.On("GetFileByID", 1).Return(nil, fileError)
 
 	// Act
-	result, err := controller.GetByID(taskID)
+	result, err := controller.GetByID(taskID, &dto.Actor{UserID: task.CreatorID})
 
 	// Assert
 	require.Error(t, err)
@@ -934,10 +852,13 @@ func TestTaskController_GetByID_NoFiles(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	taskID := 1
@@ -947,7 +868,7 @@ func TestTaskController_GetByID_NoFiles(t *testing.T) {
 	mockTaskRepo.On("GetByID", taskID).Return(task, nil)
 
 	// Act
-	result, err := controller.GetByID(taskID)
+	result, err := controller.GetByID(taskID, &dto.Actor{UserID: task.CreatorID})
 
 	// Assert
 	require.NoError(t, err)
@@ -976,10 +897,13 @@ func TestTaskController_GetUserTasks_Success(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	userID := uuid.New().String()
@@ -987,10 +911,10 @@ func TestTaskController_GetUserTasks_Success(t *testing.T) {
 	offset := 0
 	expectedTasks := createTestTaskToList()
 
-	mockTaskRepo.On("GetUserTasks", userID, limit, offset).Return(expectedTasks, nil)
+	mockTaskRepo.On("GetUserTasks", userID, (*dto.TaskListFilter)(nil), limit, offset, mock.Anything).Return(expectedTasks, nil)
 
 	// Act
-	result, err := controller.GetUserTasks(userID, limit, offset)
+	result, err := controller.GetUserTasks(userID, &dto.Actor{UserID: uuid.New()}, nil, limit, offset)
 
 	// Assert
 	require.NoError(t, err)
@@ -1013,10 +937,13 @@ func TestTaskController_GetUserTasks_RepositoryError(t *testing.T) {
 		mockTaskRepo,
 		mockTaskStatusRepo,
 		mockTaskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
 		mockNotificationService,
 		mockUserClient,
 		mockChatClient,
 		mockFileClient,
+		newChatMembershipStub(),
 	)
 
 	userID := uuid.New().String()
@@ -1024,10 +951,10 @@ func TestTaskController_GetUserTasks_RepositoryError(t *testing.T) {
 	offset := 0
 	repoError := errors.New("database error")
 
-	mockTaskRepo.On("GetUserTasks", userID, limit, offset).Return(nil, repoError)
+	mockTaskRepo.On("GetUserTasks", userID, (*dto.TaskListFilter)(nil), limit, offset, mock.Anything).Return(nil, repoError)
 
 	// Act
-	result, err := controller.GetUserTasks(userID, limit, offset)
+	result, err := controller.GetUserTasks(userID, &dto.Actor{UserID: uuid.New()}, nil, limit, offset)
 
 	// Assert
 	require.Error(t, err)
@@ -0,0 +1,342 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type dependencyMocks struct {
+	taskRepo       *MockTaskRepository
+	dependencyRepo *MockTaskDependencyRepository
+	events         *MockTaskEventRepository
+}
+
+func newDependencyController() (*controllers.TaskDependencyController, *dependencyMocks) {
+	m := &dependencyMocks{
+		taskRepo:       new(MockTaskRepository),
+		dependencyRepo: new(MockTaskDependencyRepository),
+		events:         newTaskEventRepoStub(),
+	}
+	return controllers.NewTaskDependencyController(m.taskRepo, m.dependencyRepo, m.events, newChatMembershipStub()), m
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// Тесты для TaskDependencyController.GetTree
+
+func TestTaskDependencyController_GetTree_RollsUpProgress(t *testing.T) {
+	controller, m := newDependencyController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+
+	// 1 -> {2 (закрыта), 3 -> {4 (закрыта), 5}}
+	m.taskRepo.On("GetSubtree", 1).Return([]dto.TaskTreeNode{
+		{ID: 1, Title: "root"},
+		{ID: 2, ParentTaskID: intPtr(1), Closed: true},
+		{ID: 3, ParentTaskID: intPtr(1)},
+		{ID: 4, ParentTaskID: intPtr(3), Closed: true},
+		{ID: 5, ParentTaskID: intPtr(3)},
+	}, nil)
+	m.taskRepo.On("GetVisibleIDs", []int{1, 2, 3, 4, 5}, mock.Anything).Return([]int{1, 2, 3, 4, 5}, nil)
+
+	tree, err := controller.GetTree(1, &dto.Actor{UserID: task.CreatorID})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, tree.ID)
+	require.Len(t, tree.Subtasks, 2)
+	assert.Equal(t, 100, tree.Subtasks[0].Progress)
+	assert.Equal(t, 50, tree.Subtasks[1].Progress)
+	assert.Len(t, tree.Subtasks[1].Subtasks, 2)
+	assert.Equal(t, 75, tree.Progress)
+}
+
+func TestTaskDependencyController_GetTree_ClosedParentIsDone(t *testing.T) {
+	controller, m := newDependencyController()
+
+	task := createTestTask()
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.taskRepo.On("GetSubtree", 1).Return([]dto.TaskTreeNode{
+		{ID: 1, Closed: true},
+		{ID: 2, ParentTaskID: intPtr(1)},
+	}, nil)
+	m.taskRepo.On("GetVisibleIDs", []int{1, 2}, mock.Anything).Return([]int{1, 2}, nil)
+
+	tree, err := controller.GetTree(1, &dto.Actor{UserID: task.CreatorID})
+
+	require.NoError(t, err)
+	assert.Equal(t, 100, tree.Progress)
+	assert.Equal(t, 0, tree.Subtasks[0].Progress)
+}
+
+func TestTaskDependencyController_GetTree_TaskNotFound(t *testing.T) {
+	controller, m := newDependencyController()
+
+	m.taskRepo.On("GetByID", 1).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := controller.GetTree(1, &dto.Actor{UserID: uuid.New()})
+
+	var taskErr *custom_errors.TaskNotFoundError
+	assert.True(t, errors.As(err, &taskErr))
+}
+
+func TestTaskDependencyController_GetTree_RedactsHiddenSubtasks(t *testing.T) {
+	controller, m := newDependencyController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: task.CreatorID}
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.taskRepo.On("GetSubtree", 1).Return([]dto.TaskTreeNode{
+		{ID: 1, Title: "root"},
+		{ID: 2, ParentTaskID: intPtr(1), Title: "Секретная", Status: "В работе", ExecutorID: uuid.New(), Closed: true},
+		{ID: 3, ParentTaskID: intPtr(1), Title: "Открытая"},
+	}, nil)
+	m.taskRepo.On("GetVisibleIDs", []int{1, 2, 3}, mock.MatchedBy(func(visibility *dto.TaskVisibility) bool {
+		return visibility != nil && visibility.UserID == actor.UserID
+	})).Return([]int{1, 3}, nil)
+
+	tree, err := controller.GetTree(1, actor)
+
+	require.NoError(t, err)
+	require.Len(t, tree.Subtasks, 2)
+	hidden := tree.Subtasks[0]
+	assert.True(t, hidden.Hidden)
+	assert.Empty(t, hidden.Title)
+	assert.Empty(t, hidden.Status)
+	assert.Equal(t, uuid.Nil, hidden.ExecutorID)
+	// Скрытая подзадача всё равно учитывается в прогрессе
+	assert.Equal(t, 100, hidden.Progress)
+	assert.Equal(t, 50, tree.Progress)
+	assert.False(t, tree.Subtasks[1].Hidden)
+	assert.Equal(t, "Открытая", tree.Subtasks[1].Title)
+}
+
+func TestTaskDependencyController_GetTree_AdminSeesAllSubtasks(t *testing.T) {
+	controller, m := newDependencyController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.taskRepo.On("GetSubtree", 1).Return([]dto.TaskTreeNode{
+		{ID: 1, Title: "root"},
+		{ID: 2, ParentTaskID: intPtr(1), Title: "Чужая"},
+	}, nil)
+
+	tree, err := controller.GetTree(1, &dto.Actor{UserID: uuid.New(), Permissions: []string{dto.PermissionManageAllTasks}})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Чужая", tree.Subtasks[0].Title)
+	m.taskRepo.AssertNotCalled(t, "GetVisibleIDs", mock.Anything, mock.Anything)
+}
+
+// Тесты для TaskDependencyController.AddDependency
+
+func TestTaskDependencyController_AddDependency_Success(t *testing.T) {
+	controller, m := newDependencyController()
+	task := createTestTask()
+	blocker := createTestTask()
+	blocker.ID = 2
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.taskRepo.On("GetByID", 2).Return(blocker, nil)
+	m.dependencyRepo.On("HasPath", 1, 2).Return(false, nil)
+	m.dependencyRepo.On("Create", &models.TaskDependency{BlockerTaskID: 2, BlockedTaskID: 1}).Return(nil)
+
+	err := controller.AddDependency(1, &dto.Actor{UserID: task.ExecutorID}, &dto.AddTaskDependencyDTO{BlockerTaskID: 2})
+
+	require.NoError(t, err)
+	m.dependencyRepo.AssertExpectations(t)
+	m.events.AssertCalled(t, "Create", mock.MatchedBy(func(events []models.TaskEvent) bool {
+		return len(events) == 1 && events[0].TaskID == 1 &&
+			events[0].EventType == models.TaskEventBlockerAdded && *events[0].NewValue == "2"
+	}))
+}
+
+func TestTaskDependencyController_AddDependency_RejectsCycle(t *testing.T) {
+	controller, m := newDependencyController()
+	task := createTestTask()
+	blocker := createTestTask()
+	blocker.ID = 2
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.taskRepo.On("GetByID", 2).Return(blocker, nil)
+	m.dependencyRepo.On("HasPath", 1, 2).Return(true, nil)
+
+	err := controller.AddDependency(1, &dto.Actor{UserID: task.CreatorID}, &dto.AddTaskDependencyDTO{BlockerTaskID: 2})
+
+	var cycleErr *custom_errors.TaskDependencyCycleError
+	require.True(t, errors.As(err, &cycleErr))
+	m.dependencyRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskDependencyController_AddDependency_SelfDependency(t *testing.T) {
+	controller, m := newDependencyController()
+
+	err := controller.AddDependency(1, &dto.Actor{UserID: uuid.New()}, &dto.AddTaskDependencyDTO{BlockerTaskID: 1})
+
+	var cycleErr *custom_errors.TaskDependencyCycleError
+	require.True(t, errors.As(err, &cycleErr))
+	m.taskRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+}
+
+func TestTaskDependencyController_AddDependency_AccessDenied(t *testing.T) {
+	controller, m := newDependencyController()
+
+	m.taskRepo.On("GetByID", 1).Return(createTestTask(), nil)
+
+	err := controller.AddDependency(1, &dto.Actor{UserID: uuid.New()}, &dto.AddTaskDependencyDTO{BlockerTaskID: 2})
+
+	var accessErr *custom_errors.TaskAccessDeniedError
+	require.True(t, errors.As(err, &accessErr))
+	m.dependencyRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskDependencyController_AddDependency_BlockerNotFound(t *testing.T) {
+	controller, m := newDependencyController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.taskRepo.On("GetByID", 2).Return(nil, gorm.ErrRecordNotFound)
+
+	err := controller.AddDependency(1, &dto.Actor{UserID: task.CreatorID}, &dto.AddTaskDependencyDTO{BlockerTaskID: 2})
+
+	var taskErr *custom_errors.TaskNotFoundError
+	require.True(t, errors.As(err, &taskErr))
+	assert.Equal(t, 2, taskErr.TaskID)
+}
+
+// Тесты для TaskDependencyController.RemoveDependency
+
+func TestTaskDependencyController_RemoveDependency_NotFound(t *testing.T) {
+	controller, m := newDependencyController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.dependencyRepo.On("Delete", 2, 1).Return(custom_errors.NewTaskDependencyNotFoundError(2, 1))
+
+	err := controller.RemoveDependency(1, 2, &dto.Actor{UserID: task.CreatorID})
+
+	var dependencyErr *custom_errors.TaskDependencyNotFoundError
+	require.True(t, errors.As(err, &dependencyErr))
+	m.events.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// Тесты для TaskDependencyController.GetDependencyGraph
+
+func TestTaskDependencyController_GetDependencyGraph_EmptyGraph(t *testing.T) {
+	controller, m := newDependencyController()
+
+	task := createTestTask()
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.dependencyRepo.On("GetGraph", 1).Return([]dto.TaskGraphNode{{ID: 1, Title: "Test Task"}}, nil, nil)
+	m.taskRepo.On("GetVisibleIDs", []int{1}, mock.Anything).Return([]int{1}, nil)
+
+	graph, err := controller.GetDependencyGraph(1, &dto.Actor{UserID: task.CreatorID})
+
+	require.NoError(t, err)
+	assert.Len(t, graph.Nodes, 1)
+	assert.NotNil(t, graph.Edges)
+	assert.Empty(t, graph.Edges)
+}
+
+func TestTaskDependencyController_GetDependencyGraph_RedactsHiddenNodes(t *testing.T) {
+	controller, m := newDependencyController()
+
+	task := createTestTask()
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.dependencyRepo.On("GetGraph", 1).Return(
+		[]dto.TaskGraphNode{{ID: 1, Title: "Test Task"}, {ID: 7, Title: "Чужая", Status: "Новая"}},
+		[]dto.TaskDependencyEdge{{BlockerTaskID: 7, BlockedTaskID: 1}},
+		nil,
+	)
+	m.taskRepo.On("GetVisibleIDs", []int{1, 7}, mock.Anything).Return([]int{1}, nil)
+
+	graph, err := controller.GetDependencyGraph(1, &dto.Actor{UserID: task.CreatorID})
+
+	require.NoError(t, err)
+	require.Len(t, graph.Nodes, 2)
+	assert.Equal(t, "Test Task", graph.Nodes[0].Title)
+	assert.Equal(t, dto.TaskGraphNode{ID: 7, Hidden: true}, graph.Nodes[1])
+	assert.Len(t, graph.Edges, 1)
+}
+
+// Тесты для родительской задачи в TaskController.Update
+
+func TestTaskController_Update_SetParent(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	parent := createTestTask()
+	parent.ID = 5
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("GetByID", 5).Return(parent, nil)
+	m.taskRepo.On("GetSubtree", task.ID).Return([]dto.TaskTreeNode{{ID: task.ID}}, nil)
+	m.taskRepo.On("Update", mock.MatchedBy(func(updated *models.Task) bool {
+		return updated.ParentTaskID != nil && *updated.ParentTaskID == 5
+	})).Return(nil)
+
+	_, err := controller.Update(task.ID, &dto.Actor{UserID: task.CreatorID}, &dto.UpdateTaskDTO{ParentTaskID: intPtr(5)})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertExpectations(t)
+	m.events.AssertCalled(t, "Create", mock.MatchedBy(func(events []models.TaskEvent) bool {
+		return len(events) == 1 && *events[0].Field == "parent_task_id" && *events[0].NewValue == "5"
+	}))
+}
+
+func TestTaskController_Update_ParentFromOwnSubtree(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	subtask := createTestTask()
+	subtask.ID = 3
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("GetByID", 3).Return(subtask, nil)
+	m.taskRepo.On("GetSubtree", task.ID).Return([]dto.TaskTreeNode{{ID: task.ID}, {ID: 3, ParentTaskID: intPtr(task.ID)}}, nil)
+
+	_, err := controller.Update(task.ID, &dto.Actor{UserID: task.CreatorID}, &dto.UpdateTaskDTO{ParentTaskID: intPtr(3)})
+
+	var cycleErr *custom_errors.TaskHierarchyCycleError
+	require.True(t, errors.As(err, &cycleErr))
+	m.taskRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTaskController_Update_ParentNotFound(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("GetByID", 42).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := controller.Update(task.ID, &dto.Actor{UserID: task.CreatorID}, &dto.UpdateTaskDTO{ParentTaskID: intPtr(42)})
+
+	var parentErr *custom_errors.ParentTaskNotFoundError
+	require.True(t, errors.As(err, &parentErr))
+}
+
+func TestTaskController_Update_ClearParent(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	task.ParentTaskID = intPtr(5)
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("Update", mock.MatchedBy(func(updated *models.Task) bool {
+		return updated.ParentTaskID == nil
+	})).Return(nil)
+
+	_, err := controller.Update(task.ID, &dto.Actor{UserID: task.CreatorID}, &dto.UpdateTaskDTO{ClearParentTaskID: true})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertNotCalled(t, "GetSubtree", mock.Anything)
+}
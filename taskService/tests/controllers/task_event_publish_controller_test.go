@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+func TestTaskController_UpdateStatus_PublishesRecordedEvent(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	events := controller.TaskEventRepo.(*MockTaskEventRepository)
+	events.ExpectedCalls = nil
+	// Репозиторий назначает ID, по которому потребители отсеивают повторные доставки
+	events.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		recorded := args.Get(0).([]models.TaskEvent)
+		for i := range recorded {
+			recorded[i].ID = int64(40 + i)
+		}
+	}).Return(nil)
+	publisher := new(MockTaskEventPublisher)
+	controller.EventPublisher = publisher
+
+	task := workflowTask(1)
+	task.ChatID = uuid.New()
+	task.Status = createTestTaskStatusWithID(1, "created")
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 2).Return(createTestTaskStatusWithID(2, "in_progress"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+	m.taskRepo.On("UpdateStatus", 1, 2).Return(nil)
+	publisher.On("Publish", task, mock.MatchedBy(func(published []models.TaskEvent) bool {
+		return len(published) == 1 && published[0].ID == 40 &&
+			published[0].EventType == models.TaskEventStatusChanged && *published[0].NewValue == "in_progress"
+	})).Return()
+
+	err := controller.UpdateStatus(1, 2, &dto.Actor{UserID: task.ExecutorID})
+
+	require.NoError(t, err)
+	publisher.AssertExpectations(t)
+}
+
+func TestTaskController_Update_PublishesWithUpdatedChat(t *testing.T) {
+	controller, m := newLifecycleController()
+	publisher := new(MockTaskEventPublisher)
+	controller.EventPublisher = publisher
+	task := createTestTask()
+	task.ChatID = uuid.New()
+	newChatID := testChatID
+	newExecutorID := uuid.New()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.userClient.On("GetUserByID", &newExecutorID).Return(createTestUserResponseWithEmail(""), nil)
+	m.chatClient.On("GetChatByID", newChatID.String()).Return(createTestChat(), nil)
+	m.taskRepo.On("Update", mock.AnythingOfType("*models.Task")).Return(nil)
+	publisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	_, err := controller.Update(task.ID, &dto.Actor{UserID: task.CreatorID}, &dto.UpdateTaskDTO{
+		ExecutorID: &newExecutorID,
+		ChatID:     &newChatID,
+	})
+	require.NoError(t, err)
+
+	published := publisher.Calls[0].Arguments
+	assert.Equal(t, newChatID, published.Get(0).(*models.Task).ChatID)
+	assert.Len(t, eventsOf(published.Get(1).([]models.TaskEvent), models.TaskEventReassigned), 1)
+}
@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	cc "common/contracts/chat-contracts"
+	commonHttpClients "common/http_clients"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type fromMessageMocks struct {
+	taskRepo     *MockTaskRepository
+	taskFileRepo *MockTaskFileRepository
+	userClient   *MockUserClient
+	chatClient   *MockChatClient
+	fileClient   *MockFileClient
+}
+
+func newFromMessageController() (*controllers.TaskController, *fromMessageMocks) {
+	m := &fromMessageMocks{
+		taskRepo:     new(MockTaskRepository),
+		taskFileRepo: new(MockTaskFileRepository),
+		userClient:   new(MockUserClient),
+		chatClient:   new(MockChatClient),
+		fileClient:   new(MockFileClient),
+	}
+	statusRepo := new(MockTaskStatusRepository)
+	statusRepo.On("GetByName", "created").Return(createTestTaskStatus(), nil).Maybe()
+	m.userClient.On("GetUserByID", mock.Anything).Return(createTestUserResponse(), nil).Maybe()
+	m.chatClient.On("GetChatByID", mock.Anything).Return(createTestChat(), nil).Maybe()
+	notificationService := new(MockNotificationService)
+	notificationService.On("SendTaskCreatedNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Maybe()
+
+	controller := controllers.NewTaskControllerWithClients(
+		m.taskRepo,
+		statusRepo,
+		m.taskFileRepo,
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
+		notificationService,
+		m.userClient,
+		m.chatClient,
+		m.fileClient,
+		newChatMembershipStub(),
+	)
+	return controller, m
+}
+
+func testChatMessage(content string, fileIDs ...int) *cc.Message {
+	senderID := uuid.New()
+	return &cc.Message{
+		ID:        uuid.New(),
+		ChatID:    testChatID,
+		SenderID:  &senderID,
+		Content:   content,
+		FileIDs:   fileIDs,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Тесты для TaskController.CreateFromMessage
+
+func TestTaskController_CreateFromMessage_PrefillsFromMessage(t *testing.T) {
+	controller, m := newFromMessageController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	message := testChatMessage("## Починить экспорт\nОтчёт падает на пустых данных", 3, 8)
+
+	m.chatClient.On("GetMessageByID", message.ID, actor.UserID).Return(message, nil)
+	m.fileClient.On("GetFileByID", mock.Anything).Return(createTestFile(), nil)
+	m.taskRepo.On("Create", mock.MatchedBy(func(task *models.Task) bool {
+		return task.Title == "Починить экспорт" &&
+			task.Description == message.Content &&
+			task.ChatID == message.ChatID &&
+			task.CreatorID == actor.UserID
+	})).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Task).ID = 12
+	})
+	m.taskFileRepo.On("BulkCreate", mock.MatchedBy(func(files []models.TaskFile) bool {
+		return len(files) == 2 && files[0].FileID == 3 && files[1].FileID == 8 && files[0].TaskID == 12
+	})).Return(nil)
+	m.chatClient.On("CreateSystemMessage", message.ChatID, mock.MatchedBy(func(req *cc.CreateSystemMessageRequest) bool {
+		return req.TaskID != nil && *req.TaskID == 12 && req.Content == "Создана задача #12: Починить экспорт"
+	})).Return(nil)
+
+	task, err := controller.CreateFromMessage(actor, &dto.CreateTaskFromMessageDTO{MessageID: message.ID, ExecutorID: uuid.New()})
+
+	require.NoError(t, err)
+	assert.Equal(t, 12, task.ID)
+	m.taskRepo.AssertExpectations(t)
+	m.taskFileRepo.AssertExpectations(t)
+	m.chatClient.AssertExpectations(t)
+}
+
+func TestTaskController_CreateFromMessage_TitleOverride(t *testing.T) {
+	controller, m := newFromMessageController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	message := testChatMessage("Длинное обсуждение")
+	title := "  Короткое название "
+
+	m.chatClient.On("GetMessageByID", message.ID, actor.UserID).Return(message, nil)
+	m.taskRepo.On("Create", mock.MatchedBy(func(task *models.Task) bool {
+		return task.Title == "Короткое название"
+	})).Return(nil)
+	m.chatClient.On("CreateSystemMessage", message.ChatID, mock.Anything).Return(nil)
+
+	_, err := controller.CreateFromMessage(actor, &dto.CreateTaskFromMessageDTO{MessageID: message.ID, ExecutorID: uuid.New(), Title: &title})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertExpectations(t)
+}
+
+func TestTaskController_CreateFromMessage_SystemMessageFailureKeepsTask(t *testing.T) {
+	controller, m := newFromMessageController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	message := testChatMessage("Задача")
+
+	m.chatClient.On("GetMessageByID", message.ID, actor.UserID).Return(message, nil)
+	m.taskRepo.On("Create", mock.Anything).Return(nil)
+	m.chatClient.On("CreateSystemMessage", message.ChatID, mock.Anything).Return(errors.New("chat service unavailable"))
+
+	task, err := controller.CreateFromMessage(actor, &dto.CreateTaskFromMessageDTO{MessageID: message.ID, ExecutorID: uuid.New()})
+
+	require.NoError(t, err)
+	assert.NotNil(t, task)
+}
+
+func TestTaskController_CreateFromMessage_MessageErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		clientErr error
+		check     func(t *testing.T, err error)
+	}{
+		{
+			name:      "no view_messages",
+			clientErr: commonHttpClients.ErrMessageAccessDenied,
+			check: func(t *testing.T, err error) {
+				var accessErr *custom_errors.ChatMessageAccessDeniedError
+				assert.True(t, errors.As(err, &accessErr))
+			},
+		},
+		{
+			name:      "not found",
+			clientErr: commonHttpClients.ErrMessageNotFound,
+			check: func(t *testing.T, err error) {
+				var notFoundErr *custom_errors.ChatMessageNotFoundError
+				assert.True(t, errors.As(err, &notFoundErr))
+			},
+		},
+		{
+			name:      "chat service down",
+			clientErr: errors.New("connection refused"),
+			check: func(t *testing.T, err error) {
+				var httpErr *custom_errors.GetChatMessageHTTPError
+				assert.True(t, errors.As(err, &httpErr))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, m := newFromMessageController()
+			m.chatClient.On("GetMessageByID", mock.Anything, mock.Anything).Return(nil, tt.clientErr)
+
+			_, err := controller.CreateFromMessage(&dto.Actor{UserID: uuid.New()}, &dto.CreateTaskFromMessageDTO{MessageID: uuid.New(), ExecutorID: uuid.New()})
+
+			tt.check(t, err)
+			m.taskRepo.AssertNotCalled(t, "Create", mock.Anything)
+			m.chatClient.AssertNotCalled(t, "CreateSystemMessage", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTaskController_CreateFromMessage_EmptyContentFallbackTitle(t *testing.T) {
+	controller, m := newFromMessageController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	message := testChatMessage("", 5)
+
+	m.chatClient.On("GetMessageByID", message.ID, actor.UserID).Return(message, nil)
+	m.fileClient.On("GetFileByID", 5).Return(createTestFile(), nil)
+	m.taskRepo.On("Create", mock.MatchedBy(func(task *models.Task) bool {
+		return task.Title == "Задача из сообщения чата"
+	})).Return(nil)
+	m.taskFileRepo.On("BulkCreate", mock.Anything).Return(nil)
+	m.chatClient.On("CreateSystemMessage", message.ChatID, mock.Anything).Return(nil)
+
+	_, err := controller.CreateFromMessage(actor, &dto.CreateTaskFromMessageDTO{MessageID: message.ID, ExecutorID: uuid.New()})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertExpectations(t)
+}
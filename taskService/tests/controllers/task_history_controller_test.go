@@ -0,0 +1,192 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+// eventsOf отбирает события указанного типа из переданного в репозиторий пакета
+func eventsOf(events []models.TaskEvent, eventType string) []models.TaskEvent {
+	var result []models.TaskEvent
+	for _, event := range events {
+		if event.EventType == eventType {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+func recordedEvents(m *MockTaskEventRepository) []models.TaskEvent {
+	var result []models.TaskEvent
+	for _, call := range m.Calls {
+		if call.Method == "Create" {
+			result = append(result, call.Arguments.Get(0).([]models.TaskEvent)...)
+		}
+	}
+	return result
+}
+
+func TestTaskController_Update_RecordsHistory(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	oldExecutorID := task.ExecutorID
+	actor := &dto.Actor{UserID: task.CreatorID}
+	newExecutorID := uuid.New()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.userClient.On("GetUserByID", &newExecutorID).Return(createTestUserResponseWithEmail(""), nil)
+	m.fileClient.On("GetFileByID", 5).Return(createTestFile(), nil)
+	m.taskRepo.On("Update", mock.AnythingOfType("*models.Task")).Return(nil)
+	m.taskFileRepo.On("BulkCreate", mock.Anything).Return(nil)
+
+	_, err := controller.Update(task.ID, actor, &dto.UpdateTaskDTO{
+		Title:       stringPtr("Renamed"),
+		Description: stringPtr(task.Description),
+		ExecutorID:  &newExecutorID,
+		AddFileIDs:  []int{5},
+	})
+	require.NoError(t, err)
+
+	events := recordedEvents(m.events)
+	edited := eventsOf(events, models.TaskEventEdited)
+	require.Len(t, edited, 1, "unchanged description must not be recorded")
+	assert.Equal(t, "title", *edited[0].Field)
+	assert.Equal(t, "Test Task", *edited[0].OldValue)
+	assert.Equal(t, "Renamed", *edited[0].NewValue)
+	assert.Equal(t, actor.UserID, edited[0].ActorID)
+
+	reassigned := eventsOf(events, models.TaskEventReassigned)
+	require.Len(t, reassigned, 1)
+	assert.Equal(t, oldExecutorID.String(), *reassigned[0].OldValue)
+	assert.Equal(t, newExecutorID.String(), *reassigned[0].NewValue)
+
+	attachments := eventsOf(events, models.TaskEventAttachmentAdded)
+	require.Len(t, attachments, 1)
+	assert.Equal(t, "5", *attachments[0].NewValue)
+}
+
+func TestTaskController_Update_UnassignRecordsNullExecutor(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: task.CreatorID}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("Update", mock.AnythingOfType("*models.Task")).Return(nil)
+
+	_, err := controller.Update(task.ID, actor, &dto.UpdateTaskDTO{ExecutorID: &uuid.Nil})
+	require.NoError(t, err)
+
+	reassigned := eventsOf(recordedEvents(m.events), models.TaskEventReassigned)
+	require.Len(t, reassigned, 1)
+	assert.NotNil(t, reassigned[0].OldValue)
+	assert.Nil(t, reassigned[0].NewValue)
+}
+
+func TestTaskController_UpdateStatus_RecordsHistory(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	events := controller.TaskEventRepo.(*MockTaskEventRepository)
+	task := workflowTask(1)
+	task.Status = createTestTaskStatusWithID(1, "created")
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 2).Return(createTestTaskStatusWithID(2, "in_progress"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+	m.taskRepo.On("UpdateStatus", 1, 2).Return(nil)
+
+	err := controller.UpdateStatus(1, 2, &dto.Actor{UserID: task.ExecutorID})
+	require.NoError(t, err)
+
+	changed := eventsOf(recordedEvents(events), models.TaskEventStatusChanged)
+	require.Len(t, changed, 1)
+	assert.Equal(t, "created", *changed[0].OldValue)
+	assert.Equal(t, "in_progress", *changed[0].NewValue)
+	assert.Equal(t, task.ExecutorID, changed[0].ActorID)
+}
+
+func TestTaskController_UpdateStatus_FailedTransitionNotRecorded(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	events := controller.TaskEventRepo.(*MockTaskEventRepository)
+	task := workflowTask(1)
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 3).Return(createTestTaskStatusWithID(3, "done"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+
+	err := controller.UpdateStatus(1, 3, &dto.Actor{UserID: task.CreatorID})
+	require.Error(t, err)
+
+	events.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskController_GetHistory(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	history := &[]dto.TaskActivity{{ID: 1, TaskID: task.ID, EventType: models.TaskEventCreated}}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.events.On("GetByTaskID", task.ID, 20, 0).Return(history, nil)
+
+	result, err := controller.GetHistory(task.ID, &dto.Actor{UserID: task.CreatorID}, 20, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, history, result)
+}
+
+func TestTaskController_GetHistory_TaskNotFound(t *testing.T) {
+	controller, m := newLifecycleController()
+
+	m.taskRepo.On("GetByID", 404).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := controller.GetHistory(404, &dto.Actor{UserID: uuid.New()}, 20, 0)
+
+	var taskErr *custom_errors.TaskNotFoundError
+	assert.True(t, errors.As(err, &taskErr))
+	m.events.AssertNotCalled(t, "GetByTaskID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskController_GetUserActivity(t *testing.T) {
+	controller, m := newLifecycleController()
+	userID := uuid.NewString()
+	feed := &[]dto.TaskActivity{{ID: 2, EventType: models.TaskEventStatusChanged}}
+
+	m.events.On("GetUserFeed", userID, 10, 20, mock.Anything).Return(feed, nil)
+
+	result, err := controller.GetUserActivity(userID, &dto.Actor{UserID: uuid.New()}, 10, 20)
+
+	require.NoError(t, err)
+	assert.Equal(t, feed, result)
+}
+
+func TestTaskController_Create_RecordsHistory(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	events := controller.TaskEventRepo.(*MockTaskEventRepository)
+	userClient := controller.UserClient.(*MockUserClient)
+	fileClient := controller.FileClient.(*MockFileClient)
+	fileRepo := controller.TaskFileRepo.(*MockTaskFileRepository)
+	creatorID := uuid.New()
+
+	m.statusRepo.On("GetByName", "created").Return(createTestTaskStatus(), nil)
+	userClient.On("GetUserByID", &creatorID).Return(createTestUserResponse(), nil)
+	fileClient.On("GetFileByID", 3).Return(createTestFile(), nil)
+	m.taskRepo.On("Create", mock.AnythingOfType("*models.Task")).Return(nil)
+	fileRepo.On("BulkCreate", mock.Anything).Return(nil)
+
+	_, err := controller.Create(&dto.Actor{UserID: creatorID}, &dto.CreateTaskDTO{Title: "Task", FileIDs: []int{3}})
+	require.NoError(t, err)
+
+	recorded := recordedEvents(events)
+	created := eventsOf(recorded, models.TaskEventCreated)
+	require.Len(t, created, 1)
+	assert.Equal(t, creatorID, created[0].ActorID)
+	assert.Equal(t, "Task", *created[0].NewValue)
+	assert.Len(t, eventsOf(recorded, models.TaskEventAttachmentAdded), 1)
+}
@@ -0,0 +1,230 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type labelMocks struct {
+	labelRepo *MockLabelRepository
+	taskRepo  *MockTaskRepository
+	events    *MockTaskEventRepository
+}
+
+func newLabelController() (*controllers.TaskLabelController, *labelMocks) {
+	m := &labelMocks{
+		labelRepo: new(MockLabelRepository),
+		taskRepo:  new(MockTaskRepository),
+		events:    newTaskEventRepoStub(),
+	}
+	return controllers.NewTaskLabelController(m.labelRepo, m.taskRepo, m.events, newChatMembershipStub()), m
+}
+
+// Тесты для TaskLabelController.Create и Update
+
+func TestTaskLabelController_Create_Success(t *testing.T) {
+	controller, m := newLabelController()
+
+	m.labelRepo.On("GetByName", "bug").Return(nil, gorm.ErrRecordNotFound)
+	m.labelRepo.On("Create", &models.Label{Name: "bug", Color: "#ff0000"}).
+		Run(func(args mock.Arguments) { args.Get(0).(*models.Label).ID = 3 }).
+		Return(nil)
+	m.labelRepo.On("GetByID", 3).Return(&dto.LabelResponse{ID: 3, Name: "bug", Color: "#ff0000"}, nil)
+
+	label, err := controller.Create(&dto.SaveLabelDTO{Name: "bug", Color: "#ff0000"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, label.ID)
+	assert.Zero(t, label.UsageCount)
+}
+
+func TestTaskLabelController_Create_AlreadyExists(t *testing.T) {
+	controller, m := newLabelController()
+
+	m.labelRepo.On("GetByName", "bug").Return(&models.Label{ID: 1, Name: "bug"}, nil)
+
+	_, err := controller.Create(&dto.SaveLabelDTO{Name: "bug", Color: "#ff0000"})
+
+	assert.ErrorIs(t, err, custom_errors.ErrLabelAlreadyExists)
+	m.labelRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskLabelController_Update_KeepsOwnName(t *testing.T) {
+	controller, m := newLabelController()
+
+	m.labelRepo.On("GetByName", "bug").Return(&models.Label{ID: 1, Name: "bug"}, nil)
+	m.labelRepo.On("Update", &models.Label{ID: 1, Name: "bug", Color: "#00ff00"}).Return(nil)
+	m.labelRepo.On("GetByID", 1).Return(&dto.LabelResponse{ID: 1, Name: "bug", Color: "#00ff00", UsageCount: 4}, nil)
+
+	label, err := controller.Update(1, &dto.SaveLabelDTO{Name: "bug", Color: "#00ff00"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), label.UsageCount)
+}
+
+func TestTaskLabelController_Update_NotFound(t *testing.T) {
+	controller, m := newLabelController()
+
+	m.labelRepo.On("GetByName", "bug").Return(nil, gorm.ErrRecordNotFound)
+	m.labelRepo.On("Update", mock.Anything).Return(gorm.ErrRecordNotFound)
+
+	_, err := controller.Update(7, &dto.SaveLabelDTO{Name: "bug", Color: "#00ff00"})
+
+	var labelErr *custom_errors.LabelNotFoundError
+	require.True(t, errors.As(err, &labelErr))
+	assert.Equal(t, 7, labelErr.LabelID)
+}
+
+func TestTaskLabelController_DeleteByID_NotFound(t *testing.T) {
+	controller, m := newLabelController()
+
+	m.labelRepo.On("DeleteByID", 7).Return(gorm.ErrRecordNotFound)
+
+	err := controller.DeleteByID(7)
+
+	var labelErr *custom_errors.LabelNotFoundError
+	assert.True(t, errors.As(err, &labelErr))
+}
+
+// Тесты для TaskLabelController.AddToTask и RemoveFromTask
+
+func TestTaskLabelController_AddToTask_RecordsEvent(t *testing.T) {
+	controller, m := newLabelController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.labelRepo.On("GetByID", 3).Return(&dto.LabelResponse{ID: 3, Name: "bug"}, nil)
+	m.labelRepo.On("AddToTask", task.ID, 3).Return(true, nil)
+
+	err := controller.AddToTask(task.ID, 3, &dto.Actor{UserID: task.ExecutorID})
+
+	require.NoError(t, err)
+	m.events.AssertCalled(t, "Create", mock.MatchedBy(func(events []models.TaskEvent) bool {
+		return len(events) == 1 && events[0].EventType == models.TaskEventLabelAdded && *events[0].NewValue == "bug"
+	}))
+}
+
+func TestTaskLabelController_AddToTask_DetachesOccurrence(t *testing.T) {
+	controller, m := newLabelController()
+	task := createTestTask()
+	recurrenceID := 5
+	task.RecurrenceID = &recurrenceID
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("Detach", task.ID).Return(nil).Once()
+	m.labelRepo.On("GetByID", 3).Return(&dto.LabelResponse{ID: 3, Name: "bug"}, nil)
+	m.labelRepo.On("AddToTask", task.ID, 3).Return(true, nil)
+
+	err := controller.AddToTask(task.ID, 3, &dto.Actor{UserID: task.ExecutorID})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertExpectations(t)
+}
+
+func TestTaskLabelController_AddToTask_DetachedOccurrenceNotDetachedAgain(t *testing.T) {
+	controller, m := newLabelController()
+	task := createTestTask()
+	recurrenceID := 5
+	task.RecurrenceID = &recurrenceID
+	task.Detached = true
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.labelRepo.On("GetByID", 3).Return(&dto.LabelResponse{ID: 3, Name: "bug"}, nil)
+	m.labelRepo.On("AddToTask", task.ID, 3).Return(true, nil)
+
+	err := controller.AddToTask(task.ID, 3, &dto.Actor{UserID: task.ExecutorID})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertNotCalled(t, "Detach", mock.Anything)
+}
+
+func TestTaskLabelController_AddToTask_AlreadyAssigned(t *testing.T) {
+	controller, m := newLabelController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.labelRepo.On("GetByID", 3).Return(&dto.LabelResponse{ID: 3, Name: "bug"}, nil)
+	m.labelRepo.On("AddToTask", task.ID, 3).Return(false, nil)
+
+	err := controller.AddToTask(task.ID, 3, &dto.Actor{UserID: task.CreatorID})
+
+	require.NoError(t, err)
+	m.events.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskLabelController_AddToTask_AccessDenied(t *testing.T) {
+	controller, m := newLabelController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+
+	err := controller.AddToTask(task.ID, 3, &dto.Actor{UserID: uuid.New()})
+
+	var accessErr *custom_errors.TaskAccessDeniedError
+	require.True(t, errors.As(err, &accessErr))
+	m.labelRepo.AssertNotCalled(t, "AddToTask", mock.Anything, mock.Anything)
+}
+
+func TestTaskLabelController_AddToTask_ManageAllTasks(t *testing.T) {
+	controller, m := newLabelController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.labelRepo.On("GetByID", 3).Return(&dto.LabelResponse{ID: 3, Name: "bug"}, nil)
+	m.labelRepo.On("AddToTask", task.ID, 3).Return(true, nil)
+
+	err := controller.AddToTask(task.ID, 3, &dto.Actor{UserID: uuid.New(), Permissions: []string{dto.PermissionManageAllTasks}})
+
+	require.NoError(t, err)
+}
+
+func TestTaskLabelController_AddToTask_LabelNotFound(t *testing.T) {
+	controller, m := newLabelController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.labelRepo.On("GetByID", 3).Return(nil, gorm.ErrRecordNotFound)
+
+	err := controller.AddToTask(task.ID, 3, &dto.Actor{UserID: task.CreatorID})
+
+	var labelErr *custom_errors.LabelNotFoundError
+	require.True(t, errors.As(err, &labelErr))
+	m.labelRepo.AssertNotCalled(t, "AddToTask", mock.Anything, mock.Anything)
+}
+
+func TestTaskLabelController_RemoveFromTask_NotAssigned(t *testing.T) {
+	controller, m := newLabelController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.labelRepo.On("GetByID", 3).Return(&dto.LabelResponse{ID: 3, Name: "bug"}, nil)
+	m.labelRepo.On("RemoveFromTask", task.ID, 3).Return(custom_errors.NewTaskLabelNotFoundError(task.ID, 3))
+
+	err := controller.RemoveFromTask(task.ID, 3, &dto.Actor{UserID: task.CreatorID})
+
+	var taskLabelErr *custom_errors.TaskLabelNotFoundError
+	require.True(t, errors.As(err, &taskLabelErr))
+	m.events.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskLabelController_GetTaskLabels_TaskNotFound(t *testing.T) {
+	controller, m := newLabelController()
+
+	m.taskRepo.On("GetByID", 1).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := controller.GetTaskLabels(1, &dto.Actor{UserID: uuid.New()})
+
+	var taskErr *custom_errors.TaskNotFoundError
+	require.True(t, errors.As(err, &taskErr))
+	m.labelRepo.AssertNotCalled(t, "GetByTaskID", mock.Anything)
+}
@@ -0,0 +1,351 @@
+package controllers
+
+import (
+	cuc "common/contracts/user-contracts"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type memberMocks struct {
+	memberRepo   *MockTaskMemberRepository
+	taskRepo     *MockTaskRepository
+	events       *MockTaskEventRepository
+	notification *MockNotificationService
+	userClient   *MockUserClient
+}
+
+func newMemberController() (*controllers.TaskMemberController, *memberMocks) {
+	return newMemberControllerWithMemberships(newChatMembershipStub())
+}
+
+// newMemberControllerWithMemberships создает контроллер с заданными чатами пользователей
+func newMemberControllerWithMemberships(chatMemberships *MockChatMembershipService) (*controllers.TaskMemberController, *memberMocks) {
+	m := &memberMocks{
+		memberRepo:   new(MockTaskMemberRepository),
+		taskRepo:     new(MockTaskRepository),
+		events:       newTaskEventRepoStub(),
+		notification: new(MockNotificationService),
+		userClient:   new(MockUserClient),
+	}
+	controller := controllers.NewTaskMemberControllerWithClients(
+		m.memberRepo,
+		m.taskRepo,
+		m.events,
+		m.notification,
+		m.userClient,
+		chatMemberships,
+	)
+	return controller, m
+}
+
+// Тесты для TaskMemberController.AddAssignee и RemoveAssignee
+
+func TestTaskMemberController_AddAssignee_RecordsEventAndNotifies(t *testing.T) {
+	controller, m := newMemberController()
+	task := createTestTask()
+	assigneeID := uuid.New()
+	actor := &dto.Actor{UserID: task.CreatorID}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.userClient.On("GetUserByID", &assigneeID).Return(&cuc.Response{User: testUser(assigneeID, "bob")}, nil)
+	m.userClient.On("GetUserByID", &actor.UserID).Return(&cuc.Response{User: testUser(actor.UserID, "alice")}, nil)
+	m.memberRepo.On("AddAssignee", task.ID, assigneeID).Return(true, nil)
+	m.notification.On("SendTaskCreatedNotification", task.ID, task.Title, "alice", assigneeID, "bob@example.com").Return(nil)
+
+	err := controller.AddAssignee(task.ID, assigneeID, actor)
+
+	require.NoError(t, err)
+	added := eventsOf(recordedEvents(m.events), models.TaskEventAssigneeAdded)
+	require.Len(t, added, 1)
+	assert.Equal(t, assigneeID.String(), *added[0].NewValue)
+	m.notification.AssertExpectations(t)
+}
+
+func TestTaskMemberController_AddAssignee_AlreadyAssigned(t *testing.T) {
+	controller, m := newMemberController()
+	task := createTestTask()
+	assigneeID := uuid.New()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.userClient.On("GetUserByID", &assigneeID).Return(&cuc.Response{User: testUser(assigneeID, "bob")}, nil)
+	m.memberRepo.On("AddAssignee", task.ID, assigneeID).Return(false, nil)
+
+	err := controller.AddAssignee(task.ID, assigneeID, &dto.Actor{UserID: task.CreatorID})
+
+	require.NoError(t, err)
+	m.events.AssertNotCalled(t, "Create", mock.Anything)
+	m.notification.AssertNotCalled(t, "SendTaskCreatedNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskMemberController_AddAssignee_ExecutorIsNoop(t *testing.T) {
+	controller, m := newMemberController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+
+	err := controller.AddAssignee(task.ID, task.ExecutorID, &dto.Actor{UserID: task.CreatorID})
+
+	require.NoError(t, err)
+	m.memberRepo.AssertNotCalled(t, "AddAssignee", mock.Anything, mock.Anything)
+}
+
+func TestTaskMemberController_AddAssignee_AccessDenied(t *testing.T) {
+	controller, m := newMemberController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+
+	err := controller.AddAssignee(task.ID, uuid.New(), &dto.Actor{UserID: uuid.New()})
+
+	var accessErr *custom_errors.TaskAccessDeniedError
+	require.True(t, errors.As(err, &accessErr))
+	m.memberRepo.AssertNotCalled(t, "AddAssignee", mock.Anything, mock.Anything)
+}
+
+func TestTaskMemberController_AddAssignee_UserNotFound(t *testing.T) {
+	controller, m := newMemberController()
+	task := createTestTask()
+	assigneeID := uuid.New()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.userClient.On("GetUserByID", &assigneeID).Return(nil, errors.New("not found"))
+
+	err := controller.AddAssignee(task.ID, assigneeID, &dto.Actor{UserID: task.CreatorID})
+
+	var userErr *custom_errors.GetUserHTTPError
+	require.True(t, errors.As(err, &userErr))
+	m.memberRepo.AssertNotCalled(t, "AddAssignee", mock.Anything, mock.Anything)
+}
+
+func TestTaskMemberController_RemoveAssignee_AllowedForAssignee(t *testing.T) {
+	controller, m := newMemberController()
+	task := createTestTask()
+	assigneeID := uuid.New()
+	task.Assignees = []models.TaskAssignee{{TaskID: task.ID, UserID: assigneeID}}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.memberRepo.On("RemoveAssignee", task.ID, assigneeID).Return(nil)
+
+	err := controller.RemoveAssignee(task.ID, assigneeID, &dto.Actor{UserID: assigneeID})
+
+	require.NoError(t, err)
+	removed := eventsOf(recordedEvents(m.events), models.TaskEventAssigneeRemoved)
+	require.Len(t, removed, 1)
+	assert.Equal(t, assigneeID.String(), *removed[0].OldValue)
+}
+
+func TestTaskMemberController_RemoveAssignee_NotAssigned(t *testing.T) {
+	controller, m := newMemberController()
+	task := createTestTask()
+	userID := uuid.New()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.memberRepo.On("RemoveAssignee", task.ID, userID).Return(custom_errors.NewTaskAssigneeNotFoundError(task.ID, userID.String()))
+
+	err := controller.RemoveAssignee(task.ID, userID, &dto.Actor{UserID: task.CreatorID})
+
+	var assigneeErr *custom_errors.TaskAssigneeNotFoundError
+	require.True(t, errors.As(err, &assigneeErr))
+	m.events.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// Тесты для TaskMemberController.AddWatcher и RemoveWatcher
+
+func TestTaskMemberController_AddWatcher_Self(t *testing.T) {
+	controller, m := newMemberController()
+	task := createTestTask()
+	userID := uuid.New()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.memberRepo.On("AddWatcher", task.ID, userID).Return(true, nil)
+
+	err := controller.AddWatcher(task.ID, userID, &dto.Actor{UserID: userID})
+
+	require.NoError(t, err)
+	m.userClient.AssertNotCalled(t, "GetUserByID", mock.Anything)
+	m.events.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskMemberController_AddWatcher_DetachesOccurrence(t *testing.T) {
+	controller, m := newMemberController()
+	task := createTestTask()
+	recurrenceID := 5
+	task.RecurrenceID = &recurrenceID
+	userID := uuid.New()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("Detach", task.ID).Return(nil).Once()
+	m.memberRepo.On("AddWatcher", task.ID, userID).Return(true, nil)
+
+	err := controller.AddWatcher(task.ID, userID, &dto.Actor{UserID: userID})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertExpectations(t)
+}
+
+func TestTaskMemberController_AddWatcher_OtherUserRequiresModifyAccess(t *testing.T) {
+	controller, m := newMemberController()
+	task := createTestTask()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+
+	err := controller.AddWatcher(task.ID, uuid.New(), &dto.Actor{UserID: uuid.New()})
+
+	var accessErr *custom_errors.TaskAccessDeniedError
+	require.True(t, errors.As(err, &accessErr))
+	m.memberRepo.AssertNotCalled(t, "AddWatcher", mock.Anything, mock.Anything)
+}
+
+func TestTaskMemberController_AddWatcher_ByExecutor(t *testing.T) {
+	controller, m := newMemberController()
+	task := createTestTask()
+	watcherID := uuid.New()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.userClient.On("GetUserByID", &watcherID).Return(&cuc.Response{User: testUser(watcherID, "carol")}, nil)
+	m.memberRepo.On("AddWatcher", task.ID, watcherID).Return(true, nil)
+
+	err := controller.AddWatcher(task.ID, watcherID, &dto.Actor{UserID: task.ExecutorID})
+
+	require.NoError(t, err)
+	m.memberRepo.AssertExpectations(t)
+}
+
+func TestTaskMemberController_AddWatcher_RejectsUserWhoCannotSeeTask(t *testing.T) {
+	chatMemberships := new(MockChatMembershipService)
+	controller, m := newMemberControllerWithMemberships(chatMemberships)
+	task := createTestTask()
+	outsiderID := uuid.New()
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.userClient.On("GetUserByID", &outsiderID).Return(&cuc.Response{User: testUser(outsiderID, "mallory")}, nil)
+	chatMemberships.On("GetUserChatIDs", outsiderID).Return([]uuid.UUID{uuid.New()}, nil)
+
+	err := controller.AddWatcher(task.ID, outsiderID, &dto.Actor{UserID: task.CreatorID})
+
+	var accessErr *custom_errors.TaskAccessDeniedError
+	require.True(t, errors.As(err, &accessErr))
+	assert.Equal(t, outsiderID.String(), accessErr.UserID)
+	m.memberRepo.AssertNotCalled(t, "AddWatcher", mock.Anything, mock.Anything)
+}
+
+func TestTaskMemberController_AddWatcher_UserWithManageAllTasks(t *testing.T) {
+	chatMemberships := new(MockChatMembershipService)
+	controller, m := newMemberControllerWithMemberships(chatMemberships)
+	task := createTestTask()
+	adminID := uuid.New()
+	admin := testUser(adminID, "admin")
+	admin.Role.Permissions = []cuc.Permission{{Name: dto.PermissionManageAllTasks}}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.userClient.On("GetUserByID", &adminID).Return(&cuc.Response{User: admin}, nil)
+	m.memberRepo.On("AddWatcher", task.ID, adminID).Return(true, nil)
+
+	err := controller.AddWatcher(task.ID, adminID, &dto.Actor{UserID: task.CreatorID})
+
+	require.NoError(t, err)
+	m.memberRepo.AssertExpectations(t)
+	chatMemberships.AssertNotCalled(t, "GetUserChatIDs", mock.Anything)
+}
+
+func TestTaskMemberController_RemoveWatcher_TaskNotFound(t *testing.T) {
+	controller, m := newMemberController()
+	userID := uuid.New()
+
+	m.taskRepo.On("GetByID", 42).Return(nil, gorm.ErrRecordNotFound)
+
+	err := controller.RemoveWatcher(42, userID, &dto.Actor{UserID: userID})
+
+	var taskErr *custom_errors.TaskNotFoundError
+	require.True(t, errors.As(err, &taskErr))
+}
+
+// Тесты для уведомлений о смене статуса
+
+func TestTaskController_UpdateStatus_NotifiesExecutorsAndWatchers(t *testing.T) {
+	taskRepo := new(MockTaskRepository)
+	statusRepo := new(MockTaskStatusRepository)
+	workflowRepo := new(MockTaskWorkflowRepository)
+	notification := new(MockNotificationService)
+	userClient := new(MockUserClient)
+	controller := controllers.NewTaskControllerWithClients(
+		taskRepo, statusRepo, new(MockTaskFileRepository), workflowRepo, newTaskEventRepoStub(),
+		notification, userClient, new(MockChatClient), new(MockFileClient),
+		newChatMembershipStub(),
+	)
+
+	task := createTestTask()
+	task.Status = &models.TaskStatus{ID: task.StatusID, Name: "To do"}
+	assigneeID := uuid.New()
+	watcherID := uuid.New()
+	task.Assignees = []models.TaskAssignee{{TaskID: task.ID, UserID: assigneeID}}
+	// Автор изменения тоже наблюдает за задачей, но уведомления не получает
+	task.Watchers = []models.TaskWatcher{{TaskID: task.ID, UserID: watcherID}, {TaskID: task.ID, UserID: assigneeID}}
+	actor := &dto.Actor{UserID: task.ExecutorID}
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+	statusRepo.On("GetByID", 2).Return(&models.TaskStatus{ID: 2, Name: "Done"}, nil)
+	workflowRepo.On("GetDefault").Return(nil, gorm.ErrRecordNotFound)
+	taskRepo.On("UpdateStatus", task.ID, 2).Return(nil)
+	userClient.On("GetUsersByIDs", []uuid.UUID{actor.UserID, assigneeID, watcherID}).Return(&cuc.UsersResponse{Users: []*cuc.User{
+		testUser(actor.UserID, "alice"), testUser(assigneeID, "bob"), testUser(watcherID, "carol"),
+	}}, nil)
+	notification.On("SendTaskStatusChangedNotification", task.ID, task.Title, "alice", "To do", "Done", assigneeID, "bob@example.com").Return(nil)
+	notification.On("SendTaskStatusChangedNotification", task.ID, task.Title, "alice", "To do", "Done", watcherID, "carol@example.com").Return(nil)
+
+	err := controller.UpdateStatus(task.ID, 2, actor)
+
+	require.NoError(t, err)
+	notification.AssertExpectations(t)
+	notification.AssertNumberOfCalls(t, "SendTaskStatusChangedNotification", 2)
+}
+
+func TestTaskController_UpdateStatus_LoadsRecipientsInBatches(t *testing.T) {
+	taskRepo := new(MockTaskRepository)
+	statusRepo := new(MockTaskStatusRepository)
+	workflowRepo := new(MockTaskWorkflowRepository)
+	notification := new(MockNotificationService)
+	userClient := new(MockUserClient)
+	controller := controllers.NewTaskControllerWithClients(
+		taskRepo, statusRepo, new(MockTaskFileRepository), workflowRepo, newTaskEventRepoStub(),
+		notification, userClient, new(MockChatClient), new(MockFileClient),
+		newChatMembershipStub(),
+	)
+
+	task := createTestTask()
+	task.Status = &models.TaskStatus{ID: task.StatusID, Name: "To do"}
+	actor := &dto.Actor{UserID: task.ExecutorID}
+	userIDs := []uuid.UUID{actor.UserID}
+	users := []*cuc.User{testUser(actor.UserID, "alice")}
+	for i := 0; i < 150; i++ {
+		watcherID := uuid.New()
+		task.Watchers = append(task.Watchers, models.TaskWatcher{TaskID: task.ID, UserID: watcherID})
+		userIDs = append(userIDs, watcherID)
+		users = append(users, testUser(watcherID, "watcher"))
+	}
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+	statusRepo.On("GetByID", 2).Return(&models.TaskStatus{ID: 2, Name: "Done"}, nil)
+	workflowRepo.On("GetDefault").Return(nil, gorm.ErrRecordNotFound)
+	taskRepo.On("UpdateStatus", task.ID, 2).Return(nil)
+	// userService принимает не больше 100 идентификаторов за запрос
+	userClient.On("GetUsersByIDs", userIDs[:100]).Return(&cuc.UsersResponse{Users: users[:100]}, nil).Once()
+	userClient.On("GetUsersByIDs", userIDs[100:]).Return(&cuc.UsersResponse{Users: users[100:]}, nil).Once()
+	notification.On("SendTaskStatusChangedNotification", task.ID, task.Title, "alice", "To do", "Done", mock.Anything, "watcher@example.com").Return(nil)
+
+	err := controller.UpdateStatus(task.ID, 2, actor)
+
+	require.NoError(t, err)
+	userClient.AssertExpectations(t)
+	notification.AssertNumberOfCalls(t, "SendTaskStatusChangedNotification", 150)
+}
@@ -0,0 +1,296 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type recurrenceMocks struct {
+	recurrenceRepo *MockTaskRecurrenceRepository
+	statusRepo     *MockTaskStatusRepository
+	workflowRepo   *MockTaskWorkflowRepository
+	userClient     *MockUserClient
+	chatClient     *MockChatClient
+}
+
+func newRecurrenceController() (*controllers.TaskRecurrenceController, *recurrenceMocks) {
+	m := &recurrenceMocks{
+		recurrenceRepo: new(MockTaskRecurrenceRepository),
+		statusRepo:     new(MockTaskStatusRepository),
+		workflowRepo:   new(MockTaskWorkflowRepository),
+		userClient:     new(MockUserClient),
+		chatClient:     new(MockChatClient),
+	}
+	m.statusRepo.On("GetByName", "created").Return(createTestTaskStatus(), nil).Maybe()
+	m.userClient.On("GetUserByID", mock.Anything).Return(createTestUserResponse(), nil).Maybe()
+	controller := controllers.NewTaskRecurrenceController(m.recurrenceRepo, m.statusRepo, m.workflowRepo, m.userClient, m.chatClient, newChatMembershipStub())
+	return controller, m
+}
+
+// tomorrowAt возвращает завтрашнее время суток hour:00, чтобы первый экземпляр всегда был в будущем
+func tomorrowAt(hour int) time.Time {
+	year, month, day := time.Now().UTC().AddDate(0, 0, 1).Date()
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func testRecurrenceDTO(rule string, startsAt time.Time) *dto.SaveTaskRecurrenceDTO {
+	return &dto.SaveTaskRecurrenceDTO{
+		Title:      "Weekly report",
+		ExecutorID: uuid.New(),
+		Rule:       rule,
+		StartsAt:   startsAt,
+	}
+}
+
+func testRecurrence(creatorID uuid.UUID, rule string, startsAt time.Time) *models.TaskRecurrence {
+	return &models.TaskRecurrence{
+		ID:               5,
+		Title:            "Weekly report",
+		CreatorID:        creatorID,
+		ExecutorID:       uuid.New(),
+		Priority:         models.TaskPriorityNormal,
+		Rule:             rule,
+		StartsAt:         startsAt,
+		NextOccurrenceAt: &startsAt,
+	}
+}
+
+// Тесты для TaskRecurrenceController.Create
+
+func TestTaskRecurrenceController_Create_Success(t *testing.T) {
+	controller, m := newRecurrenceController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	startsAt := tomorrowAt(9)
+	recurrenceDTO := testRecurrenceDTO("FREQ=WEEKLY;BYDAY=MO,FR", startsAt)
+
+	m.recurrenceRepo.On("Create", mock.AnythingOfType("*models.TaskRecurrence")).Return(nil)
+
+	recurrence, err := controller.Create(actor, recurrenceDTO)
+
+	require.NoError(t, err)
+	assert.Equal(t, actor.UserID, recurrence.CreatorID)
+	assert.Equal(t, recurrenceDTO.ExecutorID, recurrence.ExecutorID)
+	assert.Equal(t, models.TaskPriorityNormal, recurrence.Priority)
+	require.NotNil(t, recurrence.NextOccurrenceAt)
+	assert.False(t, recurrence.NextOccurrenceAt.Before(startsAt))
+	assert.Contains(t, []time.Weekday{time.Monday, time.Friday}, recurrence.NextOccurrenceAt.Weekday())
+	m.recurrenceRepo.AssertExpectations(t)
+}
+
+func TestTaskRecurrenceController_Create_StartedInPastSkipsPastOccurrences(t *testing.T) {
+	controller, m := newRecurrenceController()
+	startsAt := tomorrowAt(9).AddDate(0, 0, -30)
+
+	m.recurrenceRepo.On("Create", mock.AnythingOfType("*models.TaskRecurrence")).Return(nil)
+
+	recurrence, err := controller.Create(&dto.Actor{UserID: uuid.New()}, testRecurrenceDTO("FREQ=DAILY", startsAt))
+
+	require.NoError(t, err)
+	now := time.Now()
+	assert.True(t, recurrence.NextOccurrenceAt.After(now))
+	assert.True(t, recurrence.NextOccurrenceAt.Before(now.Add(24*time.Hour)))
+}
+
+func TestTaskRecurrenceController_Create_InvalidRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		startsAt time.Time
+	}{
+		{name: "unsupported frequency", rule: "FREQ=YEARLY", startsAt: tomorrowAt(9)},
+		{name: "no future occurrences", rule: "FREQ=DAILY;UNTIL=20200101", startsAt: time.Date(2019, 12, 1, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, m := newRecurrenceController()
+
+			_, err := controller.Create(&dto.Actor{UserID: uuid.New()}, testRecurrenceDTO(tt.rule, tt.startsAt))
+
+			var ruleErr *custom_errors.InvalidRecurrenceRuleError
+			require.True(t, errors.As(err, &ruleErr))
+			m.recurrenceRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestTaskRecurrenceController_Create_ExecutorNotFound(t *testing.T) {
+	controller, m := newRecurrenceController()
+	m.userClient.ExpectedCalls = nil
+	m.userClient.On("GetUserByID", mock.Anything).Return(nil, errors.New("not found"))
+
+	_, err := controller.Create(&dto.Actor{UserID: uuid.New()}, testRecurrenceDTO("FREQ=DAILY", tomorrowAt(9)))
+
+	var userErr *custom_errors.GetUserHTTPError
+	require.True(t, errors.As(err, &userErr))
+	m.recurrenceRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskRecurrenceController_Create_RequiresChatMembership(t *testing.T) {
+	controller, m := newRecurrenceController()
+	recurrenceDTO := testRecurrenceDTO("FREQ=DAILY", tomorrowAt(9))
+	recurrenceDTO.ChatID = uuid.New()
+	m.chatClient.On("GetChatByID", recurrenceDTO.ChatID.String()).Return(createTestChat(), nil)
+
+	// Участник только testChatID не может создавать экземпляры серии в чужом чате
+	_, err := controller.Create(&dto.Actor{UserID: uuid.New()}, recurrenceDTO)
+
+	var membershipErr *custom_errors.ChatMembershipRequiredError
+	require.True(t, errors.As(err, &membershipErr))
+	m.recurrenceRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskRecurrenceController_Update_MoveToChatRequiresMembership(t *testing.T) {
+	controller, m := newRecurrenceController()
+	creatorID := uuid.New()
+	startsAt := tomorrowAt(9)
+	recurrenceDTO := testRecurrenceDTO("FREQ=DAILY", startsAt)
+	recurrenceDTO.ChatID = uuid.New()
+	m.recurrenceRepo.On("GetByID", 5).Return(testRecurrence(creatorID, "FREQ=DAILY", startsAt), nil)
+	m.chatClient.On("GetChatByID", recurrenceDTO.ChatID.String()).Return(createTestChat(), nil)
+
+	_, err := controller.Update(5, &dto.Actor{UserID: creatorID}, recurrenceDTO)
+
+	var membershipErr *custom_errors.ChatMembershipRequiredError
+	require.True(t, errors.As(err, &membershipErr))
+	m.recurrenceRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+// Тесты для TaskRecurrenceController.GetByID
+
+func TestTaskRecurrenceController_GetByID_VisibleToChatMembers(t *testing.T) {
+	controller, m := newRecurrenceController()
+	recurrence := testRecurrence(uuid.New(), "FREQ=DAILY", tomorrowAt(9))
+	recurrence.ChatID = testChatID
+	m.recurrenceRepo.On("GetByID", 5).Return(recurrence, nil)
+
+	got, err := controller.GetByID(5, &dto.Actor{UserID: uuid.New()})
+
+	require.NoError(t, err)
+	assert.Equal(t, recurrence, got)
+}
+
+func TestTaskRecurrenceController_GetByID_HiddenFromOthers(t *testing.T) {
+	controller, m := newRecurrenceController()
+	m.recurrenceRepo.On("GetByID", 5).Return(testRecurrence(uuid.New(), "FREQ=DAILY", tomorrowAt(9)), nil)
+
+	_, err := controller.GetByID(5, &dto.Actor{UserID: uuid.New()})
+
+	var notFoundErr *custom_errors.TaskRecurrenceNotFoundError
+	require.True(t, errors.As(err, &notFoundErr))
+}
+
+// Тесты для TaskRecurrenceController.Update
+
+func TestTaskRecurrenceController_Update_TemplateAppliedToPendingOccurrences(t *testing.T) {
+	controller, m := newRecurrenceController()
+	creatorID := uuid.New()
+	startsAt := tomorrowAt(9)
+	existing := testRecurrence(creatorID, "FREQ=DAILY", startsAt)
+	recurrenceDTO := testRecurrenceDTO("FREQ=DAILY", startsAt)
+	recurrenceDTO.Title = "Daily report"
+	recurrenceDTO.Priority = models.TaskPriorityHigh
+
+	m.recurrenceRepo.On("GetByID", 5).Return(existing, nil)
+	m.recurrenceRepo.On("Update", mock.MatchedBy(func(r *models.TaskRecurrence) bool {
+		return r.Title == "Daily report" && r.NextOccurrenceAt.Equal(startsAt) && r.UpdatedAt != nil
+	})).Return(nil)
+	m.recurrenceRepo.On("UpdatePendingOccurrences", mock.MatchedBy(func(r *models.TaskRecurrence) bool {
+		return r.Priority == models.TaskPriorityHigh
+	}), mock.AnythingOfType("time.Time")).Return(nil)
+
+	recurrence, err := controller.Update(5, &dto.Actor{UserID: creatorID}, recurrenceDTO)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Daily report", recurrence.Title)
+	m.recurrenceRepo.AssertExpectations(t)
+	m.recurrenceRepo.AssertNotCalled(t, "DeletePendingOccurrences", mock.Anything, mock.Anything)
+}
+
+func TestTaskRecurrenceController_Update_RuleChangedReschedules(t *testing.T) {
+	controller, m := newRecurrenceController()
+	creatorID := uuid.New()
+	startsAt := tomorrowAt(9)
+	existing := testRecurrence(creatorID, "FREQ=DAILY", startsAt)
+	// Серия перенесена на три дня вперёд: следующий экземпляр - новое начало серии
+	laterStart := startsAt.AddDate(0, 0, 3)
+
+	m.recurrenceRepo.On("GetByID", 5).Return(existing, nil)
+	m.recurrenceRepo.On("Update", mock.MatchedBy(func(r *models.TaskRecurrence) bool {
+		return r.Rule == "FREQ=DAILY;INTERVAL=2" && r.NextOccurrenceAt.Equal(laterStart)
+	})).Return(nil)
+	m.recurrenceRepo.On("DeletePendingOccurrences", 5, mock.AnythingOfType("time.Time")).Return(nil)
+
+	_, err := controller.Update(5, &dto.Actor{UserID: creatorID}, testRecurrenceDTO("FREQ=DAILY;INTERVAL=2", laterStart))
+
+	require.NoError(t, err)
+	m.recurrenceRepo.AssertExpectations(t)
+	m.recurrenceRepo.AssertNotCalled(t, "UpdatePendingOccurrences", mock.Anything, mock.Anything)
+}
+
+func TestTaskRecurrenceController_Update_AccessDenied(t *testing.T) {
+	controller, m := newRecurrenceController()
+	startsAt := tomorrowAt(9)
+
+	recurrence := testRecurrence(uuid.New(), "FREQ=DAILY", startsAt)
+	m.recurrenceRepo.On("GetByID", 5).Return(recurrence, nil)
+
+	// Исполнитель видит серию, но менять её не может
+	_, err := controller.Update(5, &dto.Actor{UserID: recurrence.ExecutorID}, testRecurrenceDTO("FREQ=DAILY", startsAt))
+
+	var accessErr *custom_errors.TaskRecurrenceAccessDeniedError
+	require.True(t, errors.As(err, &accessErr))
+	m.recurrenceRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTaskRecurrenceController_Update_ManagerCanEdit(t *testing.T) {
+	controller, m := newRecurrenceController()
+	startsAt := tomorrowAt(9)
+
+	m.recurrenceRepo.On("GetByID", 5).Return(testRecurrence(uuid.New(), "FREQ=DAILY", startsAt), nil)
+	m.recurrenceRepo.On("Update", mock.Anything).Return(nil)
+	m.recurrenceRepo.On("UpdatePendingOccurrences", mock.Anything, mock.Anything).Return(nil)
+
+	actor := &dto.Actor{UserID: uuid.New(), Permissions: []string{dto.PermissionManageAllTasks}}
+	_, err := controller.Update(5, actor, testRecurrenceDTO("FREQ=DAILY", startsAt))
+
+	require.NoError(t, err)
+}
+
+// Тесты для TaskRecurrenceController.Delete
+
+func TestTaskRecurrenceController_Delete_RemovesPendingOccurrences(t *testing.T) {
+	controller, m := newRecurrenceController()
+	creatorID := uuid.New()
+
+	m.recurrenceRepo.On("GetByID", 5).Return(testRecurrence(creatorID, "FREQ=DAILY", tomorrowAt(9)), nil)
+	m.recurrenceRepo.On("Delete", 5).Return(nil)
+	m.recurrenceRepo.On("DeletePendingOccurrences", 5, mock.AnythingOfType("time.Time")).Return(nil)
+
+	err := controller.Delete(5, &dto.Actor{UserID: creatorID})
+
+	require.NoError(t, err)
+	m.recurrenceRepo.AssertExpectations(t)
+}
+
+func TestTaskRecurrenceController_Delete_NotFound(t *testing.T) {
+	controller, m := newRecurrenceController()
+
+	m.recurrenceRepo.On("GetByID", 5).Return(nil, gorm.ErrRecordNotFound)
+
+	err := controller.Delete(5, &dto.Actor{UserID: uuid.New()})
+
+	var notFoundErr *custom_errors.TaskRecurrenceNotFoundError
+	require.True(t, errors.As(err, &notFoundErr))
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+)
+
+func newReportQuery(interval string, days int) *dto.TaskReportQuery {
+	to := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	return &dto.TaskReportQuery{Interval: interval, From: to.AddDate(0, 0, -days), To: to}
+}
+
+func newReportController(reportRepo *MockTaskReportRepository) *controllers.TaskReportController {
+	return controllers.NewTaskReportController(reportRepo, newChatMembershipStub())
+}
+
+// Тесты для TaskReportController
+
+func TestTaskReportController_GetThroughput(t *testing.T) {
+	reportRepo := new(MockTaskReportRepository)
+	controller := newReportController(reportRepo)
+	actor := &dto.Actor{UserID: uuid.New()}
+	query := newReportQuery(dto.ReportIntervalWeek, 14)
+	expected := []dto.TaskThroughput{{Period: query.From, Created: 4, Completed: 2}}
+
+	// Отчёт считается только по задачам, которые видит actor
+	reportRepo.On("GetThroughput", query, &dto.TaskVisibility{UserID: actor.UserID, ChatIDs: []uuid.UUID{testChatID}}).
+		Return(expected, nil)
+
+	rows, err := controller.GetThroughput(actor, query)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, rows)
+}
+
+func TestTaskReportController_GetThroughput_AdminSeesAllTasks(t *testing.T) {
+	reportRepo := new(MockTaskReportRepository)
+	controller := newReportController(reportRepo)
+	query := newReportQuery(dto.ReportIntervalDay, 7)
+
+	reportRepo.On("GetThroughput", query, (*dto.TaskVisibility)(nil)).Return([]dto.TaskThroughput{}, nil)
+
+	_, err := controller.GetThroughput(&dto.Actor{UserID: uuid.New(), Permissions: []string{dto.PermissionManageAllTasks}}, query)
+
+	require.NoError(t, err)
+	reportRepo.AssertExpectations(t)
+}
+
+func TestTaskReportController_ChatReportRequiresMembership(t *testing.T) {
+	reportRepo := new(MockTaskReportRepository)
+	controller := newReportController(reportRepo)
+	chatID := uuid.New()
+	query := newReportQuery(dto.ReportIntervalDay, 7)
+	query.ChatID = &chatID
+
+	_, err := controller.GetStatusDurations(&dto.Actor{UserID: uuid.New()}, query)
+
+	var membershipErr *custom_errors.ChatMembershipRequiredError
+	assert.ErrorAs(t, err, &membershipErr)
+	reportRepo.AssertNotCalled(t, "GetStatusDurations", mock.Anything, mock.Anything)
+}
+
+func TestTaskReportController_GetThroughput_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *dto.TaskReportQuery
+	}{
+		{name: "unknown interval", query: newReportQuery("month", 30)},
+		{name: "empty period", query: newReportQuery(dto.ReportIntervalDay, 0)},
+		{name: "period too long", query: newReportQuery(dto.ReportIntervalDay, 400)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportRepo := new(MockTaskReportRepository)
+			controller := newReportController(reportRepo)
+
+			_, err := controller.GetThroughput(&dto.Actor{UserID: uuid.New()}, tt.query)
+
+			var invalidErr *custom_errors.InvalidTaskReportError
+			assert.ErrorAs(t, err, &invalidErr)
+			reportRepo.AssertNotCalled(t, "GetThroughput", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTaskReportController_GetOverdue_UsesCurrentTime(t *testing.T) {
+	reportRepo := new(MockTaskReportRepository)
+	controller := newReportController(reportRepo)
+	chatID := testChatID
+	before := time.Now()
+
+	reportRepo.On("GetOverdueByExecutor", mock.MatchedBy(func(now time.Time) bool {
+		return !now.Before(before) && now.Sub(before) < time.Minute
+	}), &chatID, mock.Anything).Return([]dto.TaskOverdueCount{{ExecutorID: uuid.New(), Overdue: 3}}, nil)
+
+	rows, err := controller.GetOverdue(&dto.Actor{UserID: uuid.New()}, &chatID)
+
+	require.NoError(t, err)
+	assert.Len(t, rows, 1)
+	reportRepo.AssertExpectations(t)
+}
+
+func TestTaskReportController_GetCumulativeFlow_RequiresChat(t *testing.T) {
+	reportRepo := new(MockTaskReportRepository)
+	controller := newReportController(reportRepo)
+
+	_, err := controller.GetCumulativeFlow(&dto.Actor{UserID: uuid.New()}, newReportQuery("", 7))
+
+	var invalidErr *custom_errors.InvalidTaskReportError
+	assert.ErrorAs(t, err, &invalidErr)
+	reportRepo.AssertNotCalled(t, "GetCumulativeFlow", mock.Anything, mock.Anything)
+}
+
+func TestTaskReportController_GetCumulativeFlow(t *testing.T) {
+	reportRepo := new(MockTaskReportRepository)
+	controller := newReportController(reportRepo)
+	chatID := testChatID
+	query := newReportQuery("", 7)
+	query.ChatID = &chatID
+
+	reportRepo.On("GetCumulativeFlow", query, mock.Anything).Return([]dto.TaskCumulativeFlow{{Day: query.From, Status: "Open", Tasks: 5}}, nil)
+
+	rows, err := controller.GetCumulativeFlow(&dto.Actor{UserID: uuid.New()}, query)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), rows[0].Tasks)
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+func TestTaskController_Create_StartAfterDue(t *testing.T) {
+	controller, m := newLifecycleController()
+	dueAt := time.Now().Add(time.Hour)
+	startAt := dueAt.Add(time.Minute)
+
+	_, err := controller.Create(&dto.Actor{UserID: uuid.New()}, &dto.CreateTaskDTO{
+		Title:   "Task",
+		StartAt: &startAt,
+		DueAt:   &dueAt,
+	})
+
+	var scheduleErr *custom_errors.InvalidTaskScheduleError
+	require.True(t, errors.As(err, &scheduleErr))
+	m.taskRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskController_Update_DueDateResetsReminders(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	oldDue := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	notified := oldDue.Add(-time.Hour)
+	task.Priority = models.TaskPriorityNormal
+	task.DueAt = &oldDue
+	task.DueSoonNotifiedAt = &notified
+	task.OverdueNotifiedAt = &notified
+	actor := &dto.Actor{UserID: task.CreatorID}
+	newDue := oldDue.Add(72 * time.Hour)
+	urgent := models.TaskPriorityUrgent
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("Update", mock.MatchedBy(func(updated *models.Task) bool {
+		return updated.DueAt.Equal(newDue) && updated.Priority == urgent &&
+			updated.DueSoonNotifiedAt == nil && updated.OverdueNotifiedAt == nil
+	})).Return(nil)
+
+	_, err := controller.Update(task.ID, actor, &dto.UpdateTaskDTO{DueAt: &newDue, Priority: &urgent})
+	require.NoError(t, err)
+
+	edited := eventsOf(recordedEvents(m.events), models.TaskEventEdited)
+	require.Len(t, edited, 2)
+	assert.Equal(t, "priority", *edited[0].Field)
+	assert.Equal(t, "due_at", *edited[1].Field)
+	assert.Equal(t, "2026-05-01T12:00:00Z", *edited[1].OldValue)
+	assert.Equal(t, "2026-05-04T12:00:00Z", *edited[1].NewValue)
+	m.taskRepo.AssertExpectations(t)
+}
+
+func TestTaskController_Update_SameDueDateKeepsReminders(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	due := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	notified := due.Add(-time.Hour)
+	task.DueAt = &due
+	task.DueSoonNotifiedAt = &notified
+	actor := &dto.Actor{UserID: task.ExecutorID}
+	sameDue := due.In(time.FixedZone("MSK", 3*60*60))
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("Update", mock.MatchedBy(func(updated *models.Task) bool {
+		return updated.DueSoonNotifiedAt != nil
+	})).Return(nil)
+
+	_, err := controller.Update(task.ID, actor, &dto.UpdateTaskDTO{DueAt: &sameDue})
+	require.NoError(t, err)
+
+	assert.Empty(t, eventsOf(recordedEvents(m.events), models.TaskEventEdited))
+}
+
+func TestTaskController_Update_ClearDueAt(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	due := time.Now().Add(time.Hour)
+	task.DueAt = &due
+	actor := &dto.Actor{UserID: task.CreatorID}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("Update", mock.MatchedBy(func(updated *models.Task) bool {
+		return updated.DueAt == nil
+	})).Return(nil)
+
+	_, err := controller.Update(task.ID, actor, &dto.UpdateTaskDTO{ClearDueAt: true})
+	require.NoError(t, err)
+
+	edited := eventsOf(recordedEvents(m.events), models.TaskEventEdited)
+	require.Len(t, edited, 1)
+	assert.Nil(t, edited[0].NewValue)
+}
+
+func TestTaskController_Update_StartAfterExistingDue(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	due := time.Now().Add(time.Hour)
+	task.DueAt = &due
+	actor := &dto.Actor{UserID: task.CreatorID}
+	startAt := due.Add(time.Hour)
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+
+	_, err := controller.Update(task.ID, actor, &dto.UpdateTaskDTO{StartAt: &startAt})
+
+	var scheduleErr *custom_errors.InvalidTaskScheduleError
+	require.True(t, errors.As(err, &scheduleErr))
+	m.taskRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTaskController_Update_Estimate(t *testing.T) {
+	controller, m := newLifecycleController()
+	task := createTestTask()
+	oldEstimate := 60
+	task.EstimateMinutes = &oldEstimate
+	actor := &dto.Actor{UserID: task.ExecutorID}
+	newEstimate := 90
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.taskRepo.On("Update", mock.MatchedBy(func(updated *models.Task) bool {
+		return updated.EstimateMinutes != nil && *updated.EstimateMinutes == newEstimate
+	})).Return(nil)
+
+	_, err := controller.Update(task.ID, actor, &dto.UpdateTaskDTO{EstimateMinutes: &newEstimate})
+	require.NoError(t, err)
+
+	edited := eventsOf(recordedEvents(m.events), models.TaskEventEdited)
+	require.Len(t, edited, 1)
+	assert.Equal(t, "estimate_minutes", *edited[0].Field)
+	assert.Equal(t, "60", *edited[0].OldValue)
+	assert.Equal(t, "90", *edited[0].NewValue)
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/handlers/dto"
+)
+
+func TestTaskController_QueryTasks_ReturnsNextCursor(t *testing.T) {
+	controller, m := newLifecycleController()
+	dueAt := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	query := &dto.TaskQuery{TaskListFilter: dto.TaskListFilter{SortBy: dto.TaskSortByDueAt}, Limit: 2}
+
+	m.taskRepo.On("QueryTasks", query, mock.Anything).Return([]dto.TaskToList{
+		{ID: 4, Title: "first"},
+		{ID: 7, Title: "second", DueAt: &dueAt},
+		{ID: 9, Title: "third"},
+	}, int64(5), nil)
+
+	result, err := controller.QueryTasks(&dto.Actor{UserID: uuid.New()}, query)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), result.Total)
+	require.Len(t, result.Tasks, 2)
+	require.NotNil(t, result.NextCursor)
+
+	cursor, err := dto.DecodeTaskCursor(*result.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, 7, cursor.ID)
+	assert.Equal(t, dto.TaskSortByDueAt, cursor.SortBy)
+	assert.False(t, cursor.SortDesc)
+	require.NotNil(t, cursor.Value)
+	assert.Equal(t, "2026-06-01T12:00:00Z", *cursor.Value)
+}
+
+func TestTaskController_QueryTasks_NullSortValue(t *testing.T) {
+	controller, m := newLifecycleController()
+	query := &dto.TaskQuery{TaskListFilter: dto.TaskListFilter{SortBy: dto.TaskSortByStartAt, SortDesc: true}, Limit: 1}
+
+	m.taskRepo.On("QueryTasks", query, mock.Anything).Return([]dto.TaskToList{{ID: 3}, {ID: 2}}, int64(2), nil)
+
+	result, err := controller.QueryTasks(&dto.Actor{UserID: uuid.New()}, query)
+
+	require.NoError(t, err)
+	cursor, err := dto.DecodeTaskCursor(*result.NextCursor)
+	require.NoError(t, err)
+	assert.Nil(t, cursor.Value, "tasks without start date are positioned by id only")
+	assert.Equal(t, 3, cursor.ID)
+}
+
+func TestTaskController_QueryTasks_LastPage(t *testing.T) {
+	controller, m := newLifecycleController()
+	query := &dto.TaskQuery{TaskListFilter: dto.TaskListFilter{SortBy: dto.TaskSortByCreatedAt, SortDesc: true}, Limit: 20}
+
+	m.taskRepo.On("QueryTasks", query, mock.Anything).Return(nil, int64(0), nil)
+
+	result, err := controller.QueryTasks(&dto.Actor{UserID: uuid.New()}, query)
+
+	require.NoError(t, err)
+	assert.NotNil(t, result.Tasks)
+	assert.Empty(t, result.Tasks)
+	assert.Nil(t, result.NextCursor)
+}
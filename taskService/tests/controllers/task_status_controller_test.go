@@ -4,12 +4,14 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 	"taskService/internal/controllers"
 	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
 	"taskService/internal/models"
 )
 
@@ -144,18 +146,65 @@ func TestTaskStatusController_GetByID_RepositoryError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// Тесты для TaskStatusController.GetUsage
+
+func TestTaskStatusController_GetUsage_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskStatusRepository)
+	controller := controllers.NewTaskStatusController(mockRepo)
+
+	status := createTestTaskStatusWithID(2, "in_progress")
+	mockRepo.On("GetByID", 2).Return(status, nil)
+	mockRepo.On("CountTasks", 2).Return(int64(5), int64(1), nil)
+	mockRepo.On("GetWorkflowsUsing", 2).Return([]models.TaskWorkflow{{ID: 1, Name: "kanban"}}, nil)
+
+	// Act
+	usage, err := controller.GetUsage(2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "in_progress", usage.Name)
+	assert.Equal(t, int64(5), usage.Tasks)
+	assert.Equal(t, int64(1), usage.DeletedTasks)
+	assert.Equal(t, []dto.TaskStatusWorkflowRef{{ID: 1, Name: "kanban"}}, usage.Workflows)
+	assert.True(t, usage.Protected)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTaskStatusController_GetUsage_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskStatusRepository)
+	controller := controllers.NewTaskStatusController(mockRepo)
+
+	mockRepo.On("GetByID", 7).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	usage, err := controller.GetUsage(7)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, usage)
+	var notFoundErr *custom_errors.TaskStatusNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
 // Тесты для TaskStatusController.DeleteByID
 
-func TestTaskStatusController_DeleteByID_Success(t *testing.T) {
+func TestTaskStatusController_DeleteByID_Unused(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskStatusRepository)
 	controller := controllers.NewTaskStatusController(mockRepo)
 
-	statusID := 1
-	mockRepo.On("DeleteByID", statusID).Return(nil)
+	status := createTestTaskStatusWithID(4, "archived")
+	actor := &dto.Actor{UserID: uuid.New()}
+	mockRepo.On("GetByID", status.ID).Return(status, nil)
+	mockRepo.On("CountTasks", status.ID).Return(int64(0), int64(0), nil)
+	mockRepo.On("GetWorkflowsUsing", status.ID).Return([]models.TaskWorkflow{}, nil)
+	mockRepo.On("Delete", status, (*models.TaskStatus)(nil), actor.UserID).Return(nil)
 
 	// Act
-	err := controller.DeleteByID(statusID)
+	err := controller.DeleteByID(status.ID, nil, actor)
 
 	// Assert
 	require.NoError(t, err)
@@ -163,23 +212,135 @@ func TestTaskStatusController_DeleteByID_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestTaskStatusController_DeleteByID_MigratesTasksToReplacement(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskStatusRepository)
+	controller := controllers.NewTaskStatusController(mockRepo)
+
+	status := createTestTaskStatusWithID(4, "archived")
+	replacement := createTestTaskStatusWithID(3, "completed")
+	replacementID := replacement.ID
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	mockRepo.On("GetByID", status.ID).Return(status, nil)
+	mockRepo.On("GetByID", replacement.ID).Return(replacement, nil)
+	mockRepo.On("CountTasks", status.ID).Return(int64(3), int64(2), nil)
+	mockRepo.On("GetWorkflowsUsing", status.ID).Return([]models.TaskWorkflow{}, nil)
+	mockRepo.On("Delete", status, replacement, actor.UserID).Return(nil)
+
+	// Act
+	err := controller.DeleteByID(status.ID, &replacementID, actor)
+
+	// Assert
+	require.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTaskStatusController_DeleteByID_TasksWithoutReplacement(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskStatusRepository)
+	controller := controllers.NewTaskStatusController(mockRepo)
+
+	status := createTestTaskStatusWithID(4, "archived")
+	mockRepo.On("GetByID", status.ID).Return(status, nil)
+	// Задач не было при подсчёте использования, но их создали до удаления: транзакция удаления их находит
+	mockRepo.On("CountTasks", status.ID).Return(int64(0), int64(0), nil)
+	mockRepo.On("GetWorkflowsUsing", status.ID).Return([]models.TaskWorkflow{}, nil)
+	mockRepo.On("Delete", status, (*models.TaskStatus)(nil), mock.Anything).
+		Return(custom_errors.NewTaskStatusReplacementRequiredError(status.ID, 2))
+
+	// Act
+	err := controller.DeleteByID(status.ID, nil, &dto.Actor{UserID: uuid.New()})
+
+	// Assert
+	var replacementErr *custom_errors.TaskStatusReplacementRequiredError
+	require.ErrorAs(t, err, &replacementErr)
+	assert.Equal(t, int64(2), replacementErr.Tasks)
+}
+
+func TestTaskStatusController_DeleteByID_CreatedStatusProtected(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskStatusRepository)
+	controller := controllers.NewTaskStatusController(mockRepo)
+
+	replacementID := 3
+	mockRepo.On("GetByID", 1).Return(createTestTaskStatusWithID(1, models.TaskStatusCreated), nil)
+	mockRepo.On("CountTasks", 1).Return(int64(10), int64(0), nil)
+	mockRepo.On("GetWorkflowsUsing", 1).Return([]models.TaskWorkflow{}, nil)
+
+	// Act
+	err := controller.DeleteByID(1, &replacementID, &dto.Actor{UserID: uuid.New()})
+
+	// Assert
+	var protectedErr *custom_errors.TaskStatusProtectedError
+	assert.ErrorAs(t, err, &protectedErr)
+
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskStatusController_DeleteByID_UsedByWorkflow(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskStatusRepository)
+	controller := controllers.NewTaskStatusController(mockRepo)
+
+	mockRepo.On("GetByID", 2).Return(createTestTaskStatusWithID(2, "in_progress"), nil)
+	mockRepo.On("CountTasks", 2).Return(int64(0), int64(0), nil)
+	mockRepo.On("GetWorkflowsUsing", 2).Return([]models.TaskWorkflow{{ID: 1, Name: "kanban"}}, nil)
+
+	// Act
+	err := controller.DeleteByID(2, nil, &dto.Actor{UserID: uuid.New()})
+
+	// Assert
+	var protectedErr *custom_errors.TaskStatusProtectedError
+	require.ErrorAs(t, err, &protectedErr)
+	assert.Contains(t, err.Error(), "kanban")
+
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskStatusController_DeleteByID_InvalidReplacement(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockTaskStatusRepository)
+	controller := controllers.NewTaskStatusController(mockRepo)
+
+	sameID := 4
+	missingID := 99
+	mockRepo.On("GetByID", 4).Return(createTestTaskStatusWithID(4, "archived"), nil)
+	mockRepo.On("GetByID", missingID).Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("CountTasks", 4).Return(int64(1), int64(0), nil)
+	mockRepo.On("GetWorkflowsUsing", 4).Return([]models.TaskWorkflow{}, nil)
+
+	for _, replacementID := range []*int{&sameID, &missingID} {
+		// Act
+		err := controller.DeleteByID(4, replacementID, &dto.Actor{UserID: uuid.New()})
+
+		// Assert
+		var invalidErr *custom_errors.InvalidStatusReplacementError
+		assert.ErrorAs(t, err, &invalidErr)
+	}
+
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestTaskStatusController_DeleteByID_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockTaskStatusRepository)
 	controller := controllers.NewTaskStatusController(mockRepo)
 
-	statusID := 1
+	statusID := 4
 	repoError := errors.New("database error")
-	mockRepo.On("DeleteByID", statusID).Return(repoError)
+	mockRepo.On("GetByID", statusID).Return(createTestTaskStatusWithID(statusID, "archived"), nil)
+	mockRepo.On("CountTasks", statusID).Return(int64(0), int64(0), repoError)
 
 	// Act
-	err := controller.DeleteByID(statusID)
+	err := controller.DeleteByID(statusID, nil, &dto.Actor{UserID: uuid.New()})
 
 	// Assert
 	require.Error(t, err)
 	assert.Equal(t, repoError, err)
 
-	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
 }
 
 // Тесты для TaskStatusController.GetAll
@@ -0,0 +1,304 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type templateMocks struct {
+	taskRepo     *MockTaskRepository
+	templateRepo *MockTaskTemplateRepository
+	labelRepo    *MockLabelRepository
+	userClient   *MockUserClient
+	chatClient   *MockChatClient
+	eventRepo    *MockTaskEventRepository
+}
+
+func newTemplateController() (*controllers.TaskTemplateController, *templateMocks) {
+	m := &templateMocks{
+		taskRepo:     new(MockTaskRepository),
+		templateRepo: new(MockTaskTemplateRepository),
+		labelRepo:    new(MockLabelRepository),
+		userClient:   new(MockUserClient),
+		chatClient:   new(MockChatClient),
+		eventRepo:    new(MockTaskEventRepository),
+	}
+	statusRepo := new(MockTaskStatusRepository)
+	statusRepo.On("GetByName", "created").Return(createTestTaskStatus(), nil).Maybe()
+	notificationService := new(MockNotificationService)
+	notificationService.On("SendTaskCreatedNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Maybe()
+
+	taskController := controllers.NewTaskControllerWithClients(
+		m.taskRepo,
+		statusRepo,
+		new(MockTaskFileRepository),
+		new(MockTaskWorkflowRepository),
+		m.eventRepo,
+		notificationService,
+		m.userClient,
+		m.chatClient,
+		new(MockFileClient),
+		newChatMembershipStub(),
+	)
+	return controllers.NewTaskTemplateController(taskController, m.templateRepo, m.labelRepo), m
+}
+
+func testTemplate(creatorID uuid.UUID) *models.TaskTemplate {
+	return &models.TaskTemplate{
+		ID:           4,
+		Name:         "Еженедельный отчёт",
+		TitlePattern: "Отчёт {team} за {date}",
+		Description:  "Собрать метрики",
+		CreatorID:    creatorID,
+		Priority:     models.TaskPriorityHigh,
+		Labels:       []models.TaskTemplateLabel{{LabelID: 2, Label: &models.Label{ID: 2, Name: "report"}}},
+		ChecklistItems: []models.TaskTemplateChecklistItem{
+			{ID: 1, Title: "Выгрузить данные", Position: 0},
+			{ID: 2, Title: "Отправить руководителю", Position: 1},
+		},
+	}
+}
+
+// Тесты для TaskTemplateController.CreateTask
+
+func TestTaskTemplateController_CreateTask_AppliesTemplate(t *testing.T) {
+	controller, m := newTemplateController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	template := testTemplate(actor.UserID)
+	executorID := uuid.New()
+	template.ExecutorID = &executorID
+	today := time.Now().Format("2006-01-02")
+
+	m.templateRepo.On("GetByID", 4).Return(template, nil)
+	m.userClient.On("GetUserByID", mock.Anything).Return(createTestUserResponse(), nil)
+	m.templateRepo.On("CreateTask", mock.MatchedBy(func(task *models.Task) bool {
+		return task.Title == "Отчёт backend за "+today &&
+			task.Description == "Собрать метрики" &&
+			task.CreatorID == actor.UserID &&
+			task.ExecutorID == executorID &&
+			task.Priority == models.TaskPriorityHigh
+	}), []models.TaskFile(nil), []int{2}, mock.MatchedBy(func(items []models.TaskChecklistItem) bool {
+		return len(items) == 2 && items[0].Title == "Выгрузить данные" && items[1].Position == 1
+	})).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Task).ID = 30
+	})
+	m.eventRepo.On("Create", mock.MatchedBy(func(events []models.TaskEvent) bool {
+		return len(events) == 1 && events[0].EventType == models.TaskEventCreated
+	})).Return(nil)
+	m.eventRepo.On("Create", mock.MatchedBy(func(events []models.TaskEvent) bool {
+		return len(events) == 3 && events[0].EventType == models.TaskEventLabelAdded &&
+			events[1].EventType == models.TaskEventChecklistAdded
+	})).Return(nil)
+
+	task, err := controller.CreateTask(4, actor, &dto.CreateTaskFromTemplateDTO{Variables: map[string]string{"team": "backend"}})
+
+	require.NoError(t, err)
+	assert.Len(t, task.Checklist, 2)
+	m.templateRepo.AssertExpectations(t)
+	m.eventRepo.AssertExpectations(t)
+	m.taskRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskTemplateController_CreateTask_OverridesExecutor(t *testing.T) {
+	controller, m := newTemplateController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	template := testTemplate(actor.UserID)
+	template.TitlePattern = "Дежурство"
+	template.Labels = nil
+	template.ChecklistItems = nil
+	defaultExecutor := uuid.New()
+	template.ExecutorID = &defaultExecutor
+
+	m.templateRepo.On("GetByID", 4).Return(template, nil)
+	m.userClient.On("GetUserByID", &actor.UserID).Return(createTestUserResponse(), nil)
+	m.templateRepo.On("CreateTask", mock.MatchedBy(func(task *models.Task) bool {
+		return task.ExecutorID == uuid.Nil && task.Priority == models.TaskPriorityLow
+	}), []models.TaskFile(nil), []int{}, []models.TaskChecklistItem{}).Return(nil)
+	m.eventRepo.On("Create", mock.Anything).Return(nil)
+
+	noExecutor := uuid.Nil
+	task, err := controller.CreateTask(4, actor, &dto.CreateTaskFromTemplateDTO{ExecutorID: &noExecutor, Priority: models.TaskPriorityLow})
+
+	require.NoError(t, err)
+	assert.Empty(t, task.Checklist)
+	m.userClient.AssertNumberOfCalls(t, "GetUserByID", 1)
+	m.eventRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestTaskTemplateController_CreateTask_SaveFails(t *testing.T) {
+	controller, m := newTemplateController()
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	m.templateRepo.On("GetByID", 4).Return(testTemplate(actor.UserID), nil)
+	m.userClient.On("GetUserByID", mock.Anything).Return(createTestUserResponse(), nil)
+	// Транзакция отменена целиком: ни задачи, ни её истории
+	m.templateRepo.On("CreateTask", mock.Anything, mock.Anything, []int{2}, mock.Anything).Return(assert.AnError)
+
+	task, err := controller.CreateTask(4, actor, &dto.CreateTaskFromTemplateDTO{Variables: map[string]string{"team": "qa"}})
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, task)
+	m.eventRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskTemplateController_CreateTask_MissingVariable(t *testing.T) {
+	controller, m := newTemplateController()
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	m.templateRepo.On("GetByID", 4).Return(testTemplate(actor.UserID), nil)
+
+	_, err := controller.CreateTask(4, actor, &dto.CreateTaskFromTemplateDTO{})
+
+	var invalidErr *custom_errors.InvalidTaskTemplateError
+	require.ErrorAs(t, err, &invalidErr)
+	assert.Contains(t, err.Error(), "{team}")
+	m.taskRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskTemplateController_CreateTask_ChatValidationFails(t *testing.T) {
+	controller, m := newTemplateController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	template := testTemplate(actor.UserID)
+	chatID := uuid.New()
+
+	m.templateRepo.On("GetByID", 4).Return(template, nil)
+	m.userClient.On("GetUserByID", mock.Anything).Return(createTestUserResponse(), nil)
+	m.chatClient.On("GetChatByID", chatID.String()).Return(nil, assert.AnError)
+
+	_, err := controller.CreateTask(4, actor, &dto.CreateTaskFromTemplateDTO{
+		Variables: map[string]string{"team": "qa"},
+		ChatID:    chatID,
+	})
+
+	var chatErr *custom_errors.GetChatHTTPError
+	assert.ErrorAs(t, err, &chatErr)
+	m.templateRepo.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskTemplateController_CreateTask_HiddenTemplate(t *testing.T) {
+	controller, m := newTemplateController()
+
+	m.templateRepo.On("GetByID", 4).Return(testTemplate(uuid.New()), nil)
+
+	_, err := controller.CreateTask(4, &dto.Actor{UserID: uuid.New()}, &dto.CreateTaskFromTemplateDTO{})
+
+	var notFoundErr *custom_errors.TaskTemplateNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+	m.taskRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// Тесты для TaskTemplateController.GetAll
+
+func TestTaskTemplateController_GetAll_OnlyOwnTemplates(t *testing.T) {
+	controller, m := newTemplateController()
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	m.templateRepo.On("GetAll", &actor.UserID).Return([]models.TaskTemplate{*testTemplate(actor.UserID)}, nil)
+
+	templates, err := controller.GetAll(actor)
+
+	require.NoError(t, err)
+	assert.Len(t, templates, 1)
+	m.templateRepo.AssertExpectations(t)
+}
+
+func TestTaskTemplateController_GetAll_ManagerSeesAll(t *testing.T) {
+	controller, m := newTemplateController()
+
+	m.templateRepo.On("GetAll", (*uuid.UUID)(nil)).Return([]models.TaskTemplate{}, nil)
+
+	_, err := controller.GetAll(&dto.Actor{UserID: uuid.New(), Permissions: []string{dto.PermissionManageAllTasks}})
+
+	require.NoError(t, err)
+	m.templateRepo.AssertExpectations(t)
+}
+
+// Тесты для TaskTemplateController.Create и Update
+
+func TestTaskTemplateController_Create_NameTaken(t *testing.T) {
+	controller, m := newTemplateController()
+
+	m.templateRepo.On("GetByName", "Релиз").Return(&models.TaskTemplate{ID: 9, Name: "Релиз"}, nil)
+
+	_, err := controller.Create(&dto.Actor{UserID: uuid.New()}, &dto.SaveTaskTemplateDTO{Name: "Релиз", TitlePattern: "Релиз {version}"})
+
+	assert.ErrorIs(t, err, custom_errors.ErrTaskTemplateAlreadyExists)
+	m.templateRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskTemplateController_Create_SavesLabelsAndChecklist(t *testing.T) {
+	controller, m := newTemplateController()
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	m.templateRepo.On("GetByName", "Релиз").Return(nil, gorm.ErrRecordNotFound)
+	m.labelRepo.On("GetByID", 3).Return(&dto.LabelResponse{ID: 3, Name: "release"}, nil)
+	m.templateRepo.On("Create", mock.MatchedBy(func(template *models.TaskTemplate) bool {
+		return template.CreatorID == actor.UserID && template.Priority == models.TaskPriorityNormal &&
+			template.ExecutorID == nil && len(template.Labels) == 1 &&
+			len(template.ChecklistItems) == 2 && template.ChecklistItems[1].Position == 1
+	})).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.TaskTemplate).ID = 5
+	})
+	m.templateRepo.On("GetByID", 5).Return(&models.TaskTemplate{ID: 5, Name: "Релиз"}, nil)
+
+	template, err := controller.Create(actor, &dto.SaveTaskTemplateDTO{
+		Name:           "Релиз",
+		TitlePattern:   "Релиз {version}",
+		LabelIDs:       []int{3, 3},
+		ChecklistItems: []string{"Собрать", "Выкатить"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, template.ID)
+	m.templateRepo.AssertExpectations(t)
+}
+
+func TestTaskTemplateController_Create_LabelNotFound(t *testing.T) {
+	controller, m := newTemplateController()
+
+	m.templateRepo.On("GetByName", "Релиз").Return(nil, gorm.ErrRecordNotFound)
+	m.labelRepo.On("GetByID", 3).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := controller.Create(&dto.Actor{UserID: uuid.New()}, &dto.SaveTaskTemplateDTO{Name: "Релиз", TitlePattern: "Релиз", LabelIDs: []int{3}})
+
+	var labelErr *custom_errors.LabelNotFoundError
+	assert.ErrorAs(t, err, &labelErr)
+}
+
+func TestTaskTemplateController_Update_AccessDenied(t *testing.T) {
+	controller, m := newTemplateController()
+
+	template := testTemplate(uuid.New())
+	executorID := uuid.New()
+	template.ExecutorID = &executorID
+	m.templateRepo.On("GetByID", 4).Return(template, nil)
+
+	// Исполнитель по умолчанию видит шаблон, но менять его не может
+	_, err := controller.Update(4, &dto.Actor{UserID: executorID}, &dto.SaveTaskTemplateDTO{Name: "Другое", TitlePattern: "x"})
+
+	var accessErr *custom_errors.TaskTemplateAccessDeniedError
+	assert.ErrorAs(t, err, &accessErr)
+	m.templateRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTaskTemplateController_Delete_NotFound(t *testing.T) {
+	controller, m := newTemplateController()
+
+	m.templateRepo.On("GetByID", 4).Return(nil, gorm.ErrRecordNotFound)
+
+	err := controller.Delete(4, &dto.Actor{UserID: uuid.New()})
+
+	var notFoundErr *custom_errors.TaskTemplateNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
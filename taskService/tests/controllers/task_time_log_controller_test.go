@@ -0,0 +1,228 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+func newTimeLogController() (*controllers.TaskTimeLogController, *MockTaskTimeLogRepository, *MockTaskRepository) {
+	timeLogRepo := new(MockTaskTimeLogRepository)
+	taskRepo := new(MockTaskRepository)
+	return controllers.NewTaskTimeLogController(timeLogRepo, taskRepo, newChatMembershipStub()), timeLogRepo, taskRepo
+}
+
+// Тесты для TaskTimeLogController.StartTimer и StopTimer
+
+func TestTaskTimeLogController_StartTimer_Assignee(t *testing.T) {
+	controller, timeLogRepo, taskRepo := newTimeLogController()
+	task := createTestTask()
+	assigneeID := uuid.New()
+	task.Assignees = []models.TaskAssignee{{TaskID: task.ID, UserID: assigneeID}}
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+	timeLogRepo.On("StartTimer", mock.MatchedBy(func(log *models.TaskTimeLog) bool {
+		return log.TaskID == task.ID && log.UserID == assigneeID && log.IsRunning()
+	})).Return(true, nil)
+
+	log, err := controller.StartTimer(task.ID, &dto.Actor{UserID: assigneeID})
+
+	require.NoError(t, err)
+	assert.True(t, log.IsRunning())
+	timeLogRepo.AssertExpectations(t)
+}
+
+func TestTaskTimeLogController_StartTimer_NotExecutor(t *testing.T) {
+	controller, timeLogRepo, taskRepo := newTimeLogController()
+	task := createTestTask()
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+
+	// Создатель задачи и администратор не списывают время, если не исполняют задачу
+	_, err := controller.StartTimer(task.ID, &dto.Actor{UserID: task.CreatorID, Permissions: []string{dto.PermissionManageAllTasks}})
+
+	var accessErr *custom_errors.TaskAccessDeniedError
+	assert.ErrorAs(t, err, &accessErr)
+	timeLogRepo.AssertNotCalled(t, "StartTimer", mock.Anything)
+}
+
+func TestTaskTimeLogController_StartTimer_AlreadyRunning(t *testing.T) {
+	controller, timeLogRepo, taskRepo := newTimeLogController()
+	task := createTestTask()
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+	timeLogRepo.On("StartTimer", mock.Anything).Return(false, nil)
+	timeLogRepo.On("GetRunning", task.ExecutorID).Return(&models.TaskTimeLog{ID: 7, TaskID: 42, UserID: task.ExecutorID}, nil)
+
+	_, err := controller.StartTimer(task.ID, &dto.Actor{UserID: task.ExecutorID})
+
+	var runningErr *custom_errors.TaskTimerAlreadyRunningError
+	require.ErrorAs(t, err, &runningErr)
+	assert.Equal(t, 42, runningErr.RunningTaskID)
+}
+
+func TestTaskTimeLogController_StopTimer_RecordsMinutes(t *testing.T) {
+	controller, timeLogRepo, _ := newTimeLogController()
+	userID := uuid.New()
+	running := &models.TaskTimeLog{ID: 7, TaskID: 1, UserID: userID, StartedAt: time.Now().Add(-90 * time.Minute)}
+
+	timeLogRepo.On("GetRunning", userID).Return(running, nil)
+	timeLogRepo.On("Update", running).Return(nil)
+
+	log, err := controller.StopTimer(1, &dto.Actor{UserID: userID})
+
+	require.NoError(t, err)
+	require.NotNil(t, log.EndedAt)
+	assert.Equal(t, 90, log.Minutes)
+}
+
+func TestTaskTimeLogController_StopTimer_OtherTask(t *testing.T) {
+	controller, timeLogRepo, _ := newTimeLogController()
+	userID := uuid.New()
+
+	timeLogRepo.On("GetRunning", userID).Return(&models.TaskTimeLog{ID: 7, TaskID: 2, UserID: userID, StartedAt: time.Now()}, nil)
+
+	_, err := controller.StopTimer(1, &dto.Actor{UserID: userID})
+
+	var notRunningErr *custom_errors.TaskTimerNotRunningError
+	assert.ErrorAs(t, err, &notRunningErr)
+	timeLogRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTaskTimeLogController_StopTimer_NoTimer(t *testing.T) {
+	controller, timeLogRepo, _ := newTimeLogController()
+	userID := uuid.New()
+
+	timeLogRepo.On("GetRunning", userID).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := controller.StopTimer(1, &dto.Actor{UserID: userID})
+
+	var notRunningErr *custom_errors.TaskTimerNotRunningError
+	assert.ErrorAs(t, err, &notRunningErr)
+}
+
+// Тесты для TaskTimeLogController.Create
+
+func TestTaskTimeLogController_Create_Manual(t *testing.T) {
+	controller, timeLogRepo, taskRepo := newTimeLogController()
+	task := createTestTask()
+	startedAt := time.Now().Add(-3 * time.Hour)
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+	timeLogRepo.On("Create", mock.Anything).Return(nil)
+
+	log, err := controller.Create(task.ID, &dto.Actor{UserID: task.ExecutorID}, &dto.CreateTaskTimeLogDTO{
+		StartedAt:   startedAt,
+		Minutes:     45,
+		Description: "code review",
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, log.EndedAt)
+	assert.Equal(t, startedAt.Add(45*time.Minute), *log.EndedAt)
+	assert.Equal(t, task.ExecutorID, log.UserID)
+}
+
+func TestTaskTimeLogController_Create_EndsInFuture(t *testing.T) {
+	controller, timeLogRepo, taskRepo := newTimeLogController()
+	task := createTestTask()
+
+	taskRepo.On("GetByID", task.ID).Return(task, nil)
+
+	_, err := controller.Create(task.ID, &dto.Actor{UserID: task.ExecutorID}, &dto.CreateTaskTimeLogDTO{
+		StartedAt: time.Now().Add(-10 * time.Minute),
+		Minutes:   60,
+	})
+
+	var invalidErr *custom_errors.InvalidTaskTimeLogError
+	assert.ErrorAs(t, err, &invalidErr)
+	timeLogRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// Тесты для TaskTimeLogController.Update и Delete
+
+func TestTaskTimeLogController_Update_AdminEditsOtherUsersEntry(t *testing.T) {
+	controller, timeLogRepo, taskRepo := newTimeLogController()
+	startedAt := time.Now().Add(-5 * time.Hour)
+	endedAt := startedAt.Add(30 * time.Minute)
+	entry := &models.TaskTimeLog{ID: 7, TaskID: 1, UserID: uuid.New(), StartedAt: startedAt, EndedAt: &endedAt, Minutes: 30}
+	minutes := 120
+
+	taskRepo.On("GetByID", 1).Return(createTestTask(), nil)
+	timeLogRepo.On("GetByID", 7).Return(entry, nil)
+	timeLogRepo.On("Update", entry).Return(nil)
+
+	log, err := controller.Update(1, 7, &dto.Actor{UserID: uuid.New(), Permissions: []string{dto.PermissionManageAllTasks}},
+		&dto.UpdateTaskTimeLogDTO{Minutes: &minutes})
+
+	require.NoError(t, err)
+	assert.Equal(t, 120, log.Minutes)
+	assert.Equal(t, startedAt.Add(2*time.Hour), *log.EndedAt)
+}
+
+func TestTaskTimeLogController_Update_OtherUserForbidden(t *testing.T) {
+	controller, timeLogRepo, taskRepo := newTimeLogController()
+	endedAt := time.Now()
+	entry := &models.TaskTimeLog{ID: 7, TaskID: 1, UserID: uuid.New(), StartedAt: endedAt.Add(-time.Hour), EndedAt: &endedAt, Minutes: 60}
+	description := "mine now"
+
+	taskRepo.On("GetByID", 1).Return(createTestTask(), nil)
+	timeLogRepo.On("GetByID", 7).Return(entry, nil)
+
+	_, err := controller.Update(1, 7, &dto.Actor{UserID: uuid.New()}, &dto.UpdateTaskTimeLogDTO{Description: &description})
+
+	var accessErr *custom_errors.TaskTimeLogAccessDeniedError
+	assert.ErrorAs(t, err, &accessErr)
+	timeLogRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTaskTimeLogController_Update_RunningTimerDuration(t *testing.T) {
+	controller, timeLogRepo, taskRepo := newTimeLogController()
+	userID := uuid.New()
+	minutes := 10
+
+	taskRepo.On("GetByID", 1).Return(createTestTask(), nil)
+	timeLogRepo.On("GetByID", 7).Return(&models.TaskTimeLog{ID: 7, TaskID: 1, UserID: userID, StartedAt: time.Now()}, nil)
+
+	_, err := controller.Update(1, 7, &dto.Actor{UserID: userID}, &dto.UpdateTaskTimeLogDTO{Minutes: &minutes})
+
+	var invalidErr *custom_errors.InvalidTaskTimeLogError
+	assert.ErrorAs(t, err, &invalidErr)
+}
+
+func TestTaskTimeLogController_Delete_WrongTask(t *testing.T) {
+	controller, timeLogRepo, taskRepo := newTimeLogController()
+	userID := uuid.New()
+
+	taskRepo.On("GetByID", 1).Return(createTestTask(), nil)
+	timeLogRepo.On("GetByID", 7).Return(&models.TaskTimeLog{ID: 7, TaskID: 2, UserID: userID}, nil)
+
+	err := controller.Delete(1, 7, &dto.Actor{UserID: userID})
+
+	var notFoundErr *custom_errors.TaskTimeLogNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+	timeLogRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+// Тесты для TaskTimeLogController.GetTimeSpent
+
+func TestTaskTimeLogController_GetTimeSpent_InvalidRange(t *testing.T) {
+	controller, timeLogRepo, _ := newTimeLogController()
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	_, err := controller.GetTimeSpent(&dto.Actor{UserID: uuid.New()}, &dto.TimeSpentQuery{GroupBy: dto.TimeSpentByUser, From: &from, To: &to})
+
+	var invalidErr *custom_errors.InvalidTaskTimeLogError
+	assert.ErrorAs(t, err, &invalidErr)
+	timeLogRepo.AssertNotCalled(t, "GetTimeSpent", mock.Anything, mock.Anything)
+}
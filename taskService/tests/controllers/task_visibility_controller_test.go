@@ -0,0 +1,238 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type visibilityMocks struct {
+	taskRepo   *MockTaskRepository
+	statusRepo *MockTaskStatusRepository
+	userClient *MockUserClient
+	chatClient *MockChatClient
+	chats      *MockChatMembershipService
+}
+
+func newVisibilityController() (*controllers.TaskController, *visibilityMocks) {
+	m := &visibilityMocks{
+		taskRepo:   new(MockTaskRepository),
+		statusRepo: new(MockTaskStatusRepository),
+		userClient: new(MockUserClient),
+		chatClient: new(MockChatClient),
+		chats:      new(MockChatMembershipService),
+	}
+	controller := controllers.NewTaskControllerWithClients(
+		m.taskRepo,
+		m.statusRepo,
+		new(MockTaskFileRepository),
+		new(MockTaskWorkflowRepository),
+		newTaskEventRepoStub(),
+		new(MockNotificationService),
+		m.userClient,
+		m.chatClient,
+		new(MockFileClient),
+		m.chats,
+	)
+	return controller, m
+}
+
+// Тесты для видимости отдельной задачи
+
+func TestTaskController_GetByID_HiddenFromNonMember(t *testing.T) {
+	controller, m := newVisibilityController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.chats.On("GetUserChatIDs", actor.UserID).Return([]uuid.UUID{uuid.New()}, nil)
+
+	_, err := controller.GetByID(task.ID, actor)
+
+	var taskErr *custom_errors.TaskNotFoundError
+	assert.True(t, errors.As(err, &taskErr))
+}
+
+func TestTaskController_GetByID_VisibleToChatMember(t *testing.T) {
+	controller, m := newVisibilityController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.chats.On("GetUserChatIDs", actor.UserID).Return([]uuid.UUID{uuid.New(), task.ChatID}, nil)
+
+	result, err := controller.GetByID(task.ID, actor)
+
+	require.NoError(t, err)
+	assert.Equal(t, task, result.Task)
+}
+
+func TestTaskController_GetByID_ParticipantsSkipChatLookup(t *testing.T) {
+	task := createTestTask()
+	assigneeID := uuid.New()
+	task.Assignees = []models.TaskAssignee{{TaskID: task.ID, UserID: assigneeID}}
+
+	for _, actor := range []*dto.Actor{
+		{UserID: task.CreatorID},
+		{UserID: task.ExecutorID},
+		{UserID: assigneeID},
+		{UserID: uuid.New(), Permissions: []string{dto.PermissionManageAllTasks}},
+	} {
+		controller, m := newVisibilityController()
+		m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+
+		_, err := controller.GetByID(task.ID, actor)
+
+		require.NoError(t, err)
+		m.chats.AssertNotCalled(t, "GetUserChatIDs", mock.Anything)
+	}
+}
+
+func TestTaskController_GetByID_TaskWithoutChat(t *testing.T) {
+	controller, m := newVisibilityController()
+	task := createTestTask()
+	task.ChatID = uuid.Nil
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+
+	_, err := controller.GetByID(task.ID, &dto.Actor{UserID: uuid.New()})
+
+	var taskErr *custom_errors.TaskNotFoundError
+	assert.True(t, errors.As(err, &taskErr))
+	m.chats.AssertNotCalled(t, "GetUserChatIDs", mock.Anything)
+}
+
+func TestTaskController_GetByID_ChatServiceError(t *testing.T) {
+	controller, m := newVisibilityController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.chats.On("GetUserChatIDs", actor.UserID).Return(nil, errors.New("chat service down"))
+
+	_, err := controller.GetByID(task.ID, actor)
+
+	var chatsErr *custom_errors.GetUserChatsHTTPError
+	assert.True(t, errors.As(err, &chatsErr))
+}
+
+func TestTaskController_Update_HiddenTaskIsNotFound(t *testing.T) {
+	controller, m := newVisibilityController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: uuid.New()}
+	title := "New title"
+
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.chats.On("GetUserChatIDs", actor.UserID).Return([]uuid.UUID{}, nil)
+
+	_, err := controller.Update(task.ID, actor, &dto.UpdateTaskDTO{Title: &title})
+
+	var taskErr *custom_errors.TaskNotFoundError
+	assert.True(t, errors.As(err, &taskErr))
+	m.taskRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+// Тесты для видимости списков задач
+
+func TestTaskController_QueryTasks_RestrictedToViewerChats(t *testing.T) {
+	controller, m := newVisibilityController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	chatIDs := []uuid.UUID{uuid.New()}
+	query := &dto.TaskQuery{Limit: 20}
+
+	m.chats.On("GetUserChatIDs", actor.UserID).Return(chatIDs, nil)
+	m.taskRepo.On("QueryTasks", query, &dto.TaskVisibility{UserID: actor.UserID, ChatIDs: chatIDs}).
+		Return([]dto.TaskToList{{ID: 1}}, int64(1), nil)
+
+	result, err := controller.QueryTasks(actor, query)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total)
+	m.taskRepo.AssertExpectations(t)
+}
+
+func TestTaskController_GetChatTasks_AdminSeesAll(t *testing.T) {
+	controller, m := newVisibilityController()
+	actor := &dto.Actor{UserID: uuid.New(), Permissions: []string{dto.PermissionManageAllTasks}}
+	chatID := uuid.NewString()
+	tasks := &[]dto.TaskToList{{ID: 1}}
+
+	m.taskRepo.On("GetChatTasks", chatID, 20, 0, (*dto.TaskVisibility)(nil)).Return(tasks, nil)
+
+	result, err := controller.GetChatTasks(chatID, actor, 20, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, tasks, result)
+	m.chats.AssertNotCalled(t, "GetUserChatIDs", mock.Anything)
+}
+
+func TestTaskController_GetUserTasks_ChatServiceError(t *testing.T) {
+	controller, m := newVisibilityController()
+	actor := &dto.Actor{UserID: uuid.New()}
+
+	m.chats.On("GetUserChatIDs", actor.UserID).Return(nil, errors.New("chat service down"))
+
+	_, err := controller.GetUserTasks(actor.UserID.String(), actor, nil, 20, 0)
+
+	var chatsErr *custom_errors.GetUserChatsHTTPError
+	assert.True(t, errors.As(err, &chatsErr))
+	m.taskRepo.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Тесты для проверок при создании задачи
+
+func TestTaskController_Create_RequiresChatMembership(t *testing.T) {
+	controller, m := newVisibilityController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	chatID := uuid.New()
+	m.statusRepo.On("GetByName", "created").Return(createTestTaskStatus(), nil)
+	m.userClient.On("GetUserByID", &actor.UserID).Return(createTestUserResponse(), nil)
+	m.chatClient.On("GetChatByID", chatID.String()).Return(createTestChat(), nil)
+	m.chats.On("GetUserChatIDs", actor.UserID).Return([]uuid.UUID{uuid.New()}, nil)
+
+	_, err := controller.Create(actor, &dto.CreateTaskDTO{Title: "Task", ChatID: chatID})
+
+	var membershipErr *custom_errors.ChatMembershipRequiredError
+	require.True(t, errors.As(err, &membershipErr))
+	m.taskRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskController_Update_MoveToChatRequiresMembership(t *testing.T) {
+	controller, m := newVisibilityController()
+	task := createTestTask()
+	actor := &dto.Actor{UserID: task.CreatorID}
+	chatID := uuid.New()
+	m.taskRepo.On("GetByID", task.ID).Return(task, nil)
+	m.chatClient.On("GetChatByID", chatID.String()).Return(createTestChat(), nil)
+	m.chats.On("GetUserChatIDs", actor.UserID).Return([]uuid.UUID{task.ChatID}, nil)
+
+	_, err := controller.Update(task.ID, actor, &dto.UpdateTaskDTO{ChatID: &chatID})
+
+	var membershipErr *custom_errors.ChatMembershipRequiredError
+	require.True(t, errors.As(err, &membershipErr))
+	m.taskRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTaskController_Create_HiddenParentIsNotFound(t *testing.T) {
+	controller, m := newVisibilityController()
+	actor := &dto.Actor{UserID: uuid.New()}
+	parent := createTestTask()
+	m.statusRepo.On("GetByName", "created").Return(createTestTaskStatus(), nil)
+	m.userClient.On("GetUserByID", &actor.UserID).Return(createTestUserResponse(), nil)
+	m.taskRepo.On("GetByID", parent.ID).Return(parent, nil)
+	m.chats.On("GetUserChatIDs", actor.UserID).Return([]uuid.UUID{uuid.New()}, nil)
+
+	_, err := controller.Create(actor, &dto.CreateTaskDTO{Title: "Task", ParentTaskID: &parent.ID})
+
+	var parentErr *custom_errors.ParentTaskNotFoundError
+	require.True(t, errors.As(err, &parentErr))
+	m.taskRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
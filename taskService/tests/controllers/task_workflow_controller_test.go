@@ -0,0 +1,395 @@
+package controllers
+
+import (
+	cuc "common/contracts/user-contracts"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+type workflowMocks struct {
+	taskRepo     *MockTaskRepository
+	statusRepo   *MockTaskStatusRepository
+	workflowRepo *MockTaskWorkflowRepository
+	notification *MockNotificationService
+	userClient   *MockUserClient
+}
+
+func newWorkflowAwareController() (*controllers.TaskController, *workflowMocks) {
+	m := &workflowMocks{
+		taskRepo:     new(MockTaskRepository),
+		statusRepo:   new(MockTaskStatusRepository),
+		workflowRepo: new(MockTaskWorkflowRepository),
+		notification: new(MockNotificationService),
+		userClient:   new(MockUserClient),
+	}
+	// Без пользователей в ответе уведомления о смене статуса не отправляются
+	m.userClient.On("GetUsersByIDs", mock.Anything).Return(&cuc.UsersResponse{}, nil).Maybe()
+	controller := controllers.NewTaskControllerWithClients(
+		m.taskRepo,
+		m.statusRepo,
+		new(MockTaskFileRepository),
+		m.workflowRepo,
+		newTaskEventRepoStub(),
+		m.notification,
+		m.userClient,
+		new(MockChatClient),
+		new(MockFileClient),
+		newChatMembershipStub(),
+	)
+	return controller, m
+}
+
+// createTestWorkflow - workflow "created(1) -> in_progress(2) -> done(3)" с переходами для разных ролей
+func createTestWorkflow() *models.TaskWorkflow {
+	return &models.TaskWorkflow{
+		ID:   7,
+		Name: "dev",
+		Statuses: []models.TaskWorkflowStatus{
+			{WorkflowID: 7, StatusID: 1, Position: 1, Status: createTestTaskStatusWithID(1, "created")},
+			{WorkflowID: 7, StatusID: 2, Position: 2, Status: createTestTaskStatusWithID(2, "in_progress")},
+			{WorkflowID: 7, StatusID: 3, Position: 3, Status: createTestTaskStatusWithID(3, "done")},
+		},
+		Transitions: []models.TaskWorkflowTransition{
+			{WorkflowID: 7, FromStatusID: 1, ToStatusID: 2, AllowedRole: models.TransitionRoleExecutor},
+			{WorkflowID: 7, FromStatusID: 2, ToStatusID: 3, AllowedRole: models.TransitionRoleCreator},
+			{WorkflowID: 7, FromStatusID: 2, ToStatusID: 1, AllowedRole: models.TransitionRoleAny},
+		},
+	}
+}
+
+func workflowTask(statusID int) *models.Task {
+	workflowID := 7
+	return &models.Task{
+		ID:         1,
+		StatusID:   statusID,
+		CreatorID:  uuid.New(),
+		ExecutorID: uuid.New(),
+		ChatID:     testChatID,
+		WorkflowID: &workflowID,
+	}
+}
+
+func TestTaskController_UpdateStatus_AllowedTransition(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	task := workflowTask(1)
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 2).Return(createTestTaskStatusWithID(2, "in_progress"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+	m.taskRepo.On("UpdateStatus", 1, 2).Return(nil)
+
+	err := controller.UpdateStatus(1, 2, &dto.Actor{UserID: task.ExecutorID})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertExpectations(t)
+}
+
+func TestTaskController_UpdateStatus_IllegalTransition(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	task := workflowTask(1)
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 3).Return(createTestTaskStatusWithID(3, "done"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+
+	err := controller.UpdateStatus(1, 3, &dto.Actor{
+		UserID:      task.CreatorID,
+		Permissions: []string{dto.PermissionManageAllTasks},
+	})
+
+	var transitionErr *custom_errors.IllegalStatusTransitionError
+	require.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, 1, transitionErr.FromStatusID)
+	assert.Equal(t, 3, transitionErr.ToStatusID)
+	m.taskRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func TestTaskController_UpdateStatus_RoleForbidden(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	task := workflowTask(2)
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 3).Return(createTestTaskStatusWithID(3, "done"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+
+	err := controller.UpdateStatus(1, 3, &dto.Actor{UserID: task.ExecutorID})
+
+	var forbiddenErr *custom_errors.StatusTransitionForbiddenError
+	require.True(t, errors.As(err, &forbiddenErr))
+	assert.Equal(t, models.TransitionRoleCreator, forbiddenErr.AllowedRole)
+	m.taskRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func TestTaskController_UpdateStatus_ManageAllTasksBypassesRole(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	task := workflowTask(2)
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 3).Return(createTestTaskStatusWithID(3, "done"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+	m.taskRepo.On("GetOpenBlockerIDs", 1).Return([]int{}, nil)
+	m.taskRepo.On("UpdateStatus", 1, 3).Return(nil)
+
+	err := controller.UpdateStatus(1, 3, &dto.Actor{
+		UserID:      uuid.New(),
+		Permissions: []string{dto.PermissionManageAllTasks},
+	})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertExpectations(t)
+}
+
+func TestTaskController_UpdateStatus_AnyRoleAllowsOnlyParticipants(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	task := workflowTask(2)
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 1).Return(createTestTaskStatusWithID(1, "created"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+	m.taskRepo.On("UpdateStatus", 1, 1).Return(nil)
+
+	err := controller.UpdateStatus(1, 1, &dto.Actor{UserID: uuid.New()})
+	var forbiddenErr *custom_errors.StatusTransitionForbiddenError
+	require.True(t, errors.As(err, &forbiddenErr))
+
+	err = controller.UpdateStatus(1, 1, &dto.Actor{UserID: task.CreatorID})
+	require.NoError(t, err)
+}
+
+func TestTaskController_UpdateStatus_OpenBlockersPreventClosing(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	task := workflowTask(2)
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 3).Return(createTestTaskStatusWithID(3, "done"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+	m.taskRepo.On("GetOpenBlockerIDs", 1).Return([]int{4, 9}, nil)
+
+	err := controller.UpdateStatus(1, 3, &dto.Actor{UserID: task.CreatorID})
+
+	var blockedErr *custom_errors.TaskBlockedError
+	require.True(t, errors.As(err, &blockedErr))
+	assert.Equal(t, []int{4, 9}, blockedErr.BlockerIDs)
+	m.taskRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func TestTaskController_UpdateStatus_BlockersIgnoredForOpenStatus(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	task := workflowTask(1)
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 2).Return(createTestTaskStatusWithID(2, "in_progress"), nil)
+	m.workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+	m.taskRepo.On("UpdateStatus", 1, 2).Return(nil)
+
+	err := controller.UpdateStatus(1, 2, &dto.Actor{UserID: task.ExecutorID})
+
+	require.NoError(t, err)
+	m.taskRepo.AssertNotCalled(t, "GetOpenBlockerIDs", mock.Anything)
+}
+
+func TestTaskController_UpdateStatus_FallsBackToDefaultWorkflow(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	task := workflowTask(1)
+	task.WorkflowID = nil
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 3).Return(createTestTaskStatusWithID(3, "done"), nil)
+	m.workflowRepo.On("GetDefault").Return(createTestWorkflow(), nil)
+
+	err := controller.UpdateStatus(1, 3, &dto.Actor{UserID: task.CreatorID})
+
+	var transitionErr *custom_errors.IllegalStatusTransitionError
+	assert.True(t, errors.As(err, &transitionErr))
+}
+
+func TestTaskController_UpdateStatus_WithoutWorkflow(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	task := workflowTask(1)
+	task.WorkflowID = nil
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 3).Return(createTestTaskStatusWithID(3, "done"), nil)
+	m.workflowRepo.On("GetDefault").Return(nil, gorm.ErrRecordNotFound)
+	m.taskRepo.On("UpdateStatus", 1, 3).Return(nil)
+
+	err := controller.UpdateStatus(1, 3, &dto.Actor{UserID: uuid.New()})
+	var accessErr *custom_errors.TaskAccessDeniedError
+	require.True(t, errors.As(err, &accessErr))
+
+	err = controller.UpdateStatus(1, 3, &dto.Actor{UserID: task.ExecutorID})
+	require.NoError(t, err)
+}
+
+func TestTaskController_UpdateStatus_StatusNotFound(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	task := workflowTask(1)
+
+	m.taskRepo.On("GetByID", 1).Return(task, nil)
+	m.statusRepo.On("GetByID", 999).Return(nil, gorm.ErrRecordNotFound)
+
+	err := controller.UpdateStatus(1, 999, &dto.Actor{UserID: task.CreatorID})
+
+	var statusNotFoundErr *custom_errors.TaskStatusNotFoundError
+	assert.True(t, errors.As(err, &statusNotFoundErr))
+	m.taskRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func TestTaskController_UpdateStatus_TaskNotFound(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+
+	m.taskRepo.On("GetByID", 1).Return(nil, gorm.ErrRecordNotFound)
+
+	err := controller.UpdateStatus(1, 2, &dto.Actor{UserID: uuid.New()})
+
+	var taskNotFoundErr *custom_errors.TaskNotFoundError
+	assert.True(t, errors.As(err, &taskNotFoundErr))
+}
+
+func TestTaskController_Create_UsesFirstWorkflowStatus(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	userClient := controller.UserClient.(*MockUserClient)
+	workflowID := 7
+	creatorID := uuid.New()
+
+	m.workflowRepo.On("GetByID", workflowID).Return(createTestWorkflow(), nil)
+	userClient.On("GetUserByID", &creatorID).Return(createTestUserResponse(), nil)
+	m.taskRepo.On("Create", mock.AnythingOfType("*models.Task")).Return(nil)
+
+	task, err := controller.Create(&dto.Actor{UserID: creatorID}, &dto.CreateTaskDTO{
+		Title:      "Task",
+		WorkflowID: &workflowID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, task.StatusID)
+	assert.Equal(t, &workflowID, task.WorkflowID)
+	m.statusRepo.AssertNotCalled(t, "GetByName", mock.Anything)
+}
+
+func TestTaskController_Create_WorkflowNotFound(t *testing.T) {
+	controller, m := newWorkflowAwareController()
+	workflowID := 99
+
+	m.workflowRepo.On("GetByID", workflowID).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := controller.Create(&dto.Actor{UserID: uuid.New()}, &dto.CreateTaskDTO{Title: "Task", WorkflowID: &workflowID})
+
+	var workflowErr *custom_errors.WorkflowNotFoundError
+	assert.True(t, errors.As(err, &workflowErr))
+	m.taskRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// Тесты для TaskWorkflowController
+
+func TestTaskWorkflowController_Create_Success(t *testing.T) {
+	workflowRepo := new(MockTaskWorkflowRepository)
+	statusRepo := new(MockTaskStatusRepository)
+	controller := controllers.NewTaskWorkflowController(workflowRepo, statusRepo)
+
+	statusRepo.On("GetByID", 1).Return(createTestTaskStatusWithID(1, "created"), nil)
+	statusRepo.On("GetByID", 2).Return(createTestTaskStatusWithID(2, "in_progress"), nil)
+	workflowRepo.On("GetByName", "dev").Return(nil, gorm.ErrRecordNotFound)
+	workflowRepo.On("Create", mock.MatchedBy(func(w *models.TaskWorkflow) bool {
+		w.ID = 7
+		return len(w.Statuses) == 2 && w.Statuses[1].Position == 2 && len(w.Transitions) == 1
+	})).Return(nil)
+	workflowRepo.On("GetByID", 7).Return(createTestWorkflow(), nil)
+
+	workflow, err := controller.Create(&dto.SaveWorkflowDTO{
+		Name:      "dev",
+		StatusIDs: []int{1, 2},
+		Transitions: []dto.WorkflowTransitionDTO{
+			{FromStatusID: 1, ToStatusID: 2, AllowedRole: models.TransitionRoleExecutor},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, workflow.ID)
+	workflowRepo.AssertExpectations(t)
+}
+
+func TestTaskWorkflowController_Create_AlreadyExists(t *testing.T) {
+	workflowRepo := new(MockTaskWorkflowRepository)
+	controller := controllers.NewTaskWorkflowController(workflowRepo, new(MockTaskStatusRepository))
+
+	workflowRepo.On("GetByName", "dev").Return(createTestWorkflow(), nil)
+
+	_, err := controller.Create(&dto.SaveWorkflowDTO{Name: "dev", StatusIDs: []int{1}})
+
+	assert.ErrorIs(t, err, custom_errors.ErrWorkflowAlreadyExists)
+	workflowRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTaskWorkflowController_Create_InvalidDefinition(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusIDs   []int
+		transitions []dto.WorkflowTransitionDTO
+	}{
+		{name: "duplicate status", statusIDs: []int{1, 1}},
+		{name: "status outside workflow", statusIDs: []int{1, 2}, transitions: []dto.WorkflowTransitionDTO{
+			{FromStatusID: 1, ToStatusID: 3, AllowedRole: models.TransitionRoleAny},
+		}},
+		{name: "self transition", statusIDs: []int{1, 2}, transitions: []dto.WorkflowTransitionDTO{
+			{FromStatusID: 2, ToStatusID: 2, AllowedRole: models.TransitionRoleAny},
+		}},
+		{name: "duplicate transition", statusIDs: []int{1, 2}, transitions: []dto.WorkflowTransitionDTO{
+			{FromStatusID: 1, ToStatusID: 2, AllowedRole: models.TransitionRoleAny},
+			{FromStatusID: 1, ToStatusID: 2, AllowedRole: models.TransitionRoleCreator},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowRepo := new(MockTaskWorkflowRepository)
+			statusRepo := new(MockTaskStatusRepository)
+			controller := controllers.NewTaskWorkflowController(workflowRepo, statusRepo)
+
+			statusRepo.On("GetByID", mock.AnythingOfType("int")).Return(createTestTaskStatus(), nil)
+			workflowRepo.On("GetByName", "dev").Return(nil, gorm.ErrRecordNotFound)
+
+			_, err := controller.Create(&dto.SaveWorkflowDTO{Name: "dev", StatusIDs: tt.statusIDs, Transitions: tt.transitions})
+
+			var invalidErr *custom_errors.InvalidWorkflowError
+			assert.True(t, errors.As(err, &invalidErr))
+			workflowRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestTaskWorkflowController_Update_NotFound(t *testing.T) {
+	workflowRepo := new(MockTaskWorkflowRepository)
+	statusRepo := new(MockTaskStatusRepository)
+	controller := controllers.NewTaskWorkflowController(workflowRepo, statusRepo)
+
+	statusRepo.On("GetByID", 1).Return(createTestTaskStatus(), nil)
+	workflowRepo.On("GetByName", "dev").Return(nil, gorm.ErrRecordNotFound)
+	workflowRepo.On("Replace", mock.AnythingOfType("*models.TaskWorkflow")).Return(gorm.ErrRecordNotFound)
+
+	_, err := controller.Update(42, &dto.SaveWorkflowDTO{Name: "dev", StatusIDs: []int{1}})
+
+	var notFoundErr *custom_errors.WorkflowNotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+}
+
+func TestTaskWorkflowController_Update_NameTakenByAnotherWorkflow(t *testing.T) {
+	workflowRepo := new(MockTaskWorkflowRepository)
+	controller := controllers.NewTaskWorkflowController(workflowRepo, new(MockTaskStatusRepository))
+
+	workflowRepo.On("GetByName", "dev").Return(createTestWorkflow(), nil)
+
+	_, err := controller.Update(8, &dto.SaveWorkflowDTO{Name: "dev", StatusIDs: []int{1}})
+
+	assert.ErrorIs(t, err, custom_errors.ErrWorkflowAlreadyExists)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+)
+
+func newBoardRouter(controller *MockTaskController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskHandler(controller)
+
+	router := gin.New()
+	router.GET("/tasks/board", handler.GetBoard)
+	router.POST("/tasks/:task_id/move", handler.MoveTask)
+	return router
+}
+
+func TestTaskHandler_GetBoard_ByChat(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newBoardRouter(mockController)
+	chatID := uuid.New()
+
+	mockController.On("GetBoard", mock.AnythingOfType("*dto.Actor"), &dto.TaskBoardQuery{ChatID: &chatID, Limit: 20}).Return(&dto.TaskBoard{
+		Columns: []dto.TaskBoardColumn{{
+			StatusID: 1,
+			Status:   "created",
+			Total:    3,
+			Tasks:    []dto.TaskBoardCard{{TaskToList: dto.TaskToList{ID: 5, Title: "Card"}, StatusID: 1, Rank: "i"}},
+		}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/tasks/board?chat_id="+chatID.String()+"&limit=20"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string][]map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response["columns"], 1)
+	assert.Equal(t, float64(3), response["columns"][0]["total"])
+	card := response["columns"][0]["tasks"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "i", card["rank"])
+	assert.Equal(t, float64(5), card["id"])
+}
+
+func TestTaskHandler_GetBoard_InvalidQuery(t *testing.T) {
+	id := uuid.New().String()
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "no scope", query: ""},
+		{name: "both scopes", query: "?chat_id=" + id + "&executor_id=" + id},
+		{name: "invalid chat", query: "?chat_id=abc"},
+		{name: "limit too big", query: "?chat_id=" + id + "&limit=500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			router := newBoardRouter(mockController)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newViewerRequest("/tasks/board"+tt.query))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockController.AssertNotCalled(t, "GetBoard", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTaskHandler_MoveTask(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		err          error
+		expectedCode int
+	}{
+		{name: "success", body: `{"status_id":2,"after_task_id":3}`, expectedCode: http.StatusNoContent},
+		{name: "missing status", body: `{"after_task_id":3}`, expectedCode: http.StatusBadRequest},
+		{name: "task not found", body: `{"status_id":2,"after_task_id":3}`, err: custom_errors.NewTaskNotFoundError(1), expectedCode: http.StatusNotFound},
+		{name: "status not found", body: `{"status_id":2,"after_task_id":3}`, err: custom_errors.NewTaskStatusNotFoundError("2"), expectedCode: http.StatusBadRequest},
+		{name: "access denied", body: `{"status_id":2,"after_task_id":3}`, err: custom_errors.NewTaskAccessDeniedError(1, "u"), expectedCode: http.StatusForbidden},
+		{name: "illegal transition", body: `{"status_id":2,"after_task_id":3}`, err: custom_errors.NewIllegalStatusTransitionError(1, 1, 2), expectedCode: http.StatusConflict},
+		{name: "stale board", body: `{"status_id":2,"after_task_id":3}`, err: custom_errors.NewInvalidTaskMoveError(1, "reload"), expectedCode: http.StatusConflict},
+		{name: "internal", body: `{"status_id":2,"after_task_id":3}`, err: errors.New("db down"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			router := newBoardRouter(mockController)
+			userID := uuid.New()
+			afterTaskID := 3
+			mockController.On("MoveTask", 1, &dto.Actor{UserID: userID}, &dto.MoveTaskDTO{StatusID: 2, AfterTaskID: &afterTaskID}).
+				Return(tt.err).Maybe()
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("POST", "/tasks/1/move", tt.body, userID))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+)
+
+// MockTaskBulkController - мок для TaskBulkController
+type MockTaskBulkController struct {
+	mock.Mock
+}
+
+func (m *MockTaskBulkController) Apply(actor *dto.Actor, bulkDTO *dto.TaskBulkDTO) (*dto.TaskBulkResult, error) {
+	args := m.Called(actor, bulkDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TaskBulkResult), args.Error(1)
+}
+
+func newBulkRouter(controller *MockTaskBulkController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskBulkHandler(controller)
+
+	router := gin.New()
+	router.POST("/tasks/bulk", handler.ApplyBulk)
+	return router
+}
+
+func TestTaskBulkHandler_ApplyBulk_Success(t *testing.T) {
+	mockController := new(MockTaskBulkController)
+	router := newBulkRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("Apply", &dto.Actor{UserID: userID}, &dto.TaskBulkDTO{TaskIDs: []int{1, 2}, Action: dto.TaskBulkDelete, Atomic: true}).
+		Return(&dto.TaskBulkResult{Atomic: true, Succeeded: 1, Failed: 1, Items: []dto.TaskBulkItemResult{
+			{TaskID: 1, Success: true},
+			{TaskID: 2, Error: "task with id 2 not found"},
+		}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("POST", "/tasks/bulk", `{"task_ids":[1,2],"action":"delete","atomic":true}`, userID))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var result dto.TaskBulkResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, "task with id 2 not found", result.Items[1].Error)
+}
+
+func TestTaskBulkHandler_ApplyBulk_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		err          error
+		expectedCode int
+	}{
+		{name: "unknown action", body: `{"task_ids":[1],"action":"archive"}`, expectedCode: http.StatusBadRequest},
+		{name: "no tasks", body: `{"task_ids":[],"action":"delete"}`, expectedCode: http.StatusBadRequest},
+		{name: "missing parameter", body: `{"task_ids":[1],"action":"reassign"}`,
+			err: custom_errors.NewInvalidTaskBulkError("reassign", "executor_id is required"), expectedCode: http.StatusBadRequest},
+		{name: "status not found", body: `{"task_ids":[1],"action":"change_status","status_id":9}`,
+			err: custom_errors.NewTaskStatusNotFoundError("9"), expectedCode: http.StatusNotFound},
+		{name: "user service down", body: `{"task_ids":[1],"action":"reassign","executor_id":"` + uuid.NewString() + `"}`,
+			err: custom_errors.NewGetUserHTTPError("u", "timeout"), expectedCode: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskBulkController)
+			router := newBulkRouter(mockController)
+			mockController.On("Apply", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("POST", "/tasks/bulk", tt.body, uuid.New()))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.err == nil {
+				mockController.AssertNotCalled(t, "Apply", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+// MockTaskChecklistController - мок для TaskChecklistControllerInterface
+type MockTaskChecklistController struct {
+	mock.Mock
+}
+
+func (m *MockTaskChecklistController) GetByTaskID(taskID int, actor *dto.Actor) ([]models.TaskChecklistItem, error) {
+	args := m.Called(taskID, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskChecklistController) AddItem(taskID int, actor *dto.Actor, itemDTO *dto.AddChecklistItemDTO) (*models.TaskChecklistItem, error) {
+	args := m.Called(taskID, actor, itemDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskChecklistController) UpdateItem(taskID, itemID int, actor *dto.Actor, updateDTO *dto.UpdateChecklistItemDTO) (*models.TaskChecklistItem, error) {
+	args := m.Called(taskID, itemID, actor, updateDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskChecklistItem), args.Error(1)
+}
+
+func (m *MockTaskChecklistController) RemoveItem(taskID, itemID int, actor *dto.Actor) error {
+	args := m.Called(taskID, itemID, actor)
+	return args.Error(0)
+}
+
+func newChecklistRouter(controller *MockTaskChecklistController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskChecklistHandler(controller)
+
+	router := gin.New()
+	router.GET("/tasks/:task_id/checklist", handler.GetByTaskID)
+	router.POST("/tasks/:task_id/checklist", handler.AddItem)
+	router.PATCH("/tasks/:task_id/checklist/:item_id", handler.UpdateItem)
+	router.DELETE("/tasks/:task_id/checklist/:item_id", handler.RemoveItem)
+	return router
+}
+
+func TestTaskChecklistHandler_GetByTaskID(t *testing.T) {
+	mockController := new(MockTaskChecklistController)
+	router := newChecklistRouter(mockController)
+
+	mockController.On("GetByTaskID", 1, mock.AnythingOfType("*dto.Actor")).Return([]models.TaskChecklistItem{{ID: 1, Title: "a"}, {ID: 2, Title: "b", Done: true}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/tasks/1/checklist"))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var items []models.TaskChecklistItem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &items))
+	assert.True(t, items[1].Done)
+}
+
+func TestTaskChecklistHandler_AddItem(t *testing.T) {
+	mockController := new(MockTaskChecklistController)
+	router := newChecklistRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("AddItem", 1, &dto.Actor{UserID: userID}, &dto.AddChecklistItemDTO{Title: "Ревью"}).
+		Return(&models.TaskChecklistItem{ID: 3, TaskID: 1, Title: "Ревью"}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("POST", "/tasks/1/checklist", `{"title":"Ревью"}`, userID))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestTaskChecklistHandler_UpdateItem(t *testing.T) {
+	mockController := new(MockTaskChecklistController)
+	router := newChecklistRouter(mockController)
+	userID := uuid.New()
+	done := true
+
+	mockController.On("UpdateItem", 1, 3, &dto.Actor{UserID: userID}, &dto.UpdateChecklistItemDTO{Done: &done}).
+		Return(&models.TaskChecklistItem{ID: 3, TaskID: 1, Done: true}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("PATCH", "/tasks/1/checklist/3", `{"done":true}`, userID))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskChecklistHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		body         string
+		err          error
+		expectedCode int
+	}{
+		{name: "empty title", method: "POST", url: "/tasks/1/checklist", body: `{"title":""}`, expectedCode: http.StatusBadRequest},
+		{name: "negative position", method: "PATCH", url: "/tasks/1/checklist/3", body: `{"position":-1}`, expectedCode: http.StatusBadRequest},
+		{name: "invalid item ID", method: "DELETE", url: "/tasks/1/checklist/x", expectedCode: http.StatusBadRequest},
+		{name: "item not found", method: "DELETE", url: "/tasks/1/checklist/3",
+			err: custom_errors.NewChecklistItemNotFoundError(1, 3), expectedCode: http.StatusNotFound},
+		{name: "access denied", method: "PATCH", url: "/tasks/1/checklist/3", body: `{"title":"x"}`,
+			err: custom_errors.NewTaskAccessDeniedError(1, "u"), expectedCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskChecklistController)
+			router := newChecklistRouter(mockController)
+			mockController.On("UpdateItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.err).Maybe()
+			mockController.On("RemoveItem", mock.Anything, mock.Anything, mock.Anything).Return(tt.err).Maybe()
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest(tt.method, tt.url, tt.body, uuid.New()))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+// MockTaskCommentController - мок для TaskCommentController
+type MockTaskCommentController struct {
+	mock.Mock
+}
+
+func (m *MockTaskCommentController) Create(taskID int, actor *dto.Actor, commentDTO *dto.CreateTaskCommentDTO) (*models.TaskComment, error) {
+	args := m.Called(taskID, actor, commentDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskComment), args.Error(1)
+}
+
+func (m *MockTaskCommentController) Update(taskID, commentID int, actor *dto.Actor, updateDTO *dto.UpdateTaskCommentDTO) (*models.TaskComment, error) {
+	args := m.Called(taskID, commentID, actor, updateDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskComment), args.Error(1)
+}
+
+func (m *MockTaskCommentController) Delete(taskID, commentID int, actor *dto.Actor) error {
+	args := m.Called(taskID, commentID, actor)
+	return args.Error(0)
+}
+
+func (m *MockTaskCommentController) GetByTaskID(taskID int, actor *dto.Actor, limit, offset int) ([]models.TaskComment, error) {
+	args := m.Called(taskID, actor, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskComment), args.Error(1)
+}
+
+func newCommentRouter(controller *MockTaskCommentController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskCommentHandler(controller)
+
+	router := gin.New()
+	router.POST("/tasks/:task_id/comments", handler.Create)
+	router.GET("/tasks/:task_id/comments", handler.GetByTaskID)
+	router.PATCH("/tasks/:task_id/comments/:comment_id", handler.Update)
+	router.DELETE("/tasks/:task_id/comments/:comment_id", handler.Delete)
+	return router
+}
+
+func newCommentRequest(method, url, body string, userID uuid.UUID) *http.Request {
+	req := httptest.NewRequest(method, url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", userID.String())
+	return req
+}
+
+func TestTaskCommentHandler_Create_Success(t *testing.T) {
+	mockController := new(MockTaskCommentController)
+	router := newCommentRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("Create", 1, &dto.Actor{UserID: userID}, &dto.CreateTaskCommentDTO{Body: "@bob look", FileIDs: []int{2}}).
+		Return(&models.TaskComment{ID: 7, TaskID: 1, AuthorID: userID, Body: "@bob look"}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("POST", "/tasks/1/comments", `{"body":"@bob look","file_ids":[2]}`, userID))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, float64(7), response["ID"])
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskCommentHandler_Create_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		body         string
+		err          error
+		expectedCode int
+	}{
+		{name: "invalid task id", url: "/tasks/abc/comments", body: `{"body":"x"}`, expectedCode: http.StatusBadRequest},
+		{name: "empty body", url: "/tasks/1/comments", body: `{"body":""}`, expectedCode: http.StatusBadRequest},
+		{name: "task not found", url: "/tasks/1/comments", body: `{"body":"x"}`, err: custom_errors.NewTaskNotFoundError(1), expectedCode: http.StatusNotFound},
+		{name: "file service error", url: "/tasks/1/comments", body: `{"body":"x"}`, err: custom_errors.NewGetFileHTTPError(2, "down"), expectedCode: http.StatusBadGateway},
+		{name: "user service error", url: "/tasks/1/comments", body: `{"body":"x"}`, err: custom_errors.NewGetUserHTTPError("@bob", "down"), expectedCode: http.StatusBadGateway},
+		{name: "internal", url: "/tasks/1/comments", body: `{"body":"x"}`, err: errors.New("db down"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskCommentController)
+			router := newCommentRouter(mockController)
+			if tt.err != nil {
+				mockController.On("Create", 1, mock.Anything, mock.Anything).Return(nil, tt.err)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("POST", tt.url, tt.body, uuid.New()))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskCommentHandler_Update(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		err          error
+		expectedCode int
+	}{
+		{name: "success", url: "/tasks/1/comments/5", expectedCode: http.StatusOK},
+		{name: "invalid comment id", url: "/tasks/1/comments/abc", expectedCode: http.StatusBadRequest},
+		{name: "not author", url: "/tasks/1/comments/5", err: custom_errors.NewTaskCommentAccessDeniedError(5, "u"), expectedCode: http.StatusForbidden},
+		{name: "comment not found", url: "/tasks/1/comments/5", err: custom_errors.NewTaskCommentNotFoundError(5), expectedCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskCommentController)
+			router := newCommentRouter(mockController)
+			if tt.err != nil {
+				mockController.On("Update", 1, 5, mock.Anything, &dto.UpdateTaskCommentDTO{Body: stringPtr("edited")}).Return(nil, tt.err)
+			} else {
+				mockController.On("Update", 1, 5, mock.Anything, &dto.UpdateTaskCommentDTO{Body: stringPtr("edited")}).
+					Return(&models.TaskComment{ID: 5, Body: "edited"}, nil)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("PATCH", tt.url, `{"body":"edited"}`, uuid.New()))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskCommentHandler_Delete(t *testing.T) {
+	mockController := new(MockTaskCommentController)
+	router := newCommentRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("Delete", 1, 5, &dto.Actor{UserID: userID}).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("DELETE", "/tasks/1/comments/5", "", userID))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskCommentHandler_Delete_MissingUser(t *testing.T) {
+	mockController := new(MockTaskCommentController)
+	router := newCommentRouter(mockController)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/1/comments/5", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskCommentHandler_GetByTaskID(t *testing.T) {
+	mockController := new(MockTaskCommentController)
+	router := newCommentRouter(mockController)
+
+	mockController.On("GetByTaskID", 1, mock.AnythingOfType("*dto.Actor"), 10, 0).Return([]models.TaskComment{{ID: 1, Body: "first"}, {ID: 2, Body: "second"}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/tasks/1/comments?limit=10"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+)
+
+// MockTaskDependencyController - мок для TaskDependencyController
+type MockTaskDependencyController struct {
+	mock.Mock
+}
+
+func (m *MockTaskDependencyController) GetTree(taskID int, actor *dto.Actor) (*dto.TaskTreeNode, error) {
+	args := m.Called(taskID, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TaskTreeNode), args.Error(1)
+}
+
+func (m *MockTaskDependencyController) AddDependency(blockedTaskID int, actor *dto.Actor, dependencyDTO *dto.AddTaskDependencyDTO) error {
+	args := m.Called(blockedTaskID, actor, dependencyDTO)
+	return args.Error(0)
+}
+
+func (m *MockTaskDependencyController) RemoveDependency(blockedTaskID, blockerTaskID int, actor *dto.Actor) error {
+	args := m.Called(blockedTaskID, blockerTaskID, actor)
+	return args.Error(0)
+}
+
+func (m *MockTaskDependencyController) GetDependencyGraph(taskID int, actor *dto.Actor) (*dto.TaskDependencyGraph, error) {
+	args := m.Called(taskID, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TaskDependencyGraph), args.Error(1)
+}
+
+func newDependencyRouter(controller *MockTaskDependencyController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskDependencyHandler(controller)
+
+	router := gin.New()
+	router.GET("/tasks/:task_id/tree", handler.GetTree)
+	router.GET("/tasks/:task_id/dependencies", handler.GetDependencyGraph)
+	router.POST("/tasks/:task_id/dependencies", handler.AddDependency)
+	router.DELETE("/tasks/:task_id/dependencies/:blocker_task_id", handler.RemoveDependency)
+	return router
+}
+
+func TestTaskDependencyHandler_GetTree(t *testing.T) {
+	mockController := new(MockTaskDependencyController)
+	router := newDependencyRouter(mockController)
+
+	mockController.On("GetTree", 1, mock.AnythingOfType("*dto.Actor")).Return(&dto.TaskTreeNode{
+		ID:       1,
+		Progress: 50,
+		Subtasks: []*dto.TaskTreeNode{{ID: 2, Closed: true, Progress: 100, Subtasks: []*dto.TaskTreeNode{}}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/tasks/1/tree"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, float64(50), response["progress"])
+	assert.Len(t, response["subtasks"], 1)
+}
+
+func TestTaskDependencyHandler_GetTree_NotFound(t *testing.T) {
+	mockController := new(MockTaskDependencyController)
+	router := newDependencyRouter(mockController)
+
+	mockController.On("GetTree", 1, mock.AnythingOfType("*dto.Actor")).Return(nil, custom_errors.NewTaskNotFoundError(1))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/tasks/1/tree"))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTaskDependencyHandler_AddDependency(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		err          error
+		expectedCode int
+	}{
+		{name: "success", body: `{"blocker_task_id":2}`, expectedCode: http.StatusNoContent},
+		{name: "missing blocker", body: `{}`, expectedCode: http.StatusBadRequest},
+		{name: "cycle", body: `{"blocker_task_id":2}`, err: custom_errors.NewTaskDependencyCycleError(2, 1), expectedCode: http.StatusConflict},
+		{name: "access denied", body: `{"blocker_task_id":2}`, err: custom_errors.NewTaskAccessDeniedError(1, "u"), expectedCode: http.StatusForbidden},
+		{name: "blocker not found", body: `{"blocker_task_id":2}`, err: custom_errors.NewTaskNotFoundError(2), expectedCode: http.StatusNotFound},
+		{name: "internal", body: `{"blocker_task_id":2}`, err: errors.New("db down"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskDependencyController)
+			router := newDependencyRouter(mockController)
+			userID := uuid.New()
+			mockController.On("AddDependency", 1, &dto.Actor{UserID: userID}, &dto.AddTaskDependencyDTO{BlockerTaskID: 2}).
+				Return(tt.err).Maybe()
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("POST", "/tasks/1/dependencies", tt.body, userID))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskDependencyHandler_RemoveDependency(t *testing.T) {
+	mockController := new(MockTaskDependencyController)
+	router := newDependencyRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("RemoveDependency", 1, 2, &dto.Actor{UserID: userID}).
+		Return(custom_errors.NewTaskDependencyNotFoundError(2, 1))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("DELETE", "/tasks/1/dependencies/2", "", userID))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskDependencyHandler_GetDependencyGraph(t *testing.T) {
+	mockController := new(MockTaskDependencyController)
+	router := newDependencyRouter(mockController)
+
+	mockController.On("GetDependencyGraph", 1, mock.AnythingOfType("*dto.Actor")).Return(&dto.TaskDependencyGraph{
+		TaskID: 1,
+		Nodes:  []dto.TaskGraphNode{{ID: 1}, {ID: 2}},
+		Edges:  []dto.TaskDependencyEdge{{BlockerTaskID: 2, BlockedTaskID: 1}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/tasks/1/dependencies"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dto.TaskDependencyGraph
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []dto.TaskDependencyEdge{{BlockerTaskID: 2, BlockedTaskID: 1}}, response.Edges)
+}
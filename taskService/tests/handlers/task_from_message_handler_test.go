@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+func newFromMessageRouter(controller *MockTaskController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskHandler(controller)
+
+	router := gin.New()
+	router.POST("/tasks/from-message", handler.CreateTaskFromMessage)
+	return router
+}
+
+func TestTaskHandler_CreateTaskFromMessage_Success(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newFromMessageRouter(mockController)
+	userID := uuid.New()
+	messageID := uuid.New()
+	executorID := uuid.New()
+
+	mockController.On("CreateFromMessage", &dto.Actor{UserID: userID}, &dto.CreateTaskFromMessageDTO{
+		MessageID:  messageID,
+		ExecutorID: executorID,
+		Priority:   models.TaskPriorityHigh,
+	}).Return(&models.Task{ID: 12, Title: "Починить экспорт"}, nil)
+
+	body := `{"message_id":"` + messageID.String() + `","executor_id":"` + executorID.String() + `","priority":"high"}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("POST", "/tasks/from-message", body, userID))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, float64(12), response["ID"])
+}
+
+func TestTaskHandler_CreateTaskFromMessage_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "missing message", body: `{"executor_id":"` + uuid.New().String() + `"}`},
+		{name: "missing executor", body: `{"message_id":"` + uuid.New().String() + `"}`},
+		{name: "invalid priority", body: `{"message_id":"` + uuid.New().String() + `","executor_id":"` + uuid.New().String() + `","priority":"asap"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			router := newFromMessageRouter(mockController)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("POST", "/tasks/from-message", tt.body, uuid.New()))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockController.AssertNotCalled(t, "CreateFromMessage", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTaskHandler_CreateTaskFromMessage_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "no view_messages", err: custom_errors.NewChatMessageAccessDeniedError("m", "u"), expectedCode: http.StatusForbidden},
+		{name: "message not found", err: custom_errors.NewChatMessageNotFoundError("m"), expectedCode: http.StatusNotFound},
+		{name: "chat service", err: custom_errors.NewGetChatMessageHTTPError("m", "timeout"), expectedCode: http.StatusBadGateway},
+		{name: "file service", err: custom_errors.NewGetFileHTTPError(3, "timeout"), expectedCode: http.StatusBadGateway},
+		{name: "workflow not found", err: custom_errors.NewWorkflowNotFoundError(2), expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			router := newFromMessageRouter(mockController)
+			mockController.On("CreateFromMessage", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			body := `{"message_id":"` + uuid.New().String() + `","executor_id":"` + uuid.New().String() + `"}`
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("POST", "/tasks/from-message", body, uuid.New()))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
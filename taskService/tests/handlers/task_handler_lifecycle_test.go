@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+)
+
+func newLifecycleRouter(controller *MockTaskController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskHandler(controller)
+
+	router := gin.New()
+	router.PATCH("/tasks/:task_id", handler.UpdateTask)
+	router.DELETE("/tasks/:task_id", handler.DeleteTask)
+	router.GET("/users/:user_id/tasks/created", handler.GetCreatedTasks)
+	router.GET("/chats/:chat_id/tasks", handler.GetChatTasks)
+	router.GET("/users/:user_id/tasks", handler.GetUserTasks)
+	return router
+}
+
+func TestTaskHandler_UpdateTask_Success(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newLifecycleRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("Update", 1, mock.MatchedBy(func(actor *dto.Actor) bool {
+		return actor.UserID == userID && actor.HasPermission(dto.PermissionManageAllTasks)
+	}), mock.MatchedBy(func(d *dto.UpdateTaskDTO) bool {
+		return d.Title != nil && *d.Title == "Updated"
+	})).Return(createTestTaskModel(), nil)
+
+	body, _ := json.Marshal(map[string]string{"title": "Updated"})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PATCH", "/tasks/1", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", userID.String())
+	req.Header.Set("X-User-Permissions", "process_tasks, manage_all_tasks")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_UpdateTask_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		userID string
+		body   string
+	}{
+		{"missing user", "/tasks/1", "", `{"title":"x"}`},
+		{"invalid task id", "/tasks/abc", uuid.New().String(), `{"title":"x"}`},
+		{"empty title", "/tasks/1", uuid.New().String(), `{"title":""}`},
+		{"invalid json", "/tasks/1", uuid.New().String(), `{`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			router := newLifecycleRouter(mockController)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-User-ID", tt.userID)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockController.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTaskHandler_UpdateTask_ControllerErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"not found", custom_errors.NewTaskNotFoundError(1), http.StatusNotFound},
+		{"access denied", custom_errors.NewTaskAccessDeniedError(1, "u"), http.StatusForbidden},
+		{"not a member of new chat", custom_errors.NewChatMembershipRequiredError("c", "u"), http.StatusForbidden},
+		{"user service", custom_errors.NewGetUserHTTPError("u", "down"), http.StatusBadGateway},
+		{"chat service", custom_errors.NewGetChatHTTPError("c", "down"), http.StatusBadGateway},
+		{"file service", custom_errors.NewGetFileHTTPError(1, "down"), http.StatusBadGateway},
+		{"unknown", errors.New("db"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			router := newLifecycleRouter(mockController)
+			mockController.On("Update", 1, mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/tasks/1", bytes.NewBufferString(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-User-ID", uuid.New().String())
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestTaskHandler_DeleteTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"success", nil, http.StatusNoContent},
+		{"not found", custom_errors.NewTaskNotFoundError(1), http.StatusNotFound},
+		{"access denied", custom_errors.NewTaskAccessDeniedError(1, "u"), http.StatusForbidden},
+		{"unknown", errors.New("db"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			router := newLifecycleRouter(mockController)
+			userID := uuid.New()
+			mockController.On("Delete", 1, &dto.Actor{UserID: userID}).Return(tt.err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/tasks/1", nil)
+			req.Header.Set("X-User-ID", userID.String())
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockController.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTaskHandler_GetCreatedTasks(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newLifecycleRouter(mockController)
+	userID := uuid.New().String()
+
+	mockController.On("GetCreatedTasks", userID, mock.AnythingOfType("*dto.Actor"), 5, 10).Return(createTestTaskToList(), nil)
+
+	w := httptest.NewRecorder()
+	req := newViewerRequest("/users/" + userID + "/tasks/created?limit=5&offset=10")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetChatTasks(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newLifecycleRouter(mockController)
+	chatID := uuid.New().String()
+
+	mockController.On("GetChatTasks", chatID, mock.AnythingOfType("*dto.Actor"), 20, 0).Return(createTestTaskToList(), nil)
+
+	w := httptest.NewRecorder()
+	req := newViewerRequest("/chats/" + chatID + "/tasks")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetChatTasks_InvalidParams(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newLifecycleRouter(mockController)
+
+	for _, path := range []string{
+		"/chats/not-a-uuid/tasks",
+		"/chats/" + uuid.New().String() + "/tasks?limit=0",
+		"/chats/" + uuid.New().String() + "/tasks?offset=-1",
+	} {
+		w := httptest.NewRecorder()
+		req := newViewerRequest(path)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
+
+func TestTaskHandler_GetUserTasks_ParsesFilter(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newLifecycleRouter(mockController)
+	userID := uuid.New().String()
+	dueTo := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mockController.On("GetUserTasks", userID, mock.AnythingOfType("*dto.Actor"), mock.MatchedBy(func(f *dto.TaskListFilter) bool {
+		return f != nil && len(f.Priorities) == 2 && f.Priorities[0] == "high" && f.Priorities[1] == "urgent" &&
+			f.DueFrom == nil && f.DueTo != nil && f.DueTo.Equal(dueTo) &&
+			f.Overdue && f.SortBy == dto.TaskSortByDueAt && !f.SortDesc
+	}), 20, 0).Return(&[]dto.TaskToList{}, nil)
+
+	w := httptest.NewRecorder()
+	req := newViewerRequest("/users/" + userID + "/tasks?priority=high,urgent&due_to=2026-06-01T00:00:00Z&overdue=true&sort_by=due_at&order=asc")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetUserTasks_InvalidFilter(t *testing.T) {
+	for _, query := range []string{
+		"priority=critical",
+		"due_from=yesterday",
+		"overdue=maybe",
+		"sort_by=title",
+		"order=up",
+	} {
+		t.Run(query, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			router := newLifecycleRouter(mockController)
+
+			w := httptest.NewRecorder()
+			req := newViewerRequest("/users/" + uuid.New().String() + "/tasks?" + query)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTaskHandler_UpdateTask_InvalidSchedule(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newLifecycleRouter(mockController)
+	now := time.Now()
+
+	mockController.On("Update", 1, mock.Anything, mock.Anything).
+		Return(nil, custom_errors.NewInvalidTaskScheduleError(now.Add(time.Hour), now))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PATCH", "/tasks/1", bytes.NewBufferString(`{"start_at":"2026-06-02T00:00:00Z"}`))
+	req.Header.Set("X-User-ID", uuid.New().String())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
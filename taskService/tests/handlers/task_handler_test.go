@@ -26,35 +26,109 @@ type MockTaskController struct {
 	mock.Mock
 }
 
-func (m *MockTaskController) Create(taskDTO *dto.CreateTaskDTO) (*models.Task, error) {
-	args := m.Called(taskDTO)
+func (m *MockTaskController) Create(actor *dto.Actor, taskDTO *dto.CreateTaskDTO) (*models.Task, error) {
+	args := m.Called(actor, taskDTO)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Task), args.Error(1)
 }
 
-func (m *MockTaskController) UpdateStatus(taskID, statusID int) error {
-	args := m.Called(taskID, statusID)
+func (m *MockTaskController) CreateFromMessage(actor *dto.Actor, fromMessageDTO *dto.CreateTaskFromMessageDTO) (*models.Task, error) {
+	args := m.Called(actor, fromMessageDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Task), args.Error(1)
+}
+
+func (m *MockTaskController) UpdateStatus(taskID, statusID int, actor *dto.Actor) error {
+	args := m.Called(taskID, statusID, actor)
 	return args.Error(0)
 }
 
-func (m *MockTaskController) GetByID(taskID int) (*dto.TaskResponse, error) {
-	args := m.Called(taskID)
+func (m *MockTaskController) GetByID(taskID int, actor *dto.Actor) (*dto.TaskResponse, error) {
+	args := m.Called(taskID, actor)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.TaskResponse), args.Error(1)
 }
 
-func (m *MockTaskController) GetUserTasks(userID string, limit, offset int) (*[]dto.TaskToList, error) {
-	args := m.Called(userID, limit, offset)
+func (m *MockTaskController) GetUserTasks(userID string, actor *dto.Actor, filter *dto.TaskListFilter, limit, offset int) (*[]dto.TaskToList, error) {
+	args := m.Called(userID, actor, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]dto.TaskToList), args.Error(1)
+}
+
+func (m *MockTaskController) QueryTasks(actor *dto.Actor, query *dto.TaskQuery) (*dto.TaskQueryResult, error) {
+	args := m.Called(actor, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TaskQueryResult), args.Error(1)
+}
+
+func (m *MockTaskController) GetBoard(actor *dto.Actor, query *dto.TaskBoardQuery) (*dto.TaskBoard, error) {
+	args := m.Called(actor, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TaskBoard), args.Error(1)
+}
+
+func (m *MockTaskController) MoveTask(taskID int, actor *dto.Actor, moveDTO *dto.MoveTaskDTO) error {
+	args := m.Called(taskID, actor, moveDTO)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) Update(taskID int, actor *dto.Actor, updateDTO *dto.UpdateTaskDTO) (*models.Task, error) {
+	args := m.Called(taskID, actor, updateDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Task), args.Error(1)
+}
+
+func (m *MockTaskController) Delete(taskID int, actor *dto.Actor) error {
+	args := m.Called(taskID, actor)
+	return args.Error(0)
+}
+
+func (m *MockTaskController) GetCreatedTasks(userID string, actor *dto.Actor, limit, offset int) (*[]dto.TaskToList, error) {
+	args := m.Called(userID, actor, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]dto.TaskToList), args.Error(1)
+}
+
+func (m *MockTaskController) GetChatTasks(chatID string, actor *dto.Actor, limit, offset int) (*[]dto.TaskToList, error) {
+	args := m.Called(chatID, actor, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*[]dto.TaskToList), args.Error(1)
 }
 
+func (m *MockTaskController) GetHistory(taskID int, actor *dto.Actor, limit, offset int) (*[]dto.TaskActivity, error) {
+	args := m.Called(taskID, actor, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]dto.TaskActivity), args.Error(1)
+}
+
+func (m *MockTaskController) GetUserActivity(userID string, actor *dto.Actor, limit, offset int) (*[]dto.TaskActivity, error) {
+	args := m.Called(userID, actor, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]dto.TaskActivity), args.Error(1)
+}
+
 // Вспомогательные функции для создания тестовых данных
 func createTestTaskModel() *models.Task {
 	return &models.Task{
@@ -62,7 +136,6 @@ func createTestTaskModel() *models.Task {
 		Title:       "Test Task",
 		Description: "Test Description",
 		StatusID:    1,
-		CreatorID:   uuid.New(),
 		ExecutorID:  uuid.New(),
 		Status: &models.TaskStatus{
 			ID:   1,
@@ -105,14 +178,13 @@ func TestTaskHandler_CreateTask_Success(t *testing.T) {
 	taskDTO := dto.CreateTaskDTO{
 		Title:       "Test Task",
 		Description: stringPtr("Test Description"),
-		CreatorID:   uuid.New(),
 		ExecutorID:  uuid.New(),
 		FileIDs:     []int{1, 2},
 	}
 	reqJSON, _ := json.Marshal(taskDTO)
 	expectedTask := createTestTaskModel()
 
-	mockController.On("Create", mock.AnythingOfType("*dto.CreateTaskDTO")).Return(expectedTask, nil)
+	mockController.On("Create", mock.AnythingOfType("*dto.Actor"), mock.AnythingOfType("*dto.CreateTaskDTO")).Return(expectedTask, nil)
 
 	router := gin.New()
 	router.POST("/tasks", handler.CreateTask)
@@ -121,6 +193,7 @@ func TestTaskHandler_CreateTask_Success(t *testing.T) {
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/tasks", bytes.NewBuffer(reqJSON))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", uuid.New().String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -150,6 +223,7 @@ func TestTaskHandler_CreateTask_InvalidJSON(t *testing.T) {
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/tasks", bytes.NewBuffer(invalidJSON))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", uuid.New().String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -160,7 +234,50 @@ func TestTaskHandler_CreateTask_InvalidJSON(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "invalid request body", response["error"])
 
-	mockController.AssertNotCalled(t, "Create", mock.Anything)
+	mockController.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_CreateTask_InvalidUserID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockTaskController)
+	handler := handlers.NewTaskHandler(mockController)
+	reqJSON, _ := json.Marshal(dto.CreateTaskDTO{Title: "Test Task", ExecutorID: uuid.New()})
+
+	router := gin.New()
+	router.POST("/tasks", handler.CreateTask)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks", bytes.NewBuffer(reqJSON))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_CreateTask_NotChatMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockTaskController)
+	handler := handlers.NewTaskHandler(mockController)
+	userID := uuid.New()
+	chatID := uuid.New()
+	reqJSON, _ := json.Marshal(dto.CreateTaskDTO{Title: "Test Task", ExecutorID: uuid.New(), ChatID: chatID})
+
+	mockController.On("Create", &dto.Actor{UserID: userID, Permissions: []string{"process_tasks"}}, mock.AnythingOfType("*dto.CreateTaskDTO")).
+		Return(nil, custom_errors.NewChatMembershipRequiredError(chatID.String(), userID.String()))
+
+	router := gin.New()
+	router.POST("/tasks", handler.CreateTask)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks", bytes.NewBuffer(reqJSON))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", userID.String())
+	req.Header.Set("X-User-Permissions", "process_tasks")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockController.AssertExpectations(t)
 }
 
 func TestTaskHandler_CreateTask_StatusNotFound(t *testing.T) {
@@ -171,13 +288,12 @@ func TestTaskHandler_CreateTask_StatusNotFound(t *testing.T) {
 
 	taskDTO := dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  uuid.New(),
 		ExecutorID: uuid.New(),
 	}
 	reqJSON, _ := json.Marshal(taskDTO)
 	statusError := custom_errors.NewTaskStatusNotFoundError("created")
 
-	mockController.On("Create", mock.AnythingOfType("*dto.CreateTaskDTO")).Return(nil, statusError)
+	mockController.On("Create", mock.AnythingOfType("*dto.Actor"), mock.AnythingOfType("*dto.CreateTaskDTO")).Return(nil, statusError)
 
 	router := gin.New()
 	router.POST("/tasks", handler.CreateTask)
@@ -186,6 +302,7 @@ func TestTaskHandler_CreateTask_StatusNotFound(t *testing.T) {
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/tasks", bytes.NewBuffer(reqJSON))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", uuid.New().String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -208,13 +325,12 @@ func TestTaskHandler_CreateTask_GetUserHTTPError(t *testing.T) {
 	creatorID := uuid.New()
 	taskDTO := dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  creatorID,
 		ExecutorID: uuid.New(),
 	}
 	reqJSON, _ := json.Marshal(taskDTO)
 	userError := custom_errors.NewGetUserHTTPError(creatorID.String(), "user not found")
 
-	mockController.On("Create", mock.AnythingOfType("*dto.CreateTaskDTO")).Return(nil, userError)
+	mockController.On("Create", &dto.Actor{UserID: creatorID}, mock.AnythingOfType("*dto.CreateTaskDTO")).Return(nil, userError)
 
 	router := gin.New()
 	router.POST("/tasks", handler.CreateTask)
@@ -223,6 +339,7 @@ func TestTaskHandler_CreateTask_GetUserHTTPError(t *testing.T) {
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/tasks", bytes.NewBuffer(reqJSON))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", creatorID.String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -245,14 +362,13 @@ func TestTaskHandler_CreateTask_GetChatHTTPError(t *testing.T) {
 	chatID := uuid.New()
 	taskDTO := dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  uuid.New(),
 		ExecutorID: uuid.New(),
 		ChatID:     chatID,
 	}
 	reqJSON, _ := json.Marshal(taskDTO)
 	chatError := custom_errors.NewGetChatHTTPError(chatID.String(), "chat not found")
 
-	mockController.On("Create", mock.AnythingOfType("*dto.CreateTaskDTO")).Return(nil, chatError)
+	mockController.On("Create", mock.AnythingOfType("*dto.Actor"), mock.AnythingOfType("*dto.CreateTaskDTO")).Return(nil, chatError)
 
 	router := gin.New()
 	router.POST("/tasks", handler.CreateTask)
@@ -261,6 +377,7 @@ func TestTaskHandler_CreateTask_GetChatHTTPError(t *testing.T) {
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/tasks", bytes.NewBuffer(reqJSON))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", uuid.New().String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -282,14 +399,13 @@ func TestTaskHandler_CreateTask_GetFileHTTPError(t *testing.T) {
 
 	taskDTO := dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  uuid.New(),
 		ExecutorID: uuid.New(),
 		FileIDs:    []int{1},
 	}
 	reqJSON, _ := json.Marshal(taskDTO)
 	fileError := custom_errors.NewGetFileHTTPError(1, "file not found")
 
-	mockController.On("Create", mock.AnythingOfType("*dto.CreateTaskDTO")).Return(nil, fileError)
+	mockController.On("Create", mock.AnythingOfType("*dto.Actor"), mock.AnythingOfType("*dto.CreateTaskDTO")).Return(nil, fileError)
 
 	router := gin.New()
 	router.POST("/tasks", handler.CreateTask)
@@ -298,6 +414,7 @@ func TestTaskHandler_CreateTask_GetFileHTTPError(t *testing.T) {
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/tasks", bytes.NewBuffer(reqJSON))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", uuid.New().String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -319,13 +436,12 @@ func TestTaskHandler_CreateTask_InternalServerError(t *testing.T) {
 
 	taskDTO := dto.CreateTaskDTO{
 		Title:      "Test Task",
-		CreatorID:  uuid.New(),
 		ExecutorID: uuid.New(),
 	}
 	reqJSON, _ := json.Marshal(taskDTO)
 	internalError := errors.New("database error")
 
-	mockController.On("Create", mock.AnythingOfType("*dto.CreateTaskDTO")).Return(nil, internalError)
+	mockController.On("Create", mock.AnythingOfType("*dto.Actor"), mock.AnythingOfType("*dto.CreateTaskDTO")).Return(nil, internalError)
 
 	router := gin.New()
 	router.POST("/tasks", handler.CreateTask)
@@ -334,6 +450,7 @@ func TestTaskHandler_CreateTask_InternalServerError(t *testing.T) {
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/tasks", bytes.NewBuffer(reqJSON))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", uuid.New().String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -358,7 +475,7 @@ func TestTaskHandler_UpdateTaskStatus_Success(t *testing.T) {
 	taskID := 1
 	statusID := 2
 
-	mockController.On("UpdateStatus", taskID, statusID).Return(nil)
+	mockController.On("UpdateStatus", taskID, statusID, mock.AnythingOfType("*dto.Actor")).Return(nil)
 
 	router := gin.New()
 	router.PATCH("/tasks/:task_id/status/:status_id", handler.UpdateTaskStatus)
@@ -366,6 +483,7 @@ func TestTaskHandler_UpdateTaskStatus_Success(t *testing.T) {
 	// Act
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("PATCH", "/tasks/"+strconv.Itoa(taskID)+"/status/"+strconv.Itoa(statusID), nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -396,7 +514,7 @@ func TestTaskHandler_UpdateTaskStatus_InvalidTaskID(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "invalid task ID", response["error"])
 
-	mockController.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	mockController.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestTaskHandler_UpdateTaskStatus_InvalidStatusID(t *testing.T) {
@@ -423,7 +541,7 @@ func TestTaskHandler_UpdateTaskStatus_InvalidStatusID(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "invalid status ID", response["error"])
 
-	mockController.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	mockController.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestTaskHandler_UpdateTaskStatus_StatusNotFound(t *testing.T) {
@@ -436,7 +554,7 @@ func TestTaskHandler_UpdateTaskStatus_StatusNotFound(t *testing.T) {
 	statusID := 999
 	statusError := custom_errors.NewTaskStatusNotFoundError(strconv.Itoa(statusID))
 
-	mockController.On("UpdateStatus", taskID, statusID).Return(statusError)
+	mockController.On("UpdateStatus", taskID, statusID, mock.AnythingOfType("*dto.Actor")).Return(statusError)
 
 	router := gin.New()
 	router.PATCH("/tasks/:task_id/status/:status_id", handler.UpdateTaskStatus)
@@ -444,6 +562,7 @@ func TestTaskHandler_UpdateTaskStatus_StatusNotFound(t *testing.T) {
 	// Act
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("PATCH", "/tasks/"+strconv.Itoa(taskID)+"/status/"+strconv.Itoa(statusID), nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -467,7 +586,7 @@ func TestTaskHandler_UpdateTaskStatus_TaskNotFound(t *testing.T) {
 	statusID := 2
 	taskError := custom_errors.NewTaskNotFoundError(taskID)
 
-	mockController.On("UpdateStatus", taskID, statusID).Return(taskError)
+	mockController.On("UpdateStatus", taskID, statusID, mock.AnythingOfType("*dto.Actor")).Return(taskError)
 
 	router := gin.New()
 	router.PATCH("/tasks/:task_id/status/:status_id", handler.UpdateTaskStatus)
@@ -475,6 +594,7 @@ func TestTaskHandler_UpdateTaskStatus_TaskNotFound(t *testing.T) {
 	// Act
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("PATCH", "/tasks/"+strconv.Itoa(taskID)+"/status/"+strconv.Itoa(statusID), nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -498,7 +618,7 @@ func TestTaskHandler_UpdateTaskStatus_InternalServerError(t *testing.T) {
 	statusID := 2
 	internalError := errors.New("database error")
 
-	mockController.On("UpdateStatus", taskID, statusID).Return(internalError)
+	mockController.On("UpdateStatus", taskID, statusID, mock.AnythingOfType("*dto.Actor")).Return(internalError)
 
 	router := gin.New()
 	router.PATCH("/tasks/:task_id/status/:status_id", handler.UpdateTaskStatus)
@@ -506,6 +626,7 @@ func TestTaskHandler_UpdateTaskStatus_InternalServerError(t *testing.T) {
 	// Act
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("PATCH", "/tasks/"+strconv.Itoa(taskID)+"/status/"+strconv.Itoa(statusID), nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -519,6 +640,60 @@ func TestTaskHandler_UpdateTaskStatus_InternalServerError(t *testing.T) {
 	mockController.AssertExpectations(t)
 }
 
+func TestTaskHandler_UpdateTaskStatus_MissingActor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockTaskController)
+	handler := handlers.NewTaskHandler(mockController)
+
+	router := gin.New()
+	router.PATCH("/tasks/:task_id/status/:status_id", handler.UpdateTaskStatus)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PATCH", "/tasks/1/status/2", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_UpdateTaskStatus_WorkflowErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "illegal transition", err: custom_errors.NewIllegalStatusTransitionError(1, 1, 2), expectedCode: http.StatusConflict},
+		{name: "open blockers", err: custom_errors.NewTaskBlockedError(1, []int{2}), expectedCode: http.StatusConflict},
+		{name: "role forbidden", err: custom_errors.NewStatusTransitionForbiddenError(1, "creator"), expectedCode: http.StatusForbidden},
+		{name: "not a participant", err: custom_errors.NewTaskAccessDeniedError(1, uuid.NewString()), expectedCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockController := new(MockTaskController)
+			handler := handlers.NewTaskHandler(mockController)
+			actorID := uuid.New()
+
+			mockController.On("UpdateStatus", 1, 2, mock.MatchedBy(func(actor *dto.Actor) bool {
+				return actor.UserID == actorID && actor.HasPermission("manage_all_tasks")
+			})).Return(tt.err)
+
+			router := gin.New()
+			router.PATCH("/tasks/:task_id/status/:status_id", handler.UpdateTaskStatus)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/tasks/1/status/2", nil)
+			req.Header.Set("X-User-ID", actorID.String())
+			req.Header.Set("X-User-Permissions", "view_task_statuses, manage_all_tasks")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockController.AssertExpectations(t)
+		})
+	}
+}
+
 // Тесты для TaskHandler.GetTaskByID
 
 func TestTaskHandler_GetTaskByID_Success(t *testing.T) {
@@ -530,14 +705,14 @@ func TestTaskHandler_GetTaskByID_Success(t *testing.T) {
 	taskID := 1
 	expectedResponse := createTestTaskResponse()
 
-	mockController.On("GetByID", taskID).Return(expectedResponse, nil)
+	mockController.On("GetByID", taskID, mock.AnythingOfType("*dto.Actor")).Return(expectedResponse, nil)
 
 	router := gin.New()
 	router.GET("/tasks/:task_id", handler.GetTaskByID)
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/tasks/"+strconv.Itoa(taskID), nil)
+	req := newViewerRequest("/tasks/" + strconv.Itoa(taskID))
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -562,7 +737,7 @@ func TestTaskHandler_GetTaskByID_InvalidTaskID(t *testing.T) {
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/tasks/invalid", nil)
+	req := newViewerRequest("/tasks/invalid")
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -573,7 +748,7 @@ func TestTaskHandler_GetTaskByID_InvalidTaskID(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "invalid task ID", response["error"])
 
-	mockController.AssertNotCalled(t, "GetByID", mock.Anything)
+	mockController.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
 }
 
 func TestTaskHandler_GetTaskByID_GetFileHTTPError(t *testing.T) {
@@ -585,14 +760,14 @@ func TestTaskHandler_GetTaskByID_GetFileHTTPError(t *testing.T) {
 	taskID := 1
 	fileError := custom_errors.NewGetFileHTTPError(1, "file not found")
 
-	mockController.On("GetByID", taskID).Return(nil, fileError)
+	mockController.On("GetByID", taskID, mock.AnythingOfType("*dto.Actor")).Return(nil, fileError)
 
 	router := gin.New()
 	router.GET("/tasks/:task_id", handler.GetTaskByID)
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/tasks/"+strconv.Itoa(taskID), nil)
+	req := newViewerRequest("/tasks/" + strconv.Itoa(taskID))
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -615,14 +790,14 @@ func TestTaskHandler_GetTaskByID_InternalServerError(t *testing.T) {
 	taskID := 1
 	internalError := errors.New("database error")
 
-	mockController.On("GetByID", taskID).Return(nil, internalError)
+	mockController.On("GetByID", taskID, mock.AnythingOfType("*dto.Actor")).Return(nil, internalError)
 
 	router := gin.New()
 	router.GET("/tasks/:task_id", handler.GetTaskByID)
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/tasks/"+strconv.Itoa(taskID), nil)
+	req := newViewerRequest("/tasks/" + strconv.Itoa(taskID))
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -636,6 +811,71 @@ func TestTaskHandler_GetTaskByID_InternalServerError(t *testing.T) {
 	mockController.AssertExpectations(t)
 }
 
+func TestTaskHandler_GetTaskByID_MissingUserID(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockTaskController)
+	handler := handlers.NewTaskHandler(mockController)
+
+	router := gin.New()
+	router.GET("/tasks/:task_id", handler.GetTaskByID)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/tasks/1", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestTaskHandler_GetTaskByID_HiddenTaskNotFound(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockTaskController)
+	handler := handlers.NewTaskHandler(mockController)
+
+	taskID := 1
+	mockController.On("GetByID", taskID, mock.AnythingOfType("*dto.Actor")).
+		Return(nil, custom_errors.NewTaskNotFoundError(taskID))
+
+	router := gin.New()
+	router.GET("/tasks/:task_id", handler.GetTaskByID)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := newViewerRequest("/tasks/" + strconv.Itoa(taskID))
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_GetTaskByID_ChatServiceError(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockTaskController)
+	handler := handlers.NewTaskHandler(mockController)
+
+	taskID := 1
+	mockController.On("GetByID", taskID, mock.AnythingOfType("*dto.Actor")).
+		Return(nil, custom_errors.NewGetUserChatsHTTPError(uuid.NewString(), "connection refused"))
+
+	router := gin.New()
+	router.GET("/tasks/:task_id", handler.GetTaskByID)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := newViewerRequest("/tasks/" + strconv.Itoa(taskID))
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	mockController.AssertExpectations(t)
+}
+
 // Тесты для TaskHandler.GetUserTasks
 
 func TestTaskHandler_GetUserTasks_Success(t *testing.T) {
@@ -649,14 +889,14 @@ func TestTaskHandler_GetUserTasks_Success(t *testing.T) {
 	offset := 0
 	expectedTasks := createTestTaskToList()
 
-	mockController.On("GetUserTasks", userID, limit, offset).Return(expectedTasks, nil)
+	mockController.On("GetUserTasks", userID, mock.AnythingOfType("*dto.Actor"), (*dto.TaskListFilter)(nil), limit, offset).Return(expectedTasks, nil)
 
 	router := gin.New()
 	router.GET("/users/:user_id/tasks", handler.GetUserTasks)
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/users/"+userID+"/tasks?limit="+strconv.Itoa(limit)+"&offset="+strconv.Itoa(offset), nil)
+	req := newViewerRequest("/users/" + userID + "/tasks?limit=" + strconv.Itoa(limit) + "&offset=" + strconv.Itoa(offset))
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -681,14 +921,14 @@ func TestTaskHandler_GetUserTasks_DefaultValues(t *testing.T) {
 	offset := 0
 	expectedTasks := createTestTaskToList()
 
-	mockController.On("GetUserTasks", userID, limit, offset).Return(expectedTasks, nil)
+	mockController.On("GetUserTasks", userID, mock.AnythingOfType("*dto.Actor"), (*dto.TaskListFilter)(nil), limit, offset).Return(expectedTasks, nil)
 
 	router := gin.New()
 	router.GET("/users/:user_id/tasks", handler.GetUserTasks)
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/users/"+userID+"/tasks", nil)
+	req := newViewerRequest("/users/" + userID + "/tasks")
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -713,7 +953,7 @@ func TestTaskHandler_GetUserTasks_EmptyUserID(t *testing.T) {
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/users//tasks", nil)
+	req := newViewerRequest("/users//tasks")
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -724,7 +964,7 @@ func TestTaskHandler_GetUserTasks_EmptyUserID(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "user ID is required", response["error"])
 
-	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything)
+	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestTaskHandler_GetUserTasks_InvalidLimit(t *testing.T) {
@@ -740,7 +980,7 @@ func TestTaskHandler_GetUserTasks_InvalidLimit(t *testing.T) {
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/users/"+userID+"/tasks?limit=invalid", nil)
+	req := newViewerRequest("/users/" + userID + "/tasks?limit=invalid")
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -751,7 +991,7 @@ func TestTaskHandler_GetUserTasks_InvalidLimit(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "invalid limit", response["error"])
 
-	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything)
+	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestTaskHandler_GetUserTasks_InvalidLimitZero(t *testing.T) {
@@ -767,7 +1007,7 @@ func TestTaskHandler_GetUserTasks_InvalidLimitZero(t *testing.T) {
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/users/"+userID+"/tasks?limit=0", nil)
+	req := newViewerRequest("/users/" + userID + "/tasks?limit=0")
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -778,7 +1018,7 @@ func TestTaskHandler_GetUserTasks_InvalidLimitZero(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "invalid limit", response["error"])
 
-	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything)
+	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestTaskHandler_GetUserTasks_InvalidOffset(t *testing.T) {
@@ -794,7 +1034,7 @@ func TestTaskHandler_GetUserTasks_InvalidOffset(t *testing.T) {
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/users/"+userID+"/tasks?limit=10&offset=invalid", nil)
+	req := newViewerRequest("/users/" + userID + "/tasks?limit=10&offset=invalid")
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -805,7 +1045,7 @@ func TestTaskHandler_GetUserTasks_InvalidOffset(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "invalid offset", response["error"])
 
-	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything)
+	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestTaskHandler_GetUserTasks_InvalidOffsetNegative(t *testing.T) {
@@ -821,7 +1061,7 @@ func TestTaskHandler_GetUserTasks_InvalidOffsetNegative(t *testing.T) {
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/users/"+userID+"/tasks?limit=10&offset=-1", nil)
+	req := newViewerRequest("/users/" + userID + "/tasks?limit=10&offset=-1")
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -832,7 +1072,7 @@ func TestTaskHandler_GetUserTasks_InvalidOffsetNegative(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "invalid offset", response["error"])
 
-	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything)
+	mockController.AssertNotCalled(t, "GetUserTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestTaskHandler_GetUserTasks_ControllerError(t *testing.T) {
@@ -846,14 +1086,14 @@ func TestTaskHandler_GetUserTasks_ControllerError(t *testing.T) {
 	offset := 0
 	controllerError := errors.New("database error")
 
-	mockController.On("GetUserTasks", userID, limit, offset).Return(nil, controllerError)
+	mockController.On("GetUserTasks", userID, mock.AnythingOfType("*dto.Actor"), (*dto.TaskListFilter)(nil), limit, offset).Return(nil, controllerError)
 
 	router := gin.New()
 	router.GET("/users/:user_id/tasks", handler.GetUserTasks)
 
 	// Act
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest("GET", "/users/"+userID+"/tasks?limit="+strconv.Itoa(limit)+"&offset="+strconv.Itoa(offset), nil)
+	req := newViewerRequest("/users/" + userID + "/tasks?limit=" + strconv.Itoa(limit) + "&offset=" + strconv.Itoa(offset))
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -868,6 +1108,13 @@ func TestTaskHandler_GetUserTasks_ControllerError(t *testing.T) {
 }
 
 // Вспомогательные функции
+// newViewerRequest - GET-запрос от произвольного пользователя; эндпоинты чтения задач требуют X-User-ID
+func newViewerRequest(url string) *http.Request {
+	req := httptest.NewRequest("GET", url, nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
+	return req
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
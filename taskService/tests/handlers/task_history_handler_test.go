@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+)
+
+func newHistoryRouter(controller *MockTaskController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskHandler(controller)
+
+	router := gin.New()
+	router.GET("/tasks/:task_id/history", handler.GetTaskHistory)
+	router.GET("/users/:user_id/activity", handler.GetUserActivity)
+	return router
+}
+
+func TestTaskHandler_GetTaskHistory_Success(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newHistoryRouter(mockController)
+	field := "title"
+	history := &[]dto.TaskActivity{{ID: 3, TaskID: 1, EventType: "edited", Field: &field}}
+
+	mockController.On("GetHistory", 1, mock.AnythingOfType("*dto.Actor"), 5, 10).Return(history, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/tasks/1/history?limit=5&offset=10"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response, 1)
+	assert.Equal(t, "edited", response[0]["eventType"])
+	assert.Equal(t, "title", response[0]["field"])
+}
+
+func TestTaskHandler_GetTaskHistory_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		err          error
+		expectedCode int
+	}{
+		{name: "invalid task id", url: "/tasks/abc/history", expectedCode: http.StatusBadRequest},
+		{name: "invalid limit", url: "/tasks/1/history?limit=0", expectedCode: http.StatusBadRequest},
+		{name: "task not found", url: "/tasks/1/history", err: custom_errors.NewTaskNotFoundError(1), expectedCode: http.StatusNotFound},
+		{name: "internal", url: "/tasks/1/history", err: errors.New("db down"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			router := newHistoryRouter(mockController)
+			if tt.err != nil {
+				mockController.On("GetHistory", 1, mock.AnythingOfType("*dto.Actor"), 20, 0).Return(nil, tt.err)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newViewerRequest(tt.url))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskHandler_GetUserActivity(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newHistoryRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("GetUserActivity", userID.String(), mock.AnythingOfType("*dto.Actor"), 20, 0).Return(&[]dto.TaskActivity{}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/users/"+userID.String()+"/activity"))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/users/not-a-uuid/activity"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNumberOfCalls(t, "GetUserActivity", 1)
+	mockController.AssertNotCalled(t, "GetHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+// MockTaskLabelController - мок для TaskLabelController
+type MockTaskLabelController struct {
+	mock.Mock
+}
+
+func (m *MockTaskLabelController) Create(labelDTO *dto.SaveLabelDTO) (*dto.LabelResponse, error) {
+	args := m.Called(labelDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.LabelResponse), args.Error(1)
+}
+
+func (m *MockTaskLabelController) Update(id int, labelDTO *dto.SaveLabelDTO) (*dto.LabelResponse, error) {
+	args := m.Called(id, labelDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.LabelResponse), args.Error(1)
+}
+
+func (m *MockTaskLabelController) GetByID(id int) (*dto.LabelResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.LabelResponse), args.Error(1)
+}
+
+func (m *MockTaskLabelController) GetAll() ([]dto.LabelResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.LabelResponse), args.Error(1)
+}
+
+func (m *MockTaskLabelController) DeleteByID(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTaskLabelController) AddToTask(taskID, labelID int, actor *dto.Actor) error {
+	args := m.Called(taskID, labelID, actor)
+	return args.Error(0)
+}
+
+func (m *MockTaskLabelController) RemoveFromTask(taskID, labelID int, actor *dto.Actor) error {
+	args := m.Called(taskID, labelID, actor)
+	return args.Error(0)
+}
+
+func (m *MockTaskLabelController) GetTaskLabels(taskID int, actor *dto.Actor) ([]models.Label, error) {
+	args := m.Called(taskID, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Label), args.Error(1)
+}
+
+func newLabelRouter(controller *MockTaskLabelController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskLabelHandler(controller)
+
+	router := gin.New()
+	router.POST("/tasks/labels", handler.Create)
+	router.GET("/tasks/labels", handler.GetAll)
+	router.PUT("/tasks/labels/:label_id", handler.Update)
+	router.DELETE("/tasks/labels/:label_id", handler.DeleteByID)
+	router.GET("/tasks/:task_id/labels", handler.GetTaskLabels)
+	router.POST("/tasks/:task_id/labels/:label_id", handler.AddToTask)
+	router.DELETE("/tasks/:task_id/labels/:label_id", handler.RemoveFromTask)
+	return router
+}
+
+func TestTaskLabelHandler_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		err          error
+		expectedCode int
+	}{
+		{name: "success", body: `{"name":"bug","color":"#ff0000"}`, expectedCode: http.StatusCreated},
+		{name: "invalid color", body: `{"name":"bug","color":"red"}`, expectedCode: http.StatusBadRequest},
+		{name: "missing name", body: `{"color":"#ff0000"}`, expectedCode: http.StatusBadRequest},
+		{name: "duplicate", body: `{"name":"bug","color":"#ff0000"}`, err: custom_errors.ErrLabelAlreadyExists, expectedCode: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskLabelController)
+			router := newLabelRouter(mockController)
+			var label *dto.LabelResponse
+			if tt.err == nil {
+				label = &dto.LabelResponse{ID: 1, Name: "bug", Color: "#ff0000"}
+			}
+			mockController.On("Create", &dto.SaveLabelDTO{Name: "bug", Color: "#ff0000"}).Return(label, tt.err).Maybe()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/labels", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskLabelHandler_GetAll(t *testing.T) {
+	mockController := new(MockTaskLabelController)
+	router := newLabelRouter(mockController)
+
+	mockController.On("GetAll").Return([]dto.LabelResponse{{ID: 1, Name: "bug", Color: "#ff0000", UsageCount: 3}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/labels", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response, 1)
+	assert.Equal(t, float64(3), response[0]["usageCount"])
+}
+
+func TestTaskLabelHandler_Update_NotFound(t *testing.T) {
+	mockController := new(MockTaskLabelController)
+	router := newLabelRouter(mockController)
+
+	mockController.On("Update", 7, &dto.SaveLabelDTO{Name: "bug", Color: "#f00"}).
+		Return(nil, custom_errors.NewLabelNotFoundError(7))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/tasks/labels/7", bytes.NewBufferString(`{"name":"bug","color":"#f00"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTaskLabelHandler_DeleteByID(t *testing.T) {
+	mockController := new(MockTaskLabelController)
+	router := newLabelRouter(mockController)
+
+	mockController.On("DeleteByID", 1).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/labels/1", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskLabelHandler_AddToTask(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "success", expectedCode: http.StatusNoContent},
+		{name: "access denied", err: custom_errors.NewTaskAccessDeniedError(1, "u"), expectedCode: http.StatusForbidden},
+		{name: "label not found", err: custom_errors.NewLabelNotFoundError(2), expectedCode: http.StatusNotFound},
+		{name: "internal", err: errors.New("db down"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskLabelController)
+			router := newLabelRouter(mockController)
+			userID := uuid.New()
+			mockController.On("AddToTask", 1, 2, &dto.Actor{UserID: userID}).Return(tt.err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("POST", "/tasks/1/labels/2", "", userID))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskLabelHandler_AddToTask_MissingUser(t *testing.T) {
+	mockController := new(MockTaskLabelController)
+	router := newLabelRouter(mockController)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/tasks/1/labels/2", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "AddToTask", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskLabelHandler_RemoveFromTask_NotAssigned(t *testing.T) {
+	mockController := new(MockTaskLabelController)
+	router := newLabelRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("RemoveFromTask", 1, 2, &dto.Actor{UserID: userID}).
+		Return(custom_errors.NewTaskLabelNotFoundError(1, 2))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("DELETE", "/tasks/1/labels/2", "", userID))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTaskLabelHandler_GetTaskLabels(t *testing.T) {
+	mockController := new(MockTaskLabelController)
+	router := newLabelRouter(mockController)
+
+	mockController.On("GetTaskLabels", 1, mock.AnythingOfType("*dto.Actor")).Return([]models.Label{{ID: 2, Name: "bug", Color: "#ff0000"}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/tasks/1/labels"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []models.Label
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "bug", response[0].Name)
+}
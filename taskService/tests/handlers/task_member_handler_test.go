@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+)
+
+// MockTaskMemberController - мок для TaskMemberController
+type MockTaskMemberController struct {
+	mock.Mock
+}
+
+func (m *MockTaskMemberController) AddAssignee(taskID int, userID uuid.UUID, actor *dto.Actor) error {
+	args := m.Called(taskID, userID, actor)
+	return args.Error(0)
+}
+
+func (m *MockTaskMemberController) RemoveAssignee(taskID int, userID uuid.UUID, actor *dto.Actor) error {
+	args := m.Called(taskID, userID, actor)
+	return args.Error(0)
+}
+
+func (m *MockTaskMemberController) AddWatcher(taskID int, userID uuid.UUID, actor *dto.Actor) error {
+	args := m.Called(taskID, userID, actor)
+	return args.Error(0)
+}
+
+func (m *MockTaskMemberController) RemoveWatcher(taskID int, userID uuid.UUID, actor *dto.Actor) error {
+	args := m.Called(taskID, userID, actor)
+	return args.Error(0)
+}
+
+func newMemberRouter(controller *MockTaskMemberController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskMemberHandler(controller)
+
+	router := gin.New()
+	router.POST("/tasks/:task_id/assignees/:user_id", handler.AddAssignee)
+	router.DELETE("/tasks/:task_id/assignees/:user_id", handler.RemoveAssignee)
+	router.POST("/tasks/:task_id/watchers/:user_id", handler.AddWatcher)
+	router.DELETE("/tasks/:task_id/watchers/:user_id", handler.RemoveWatcher)
+	return router
+}
+
+func TestTaskMemberHandler_AddAssignee(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "success", expectedCode: http.StatusNoContent},
+		{name: "access denied", err: custom_errors.NewTaskAccessDeniedError(1, "u"), expectedCode: http.StatusForbidden},
+		{name: "task not found", err: custom_errors.NewTaskNotFoundError(1), expectedCode: http.StatusNotFound},
+		{name: "user service error", err: custom_errors.NewGetUserHTTPError("u", "timeout"), expectedCode: http.StatusBadGateway},
+		{name: "internal", err: errors.New("db down"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskMemberController)
+			router := newMemberRouter(mockController)
+			actorID := uuid.New()
+			assigneeID := uuid.New()
+			mockController.On("AddAssignee", 1, assigneeID, &dto.Actor{UserID: actorID}).Return(tt.err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("POST", "/tasks/1/assignees/"+assigneeID.String(), "", actorID))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskMemberHandler_AddAssignee_InvalidUserID(t *testing.T) {
+	mockController := new(MockTaskMemberController)
+	router := newMemberRouter(mockController)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("POST", "/tasks/1/assignees/not-a-uuid", "", uuid.New()))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "AddAssignee", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskMemberHandler_RemoveAssignee_NotAssigned(t *testing.T) {
+	mockController := new(MockTaskMemberController)
+	router := newMemberRouter(mockController)
+	actorID := uuid.New()
+	userID := uuid.New()
+
+	mockController.On("RemoveAssignee", 1, userID, &dto.Actor{UserID: actorID}).
+		Return(custom_errors.NewTaskAssigneeNotFoundError(1, userID.String()))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("DELETE", "/tasks/1/assignees/"+userID.String(), "", actorID))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTaskMemberHandler_AddWatcher_MissingUser(t *testing.T) {
+	mockController := new(MockTaskMemberController)
+	router := newMemberRouter(mockController)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/tasks/1/watchers/"+uuid.New().String(), nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "AddWatcher", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskMemberHandler_RemoveWatcher(t *testing.T) {
+	mockController := new(MockTaskMemberController)
+	router := newMemberRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("RemoveWatcher", 1, userID, &dto.Actor{UserID: userID}).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("DELETE", "/tasks/1/watchers/"+userID.String(), "", userID))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+// MockTaskRecurrenceController - мок для TaskRecurrenceControllerInterface
+type MockTaskRecurrenceController struct {
+	mock.Mock
+}
+
+func (m *MockTaskRecurrenceController) Create(actor *dto.Actor, recurrenceDTO *dto.SaveTaskRecurrenceDTO) (*models.TaskRecurrence, error) {
+	args := m.Called(actor, recurrenceDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskRecurrence), args.Error(1)
+}
+
+func (m *MockTaskRecurrenceController) GetByID(id int, actor *dto.Actor) (*models.TaskRecurrence, error) {
+	args := m.Called(id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskRecurrence), args.Error(1)
+}
+
+func (m *MockTaskRecurrenceController) Update(id int, actor *dto.Actor, recurrenceDTO *dto.SaveTaskRecurrenceDTO) (*models.TaskRecurrence, error) {
+	args := m.Called(id, actor, recurrenceDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskRecurrence), args.Error(1)
+}
+
+func (m *MockTaskRecurrenceController) Delete(id int, actor *dto.Actor) error {
+	args := m.Called(id, actor)
+	return args.Error(0)
+}
+
+func newRecurrenceRouter(controller *MockTaskRecurrenceController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskRecurrenceHandler(controller)
+
+	router := gin.New()
+	router.POST("/tasks/recurrences", handler.Create)
+	router.GET("/tasks/recurrences/:recurrence_id", handler.GetByID)
+	router.PUT("/tasks/recurrences/:recurrence_id", handler.Update)
+	router.DELETE("/tasks/recurrences/:recurrence_id", handler.Delete)
+	return router
+}
+
+func TestTaskRecurrenceHandler_Create_Success(t *testing.T) {
+	mockController := new(MockTaskRecurrenceController)
+	router := newRecurrenceRouter(mockController)
+	userID := uuid.New()
+	executorID := uuid.New()
+	startsAt := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	dueAfter := 120
+
+	mockController.On("Create", &dto.Actor{UserID: userID}, &dto.SaveTaskRecurrenceDTO{
+		Title:           "Report",
+		ExecutorID:      executorID,
+		Rule:            "FREQ=WEEKLY;BYDAY=MO",
+		StartsAt:        startsAt,
+		DueAfterMinutes: &dueAfter,
+	}).Return(&models.TaskRecurrence{ID: 4, Title: "Report", NextOccurrenceAt: &startsAt}, nil)
+
+	body := `{"title":"Report","executor_id":"` + executorID.String() +
+		`","rule":"FREQ=WEEKLY;BYDAY=MO","starts_at":"2026-06-01T09:00:00Z","due_after_minutes":120}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("POST", "/tasks/recurrences", body, userID))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, float64(4), response["ID"])
+	assert.Equal(t, "2026-06-01T09:00:00Z", response["NextOccurrenceAt"])
+}
+
+func TestTaskRecurrenceHandler_Create_InvalidBody(t *testing.T) {
+	executorID := uuid.New().String()
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "missing rule", body: `{"title":"Report","executor_id":"` + executorID + `","starts_at":"2026-06-01T09:00:00Z"}`},
+		{name: "missing start", body: `{"title":"Report","executor_id":"` + executorID + `","rule":"FREQ=DAILY"}`},
+		{name: "invalid due", body: `{"title":"Report","executor_id":"` + executorID + `","rule":"FREQ=DAILY","starts_at":"2026-06-01T09:00:00Z","due_after_minutes":0}`},
+		{name: "invalid priority", body: `{"title":"Report","executor_id":"` + executorID + `","rule":"FREQ=DAILY","starts_at":"2026-06-01T09:00:00Z","priority":"asap"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskRecurrenceController)
+			router := newRecurrenceRouter(mockController)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("POST", "/tasks/recurrences", tt.body, uuid.New()))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockController.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTaskRecurrenceHandler_Update_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "invalid rule", err: custom_errors.NewInvalidRecurrenceRuleError("FREQ=DAILY", "rule has no future occurrences"), expectedCode: http.StatusBadRequest},
+		{name: "workflow not found", err: custom_errors.NewWorkflowNotFoundError(3), expectedCode: http.StatusBadRequest},
+		{name: "access denied", err: custom_errors.NewTaskRecurrenceAccessDeniedError(1, "u"), expectedCode: http.StatusForbidden},
+		{name: "not a member of chat", err: custom_errors.NewChatMembershipRequiredError("c", "u"), expectedCode: http.StatusForbidden},
+		{name: "not found", err: custom_errors.NewTaskRecurrenceNotFoundError(1), expectedCode: http.StatusNotFound},
+		{name: "user service", err: custom_errors.NewGetUserHTTPError("u", "timeout"), expectedCode: http.StatusBadGateway},
+		{name: "internal", err: errors.New("db down"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskRecurrenceController)
+			router := newRecurrenceRouter(mockController)
+			mockController.On("Update", 1, mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			body := `{"title":"Report","executor_id":"` + uuid.New().String() + `","rule":"FREQ=DAILY","starts_at":"2026-06-01T09:00:00Z"}`
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("PUT", "/tasks/recurrences/1", body, uuid.New()))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskRecurrenceHandler_GetByID_NotFound(t *testing.T) {
+	mockController := new(MockTaskRecurrenceController)
+	router := newRecurrenceRouter(mockController)
+	userID := uuid.New()
+	mockController.On("GetByID", 9, &dto.Actor{UserID: userID}).Return(nil, custom_errors.NewTaskRecurrenceNotFoundError(9))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("GET", "/tasks/recurrences/9", "", userID))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTaskRecurrenceHandler_Delete(t *testing.T) {
+	mockController := new(MockTaskRecurrenceController)
+	router := newRecurrenceRouter(mockController)
+	userID := uuid.New()
+	mockController.On("Delete", 2, &dto.Actor{UserID: userID}).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("DELETE", "/tasks/recurrences/2", "", userID))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskRecurrenceHandler_Delete_MissingUser(t *testing.T) {
+	mockController := new(MockTaskRecurrenceController)
+	router := newRecurrenceRouter(mockController)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/recurrences/2", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+)
+
+// MockTaskReportController - мок для TaskReportController
+type MockTaskReportController struct {
+	mock.Mock
+}
+
+func (m *MockTaskReportController) GetThroughput(actor *dto.Actor, query *dto.TaskReportQuery) ([]dto.TaskThroughput, error) {
+	args := m.Called(actor, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.TaskThroughput), args.Error(1)
+}
+
+func (m *MockTaskReportController) GetStatusDurations(actor *dto.Actor, query *dto.TaskReportQuery) ([]dto.TaskStatusDuration, error) {
+	args := m.Called(actor, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.TaskStatusDuration), args.Error(1)
+}
+
+func (m *MockTaskReportController) GetOverdue(actor *dto.Actor, chatID *uuid.UUID) ([]dto.TaskOverdueCount, error) {
+	args := m.Called(actor, chatID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.TaskOverdueCount), args.Error(1)
+}
+
+func (m *MockTaskReportController) GetCumulativeFlow(actor *dto.Actor, query *dto.TaskReportQuery) ([]dto.TaskCumulativeFlow, error) {
+	args := m.Called(actor, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.TaskCumulativeFlow), args.Error(1)
+}
+
+func newReportRouter(controller *MockTaskReportController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskReportHandler(controller)
+
+	router := gin.New()
+	router.GET("/reports/throughput", handler.GetThroughput)
+	router.GET("/reports/status-durations", handler.GetStatusDurations)
+	router.GET("/reports/overdue", handler.GetOverdue)
+	router.GET("/reports/cumulative-flow", handler.GetCumulativeFlow)
+	return router
+}
+
+// newReportRequest - GET-запрос отчёта от имени пользователя
+func newReportRequest(url string) *http.Request {
+	req := httptest.NewRequest("GET", url, nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
+	return req
+}
+
+// Тесты для TaskReportHandler
+
+func TestTaskReportHandler_GetThroughput_ParsesQuery(t *testing.T) {
+	controller := new(MockTaskReportController)
+	router := newReportRouter(controller)
+	chatID := uuid.New()
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	controller.On("GetThroughput", mock.Anything, &dto.TaskReportQuery{Interval: dto.ReportIntervalWeek, From: from, To: to, ChatID: &chatID}).
+		Return([]dto.TaskThroughput{{Period: from, Created: 3, Completed: 1}}, nil)
+
+	w := httptest.NewRecorder()
+	// Смещение +03:00 приводится к UTC
+	router.ServeHTTP(w, newReportRequest(
+		"/reports/throughput?interval=week&from=2026-05-01T03:00:00%2B03:00&to=2026-06-01T00:00:00Z&chat_id="+chatID.String()))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"created":3`)
+	controller.AssertExpectations(t)
+}
+
+func TestTaskReportHandler_GetThroughput_DefaultPeriod(t *testing.T) {
+	controller := new(MockTaskReportController)
+	router := newReportRouter(controller)
+
+	controller.On("GetThroughput", mock.Anything, mock.MatchedBy(func(query *dto.TaskReportQuery) bool {
+		return query.Interval == dto.ReportIntervalDay && query.ChatID == nil &&
+			query.To.Sub(query.From) == 30*24*time.Hour && time.Since(query.To) < time.Minute
+	})).Return([]dto.TaskThroughput{}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newReportRequest("/reports/throughput"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	controller.AssertExpectations(t)
+}
+
+func TestTaskReportHandler_InvalidParams(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "invalid from", url: "/reports/throughput?from=yesterday"},
+		{name: "invalid chat", url: "/reports/status-durations?chat_id=abc"},
+		{name: "invalid format", url: "/reports/overdue?format=xlsx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := new(MockTaskReportController)
+			router := newReportRouter(controller)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newReportRequest(tt.url))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Empty(t, controller.Calls)
+		})
+	}
+}
+
+func TestTaskReportHandler_GetCumulativeFlow_MissingChat(t *testing.T) {
+	controller := new(MockTaskReportController)
+	router := newReportRouter(controller)
+
+	controller.On("GetCumulativeFlow", mock.Anything, mock.Anything).
+		Return(nil, custom_errors.NewInvalidTaskReportError("chat_id is required"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newReportRequest("/reports/cumulative-flow"))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "chat_id is required")
+}
+
+func TestTaskReportHandler_GetStatusDurations_CSV(t *testing.T) {
+	controller := new(MockTaskReportController)
+	router := newReportRouter(controller)
+	statusID := 2
+
+	controller.On("GetStatusDurations", mock.Anything, mock.Anything).Return([]dto.TaskStatusDuration{
+		{StatusID: &statusID, Status: "In progress", Stays: 4, AvgMinutes: 90.25},
+		{Status: "Review, old", Stays: 1, AvgMinutes: 30},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newReportRequest("/reports/status-durations?format=csv"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "status-durations.csv")
+
+	records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"status_id", "status", "stays", "avg_minutes"},
+		{"2", "In progress", "4", "90.2"},
+		{"", "Review, old", "1", "30.0"},
+	}, records)
+}
+
+func TestTaskReportHandler_GetOverdue_CSV(t *testing.T) {
+	controller := new(MockTaskReportController)
+	router := newReportRouter(controller)
+	executorID := uuid.New()
+	dueAt := time.Date(2026, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	controller.On("GetOverdue", mock.Anything, (*uuid.UUID)(nil)).
+		Return([]dto.TaskOverdueCount{{ExecutorID: executorID, Overdue: 3, OldestDueAt: dueAt}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newReportRequest("/reports/overdue?format=csv"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "executor_id,overdue,oldest_due_at\n"+executorID.String()+",3,2026-05-20T12:00:00Z\n", w.Body.String())
+}
+
+func TestTaskReportHandler_NotChatMember(t *testing.T) {
+	controller := new(MockTaskReportController)
+	router := newReportRouter(controller)
+	chatID := uuid.New()
+
+	controller.On("GetOverdue", mock.Anything, &chatID).
+		Return(nil, custom_errors.NewChatMembershipRequiredError(chatID.String(), uuid.New().String()))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newReportRequest("/reports/overdue?chat_id="+chatID.String()))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestTaskReportHandler_MissingUser(t *testing.T) {
+	controller := new(MockTaskReportController)
+	router := newReportRouter(controller)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/reports/throughput", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, controller.Calls)
+}
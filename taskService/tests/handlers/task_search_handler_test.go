@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+)
+
+func newSearchRouter(controller *MockTaskController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskHandler(controller)
+
+	router := gin.New()
+	router.GET("/tasks/search", handler.SearchTasks)
+	return router
+}
+
+func TestTaskHandler_SearchTasks_ParsesQuery(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newSearchRouter(mockController)
+	creatorID := uuid.New()
+	chatID := uuid.New()
+	createdFrom := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cursor := (&dto.TaskCursor{SortBy: dto.TaskSortByTitle, Value: stringPtr("Отчёт"), ID: 12}).Encode()
+
+	mockController.On("QueryTasks", mock.AnythingOfType("*dto.Actor"), mock.MatchedBy(func(q *dto.TaskQuery) bool {
+		return q.Search == "квартальный отчёт" &&
+			assert.ObjectsAreEqual([]int{1, 3}, q.StatusIDs) &&
+			q.CreatorID != nil && *q.CreatorID == creatorID &&
+			q.ChatID != nil && *q.ChatID == chatID && q.ExecutorID == nil &&
+			assert.ObjectsAreEqual([]string{"high"}, q.Priorities) &&
+			assert.ObjectsAreEqual([]int{4, 9}, q.LabelIDs) &&
+			q.CreatedFrom != nil && q.CreatedFrom.Equal(createdFrom) &&
+			q.SortBy == dto.TaskSortByTitle && !q.SortDesc && q.Limit == 5 &&
+			q.Cursor != nil && q.Cursor.ID == 12
+	})).Return(&dto.TaskQueryResult{Tasks: []dto.TaskToList{{ID: 13}}, Total: 8}, nil)
+
+	w := httptest.NewRecorder()
+	req := newViewerRequest("/tasks/search?q=%D0%BA%D0%B2%D0%B0%D1%80%D1%82%D0%B0%D0%BB%D1%8C%D0%BD%D1%8B%D0%B9+%D0%BE%D1%82%D1%87%D1%91%D1%82" +
+		"&status=1,3&creator_id=" + creatorID.String() + "&chat_id=" + chatID.String() + "&priority=high&label=4,9" +
+		"&created_from=2026-01-01T00:00:00Z&sort_by=title&order=asc&limit=5&cursor=" + cursor)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dto.TaskQueryResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(8), response.Total)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_SearchTasks_ParsesWatcher(t *testing.T) {
+	mockController := new(MockTaskController)
+	router := newSearchRouter(mockController)
+	watcherID := uuid.New()
+
+	mockController.On("QueryTasks", mock.AnythingOfType("*dto.Actor"), mock.MatchedBy(func(q *dto.TaskQuery) bool {
+		return q.WatcherID != nil && *q.WatcherID == watcherID && q.ExecutorID == nil
+	})).Return(&dto.TaskQueryResult{Tasks: []dto.TaskToList{}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/tasks/search?watcher_id="+watcherID.String()))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskHandler_SearchTasks_InvalidQuery(t *testing.T) {
+	descCursor := (&dto.TaskCursor{SortBy: dto.TaskSortByCreatedAt, SortDesc: true, ID: 1}).Encode()
+
+	for _, query := range []string{
+		"status=open",
+		"creator_id=me",
+		"label=bug",
+		"start_to=tomorrow",
+		"sort_by=executor_id",
+		"limit=0",
+		"limit=500",
+		"cursor=not-a-cursor",
+		"order=asc&cursor=" + descCursor,
+	} {
+		t.Run(query, func(t *testing.T) {
+			mockController := new(MockTaskController)
+			router := newSearchRouter(mockController)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newViewerRequest("/tasks/search?"+query))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockController.AssertNotCalled(t, "QueryTasks", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -10,11 +10,13 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"taskService/internal/custom_errors"
 	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
 	"taskService/internal/models"
 )
 
@@ -39,8 +41,16 @@ func (m *MockTaskStatusController) GetByID(id int) (*models.TaskStatus, error) {
 	return args.Get(0).(*models.TaskStatus), args.Error(1)
 }
 
-func (m *MockTaskStatusController) DeleteByID(id int) error {
+func (m *MockTaskStatusController) GetUsage(id int) (*dto.TaskStatusUsage, error) {
 	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TaskStatusUsage), args.Error(1)
+}
+
+func (m *MockTaskStatusController) DeleteByID(id int, replacementID *int, actor *dto.Actor) error {
+	args := m.Called(id, replacementID, actor)
 	return args.Error(0)
 }
 
@@ -259,6 +269,63 @@ func TestTaskStatusHandler_GetByID_NotFound(t *testing.T) {
 	mockController.AssertExpectations(t)
 }
 
+// Тесты для TaskStatusHandler.GetUsage
+
+func TestTaskStatusHandler_GetUsage_Success(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockTaskStatusController)
+	handler := handlers.NewTaskStatusHandler(mockController)
+
+	usage := &dto.TaskStatusUsage{
+		StatusID:  2,
+		Name:      "in_progress",
+		Tasks:     4,
+		Workflows: []dto.TaskStatusWorkflowRef{{ID: 1, Name: "kanban"}},
+		Protected: true,
+	}
+	mockController.On("GetUsage", 2).Return(usage, nil)
+
+	router := gin.New()
+	router.GET("/tasks/statuses/:id/usage", handler.GetUsage)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/tasks/statuses/2/usage", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.TaskStatusUsage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, *usage, response)
+
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskStatusHandler_GetUsage_NotFound(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockTaskStatusController)
+	handler := handlers.NewTaskStatusHandler(mockController)
+
+	mockController.On("GetUsage", 7).Return(nil, custom_errors.NewTaskStatusNotFoundError("7"))
+
+	router := gin.New()
+	router.GET("/tasks/statuses/:id/usage", handler.GetUsage)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/tasks/statuses/7/usage", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockController.AssertExpectations(t)
+}
+
 // Тесты для TaskStatusHandler.DeleteByID
 
 func TestTaskStatusHandler_DeleteByID_Success(t *testing.T) {
@@ -268,8 +335,11 @@ func TestTaskStatusHandler_DeleteByID_Success(t *testing.T) {
 	handler := handlers.NewTaskStatusHandler(mockController)
 
 	statusID := 1
+	userID := uuid.New()
 
-	mockController.On("DeleteByID", statusID).Return(nil)
+	mockController.On("DeleteByID", statusID, (*int)(nil), mock.MatchedBy(func(actor *dto.Actor) bool {
+		return actor.UserID == userID
+	})).Return(nil)
 
 	router := gin.New()
 	router.DELETE("/tasks/statuses/:id", handler.DeleteByID)
@@ -277,6 +347,32 @@ func TestTaskStatusHandler_DeleteByID_Success(t *testing.T) {
 	// Act
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("DELETE", "/tasks/statuses/"+strconv.Itoa(statusID), nil)
+	req.Header.Set("X-User-ID", userID.String())
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskStatusHandler_DeleteByID_WithReplacement(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockTaskStatusController)
+	handler := handlers.NewTaskStatusHandler(mockController)
+
+	mockController.On("DeleteByID", 4, mock.MatchedBy(func(replacementID *int) bool {
+		return replacementID != nil && *replacementID == 3
+	}), mock.Anything).Return(nil)
+
+	router := gin.New()
+	router.DELETE("/tasks/statuses/:id", handler.DeleteByID)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/tasks/statuses/4?replacement_id=3", nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -307,7 +403,85 @@ func TestTaskStatusHandler_DeleteByID_InvalidID(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "invalid ID", response["error"])
 
-	mockController.AssertNotCalled(t, "DeleteByID", mock.Anything)
+	mockController.AssertNotCalled(t, "DeleteByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskStatusHandler_DeleteByID_InvalidReplacementID(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockTaskStatusController)
+	handler := handlers.NewTaskStatusHandler(mockController)
+
+	router := gin.New()
+	router.DELETE("/tasks/statuses/:id", handler.DeleteByID)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/tasks/statuses/4?replacement_id=abc", nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockController.AssertNotCalled(t, "DeleteByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskStatusHandler_DeleteByID_InvalidUser(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockTaskStatusController)
+	handler := handlers.NewTaskStatusHandler(mockController)
+
+	router := gin.New()
+	router.DELETE("/tasks/statuses/:id", handler.DeleteByID)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/tasks/statuses/4", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockController.AssertNotCalled(t, "DeleteByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskStatusHandler_DeleteByID_Conflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "protected", err: custom_errors.NewTaskStatusProtectedError(1, "new tasks without workflow start in it")},
+		{name: "replacement required", err: custom_errors.NewTaskStatusReplacementRequiredError(4, 3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockController := new(MockTaskStatusController)
+			handler := handlers.NewTaskStatusHandler(mockController)
+			mockController.On("DeleteByID", 4, (*int)(nil), mock.Anything).Return(tt.err)
+
+			router := gin.New()
+			router.DELETE("/tasks/statuses/:id", handler.DeleteByID)
+
+			// Act
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/tasks/statuses/4", nil)
+			req.Header.Set("X-User-ID", uuid.New().String())
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusConflict, w.Code)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.err.Error(), response["error"])
+		})
+	}
 }
 
 func TestTaskStatusHandler_DeleteByID_ControllerError(t *testing.T) {
@@ -319,7 +493,7 @@ func TestTaskStatusHandler_DeleteByID_ControllerError(t *testing.T) {
 	statusID := 1
 	controllerError := errors.New("database error")
 
-	mockController.On("DeleteByID", statusID).Return(controllerError)
+	mockController.On("DeleteByID", statusID, (*int)(nil), mock.Anything).Return(controllerError)
 
 	router := gin.New()
 	router.DELETE("/tasks/statuses/:id", handler.DeleteByID)
@@ -327,6 +501,7 @@ func TestTaskStatusHandler_DeleteByID_ControllerError(t *testing.T) {
 	// Act
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest("DELETE", "/tasks/statuses/"+strconv.Itoa(statusID), nil)
+	req.Header.Set("X-User-ID", uuid.New().String())
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -335,7 +510,7 @@ func TestTaskStatusHandler_DeleteByID_ControllerError(t *testing.T) {
 	var response map[string]interface{}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
-	assert.Equal(t, "could not delete task status", response["error"])
+	assert.Equal(t, "internal server error", response["error"])
 
 	mockController.AssertExpectations(t)
 }
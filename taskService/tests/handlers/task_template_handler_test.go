@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+// MockTaskTemplateController - мок для TaskTemplateControllerInterface
+type MockTaskTemplateController struct {
+	mock.Mock
+}
+
+func (m *MockTaskTemplateController) Create(actor *dto.Actor, templateDTO *dto.SaveTaskTemplateDTO) (*models.TaskTemplate, error) {
+	args := m.Called(actor, templateDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskTemplateController) GetByID(id int, actor *dto.Actor) (*models.TaskTemplate, error) {
+	args := m.Called(id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskTemplateController) GetAll(actor *dto.Actor) ([]models.TaskTemplate, error) {
+	args := m.Called(actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskTemplateController) Update(id int, actor *dto.Actor, templateDTO *dto.SaveTaskTemplateDTO) (*models.TaskTemplate, error) {
+	args := m.Called(id, actor, templateDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTemplate), args.Error(1)
+}
+
+func (m *MockTaskTemplateController) Delete(id int, actor *dto.Actor) error {
+	args := m.Called(id, actor)
+	return args.Error(0)
+}
+
+func (m *MockTaskTemplateController) CreateTask(id int, actor *dto.Actor, fromTemplateDTO *dto.CreateTaskFromTemplateDTO) (*models.Task, error) {
+	args := m.Called(id, actor, fromTemplateDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Task), args.Error(1)
+}
+
+func newTemplateRouter(controller *MockTaskTemplateController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskTemplateHandler(controller)
+
+	router := gin.New()
+	router.POST("/tasks/templates", handler.Create)
+	router.GET("/tasks/templates", handler.GetAll)
+	router.GET("/tasks/templates/:template_id", handler.GetByID)
+	router.PUT("/tasks/templates/:template_id", handler.Update)
+	router.DELETE("/tasks/templates/:template_id", handler.Delete)
+	router.POST("/tasks/templates/:template_id/tasks", handler.CreateTask)
+	return router
+}
+
+func TestTaskTemplateHandler_Create_Success(t *testing.T) {
+	mockController := new(MockTaskTemplateController)
+	router := newTemplateRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("Create", &dto.Actor{UserID: userID}, &dto.SaveTaskTemplateDTO{
+		Name:           "Релиз",
+		TitlePattern:   "Релиз {version}",
+		LabelIDs:       []int{3},
+		ChecklistItems: []string{"Собрать", "Выкатить"},
+	}).Return(&models.TaskTemplate{ID: 5, Name: "Релиз"}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("POST", "/tasks/templates",
+		`{"name":"Релиз","title_pattern":"Релиз {version}","label_ids":[3],"checklist_items":["Собрать","Выкатить"]}`, userID))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskTemplateHandler_Create_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		err          error
+		expectedCode int
+	}{
+		{name: "no name", body: `{"title_pattern":"x"}`, expectedCode: http.StatusBadRequest},
+		{name: "empty checklist item", body: `{"name":"a","title_pattern":"x","checklist_items":[""]}`, expectedCode: http.StatusBadRequest},
+		{name: "name taken", body: `{"name":"a","title_pattern":"x"}`,
+			err: custom_errors.ErrTaskTemplateAlreadyExists, expectedCode: http.StatusConflict},
+		{name: "label not found", body: `{"name":"a","title_pattern":"x","label_ids":[9]}`,
+			err: custom_errors.NewLabelNotFoundError(9), expectedCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskTemplateController)
+			router := newTemplateRouter(mockController)
+			mockController.On("Create", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("POST", "/tasks/templates", tt.body, uuid.New()))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.err == nil {
+				mockController.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestTaskTemplateHandler_CreateTask_Success(t *testing.T) {
+	mockController := new(MockTaskTemplateController)
+	router := newTemplateRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("CreateTask", 4, &dto.Actor{UserID: userID}, &dto.CreateTaskFromTemplateDTO{
+		Variables: map[string]string{"version": "1.2"},
+	}).Return(&models.Task{ID: 30, Title: "Релиз 1.2", Checklist: []models.TaskChecklistItem{{ID: 1, Title: "Собрать"}}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("POST", "/tasks/templates/4/tasks", `{"variables":{"version":"1.2"}}`, userID))
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var task models.Task
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+	assert.Equal(t, "Релиз 1.2", task.Title)
+	assert.Len(t, task.Checklist, 1)
+}
+
+func TestTaskTemplateHandler_CreateTask_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "template not found", err: custom_errors.NewTaskTemplateNotFoundError(4), expectedCode: http.StatusNotFound},
+		{name: "missing variable", err: custom_errors.NewInvalidTaskTemplateError(4, "no value for {version}"), expectedCode: http.StatusBadRequest},
+		{name: "parent not found", err: custom_errors.NewParentTaskNotFoundError(8), expectedCode: http.StatusBadRequest},
+		{name: "user service down", err: custom_errors.NewGetUserHTTPError("u", "timeout"), expectedCode: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskTemplateController)
+			router := newTemplateRouter(mockController)
+			mockController.On("CreateTask", 4, mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("POST", "/tasks/templates/4/tasks", `{}`, uuid.New()))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskTemplateHandler_Delete_AccessDenied(t *testing.T) {
+	mockController := new(MockTaskTemplateController)
+	router := newTemplateRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("Delete", 4, &dto.Actor{UserID: userID}).Return(custom_errors.NewTaskTemplateAccessDeniedError(4, userID.String()))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("DELETE", "/tasks/templates/4", "", userID))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+// MockTaskTimeLogController - мок для TaskTimeLogController
+type MockTaskTimeLogController struct {
+	mock.Mock
+}
+
+func (m *MockTaskTimeLogController) StartTimer(taskID int, actor *dto.Actor) (*models.TaskTimeLog, error) {
+	args := m.Called(taskID, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskTimeLogController) StopTimer(taskID int, actor *dto.Actor) (*models.TaskTimeLog, error) {
+	args := m.Called(taskID, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskTimeLogController) Create(taskID int, actor *dto.Actor, logDTO *dto.CreateTaskTimeLogDTO) (*models.TaskTimeLog, error) {
+	args := m.Called(taskID, actor, logDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskTimeLogController) Update(taskID, logID int, actor *dto.Actor, updateDTO *dto.UpdateTaskTimeLogDTO) (*models.TaskTimeLog, error) {
+	args := m.Called(taskID, logID, actor, updateDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskTimeLogController) Delete(taskID, logID int, actor *dto.Actor) error {
+	args := m.Called(taskID, logID, actor)
+	return args.Error(0)
+}
+
+func (m *MockTaskTimeLogController) GetByTaskID(taskID int, actor *dto.Actor, limit, offset int) ([]models.TaskTimeLog, error) {
+	args := m.Called(taskID, actor, limit, offset)
+	return args.Get(0).([]models.TaskTimeLog), args.Error(1)
+}
+
+func (m *MockTaskTimeLogController) GetTimeSpent(actor *dto.Actor, query *dto.TimeSpentQuery) ([]dto.TimeSpent, error) {
+	args := m.Called(actor, query)
+	return args.Get(0).([]dto.TimeSpent), args.Error(1)
+}
+
+func newTimeLogRouter(controller *MockTaskTimeLogController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskTimeLogHandler(controller)
+
+	router := gin.New()
+	router.POST("/tasks/:task_id/time-logs", handler.Create)
+	router.GET("/tasks/:task_id/time-logs", handler.GetByTaskID)
+	router.POST("/tasks/:task_id/time-logs/start", handler.StartTimer)
+	router.POST("/tasks/:task_id/time-logs/stop", handler.StopTimer)
+	router.PATCH("/tasks/:task_id/time-logs/:log_id", handler.Update)
+	router.DELETE("/tasks/:task_id/time-logs/:log_id", handler.Delete)
+	router.GET("/time-spent", handler.GetTimeSpent)
+	return router
+}
+
+func TestTaskTimeLogHandler_StartTimer(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "success", expectedCode: http.StatusCreated},
+		{name: "not executor", err: custom_errors.NewTaskAccessDeniedError(1, "u"), expectedCode: http.StatusForbidden},
+		{name: "already running", err: custom_errors.NewTaskTimerAlreadyRunningError("u", 2), expectedCode: http.StatusConflict},
+		{name: "task not found", err: custom_errors.NewTaskNotFoundError(1), expectedCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskTimeLogController)
+			router := newTimeLogRouter(mockController)
+			userID := uuid.New()
+			var log *models.TaskTimeLog
+			if tt.err == nil {
+				log = &models.TaskTimeLog{ID: 7, TaskID: 1, UserID: userID, StartedAt: time.Now()}
+			}
+			mockController.On("StartTimer", 1, &dto.Actor{UserID: userID}).Return(log, tt.err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newCommentRequest("POST", "/tasks/1/time-logs/start", "", userID))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskTimeLogHandler_StopTimer_NotRunning(t *testing.T) {
+	mockController := new(MockTaskTimeLogController)
+	router := newTimeLogRouter(mockController)
+	userID := uuid.New()
+
+	mockController.On("StopTimer", 1, &dto.Actor{UserID: userID}).
+		Return(nil, custom_errors.NewTaskTimerNotRunningError(1, userID.String()))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("POST", "/tasks/1/time-logs/stop", "", userID))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTaskTimeLogHandler_Create_InvalidMinutes(t *testing.T) {
+	mockController := new(MockTaskTimeLogController)
+	router := newTimeLogRouter(mockController)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("POST", "/tasks/1/time-logs", `{"started_at":"2026-05-01T10:00:00Z","minutes":0}`, uuid.New()))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTaskTimeLogHandler_Update_Forbidden(t *testing.T) {
+	mockController := new(MockTaskTimeLogController)
+	router := newTimeLogRouter(mockController)
+	userID := uuid.New()
+	description := "review"
+
+	mockController.On("Update", 1, 7, &dto.Actor{UserID: userID}, &dto.UpdateTaskTimeLogDTO{Description: &description}).
+		Return(nil, custom_errors.NewTaskTimeLogAccessDeniedError(7, userID.String()))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newCommentRequest("PATCH", "/tasks/1/time-logs/7", `{"description":"review"}`, userID))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestTaskTimeLogHandler_GetTimeSpent(t *testing.T) {
+	mockController := new(MockTaskTimeLogController)
+	router := newTimeLogRouter(mockController)
+	chatID := uuid.New()
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mockController.On("GetTimeSpent", mock.AnythingOfType("*dto.Actor"), &dto.TimeSpentQuery{GroupBy: dto.TimeSpentByUser, From: &from, To: &to, ChatID: &chatID}).
+		Return([]dto.TimeSpent{{Minutes: 120, Entries: 3}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/time-spent?group_by=user&from=2026-05-01T00:00:00Z&to=2026-06-01T00:00:00Z&chat_id="+chatID.String()))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"minutes":120,"entries":3}]`, w.Body.String())
+}
+
+func TestTaskTimeLogHandler_GetTimeSpent_InvalidGroup(t *testing.T) {
+	mockController := new(MockTaskTimeLogController)
+	router := newTimeLogRouter(mockController)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newViewerRequest("/time-spent?group_by=label"))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetTimeSpent", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/models"
+)
+
+// MockTaskWorkflowController - мок для TaskWorkflowController
+type MockTaskWorkflowController struct {
+	mock.Mock
+}
+
+func (m *MockTaskWorkflowController) Create(workflowDTO *dto.SaveWorkflowDTO) (*models.TaskWorkflow, error) {
+	args := m.Called(workflowDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskWorkflowController) Update(id int, workflowDTO *dto.SaveWorkflowDTO) (*models.TaskWorkflow, error) {
+	args := m.Called(id, workflowDTO)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskWorkflowController) GetByID(id int) (*models.TaskWorkflow, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskWorkflowController) GetAll() ([]models.TaskWorkflow, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskWorkflow), args.Error(1)
+}
+
+func (m *MockTaskWorkflowController) DeleteByID(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func setupWorkflowRouter(controller *MockTaskWorkflowController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewTaskWorkflowHandler(controller)
+	router := gin.New()
+	router.POST("/tasks/workflows", handler.Create)
+	router.GET("/tasks/workflows", handler.GetAll)
+	router.GET("/tasks/workflows/:workflow_id", handler.GetByID)
+	router.PUT("/tasks/workflows/:workflow_id", handler.Update)
+	router.DELETE("/tasks/workflows/:workflow_id", handler.DeleteByID)
+	return router
+}
+
+func TestTaskWorkflowHandler_Create_Success(t *testing.T) {
+	mockController := new(MockTaskWorkflowController)
+	router := setupWorkflowRouter(mockController)
+
+	mockController.On("Create", mock.MatchedBy(func(d *dto.SaveWorkflowDTO) bool {
+		return d.Name == "dev" && len(d.StatusIDs) == 2 && d.Transitions[0].AllowedRole == "executor"
+	})).Return(&models.TaskWorkflow{ID: 3, Name: "dev"}, nil)
+
+	body := `{"name":"dev","status_ids":[1,2],"transitions":[{"from_status_id":1,"to_status_id":2,"allowed_role":"executor"}]}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/tasks/workflows", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response models.TaskWorkflow
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 3, response.ID)
+	mockController.AssertExpectations(t)
+}
+
+func TestTaskWorkflowHandler_Create_InvalidBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "no statuses", body: `{"name":"dev","status_ids":[]}`},
+		{name: "unknown role", body: `{"name":"dev","status_ids":[1,2],"transitions":[{"from_status_id":1,"to_status_id":2,"allowed_role":"admin"}]}`},
+		{name: "no name", body: `{"status_ids":[1]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskWorkflowController)
+			router := setupWorkflowRouter(mockController)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/workflows", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockController.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestTaskWorkflowHandler_Create_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "already exists", err: custom_errors.ErrWorkflowAlreadyExists, expectedCode: http.StatusConflict},
+		{name: "invalid workflow", err: custom_errors.NewInvalidWorkflowError("status 5 not found"), expectedCode: http.StatusBadRequest},
+		{name: "internal", err: errors.New("db down"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockController := new(MockTaskWorkflowController)
+			router := setupWorkflowRouter(mockController)
+			mockController.On("Create", mock.Anything).Return(nil, tt.err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tasks/workflows", bytes.NewBufferString(`{"name":"dev","status_ids":[1]}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestTaskWorkflowHandler_Update_NotFound(t *testing.T) {
+	mockController := new(MockTaskWorkflowController)
+	router := setupWorkflowRouter(mockController)
+	mockController.On("Update", 9, mock.Anything).Return(nil, custom_errors.NewWorkflowNotFoundError(9))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/tasks/workflows/9", bytes.NewBufferString(`{"name":"dev","status_ids":[1]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTaskWorkflowHandler_GetByID(t *testing.T) {
+	mockController := new(MockTaskWorkflowController)
+	router := setupWorkflowRouter(mockController)
+	mockController.On("GetByID", 3).Return(&models.TaskWorkflow{ID: 3, Name: "dev"}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/workflows/3", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/workflows/abc", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTaskWorkflowHandler_GetAll(t *testing.T) {
+	mockController := new(MockTaskWorkflowController)
+	router := setupWorkflowRouter(mockController)
+	mockController.On("GetAll").Return([]models.TaskWorkflow{{ID: 1, Name: "default", IsDefault: true}}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/tasks/workflows", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []models.TaskWorkflow
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+}
+
+func TestTaskWorkflowHandler_DeleteByID(t *testing.T) {
+	mockController := new(MockTaskWorkflowController)
+	router := setupWorkflowRouter(mockController)
+	mockController.On("DeleteByID", 3).Return(nil)
+	mockController.On("DeleteByID", 4).Return(custom_errors.NewWorkflowNotFoundError(4))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/workflows/3", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/tasks/workflows/4", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -14,6 +14,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+
+	taskConfig "taskService/internal/config"
+	"taskService/internal/handlers/dto"
+	"taskService/internal/http_clients"
 	"taskService/internal/services"
 
 	"gorm.io/gorm"
@@ -79,6 +84,8 @@ func cleanupTestData(t *testing.T, db *gorm.DB) {
 	// Удаляем тестовые задачи и связанные данные
 	db.Exec("DELETE FROM task_service.task_files WHERE task_id IN (SELECT id FROM task_service.tasks WHERE title LIKE 'test_%')")
 	db.Exec("DELETE FROM task_service.tasks WHERE title LIKE 'test_%'")
+	db.Exec("DELETE FROM task_service.task_recurrences WHERE title LIKE 'test_%'")
+	db.Exec("DELETE FROM task_service.labels WHERE name LIKE 'test_%'")
 	// Статусы не удаляем, так как они нужны для тестов
 }
 
@@ -110,6 +117,20 @@ func setupTestKafkaProducer(t *testing.T, topic string) services.NotificationPro
 	return safeProducer
 }
 
+// setupTestChatMemberships создает кэш членства в чатах поверх чат-сервиса из CHAT_SERVICE_URL
+func setupTestChatMemberships() services.ChatMembershipServiceInterface {
+	return services.NewChatMembershipCache(
+		http_clients.NewChatClientAdapter(),
+		taskConfig.ChatMembershipCacheConfig{TTL: time.Minute},
+	)
+}
+
+// testAdmin - пользователь с manage_all_tasks: списки задач для него не ограничиваются чатами,
+// поэтому чат-сервис в таких тестах не нужен
+func testAdmin() *dto.Actor {
+	return &dto.Actor{UserID: uuid.New(), Permissions: []string{dto.PermissionManageAllTasks}}
+}
+
 // getKafkaBrokers получает список Kafka брокеров из переменных окружения
 func getKafkaBrokers() []string {
 	brokers := os.Getenv("KAFKA_BROKERS")
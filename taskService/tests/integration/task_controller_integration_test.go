@@ -5,6 +5,7 @@ package integration
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -15,7 +16,9 @@ import (
 	cc "common/contracts/chat-contracts"
 	fc "common/contracts/file-contracts"
 	cuc "common/contracts/user-contracts"
+	taskConfig "taskService/internal/config"
 	"taskService/internal/controllers"
+	customErrors "taskService/internal/custom_errors"
 	"taskService/internal/handlers/dto"
 	"taskService/internal/http_clients"
 	"taskService/internal/models"
@@ -223,10 +226,13 @@ func TestTaskController_Create_Integration(t *testing.T) {
 		taskRepo,
 		taskStatusRepo,
 		taskFileRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
 		notificationService,
 		userClient,
 		chatClient,
 		fileClient,
+		setupTestChatMemberships(),
 	)
 
 	creatorID := uuid.New()
@@ -238,14 +244,13 @@ func TestTaskController_Create_Integration(t *testing.T) {
 	createDTO := &dto.CreateTaskDTO{
 		Title:       "test_CreateTask_Integration",
 		Description: &description,
-		CreatorID:   creatorID,
 		ExecutorID:  executorID,
 		ChatID:      chatID,
 		FileIDs:     fileIDs,
 	}
 
 	// Act - выполнение реального сценария
-	task, err := controller.Create(createDTO)
+	task, err := controller.Create(&dto.Actor{UserID: creatorID}, createDTO)
 
 	// Assert - проверка реального результата
 	require.NoError(t, err)
@@ -292,23 +297,25 @@ func TestTaskController_Create_Integration_WithoutExecutor(t *testing.T) {
 		taskRepo,
 		taskStatusRepo,
 		taskFileRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
 		notificationService,
 		userClient,
 		chatClient,
 		fileClient,
+		setupTestChatMemberships(),
 	)
 
 	creatorID := uuid.New()
 	createDTO := &dto.CreateTaskDTO{
 		Title:      "test_CreateTask_WithoutExecutor",
-		CreatorID:  creatorID,
 		ExecutorID: uuid.Nil, // Нет исполнителя
 		ChatID:     uuid.Nil,
 		FileIDs:    []int{},
 	}
 
 	// Act
-	task, err := controller.Create(createDTO)
+	task, err := controller.Create(&dto.Actor{UserID: creatorID}, createDTO)
 
 	// Assert
 	require.NoError(t, err)
@@ -348,23 +355,25 @@ func TestTaskController_Create_Integration_UserNotFound(t *testing.T) {
 		taskRepo,
 		taskStatusRepo,
 		taskFileRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
 		notificationService,
 		userClient,
 		chatClient,
 		fileClient,
+		setupTestChatMemberships(),
 	)
 
 	creatorID := uuid.New()
 	createDTO := &dto.CreateTaskDTO{
 		Title:      "test_CreateTask_UserNotFound",
-		CreatorID:  creatorID,
 		ExecutorID: uuid.Nil,
 		ChatID:     uuid.Nil,
 		FileIDs:    []int{},
 	}
 
 	// Act
-	task, err := controller.Create(createDTO)
+	task, err := controller.Create(&dto.Actor{UserID: creatorID}, createDTO)
 
 	// Assert
 	require.Error(t, err)
@@ -404,24 +413,26 @@ func TestTaskController_Create_Integration_ChatNotFound(t *testing.T) {
 		taskRepo,
 		taskStatusRepo,
 		taskFileRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
 		notificationService,
 		userClient,
 		chatClient,
 		fileClient,
+		setupTestChatMemberships(),
 	)
 
 	creatorID := uuid.New()
 	chatID := uuid.New()
 	createDTO := &dto.CreateTaskDTO{
 		Title:      "test_CreateTask_ChatNotFound",
-		CreatorID:  creatorID,
 		ExecutorID: uuid.Nil,
 		ChatID:     chatID,
 		FileIDs:    []int{},
 	}
 
 	// Act
-	task, err := controller.Create(createDTO)
+	task, err := controller.Create(&dto.Actor{UserID: creatorID}, createDTO)
 
 	// Assert
 	require.Error(t, err)
@@ -461,23 +472,25 @@ func TestTaskController_Create_Integration_FileNotFound(t *testing.T) {
 		taskRepo,
 		taskStatusRepo,
 		taskFileRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
 		notificationService,
 		userClient,
 		chatClient,
 		fileClient,
+		setupTestChatMemberships(),
 	)
 
 	creatorID := uuid.New()
 	createDTO := &dto.CreateTaskDTO{
 		Title:      "test_CreateTask_FileNotFound",
-		CreatorID:  creatorID,
 		ExecutorID: uuid.Nil,
 		ChatID:     uuid.Nil,
 		FileIDs:    []int{999}, // Несуществующий файл
 	}
 
 	// Act
-	task, err := controller.Create(createDTO)
+	task, err := controller.Create(&dto.Actor{UserID: creatorID}, createDTO)
 
 	// Assert
 	require.Error(t, err)
@@ -526,14 +539,17 @@ func TestTaskController_GetByID_Integration(t *testing.T) {
 		taskRepo,
 		taskStatusRepo,
 		taskFileRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
 		nil, // NotificationService не нужен для GetByID
 		nil, // UserClient не нужен
 		nil, // ChatClient не нужен
 		fileClient,
+		setupTestChatMemberships(),
 	)
 
 	// Act
-	result, err := controller.GetByID(task.ID)
+	result, err := controller.GetByID(task.ID, &dto.Actor{UserID: task.CreatorID})
 
 	// Assert
 	require.NoError(t, err)
@@ -584,14 +600,17 @@ func TestTaskController_GetByID_Integration_FileNotFound(t *testing.T) {
 		taskRepo,
 		taskStatusRepo,
 		taskFileRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
 		nil,
 		nil,
 		nil,
 		fileClient,
+		setupTestChatMemberships(),
 	)
 
 	// Act
-	result, err := controller.GetByID(task.ID)
+	result, err := controller.GetByID(task.ID, &dto.Actor{UserID: task.CreatorID})
 
 	// Assert
 	require.Error(t, err)
@@ -624,19 +643,22 @@ func TestTaskController_UpdateStatus_Integration(t *testing.T) {
 	err = taskRepo.Create(task)
 	require.NoError(t, err)
 
-	// Создаем новый статус для обновления
-	newStatus, err := taskStatusRepo.Create("test_status")
+	// Workflow по умолчанию разрешает создателю переход created -> canseled
+	newStatus, err := taskStatusRepo.GetByName("canseled")
 	require.NoError(t, err)
 
 	controller := controllers.NewTaskController(
 		taskRepo,
 		taskStatusRepo,
 		taskFileRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
 		nil,
+		setupTestChatMemberships(),
 	)
 
 	// Act
-	err = controller.UpdateStatus(task.ID, newStatus.ID)
+	err = controller.UpdateStatus(task.ID, newStatus.ID, &dto.Actor{UserID: task.CreatorID})
 
 	// Assert
 	require.NoError(t, err)
@@ -645,6 +667,19 @@ func TestTaskController_UpdateStatus_Integration(t *testing.T) {
 	updatedTask, err := taskRepo.GetByID(task.ID)
 	require.NoError(t, err)
 	assert.Equal(t, newStatus.ID, updatedTask.StatusID)
+
+	// Смена статуса попала в историю задачи
+	history, err := controller.GetHistory(task.ID, &dto.Actor{UserID: task.CreatorID}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, *history, 1)
+	assert.Equal(t, models.TaskEventStatusChanged, (*history)[0].EventType)
+	assert.Equal(t, "created", *(*history)[0].OldValue)
+	assert.Equal(t, "canseled", *(*history)[0].NewValue)
+	assert.Equal(t, task.Title, (*history)[0].TaskTitle)
+
+	feed, err := controller.GetUserActivity(task.CreatorID.String(), &dto.Actor{UserID: task.CreatorID}, 10, 0)
+	require.NoError(t, err)
+	assert.NotEmpty(t, *feed)
 }
 
 // TestTaskController_UpdateStatus_Integration_StatusNotFound тестирует обработку ошибки, когда статус не найден
@@ -675,17 +710,67 @@ func TestTaskController_UpdateStatus_Integration_StatusNotFound(t *testing.T) {
 		taskRepo,
 		taskStatusRepo,
 		taskFileRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
 		nil,
+		setupTestChatMemberships(),
 	)
 
 	// Act - пытаемся обновить на несуществующий статус
-	err = controller.UpdateStatus(task.ID, 99999)
+	err = controller.UpdateStatus(task.ID, 99999, &dto.Actor{UserID: task.CreatorID})
 
 	// Assert
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "task status")
 }
 
+// TestTaskController_UpdateStatus_Integration_IllegalTransition проверяет, что workflow по умолчанию
+// не пропускает переход, которого в нём нет
+func TestTaskController_UpdateStatus_Integration_IllegalTransition(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Arrange
+	db := setupTestDB(t)
+	taskRepo := repositories.NewTaskRepository(db)
+	taskStatusRepo := repositories.NewTaskStatusRepository(db)
+	taskFileRepo := repositories.NewTaskFileRepository(db)
+
+	status, err := taskStatusRepo.GetByName("created")
+	require.NoError(t, err)
+
+	task := &models.Task{
+		Title:     "test_UpdateStatus_IllegalTransition",
+		CreatorID: uuid.New(),
+		StatusID:  status.ID,
+		Status:    status,
+	}
+	err = taskRepo.Create(task)
+	require.NoError(t, err)
+
+	newStatus, err := taskStatusRepo.Create("test_status_outside_workflow")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = taskStatusRepo.DeleteByID(newStatus.ID) })
+
+	controller := controllers.NewTaskController(
+		taskRepo,
+		taskStatusRepo,
+		taskFileRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
+		nil,
+		setupTestChatMemberships(),
+	)
+
+	// Act
+	err = controller.UpdateStatus(task.ID, newStatus.ID, &dto.Actor{UserID: task.CreatorID})
+
+	// Assert
+	var transitionErr *customErrors.IllegalStatusTransitionError
+	assert.True(t, errors.As(err, &transitionErr))
+}
+
 // TestTaskController_GetUserTasks_Integration тестирует получение задач пользователя с реальной БД
 func TestTaskController_GetUserTasks_Integration(t *testing.T) {
 	if testing.Short() {
@@ -719,11 +804,14 @@ func TestTaskController_GetUserTasks_Integration(t *testing.T) {
 		taskRepo,
 		taskStatusRepo,
 		taskFileRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
 		nil,
+		setupTestChatMemberships(),
 	)
 
 	// Act
-	tasks, err := controller.GetUserTasks(userID.String(), 10, 0)
+	tasks, err := controller.GetUserTasks(userID.String(), testAdmin(), nil, 10, 0)
 
 	// Assert
 	require.NoError(t, err)
@@ -731,6 +819,343 @@ func TestTaskController_GetUserTasks_Integration(t *testing.T) {
 	assert.Len(t, *tasks, 3)
 }
 
+// TestTaskRepository_GetUserTasks_FilterAndSort_Integration проверяет фильтр по приоритету и сортировку по сроку
+func TestTaskRepository_GetUserTasks_FilterAndSort_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := setupTestDB(t)
+	taskRepo := repositories.NewTaskRepository(db)
+	status, err := repositories.NewTaskStatusRepository(db).GetByName("created")
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+	later := now.Add(48 * time.Hour)
+	sooner := now.Add(2 * time.Hour)
+	for _, task := range []*models.Task{
+		{Title: "test_filter_low", Priority: models.TaskPriorityLow, DueAt: &sooner},
+		{Title: "test_filter_urgent_later", Priority: models.TaskPriorityUrgent, DueAt: &later},
+		{Title: "test_filter_high_sooner", Priority: models.TaskPriorityHigh, DueAt: &sooner},
+		{Title: "test_filter_high_no_due", Priority: models.TaskPriorityHigh},
+	} {
+		task.CreatorID = uuid.New()
+		task.ExecutorID = userID
+		task.StatusID = status.ID
+		require.NoError(t, taskRepo.Create(task))
+	}
+
+	tasks, err := taskRepo.GetUserTasks(userID.String(), &dto.TaskListFilter{
+		Priorities: []string{models.TaskPriorityHigh, models.TaskPriorityUrgent},
+		SortBy:     dto.TaskSortByDueAt,
+	}, 10, 0, nil)
+	require.NoError(t, err)
+	require.Len(t, *tasks, 3)
+	assert.Equal(t, "test_filter_high_sooner", (*tasks)[0].Title)
+	assert.Equal(t, "test_filter_urgent_later", (*tasks)[1].Title)
+	assert.Equal(t, "test_filter_high_no_due", (*tasks)[2].Title, "tasks without due date go last")
+
+	tasks, err = taskRepo.GetUserTasks(userID.String(), &dto.TaskListFilter{SortBy: dto.TaskSortByPriority, SortDesc: true}, 1, 0, nil)
+	require.NoError(t, err)
+	require.Len(t, *tasks, 1)
+	assert.Equal(t, models.TaskPriorityUrgent, (*tasks)[0].Priority)
+}
+
+// TestTaskRepository_QueryTasks_Integration проверяет фильтры, полнотекстовый поиск, total и проход по курсору
+func TestTaskRepository_QueryTasks_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := setupTestDB(t)
+	taskRepo := repositories.NewTaskRepository(db)
+	controller := controllers.NewTaskController(taskRepo, nil, nil, nil, nil, nil, setupTestChatMemberships())
+	status, err := repositories.NewTaskStatusRepository(db).GetByName("created")
+	require.NoError(t, err)
+
+	chatID := uuid.New()
+	soon := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	for _, task := range []*models.Task{
+		{Title: "test_query квартальный отчёт", Description: "собрать цифры", Priority: models.TaskPriorityHigh, DueAt: &soon},
+		{Title: "test_query ревью", Description: "квартальный план", Priority: models.TaskPriorityLow},
+		{Title: "test_query отчёт без срока", Description: "квартальный", Priority: models.TaskPriorityUrgent},
+		{Title: "test_query другое", Description: "не подходит", Priority: models.TaskPriorityHigh},
+	} {
+		task.CreatorID = uuid.New()
+		task.ChatID = chatID
+		task.StatusID = status.ID
+		require.NoError(t, taskRepo.Create(task))
+	}
+
+	query := &dto.TaskQuery{
+		TaskListFilter: dto.TaskListFilter{SortBy: dto.TaskSortByDueAt},
+		ChatID:         &chatID,
+		StatusIDs:      []int{status.ID},
+		Search:         "квартальный",
+		Limit:          2,
+	}
+	first, err := controller.QueryTasks(testAdmin(), query)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), first.Total)
+	require.Len(t, first.Tasks, 2)
+	assert.Equal(t, "test_query квартальный отчёт", first.Tasks[0].Title, "tasks with due date go first")
+	require.NotNil(t, first.NextCursor)
+
+	query.Cursor, err = dto.DecodeTaskCursor(*first.NextCursor)
+	require.NoError(t, err)
+	second, err := controller.QueryTasks(testAdmin(), query)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), second.Total)
+	require.Len(t, second.Tasks, 1)
+	assert.Nil(t, second.NextCursor)
+	assert.NotContains(t, []int{first.Tasks[0].ID, first.Tasks[1].ID}, second.Tasks[0].ID)
+
+	prioritized, err := controller.QueryTasks(testAdmin(), &dto.TaskQuery{
+		TaskListFilter: dto.TaskListFilter{
+			Priorities: []string{models.TaskPriorityHigh, models.TaskPriorityUrgent},
+			SortBy:     dto.TaskSortByPriority,
+			SortDesc:   true,
+		},
+		ChatID: &chatID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, prioritized.Tasks, 3)
+	assert.Equal(t, models.TaskPriorityUrgent, prioritized.Tasks[0].Priority)
+}
+
+// TestTaskRepository_DeadlineReminders_Integration проверяет выборку задач для напоминаний и однократность отметки
+func TestTaskRepository_DeadlineReminders_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := setupTestDB(t)
+	taskRepo := repositories.NewTaskRepository(db)
+	status, err := repositories.NewTaskStatusRepository(db).GetByName("created")
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	dueSoon := now.Add(time.Hour)
+	overdue := now.Add(-time.Hour)
+	soonTask := &models.Task{Title: "test_reminder_soon", CreatorID: uuid.New(), ExecutorID: uuid.New(), StatusID: status.ID, DueAt: &dueSoon}
+	overdueTask := &models.Task{Title: "test_reminder_overdue", CreatorID: uuid.New(), ExecutorID: uuid.New(), StatusID: status.ID, DueAt: &overdue}
+	require.NoError(t, taskRepo.Create(soonTask))
+	require.NoError(t, taskRepo.Create(overdueTask))
+
+	candidates, err := taskRepo.GetDueSoon(now, now.Add(24*time.Hour), 100)
+	require.NoError(t, err)
+	assert.True(t, containsTask(candidates, soonTask.ID))
+	assert.False(t, containsTask(candidates, overdueTask.ID))
+
+	claimed, err := taskRepo.ClaimDueSoonReminder(soonTask.ID, dueSoon, now)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	claimed, err = taskRepo.ClaimDueSoonReminder(soonTask.ID, dueSoon, now)
+	require.NoError(t, err)
+	assert.False(t, claimed, "reminder must be claimed only once")
+
+	candidates, err = taskRepo.GetOverdue(now, 100)
+	require.NoError(t, err)
+	assert.True(t, containsTask(candidates, overdueTask.ID))
+	assert.False(t, containsTask(candidates, soonTask.ID))
+}
+
+// TestTaskBulkRepository_StatusGuard_Integration проверяет, что смена статуса не сохраняется,
+// если задачу перевели в другой статус после проверки перехода
+func TestTaskBulkRepository_StatusGuard_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := setupTestDB(t)
+	taskRepo := repositories.NewTaskRepository(db)
+	bulkRepo := repositories.NewTaskBulkRepository(db)
+	statusRepo := repositories.NewTaskStatusRepository(db)
+	created, err := statusRepo.GetByName("created")
+	require.NoError(t, err)
+	canceled, err := statusRepo.GetByName("canseled")
+	require.NoError(t, err)
+
+	task := &models.Task{Title: "test_bulk_guard", CreatorID: uuid.New(), ExecutorID: uuid.New(), StatusID: created.ID}
+	require.NoError(t, taskRepo.Create(task))
+	require.NoError(t, taskRepo.UpdateStatus(task.ID, canceled.ID))
+
+	err = bulkRepo.Apply([]dto.TaskBulkChange{{TaskID: task.ID, StatusID: &canceled.ID, FromStatusID: &created.ID}})
+	var changedErr *customErrors.TaskStatusChangedError
+	assert.True(t, errors.As(err, &changedErr))
+
+	err = bulkRepo.Apply([]dto.TaskBulkChange{{TaskID: task.ID, StatusID: &created.ID, FromStatusID: &canceled.ID}})
+	require.NoError(t, err)
+	stored, err := taskRepo.GetByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, stored.StatusID)
+}
+
+// TestTaskTemplateRepository_CreateTask_Integration проверяет, что задача по шаблону сохраняется вместе с метками
+// и чек-листом, а при ошибке любой части не сохраняется совсем
+func TestTaskTemplateRepository_CreateTask_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := setupTestDB(t)
+	templateRepo := repositories.NewTaskTemplateRepository(db)
+	status, err := repositories.NewTaskStatusRepository(db).GetByName("created")
+	require.NoError(t, err)
+	label := &models.Label{Name: "test_template_label", Color: "#00ff00"}
+	require.NoError(t, repositories.NewLabelRepository(db).Create(label))
+
+	checklist := []models.TaskChecklistItem{{Title: "Первый", Position: 0}, {Title: "Второй", Position: 1}}
+	task := &models.Task{Title: "test_from_template", CreatorID: uuid.New(), ExecutorID: uuid.New(), StatusID: status.ID}
+	require.NoError(t, templateRepo.CreateTask(task, nil, []int{label.ID}, checklist))
+	assert.Equal(t, task.ID, checklist[1].TaskID)
+	var labels int64
+	require.NoError(t, db.Model(&models.TaskLabel{}).Where("task_id = ?", task.ID).Count(&labels).Error)
+	assert.Equal(t, int64(1), labels)
+
+	// Несуществующая метка отменяет всю транзакцию
+	failed := &models.Task{Title: "test_from_template_failed", CreatorID: uuid.New(), ExecutorID: uuid.New(), StatusID: status.ID}
+	assert.Error(t, templateRepo.CreateTask(failed, nil, []int{label.ID, 999999}, []models.TaskChecklistItem{{Title: "Пункт"}}))
+	var stored int64
+	require.NoError(t, db.Model(&models.Task{}).Where("title = ?", "test_from_template_failed").Count(&stored).Error)
+	assert.Zero(t, stored)
+}
+
+// TestTaskCommentRepository_Integration проверяет сохранение комментариев с вложениями и упоминаниями,
+// их правку и мягкое удаление
+func TestTaskCommentRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := setupTestDB(t)
+	taskRepo := repositories.NewTaskRepository(db)
+	commentRepo := repositories.NewTaskCommentRepository(db)
+	status, err := repositories.NewTaskStatusRepository(db).GetByName("created")
+	require.NoError(t, err)
+
+	task := &models.Task{Title: "test_comments", CreatorID: uuid.New(), ExecutorID: uuid.New(), StatusID: status.ID}
+	require.NoError(t, taskRepo.Create(task))
+
+	authorID := uuid.New()
+	mentionedID := uuid.New()
+	first := &models.TaskComment{
+		TaskID:   task.ID,
+		AuthorID: authorID,
+		Body:     "@someone look",
+		Files:    []models.TaskCommentFile{{FileID: 1}, {FileID: 2}},
+		Mentions: []models.TaskCommentMention{{UserID: mentionedID}},
+	}
+	require.NoError(t, commentRepo.Create(first))
+	second := &models.TaskComment{TaskID: task.ID, AuthorID: task.CreatorID, Body: "ok"}
+	require.NoError(t, commentRepo.Create(second))
+
+	now := time.Now()
+	first.Body = "edited"
+	first.UpdatedAt = &now
+	first.Mentions = nil
+	require.NoError(t, commentRepo.Update(first, []models.TaskCommentFile{{CommentID: first.ID, FileID: 3}}, []int{1}))
+
+	stored, err := commentRepo.GetByID(first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "edited", stored.Body)
+	assert.NotNil(t, stored.UpdatedAt)
+	assert.Empty(t, stored.Mentions)
+	assert.ElementsMatch(t, []int{2, 3}, []int{stored.Files[0].FileID, stored.Files[1].FileID})
+
+	authors, err := commentRepo.GetAuthorIDs(task.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{authorID, task.CreatorID}, authors)
+
+	require.NoError(t, commentRepo.Delete(second.ID))
+	comments, err := commentRepo.GetByTaskID(task.ID, 20, 0)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, first.ID, comments[0].ID)
+
+	var notFoundErr *customErrors.TaskCommentNotFoundError
+	assert.True(t, errors.As(commentRepo.Delete(second.ID), &notFoundErr))
+}
+
+// TestTaskDependencies_Integration проверяет дерево подзадач, поиск открытых блокирующих задач
+// и обход графа зависимостей
+func TestTaskDependencies_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := setupTestDB(t)
+	taskRepo := repositories.NewTaskRepository(db)
+	dependencyRepo := repositories.NewTaskDependencyRepository(db)
+	statusRepo := repositories.NewTaskStatusRepository(db)
+	created, err := statusRepo.GetByName("created")
+	require.NoError(t, err)
+	canceled, err := statusRepo.GetByName("canseled")
+	require.NoError(t, err)
+
+	newTask := func(title string, statusID int, parentID *int) *models.Task {
+		task := &models.Task{Title: title, CreatorID: uuid.New(), StatusID: statusID, ParentTaskID: parentID}
+		require.NoError(t, taskRepo.Create(task))
+		return task
+	}
+	root := newTask("test_tree_root", created.ID, nil)
+	child := newTask("test_tree_child", canceled.ID, &root.ID)
+	grandchild := newTask("test_tree_grandchild", created.ID, &child.ID)
+	deleted := newTask("test_tree_deleted", created.ID, &root.ID)
+	require.NoError(t, taskRepo.Delete(deleted.ID))
+
+	subtree, err := taskRepo.GetSubtree(root.ID)
+	require.NoError(t, err)
+	ids := make([]int, 0, len(subtree))
+	for _, node := range subtree {
+		ids = append(ids, node.ID)
+		if node.ID == child.ID {
+			assert.True(t, node.Closed, "canceled task is in a terminal status of the default workflow")
+		}
+	}
+	assert.ElementsMatch(t, []int{root.ID, child.ID, grandchild.ID}, ids)
+
+	visible, err := taskRepo.GetVisibleIDs(ids, &dto.TaskVisibility{UserID: child.CreatorID})
+	require.NoError(t, err)
+	assert.Equal(t, []int{child.ID}, visible)
+
+	// root <- child (закрыта), root <- grandchild (открыта)
+	require.NoError(t, dependencyRepo.Create(&models.TaskDependency{BlockerTaskID: child.ID, BlockedTaskID: root.ID}))
+	require.NoError(t, dependencyRepo.Create(&models.TaskDependency{BlockerTaskID: grandchild.ID, BlockedTaskID: root.ID}))
+	require.NoError(t, dependencyRepo.Create(&models.TaskDependency{BlockerTaskID: grandchild.ID, BlockedTaskID: root.ID}), "duplicate is ignored")
+
+	blockers, err := taskRepo.GetOpenBlockerIDs(root.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{grandchild.ID}, blockers)
+
+	reachable, err := dependencyRepo.HasPath(grandchild.ID, root.ID)
+	require.NoError(t, err)
+	assert.True(t, reachable)
+	reachable, err = dependencyRepo.HasPath(root.ID, grandchild.ID)
+	require.NoError(t, err)
+	assert.False(t, reachable)
+
+	nodes, edges, err := dependencyRepo.GetGraph(child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []dto.TaskDependencyEdge{{BlockerTaskID: child.ID, BlockedTaskID: root.ID}}, edges)
+	assert.Len(t, nodes, 2)
+
+	require.NoError(t, dependencyRepo.Delete(grandchild.ID, root.ID))
+	var notFoundErr *customErrors.TaskDependencyNotFoundError
+	assert.True(t, errors.As(dependencyRepo.Delete(grandchild.ID, root.ID), &notFoundErr))
+}
+
+func containsTask(tasks []models.Task, taskID int) bool {
+	for _, task := range tasks {
+		if task.ID == taskID {
+			return true
+		}
+	}
+	return false
+}
+
 // TestTaskController_Create_Integration_KafkaNotification тестирует отправку уведомления через Kafka
 func TestTaskController_Create_Integration_KafkaNotification(t *testing.T) {
 	if testing.Short() {
@@ -757,24 +1182,26 @@ func TestTaskController_Create_Integration_KafkaNotification(t *testing.T) {
 		taskRepo,
 		taskStatusRepo,
 		taskFileRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
 		notificationService,
 		userClient,
 		chatClient,
 		fileClient,
+		setupTestChatMemberships(),
 	)
 
 	creatorID := uuid.New()
 	executorID := uuid.New()
 	createDTO := &dto.CreateTaskDTO{
 		Title:      "test_KafkaNotification",
-		CreatorID:  creatorID,
 		ExecutorID: executorID,
 		ChatID:     uuid.Nil,
 		FileIDs:    []int{},
 	}
 
 	// Act
-	task, err := controller.Create(createDTO)
+	task, err := controller.Create(&dto.Actor{UserID: creatorID}, createDTO)
 
 	// Assert
 	require.NoError(t, err)
@@ -782,3 +1209,183 @@ func TestTaskController_Create_Integration_KafkaNotification(t *testing.T) {
 	// Уведомление отправляется асинхронно, поэтому мы не можем проверить его напрямую
 	// Но если ошибки нет, значит интеграция работает
 }
+
+func TestTaskLabels_UsageAndFilter_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := setupTestDB(t)
+	taskRepo := repositories.NewTaskRepository(db)
+	labelController := controllers.NewTaskLabelController(
+		repositories.NewLabelRepository(db), taskRepo, repositories.NewTaskEventRepository(db), setupTestChatMemberships())
+	status, err := repositories.NewTaskStatusRepository(db).GetByName("created")
+	require.NoError(t, err)
+
+	bug, err := labelController.Create(&dto.SaveLabelDTO{Name: "test_bug", Color: "#ff0000"})
+	require.NoError(t, err)
+	_, err = labelController.Create(&dto.SaveLabelDTO{Name: "test_bug", Color: "#00ff00"})
+	assert.ErrorIs(t, err, customErrors.ErrLabelAlreadyExists)
+
+	chatID := uuid.New()
+	var tasks []*models.Task
+	for _, title := range []string{"test_label first", "test_label second"} {
+		task := &models.Task{Title: title, CreatorID: uuid.New(), ChatID: chatID, StatusID: status.ID, Priority: models.TaskPriorityNormal}
+		require.NoError(t, taskRepo.Create(task))
+		tasks = append(tasks, task)
+	}
+
+	actor := &dto.Actor{UserID: tasks[0].CreatorID}
+	require.NoError(t, labelController.AddToTask(tasks[0].ID, bug.ID, actor))
+	require.NoError(t, labelController.AddToTask(tasks[0].ID, bug.ID, actor), "repeated assignment is a no-op")
+
+	label, err := labelController.GetByID(bug.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), label.UsageCount)
+
+	result, err := controllers.NewTaskController(taskRepo, nil, nil, nil, nil, nil, setupTestChatMemberships()).QueryTasks(testAdmin(), &dto.TaskQuery{
+		TaskListFilter: dto.TaskListFilter{LabelIDs: []int{bug.ID}, SortBy: dto.TaskSortByCreatedAt},
+		ChatID:         &chatID,
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Tasks, 1)
+	assert.Equal(t, tasks[0].ID, result.Tasks[0].ID)
+
+	require.NoError(t, labelController.RemoveFromTask(tasks[0].ID, bug.ID, actor))
+	err = labelController.RemoveFromTask(tasks[0].ID, bug.ID, actor)
+	var taskLabelErr *customErrors.TaskLabelNotFoundError
+	assert.True(t, errors.As(err, &taskLabelErr))
+}
+
+// TestTaskBoard_MoveTask_Integration проверяет порядок карточек на доске и перенос карточки между колонками
+func TestTaskBoard_MoveTask_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := setupTestDB(t)
+	taskRepo := repositories.NewTaskRepository(db)
+	taskStatusRepo := repositories.NewTaskStatusRepository(db)
+	controller := controllers.NewTaskController(
+		taskRepo,
+		taskStatusRepo,
+		repositories.NewTaskFileRepository(db),
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
+		nil,
+		setupTestChatMemberships(),
+	)
+	created, err := taskStatusRepo.GetByName("created")
+	require.NoError(t, err)
+	canceled, err := taskStatusRepo.GetByName("canseled")
+	require.NoError(t, err)
+
+	chatID := uuid.New()
+	creatorID := uuid.New()
+	var tasks []*models.Task
+	for _, title := range []string{"test_board first", "test_board second", "test_board third"} {
+		task := &models.Task{Title: title, CreatorID: creatorID, ChatID: chatID, StatusID: created.ID, Priority: models.TaskPriorityNormal}
+		require.NoError(t, taskRepo.Create(task))
+		tasks = append(tasks, task)
+	}
+
+	boardColumn := func(statusID int) []int {
+		board, err := controller.GetBoard(testAdmin(), &dto.TaskBoardQuery{ChatID: &chatID, Limit: 50})
+		require.NoError(t, err)
+		for _, column := range board.Columns {
+			if column.StatusID == statusID {
+				ids := []int{}
+				for _, card := range column.Tasks {
+					ids = append(ids, card.ID)
+				}
+				return ids
+			}
+		}
+		return nil
+	}
+	assert.Equal(t, []int{tasks[0].ID, tasks[1].ID, tasks[2].ID}, boardColumn(created.ID))
+
+	actor := &dto.Actor{UserID: creatorID}
+	require.NoError(t, controller.MoveTask(tasks[2].ID, actor, &dto.MoveTaskDTO{StatusID: created.ID, BeforeTaskID: &tasks[0].ID}))
+	assert.Equal(t, []int{tasks[2].ID, tasks[0].ID, tasks[1].ID}, boardColumn(created.ID))
+
+	// Workflow по умолчанию разрешает создателю переход created -> canseled
+	require.NoError(t, controller.MoveTask(tasks[0].ID, actor, &dto.MoveTaskDTO{StatusID: canceled.ID}))
+	assert.Equal(t, []int{tasks[2].ID, tasks[1].ID}, boardColumn(created.ID))
+	assert.Equal(t, []int{tasks[0].ID}, boardColumn(canceled.ID))
+
+	// Перенос по устаревшему статусу не проходит
+	err = taskRepo.MoveOnBoard(tasks[0].ID, created.ID, canceled.ID, "z")
+	var moveErr *customErrors.InvalidTaskMoveError
+	assert.True(t, errors.As(err, &moveErr))
+}
+
+func TestTaskRecurrence_Scheduler_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := setupTestDB(t)
+	recurrenceRepo := repositories.NewTaskRecurrenceRepository(db)
+	taskRepo := repositories.NewTaskRepository(db)
+	taskStatusRepo := repositories.NewTaskStatusRepository(db)
+	created, err := taskStatusRepo.GetByName("created")
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	startsAt := now.Add(time.Hour)
+	recurrence := &models.TaskRecurrence{
+		Title:            "test_recurrence daily",
+		CreatorID:        uuid.New(),
+		ExecutorID:       uuid.New(),
+		Priority:         models.TaskPriorityNormal,
+		Rule:             "FREQ=DAILY",
+		StartsAt:         startsAt,
+		NextOccurrenceAt: &startsAt,
+	}
+	require.NoError(t, recurrenceRepo.Create(recurrence))
+
+	scheduler := services.NewTaskRecurrenceScheduler(
+		recurrenceRepo,
+		taskStatusRepo,
+		repositories.NewTaskWorkflowRepository(db),
+		repositories.NewTaskEventRepository(db),
+		nil,
+		nil,
+		nil,
+		taskConfig.RecurrenceSchedulerConfig{Interval: time.Minute, Horizon: 50 * time.Hour, BatchSize: 10},
+	)
+
+	occurrences := func() []models.Task {
+		var tasks []models.Task
+		require.NoError(t, db.Where("recurrence_id = ?", recurrence.ID).Order("occurrence_at").Find(&tasks).Error)
+		return tasks
+	}
+
+	// Повторный запуск, как и запуск на другой реплике, не создаёт экземпляры заново
+	require.NoError(t, scheduler.RunOnce(now))
+	require.NoError(t, scheduler.RunOnce(now))
+	tasks := occurrences()
+	require.Len(t, tasks, 3)
+	assert.True(t, tasks[0].OccurrenceAt.Equal(startsAt))
+	assert.Equal(t, created.ID, tasks[0].StatusID)
+
+	stored, err := recurrenceRepo.GetByID(recurrence.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.NextOccurrenceAt.Equal(startsAt.AddDate(0, 0, 3)))
+
+	// Экземпляр, изменённый отдельно, не получает изменения серии
+	require.NoError(t, taskRepo.Detach(tasks[1].ID))
+	stored.Title = "test_recurrence renamed"
+	require.NoError(t, recurrenceRepo.UpdatePendingOccurrences(stored, now))
+	tasks = occurrences()
+	assert.Equal(t, "test_recurrence renamed", tasks[0].Title)
+	assert.Equal(t, "test_recurrence daily", tasks[1].Title)
+	assert.Equal(t, "test_recurrence renamed", tasks[2].Title)
+
+	require.NoError(t, recurrenceRepo.DeletePendingOccurrences(recurrence.ID, now))
+	tasks = occurrences()
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "test_recurrence daily", tasks[0].Title)
+}
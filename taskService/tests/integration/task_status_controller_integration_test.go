@@ -7,9 +7,12 @@ import (
 	"testing"
 
 	"taskService/internal/controllers"
+	"taskService/internal/custom_errors"
+	"taskService/internal/handlers/dto"
 	"taskService/internal/models"
 	"taskService/internal/repositories"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -180,7 +183,7 @@ func TestTaskStatusController_DeleteByID_Integration(t *testing.T) {
 	require.NoError(t, err)
 
 	// Act
-	err = controller.DeleteByID(createdStatus.ID)
+	err = controller.DeleteByID(createdStatus.ID, nil, &dto.Actor{UserID: uuid.New()})
 
 	// Assert
 	require.NoError(t, err)
@@ -191,6 +194,60 @@ func TestTaskStatusController_DeleteByID_Integration(t *testing.T) {
 	assert.Error(t, err, "Status should be deleted from database")
 }
 
+// TestTaskStatusController_DeleteByID_Integration_ReplacementOutsideWorkflow проверяет, что задачи не переносятся
+// в статус, которого нет в их workflow, и что задачи статуса считаются в транзакции удаления
+func TestTaskStatusController_DeleteByID_Integration_ReplacementOutsideWorkflow(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Arrange
+	db := setupTestDB(t)
+	repo := repositories.NewTaskStatusRepository(db)
+	controller := controllers.NewTaskStatusController(repo)
+	taskRepo := repositories.NewTaskRepository(db)
+	workflowRepo := repositories.NewTaskWorkflowRepository(db)
+
+	created, err := repo.GetByName(models.TaskStatusCreated)
+	require.NoError(t, err)
+	canceled, err := repo.GetByName("canseled")
+	require.NoError(t, err)
+	obsolete, err := controller.Create("test_obsolete_status")
+	require.NoError(t, err)
+	outside, err := controller.Create("test_outside_status")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM task_service.task_workflows WHERE name = 'test_replacement_workflow'")
+		db.Exec("DELETE FROM task_service.task_statuses WHERE name IN ('test_obsolete_status', 'test_outside_status')")
+	})
+
+	workflow := &models.TaskWorkflow{
+		Name: "test_replacement_workflow",
+		Statuses: []models.TaskWorkflowStatus{
+			{StatusID: created.ID, Position: 0},
+			{StatusID: canceled.ID, Position: 1},
+		},
+		Transitions: []models.TaskWorkflowTransition{
+			{FromStatusID: created.ID, ToStatusID: canceled.ID, AllowedRole: models.TransitionRoleAny},
+		},
+	}
+	require.NoError(t, workflowRepo.Create(workflow))
+	task := &models.Task{Title: "test_status_replacement", CreatorID: uuid.New(), ExecutorID: uuid.New(), StatusID: obsolete.ID, WorkflowID: &workflow.ID}
+	require.NoError(t, taskRepo.Create(task))
+
+	// Act & Assert: без статуса для переноса и со статусом вне workflow задачи статус не удаляется
+	var replacementErr *custom_errors.TaskStatusReplacementRequiredError
+	assert.ErrorAs(t, controller.DeleteByID(obsolete.ID, nil, testAdmin()), &replacementErr)
+	var invalidErr *custom_errors.InvalidStatusReplacementError
+	require.ErrorAs(t, controller.DeleteByID(obsolete.ID, &outside.ID, testAdmin()), &invalidErr)
+	assert.Contains(t, invalidErr.Error(), "test_replacement_workflow")
+
+	require.NoError(t, controller.DeleteByID(obsolete.ID, &canceled.ID, testAdmin()))
+	stored, err := taskRepo.GetByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, canceled.ID, stored.StatusID)
+}
+
 // TestTaskStatusController_DeleteByID_Integration_NotFound тестирует обработку ошибки при удалении несуществующего статуса
 func TestTaskStatusController_DeleteByID_Integration_NotFound(t *testing.T) {
 	if testing.Short() {
@@ -205,12 +262,11 @@ func TestTaskStatusController_DeleteByID_Integration_NotFound(t *testing.T) {
 	nonExistentID := 99999
 
 	// Act
-	err := controller.DeleteByID(nonExistentID)
+	err := controller.DeleteByID(nonExistentID, nil, &dto.Actor{UserID: uuid.New()})
 
 	// Assert
-	// GORM не возвращает ошибку при удалении несуществующей записи
-	// Это нормальное поведение, поэтому тест просто проверяет, что нет паники
-	assert.NoError(t, err)
+	var notFoundErr *custom_errors.TaskStatusNotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
 }
 
 // TestTaskStatusController_FullFlow_Integration тестирует полный цикл работы со статусами
@@ -254,7 +310,7 @@ func TestTaskStatusController_FullFlow_Integration(t *testing.T) {
 	assert.True(t, found, "Created status should be in GetAll result")
 
 	// 4. Удаление
-	err = controller.DeleteByID(createdStatus.ID)
+	err = controller.DeleteByID(createdStatus.ID, nil, &dto.Actor{UserID: uuid.New()})
 	require.NoError(t, err)
 
 	// 5. Проверка, что статус удален
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/config"
+	"taskService/internal/http_clients"
+	"taskService/internal/services"
+)
+
+// MockMembershipChatClient - мок ChatClientInterface только с методом, который использует кэш
+type MockMembershipChatClient struct {
+	http_clients.ChatClientInterface
+	mock.Mock
+}
+
+func (m *MockMembershipChatClient) GetUserChatIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func TestChatMembershipCache_GetUserChatIDs_CachesWithinTTL(t *testing.T) {
+	chatClient := new(MockMembershipChatClient)
+	cache := services.NewChatMembershipCache(chatClient, config.ChatMembershipCacheConfig{TTL: time.Minute})
+	userID := uuid.New()
+	chatIDs := []uuid.UUID{uuid.New()}
+
+	chatClient.On("GetUserChatIDs", userID).Return(chatIDs, nil).Once()
+
+	first, err := cache.GetUserChatIDs(userID)
+	require.NoError(t, err)
+	second, err := cache.GetUserChatIDs(userID)
+	require.NoError(t, err)
+
+	assert.Equal(t, chatIDs, first)
+	assert.Equal(t, chatIDs, second)
+	chatClient.AssertExpectations(t)
+}
+
+func TestChatMembershipCache_GetUserChatIDs_RefreshesAfterTTL(t *testing.T) {
+	chatClient := new(MockMembershipChatClient)
+	cache := services.NewChatMembershipCache(chatClient, config.ChatMembershipCacheConfig{TTL: 0})
+	userID := uuid.New()
+	removed := []uuid.UUID{}
+
+	chatClient.On("GetUserChatIDs", userID).Return([]uuid.UUID{uuid.New()}, nil).Once()
+	chatClient.On("GetUserChatIDs", userID).Return(removed, nil).Once()
+
+	_, err := cache.GetUserChatIDs(userID)
+	require.NoError(t, err)
+	chatIDs, err := cache.GetUserChatIDs(userID)
+	require.NoError(t, err)
+
+	assert.Empty(t, chatIDs)
+	chatClient.AssertExpectations(t)
+}
+
+func TestChatMembershipCache_GetUserChatIDs_ErrorNotCached(t *testing.T) {
+	chatClient := new(MockMembershipChatClient)
+	cache := services.NewChatMembershipCache(chatClient, config.ChatMembershipCacheConfig{TTL: time.Minute})
+	userID := uuid.New()
+	chatIDs := []uuid.UUID{uuid.New()}
+
+	chatClient.On("GetUserChatIDs", userID).Return(nil, errors.New("chat service down")).Once()
+	chatClient.On("GetUserChatIDs", userID).Return(chatIDs, nil).Once()
+
+	_, err := cache.GetUserChatIDs(userID)
+	require.Error(t, err)
+	result, err := cache.GetUserChatIDs(userID)
+	require.NoError(t, err)
+
+	assert.Equal(t, chatIDs, result)
+	chatClient.AssertExpectations(t)
+}
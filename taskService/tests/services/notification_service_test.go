@@ -3,8 +3,10 @@ package services
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"common/kafka"
+	"common/models"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -232,3 +234,99 @@ func createTestExecutorID() uuid.UUID {
 func createTestExecutorEmail() string {
 	return "executor@example.com"
 }
+
+// Тесты для напоминаний о сроках задач
+
+func TestNotificationService_SendTaskDeadlineNotifications(t *testing.T) {
+	mockProducer := new(MockNotificationProducer)
+	service := services.NewNotificationServiceWithProducer(mockProducer)
+	executorID := uuid.New()
+	dueAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mockProducer.On("SendNotification", mock.MatchedBy(func(n *models.TaskDeadlineNotification) bool {
+		return n.Type == models.NotificationTaskDueSoon && n.TaskID == 7 && n.ExecutorID == executorID && n.DueAt.Equal(dueAt)
+	})).Return(nil).Once()
+	mockProducer.On("SendNotification", mock.MatchedBy(func(n *models.TaskDeadlineNotification) bool {
+		return n.Type == models.NotificationTaskOverdue && n.Priority == "urgent"
+	})).Return(nil).Once()
+
+	require.NoError(t, service.SendTaskDueSoonNotification(7, "Report", "high", executorID, "executor@example.com", dueAt))
+	require.NoError(t, service.SendTaskOverdueNotification(7, "Report", "urgent", executorID, "executor@example.com", dueAt))
+	mockProducer.AssertExpectations(t)
+}
+
+func TestNotificationService_SendTaskOverdueNotification_EmptyEmail(t *testing.T) {
+	mockProducer := new(MockNotificationProducer)
+	service := services.NewNotificationServiceWithProducer(mockProducer)
+
+	err := service.SendTaskOverdueNotification(1, "Task", "normal", uuid.New(), "", time.Now())
+
+	require.NoError(t, err)
+	mockProducer.AssertNotCalled(t, "SendNotification", mock.Anything)
+}
+
+func TestNotificationService_SendTaskDueSoonNotification_ProducerError(t *testing.T) {
+	mockProducer := new(MockNotificationProducer)
+	service := services.NewNotificationServiceWithProducer(mockProducer)
+	mockProducer.On("SendNotification", mock.Anything).Return(errors.New("kafka down"))
+
+	err := service.SendTaskDueSoonNotification(1, "Task", "normal", uuid.New(), "executor@example.com", time.Now())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "kafka down")
+}
+
+// Тесты для уведомлений о комментариях
+
+func TestNotificationService_SendTaskCommentNotification(t *testing.T) {
+	mockProducer := new(MockNotificationProducer)
+	service := services.NewNotificationServiceWithProducer(mockProducer)
+	recipientID := uuid.New()
+
+	mockProducer.On("SendNotification", mock.MatchedBy(func(n *models.TaskCommentNotification) bool {
+		return n.Type == models.NotificationTaskComment && n.CommentID == 3 && n.RecipientID == recipientID && n.AuthorName == "alice"
+	})).Return(nil).Once()
+	mockProducer.On("SendNotification", mock.MatchedBy(func(n *models.TaskCommentNotification) bool {
+		return n.Type == models.NotificationTaskMention && n.Email == "bob@example.com"
+	})).Return(nil).Once()
+
+	require.NoError(t, service.SendTaskCommentNotification(7, "Report", 3, "alice", "text", recipientID, "creator@example.com", false))
+	require.NoError(t, service.SendTaskCommentNotification(7, "Report", 3, "alice", "@bob", uuid.New(), "bob@example.com", true))
+	mockProducer.AssertExpectations(t)
+}
+
+func TestNotificationService_SendTaskCommentNotification_EmptyEmail(t *testing.T) {
+	mockProducer := new(MockNotificationProducer)
+	service := services.NewNotificationServiceWithProducer(mockProducer)
+
+	err := service.SendTaskCommentNotification(7, "Report", 3, "alice", "text", uuid.New(), "", true)
+
+	require.NoError(t, err)
+	mockProducer.AssertNotCalled(t, "SendNotification", mock.Anything)
+}
+
+// Тесты для уведомлений о смене статуса
+
+func TestNotificationService_SendTaskStatusChangedNotification(t *testing.T) {
+	mockProducer := new(MockNotificationProducer)
+	service := services.NewNotificationServiceWithProducer(mockProducer)
+	recipientID := uuid.New()
+
+	mockProducer.On("SendNotification", mock.MatchedBy(func(n *models.TaskStatusChangedNotification) bool {
+		return n.Type == models.NotificationTaskStatusChanged && n.Email == "watcher@example.com" &&
+			n.OldStatus == "To do" && n.NewStatus == "Done" && n.RecipientID == recipientID
+	})).Return(nil).Once()
+
+	require.NoError(t, service.SendTaskStatusChangedNotification(7, "Report", "alice", "To do", "Done", recipientID, "watcher@example.com"))
+	mockProducer.AssertExpectations(t)
+}
+
+func TestNotificationService_SendTaskStatusChangedNotification_EmptyEmail(t *testing.T) {
+	mockProducer := new(MockNotificationProducer)
+	service := services.NewNotificationServiceWithProducer(mockProducer)
+
+	err := service.SendTaskStatusChangedNotification(7, "Report", "alice", "To do", "Done", uuid.New(), "")
+
+	require.NoError(t, err)
+	mockProducer.AssertNotCalled(t, "SendNotification", mock.Anything)
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	cuc "common/contracts/user-contracts"
+	commonModels "common/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/config"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+	"taskService/internal/services"
+)
+
+// MockDeadlineTaskRepository - мок TaskRepository только с методами, которые использует планировщик
+type MockDeadlineTaskRepository struct {
+	repositories.TaskRepository
+	mock.Mock
+}
+
+func (m *MockDeadlineTaskRepository) GetDueSoon(now, until time.Time, limit int) ([]models.Task, error) {
+	args := m.Called(now, until, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockDeadlineTaskRepository) GetOverdue(now time.Time, limit int) ([]models.Task, error) {
+	args := m.Called(now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockDeadlineTaskRepository) ClaimDueSoonReminder(taskID int, dueAt, now time.Time) (bool, error) {
+	args := m.Called(taskID, dueAt, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockDeadlineTaskRepository) ClaimOverdueReminder(taskID int, dueAt, now time.Time) (bool, error) {
+	args := m.Called(taskID, dueAt, now)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockUserClient - мок для UserClientInterface
+type MockUserClient struct {
+	mock.Mock
+}
+
+func (m *MockUserClient) GetUserByID(userID *uuid.UUID) (*cuc.Response, error) {
+	args := m.Called(*userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cuc.Response), args.Error(1)
+}
+
+func (m *MockUserClient) GetUsersByIDs(userIDs []uuid.UUID) (*cuc.UsersResponse, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cuc.UsersResponse), args.Error(1)
+}
+
+func (m *MockUserClient) GetUsersByUsernames(usernames []string) (*cuc.UsersResponse, error) {
+	args := m.Called(usernames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cuc.UsersResponse), args.Error(1)
+}
+
+func newTestDeadlineScheduler(repo *MockDeadlineTaskRepository, userClient *MockUserClient, producer *MockNotificationProducer) *services.TaskDeadlineScheduler {
+	return services.NewTaskDeadlineScheduler(
+		repo,
+		userClient,
+		services.NewNotificationServiceWithProducer(producer),
+		config.DeadlineSchedulerConfig{Interval: time.Minute, DueSoonWindow: 24 * time.Hour, BatchSize: 10},
+	)
+}
+
+func TestTaskDeadlineScheduler_RunOnce_SendsReminders(t *testing.T) {
+	repo := new(MockDeadlineTaskRepository)
+	userClient := new(MockUserClient)
+	producer := new(MockNotificationProducer)
+	scheduler := newTestDeadlineScheduler(repo, userClient, producer)
+
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	soonDue := now.Add(3 * time.Hour)
+	overdueDue := now.Add(-time.Hour)
+	executorID := uuid.New()
+	soon := models.Task{ID: 1, Title: "Soon", Priority: models.TaskPriorityHigh, ExecutorID: executorID, DueAt: &soonDue}
+	overdue := models.Task{ID: 2, Title: "Late", Priority: models.TaskPriorityNormal, ExecutorID: executorID, DueAt: &overdueDue}
+
+	repo.On("GetDueSoon", now, now.Add(24*time.Hour), 10).Return([]models.Task{soon}, nil)
+	repo.On("GetOverdue", now, 10).Return([]models.Task{overdue}, nil)
+	repo.On("ClaimDueSoonReminder", 1, soonDue, now).Return(true, nil)
+	repo.On("ClaimOverdueReminder", 2, overdueDue, now).Return(true, nil)
+	userClient.On("GetUserByID", executorID).Return(&cuc.Response{User: &cuc.User{Email: "executor@example.com"}}, nil)
+	producer.On("SendNotification", mock.MatchedBy(func(n *commonModels.TaskDeadlineNotification) bool {
+		return n.Type == "task_due_soon" && n.TaskID == 1 && n.Priority == "high" && n.DueAt.Equal(soonDue) && n.Email == "executor@example.com"
+	})).Return(nil).Once()
+	producer.On("SendNotification", mock.MatchedBy(func(n *commonModels.TaskDeadlineNotification) bool {
+		return n.Type == "task_overdue" && n.TaskID == 2
+	})).Return(nil).Once()
+
+	require.NoError(t, scheduler.RunOnce(now))
+
+	repo.AssertExpectations(t)
+	producer.AssertExpectations(t)
+}
+
+func TestTaskDeadlineScheduler_RunOnce_SkipsClaimedByOtherReplica(t *testing.T) {
+	repo := new(MockDeadlineTaskRepository)
+	userClient := new(MockUserClient)
+	producer := new(MockNotificationProducer)
+	scheduler := newTestDeadlineScheduler(repo, userClient, producer)
+
+	now := time.Now()
+	due := now.Add(time.Hour)
+	task := models.Task{ID: 1, ExecutorID: uuid.New(), DueAt: &due}
+
+	repo.On("GetDueSoon", now, now.Add(24*time.Hour), 10).Return([]models.Task{task}, nil)
+	repo.On("GetOverdue", now, 10).Return([]models.Task{}, nil)
+	repo.On("ClaimDueSoonReminder", 1, due, now).Return(false, nil)
+
+	require.NoError(t, scheduler.RunOnce(now))
+
+	userClient.AssertNotCalled(t, "GetUserByID", mock.Anything)
+	producer.AssertNotCalled(t, "SendNotification", mock.Anything)
+}
+
+func TestTaskDeadlineScheduler_RunOnce_ContinuesAfterUserError(t *testing.T) {
+	repo := new(MockDeadlineTaskRepository)
+	userClient := new(MockUserClient)
+	producer := new(MockNotificationProducer)
+	scheduler := newTestDeadlineScheduler(repo, userClient, producer)
+
+	now := time.Now()
+	due := now.Add(time.Hour)
+	missing := models.Task{ID: 1, ExecutorID: uuid.New(), DueAt: &due}
+	found := models.Task{ID: 2, ExecutorID: uuid.New(), DueAt: &due}
+
+	repo.On("GetDueSoon", mock.Anything, mock.Anything, 10).Return([]models.Task{missing, found}, nil)
+	repo.On("GetOverdue", now, 10).Return([]models.Task{}, nil)
+	repo.On("ClaimDueSoonReminder", mock.Anything, due, now).Return(true, nil)
+	userClient.On("GetUserByID", missing.ExecutorID).Return(nil, errors.New("user service unavailable"))
+	userClient.On("GetUserByID", found.ExecutorID).Return(&cuc.Response{User: &cuc.User{Email: "found@example.com"}}, nil)
+	producer.On("SendNotification", mock.AnythingOfType("*models.TaskDeadlineNotification")).Return(nil).Once()
+
+	require.NoError(t, scheduler.RunOnce(now))
+
+	producer.AssertExpectations(t)
+}
+
+func TestTaskDeadlineScheduler_RunOnce_RepositoryError(t *testing.T) {
+	repo := new(MockDeadlineTaskRepository)
+	scheduler := newTestDeadlineScheduler(repo, new(MockUserClient), new(MockNotificationProducer))
+
+	repo.On("GetDueSoon", mock.Anything, mock.Anything, 10).Return(nil, errors.New("db down"))
+
+	err := scheduler.RunOnce(time.Now())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "db down")
+	repo.AssertNotCalled(t, "GetOverdue", mock.Anything, mock.Anything)
+}
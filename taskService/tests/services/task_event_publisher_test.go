@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	commonModels "common/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"taskService/internal/models"
+	"taskService/internal/services"
+)
+
+// MockTaskEventProducer - мок для TaskEventProducerInterface
+type MockTaskEventProducer struct {
+	mock.Mock
+}
+
+func (m *MockTaskEventProducer) SendTaskEvent(event *commonModels.TaskDomainEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockTaskEventProducer) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func stringValue(value string) *string {
+	return &value
+}
+
+func TestTaskEventPublisher_Publish_DomainEvents(t *testing.T) {
+	producer := new(MockTaskEventProducer)
+	publisher := services.NewTaskEventPublisherWithProducer(producer)
+	actorID := uuid.New()
+	oldExecutor, newExecutor := uuid.New(), uuid.New()
+	occurredAt := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	task := &models.Task{ID: 7, Title: "Release", ChatID: uuid.New()}
+
+	events := []models.TaskEvent{
+		{ID: 1, TaskID: 7, ActorID: actorID, EventType: models.TaskEventCreated, NewValue: stringValue("Release"), CreatedAt: occurredAt},
+		{ID: 2, TaskID: 7, ActorID: actorID, EventType: models.TaskEventStatusChanged, OldValue: stringValue("created"), NewValue: stringValue("in_progress"), CreatedAt: occurredAt},
+		{ID: 3, TaskID: 7, ActorID: actorID, EventType: models.TaskEventReassigned, OldValue: stringValue(oldExecutor.String()), NewValue: stringValue(newExecutor.String()), CreatedAt: occurredAt},
+		{ID: 4, TaskID: 7, ActorID: actorID, EventType: models.TaskEventEdited, Field: stringValue("title"), CreatedAt: occurredAt},
+	}
+
+	producer.On("SendTaskEvent", &commonModels.TaskDomainEvent{
+		EventID: "task:7:created", Type: commonModels.TaskDomainEventCreated, TaskID: 7, TaskTitle: "Release",
+		ChatID: task.ChatID, ActorID: actorID, OccurredAt: occurredAt,
+	}).Return(nil).Once()
+	producer.On("SendTaskEvent", &commonModels.TaskDomainEvent{
+		EventID: "task_event:2", Type: commonModels.TaskDomainEventStatusChanged, TaskID: 7, TaskTitle: "Release",
+		ChatID: task.ChatID, ActorID: actorID, OldValue: "created", NewValue: "in_progress", OccurredAt: occurredAt,
+	}).Return(nil).Once()
+	producer.On("SendTaskEvent", &commonModels.TaskDomainEvent{
+		EventID: "task_event:3", Type: commonModels.TaskDomainEventReassigned, TaskID: 7, TaskTitle: "Release",
+		ChatID: task.ChatID, ActorID: actorID, OldValue: oldExecutor.String(), NewValue: newExecutor.String(), OccurredAt: occurredAt,
+	}).Return(nil).Once()
+
+	publisher.Publish(task, events)
+
+	producer.AssertExpectations(t)
+	producer.AssertNumberOfCalls(t, "SendTaskEvent", 3)
+}
+
+func TestTaskEventPublisher_Publish_SkipsTaskWithoutChat(t *testing.T) {
+	producer := new(MockTaskEventProducer)
+	publisher := services.NewTaskEventPublisherWithProducer(producer)
+
+	publisher.Publish(&models.Task{ID: 7, Title: "Release"}, []models.TaskEvent{
+		{ID: 1, TaskID: 7, EventType: models.TaskEventCreated},
+	})
+
+	producer.AssertNotCalled(t, "SendTaskEvent", mock.Anything)
+}
+
+func TestTaskEventPublisher_Publish_UnrecordedEventGetsUniqueID(t *testing.T) {
+	producer := new(MockTaskEventProducer)
+	publisher := services.NewTaskEventPublisherWithProducer(producer)
+	var sent []*commonModels.TaskDomainEvent
+	producer.On("SendTaskEvent", mock.Anything).Run(func(args mock.Arguments) {
+		sent = append(sent, args.Get(0).(*commonModels.TaskDomainEvent))
+	}).Return(errors.New("broker unavailable"))
+
+	// Событие не записано в историю: ID нет, но ошибка отправки не прерывает публикацию
+	event := models.TaskEvent{TaskID: 7, EventType: models.TaskEventStatusChanged}
+	publisher.Publish(&models.Task{ID: 7, ChatID: uuid.New()}, []models.TaskEvent{event, event})
+
+	if assert.Len(t, sent, 2) {
+		assert.NotEqual(t, sent[0].EventID, sent[1].EventID)
+		assert.False(t, sent[0].OccurredAt.IsZero())
+	}
+}
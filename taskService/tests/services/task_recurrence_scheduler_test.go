@@ -0,0 +1,317 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	cuc "common/contracts/user-contracts"
+	commonModels "common/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/config"
+	"taskService/internal/models"
+	"taskService/internal/repositories"
+	"taskService/internal/services"
+)
+
+// MockTaskRecurrenceRepository - мок TaskRecurrenceRepository с методами, которые использует планировщик
+type MockTaskRecurrenceRepository struct {
+	repositories.TaskRecurrenceRepository
+	mock.Mock
+}
+
+func (m *MockTaskRecurrenceRepository) GetDue(until time.Time, limit int) ([]models.TaskRecurrence, error) {
+	args := m.Called(until, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TaskRecurrence), args.Error(1)
+}
+
+func (m *MockTaskRecurrenceRepository) CreateOccurrences(recurrence *models.TaskRecurrence, next *time.Time, occurrences []models.Task) (bool, error) {
+	args := m.Called(recurrence, next, occurrences)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockStatusRepository - мок TaskStatusRepository только со стартовым статусом
+type MockStatusRepository struct {
+	repositories.TaskStatusRepository
+	mock.Mock
+}
+
+func (m *MockStatusRepository) GetByName(name string) (*models.TaskStatus, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskStatus), args.Error(1)
+}
+
+// MockEventRepository - мок TaskEventRepository только с записью событий
+type MockEventRepository struct {
+	repositories.TaskEventRepository
+	mock.Mock
+}
+
+func (m *MockEventRepository) Create(events []models.TaskEvent) error {
+	args := m.Called(events)
+	return args.Error(0)
+}
+
+type recurrenceSchedulerMocks struct {
+	recurrenceRepo *MockTaskRecurrenceRepository
+	statusRepo     *MockStatusRepository
+	eventRepo      *MockEventRepository
+	userClient     *MockUserClient
+	producer       *MockNotificationProducer
+	eventProducer  *MockTaskEventProducer
+}
+
+func newTestRecurrenceScheduler() (*services.TaskRecurrenceScheduler, *recurrenceSchedulerMocks) {
+	m := &recurrenceSchedulerMocks{
+		recurrenceRepo: new(MockTaskRecurrenceRepository),
+		statusRepo:     new(MockStatusRepository),
+		eventRepo:      new(MockEventRepository),
+		userClient:     new(MockUserClient),
+		producer:       new(MockNotificationProducer),
+		eventProducer:  new(MockTaskEventProducer),
+	}
+	m.statusRepo.On("GetByName", "created").Return(&models.TaskStatus{ID: 1, Name: "created"}, nil).Maybe()
+	scheduler := services.NewTaskRecurrenceScheduler(
+		m.recurrenceRepo,
+		m.statusRepo,
+		nil,
+		m.eventRepo,
+		m.userClient,
+		services.NewNotificationServiceWithProducer(m.producer),
+		services.NewTaskEventPublisherWithProducer(m.eventProducer),
+		config.RecurrenceSchedulerConfig{Interval: time.Minute, Horizon: 7 * 24 * time.Hour, BatchSize: 10},
+	)
+	return scheduler, m
+}
+
+func weeklyRecurrence(rule string, startsAt time.Time) models.TaskRecurrence {
+	dueAfter := 60
+	return models.TaskRecurrence{
+		ID:               3,
+		Title:            "Standup notes",
+		CreatorID:        uuid.New(),
+		ExecutorID:       uuid.New(),
+		Priority:         models.TaskPriorityNormal,
+		Rule:             rule,
+		StartsAt:         startsAt,
+		DueAfterMinutes:  &dueAfter,
+		NextOccurrenceAt: &startsAt,
+	}
+}
+
+// assignIDs имитирует создание экземпляров в БД
+func assignIDs(args mock.Arguments) {
+	occurrences := args.Get(2).([]models.Task)
+	for i := range occurrences {
+		occurrences[i].ID = 100 + i
+	}
+}
+
+func TestTaskRecurrenceScheduler_RunOnce_CreatesOccurrencesAndNotifies(t *testing.T) {
+	scheduler, m := newTestRecurrenceScheduler()
+
+	// Понедельник 4 мая 2026, 09:00; горизонт - до понедельника 11 мая, 08:00
+	startsAt := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	now := startsAt.Add(-time.Hour)
+	recurrence := weeklyRecurrence("FREQ=WEEKLY;BYDAY=MO,TH", startsAt)
+	nextMonday := time.Date(2026, 5, 11, 9, 0, 0, 0, time.UTC)
+
+	m.recurrenceRepo.On("GetDue", now.Add(7*24*time.Hour), 10).Return([]models.TaskRecurrence{recurrence}, nil)
+	m.recurrenceRepo.On("CreateOccurrences", mock.Anything, &nextMonday, mock.MatchedBy(func(tasks []models.Task) bool {
+		return len(tasks) == 2 &&
+			tasks[0].OccurrenceAt.Equal(startsAt) &&
+			tasks[1].OccurrenceAt.Equal(time.Date(2026, 5, 7, 9, 0, 0, 0, time.UTC)) &&
+			tasks[1].DueAt.Equal(time.Date(2026, 5, 7, 10, 0, 0, 0, time.UTC)) &&
+			*tasks[1].RecurrenceID == 3 && tasks[1].StatusID == 1 && tasks[1].Title == "Standup notes"
+	})).Run(assignIDs).Return(true, nil)
+	m.eventRepo.On("Create", mock.MatchedBy(func(events []models.TaskEvent) bool {
+		return len(events) == 2 && events[0].TaskID == 100 && events[1].TaskID == 101 &&
+			events[0].EventType == models.TaskEventCreated && events[0].ActorID == recurrence.CreatorID
+	})).Return(nil)
+	m.userClient.On("GetUserByID", recurrence.ExecutorID).Return(&cuc.Response{User: &cuc.User{Email: "executor@example.com"}}, nil)
+	m.userClient.On("GetUserByID", recurrence.CreatorID).Return(&cuc.Response{User: &cuc.User{Username: "lead"}}, nil)
+	m.producer.On("SendNotification", mock.MatchedBy(func(n *commonModels.NewTaskNotification) bool {
+		return n.Type == commonModels.NotificationNewTask && n.Email == "executor@example.com" &&
+			n.CreatorName == "lead" && n.ExecutorID == recurrence.ExecutorID
+	})).Return(nil).Twice()
+
+	require.NoError(t, scheduler.RunOnce(now))
+
+	m.recurrenceRepo.AssertExpectations(t)
+	m.eventRepo.AssertExpectations(t)
+	m.producer.AssertExpectations(t)
+}
+
+func TestTaskRecurrenceScheduler_RunOnce_PublishesCreatedEventsToChat(t *testing.T) {
+	scheduler, m := newTestRecurrenceScheduler()
+
+	startsAt := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	recurrence := weeklyRecurrence("FREQ=DAILY;COUNT=2", startsAt)
+	recurrence.ChatID = uuid.New()
+
+	m.recurrenceRepo.On("GetDue", mock.Anything, 10).Return([]models.TaskRecurrence{recurrence}, nil)
+	m.recurrenceRepo.On("CreateOccurrences", mock.Anything, mock.Anything, mock.Anything).Run(assignIDs).Return(true, nil)
+	m.eventRepo.On("Create", mock.Anything).Return(nil)
+	m.userClient.On("GetUserByID", mock.Anything).Return(&cuc.Response{User: &cuc.User{Email: "executor@example.com"}}, nil)
+	m.producer.On("SendNotification", mock.Anything).Return(nil)
+	m.eventProducer.On("SendTaskEvent", mock.MatchedBy(func(event *commonModels.TaskDomainEvent) bool {
+		return event.Type == commonModels.TaskDomainEventCreated && event.ChatID == recurrence.ChatID &&
+			event.TaskTitle == "Standup notes" && event.EventID == commonModels.TaskCreatedEventID(event.TaskID)
+	})).Return(nil).Twice()
+
+	require.NoError(t, scheduler.RunOnce(startsAt))
+
+	m.eventProducer.AssertExpectations(t)
+	m.eventProducer.AssertCalled(t, "SendTaskEvent", mock.MatchedBy(func(event *commonModels.TaskDomainEvent) bool {
+		return event.TaskID == 101
+	}))
+}
+
+func TestTaskRecurrenceScheduler_RunOnce_SkipsClaimedByOtherReplica(t *testing.T) {
+	scheduler, m := newTestRecurrenceScheduler()
+
+	startsAt := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	recurrence := weeklyRecurrence("FREQ=DAILY", startsAt)
+
+	m.recurrenceRepo.On("GetDue", mock.Anything, 10).Return([]models.TaskRecurrence{recurrence}, nil)
+	m.recurrenceRepo.On("CreateOccurrences", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+
+	require.NoError(t, scheduler.RunOnce(startsAt))
+
+	m.eventRepo.AssertNotCalled(t, "Create", mock.Anything)
+	m.userClient.AssertNotCalled(t, "GetUserByID", mock.Anything)
+	m.producer.AssertNotCalled(t, "SendNotification", mock.Anything)
+}
+
+func TestTaskRecurrenceScheduler_RunOnce_FinishesSeries(t *testing.T) {
+	scheduler, m := newTestRecurrenceScheduler()
+
+	startsAt := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	recurrence := weeklyRecurrence("FREQ=DAILY;COUNT=2", startsAt)
+
+	m.recurrenceRepo.On("GetDue", mock.Anything, 10).Return([]models.TaskRecurrence{recurrence}, nil)
+	m.recurrenceRepo.On("CreateOccurrences", mock.Anything, (*time.Time)(nil), mock.MatchedBy(func(tasks []models.Task) bool {
+		return len(tasks) == 2
+	})).Return(true, nil)
+
+	require.NoError(t, scheduler.RunOnce(startsAt))
+
+	m.recurrenceRepo.AssertExpectations(t)
+	// Экземпляры уже были у серии и не создавались заново: ни истории, ни уведомлений
+	m.eventRepo.AssertNotCalled(t, "Create", mock.Anything)
+	m.producer.AssertNotCalled(t, "SendNotification", mock.Anything)
+}
+
+func TestTaskRecurrenceScheduler_RunOnce_RepositoryError(t *testing.T) {
+	scheduler, m := newTestRecurrenceScheduler()
+
+	m.recurrenceRepo.On("GetDue", mock.Anything, 10).Return(nil, errors.New("db down"))
+
+	err := scheduler.RunOnce(time.Now())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "db down")
+}
+
+// Тесты для models.RecurrenceRule
+
+func TestRecurrenceRule_Between(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		startsAt time.Time
+		from     time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "daily with interval",
+			rule:     "FREQ=DAILY;INTERVAL=2",
+			startsAt: date(1, 30),
+			from:     date(1, 30),
+			expected: []time.Time{date(1, 30), date(2, 1), date(2, 3), date(2, 5)},
+		},
+		{
+			name:     "every other week on weekdays",
+			rule:     "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=FR,TU",
+			startsAt: date(5, 6), // среда: вторник этой недели раньше начала серии
+			from:     date(5, 6),
+			expected: []time.Time{date(5, 8), date(5, 19), date(5, 22), date(6, 2)},
+		},
+		{
+			name:     "monthly skips short months",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=31",
+			startsAt: date(1, 10),
+			from:     date(1, 10),
+			expected: []time.Time{date(1, 31), date(3, 31), date(5, 31), date(7, 31)},
+		},
+		{
+			name:     "count is counted from series start",
+			rule:     "FREQ=DAILY;COUNT=3",
+			startsAt: date(3, 1),
+			from:     date(3, 2),
+			expected: []time.Time{date(3, 2), date(3, 3)},
+		},
+		{
+			name:     "until date includes whole day",
+			rule:     "FREQ=WEEKLY;UNTIL=20260315",
+			startsAt: date(3, 1),
+			from:     date(3, 1),
+			expected: []time.Time{date(3, 1), date(3, 8), date(3, 15)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := models.ParseRecurrenceRule(tt.rule)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, rule.Between(tt.startsAt, tt.from, time.Time{}, 4))
+		})
+	}
+}
+
+func TestRecurrenceRule_Next_NoMoreOccurrences(t *testing.T) {
+	rule, err := models.ParseRecurrenceRule("FREQ=DAILY;UNTIL=20260301T000000Z")
+	require.NoError(t, err)
+
+	_, ok := rule.Next(time.Date(2026, 2, 27, 9, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 10, 0, 0, 0, time.UTC))
+
+	assert.False(t, ok)
+}
+
+func TestParseRecurrenceRule_Invalid(t *testing.T) {
+	rules := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;COUNT=2;UNTIL=20260101",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;UNTIL=tomorrow",
+	}
+
+	for _, rule := range rules {
+		t.Run(rule, func(t *testing.T) {
+			_, err := models.ParseRecurrenceRule(rule)
+			assert.Error(t, err)
+		})
+	}
+}
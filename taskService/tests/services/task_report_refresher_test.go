@@ -0,0 +1,47 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"taskService/internal/config"
+	"taskService/internal/repositories"
+	"taskService/internal/services"
+)
+
+// MockReportRepository - мок TaskReportRepository только с методом, который использует пересчёт
+type MockReportRepository struct {
+	repositories.TaskReportRepository
+	mock.Mock
+}
+
+func (m *MockReportRepository) RefreshStatusIntervals() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func TestTaskReportRefresher_RunOnce(t *testing.T) {
+	reportRepo := new(MockReportRepository)
+	refresher := services.NewTaskReportRefresher(reportRepo, config.ReportRefresherConfig{Interval: time.Minute})
+
+	reportRepo.On("RefreshStatusIntervals").Return(nil).Once()
+
+	require.NoError(t, refresher.RunOnce())
+	reportRepo.AssertExpectations(t)
+}
+
+func TestTaskReportRefresher_RunOnce_Error(t *testing.T) {
+	reportRepo := new(MockReportRepository)
+	refresher := services.NewTaskReportRefresher(reportRepo, config.ReportRefresherConfig{Interval: time.Minute})
+	dbErr := errors.New("lock timeout")
+
+	reportRepo.On("RefreshStatusIntervals").Return(dbErr)
+
+	err := refresher.RunOnce()
+
+	assert.ErrorIs(t, err, dbErr)
+}
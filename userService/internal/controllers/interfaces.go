@@ -14,6 +14,9 @@ type UserControllerInterface interface {
 	UpdateUserProfile(req *au.UpdateUserRequest, userId *uuid.UUID) error
 	GetUserBrief(userID uuid.UUID, chatID string, requesterID string) (*dto.UserBriefResponse, error)
 	SearchUsers(query string, limit int) (*dto.UserSearchResponse, error)
+	GetUsersByIDs(ids []uuid.UUID) ([]*models.User, error)
+	GetUsersByUsernames(usernames []string) ([]*models.User, error)
+	SearchUsersByIDs(ids []uuid.UUID, query string) ([]*models.User, error)
 	UpdateUserRole(userID uuid.UUID, roleID int) error
 }
 
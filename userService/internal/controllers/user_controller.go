@@ -187,6 +187,44 @@ func (c *UserController) SearchUsers(query string, limit int) (*dto.UserSearchRe
 	return &dto.UserSearchResponse{Users: results}, nil
 }
 
+// GetUsersByIDs возвращает пользователей по списку ID (дубликаты ID игнорируются)
+func (c *UserController) GetUsersByIDs(ids []uuid.UUID) ([]*models.User, error) {
+	return c.userRepo.GetUsersByIDs(uniqueUserIDs(ids))
+}
+
+// SearchUsersByIDs возвращает пользователей из списка ID, чьё имя содержит query без учёта регистра
+func (c *UserController) SearchUsersByIDs(ids []uuid.UUID, query string) ([]*models.User, error) {
+	return c.userRepo.SearchUsersByIDs(uniqueUserIDs(ids), query)
+}
+
+func uniqueUserIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	uniqueIDs := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		uniqueIDs = append(uniqueIDs, id)
+	}
+	return uniqueIDs
+}
+
+// GetUsersByUsernames возвращает пользователей по точным именам (дубликаты имён игнорируются)
+func (c *UserController) GetUsersByUsernames(usernames []string) ([]*models.User, error) {
+	seen := make(map[string]struct{}, len(usernames))
+	unique := make([]string, 0, len(usernames))
+	for _, username := range usernames {
+		if _, ok := seen[username]; ok {
+			continue
+		}
+		seen[username] = struct{}{}
+		unique = append(unique, username)
+	}
+
+	return c.userRepo.GetUsersByUsernames(unique)
+}
+
 // UpdateUserRole обновляет роль пользователя
 func (c *UserController) UpdateUserRole(userID uuid.UUID, roleID int) error {
 	user, err := c.userRepo.GetUserByID(userID)
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/google/uuid"
+
+// GetUsersByIDsRequest - запрос на пакетное получение пользователей по ID
+type GetUsersByIDsRequest struct {
+	IDs []uuid.UUID `json:"ids" binding:"required,min=1,max=100"`
+	// UsernameQuery - если задан, из переданных ID возвращаются только пользователи с подстрокой в имени
+	UsernameQuery string `json:"username_query,omitempty"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+// GetUsersByUsernamesRequest - запрос на пакетное получение пользователей по точным именам
+type GetUsersByUsernamesRequest struct {
+	Usernames []string `json:"usernames" binding:"required,min=1,max=100,dive,required"`
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"userService/internal/controllers"
 	"userService/internal/custom_errors"
 	dto "userService/internal/handlers/dto" // для Swagger документации
+	"userService/internal/models"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -208,6 +210,66 @@ func (h *UserHandler) SearchUsers(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// GetUsersByIDs Пакетное получение пользователей
+// @Summary Получить пользователей по списку ID
+// @Description Возвращает пользователей с указанными ID одним запросом. Несуществующие ID пропускаются. Если задан username_query, возвращаются только пользователи, в имени которых есть эта подстрока
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.GetUsersByIDsRequest true "Список UUID пользователей (до 100)"
+// @Success 200 {object} map[string]interface{} "Найденные пользователи"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /users/batch [post]
+func (h *UserHandler) GetUsersByIDs(c *gin.Context) {
+	var req dto.GetUsersByIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var users []*models.User
+	var err error
+	if query := strings.TrimSpace(req.UsernameQuery); query != "" {
+		users, err = h.userController.SearchUsersByIDs(req.IDs, query)
+	} else {
+		users, err = h.userController.GetUsersByIDs(req.IDs)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": users})
+}
+
+// GetUsersByUsernames Пакетное получение пользователей по именам
+// @Summary Получить пользователей по списку имён
+// @Description Возвращает пользователей с точно совпадающими именами одним запросом (используется для разбора @упоминаний). Неизвестные имена пропускаются
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.GetUsersByUsernamesRequest true "Список имён пользователей (до 100)"
+// @Success 200 {object} map[string]interface{} "Найденные пользователи"
+// @Failure 400 {object} map[string]interface{} "Некорректный запрос"
+// @Failure 500 {object} map[string]interface{} "Внутренняя ошибка сервера"
+// @Router /users/by-usernames [post]
+func (h *UserHandler) GetUsersByUsernames(c *gin.Context) {
+	var req dto.GetUsersByUsernamesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	users, err := h.userController.GetUsersByUsernames(req.Usernames)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": users})
+}
+
 // UpdateUserRole Изменение роли пользователя
 // @Summary Изменить роль пользователя
 // @Description Изменяет роль указанного пользователя
@@ -13,6 +13,9 @@ type UserRepositoryInterface interface {
 	GetUserByID(id uuid.UUID) (*models.User, error)
 	UpdateUser(user *models.User) error
 	SearchUsers(query string, limit int) ([]*models.User, error)
+	GetUsersByIDs(ids []uuid.UUID) ([]*models.User, error)
+	GetUsersByUsernames(usernames []string) ([]*models.User, error)
+	SearchUsersByIDs(ids []uuid.UUID, query string) ([]*models.User, error)
 }
 
 // RoleRepositoryInterface - интерфейс для RoleRepository для возможности мокирования
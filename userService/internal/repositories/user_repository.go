@@ -51,3 +51,36 @@ func (r *UserRepository) SearchUsers(query string, limit int) ([]*models.User, e
 		Find(&users).Error
 	return users, err
 }
+
+// GetUsersByIDs возвращает пользователей с указанными ID одним запросом.
+// Несуществующие ID просто отсутствуют в результате
+func (r *UserRepository) GetUsersByIDs(ids []uuid.UUID) ([]*models.User, error) {
+	var users []*models.User
+	err := r.db.
+		Preload("Role").
+		Where("id IN ?", ids).
+		Find(&users).Error
+	return users, err
+}
+
+// SearchUsersByIDs возвращает пользователей из ids, в имени которых есть подстрока query.
+// Email не учитывается, чтобы поиск нельзя было использовать для перебора адресов
+func (r *UserRepository) SearchUsersByIDs(ids []uuid.UUID, query string) ([]*models.User, error) {
+	var users []*models.User
+	err := r.db.
+		Preload("Role").
+		Where("id IN ? AND username ILIKE ?", ids, "%"+query+"%").
+		Find(&users).Error
+	return users, err
+}
+
+// GetUsersByUsernames возвращает пользователей с точно совпадающими именами.
+// Неизвестные имена просто отсутствуют в результате
+func (r *UserRepository) GetUsersByUsernames(usernames []string) ([]*models.User, error) {
+	var users []*models.User
+	err := r.db.
+		Preload("Role").
+		Where("username IN ?", usernames).
+		Find(&users).Error
+	return users, err
+}
@@ -18,6 +18,8 @@ func RegisterRoutes(router *gin.Engine, authHandler *handlers.AuthHandler, userH
 		{
 			// Поиск должен быть перед /:user_id чтобы избежать конфликта
 			users.GET("/search", userHandler.SearchUsers)
+			users.POST("/batch", userHandler.GetUsersByIDs)
+			users.POST("/by-usernames", userHandler.GetUsersByUsernames)
 			users.GET("/:user_id", userHandler.GetProfile)
 			users.PUT("/:user_id", userHandler.UpdateProfile)
 			users.PATCH("/:user_id/role", userHandler.UpdateUserRole)
@@ -0,0 +1,5 @@
+-- Rollback: Remove manage_all_tasks permission
+-- ВНИМАНИЕ: Удаление permission также удалит все связи в role_permissions (ON DELETE CASCADE)
+
+DELETE FROM user_service.permissions
+WHERE name = 'manage_all_tasks';
@@ -0,0 +1,10 @@
+-- Migration: Add permission for managing tasks of other users
+
+INSERT INTO user_service.permissions (name, description) VALUES
+    ('manage_all_tasks', 'Редактирование, переназначение и удаление любых задач (только для админов)')
+ON CONFLICT (name) DO NOTHING;
+
+-- Примечание: permission нужно назначить ролям администраторов, например:
+-- INSERT INTO user_service.role_permissions (role_id, permission_id)
+-- SELECT 2, id FROM user_service.permissions WHERE name = 'manage_all_tasks'
+-- ON CONFLICT DO NOTHING;
@@ -0,0 +1,10 @@
+-- Migration: Add permission for managing task labels
+
+INSERT INTO user_service.permissions (name, description) VALUES
+    ('manage_task_labels', 'Создание, изменение и удаление меток задач (только для админов)')
+ON CONFLICT (name) DO NOTHING;
+
+-- Примечание: permission нужно назначить ролям администраторов, например:
+-- INSERT INTO user_service.role_permissions (role_id, permission_id)
+-- SELECT 2, id FROM user_service.permissions WHERE name = 'manage_task_labels'
+-- ON CONFLICT DO NOTHING;
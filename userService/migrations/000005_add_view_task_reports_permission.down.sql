@@ -0,0 +1,5 @@
+-- Rollback: Remove view_task_reports permission
+-- ВНИМАНИЕ: Удаление permission также удалит все связи в role_permissions (ON DELETE CASCADE)
+
+DELETE FROM user_service.permissions
+WHERE name = 'view_task_reports';
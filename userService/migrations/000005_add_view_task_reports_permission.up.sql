@@ -0,0 +1,10 @@
+-- Migration: Add permission for viewing task reports
+
+INSERT INTO user_service.permissions (name, description) VALUES
+    ('view_task_reports', 'Просмотр и выгрузка отчётов по задачам (для руководителей команд)')
+ON CONFLICT (name) DO NOTHING;
+
+-- Примечание: permission нужно назначить ролям руководителей и администраторов, например:
+-- INSERT INTO user_service.role_permissions (role_id, permission_id)
+-- SELECT 2, id FROM user_service.permissions WHERE name = 'view_task_reports'
+-- ON CONFLICT DO NOTHING;
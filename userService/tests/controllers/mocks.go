@@ -55,6 +55,30 @@ func (m *MockUserRepository) SearchUsers(query string, limit int) ([]*models.Use
 	return args.Get(0).([]*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetUsersByIDs(ids []uuid.UUID) ([]*models.User, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUsersByUsernames(usernames []string) ([]*models.User, error) {
+	args := m.Called(usernames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) SearchUsersByIDs(ids []uuid.UUID, query string) ([]*models.User, error) {
+	args := m.Called(ids, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
 // MockRoleRepository - мок для RoleRepository
 type MockRoleRepository struct {
 	mock.Mock
@@ -790,3 +790,48 @@ func TestUserController_UpdateUserRole_RoleNotFound(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 	mockRoleRepo.AssertExpectations(t)
 }
+
+// Тесты для GetUsersByIDs
+
+func TestUserController_GetUsersByIDs_DeduplicatesIDs(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockRoleRepo := new(MockRoleRepository)
+
+	user1 := createTestUser()
+	user2 := createTestUser()
+	ids := []uuid.UUID{user1.ID, user2.ID, user1.ID}
+
+	mockUserRepo.On("GetUsersByIDs", []uuid.UUID{user1.ID, user2.ID}).Return([]*models.User{user1, user2}, nil)
+
+	controller := controllers.NewUserControllerWithClients(mockUserRepo, mockRoleRepo, nil, nil)
+
+	// Act
+	result, err := controller.GetUsersByIDs(ids)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUserController_GetUsersByUsernames_DeduplicatesNames(t *testing.T) {
+	// Arrange
+	mockUserRepo := new(MockUserRepository)
+	mockRoleRepo := new(MockRoleRepository)
+
+	user := createTestUser()
+	mockUserRepo.On("GetUsersByUsernames", []string{"alice", "bob"}).Return([]*models.User{user}, nil)
+
+	controller := controllers.NewUserControllerWithClients(mockUserRepo, mockRoleRepo, nil, nil)
+
+	// Act
+	result, err := controller.GetUsersByUsernames([]string{"alice", "bob", "alice"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+
+	mockUserRepo.AssertExpectations(t)
+}
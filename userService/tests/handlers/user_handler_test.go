@@ -61,6 +61,30 @@ func (m *MockUserController) SearchUsers(query string, limit int) (*dto.UserSear
 	return args.Get(0).(*dto.UserSearchResponse), args.Error(1)
 }
 
+func (m *MockUserController) GetUsersByIDs(ids []uuid.UUID) ([]*models.User, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
+func (m *MockUserController) GetUsersByUsernames(usernames []string) ([]*models.User, error) {
+	args := m.Called(usernames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
+func (m *MockUserController) SearchUsersByIDs(ids []uuid.UUID, query string) ([]*models.User, error) {
+	args := m.Called(ids, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
 func (m *MockUserController) UpdateUserRole(userID uuid.UUID, roleID int) error {
 	args := m.Called(userID, roleID)
 	return args.Error(0)
@@ -892,3 +916,143 @@ func stringPtr(s string) *string {
 func intPtr(i int) *int {
 	return &i
 }
+
+// Тесты для GetUsersByIDs
+
+func TestUserHandler_GetUsersByIDs_Success(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockUserController)
+	handler := handlers.NewUserHandler(mockController)
+
+	user := createTestUserModel()
+	ids := []uuid.UUID{user.ID}
+
+	mockController.On("GetUsersByIDs", ids).Return([]*models.User{user}, nil)
+
+	router := gin.New()
+	router.POST("/users/batch", handler.GetUsersByIDs)
+
+	body, _ := json.Marshal(dto.GetUsersByIDsRequest{IDs: ids})
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/users/batch", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Users []*models.User `json:"users"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Users, 1)
+	assert.Equal(t, user.ID, response.Users[0].ID)
+
+	mockController.AssertExpectations(t)
+}
+
+func TestUserHandler_GetUsersByIDs_UsernameQuery(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockUserController)
+	handler := handlers.NewUserHandler(mockController)
+
+	user := createTestUserModel()
+	ids := []uuid.UUID{user.ID, uuid.New()}
+
+	mockController.On("SearchUsersByIDs", ids, "ali").Return([]*models.User{user}, nil)
+
+	router := gin.New()
+	router.POST("/users/batch", handler.GetUsersByIDs)
+
+	body, _ := json.Marshal(dto.GetUsersByIDsRequest{IDs: ids, UsernameQuery: " ali "})
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/users/batch", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockController.AssertExpectations(t)
+	mockController.AssertNotCalled(t, "GetUsersByIDs", mock.Anything)
+}
+
+func TestUserHandler_GetUsersByIDs_EmptyList(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockUserController)
+	handler := handlers.NewUserHandler(mockController)
+
+	router := gin.New()
+	router.POST("/users/batch", handler.GetUsersByIDs)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/users/batch", bytes.NewBufferString(`{"ids":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetUsersByIDs", mock.Anything)
+}
+
+func TestUserHandler_GetUsersByUsernames_Success(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockUserController)
+	handler := handlers.NewUserHandler(mockController)
+
+	user := createTestUserModel()
+	mockController.On("GetUsersByUsernames", []string{user.Username}).Return([]*models.User{user}, nil)
+
+	router := gin.New()
+	router.POST("/users/by-usernames", handler.GetUsersByUsernames)
+
+	body, _ := json.Marshal(dto.GetUsersByUsernamesRequest{Usernames: []string{user.Username}})
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/users/by-usernames", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Users []*models.User `json:"users"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Len(t, response.Users, 1)
+	assert.Equal(t, user.ID, response.Users[0].ID)
+
+	mockController.AssertExpectations(t)
+}
+
+func TestUserHandler_GetUsersByUsernames_EmptyName(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	mockController := new(MockUserController)
+	handler := handlers.NewUserHandler(mockController)
+
+	router := gin.New()
+	router.POST("/users/by-usernames", handler.GetUsersByUsernames)
+
+	// Act
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/users/by-usernames", bytes.NewBufferString(`{"usernames":[""]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockController.AssertNotCalled(t, "GetUsersByUsernames", mock.Anything)
+}